@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/spice/state"
@@ -27,13 +28,23 @@ type branchCmd struct {
 	Squash branchSquashCmd `cmd:"" aliases:"sq" help:"Squash a branch into one commit" released:"v0.11.0"`
 
 	// Mutation
-	Edit    branchEditCmd    `cmd:"" aliases:"e" help:"Edit the commits in a branch"`
-	Rename  branchRenameCmd  `cmd:"" aliases:"rn,mv" help:"Rename a branch"`
-	Restack branchRestackCmd `cmd:"" aliases:"r" help:"Restack a branch"`
-	Onto    branchOntoCmd    `cmd:"" aliases:"on" help:"Move a branch onto another branch"`
+	Edit     branchEditCmd     `cmd:"" aliases:"e" help:"Edit the commits in a branch"`
+	Rename   branchRenameCmd   `cmd:"" aliases:"rn,mv" help:"Rename a branch"`
+	Restack  branchRestackCmd  `cmd:"" aliases:"r" help:"Restack a branch"`
+	Onto     branchOntoCmd     `cmd:"" aliases:"on" help:"Move a branch onto another branch"`
+	Note     branchNoteCmd     `cmd:"" aliases:"n" released:"unreleased" help:"Manage notes attached to branches"`
+	Describe branchDescribeCmd `cmd:"" aliases:"desc" released:"unreleased" help:"Edit the description of a branch"`
+	Config   branchConfigCmd   `cmd:"" aliases:"cf" released:"unreleased" help:"Manage configuration overrides for branches and stacks"`
+
+	// Archival
+	Archive   branchArchiveCmd   `cmd:"" aliases:"ar" released:"unreleased" help:"Archive a branch to park it out of the active stack"`
+	Unarchive branchUnarchiveCmd `cmd:"" aliases:"unar" released:"unreleased" help:"Restore an archived branch"`
 
 	// Pull request management
-	Submit branchSubmitCmd `cmd:"" aliases:"s" help:"Submit a branch"`
+	Submit   branchSubmitCmd   `cmd:"" aliases:"s" help:"Submit a branch"`
+	Push     branchPushCmd     `cmd:"" aliases:"p" released:"unreleased" help:"Push a branch without submitting it"`
+	Refresh  branchRefreshCmd  `cmd:"" aliases:"rf" released:"unreleased" help:"Refresh the change request associated with a branch"`
+	Comments branchCommentsCmd `cmd:"" aliases:"cm" released:"unreleased" help:"Manage review comments on a branch's change request"`
 }
 
 // BranchPromptConfig defines configuration for the branch tree prompt
@@ -58,13 +69,15 @@ func (cfg *BranchPromptConfig) BeforeApply(kctx *kong.Context) error {
 		repo *git.Repository,
 		store *state.Store,
 		svc *spice.Service,
+		forges *forge.Registry,
 	) (*branchPrompter, error) {
 		return &branchPrompter{
-			sort:  cfg.BranchPromptSort,
-			view:  view,
-			repo:  repo,
-			store: store,
-			svc:   svc,
+			sort:   cfg.BranchPromptSort,
+			view:   view,
+			repo:   repo,
+			store:  store,
+			svc:    svc,
+			forges: forges,
 		}, nil
 	})
 }
@@ -78,10 +91,11 @@ type branchPrompter struct {
 	// Defaults to branch name if unset.
 	sort string
 
-	view  ui.View
-	repo  *git.Repository
-	store *state.Store
-	svc   *spice.Service
+	view   ui.View
+	repo   *git.Repository
+	store  *state.Store
+	svc    *spice.Service
+	forges *forge.Registry
 }
 
 // branchPromptRequest defines parameters for the branch prompt
@@ -161,7 +175,7 @@ func (p *branchPrompter) Prompt(ctx context.Context, req *branchPromptRequest) (
 		if graphItem, ok := branchGraph.Lookup(branch.Name); ok {
 			widgetItem.Base = graphItem.Base
 			if graphItem.Change != nil {
-				widgetItem.ChangeID = graphItem.Change.ChangeID().String()
+				widgetItem.ChangeID = p.forges.FormatChangeID(graphItem.Change)
 			}
 		}
 
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchArchiveCmd struct {
+	Branch string `arg:"" optional:"" help:"Name of the branch to archive. Defaults to current." predictor:"trackedBranches"`
+}
+
+func (*branchArchiveCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Parks a tracked branch out of the active stack
+		without deleting it or forgetting its state.
+		Use this for long-paused work
+		that shouldn't clutter or slow down daily operations.
+
+		Archived branches are hidden from '%[1]s log'
+		unless --archived is used,
+		and are skipped by restack and batch submit operations
+		unless they are explicitly targeted.
+
+		Branches above the archived branch must be archived first.
+		Use '%[1]s branch unarchive' to restore the branch.
+
+		Provide a branch name as an argument to target
+		a different branch.
+	`, cli.Name()))
+}
+
+func (cmd *branchArchiveCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	wt *git.Worktree,
+	svc *spice.Service,
+) error {
+	if cmd.Branch == "" {
+		var err error
+		cmd.Branch, err = wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+	}
+
+	if err := svc.ArchiveBranch(ctx, cmd.Branch); err != nil {
+		var activeErr *spice.ActiveUpstackError
+		switch {
+		case errors.Is(err, spice.ErrAlreadyArchived):
+			log.Infof("%v: already archived", cmd.Branch)
+			return nil
+
+		case errors.Is(err, state.ErrTrunk):
+			return errors.New("cannot archive trunk")
+
+		case errors.As(err, &activeErr):
+			log.Errorf("%v: archive or move these branches first:", cmd.Branch)
+			for _, branch := range activeErr.Upstack {
+				log.Errorf("  - %v", branch)
+			}
+		}
+
+		return fmt.Errorf("archive branch: %w", err)
+	}
+
+	log.Infof("%v: archived", cmd.Branch)
+	return nil
+}
@@ -76,7 +76,7 @@ type branchCheckoutCmd struct {
 	TrackUntrackedPromptOld *bool          `config:"branchCheckout.trackUntrackedPrompt" hidden:"" deprecated:""`
 
 	Untracked bool   `short:"u" config:"branchCheckout.showUntracked" help:"Show untracked branches if one isn't supplied"`
-	Branch    string `arg:"" optional:"" help:"Name of the branch to checkout" predictor:"branches"`
+	Branch    string `arg:"" optional:"" help:"Name of the branch to checkout, or a Change Request" predictor:"branches"`
 }
 
 func (*branchCheckoutCmd) Help() string {
@@ -84,6 +84,11 @@ func (*branchCheckoutCmd) Help() string {
 		A prompt will allow selecting between tracked branches.
 		Provide a branch name as an argument to skip the prompt.
 
+		The argument may also refer to a Change Request
+		as '#123', 'pr/123', or the URL of the Change Request.
+		If the CR's branch isn't tracked,
+		it's fetched from the remote before it's checked out.
+
 		Use -u/--untracked to show untracked branches in the prompt.
 		Use --detach to detach HEAD to the commit of the selected branch.
 		Use -n to print the selected branch name to stdout
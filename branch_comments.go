@@ -0,0 +1,5 @@
+package main
+
+type branchCommentsCmd struct {
+	Resolve branchCommentsResolveCmd `cmd:"" aliases:"r" released:"unreleased" help:"Resolve review threads on a branch's Change Request"`
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/comments"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchCommentsResolveCmd struct {
+	Branch   string `placeholder:"NAME" help:"Branch whose Change Request to resolve threads on. Defaults to current." predictor:"trackedBranches"`
+	All      bool   `help:"Resolve all unresolved threads"`
+	Outdated bool   `help:"Resolve unresolved threads on lines that have since changed"`
+}
+
+func (*branchCommentsResolveCmd) Help() string {
+	return text.Dedent(`
+		Resolves review threads on the Change Request
+		associated with the current branch.
+		Use this after pushing fixes to mark addressed feedback
+		as resolved in bulk.
+
+		Use --outdated to resolve threads on lines
+		that have changed since the thread was started.
+		Use --all to resolve all unresolved threads.
+		Otherwise, you will be prompted to pick threads to resolve.
+
+		Use --branch to resolve threads on a different branch.
+		Only GitHub and GitLab support review threads.
+	`)
+}
+
+// CommentsHandler acts on review comments on Change Requests.
+type CommentsHandler interface {
+	ResolveThreads(context.Context, *comments.ResolveRequest) error
+}
+
+var _ CommentsHandler = (*comments.Handler)(nil)
+
+func (cmd *branchCommentsResolveCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchCommentsResolveCmd) Run(ctx context.Context, handler CommentsHandler) error {
+	return handler.ResolveThreads(ctx, &comments.ResolveRequest{
+		Branch:   cmd.Branch,
+		All:      cmd.All,
+		Outdated: cmd.Outdated,
+	})
+}
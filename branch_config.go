@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/handler/submit"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+type branchConfigCmd struct {
+	List  branchConfigListCmd  `cmd:"" released:"unreleased" help:"List configuration overrides for a branch"`
+	Set   branchConfigSetCmd   `cmd:"" released:"unreleased" help:"Override configuration for a branch or stack"`
+	Unset branchConfigUnsetCmd `cmd:"" released:"unreleased" help:"Remove a configuration override"`
+}
+
+// findBranchConfigOption looks up a configuration option
+// that may be overridden per branch.
+func findBranchConfigOption(model *kong.Application, key string) (*spice.ConfigOption, error) {
+	opt, err := spice.NewConfigSchema(model).Find(key)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", key, err)
+	}
+
+	if !slices.Contains(submit.BranchConfigKeys(), opt.Key) {
+		return nil, fmt.Errorf("%v: cannot be overridden per branch", opt.Key)
+	}
+	return opt, nil
+}
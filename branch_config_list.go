@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchConfigListCmd struct {
+	Branch string `placeholder:"NAME" help:"Branch whose overrides to list. Defaults to current." predictor:"trackedBranches"`
+}
+
+func (*branchConfigListCmd) Help() string {
+	return text.Dedent(`
+		Prints the configuration overrides in effect for a branch
+		as key=value pairs.
+		Overrides inherited from a branch downstack
+		are followed by the name of that branch.
+
+		Use --branch to list the overrides of a different branch.
+	`)
+}
+
+func (cmd *branchConfigListCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchConfigListCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	svc *spice.Service,
+) error {
+	if _, err := svc.LookupBranch(ctx, cmd.Branch); err != nil {
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	cfg, err := svc.BranchConfig(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("load branch configuration: %w", err)
+	}
+
+	if len(cfg) == 0 {
+		log.Infof("%v: no configuration overrides", cmd.Branch)
+		return nil
+	}
+
+	for _, key := range cfg.Keys() {
+		v := cfg[key]
+		var suffix string
+		if v.Branch != cmd.Branch {
+			suffix = fmt.Sprintf(" (inherited from %v)", v.Branch)
+		}
+		for _, value := range v.Values {
+			if _, err := fmt.Fprintf(kctx.Stdout, "%v=%v%v\n", key, value, suffix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
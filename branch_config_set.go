@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchConfigSetCmd struct {
+	Branch string `placeholder:"NAME" help:"Branch to override the option for. Defaults to current." predictor:"trackedBranches"`
+	Stack  bool   `help:"Override the option for the whole stack instead of a single branch"`
+
+	Key    string   `arg:"" help:"Configuration key, e.g. spice.submit.reviewers" predictor:"configKeys"`
+	Values []string `arg:"" sep:"none" help:"New values of the option"`
+}
+
+func (*branchConfigSetCmd) Help() string {
+	return text.Dedent(`
+		Overrides the value of a git-spice configuration option
+		for a branch and the branches stacked on top of it.
+		Overrides take precedence over values set with 'gs config set'
+		or 'git config', and are replaced by overrides set further upstack.
+
+		Use --stack to set the override on the bottom-most branch
+		of the current stack, applying it to the whole stack.
+		Use --branch to target a different branch.
+
+		Options that accept multiple values, like spice.submit.reviewers,
+		may be given more than one value.
+		Only options that control how branches are submitted
+		may be overridden.
+	`)
+}
+
+func (cmd *branchConfigSetCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchConfigSetCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	svc *spice.Service,
+) error {
+	opt, err := findBranchConfigOption(kctx.Model, cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	if opt.Type != "list" && len(cmd.Values) != 1 {
+		return fmt.Errorf("%v: expected one value, got %d", opt.Key, len(cmd.Values))
+	}
+	for _, value := range cmd.Values {
+		if err := opt.Validate(value); err != nil {
+			return fmt.Errorf("%v: %w", opt.Key, err)
+		}
+	}
+
+	branch := cmd.Branch
+	if cmd.Stack {
+		branch, err = svc.FindBottom(ctx, cmd.Branch)
+		if err != nil {
+			return fmt.Errorf("find bottom of stack: %w", err)
+		}
+	}
+
+	if err := svc.SetBranchConfig(ctx, branch, opt.Key, cmd.Values); err != nil {
+		return err
+	}
+	log.Infof("%v: set %v", branch, opt.Key)
+	return nil
+}
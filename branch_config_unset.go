@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchConfigUnsetCmd struct {
+	Branch string `placeholder:"NAME" help:"Branch to remove the override from. Defaults to current." predictor:"trackedBranches"`
+	Stack  bool   `help:"Remove the override from the bottom of the stack instead"`
+
+	Key string `arg:"" help:"Configuration key, e.g. spice.submit.reviewers" predictor:"configKeys"`
+}
+
+func (*branchConfigUnsetCmd) Help() string {
+	return text.Dedent(`
+		Removes a configuration override set on a branch
+		with 'gs branch config set'.
+		Overrides inherited from branches downstack are not affected.
+
+		Use --stack to remove an override set with
+		'gs branch config set --stack'.
+	`)
+}
+
+func (cmd *branchConfigUnsetCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchConfigUnsetCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	svc *spice.Service,
+) error {
+	opt, err := findBranchConfigOption(kctx.Model, cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	branch := cmd.Branch
+	if cmd.Stack {
+		branch, err = svc.FindBottom(ctx, cmd.Branch)
+		if err != nil {
+			return fmt.Errorf("find bottom of stack: %w", err)
+		}
+	}
+
+	if err := svc.SetBranchConfig(ctx, branch, opt.Key, nil); err != nil {
+		return err
+	}
+	log.Infof("%v: unset %v", branch, opt.Key)
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/git"
@@ -17,6 +19,68 @@ import (
 type branchCreateConfig struct {
 	Prefix                   string `default:"" config:"branchCreate.prefix" help:"Always add a prefix to branch names." hidden:""`
 	GeneratedBranchNameLimit int    `default:"32" config:"branchCreate.generatedBranchNameLimit" help:"Maximum length of auto-generated branch names (truncated at word boundaries). Defaults to 32." hidden:""`
+	Template                 string `config:"branchCreate.template" help:"Template for auto-generated branch names, e.g. '{user}/{ticket}-{slug}'." hidden:"" released:"unreleased"`
+	Pattern                  string `config:"branchCreate.pattern" help:"Regular expression that names of new branches must match." hidden:"" released:"unreleased"`
+}
+
+// generateBranchName generates a name for a new branch
+// from the subject of its commit.
+// The configured prefix is not included.
+func (cfg *branchCreateConfig) generateBranchName(
+	ctx context.Context,
+	repo *git.Repository,
+	subject string,
+) (string, error) {
+	if cfg.Template == "" {
+		return spice.GenerateBranchName(subject, cfg.GeneratedBranchNameLimit), nil
+	}
+
+	ticket, rest := spice.FindTicket(subject)
+	data := spice.BranchNameData{
+		Ticket: ticket,
+		Slug:   spice.GenerateBranchName(rest, cfg.GeneratedBranchNameLimit),
+	}
+	if strings.Contains(cfg.Template, "{user}") {
+		user, err := branchNameUser(ctx, repo)
+		if err != nil {
+			return "", err
+		}
+		data.User = user
+	}
+
+	name, err := spice.ExpandBranchNameTemplate(cfg.Template, data)
+	if err != nil {
+		return "", fmt.Errorf("spice.branchCreate.template: %w", err)
+	}
+	return name, nil
+}
+
+// checkBranchName reports an error
+// if name does not match the configured pattern.
+func (cfg *branchCreateConfig) checkBranchName(name string) error {
+	if cfg.Pattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile("^(?:" + cfg.Pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("spice.branchCreate.pattern: %w", err)
+	}
+	if !re.MatchString(name) {
+		return fmt.Errorf("branch name %q does not match pattern %q", name, cfg.Pattern)
+	}
+	return nil
+}
+
+// branchNameUser returns the name of the current user
+// for use in generated branch names.
+// This is the local part of the author email address.
+func branchNameUser(ctx context.Context, repo *git.Repository) (string, error) {
+	ident, err := repo.Var(ctx, "GIT_AUTHOR_IDENT")
+	if err != nil {
+		return "", fmt.Errorf("get author: %w", err)
+	}
+	return spice.IdentUser(ident)
 }
 
 type branchCreateCmd struct {
@@ -53,6 +117,8 @@ func (*branchCreateCmd) Help() string {
 		branch names will be prefixed with its value.
 		If the 'spice.branchCreate.generatedBranchNameLimit' configuration option is set,
 		auto-generated branch names will be truncated to that length at word boundaries (defaults to 32).
+		Use 'spice.branchCreate.template' to change the format of generated names,
+		and 'spice.branchCreate.pattern' to reject names that don't match a pattern.
 
 		The new branch will use the current branch as its base.
 		Use --target to specify a different base branch.
@@ -129,6 +195,9 @@ func (cmd *branchCreateCmd) Run(
 		if repo.BranchExists(ctx, cmd.Name) {
 			return fmt.Errorf("branch already exists: %v", cmd.Name)
 		}
+		if err := cmd.checkBranchName(cmd.Prefix + cmd.Name); err != nil {
+			return err
+		}
 	}
 
 	baseName := cmd.Target
@@ -243,7 +312,10 @@ func (cmd *branchCreateCmd) Run(
 				return fmt.Errorf("get commit subject: %w", err)
 			}
 
-			msgName := spice.GenerateBranchName(subject, cmd.GeneratedBranchNameLimit)
+			msgName, err := cmd.generateBranchName(ctx, repo, subject)
+			if err != nil {
+				return err
+			}
 			current := cmd.Prefix + msgName
 
 			// If the auto-generated branch name already exists,
@@ -252,6 +324,10 @@ func (cmd *branchCreateCmd) Run(
 				current = fmt.Sprintf("%s%s-%d", cmd.Prefix, msgName, num)
 			}
 
+			if err := cmd.checkBranchName(current); err != nil {
+				return err
+			}
+
 			cmd.Name = current
 			generatedName = true
 			log.Debug("Branch name generated from commit",
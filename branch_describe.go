@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchDescribeCmd struct {
+	Branch  string `placeholder:"NAME" help:"Branch whose description to edit. Defaults to current." predictor:"branches"`
+	Message string `short:"m" placeholder:"MSG" help:"Use the given message as the description instead of opening an editor"`
+	Clear   bool   `help:"Remove the description from the branch"`
+}
+
+func (*branchDescribeCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Edits the description of the current branch.
+		This is the same description that
+		'git branch --edit-description' edits,
+		so it is available to other Git tools as well.
+
+		The first line of the description is used
+		as the default title of new Change Requests,
+		and the rest of it as the default body.
+		Set spice.submit.branchDescription to false
+		to use only commit messages for these instead.
+
+		An editor opens with the current description.
+		Lines starting with '#' are ignored,
+		and saving an empty description removes it.
+		Use -m to set the description without opening an editor,
+		or --clear to remove it.
+
+		Use --branch to edit the description of a different branch.
+		For example:
+
+			%[1]s branch describe -m 'Add login form'
+	`, cli.Name()))
+}
+
+func (cmd *branchDescribeCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	if cmd.Clear && cmd.Message != "" {
+		return errors.New("cannot use --clear with --message")
+	}
+	return nil
+}
+
+func (cmd *branchDescribeCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+) error {
+	if !repo.BranchExists(ctx, cmd.Branch) {
+		return fmt.Errorf("branch %v does not exist", cmd.Branch)
+	}
+
+	current, err := repo.BranchDescription(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("get description: %w", err)
+	}
+
+	var desc string
+	switch {
+	case cmd.Clear:
+		// desc stays empty.
+
+	case cmd.Message != "":
+		desc = cmd.Message
+
+	default:
+		desc, err = editCommentedText(
+			gitEditor(ctx, repo), "spice-description-*.txt",
+			current, fmt.Sprintf(_branchDescriptionFileFooter, cmd.Branch),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	desc = strings.TrimSpace(desc)
+	if desc == current {
+		log.Infof("%v: description unchanged", cmd.Branch)
+		return nil
+	}
+
+	if err := repo.SetBranchDescription(ctx, cmd.Branch, desc); err != nil {
+		return fmt.Errorf("set description: %w", err)
+	}
+
+	if desc == "" {
+		log.Infof("%v: removed description", cmd.Branch)
+	} else {
+		log.Infof("%v: updated description", cmd.Branch)
+	}
+	return nil
+}
+
+const _branchDescriptionFileFooter = `
+# Describe branch %q above.
+# The first line is the default title for its Change Request.
+# Lines starting with '#' will be ignored.
+# Save an empty description to remove it.
+`
@@ -47,6 +47,10 @@ func (cmd *branchFoldCmd) Run(
 		cmd.Branch = currentBranch
 	}
 
+	if err := svc.CheckProtected(cmd.Branch); err != nil {
+		return err
+	}
+
 	if err := svc.VerifyRestacked(ctx, cmd.Branch); err != nil {
 		var restackErr *spice.BranchNeedsRestackError
 		switch {
@@ -64,6 +68,11 @@ func (cmd *branchFoldCmd) Run(
 		return fmt.Errorf("get branch: %w", err)
 	}
 
+	// Folding moves the base branch as well.
+	if err := svc.CheckProtected(b.Base); err != nil {
+		return err
+	}
+
 	// Check if we're about to fold onto the trunk branch
 	if b.Base == store.Trunk() {
 		if !ui.Interactive(view) {
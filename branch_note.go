@@ -0,0 +1,6 @@
+package main
+
+type branchNoteCmd struct {
+	Edit branchNoteEditCmd `cmd:"" aliases:"e" released:"unreleased" help:"Edit the note attached to a branch"`
+	Show branchNoteShowCmd `cmd:"" aliases:"s" released:"unreleased" help:"Show the note attached to a branch"`
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+	"go.abhg.dev/gs/internal/xec"
+)
+
+type branchNoteEditCmd struct {
+	Branch  string `placeholder:"NAME" help:"Branch whose note to edit. Defaults to current." predictor:"trackedBranches"`
+	Message string `short:"m" placeholder:"MSG" help:"Use the given message as the note instead of opening an editor"`
+	Clear   bool   `help:"Remove the note from the branch"`
+}
+
+func (*branchNoteEditCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Attaches a free-form note to the current branch,
+		replacing any existing note.
+		Notes are private to the repository:
+		they are stored alongside other git-spice state,
+		and are shown in '%[1]s log long'.
+
+		An editor opens with the current note.
+		Lines starting with '#' are ignored,
+		and saving an empty note removes it.
+		Use -m to set the note without opening an editor,
+		or --clear to remove it.
+
+		Set spice.submit.includeNote to true
+		to append notes to the body of new Change Requests.
+
+		Use --branch to edit the note of a different branch.
+	`, cli.Name()))
+}
+
+func (cmd *branchNoteEditCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	if cmd.Clear && cmd.Message != "" {
+		return errors.New("cannot use --clear with --message")
+	}
+	return nil
+}
+
+func (cmd *branchNoteEditCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	svc *spice.Service,
+) error {
+	branch, err := svc.LookupBranch(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	var note string
+	switch {
+	case cmd.Clear:
+		// note stays empty.
+
+	case cmd.Message != "":
+		note = cmd.Message
+
+	default:
+		note, err = editBranchNote(gitEditor(ctx, repo), cmd.Branch, branch.Note)
+		if err != nil {
+			return err
+		}
+	}
+
+	note = strings.TrimSpace(note)
+	if note == strings.TrimSpace(branch.Note) {
+		log.Infof("%v: note unchanged", cmd.Branch)
+		return nil
+	}
+
+	if err := svc.SetBranchNote(ctx, cmd.Branch, note); err != nil {
+		return fmt.Errorf("set note: %w", err)
+	}
+
+	if note == "" {
+		log.Infof("%v: removed note", cmd.Branch)
+	} else {
+		log.Infof("%v: updated note", cmd.Branch)
+	}
+	return nil
+}
+
+const _branchNoteFileFooter = `
+# Write a note for branch %q above.
+# Lines starting with '#' will be ignored.
+# Save an empty note to remove it.
+`
+
+// editBranchNote opens an editor with the given note
+// and returns the edited note with comment lines removed.
+func editBranchNote(editor, branch, note string) (string, error) {
+	return editCommentedText(editor, "spice-note-*.md", note, fmt.Sprintf(_branchNoteFileFooter, branch))
+}
+
+// editCommentedText opens an editor with the given text
+// followed by the given footer of '#'-prefixed comment lines,
+// and returns the edited text with comment lines removed.
+//
+// pattern is the name pattern for the temporary file
+// as accepted by [os.CreateTemp].
+func editCommentedText(editor, pattern, text, footer string) (_ string, err error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if text != "" {
+		text = strings.TrimRight(text, "\n") + "\n"
+	}
+	_, err = io.WriteString(file, text+footer)
+	err = errors.Join(err, file.Close())
+	if err != nil {
+		return "", fmt.Errorf("write temporary file: %w", err)
+	}
+
+	if err := xec.EditCommand(editor, file.Name()).Run(); err != nil {
+		return "", fmt.Errorf("run editor: %w", err)
+	}
+
+	f, err := os.Open(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("open edited file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("read edited file: %w", err)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchNoteShowCmd struct {
+	Branch string `placeholder:"NAME" help:"Branch whose note to show. Defaults to current." predictor:"trackedBranches"`
+}
+
+func (*branchNoteShowCmd) Help() string {
+	return text.Dedent(`
+		Prints the note attached to the current branch to stdout.
+		Nothing is printed if the branch does not have a note.
+
+		Use --branch to show the note of a different branch.
+	`)
+}
+
+func (cmd *branchNoteShowCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchNoteShowCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	svc *spice.Service,
+) error {
+	branch, err := svc.LookupBranch(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	if branch.Note == "" {
+		log.Infof("%v: no note", cmd.Branch)
+		return nil
+	}
+
+	_, err = fmt.Fprintln(kctx.Stdout, strings.TrimRight(branch.Note, "\n"))
+	return err
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/submit"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchPushCmd struct {
+	Branch         string `placeholder:"NAME" help:"Branch to push. Defaults to current." predictor:"trackedBranches"`
+	Downstack      bool   `help:"Also push the branches below this one"`
+	ForceWithLease bool   `name:"force-with-lease" help:"Overwrite the remote branch if it's where it was last pushed"`
+	NoVerify       bool   `help:"Bypass pre-push hooks when pushing to the remote."`
+
+	UpstreamBranchTemplate string `config:"submit.upstreamBranchTemplate" hidden:"" help:"Template for the names of new branches in the remote, e.g. 'users/{user}/{branch}'."`
+	UserNamespace          bool   `config:"submit.userNamespace" hidden:"" help:"Push new branches under the forge username of the current user, e.g. 'alice/feature'."`
+}
+
+func (*branchPushCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Pushes the branch to its remote
+		without creating or updating a Change Request.
+
+		Submitted branches are pushed to the same remote and branch name
+		they were submitted to.
+		Other branches are pushed to the repository's remote
+		under the same name, or a unique name if that's taken.
+		Use the 'spice.submit.upstreamBranchTemplate' configuration option
+		to push them under a different name, e.g. 'users/{user}/{branch}'.
+
+		Only fast-forward pushes are allowed by default.
+		Use --force-with-lease to overwrite the remote branch,
+		e.g. after a restack,
+		as long as nobody else has pushed to it.
+
+		Use --downstack to also push the branches below it.
+		Use --branch to push a different branch.
+
+		Use '%[1]s branch submit --no-push' afterwards
+		to create or update the Change Request.
+	`, cli.Name()))
+}
+
+func (cmd *branchPushCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchPushCmd) Run(
+	ctx context.Context,
+	store *state.Store,
+	svc *spice.Service,
+	submitHandler SubmitHandler,
+) error {
+	if cmd.Branch == store.Trunk() {
+		return errors.New("cannot push trunk")
+	}
+
+	branches := []string{cmd.Branch}
+	if cmd.Downstack {
+		var err error
+		branches, err = svc.ListDownstack(ctx, cmd.Branch)
+		if err != nil {
+			return fmt.Errorf("list downstack: %w", err)
+		}
+		slices.Reverse(branches)
+	}
+
+	return submitHandler.Push(ctx, &submit.PushRequest{
+		Branches:       branches,
+		ForceWithLease: cmd.ForceWithLease,
+		NoVerify:       cmd.NoVerify,
+
+		UpstreamBranchTemplate: cmd.UpstreamBranchTemplate,
+		UserNamespace:          cmd.UserNamespace,
+	})
+}
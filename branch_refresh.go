@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/refresh"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchRefreshCmd struct {
+	Branch string `placeholder:"NAME" help:"Branch to refresh. Defaults to current." predictor:"trackedBranches"`
+}
+
+func (*branchRefreshCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Looks up open Change Requests for the branch's upstream branch
+		and updates the Change Request associated with the branch.
+
+		Use this if the Change Request associated with a branch
+		is out of date, e.g. because it was closed and re-created.
+		A branch that is not associated with a Change Request
+		is associated with an open Change Request for the same commit.
+
+		'%[1]s repo sync' refreshes all submitted branches automatically.
+
+		Use --branch to refresh a different branch.
+	`, cli.Name()))
+}
+
+// RefreshHandler refreshes the change metadata of branches.
+type RefreshHandler interface {
+	RefreshBranches(context.Context, *refresh.Request) (*refresh.Response, error)
+}
+
+var _ RefreshHandler = (*refresh.Handler)(nil)
+
+func (cmd *branchRefreshCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *branchRefreshCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	handler RefreshHandler,
+) error {
+	res, err := handler.RefreshBranches(ctx, &refresh.Request{
+		Branches: []string{cmd.Branch},
+	})
+	if err != nil {
+		return fmt.Errorf("refresh branch: %w", err)
+	}
+
+	if len(res.Failed) > 0 {
+		return fmt.Errorf("could not refresh %v", cmd.Branch)
+	}
+	if len(res.Updated) == 0 {
+		log.Infof("%v: already up-to-date", cmd.Branch)
+	}
+	return nil
+}
@@ -62,6 +62,11 @@ func (cmd *branchRenameCmd) Run(
 		}
 	}
 
+	// Check early so that we don't prompt for a name we can't use.
+	if err := svc.CheckProtected(oldName); err != nil {
+		return err
+	}
+
 	if newName == "" {
 		prompt := ui.NewInput().
 			WithValue(&newName).
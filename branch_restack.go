@@ -5,11 +5,14 @@ import (
 	"fmt"
 
 	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/text"
 )
 
 type branchRestackCmd struct {
-	Branch string `placeholder:"NAME" help:"Branch to restack" predictor:"trackedBranches"`
+	Branch       string `placeholder:"NAME" help:"Branch to restack" predictor:"trackedBranches"`
+	OntoUpstream string `name:"onto-upstream" placeholder:"REMOTE/BRANCH" released:"unreleased" help:"Base the branch on a remote branch, e.g. origin/feature, and restack onto it"`
 }
 
 func (*branchRestackCmd) Help() string {
@@ -17,20 +20,56 @@ func (*branchRestackCmd) Help() string {
 		The current branch will be rebased onto its base,
 		ensuring a linear history.
 		Use --branch to target a different branch.
+
+		Use --onto-upstream to base the branch on a branch
+		that only exists in the remote repository,
+		e.g. a coworker's branch that is still in review.
+		The branch is rebased onto the remote branch
+		whenever it's restacked.
+		'repo sync' fetches the remote branch,
+		and moves the branch onto trunk once the remote branch is merged.
+		Use 'branch onto' to base it on a local branch again.
 	`)
 }
 
-func (cmd *branchRestackCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+func (cmd *branchRestackCmd) AfterApply(
+	ctx context.Context,
+	wt *git.Worktree,
+	changes ChangeBranchResolver,
+) error {
 	if cmd.Branch == "" {
 		currentBranch, err := wt.CurrentBranch(ctx)
 		if err != nil {
 			return fmt.Errorf("get current branch: %w", err)
 		}
 		cmd.Branch = currentBranch
+	} else {
+		branch, err := changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
+		cmd.Branch = branch
 	}
 	return nil
 }
 
-func (cmd *branchRestackCmd) Run(ctx context.Context, handler RestackHandler) error {
+func (cmd *branchRestackCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	svc *spice.Service,
+	handler RestackHandler,
+) error {
+	if cmd.OntoUpstream != "" {
+		base, err := svc.ParseUpstreamBase(ctx, cmd.OntoUpstream)
+		if err != nil {
+			return err
+		}
+
+		if err := svc.SetUpstreamBase(ctx, cmd.Branch, base); err != nil {
+			return err
+		}
+		log.Infof("%v: based on %v", cmd.Branch, base)
+	}
+
 	return handler.RestackBranch(ctx, cmd.Branch)
 }
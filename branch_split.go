@@ -216,9 +216,9 @@ func (cmd *branchSplitCmd) Run(
 func (cmd *branchSplitCmd) commitDescription(c git.CommitDetail, head bool) string {
 	var desc strings.Builder
 	if head {
-		desc.WriteString("  ■ ")
+		desc.WriteString("  " + ui.Glyph("■", "*") + " ")
 	} else {
-		desc.WriteString("  □ ")
+		desc.WriteString("  " + ui.Glyph("□", "o") + " ")
 	}
 	(&commit.Summary{
 		ShortHash:  c.ShortHash,
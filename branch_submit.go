@@ -36,6 +36,9 @@ Without the flag, the draft status is not changed.
 Use --no-publish to push branches without creating CRs.
 This has no effect if a branch already has an open CR.
 
+Use --no-push if branches were already pushed by other means, e.g. CI.
+CRs are created and updated from the pushed commits.
+
 Use --update-only to only update branches with existing CRs,
 and skip those that would create new CRs.
 
@@ -73,6 +76,9 @@ func (*branchSubmitCmd) Help() string {
 		Use --no-publish to push branches without creating CRs.
 		This has no effect if a branch already has an open CR.
 
+		Use --no-push if branches were already pushed by other means, e.g. CI.
+		CRs are created and updated from the pushed commits.
+
 		Use --update-only to only update branches with existing CRs,
 		and skip those that would create new CRs.
 
@@ -86,12 +92,15 @@ func (*branchSubmitCmd) Help() string {
 type SubmitHandler interface {
 	Submit(ctx context.Context, req *submit.Request) error
 	SubmitBatch(ctx context.Context, req *submit.BatchRequest) error
+	DescribeStack(ctx context.Context, req *submit.DescribeStackRequest) error
+	Push(ctx context.Context, req *submit.PushRequest) error
 }
 
 func (cmd *branchSubmitCmd) Run(
 	ctx context.Context,
 	wt *git.Worktree,
 	submitHandler SubmitHandler,
+	changes ChangeBranchResolver,
 ) error {
 	if cmd.NoWeb {
 		cmd.Web = submit.OpenWebNever
@@ -103,6 +112,12 @@ func (cmd *branchSubmitCmd) Run(
 			return fmt.Errorf("get current branch: %w", err)
 		}
 		cmd.Branch = currentBranch
+	} else {
+		branch, err := changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
+		cmd.Branch = branch
 	}
 
 	return submitHandler.Submit(ctx, &submit.Request{
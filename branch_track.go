@@ -12,6 +12,7 @@ import (
 
 type branchTrackCmd struct {
 	Base   string `short:"b" placeholder:"BRANCH" help:"Base branch this merges into" predictor:"trackedBranches"`
+	Change string `placeholder:"CR" predictor:"changes" released:"unreleased" help:"Existing change request to associate with the branch. Accepts a number or URL."`
 	Branch string `arg:"" optional:"" help:"Name of the branch to track" predictor:"branches"`
 }
 
@@ -24,6 +25,14 @@ func (*branchTrackCmd) Help() string {
 		The base is guessed by comparing against other tracked branches.
 		Use --base to specify a base explicitly.
 
+		Change requests for tracked branches are detected automatically
+		when they are submitted.
+		If that does not find the change request
+		(for example, because its branch was renamed
+		or it was opened from a fork),
+		use --change to associate it explicitly.
+		The change request's head commit must match the branch.
+
 		Use '%[1]s downstack track' from the topmost branch
 		to track a manully created stack of branches at once.
 	`, name))
@@ -56,5 +65,6 @@ func (cmd *branchTrackCmd) Run(ctx context.Context, handler TrackHandler) error
 	return handler.TrackBranch(ctx, &track.BranchRequest{
 		Branch: cmd.Branch,
 		Base:   cmd.Base,
+		Change: cmd.Change,
 	})
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type branchUnarchiveCmd struct {
+	Branch string `arg:"" optional:"" help:"Name of the branch to unarchive. Defaults to current." predictor:"trackedBranches"`
+}
+
+func (*branchUnarchiveCmd) Help() string {
+	return text.Dedent(`
+		Restores a branch archived with 'branch archive'
+		to the active stack.
+		Its base branch must not be archived.
+
+		The branch may need to be restacked
+		if its base changed while it was archived.
+
+		Provide a branch name as an argument to target
+		a different branch.
+	`)
+}
+
+func (cmd *branchUnarchiveCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	wt *git.Worktree,
+	svc *spice.Service,
+) error {
+	if cmd.Branch == "" {
+		var err error
+		cmd.Branch, err = wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+	}
+
+	if err := svc.UnarchiveBranch(ctx, cmd.Branch); err != nil {
+		if errors.Is(err, spice.ErrNotArchived) {
+			log.Infof("%v: not archived", cmd.Branch)
+			return nil
+		}
+		return fmt.Errorf("unarchive branch: %w", err)
+	}
+
+	log.Infof("%v: unarchived", cmd.Branch)
+	return nil
+}
@@ -31,13 +31,19 @@ func (cmd *branchUntrackCmd) Run(
 	ctx context.Context,
 	wt *git.Worktree,
 	svc *spice.Service,
+	changes ChangeBranchResolver,
 ) error {
+	var err error
 	if cmd.Branch == "" {
-		var err error
 		cmd.Branch, err = wt.CurrentBranch(ctx)
 		if err != nil {
 			return fmt.Errorf("get current branch: %w", err)
 		}
+	} else {
+		cmd.Branch, err = changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := svc.ForgetBranch(ctx, cmd.Branch); err != nil {
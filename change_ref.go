@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+// ChangeBranchResolver resolves references to Change Requests
+// (e.g. "#123", "pr/123", or a URL) to the tracked branches
+// that they were submitted from.
+type ChangeBranchResolver interface {
+	ResolveBranch(ctx context.Context, name string) (string, error)
+}
+
+// changeBranchResolver is a [ChangeBranchResolver]
+// that consults the change metadata stored for tracked branches.
+// It does not talk to the forge.
+type changeBranchResolver struct {
+	Repository interface {
+		PeelToCommit(ctx context.Context, ref string) (git.Hash, error)
+	}
+	Service interface {
+		LoadBranches(ctx context.Context) ([]spice.LoadBranchItem, error)
+	}
+	Forges *forge.Registry
+}
+
+var _ ChangeBranchResolver = (*changeBranchResolver)(nil)
+
+// ResolveBranch returns the name of the tracked branch
+// for the CR referenced by name.
+//
+// name is returned unchanged if it doesn't look like a CR reference,
+// or if a branch with that name exists.
+func (r *changeBranchResolver) ResolveBranch(ctx context.Context, name string) (string, error) {
+	ref, ok := forge.ParseChangeRef(name)
+	if !ok {
+		return name, nil
+	}
+	if _, err := r.Repository.PeelToCommit(ctx, name); err == nil {
+		return name, nil // branch names take precedence
+	}
+
+	branches, err := r.Service.LoadBranches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("load branches: %w", err)
+	}
+
+	for _, b := range branches {
+		if b.Change == nil {
+			continue
+		}
+
+		f, ok := r.Forges.Lookup(b.Change.ForgeID())
+		if !ok {
+			continue
+		}
+
+		id, err := f.ParseChangeID(ref)
+		if err != nil {
+			continue // not a reference for this forge
+		}
+
+		if f.FormatChangeID(id) == f.FormatChangeID(b.Change.ChangeID()) {
+			return b.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%v: no tracked branch for this change request", name)
+}
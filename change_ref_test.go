@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/shamhub"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+func TestChangeBranchResolver(t *testing.T) {
+	var forges forge.Registry
+	forges.Register(&shamhub.Forge{})
+
+	resolver := &changeBranchResolver{
+		Repository: peelFunc(func(_ context.Context, ref string) (git.Hash, error) {
+			if ref == "pr/2" {
+				return "abc123", nil // a branch named like a CR
+			}
+			return "", git.ErrNotExist
+		}),
+		Service: loadBranchesFunc(func(context.Context) ([]spice.LoadBranchItem, error) {
+			return []spice.LoadBranchItem{
+				{Name: "unsubmitted"},
+				{Name: "feat1", Change: &shamhub.ChangeMetadata{Number: 1}},
+				{Name: "feat3", Change: &shamhub.ChangeMetadata{Number: 3}},
+			}, nil
+		}),
+		Forges: &forges,
+	}
+
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: "feature", want: "feature"},
+		{give: "#1", want: "feat1"},
+		{give: "pr/3", want: "feat3"},
+		{give: "https://example.com/alice/example/change/3", want: "feat3"},
+		{give: "pr/2", want: "pr/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := resolver.ResolveBranch(t.Context(), tt.give)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("NotTracked", func(t *testing.T) {
+		_, err := resolver.ResolveBranch(t.Context(), "#42")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "#42: no tracked branch")
+	})
+}
+
+type peelFunc func(context.Context, string) (git.Hash, error)
+
+func (f peelFunc) PeelToCommit(ctx context.Context, ref string) (git.Hash, error) {
+	return f(ctx, ref)
+}
+
+type loadBranchesFunc func(context.Context) ([]spice.LoadBranchItem, error)
+
+func (f loadBranchesFunc) LoadBranches(ctx context.Context) ([]spice.LoadBranchItem, error) {
+	return f(ctx)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// _commandLogLines is the maximum number of lines of log output
+// remembered for 'gs bug-report'.
+const _commandLogLines = 200
+
+// Overridden in tests.
+var _commandLogPath = defaultCommandLogPath
+
+func defaultCommandLogPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "git-spice", "last-command.json"), nil
+}
+
+// commandLog is an io.Writer for log output
+// that passes it through to another writer,
+// remembering the last few lines of it
+// so that they may be included in a bug report
+// after the command exits.
+type commandLog struct {
+	w io.Writer // required
+
+	// sanitize removes credentials from lines before they're saved.
+	// If nil, lines are saved as-is.
+	sanitize *bugReportSanitizer
+
+	mu      sync.Mutex
+	lines   []string // at most _commandLogLines
+	partial []byte   // incomplete last line
+}
+
+var _ io.Writer = (*commandLog)(nil)
+
+func (l *commandLog) Write(bs []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.partial = append(l.partial, bs...)
+	for {
+		idx := bytes.IndexByte(l.partial, '\n')
+		if idx < 0 {
+			break
+		}
+		l.addLine(string(l.partial[:idx]))
+		l.partial = l.partial[idx+1:]
+	}
+
+	return l.w.Write(bs)
+}
+
+// Fd reports the file descriptor of the underlying writer
+// so that the logger can tell if it's writing to a terminal.
+func (l *commandLog) Fd() uintptr {
+	if f, ok := l.w.(interface{ Fd() uintptr }); ok {
+		return f.Fd()
+	}
+	return ^uintptr(0) // not a terminal
+}
+
+// _ansiEscape matches terminal escape sequences used for styling.
+var _ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+func (l *commandLog) addLine(line string) {
+	line = _ansiEscape.ReplaceAllString(line, "")
+	if len(l.lines) == _commandLogLines {
+		l.lines = append(l.lines[:0], l.lines[1:]...)
+	}
+	l.lines = append(l.lines, line)
+}
+
+// commandLogRecord is the log output of the last command,
+// as saved to disk.
+type commandLogRecord struct {
+	// Command is the command that was run, e.g. "branch submit".
+	// This is empty if the command line could not be parsed.
+	Command string `json:"command,omitempty"`
+
+	// Time is when the command exited.
+	Time time.Time `json:"time"`
+
+	// Failed reports whether the command exited with an error.
+	Failed bool `json:"failed,omitempty"`
+
+	// Lines are the last lines of log output, oldest first.
+	Lines []string `json:"lines,omitempty"`
+}
+
+// Save writes the remembered log output to path,
+// creating its parent directory if needed.
+func (l *commandLog) Save(path, command string, failed bool) error {
+	l.mu.Lock()
+	lines := slices.Clone(l.lines)
+	if len(l.partial) > 0 {
+		lines = append(lines, _ansiEscape.ReplaceAllString(string(l.partial), ""))
+	}
+	if l.sanitize != nil {
+		for i, line := range lines {
+			lines[i] = l.sanitize.Text(line)
+		}
+	}
+	rec := commandLogRecord{
+		Command: command,
+		Time:    time.Now(),
+		Failed:  failed,
+		Lines:   lines,
+	}
+	bs, err := json.Marshal(rec)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// The log may mention private details of the repository.
+	return os.WriteFile(path, bs, 0o600)
+}
+
+// loadCommandLog loads the log output of the last command
+// saved at path.
+// It returns nil if there is no saved output.
+func loadCommandLog(path string) (*commandLogRecord, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rec commandLogRecord
+	if err := json.Unmarshal(bs, &rec); err != nil {
+		return nil, fmt.Errorf("decode %v: %w", path, err)
+	}
+	return &rec, nil
+}
+
+// saveCommandLog saves the log output of the given command
+// for 'gs bug-report'.
+// Nothing is saved if the command line was never parsed,
+// or if GIT_SPICE_NO_COMMAND_LOG is set to a true value.
+func saveCommandLog(log *silog.Logger, cmdLog *commandLog, command string, failed bool) {
+	if command == "" || !shouldSaveCommandLog(command) {
+		return
+	}
+	if noLog, err := strconv.ParseBool(os.Getenv("GIT_SPICE_NO_COMMAND_LOG")); err == nil && noLog {
+		return
+	}
+
+	path, err := _commandLogPath()
+	if err != nil {
+		log.Debug("Not saving command log", "error", err)
+		return
+	}
+	if err := cmdLog.Save(path, command, failed); err != nil {
+		log.Debug("Could not save command log", "error", err)
+	}
+}
+
+// shouldSaveCommandLog reports whether the log output of the given command
+// should replace the saved output of the last command.
+//
+// Commands that are run by other programs,
+// or that are used to report on earlier commands, are skipped.
+func shouldSaveCommandLog(command string) bool {
+	name, _, _ := strings.Cut(command, " ")
+	switch name {
+	case "bug-report", "version", "rpc", "prompt", "internal", "dumpmd":
+		return false
+	}
+	return true
+}
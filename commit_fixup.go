@@ -24,6 +24,8 @@ import (
 type commitFixupCmd struct {
 	fixup.Options
 
+	IncludeUntracked bool `config:"autostash.includeUntracked" released:"unreleased" help:"Also stash untracked files while fixing up the commit"`
+
 	Commit string `arg:"" optional:"" help:"The commit to fixup. Must be reachable from the HEAD commit."`
 }
 
@@ -90,9 +92,11 @@ func (cmd *commitFixupCmd) Run(
 	}
 
 	cleanup, err := autostashHandler.BeginAutostash(ctx, &autostash.Options{
-		Message:   "git-spice: autostash before commit fixup",
-		ResetMode: autostash.ResetWorktree,
-		Branch:    currentBranch,
+		Message:          "git-spice: autostash before commit fixup",
+		ResetMode:        autostash.ResetWorktree,
+		Branch:           currentBranch,
+		Command:          "commit fixup",
+		IncludeUntracked: cmd.IncludeUntracked,
 	})
 	if err != nil {
 		return err
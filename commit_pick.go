@@ -20,8 +20,10 @@ import (
 type commitPickCmd struct {
 	cherrypick.Options
 
-	Commit string `arg:"" optional:"" help:"Commit to cherry-pick"`
-	From   string `placeholder:"NAME" predictor:"trackedBranches" help:"Branch whose upstack commits will be considered."`
+	Commits []string `arg:"" optional:"" name:"commit" help:"Commits to cherry-pick, applied in the order given"`
+	From    string   `placeholder:"NAME" predictor:"trackedBranches" help:"Branch whose upstack commits will be considered."`
+	AllFrom string   `placeholder:"NAME" predictor:"trackedBranches" released:"unreleased" help:"Cherry-pick all commits of this branch"`
+	Onto    string   `placeholder:"NAME" predictor:"trackedBranches" released:"unreleased" help:"Branch to cherry-pick onto. Defaults to the current branch."`
 }
 
 func (*commitPickCmd) Help() string {
@@ -33,10 +35,20 @@ func (*commitPickCmd) Help() string {
 		from commits of upstack branches of the current branch.
 		Use the --from option to pick a commit from a different branch
 		or its upstack.
+		Multiple commits may be specified,
+		and they will be applied in the order given.
+		Use --all-from to cherry-pick all commits of another branch,
+		e.g. to backport a fix that landed in the middle of a stack.
 
-		If it's not possible to cherry-pick the requested commit
-		without causing a conflict, the command will fail.
-		If the requested commit is a merge commit,
+		Use --onto to cherry-pick onto a different branch
+		without checking it out.
+		The upstack branches of that branch will be restacked.
+		Picked commits are recorded with the branch they were picked onto.
+
+		If it's not possible to cherry-pick the requested commits
+		without causing a conflict, the command will fail
+		and the branch will be left unchanged.
+		If a requested commit is a merge commit,
 		the command will fail.
 
 		This command requires at least Git 2.45.
@@ -47,6 +59,13 @@ type CherryPickHandler interface {
 	CherryPickCommit(ctx context.Context, req *cherrypick.Request) error
 }
 
+func (cmd *commitPickCmd) AfterApply() error {
+	if cmd.AllFrom != "" && len(cmd.Commits) > 0 {
+		return errors.New("cannot use --all-from with commits")
+	}
+	return nil
+}
+
 func (cmd *commitPickCmd) Run(
 	ctx context.Context,
 	log *silog.Logger,
@@ -56,46 +75,71 @@ func (cmd *commitPickCmd) Run(
 	svc *spice.Service,
 	cherryPickHandler CherryPickHandler,
 ) (err error) {
-	branch, err := wt.CurrentBranch(ctx)
+	current, err := wt.CurrentBranch(ctx)
 	if err != nil {
-		if errors.Is(err, git.ErrDetachedHead) {
+		if !errors.Is(err, git.ErrDetachedHead) {
+			return fmt.Errorf("determine current branch: %w", err)
+		}
+		if cmd.Onto == "" {
 			return errors.New("cannot cherry-pick onto detached HEAD")
 		}
-		return fmt.Errorf("determine current branch: %w", err)
 	}
-	cmd.From = cmp.Or(cmd.From, branch)
+	branch := cmp.Or(cmd.Onto, current)
+	cmd.From = cmp.Or(cmd.From, current, branch)
+
+	var (
+		commits []git.Hash
+		from    string // branch the commits were picked from, if known
+	)
+	switch {
+	case cmd.AllFrom != "":
+		from = cmd.AllFrom
+		commits, err = cmd.branchCommits(ctx, repo, svc, cmd.AllFrom)
+		if err != nil {
+			return err
+		}
 
-	var commit git.Hash
-	if cmd.Commit == "" {
+	case len(cmd.Commits) == 0:
 		if !ui.Interactive(view) {
 			return fmt.Errorf("no commit specified: %w", errNoPrompt)
 		}
 
-		commit, err = cmd.commitPrompt(ctx, log, view, repo, svc, branch)
+		var commit git.Hash
+		commit, from, err = cmd.commitPrompt(ctx, log, view, repo, svc, branch)
 		if err != nil {
 			return fmt.Errorf("prompt for commit: %w", err)
 		}
-	} else {
-		commit, err = repo.PeelToCommit(ctx, cmd.Commit)
-		if err != nil {
-			return fmt.Errorf("peel to commit: %w", err)
+		commits = []git.Hash{commit}
+
+	default:
+		for _, rev := range cmd.Commits {
+			commit, err := repo.PeelToCommit(ctx, rev)
+			if err != nil {
+				return fmt.Errorf("peel to commit: %w", err)
+			}
+			commits = append(commits, commit)
 		}
 	}
 
 	if branch == svc.Trunk() {
+		what := fmt.Sprintf("commit %v", commits[0].Short())
+		if len(commits) > 1 {
+			what = fmt.Sprintf("%d commits", len(commits))
+		}
+
 		if !ui.Interactive(view) {
-			log.Warnf("You are about to cherry-pick commit %v on the trunk branch (%v).", commit.Short(), svc.Trunk())
+			log.Warnf("You are about to cherry-pick %v on the trunk branch (%v).", what, svc.Trunk())
 		} else {
 			var pickOnTrunk bool
 			prompt := ui.NewList[bool]().
 				WithTitle("Do you want to cherry-pick on trunk?").
-				WithDescription(fmt.Sprintf("You are about to cherry-pick commit %v on the trunk branch (%v). "+
-					"This is usually not what you want to do.", commit.Short(), svc.Trunk())).
+				WithDescription(fmt.Sprintf("You are about to cherry-pick %v on the trunk branch (%v). "+
+					"This is usually not what you want to do.", what, svc.Trunk())).
 				WithItems(
 					ui.ListItem[bool]{
 						Title: "Yes",
 						Description: func(bool) string {
-							return fmt.Sprintf("Cherry-pick commit %v on trunk", commit.Short())
+							return fmt.Sprintf("Cherry-pick %v on trunk", what)
 						},
 						Value: true,
 					},
@@ -119,29 +163,58 @@ func (cmd *commitPickCmd) Run(
 		}
 	}
 
-	log.Debugf("Cherry-picking: %v", commit)
+	log.Debugf("Cherry-picking: %v", commits)
 	return cherryPickHandler.CherryPickCommit(ctx, &cherrypick.Request{
-		Commit:  commit,
+		Commits: commits,
+		From:    from,
 		Branch:  branch,
 		Options: &cmd.Options,
 	})
 }
 
+// branchCommits returns the commits of a tracked branch,
+// oldest first.
+func (cmd *commitPickCmd) branchCommits(
+	ctx context.Context,
+	repo *git.Repository,
+	svc *spice.Service,
+	name string,
+) ([]git.Hash, error) {
+	b, err := svc.LookupBranch(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup branch %v: %w", name, err)
+	}
+
+	commits, err := sliceutil.CollectErr(repo.ListCommits(ctx,
+		git.CommitRangeFrom(b.Head).
+			ExcludeFrom(b.BaseHash).
+			FirstParent().
+			Reverse()))
+	if err != nil {
+		return nil, fmt.Errorf("list commits of %v: %w", name, err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("branch %v does not have any commits to cherry-pick", name)
+	}
+	return commits, nil
+}
+
 func (cmd *commitPickCmd) commitPrompt(
 	ctx context.Context,
 	log *silog.Logger,
 	view ui.View,
 	repo *git.Repository,
 	svc *spice.Service,
-	currentBranch string,
-) (git.Hash, error) {
+	targetBranch string,
+) (git.Hash, string, error) {
 	graph, err := svc.BranchGraph(ctx, nil)
 	if err != nil {
-		return "", fmt.Errorf("load branch graph: %w", err)
+		return "", "", fmt.Errorf("load branch graph: %w", err)
 	}
 
 	var totalCommits int
 	shortToLongHash := make(map[git.Hash]git.Hash)
+	commitBranch := make(map[git.Hash]string) // long hash -> branch
 	var branches []widget.CommitPickBranch
 	for name := range graph.Upstack(cmd.From) {
 		if name == graph.Trunk() {
@@ -155,10 +228,10 @@ func (cmd *commitPickCmd) commitPrompt(
 		}
 
 		// If doing a --from=$other,
-		// where $other is downstack from current,
-		// we don't want to list commits for current branch,
+		// where $other is downstack from the target,
+		// we don't want to list commits for the target branch,
 		// so add an empty entry for it.
-		if name == currentBranch {
+		if name == targetBranch {
 			branches = append(branches, widget.CommitPickBranch{
 				Branch: name,
 				Base:   b.Base,
@@ -185,6 +258,7 @@ func (cmd *commitPickCmd) commitPrompt(
 				AuthorDate: c.AuthorDate,
 			}
 			shortToLongHash[c.ShortHash] = c.Hash
+			commitBranch[c.Hash] = name
 		}
 
 		branches = append(branches, widget.CommitPickBranch{
@@ -197,10 +271,10 @@ func (cmd *commitPickCmd) commitPrompt(
 
 	if totalCommits == 0 {
 		log.Warn("Please provide a commit hash to cherry pick from.")
-		return "", fmt.Errorf("upstack of %v does not have any commits to cherry-pick", cmd.From)
+		return "", "", fmt.Errorf("upstack of %v does not have any commits to cherry-pick", cmd.From)
 	}
 
-	msg := fmt.Sprintf("Selected commit will be cherry-picked into %v", currentBranch)
+	msg := fmt.Sprintf("Selected commit will be cherry-picked into %v", targetBranch)
 	var selected git.Hash
 	prompt := widget.NewCommitPick().
 		WithTitle("Pick a commit").
@@ -208,7 +282,7 @@ func (cmd *commitPickCmd) commitPrompt(
 		WithBranches(branches...).
 		WithValue(&selected)
 	if err := ui.Run(view, prompt); err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	if long, ok := shortToLongHash[selected]; ok {
@@ -216,5 +290,5 @@ func (cmd *commitPickCmd) commitPrompt(
 		// to be defensive here.
 		selected = long
 	}
-	return selected, nil
+	return selected, commitBranch[selected], nil
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+type configCmd struct {
+	Doctor configDoctorCmd `cmd:"" help:"Find problems with git-spice configuration" released:"unreleased"`
+	List   configListCmd   `cmd:"" help:"List git-spice configuration" released:"unreleased"`
+	Get    configGetCmd    `cmd:"" help:"Print the value of a configuration option" released:"unreleased"`
+	Set    configSetCmd    `cmd:"" help:"Change the value of a configuration option" released:"unreleased"`
+}
+
+// newLayeredConfig returns the source of git-spice configuration
+// for the current directory:
+// git-config layered over the defaults checked into the repository, if any.
+func newLayeredConfig(ctx context.Context, log *silog.Logger) *spice.LayeredConfig {
+	cfg := &spice.LayeredConfig{
+		Git: git.NewConfig(git.ConfigOptions{Log: log}),
+		Log: log,
+	}
+	if wt, err := git.OpenWorktree(ctx, ".", git.OpenOptions{Log: log}); err == nil {
+		cfg.RepoConfigFile = filepath.Join(wt.RootDir(), spice.RepoConfigFile)
+	}
+	return cfg
+}
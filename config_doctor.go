@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/secret"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type configDoctorCmd struct{}
+
+func (*configDoctorCmd) Help() string {
+	return text.Dedent(`
+		Checks all spice.* keys in git-config
+		and in the repository's .gitspice.yaml for problems,
+		reporting the file and line that each problem came from.
+
+		The following problems are detected:
+
+		  - unknown keys, e.g. misspelled options
+		  - values that are not valid for their options
+		  - deprecated options
+		  - options that have no effect
+		    because of the values of other options,
+		    or because the Git host of the current repository
+		    does not support the feature they configure
+
+		Options are checked against the Git host
+		only inside an initialized repository
+		when you are logged in to that host.
+
+		The command fails if any problems are found.
+	`)
+}
+
+func (*configDoctorCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	secretStash secret.Stash,
+	forges *forge.Registry,
+) error {
+	opts := spice.CheckConfigOptions{Log: log}
+	if f, repo, ok := configDoctorRemoteRepository(ctx, log, secretStash, forges); ok {
+		opts.Forge = f
+		opts.Capabilities = repo.Capabilities()
+	}
+
+	problems, err := spice.CheckConfig(ctx,
+		newLayeredConfig(ctx, log), kctx.Model, &opts)
+	if err != nil {
+		return fmt.Errorf("check configuration: %w", err)
+	}
+
+	if len(problems) == 0 {
+		log.Info("No problems found")
+		return nil
+	}
+
+	for _, p := range problems {
+		log.Warnf("%v: %v: %v", p.Location(), p.Key, p.Message)
+	}
+	return fmt.Errorf("found %d configuration problem(s)", len(problems))
+}
+
+// configDoctorRemoteRepository opens the remote repository
+// for the current Git repository without prompting
+// for anything that's missing.
+//
+// It reports false if there's no initialized repository
+// or the remote repository cannot be opened.
+func configDoctorRemoteRepository(
+	ctx context.Context,
+	log *silog.Logger,
+	secretStash secret.Stash,
+	forges *forge.Registry,
+) (forge.Forge, forge.Repository, bool) {
+	wt, err := git.OpenWorktree(ctx, ".", git.OpenOptions{Log: log})
+	if err != nil {
+		log.Debug("Not checking configuration against the Git host: not in a repository", "error", err)
+		return nil, nil, false
+	}
+	repo := wt.Repository()
+
+	store, err := state.OpenStore(ctx, newRepoStorage(repo, log), log)
+	if err != nil {
+		log.Debug("Not checking configuration against the Git host: could not open store", "error", err)
+		return nil, nil, false
+	}
+
+	remote, err := store.Remote()
+	if err != nil {
+		log.Debug("Not checking configuration against the Git host: no remote", "error", err)
+		return nil, nil, false
+	}
+
+	f, repoID, err := findRemoteRepositoryID(ctx, forges, repo, remote)
+	if err != nil {
+		log.Debug("Not checking configuration against the Git host", "error", err)
+		return nil, nil, false
+	}
+
+	remoteRepo, err := openForgeRepository(ctx, secretStash, f, repoID)
+	if err != nil {
+		log.Debug("Not checking configuration against the Git host", "error", err)
+		return nil, nil, false
+	}
+
+	return f, remoteRepo, true
+}
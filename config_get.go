@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type configGetCmd struct {
+	Key string `arg:"" help:"Configuration key, e.g. spice.submit.publish" predictor:"configKeys"`
+}
+
+func (*configGetCmd) Help() string {
+	return text.Dedent(`
+		Prints the value of a git-spice configuration option
+		that is in effect for the current repository.
+		If the option is not set, its default value is printed.
+
+		Options that accept multiple values
+		print each value on its own line.
+
+		The command fails if the key is not a known option.
+	`)
+}
+
+func (cmd *configGetCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+) error {
+	opt, err := spice.NewConfigSchema(kctx.Model).Find(cmd.Key)
+	if err != nil {
+		return fmt.Errorf("%v: %w", cmd.Key, err)
+	}
+
+	values, err := configValues(ctx, newLayeredConfig(ctx, log), opt)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(values) == 0:
+		fmt.Fprintln(kctx.Stdout, opt.Default)
+	case opt.Type == "list":
+		for _, value := range values {
+			fmt.Fprintln(kctx.Stdout, value)
+		}
+	default:
+		fmt.Fprintln(kctx.Stdout, values[len(values)-1])
+	}
+	return nil
+}
+
+// configValues returns the values of the given option
+// in the order that git-config reports them.
+// The last value is the one in effect for single-valued options.
+func configValues(ctx context.Context, cfg spice.GitConfigLister, opt *spice.ConfigOption) ([]string, error) {
+	key := git.ConfigKey(opt.Key).Canonical()
+
+	var values []string
+	for entry, err := range cfg.ListRegexp(ctx, "^"+regexp.QuoteMeta(string(key))+"$") {
+		if err != nil {
+			return nil, fmt.Errorf("list configuration: %w", err)
+		}
+		values = append(values, entry.Value)
+	}
+	return values, nil
+}
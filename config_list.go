@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type configListCmd struct {
+	All bool `short:"a" help:"Also list options that are not set, with their default values"`
+}
+
+func (*configListCmd) Help() string {
+	return text.Dedent(`
+		Lists git-spice configuration options
+		that are set for the current repository
+		in the form 'key=value', sorted by key.
+
+		Options that accept multiple values
+		are listed once for each value.
+		Only the last value of other options is listed
+		as that is the one in effect.
+
+		Use --all to also list options that are not set
+		with their default values.
+
+		Shorthands and experiments are not listed.
+		Use 'gs experiment list' to list experiments.
+	`)
+}
+
+func (cmd *configListCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+) error {
+	schema := spice.NewConfigSchema(kctx.Model)
+	cfg := newLayeredConfig(ctx, log)
+
+	values := make(map[git.ConfigKey][]string)
+	for entry, err := range cfg.ListRegexp(ctx, `^spice\.`) {
+		if err != nil {
+			return fmt.Errorf("list configuration: %w", err)
+		}
+
+		key := entry.Key.Canonical()
+		if _, ok := schema.Lookup(string(key)); !ok {
+			// Unknown keys are reported by 'gs config doctor'.
+			continue
+		}
+		values[key] = append(values[key], entry.Value)
+	}
+
+	for _, opt := range schema.Options() {
+		vs, ok := values[git.ConfigKey(opt.Key).Canonical()]
+		switch {
+		case ok && opt.Type != "list":
+			vs = vs[len(vs)-1:]
+		case !ok && cmd.All:
+			vs = []string{opt.Default}
+		}
+
+		for _, v := range vs {
+			fmt.Fprintf(kctx.Stdout, "%v=%v\n", opt.Key, v)
+		}
+	}
+	return nil
+}
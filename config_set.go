@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type configSetCmd struct {
+	Global bool `help:"Change the option for all repositories of the current user"`
+
+	Key   string `arg:"" help:"Configuration key, e.g. spice.submit.publish" predictor:"configKeys"`
+	Value string `arg:"" help:"New value of the option"`
+}
+
+func (*configSetCmd) Help() string {
+	return text.Dedent(`
+		Changes the value of a git-spice configuration option
+		after verifying that the key is a known option
+		and the value is valid for it.
+
+		By default, the option is changed for the current repository.
+		Use --global to change it for all repositories.
+
+		Options that accept multiple values
+		cannot be changed with this command
+		if they already have more than one value.
+		Use 'git config' to edit those.
+	`)
+}
+
+func (cmd *configSetCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+) error {
+	opt, err := spice.NewConfigSchema(kctx.Model).Find(cmd.Key)
+	if err != nil {
+		return fmt.Errorf("%v: %w", cmd.Key, err)
+	}
+
+	if err := opt.Validate(cmd.Value); err != nil {
+		return fmt.Errorf("%v: %w", opt.Key, err)
+	}
+
+	if opt.Deprecated {
+		log.Warnf("%v: deprecated key: support will be removed in a future version", opt.Key)
+	}
+
+	cfg := git.NewConfig(git.ConfigOptions{Log: log})
+	if err := cfg.Set(ctx, git.ConfigKey(opt.Key), cmd.Value, &git.ConfigSetOptions{
+		Global: cmd.Global,
+	}); err != nil {
+		return fmt.Errorf("set %v: %w", opt.Key, err)
+	}
+	return nil
+}
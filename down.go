@@ -8,7 +8,6 @@ import (
 	"go.abhg.dev/gs/internal/handler/checkout"
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/spice"
-	"go.abhg.dev/gs/internal/spice/state"
 	"go.abhg.dev/gs/internal/text"
 )
 
@@ -31,7 +30,6 @@ func (cmd *downCmd) Run(
 	ctx context.Context,
 	log *silog.Logger,
 	wt *git.Worktree,
-	store *state.Store,
 	svc *spice.Service,
 	checkoutHandler CheckoutHandler,
 ) error {
@@ -41,39 +39,25 @@ func (cmd *downCmd) Run(
 		return fmt.Errorf("get current branch: %w", err)
 	}
 
-	var below string
-outer:
-	for range cmd.N {
-		downstack, err := svc.ListDownstack(ctx, current)
-		if err != nil {
-			return fmt.Errorf("list downstacks: %w", err)
-		}
-
-		switch len(downstack) {
-		case 0:
-			if below != "" {
-				// If we've already moved up once,
-				// and there are no branches above the current one,
-				// we're done.
-				break outer
-			}
-			return fmt.Errorf("%v: no branches found downstack", current)
-
-		case 1:
-			// Current branch is bottom of stack.
-			// Move to trunk.
-			log.Info("moving to trunk: end of stack")
-			below = store.Trunk()
-
-		default:
-			below = downstack[1]
-		}
+	// The branch itself is the first item, and trunk is the last.
+	downstack, err := svc.ListDownstackItems(ctx, current, &spice.ListDownstackItemsOptions{
+		IncludeTrunk: true,
+	})
+	if err != nil {
+		return fmt.Errorf("list downstacks: %w", err)
+	}
+	if len(downstack) < 2 {
+		return fmt.Errorf("%v: no branches found downstack", current)
+	}
 
-		current = below
+	// Stop at trunk if asked to move past the bottom of the stack.
+	below := downstack[min(max(cmd.N, 1), len(downstack)-1)]
+	if below.Trunk {
+		log.Info("moving to trunk: end of stack")
 	}
 
 	return checkoutHandler.CheckoutBranch(ctx, &checkout.Request{
-		Branch:  below,
+		Branch:  below.Name,
 		Options: &cmd.Options,
 	})
 }
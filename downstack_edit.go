@@ -31,7 +31,10 @@ func (*downstackEditCmd) Help() string {
 		Modifications to the list will be reflected in the stack
 		when the editor is closed, and the topmost branch will be checked out.
 		If the file is cleared, no changes will be made.
-		Branches that are deleted from the list will be ignored.
+
+		Branches that are deleted from the list are dropped from the stack
+		and moved onto the base of the lowest branch in the list,
+		usually trunk.
 		Branches that are upstack of the current branch will not be modified.
 	`)
 }
@@ -76,8 +79,9 @@ func (cmd *downstackEditCmd) Run(
 
 	slices.Reverse(downstacks) // branch closest to trunk first
 	res, err := svc.StackEdit(ctx, &spice.StackEditRequest{
-		Stack:  downstacks,
-		Editor: cmd.Editor,
+		Stack:       downstacks,
+		Editor:      cmd.Editor,
+		DropDeleted: true,
 	})
 	if err != nil {
 		if errors.Is(err, spice.ErrStackEditAborted) {
@@ -91,6 +95,10 @@ func (cmd *downstackEditCmd) Run(
 		return fmt.Errorf("edit downstack: %w", err)
 	}
 
+	for _, branch := range res.Dropped {
+		log.Infof("%v: dropped from the stack", branch)
+	}
+
 	return checkoutHandler.CheckoutBranch(ctx, &checkout.Request{
 		Branch: res.Stack[len(res.Stack)-1],
 	})
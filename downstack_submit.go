@@ -35,6 +35,7 @@ func (cmd *downstackSubmitCmd) Run(
 	store *state.Store,
 	svc *spice.Service,
 	submitHandler SubmitHandler,
+	changes ChangeBranchResolver,
 ) error {
 	if cmd.Branch == "" {
 		currentBranch, err := wt.CurrentBranch(ctx)
@@ -42,6 +43,12 @@ func (cmd *downstackSubmitCmd) Run(
 			return fmt.Errorf("get current branch: %w", err)
 		}
 		cmd.Branch = currentBranch
+	} else {
+		branch, err := changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
+		cmd.Branch = branch
 	}
 
 	if cmd.Branch == store.Trunk() {
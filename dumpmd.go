@@ -12,6 +12,7 @@ import (
 
 	"github.com/alecthomas/kong"
 	"go.abhg.dev/gs/internal/cli/shorthand"
+	"go.abhg.dev/gs/internal/spice"
 )
 
 // dumpMarkdownCmd is a hidden commnad that dumps
@@ -19,6 +20,7 @@ import (
 type dumpMarkdownCmd struct {
 	Ref        string `name:"ref" help:"Output file for command reference."`
 	Shorthands string `name:"shorthands" help:"Output file for shorthands table."`
+	Config     string `name:"config" help:"Output file for configuration options table."`
 }
 
 func (cmd *dumpMarkdownCmd) Run(app *kong.Kong, shorts *shorthand.BuiltinSource) (err error) {
@@ -47,9 +49,46 @@ func (cmd *dumpMarkdownCmd) Run(app *kong.Kong, shorts *shorthand.BuiltinSource)
 		defer func() { _ = f.Close() }()
 		dumpShorthands(f, shorts)
 	}
+
+	if cmd.Config != "" {
+		f, err := os.Create(cmd.Config)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		dumpConfigOptions(f, spice.NewConfigSchema(app.Model))
+	}
 	return nil
 }
 
+func dumpConfigOptions(w io.Writer, schema *spice.ConfigSchema) {
+	var t table
+	t.appendHeaders("Key", "Accepted values", "Default", "Description")
+	for _, opt := range schema.Options() {
+		if opt.Deprecated {
+			continue
+		}
+
+		id := strings.ToLower(strings.ReplaceAll(opt.Key, ".", ""))
+		values := opt.Type
+		if len(opt.Enum) > 0 {
+			values = "`" + strings.Join(opt.Enum, "`, `") + "`"
+		}
+		var def string
+		if opt.Default != "" {
+			def = "`" + opt.Default + "`"
+		}
+
+		t.addRow(
+			fmt.Sprintf("[%v](#%v)", opt.Key, id),
+			values,
+			def,
+			strings.ReplaceAll(opt.Help, "|", `\|`),
+		)
+	}
+	t.dump(w)
+}
+
 func dumpShorthands(w io.Writer, shorts *shorthand.BuiltinSource) {
 	keys := slices.Sorted(shorts.Keys())
 
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// Machine-readable codes for failures.
+//
+// These are reported in the "code" field
+// of the final log message with --log-format=json.
+// Codes are part of the public interface:
+// do not change existing codes.
+const (
+	errorCodeUsage             = "usage"
+	errorCodePromptRequired    = "prompt_required"
+	errorCodeNotInitialized    = "not_initialized"
+	errorCodeRebaseInterrupted = "rebase_interrupted"
+	errorCodeNeedsRestack      = "needs_restack"
+	errorCodeUnsupportedForge  = "unsupported_forge"
+	errorCodeNotLoggedIn       = "not_logged_in"
+	errorCodeForgeUnreachable  = "forge_unreachable"
+	errorCodeUnauthorized      = "unauthorized"
+	errorCodePermissionDenied  = "permission_denied"
+	errorCodeRateLimited       = "rate_limited"
+	errorCodeConflict          = "conflict"
+	errorCodeInterrupted       = "interrupted"
+	errorCodeLocked            = "locked"
+	errorCodeUnknown           = "error"
+)
+
+// errorCode returns a machine-readable code for the given error.
+func errorCode(err error) string {
+	var (
+		parseErr       *kong.ParseError
+		rebaseErr      *git.RebaseInterruptError
+		restackErr     *spice.BranchNeedsRestackError
+		unsupportedErr *unsupportedForgeError
+		loginErr       *notLoggedInError
+		unreachableErr *forgeUnreachableError
+		lockedErr      *repoLockedError
+	)
+	switch {
+	case errors.As(err, &parseErr):
+		return errorCodeUsage
+	case errors.Is(err, ui.ErrPrompt):
+		return errorCodePromptRequired
+	case errors.Is(err, state.ErrUninitialized):
+		return errorCodeNotInitialized
+	case errors.As(err, &rebaseErr):
+		return errorCodeRebaseInterrupted
+	case errors.As(err, &restackErr):
+		return errorCodeNeedsRestack
+	case errors.As(err, &unsupportedErr):
+		return errorCodeUnsupportedForge
+	case errors.As(err, &loginErr):
+		return errorCodeNotLoggedIn
+	case errors.As(err, &unreachableErr):
+		return errorCodeForgeUnreachable
+	case errors.Is(err, forge.ErrUnauthorized):
+		return errorCodeUnauthorized
+	case errors.Is(err, forge.ErrPermission):
+		return errorCodePermissionDenied
+	case errors.Is(err, forge.ErrRateLimited):
+		return errorCodeRateLimited
+	case errors.Is(err, forge.ErrConflict):
+		return errorCodeConflict
+	case errors.Is(err, context.Canceled):
+		return errorCodeInterrupted
+	case errors.As(err, &lockedErr):
+		return errorCodeLocked
+	default:
+		return errorCodeUnknown
+	}
+}
+
+// fatalError logs a command failure and exits.
+//
+// With JSON logging, the message includes
+// a machine-readable code for the error.
+func fatalError(log *silog.Logger, cmdName string, err error) {
+	var attrs []any
+	if log.Format() == silog.FormatJSON {
+		attrs = append(attrs, "code", errorCode(err))
+	}
+	log.Fatal(fmt.Sprintf("%v: %v", cmdName, err), attrs...)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/netcheck"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "Prompt",
+			err:  fmt.Errorf("select branch: %w", &ui.PromptError{Fields: []string{"Branch"}}),
+			want: "prompt_required",
+		},
+		{
+			name: "Uninitialized",
+			err:  fmt.Errorf("open store: %w", state.ErrUninitialized),
+			want: "not_initialized",
+		},
+		{
+			name: "RebaseInterrupted",
+			err:  &git.RebaseInterruptError{State: &git.RebaseState{Branch: "feat"}},
+			want: "rebase_interrupted",
+		},
+		{
+			name: "NeedsRestack",
+			err:  fmt.Errorf("branch feat: %w", &spice.BranchNeedsRestackError{Base: "main"}),
+			want: "needs_restack",
+		},
+		{
+			name: "UnsupportedForge",
+			err:  &unsupportedForgeError{Remote: "origin", RemoteURL: "https://example.com/foo.git"},
+			want: "unsupported_forge",
+		},
+		{
+			name: "ForgeUnreachable",
+			err:  &forgeUnreachableError{Err: &netcheck.Error{Problem: netcheck.DNS, Err: errors.New("no such host")}},
+			want: "forge_unreachable",
+		},
+		{
+			name: "Unauthorized",
+			err:  fmt.Errorf("find change: %w", forge.WrapError(errors.New("401 Unauthorized"), forge.ErrUnauthorized)),
+			want: "unauthorized",
+		},
+		{
+			name: "PermissionDenied",
+			err:  fmt.Errorf("update change: %w", forge.ErrPermission),
+			want: "permission_denied",
+		},
+		{
+			name: "RateLimited",
+			err:  fmt.Errorf("post comment: %w", forge.ErrRateLimited),
+			want: "rate_limited",
+		},
+		{
+			name: "Conflict",
+			err:  fmt.Errorf("create change: %w", forge.ErrConflict),
+			want: "conflict",
+		},
+		{
+			name: "Canceled",
+			err:  fmt.Errorf("fetch: %w", context.Canceled),
+			want: "interrupted",
+		},
+		{
+			name: "Locked",
+			err:  fmt.Errorf("open store: %w", &repoLockedError{PID: 42}),
+			want: "locked",
+		},
+		{
+			name: "Unknown",
+			err:  errors.New("great sadness"),
+			want: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorCode(tt.err))
+		})
+	}
+}
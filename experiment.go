@@ -0,0 +1,5 @@
+package main
+
+type experimentCmd struct {
+	List experimentListCmd `cmd:"" help:"List experiments and whether they're enabled" released:"unreleased"`
+}
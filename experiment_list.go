@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/cli/experiment"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type experimentListCmd struct{}
+
+func (*experimentListCmd) Help() string {
+	return text.Dedent(`
+		Lists all experiments available in this version of git-spice,
+		whether each is enabled for the current repository,
+		and the commands that it gates.
+
+		For experiments that were enabled or disabled explicitly,
+		the file and line that did so is also reported.
+		Use this to verify experiments that were rolled out
+		with 'git config --global', 'git config --local',
+		or conditional includes.
+	`)
+}
+
+func (*experimentListCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	enabler experiment.Enabler,
+) error {
+	// Experiment names are case-insensitive,
+	// and the last valid value for each wins.
+	origins := make(map[string]string)
+	lines := git.ConfigLineFinder{Log: log}
+	cfg := git.NewConfig(git.ConfigOptions{Log: log})
+	for entry, err := range cfg.ListOriginRegexp(ctx, `^spice\.experiment\.`) {
+		if err != nil {
+			return fmt.Errorf("list configuration: %w", err)
+		}
+
+		line := lines.Line(entry)
+		if _, err := strconv.ParseBool(entry.Value); err != nil {
+			continue // reported by LoadConfig
+		}
+
+		_, _, name := entry.Key.Split()
+		origins[strings.ToLower(name)] = git.FormatConfigOrigin(entry.Origin, line)
+	}
+
+	for _, exp := range experiment.List(kctx.Model.Node) {
+		state := "disabled"
+		if enabler.ExperimentEnabled(exp.Name) {
+			state = "enabled"
+		}
+
+		fmt.Fprintf(kctx.Stdout, "%v: %v", exp.Name, state)
+		if origin, ok := origins[strings.ToLower(exp.Name)]; ok {
+			fmt.Fprintf(kctx.Stdout, " (%v)", origin)
+		}
+		fmt.Fprintln(kctx.Stdout)
+
+		for _, cmd := range exp.Commands {
+			// Node.FullPath includes aliases.
+			var parts []string
+			for n := cmd; n != nil; n = n.Parent {
+				parts = append(parts, n.Name)
+			}
+			slices.Reverse(parts)
+			fmt.Fprintf(kctx.Stdout, "  %v\n", strings.Join(parts, " "))
+		}
+	}
+	return nil
+}
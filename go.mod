@@ -27,6 +27,7 @@ require (
 	go.abhg.dev/log/silog v0.2.0
 	go.abhg.dev/testing/stub v0.2.0
 	go.uber.org/mock v0.6.0
+	golang.org/x/mod v0.33.0
 	golang.org/x/oauth2 v0.35.0
 	gopkg.in/dnaeon/go-vcr.v4 v4.0.6
 	gopkg.in/yaml.v3 v3.0.1
@@ -74,7 +75,6 @@ require (
 	github.com/tidwall/pretty v1.2.0 // indirect
 	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	go.yaml.in/yaml/v4 v4.0.0-rc.3 // indirect
-	golang.org/x/mod v0.33.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
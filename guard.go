@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/stacknav"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/guard"
+	"go.abhg.dev/gs/internal/secret"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type guardCmd struct {
+	Change guardChangeCmd `cmd:"" help:"Check that Change Requests are safe to merge"`
+	Stack  guardStackCmd  `cmd:"" help:"Check the order of Change Requests in a stack"`
+
+	Repo       string `placeholder:"URL" env:"GIT_SPICE_GUARD_REPO" help:"URL of the repository to check. If unset, the repository is identified from --remote in the current directory."`
+	Remote     string `placeholder:"NAME" default:"origin" help:"Git remote identifying the repository to check"`
+	Trunk      string `placeholder:"BRANCH" help:"Require Change Requests at the bottom of their stack to be proposed against this branch"`
+	SigningKey string `name:"signing-key" placeholder:"KEY" env:"GIT_SPICE_NAV_COMMENT_SIGNING_KEY" help:"Secret that navigation comments were signed with. Unsigned comments are ignored if set."`
+}
+
+func (*guardCmd) Help() string {
+	return text.Dedent(`
+		Checks that Change Requests in a stack are merged in order
+		using the navigation comments posted by git-spice.
+		Use these commands in CI to stop Change Requests
+		from being merged before the ones below them.
+
+		The commands only talk to the forge, so they don't need
+		a clone of the repository if --repo is given.
+		Provide an authentication token with an environment variable,
+		e.g. GITHUB_TOKEN or GITLAB_TOKEN.
+
+		If navigation comments are signed with
+		spice.submit.navigationComment.signingKey,
+		set --signing-key to the same secret
+		to ignore comments that weren't posted by git-spice.
+
+		Problems are reported as errors, and the command exits with a
+		non-zero status if any are found.
+	`)
+}
+
+// AfterApply makes the guard.Handler available to all subcommands.
+func (cmd *guardCmd) AfterApply(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	stash secret.Stash,
+	forges *forge.Registry,
+) error {
+	f, repoID, err := cmd.repositoryID(ctx, log, forges)
+	if err != nil {
+		return err
+	}
+
+	remoteRepo, err := openForgeRepository(ctx, stash, f, repoID)
+	if err != nil {
+		var (
+			notLoggedInErr *notLoggedInError
+			unreachableErr *forgeUnreachableError
+		)
+		switch {
+		case errors.As(err, &notLoggedInErr):
+			log.Errorf("No authentication token found for %s.", f.ID())
+			log.Errorf("Set one in the environment, or run `%s auth login --forge=%s`", cli.Name(), f.ID())
+		case errors.As(err, &unreachableErr):
+			logForgeUnreachable(log, unreachableErr)
+		}
+		return fmt.Errorf("open repository: %w", err)
+	}
+
+	handler := &guard.Handler{
+		Log:              log,
+		RemoteRepository: remoteRepo,
+		Trunk:            cmd.Trunk,
+	}
+	if cmd.SigningKey != "" {
+		// Must match how 'submit' signs comments.
+		handler.Signer = stacknav.NewSigner(cmd.SigningKey, f.ID()+":"+repoID.String())
+	}
+
+	kctx.Bind(handler)
+	return nil
+}
+
+// repositoryID identifies the repository to check
+// from --repo or from --remote.
+func (cmd *guardCmd) repositoryID(
+	ctx context.Context,
+	log *silog.Logger,
+	forges *forge.Registry,
+) (forge.Forge, forge.RepositoryID, error) {
+	if cmd.Repo != "" {
+		f, repoID, ok := forge.MatchRemoteURL(forges, cmd.Repo)
+		if !ok {
+			log.Error("Are you sure the URL identifies a supported Git host?")
+			return nil, nil, &unsupportedForgeError{Remote: "--repo", RemoteURL: cmd.Repo}
+		}
+		return f, repoID, nil
+	}
+
+	repo, err := git.Open(ctx, ".", git.OpenOptions{Log: log})
+	if err != nil {
+		log.Error("Use --repo to check a repository without a clone.")
+		return nil, nil, fmt.Errorf("open repository: %w", err)
+	}
+
+	f, repoID, err := findRemoteRepositoryID(ctx, forges, repo, cmd.Remote)
+	if err != nil {
+		var unsupportedErr *unsupportedForgeError
+		if errors.As(err, &unsupportedErr) {
+			log.Error("Could not guess repository from remote URL", "url", unsupportedErr.RemoteURL)
+		}
+		return nil, nil, err
+	}
+	return f, repoID, nil
+}
+
+// guardViolationsError reports that a guard check found problems.
+type guardViolationsError struct {
+	Count int
+}
+
+func (e *guardViolationsError) Error() string {
+	if e.Count == 1 {
+		return "found 1 problem"
+	}
+	return fmt.Sprintf("found %d problems", e.Count)
+}
+
+// reportGuardViolations logs the given violations
+// and returns an error if there were any.
+func reportGuardViolations(log *silog.Logger, violations []*guard.Violation) error {
+	for _, v := range violations {
+		log.Errorf("%v", v)
+	}
+	if len(violations) > 0 {
+		return &guardViolationsError{Count: len(violations)}
+	}
+	return nil
+}
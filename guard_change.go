@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/handler/guard"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type guardChangeCmd struct {
+	Changes []string `arg:"" help:"Change Requests to check, as numbers or URLs"`
+}
+
+func (*guardChangeCmd) Help() string {
+	return text.Dedent(`
+		Checks that Change Requests are safe to merge now.
+		Run this when a Change Request is opened or updated,
+		and make the check required before merging.
+
+		A Change Request fails the check if:
+
+		  - a Change Request below it in its stack is still open
+		  - it's proposed against the branch of a merged Change Request
+		    below it, and hasn't been retargeted
+		  - with --trunk, it's not proposed against that branch
+
+		Change Requests that aren't open always pass.
+	`)
+}
+
+func (cmd *guardChangeCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	handler *guard.Handler,
+) error {
+	f := handler.RemoteRepository.Forge()
+
+	var violations []*guard.Violation
+	for _, s := range cmd.Changes {
+		id, err := f.ParseChangeID(s)
+		if err != nil {
+			return fmt.Errorf("parse change %q: %w", s, err)
+		}
+
+		vs, err := handler.CheckChange(ctx, id)
+		if err != nil {
+			return fmt.Errorf("check %v: %w", id, err)
+		}
+		if len(vs) == 0 {
+			log.Infof("%v: ok", id)
+		}
+		violations = append(violations, vs...)
+	}
+
+	return reportGuardViolations(log, violations)
+}
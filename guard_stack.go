@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/handler/guard"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type guardStackCmd struct {
+	Branch string `arg:"" help:"Branch that was pushed"`
+}
+
+func (*guardStackCmd) Help() string {
+	return text.Dedent(`
+		Checks the order of the open Change Requests
+		in the stack that a branch's Change Request is part of.
+		Run this when a branch is pushed
+		to catch Change Requests that were retargeted by hand
+		or left behind when the stack changed.
+
+		The stack fails the check if:
+
+		  - an open Change Request is not proposed against the branch
+		    of the nearest open Change Request below it
+		  - the bottom-most open Change Request is proposed against
+		    the branch of a merged or closed Change Request
+		  - with --trunk, the bottom-most open Change Request
+		    is not proposed against that branch
+
+		Branches without an open Change Request always pass.
+	`)
+}
+
+func (cmd *guardStackCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	handler *guard.Handler,
+) error {
+	violations, err := handler.CheckStack(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("check %v: %w", cmd.Branch, err)
+	}
+	if len(violations) == 0 {
+		log.Infof("%v: ok", cmd.Branch)
+	}
+
+	return reportGuardViolations(log, violations)
+}
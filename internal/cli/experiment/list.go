@@ -0,0 +1,55 @@
+package experiment
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// Experiment is an experiment declared in a Kong application
+// with `experiment:"name"` tags.
+type Experiment struct {
+	// Name is the name of the experiment as specified in the tag.
+	Name string
+
+	// Commands lists the commands gated by the experiment.
+	Commands []*kong.Node
+}
+
+// List reports the experiments declared in the command tree
+// rooted at the given node, sorted by name.
+//
+// Experiment names are case-insensitive.
+// If the same experiment is declared with different spellings,
+// the first spelling found is used.
+func List(node *kong.Node) []*Experiment {
+	byName := make(map[string]*Experiment)
+	var walk func(*kong.Node)
+	walk = func(node *kong.Node) {
+		if name := node.Tag.Get("experiment"); name != "" {
+			key := strings.ToLower(name)
+			exp, ok := byName[key]
+			if !ok {
+				exp = &Experiment{Name: name}
+				byName[key] = exp
+			}
+			exp.Commands = append(exp.Commands, node)
+		}
+
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	experiments := make([]*Experiment, 0, len(byName))
+	for _, exp := range byName {
+		experiments = append(experiments, exp)
+	}
+	slices.SortFunc(experiments, func(a, b *Experiment) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return experiments
+}
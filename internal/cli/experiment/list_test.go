@@ -0,0 +1,36 @@
+package experiment_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/cli/experiment"
+)
+
+func TestList(t *testing.T) {
+	var cmd struct {
+		Foo struct {
+			Bar mustNotRunCmd `cmd:"" experiment:"zeta"`
+			Baz mustNotRunCmd `cmd:"" experiment:"alpha"`
+		} `cmd:""`
+		Qux   mustNotRunCmd `cmd:"" experiment:"Zeta"`
+		Other mustNotRunCmd `cmd:""`
+	}
+
+	app, err := kong.New(&cmd, kong.Name("my-cli"))
+	require.NoError(t, err)
+
+	experiments := experiment.List(app.Model.Node)
+	require.Len(t, experiments, 2)
+
+	assert.Equal(t, "alpha", experiments[0].Name)
+	require.Len(t, experiments[0].Commands, 1)
+	assert.Equal(t, "my-cli foo baz", experiments[0].Commands[0].FullPath())
+
+	assert.Equal(t, "zeta", experiments[1].Name)
+	require.Len(t, experiments[1].Commands, 2)
+	assert.Equal(t, "my-cli foo bar", experiments[1].Commands[0].FullPath())
+	assert.Equal(t, "my-cli qux", experiments[1].Commands[1].FullPath())
+}
@@ -26,6 +26,15 @@ type apiUpdatePRRequest struct {
 type apiBranchRef struct {
 	Branch apiBranch  `json:"branch"`
 	Commit *apiCommit `json:"commit,omitempty"`
+
+	// Repository is set only if the branch is in a different repository,
+	// e.g. a fork.
+	Repository *apiRepositoryRef `json:"repository,omitempty"`
+}
+
+// apiRepositoryRef references a repository by its full name.
+type apiRepositoryRef struct {
+	FullName string `json:"full_name"`
 }
 
 // apiBranch represents a branch name.
@@ -117,3 +126,15 @@ type apiWorkspaceMemberList struct {
 	Values []apiWorkspaceMember `json:"values"`
 	Next   string               `json:"next,omitempty"`
 }
+
+// apiDiffStat is the diff stat of a single file in a pull request.
+type apiDiffStat struct {
+	LinesAdded   int `json:"lines_added"`
+	LinesRemoved int `json:"lines_removed"`
+}
+
+// apiDiffStatList is the paginated response for a pull request's diff stat.
+type apiDiffStatList struct {
+	Values []apiDiffStat `json:"values"`
+	Next   string        `json:"next,omitempty"`
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/httplog"
 	"go.abhg.dev/gs/internal/silog"
 )
 
@@ -19,16 +22,27 @@ type client struct {
 	log     *silog.Logger
 }
 
-func newClient(baseURL string, token *AuthenticationToken, log *silog.Logger) *client {
+func newClient(
+	baseURL string,
+	token *AuthenticationToken,
+	transport http.RoundTripper, // optional
+	log *silog.Logger,
+) *client {
 	return &client{
 		baseURL: baseURL,
 		token:   token,
-		http:    http.DefaultClient,
+		http:    &http.Client{Transport: httplog.WrapTransport(transport, log)},
 		log:     log,
 	}
 }
 
+// url returns the URL for the given API path.
+// Absolute URLs, such as the "next" links of paginated responses,
+// are returned unchanged.
 func (c *client) url(path string) string {
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		return path
+	}
 	return c.baseURL + path
 }
 
@@ -138,3 +152,11 @@ type apiError struct {
 func (e *apiError) Error() string {
 	return fmt.Sprintf("bitbucket API error (status %d): %s", e.StatusCode, e.Body)
 }
+
+// Is reports whether the error matches target.
+// API errors match the forge error for their status code,
+// e.g. forge.ErrNotFound for 404 responses.
+func (e *apiError) Is(target error) bool {
+	kind := forge.StatusError(e.StatusCode)
+	return kind != nil && kind == target
+}
@@ -0,0 +1,20 @@
+package bitbucket
+
+import (
+	"context"
+
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// ChangeDescription reports the title and description of a pull request.
+func (r *Repository) ChangeDescription(ctx context.Context, id forge.ChangeID) (*forge.ChangeDescription, error) {
+	pr, err := r.getPullRequest(ctx, mustPR(id).Number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forge.ChangeDescription{
+		Subject: pr.Title,
+		Body:    pr.Description,
+	}, nil
+}
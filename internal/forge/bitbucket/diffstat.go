@@ -0,0 +1,34 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// ChangeDiffStat reports the number of lines added and deleted,
+// and the number of files changed by a pull request.
+func (r *Repository) ChangeDiffStat(ctx context.Context, id forge.ChangeID) (*forge.DiffStat, error) {
+	path := fmt.Sprintf(
+		"/repositories/%s/%s/pullrequests/%d/diffstat",
+		r.workspace, r.repo, mustPR(id).Number,
+	)
+
+	var stat forge.DiffStat
+	for path != "" {
+		var resp apiDiffStatList
+		if err := r.client.get(ctx, path, &resp); err != nil {
+			return nil, fmt.Errorf("get diff stat: %w", err)
+		}
+
+		for _, file := range resp.Values {
+			stat.ChangedFiles++
+			stat.Additions += file.LinesAdded
+			stat.Deletions += file.LinesRemoved
+		}
+		path = resp.Next
+	}
+
+	return &stat, nil
+}
@@ -27,7 +27,6 @@ func (r *Repository) EditChange(
 		return err
 	}
 
-	r.warnUnsupportedEditOptions(opts)
 	return nil
 }
 
@@ -113,12 +112,3 @@ func (r *Repository) updatePullRequest(
 	r.log.Debug("Updated pull request", "pr", prID)
 	return nil
 }
-
-func (r *Repository) warnUnsupportedEditOptions(opts forge.EditChangeOptions) {
-	if len(opts.AddLabels) > 0 {
-		r.log.Warn("Bitbucket does not support PR labels; ignoring --label flags")
-	}
-	if len(opts.AddAssignees) > 0 {
-		r.log.Warn("Bitbucket does not support PR assignees; ignoring --assign flags")
-	}
-}
@@ -49,6 +49,55 @@ func (r *Repository) listPRsByBranch(
 	return resp.Values, nil
 }
 
+// FindOpenChangeByHead finds an open pull request
+// by source branch and, optionally, destination branch.
+func (r *Repository) FindOpenChangeByHead(
+	ctx context.Context,
+	req forge.FindOpenChangeByHeadRequest,
+) (*forge.FindChangeItem, error) {
+	headRepo := r.workspace + "/" + r.repo
+	if req.HeadRepository != nil {
+		rid := mustRepositoryID(req.HeadRepository)
+		headRepo = rid.workspace + "/" + rid.name
+	}
+
+	query := fmt.Sprintf(
+		`source.branch.name="%s" AND source.repository.full_name="%s" AND state="%s"`,
+		req.Head, headRepo, stateOpen,
+	)
+	if req.Base != "" {
+		query += fmt.Sprintf(` AND destination.branch.name="%s"`, req.Base)
+	}
+
+	// A second result is enough to tell if the match is unique.
+	pageLen := 1
+	if req.Unique {
+		pageLen = 2
+	}
+
+	path := fmt.Sprintf(
+		"/repositories/%s/%s/pullrequests?q=%s&sort=-updated_on&pagelen=%d&fields=%%2Bvalues.reviewers",
+		r.workspace, r.repo, url.QueryEscape(query), pageLen,
+	)
+
+	var resp apiPRList
+	if err := r.client.get(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("list pull requests: %w", err)
+	}
+	switch {
+	case len(resp.Values) == 0:
+		return nil, forge.ErrNotFound
+	case len(resp.Values) > 1 && req.Unique:
+		matches := make([]*forge.FindChangeItem, len(resp.Values))
+		for i := range resp.Values {
+			matches[i] = r.convertPRToFindItem(&resp.Values[i])
+		}
+		return nil, &forge.AmbiguousChangeError{Changes: matches}
+	default:
+		return r.convertPRToFindItem(&resp.Values[0]), nil
+	}
+}
+
 // FindChangeByID finds a pull request by its ID.
 func (r *Repository) FindChangeByID(
 	ctx context.Context,
@@ -90,6 +139,7 @@ func (r *Repository) convertPRToFindItem(pr *apiPullRequest) *forge.FindChangeIt
 		Subject:   pr.Title,
 		BaseName:  pr.Destination.Branch.Name,
 		HeadHash:  extractHeadHash(pr),
+		HeadName:  pr.Source.Branch.Name,
 		Draft:     pr.Draft,
 		Reviewers: extractUsernames(pr.Reviewers),
 	}
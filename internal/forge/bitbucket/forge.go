@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"go.abhg.dev/gs/internal/forge"
@@ -17,11 +18,17 @@ type Forge struct {
 
 	// Log specifies the logger to use.
 	Log *silog.Logger
+
+	// HTTPMiddleware wraps the transport used for API requests,
+	// e.g. to sign requests.
+	HTTPMiddleware []forge.HTTPMiddleware
 }
 
 var (
 	_ forge.Forge             = (*Forge)(nil)
 	_ forge.WithCommentFormat = (*Forge)(nil)
+	_ forge.WithAPIURL        = (*Forge)(nil)
+	_ forge.WithHTTPTransport = (*Forge)(nil)
 )
 
 func (f *Forge) logger() *silog.Logger {
@@ -43,6 +50,18 @@ func (f *Forge) APIURL() string {
 	return cmp.Or(f.Options.APIURL, DefaultAPIURL)
 }
 
+// HTTPTransport returns the transport used for API requests.
+func (f *Forge) HTTPTransport() (http.RoundTripper, error) {
+	return (&forge.HTTPOptions{
+		Headers:    f.Options.HTTPHeaders,
+		ClientCert: f.Options.ClientCert,
+		ClientKey:  f.Options.ClientKey,
+		Proxy:      f.Options.Proxy,
+		CABundle:   f.Options.CABundle,
+		Middleware: f.HTTPMiddleware,
+	}).Transport(nil)
+}
+
 // ID reports a unique key for this forge.
 func (*Forge) ID() string { return "bitbucket" }
 
@@ -100,7 +119,12 @@ func (f *Forge) OpenRepository(
 	rid := mustRepositoryID(id)
 	tok := token.(*AuthenticationToken)
 
-	client := newClient(f.APIURL(), tok, f.logger())
+	transport, err := f.HTTPTransport()
+	if err != nil {
+		return nil, fmt.Errorf("configure HTTP transport: %w", err)
+	}
+
+	client := newClient(f.APIURL(), tok, transport, f.logger())
 	return newRepository(f, rid.url, rid.workspace, rid.name, f.logger(), client), nil
 }
 
@@ -204,3 +204,22 @@ func TestForge_ChangeTemplatePaths(t *testing.T) {
 	assert.NotEmpty(t, paths)
 	assert.Contains(t, paths, "PULL_REQUEST_TEMPLATE.md")
 }
+
+func TestForge_FormatChangeID(t *testing.T) {
+	var f Forge
+	assert.Equal(t, "PR #42", f.FormatChangeID(&PR{Number: 42}))
+}
+
+func TestForge_ParseChangeID(t *testing.T) {
+	var f Forge
+	for _, give := range []string{
+		"42",
+		"#42",
+		"PR #42",
+		"https://bitbucket.org/example/repo/pull-requests/42",
+	} {
+		got, err := f.ParseChangeID(give)
+		require.NoError(t, err, "input: %q", give)
+		assert.Equal(t, &PR{Number: 42}, got, "input: %q", give)
+	}
+}
@@ -1,9 +1,11 @@
 package bitbucket
 
 import (
+	"cmp"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path"
 	"regexp"
 	"testing"
 
@@ -170,6 +172,99 @@ func TestFindChangesByBranch(t *testing.T) {
 	}
 }
 
+func TestFindOpenChangeByHead(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         forge.FindOpenChangeByHeadRequest
+		prs         []apiPullRequest
+		wantQuery   string
+		wantPageLen string
+		wantID      int64 // 0 if not found
+		ambiguous   bool
+	}{
+		{
+			name: "SameRepository",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Base: "main"},
+			prs: []apiPullRequest{
+				{ID: 7, Title: "Feature", State: stateOpen},
+			},
+			wantQuery: `source.branch.name="feature" AND source.repository.full_name="workspace/repo" AND state="OPEN" AND destination.branch.name="main"`,
+			wantID:    7,
+		},
+		{
+			name: "Fork",
+			req: forge.FindOpenChangeByHeadRequest{
+				Head: "feature",
+				HeadRepository: &RepositoryID{
+					workspace: "contributor",
+					name:      "repo",
+				},
+			},
+			prs: []apiPullRequest{
+				{ID: 8, Title: "Feature", State: stateOpen},
+			},
+			wantQuery: `source.branch.name="feature" AND source.repository.full_name="contributor/repo" AND state="OPEN"`,
+			wantID:    8,
+		},
+		{
+			name:      "NotFound",
+			req:       forge.FindOpenChangeByHeadRequest{Head: "feature", Base: "main"},
+			wantQuery: `source.branch.name="feature" AND source.repository.full_name="workspace/repo" AND state="OPEN" AND destination.branch.name="main"`,
+		},
+		{
+			name: "Unique",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Unique: true},
+			prs: []apiPullRequest{
+				{ID: 9, Title: "Feature", State: stateOpen},
+			},
+			wantQuery:   `source.branch.name="feature" AND source.repository.full_name="workspace/repo" AND state="OPEN"`,
+			wantPageLen: "2",
+			wantID:      9,
+		},
+		{
+			name: "Ambiguous",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Unique: true},
+			prs: []apiPullRequest{
+				{ID: 9, Title: "Feature", State: stateOpen},
+				{ID: 7, Title: "Feature", State: stateOpen},
+			},
+			wantQuery:   `source.branch.name="feature" AND source.repository.full_name="workspace/repo" AND state="OPEN"`,
+			wantPageLen: "2",
+			ambiguous:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantQuery, r.URL.Query().Get("q"))
+				assert.Equal(t, cmp.Or(tt.wantPageLen, "1"), r.URL.Query().Get("pagelen"))
+
+				resp := apiPRList{Values: tt.prs}
+				assert.NoError(t, json.NewEncoder(w).Encode(resp))
+			}))
+			defer srv.Close()
+
+			repo := newTestRepository(srv.URL)
+
+			item, err := repo.FindOpenChangeByHead(t.Context(), tt.req)
+			if tt.ambiguous {
+				var ambiguousErr *forge.AmbiguousChangeError
+				require.ErrorAs(t, err, &ambiguousErr)
+				assert.Len(t, ambiguousErr.Changes, len(tt.prs))
+				return
+			}
+			if tt.wantID == 0 {
+				assert.ErrorIs(t, err, forge.ErrNotFound)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, &PR{Number: tt.wantID}, item.ID)
+		})
+	}
+}
+
 func TestFindChangeByID(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Contains(t, r.URL.Path, "/pullrequests/42")
@@ -260,6 +355,50 @@ func TestChangesStates(t *testing.T) {
 	}
 }
 
+func TestChangesDetails(t *testing.T) {
+	prs := map[string]apiPullRequest{
+		"1": {
+			ID:          1,
+			State:       stateOpen,
+			Destination: apiBranchRef{Branch: apiBranch{Name: "main"}},
+		},
+		"2": {
+			ID:          2,
+			State:       stateMerged,
+			Destination: apiBranchRef{Branch: apiBranch{Name: "main"}},
+			MergeCommit: &apiCommit{Hash: "abc123"},
+		},
+		"3": {
+			ID:          3,
+			State:       stateDeclined,
+			Destination: apiBranchRef{Branch: apiBranch{Name: "feature"}},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pr, ok := prs[path.Base(r.URL.Path)]
+		if !assert.True(t, ok, "unexpected request: %v", r.URL) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(pr))
+	}))
+	defer srv.Close()
+
+	repo := newTestRepository(srv.URL)
+	details, err := repo.ChangesDetails(t.Context(), []forge.ChangeID{
+		&PR{Number: 1},
+		&PR{Number: 2},
+		&PR{Number: 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []forge.ChangeDetails{
+		{State: forge.ChangeOpen, BaseName: "main"},
+		{State: forge.ChangeMerged, BaseName: "main", MergeCommit: "abc123"},
+		{State: forge.ChangeClosed, BaseName: "feature"},
+	}, details)
+}
+
 func TestSubmitChange(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Handle workspace members lookup for reviewer resolution.
@@ -307,6 +446,42 @@ func TestSubmitChange(t *testing.T) {
 	assert.Equal(t, "https://example.com/pr/123", result.URL)
 }
 
+func TestSubmitChange_fork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/workspace/repo/pullrequests", r.URL.Path)
+
+		var req apiCreatePRRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "feature", req.Source.Branch.Name)
+		if assert.NotNil(t, req.Source.Repository) {
+			assert.Equal(t, "contributor/repo", req.Source.Repository.FullName)
+		}
+		assert.Nil(t, req.Destination.Repository)
+
+		resp := apiPullRequest{
+			ID:    123,
+			Title: req.Title,
+			Links: apiPRLinks{HTML: apiLink{Href: "https://example.com/pr/123"}},
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	repo := newTestRepository(srv.URL)
+
+	_, err := repo.SubmitChange(t.Context(), forge.SubmitChangeRequest{
+		Subject: "Test PR",
+		Head:    "feature",
+		Base:    "main",
+		HeadRepository: &RepositoryID{
+			url:       srv.URL,
+			workspace: "contributor",
+			name:      "repo",
+		},
+	})
+	require.NoError(t, err)
+}
+
 func TestEditChange(t *testing.T) {
 	tests := []struct {
 		name string
@@ -406,7 +581,83 @@ func TestStateToAPI(t *testing.T) {
 	}
 }
 
+func TestRepository_Capabilities(t *testing.T) {
+	caps := newTestRepository("https://bitbucket.org").Capabilities()
+	assert.Equal(t, forge.Capabilities{
+		Draft:              true,
+		Reviewers:          true,
+		MergeStrategies:    []forge.MergeStrategy{forge.MergeCommit, forge.SquashMerge},
+		RejectsMissingBase: true,
+	}, caps)
+}
+
+func TestChangeDiffStat(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/workspace/repo/pullrequests/42/diffstat", r.URL.Path)
+
+		var resp apiDiffStatList
+		switch r.URL.Query().Get("page") {
+		case "":
+			resp = apiDiffStatList{
+				Values: []apiDiffStat{
+					{LinesAdded: 10, LinesRemoved: 2},
+					{LinesAdded: 0, LinesRemoved: 5},
+				},
+				Next: srvURL + r.URL.Path + "?page=2",
+			}
+		case "2":
+			resp = apiDiffStatList{
+				Values: []apiDiffStat{
+					{LinesAdded: 3, LinesRemoved: 0},
+				},
+			}
+		default:
+			t.Errorf("unexpected page: %v", r.URL)
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	repo := newTestRepository(srv.URL)
+	stat, err := repo.ChangeDiffStat(t.Context(), &PR{Number: 42})
+	require.NoError(t, err)
+	assert.Equal(t, &forge.DiffStat{
+		Additions:    13,
+		Deletions:    7,
+		ChangedFiles: 3,
+	}, stat)
+}
+
 func newTestRepository(baseURL string) *Repository {
-	client := newClient(baseURL, &AuthenticationToken{AccessToken: "test"}, silog.Nop())
+	client := newClient(baseURL, &AuthenticationToken{AccessToken: "test"}, nil, silog.Nop())
 	return newRepository(&Forge{}, baseURL, "workspace", "repo", silog.Nop(), client)
 }
+
+func TestAPIErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, forge.ErrUnauthorized},
+		{http.StatusForbidden, forge.ErrPermission},
+		{http.StatusNotFound, forge.ErrNotFound},
+		{http.StatusConflict, forge.ErrConflict},
+		{http.StatusTooManyRequests, forge.ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			repo := newTestRepository(srv.URL)
+			_, err := repo.FindChangeByID(t.Context(), &PR{Number: 1})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -23,4 +23,17 @@ type Options struct {
 	// Token is a fixed token used to authenticate with Bitbucket.
 	// This may be used to skip the login flow.
 	Token string `name:"bitbucket-token" hidden:"" env:"BITBUCKET_TOKEN" help:"Bitbucket API token"`
+
+	// HTTPHeaders, ClientCert, and ClientKey configure API requests
+	// for instances behind authenticating proxies.
+	// See [forge.HTTPOptions] for details.
+	HTTPHeaders []string `name:"bitbucket-http-header" hidden:"" config:"forge.bitbucket.httpHeader" sep:"\n" placeholder:"NAME: VALUE" help:"Additional header to send with Bitbucket API requests. May be repeated."`
+	ClientCert  string   `name:"bitbucket-client-cert" hidden:"" config:"forge.bitbucket.clientCert" placeholder:"FILE" help:"PEM-encoded client certificate to present to Bitbucket"`
+	ClientKey   string   `name:"bitbucket-client-key" hidden:"" config:"forge.bitbucket.clientKey" placeholder:"FILE" help:"PEM-encoded private key for the client certificate"`
+
+	// Proxy and CABundle configure API requests for networks
+	// that route traffic through a proxy, or intercept TLS connections.
+	// See [forge.HTTPOptions] for details.
+	Proxy    string `name:"bitbucket-proxy" hidden:"" config:"forge.bitbucket.proxy" env:"BITBUCKET_PROXY" placeholder:"URL" help:"Proxy to use for Bitbucket API requests. Defaults to HTTPS_PROXY."`
+	CABundle string `name:"bitbucket-ca-bundle" hidden:"" config:"forge.bitbucket.caBundle" env:"BITBUCKET_CA_BUNDLE" placeholder:"FILE" help:"PEM-encoded certificates of additional certificate authorities to trust for Bitbucket API requests"`
 }
@@ -3,6 +3,7 @@ package bitbucket
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/silog"
@@ -16,11 +17,16 @@ type Repository struct {
 	workspace, repo string
 	log             *silog.Logger
 	forge           *Forge
+	cache           forge.Cache // optional
+
+	usersMu sync.Mutex
+	users   map[string]string // lowercase reviewer identifier => UUID
 }
 
 var (
 	_ forge.Repository    = (*Repository)(nil)
 	_ forge.WithChangeURL = (*Repository)(nil)
+	_ forge.WithCache     = (*Repository)(nil)
 )
 
 func newRepository(
@@ -39,9 +45,25 @@ func newRepository(
 	}
 }
 
+// SetCache sets the cache used to remember
+// the accounts of reviewers between invocations.
+func (r *Repository) SetCache(cache forge.Cache) { r.cache = cache }
+
 // Forge returns the forge this repository belongs to.
 func (r *Repository) Forge() forge.Forge { return r.forge }
 
+// Capabilities reports the features supported by Bitbucket.
+// Bitbucket does not support labels, assignees, team reviewers,
+// or auto-merge on pull requests.
+func (r *Repository) Capabilities() forge.Capabilities {
+	return forge.Capabilities{
+		Draft:              true,
+		Reviewers:          true,
+		MergeStrategies:    []forge.MergeStrategy{forge.MergeCommit, forge.SquashMerge},
+		RejectsMissingBase: true,
+	}
+}
+
 // ChangeURL returns the web URL for viewing the given pull request.
 func (r *Repository) ChangeURL(id forge.ChangeID) string {
 	prNum := mustPR(id).Number
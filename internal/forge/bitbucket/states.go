@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
 )
 
 // Bitbucket PR states.
@@ -20,23 +21,37 @@ func (r *Repository) ChangesStates(
 	ctx context.Context,
 	ids []forge.ChangeID,
 ) ([]forge.ChangeState, error) {
-	states := make([]forge.ChangeState, len(ids))
-	for i, id := range ids {
-		state, err := r.getChangeState(ctx, mustPR(id).Number)
-		if err != nil {
-			return nil, fmt.Errorf("get state for PR #%d: %w", mustPR(id).Number, err)
-		}
-		states[i] = state
+	details, err := r.ChangesDetails(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]forge.ChangeState, len(details))
+	for i, d := range details {
+		states[i] = d.State
 	}
 	return states, nil
 }
 
-func (r *Repository) getChangeState(ctx context.Context, prID int64) (forge.ChangeState, error) {
-	pr, err := r.getPullRequest(ctx, prID)
-	if err != nil {
-		return 0, err
+// ChangesDetails retrieves the states of multiple pull requests,
+// along with the merge commits of merged pull requests.
+func (r *Repository) ChangesDetails(
+	ctx context.Context,
+	ids []forge.ChangeID,
+) ([]forge.ChangeDetails, error) {
+	details := make([]forge.ChangeDetails, len(ids))
+	for i, id := range ids {
+		pr, err := r.getPullRequest(ctx, mustPR(id).Number)
+		if err != nil {
+			return nil, fmt.Errorf("get state for PR #%d: %w", mustPR(id).Number, err)
+		}
+		details[i].State = stateFromAPI(pr.State)
+		details[i].BaseName = pr.Destination.Branch.Name
+		if details[i].State == forge.ChangeMerged && pr.MergeCommit != nil {
+			details[i].MergeCommit = git.Hash(pr.MergeCommit.Hash)
+		}
 	}
-	return stateFromAPI(pr.State), nil
+	return details, nil
 }
 
 func stateFromAPI(state string) forge.ChangeState {
@@ -122,3 +122,21 @@ func (*Forge) UnmarshalChangeID(data json.RawMessage) (forge.ChangeID, error) {
 	}
 	return &id, nil
 }
+
+// FormatChangeID formats a PR for display, e.g. "PR #123".
+func (*Forge) FormatChangeID(id forge.ChangeID) string {
+	if pr, ok := id.(*PR); ok {
+		return fmt.Sprintf("PR #%d", pr.Number)
+	}
+	return id.String()
+}
+
+// ParseChangeID parses a PR number ("123", "#123", or "PR #123")
+// or a pull request URL.
+func (*Forge) ParseChangeID(s string) (forge.ChangeID, error) {
+	num, err := forge.ParseChangeNumber(s, []string{"PR #", "#"}, []string{"pull-requests"})
+	if err != nil {
+		return nil, err
+	}
+	return &PR{Number: num}, nil
+}
@@ -0,0 +1,17 @@
+package bitbucket
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+)
+
+// CurrentUser reports the username of the authenticated user,
+// or their nickname if the account has no username.
+func (r *Repository) CurrentUser(ctx context.Context) (string, error) {
+	var user apiUser
+	if err := r.client.get(ctx, "/user", &user); err != nil {
+		return "", fmt.Errorf("get current user: %w", err)
+	}
+	return cmp.Or(user.Username, user.Nickname), nil
+}
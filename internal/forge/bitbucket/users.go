@@ -0,0 +1,261 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// resolveReviewerUUIDs resolves reviewers to the UUIDs of their accounts.
+// Reviewers may be identified by nickname, account ID, or email address.
+//
+// Resolved UUIDs are remembered for the lifetime of the repository,
+// and in the repository's cache if one was set with SetCache.
+func (r *Repository) resolveReviewerUUIDs(
+	ctx context.Context,
+	identifiers []string,
+) ([]apiReviewer, error) {
+	if len(identifiers) == 0 {
+		return nil, nil
+	}
+
+	r.usersMu.Lock()
+	defer r.usersMu.Unlock()
+
+	if r.users == nil {
+		r.users = r.loadCachedUsers(ctx)
+	}
+
+	var missing []string
+	seen := make(map[string]struct{})
+	for _, id := range identifiers {
+		key := strings.ToLower(id)
+		if _, ok := r.users[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		missing = append(missing, id)
+	}
+
+	if len(missing) > 0 {
+		found, err := r.lookupUsers(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for id, uuid := range found {
+			r.users[strings.ToLower(id)] = uuid
+		}
+		r.saveCachedUsers(ctx)
+	}
+
+	reviewers := make([]apiReviewer, 0, len(identifiers))
+	for _, id := range identifiers {
+		uuid := r.users[strings.ToLower(id)]
+		reviewers = append(reviewers, apiReviewer{UUID: uuid})
+		r.log.Debug("Resolved reviewer", "reviewer", id, "uuid", uuid)
+	}
+	return reviewers, nil
+}
+
+// usersCacheKey is the key under which resolved users
+// are stored in the repository's cache.
+func (r *Repository) usersCacheKey() string {
+	return "users/" + r.workspace
+}
+
+// loadCachedUsers loads the users resolved in previous invocations.
+// It returns an empty map if there's no cache.
+func (r *Repository) loadCachedUsers(ctx context.Context) map[string]string {
+	users := make(map[string]string)
+	if r.cache == nil {
+		return users
+	}
+
+	if err := r.cache.Get(ctx, r.usersCacheKey(), &users); err != nil {
+		if !errors.Is(err, forge.ErrNotFound) {
+			r.log.Warn("Could not load cached users", "error", err)
+		}
+		return make(map[string]string)
+	}
+	return users
+}
+
+func (r *Repository) saveCachedUsers(ctx context.Context) {
+	if r.cache == nil {
+		return
+	}
+
+	if err := r.cache.Set(ctx, r.usersCacheKey(), r.users); err != nil {
+		r.log.Warn("Could not cache users", "error", err)
+	}
+}
+
+// lookupUsers looks up the UUIDs of the given users.
+// It returns a map from identifier to UUID.
+//
+// Email addresses are looked up one request at a time.
+// Other identifiers are matched against workspace members
+// in a single pass over the member list.
+func (r *Repository) lookupUsers(ctx context.Context, identifiers []string) (map[string]string, error) {
+	found := make(map[string]string, len(identifiers))
+
+	var others []string
+	for _, id := range identifiers {
+		if !isEmail(id) {
+			others = append(others, id)
+			continue
+		}
+
+		user, err := r.findWorkspaceMemberByEmail(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("lookup user %q: %w", id, err)
+		}
+		found[id] = user.UUID
+	}
+
+	if len(others) == 0 {
+		return found, nil
+	}
+
+	matches, err := r.scanWorkspaceMembers(ctx, others)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, id := range others {
+		user, err := r.selectUniqueMatch(id, matches[id])
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("lookup user %q: %w", id, err))
+		case user == nil && isAccountID(id):
+			errs = append(errs, fmt.Errorf("account_id %q not found in workspace %q", id, r.workspace))
+		case user == nil:
+			errs = append(errs, fmt.Errorf("user %q not found in workspace %q", id, r.workspace))
+		default:
+			found[id] = user.UUID
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// scanWorkspaceMembers lists workspace members once,
+// collecting the members that match each of the given identifiers.
+func (r *Repository) scanWorkspaceMembers(
+	ctx context.Context,
+	identifiers []string,
+) (map[string][]apiUser, error) {
+	matches := make(map[string][]apiUser, len(identifiers))
+	path := fmt.Sprintf("/workspaces/%s/members", r.workspace)
+	for path != "" {
+		var resp apiWorkspaceMemberList
+		if err := r.client.get(ctx, path, &resp); err != nil {
+			return nil, fmt.Errorf("list workspace members: %w", err)
+		}
+
+		for _, member := range resp.Values {
+			for _, id := range identifiers {
+				if matchesIdentifier(&member.User, id) {
+					matches[id] = append(matches[id], member.User)
+				}
+			}
+		}
+		path = resp.Next
+	}
+	return matches, nil
+}
+
+// findWorkspaceMemberByEmail finds the workspace member
+// with the given email address.
+//
+// Bitbucket only allows workspace administrators
+// to filter members by email address.
+func (r *Repository) findWorkspaceMemberByEmail(ctx context.Context, email string) (*apiUser, error) {
+	query := url.Values{"q": {fmt.Sprintf("user.email=%q", email)}}
+	path := fmt.Sprintf("/workspaces/%s/members?%s", r.workspace, query.Encode())
+
+	var resp apiWorkspaceMemberList
+	if err := r.client.get(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("list workspace members: %w", err)
+	}
+
+	switch len(resp.Values) {
+	case 0:
+		return nil, fmt.Errorf("email %q not found in workspace %q"+
+			" (looking up users by email requires workspace admin access)", email, r.workspace)
+	case 1:
+		return &resp.Values[0].User, nil
+	default:
+		return nil, fmt.Errorf("multiple users match email %q", email)
+	}
+}
+
+func (r *Repository) selectUniqueMatch(
+	nickname string,
+	matches []apiUser,
+) (*apiUser, error) {
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &ambiguousUserError{Nickname: nickname, Matches: matches}
+	}
+}
+
+// matchesIdentifier checks if the user is identified by
+// the given account ID or nickname.
+func matchesIdentifier(user *apiUser, id string) bool {
+	if isAccountID(id) {
+		return user.AccountID == id
+	}
+	return matchesNickname(user, id)
+}
+
+// matchesNickname checks if the user matches the given nickname.
+// It checks Username first (for backward compatibility), then Nickname
+// (since Bitbucket deprecated usernames in favor of account IDs).
+func matchesNickname(user *apiUser, nickname string) bool {
+	if user.Username != "" && strings.EqualFold(user.Username, nickname) {
+		return true
+	}
+	return strings.EqualFold(user.Nickname, nickname)
+}
+
+// isAccountID checks if the identifier looks like a Bitbucket account ID.
+// Account IDs have the format "number:uuid" (e.g., "712020:f766d886-...").
+func isAccountID(identifier string) bool {
+	return strings.Contains(identifier, ":")
+}
+
+// isEmail checks if the identifier looks like an email address.
+func isEmail(identifier string) bool {
+	return strings.Contains(identifier, "@")
+}
+
+// ambiguousUserError indicates multiple workspace members match the nickname.
+type ambiguousUserError struct {
+	Nickname string
+	Matches  []apiUser
+}
+
+func (e *ambiguousUserError) Error() string {
+	var ids []string
+	for _, u := range e.Matches {
+		ids = append(ids, u.AccountID)
+	}
+	return fmt.Sprintf(
+		"multiple users match %q: %v (use account_id to disambiguate)",
+		e.Nickname, ids)
+}
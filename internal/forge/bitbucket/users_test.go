@@ -0,0 +1,158 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+func TestResolveReviewerUUIDs_batch(t *testing.T) {
+	var requests int
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/workspaces/workspace/members", r.URL.Path)
+
+		var resp apiWorkspaceMemberList
+		if r.URL.Query().Get("page") == "" {
+			resp.Values = []apiWorkspaceMember{
+				{User: apiUser{UUID: "{alice}", Nickname: "alice"}},
+				{User: apiUser{UUID: "{bob}", Username: "bob"}},
+			}
+			resp.Next = srv.URL + "/workspaces/workspace/members?page=2"
+		} else {
+			resp.Values = []apiWorkspaceMember{
+				{User: apiUser{UUID: "{carol}", Nickname: "carol", AccountID: "123:carol"}},
+			}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	repo := newTestRepository(srv.URL)
+	got, err := repo.resolveReviewerUUIDs(t.Context(), []string{"Alice", "bob", "123:carol", "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, []apiReviewer{
+		{UUID: "{alice}"},
+		{UUID: "{bob}"},
+		{UUID: "{carol}"},
+		{UUID: "{alice}"},
+	}, got)
+	assert.Equal(t, 2, requests, "member list should be scanned once")
+
+	// Resolved users are remembered.
+	_, err = repo.resolveReviewerUUIDs(t.Context(), []string{"bob"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestResolveReviewerUUIDs_email(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workspaces/workspace/members", r.URL.Path)
+
+		var resp apiWorkspaceMemberList
+		if r.URL.Query().Get("q") == `user.email="alice@example.com"` {
+			resp.Values = []apiWorkspaceMember{
+				{User: apiUser{UUID: "{alice}", Nickname: "alice"}},
+			}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	repo := newTestRepository(srv.URL)
+	got, err := repo.resolveReviewerUUIDs(t.Context(), []string{"alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []apiReviewer{{UUID: "{alice}"}}, got)
+
+	_, err = repo.resolveReviewerUUIDs(t.Context(), []string{"bob@example.com"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `email "bob@example.com" not found`)
+	assert.ErrorContains(t, err, "workspace admin")
+}
+
+func TestResolveReviewerUUIDs_cache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := apiWorkspaceMemberList{
+			Values: []apiWorkspaceMember{
+				{User: apiUser{UUID: "{bob}", Nickname: "bob"}},
+			},
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	cache := mapCache{
+		"users/workspace": `{"alice":"{alice}"}`,
+	}
+
+	repo := newTestRepository(srv.URL)
+	repo.SetCache(cache)
+
+	got, err := repo.resolveReviewerUUIDs(t.Context(), []string{"alice", "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, []apiReviewer{{UUID: "{alice}"}, {UUID: "{bob}"}}, got)
+	assert.JSONEq(t, `{"alice":"{alice}","bob":"{bob}"}`, cache["users/workspace"])
+
+	t.Run("Hit", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			t.Errorf("unexpected request")
+		}))
+		defer srv.Close()
+
+		repo := newTestRepository(srv.URL)
+		repo.SetCache(cache)
+
+		got, err := repo.resolveReviewerUUIDs(t.Context(), []string{"Bob"})
+		require.NoError(t, err)
+		assert.Equal(t, []apiReviewer{{UUID: "{bob}"}}, got)
+	})
+}
+
+func TestResolveReviewerUUIDs_errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := apiWorkspaceMemberList{
+			Values: []apiWorkspaceMember{
+				{User: apiUser{UUID: "{1}", Nickname: "sam", AccountID: "1:sam"}},
+				{User: apiUser{UUID: "{2}", Nickname: "Sam", AccountID: "2:sam"}},
+			},
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	repo := newTestRepository(srv.URL)
+	_, err := repo.resolveReviewerUUIDs(t.Context(), []string{"sam", "nobody", "3:nobody"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `multiple users match "sam": [1:sam 2:sam]`)
+	assert.ErrorContains(t, err, `user "nobody" not found in workspace "workspace"`)
+	assert.ErrorContains(t, err, `account_id "3:nobody" not found in workspace "workspace"`)
+}
+
+// mapCache is a [forge.Cache] that stores JSON-encoded values in a map.
+type mapCache map[string]string
+
+var _ forge.Cache = mapCache(nil)
+
+func (c mapCache) Get(_ context.Context, key string, v any) error {
+	s, ok := c[key]
+	if !ok {
+		return forge.ErrNotFound
+	}
+	return json.Unmarshal([]byte(s), v)
+}
+
+func (c mapCache) Set(_ context.Context, key string, v any) error {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c[key] = string(bs)
+	return nil
+}
@@ -0,0 +1,44 @@
+package forge
+
+import "net/http"
+
+// StatusError returns the error reported by forge APIs
+// that corresponds to the given HTTP status code.
+// It returns nil if there's no such error.
+func StatusError(code int) error {
+	switch code {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrPermission
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// WrapError returns an error that matches kind with errors.Is
+// in addition to everything that err matches.
+// The returned error has the same message as err.
+//
+// It returns err unchanged if kind is nil.
+func WrapError(err, kind error) error {
+	if err == nil || kind == nil {
+		return err
+	}
+	return &kindError{err: err, kind: kind}
+}
+
+type kindError struct {
+	err  error
+	kind error
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() []error { return []error{e.err, e.kind} }
@@ -0,0 +1,50 @@
+package forge_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		code int
+		want error
+	}{
+		{http.StatusUnauthorized, forge.ErrUnauthorized},
+		{http.StatusForbidden, forge.ErrPermission},
+		{http.StatusNotFound, forge.ErrNotFound},
+		{http.StatusConflict, forge.ErrConflict},
+		{http.StatusTooManyRequests, forge.ErrRateLimited},
+		{http.StatusInternalServerError, nil},
+		{http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.code), func(t *testing.T) {
+			assert.Equal(t, tt.want, forge.StatusError(tt.code))
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	base := errors.New("great sadness")
+	err := forge.WrapError(fmt.Errorf("do thing: %w", base), forge.ErrRateLimited)
+
+	assert.EqualError(t, err, "do thing: great sadness")
+	assert.ErrorIs(t, err, base)
+	assert.ErrorIs(t, err, forge.ErrRateLimited)
+	assert.NotErrorIs(t, err, forge.ErrNotFound)
+
+	t.Run("NilKind", func(t *testing.T) {
+		assert.Same(t, base, forge.WrapError(base, nil))
+	})
+
+	t.Run("NilError", func(t *testing.T) {
+		assert.NoError(t, forge.WrapError(nil, forge.ErrConflict))
+	})
+}
@@ -8,7 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"net/url"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 
 	"go.abhg.dev/gs/internal/git"
@@ -51,6 +56,15 @@ func (r *Registry) Lookup(id string) (Forge, bool) {
 	return f.(Forge), true
 }
 
+// FormatChangeID formats the change ID in the given metadata
+// for display to users, following the conventions of the forge
+// that the change belongs to.
+// If that forge is not registered, it falls back to the ID's String method.
+func (r *Registry) FormatChangeID(md ChangeMetadata) string {
+	f, _ := r.Lookup(md.ForgeID())
+	return FormatChangeID(f, md.ChangeID())
+}
+
 // MatchRemoteURL attempts to match the given remote URL with a registered forge.
 // Returns the matched forge, and information about the matched repository.
 func MatchRemoteURL(r *Registry, remoteURL string) (forge Forge, rid RepositoryID, ok bool) {
@@ -70,6 +84,44 @@ var ErrUnsupportedURL = errors.New("unsupported URL")
 // ErrNotFound indicates that a requested resource does not exist.
 var ErrNotFound = errors.New("not found")
 
+// Errors reported by forge APIs.
+//
+// Forge implementations map their API errors to these
+// (and to [ErrNotFound]) so that callers may match them with errors.Is
+// regardless of the forge in use.
+// See [StatusError] and [WrapError].
+var (
+	// ErrUnauthorized indicates that the forge rejected
+	// the authentication token, e.g. because it expired.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrPermission indicates that the user is authenticated
+	// but isn't allowed to perform the operation.
+	ErrPermission = errors.New("permission denied")
+
+	// ErrRateLimited indicates that the forge rejected the request
+	// because too many requests were made recently.
+	// The request may succeed if it's tried again later.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrConflict indicates that the request conflicts
+	// with the current state of the resource,
+	// e.g. a change request for the branch already exists.
+	ErrConflict = errors.New("conflict")
+)
+
+// AmbiguousChangeError indicates that more than one change request
+// matched a search that expected at most one.
+type AmbiguousChangeError struct {
+	// Changes are the matching changes, most recently updated first.
+	// This may not list every match.
+	Changes []*FindChangeItem
+}
+
+func (e *AmbiguousChangeError) Error() string {
+	return fmt.Sprintf("found %d or more matching changes", len(e.Changes))
+}
+
 // ErrCommentCannotUpdate indicates that an existing comment cannot be updated.
 // This typically occurs when local state is missing required information
 // (e.g., PR ID for Bitbucket comments).
@@ -145,6 +197,16 @@ type Forge interface {
 	// UnmarshalChangeID deserializes the given JSON blob into a change ID.
 	UnmarshalChangeID(json.RawMessage) (ChangeID, error)
 
+	// FormatChangeID formats the given change ID for display to users
+	// following the forge's conventions.
+	// For example, "#123" for a GitHub PR, or "!123" for a GitLab MR.
+	FormatChangeID(ChangeID) string
+
+	// ParseChangeID parses a user-provided reference to a change.
+	// This may be a change number, an ID formatted with FormatChangeID,
+	// or the web URL of the change.
+	ParseChangeID(string) (ChangeID, error)
+
 	// MarshalChangeMetadata serializes the given change metadata
 	// into a valid JSON blob.
 	MarshalChangeMetadata(ChangeMetadata) (json.RawMessage, error)
@@ -194,6 +256,18 @@ func GetDisplayName(f Forge) string {
 	return f.ID()
 }
 
+// WithAPIURL is an optional interface that forges can implement
+// to report the base URL of their API.
+// If implemented, the API is checked for reachability
+// before commands that need the forge,
+// so that network problems are reported early.
+type WithAPIURL interface {
+	Forge
+
+	// APIURL returns the base URL of the forge's API.
+	APIURL() string
+}
+
 // AuthenticationToken is a secret that results from a successful login.
 // It will be persisted in a safe place,
 // and re-used for future authentication with the forge.
@@ -235,9 +309,30 @@ type Repository interface {
 
 	EditChange(ctx context.Context, id ChangeID, opts EditChangeOptions) error
 	FindChangesByBranch(ctx context.Context, branch string, opts FindChangesOptions) ([]*FindChangeItem, error)
+
+	// FindOpenChangeByHead finds an open change request
+	// that proposes merging the given head branch.
+	// If more than one change matches,
+	// the most recently updated one is returned
+	// unless FindOpenChangeByHeadRequest.Unique is set.
+	//
+	// Returns ErrNotFound if there is no such change.
+	FindOpenChangeByHead(ctx context.Context, req FindOpenChangeByHeadRequest) (*FindChangeItem, error)
+
 	FindChangeByID(ctx context.Context, id ChangeID) (*FindChangeItem, error)
 	ChangesStates(ctx context.Context, ids []ChangeID) ([]ChangeState, error)
 
+	// ChangesDetails is like ChangesStates,
+	// but also reports how merged changes were merged.
+	ChangesDetails(ctx context.Context, ids []ChangeID) ([]ChangeDetails, error)
+
+	// ChangeDiffStat reports the size of the given change:
+	// the number of lines added and deleted, and the number of files changed.
+	ChangeDiffStat(ctx context.Context, id ChangeID) (*DiffStat, error)
+
+	// ChangeDescription reports the current title and body of a change.
+	ChangeDescription(ctx context.Context, id ChangeID) (*ChangeDescription, error)
+
 	// Post, update, and delete comments on changes.
 	PostChangeComment(context.Context, ChangeID, string) (ChangeCommentID, error)
 	UpdateChangeComment(context.Context, ChangeCommentID, string) error
@@ -257,6 +352,18 @@ type Repository interface {
 	//
 	// Returns an empty list if no templates are found.
 	ListChangeTemplates(context.Context) ([]*ChangeTemplate, error)
+
+	// CurrentUser reports the username of the user
+	// that the repository was opened as.
+	CurrentUser(ctx context.Context) (string, error)
+
+	// Capabilities reports the optional features
+	// supported by the repository.
+	// Callers use this to adapt their behavior
+	// instead of relying on the forge to ignore unsupported requests.
+	//
+	// It must not make network requests.
+	Capabilities() Capabilities
 }
 
 // WithChangeURL is an optional interface that repositories can implement
@@ -270,11 +377,241 @@ type WithChangeURL interface {
 	ChangeURL(id ChangeID) string
 }
 
+// WithReviewThreads is an optional interface that repositories can implement
+// if reviewers may leave threads of comments on lines of a change
+// that are resolved once addressed.
+type WithReviewThreads interface {
+	Repository
+
+	// ListChangeThreads lists review threads on a change
+	// in the order they were started.
+	ListChangeThreads(context.Context, ChangeID) iter.Seq2[*ChangeThread, error]
+
+	// ResolveThread marks a review thread as resolved,
+	// or as unresolved if resolved is false.
+	ResolveThread(ctx context.Context, id ChangeThreadID, resolved bool) error
+}
+
+// ChangeThreadID is a unique identifier for a review thread on a change.
+type ChangeThreadID interface {
+	String() string
+}
+
+// ChangeThread is a thread of review comments on a change.
+type ChangeThread struct {
+	ID ChangeThreadID // required
+
+	// Path is the path to the file the thread is on,
+	// relative to the root of the repository.
+	// This is empty for threads that aren't on a file.
+	Path string
+
+	// Line is the line in the file that the thread is on.
+	// This is zero if the thread isn't on a specific line.
+	Line int
+
+	// Author is the username of the user who started the thread.
+	Author string
+
+	// Body is the contents of the first comment in the thread.
+	Body string
+
+	// Resolved reports whether the thread has been resolved.
+	Resolved bool
+
+	// Outdated reports whether the code that the thread is on
+	// has changed since the thread was started.
+	Outdated bool
+}
+
+// Cache stores information that a repository may reuse
+// in later invocations, e.g. the accounts of reviewers.
+type Cache interface {
+	// Get loads the value stored under key into v.
+	// It returns ErrNotFound if nothing is stored under key.
+	Get(ctx context.Context, key string, v any) error
+
+	// Set stores v under key, replacing any existing value.
+	Set(ctx context.Context, key string, v any) error
+}
+
+// WithCache is an optional interface that repositories can implement
+// to remember information between invocations.
+type WithCache interface {
+	Repository
+
+	// SetCache sets the cache used by the repository.
+	// It must be called before the repository is used.
+	SetCache(Cache)
+}
+
+// Capabilities describes optional features of change requests
+// that a repository supports.
+type Capabilities struct {
+	// Draft reports whether changes may be submitted as drafts,
+	// and moved between draft and ready for review.
+	Draft bool
+
+	// Labels reports whether labels may be added to changes.
+	Labels bool
+
+	// Reviewers reports whether reviews may be requested on changes.
+	Reviewers bool
+
+	// TeamReviewers reports whether reviews may be requested
+	// from teams in the form "org/team" in addition to users.
+	TeamReviewers bool
+
+	// Assignees reports whether users may be assigned to changes.
+	Assignees bool
+
+	// AutoMerge reports whether changes may be set to merge automatically
+	// once they meet the repository's requirements, e.g. passing CI.
+	AutoMerge bool
+
+	// MergeStrategies lists the ways in which changes may be merged,
+	// subject to the repository's settings.
+	MergeStrategies []MergeStrategy
+
+	// RejectsMissingBase reports whether SubmitChange fails
+	// with ErrUnsubmittedBase if the base branch of the change
+	// does not exist in the remote repository.
+	// Some forges (e.g. GitLab) accept such changes.
+	RejectsMissingBase bool
+}
+
+// SupportsMergeStrategy reports whether changes may be merged
+// with the given strategy.
+func (c Capabilities) SupportsMergeStrategy(s MergeStrategy) bool {
+	return slices.Contains(c.MergeStrategies, s)
+}
+
+// AllCapabilities reports support for every optional feature.
+var AllCapabilities = Capabilities{
+	Draft:              true,
+	Labels:             true,
+	Reviewers:          true,
+	TeamReviewers:      true,
+	Assignees:          true,
+	AutoMerge:          true,
+	MergeStrategies:    []MergeStrategy{MergeCommit, SquashMerge, RebaseMerge},
+	RejectsMissingBase: true,
+}
+
+// MergeStrategy is a way of merging a change into its base branch.
+type MergeStrategy int
+
+const (
+	// MergeCommit merges the change with a merge commit.
+	MergeCommit MergeStrategy = iota + 1
+
+	// SquashMerge squashes the commits of the change
+	// into a single commit on the base branch.
+	SquashMerge
+
+	// RebaseMerge rebases the commits of the change
+	// onto the base branch without a merge commit.
+	RebaseMerge
+)
+
+// String returns the name of the merge strategy.
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeCommit:
+		return "merge"
+	case SquashMerge:
+		return "squash"
+	case RebaseMerge:
+		return "rebase"
+	default:
+		return fmt.Sprintf("MergeStrategy(%d)", int(s))
+	}
+}
+
 // ChangeID is a unique identifier for a change in a repository.
 type ChangeID interface {
 	String() string
 }
 
+// FormatChangeID formats the given change ID for display to users
+// following the conventions of the given forge.
+// If the forge is nil, it falls back to the ID's String method.
+func FormatChangeID(f Forge, id ChangeID) string {
+	if f == nil {
+		return id.String()
+	}
+	return f.FormatChangeID(id)
+}
+
+// ParseChangeNumber parses a user-provided reference to a numbered change
+// for forges that identify changes by number.
+//
+// s may be a plain number ("123"),
+// a number with one of the given prefixes ("#123"),
+// or a web URL with a path ending in "/<segment>/<number>"
+// for one of the given path segments.
+func ParseChangeNumber(s string, prefixes, segments []string) (int64, error) {
+	s = strings.TrimSpace(s)
+	numStr := s
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" && u.Host != "" {
+		dir, num := path.Split(strings.TrimSuffix(u.Path, "/"))
+		if !slices.Contains(segments, path.Base(dir)) {
+			return 0, fmt.Errorf("not a change URL: %v", s)
+		}
+		numStr = num
+	} else {
+		for _, prefix := range prefixes {
+			if rest, ok := strings.CutPrefix(s, prefix); ok {
+				numStr = rest
+				break
+			}
+		}
+	}
+
+	num, err := strconv.ParseInt(numStr, 10, 64)
+	if err != nil || num <= 0 {
+		return 0, fmt.Errorf("invalid change number: %q", s)
+	}
+	return num, nil
+}
+
+// ParseChangeRef reports whether s looks like a reference to a change
+// rather than a branch name.
+// If so, it returns the reference in a form that
+// [Forge.ParseChangeID] understands.
+//
+// References are "#123", "!123", "pr/123", "mr/123",
+// or the web URL of a change.
+// Plain numbers are not considered references
+// because they're valid branch names.
+func ParseChangeRef(s string) (string, bool) {
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return s, true
+	}
+
+	num, ok := strings.CutPrefix(s, "#")
+	if !ok {
+		num, ok = strings.CutPrefix(s, "!")
+	}
+	if !ok {
+		for _, prefix := range []string{"pr/", "mr/"} {
+			if rest, found := strings.CutPrefix(strings.ToLower(s), prefix); found {
+				num, ok = rest, true
+				s = rest // forges don't understand the prefix
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", false
+	}
+
+	if n, err := strconv.Atoi(num); err != nil || n <= 0 {
+		return "", false
+	}
+	return s, true
+}
+
 // ChangeCommentID is a unique identifier for a comment on a change.
 type ChangeCommentID interface {
 	String() string
@@ -318,6 +655,29 @@ type FindChangesOptions struct {
 	Limit int
 }
 
+// FindOpenChangeByHeadRequest specifies the change request to look for
+// with FindOpenChangeByHead.
+type FindOpenChangeByHeadRequest struct {
+	// Head is the name of the branch with the changes.
+	Head string // required
+
+	// HeadRepository is the repository that Head was pushed to
+	// if that's not this repository, e.g. a fork.
+	//
+	// If nil, only changes from branches in this repository match.
+	HeadRepository RepositoryID
+
+	// Base is the name of the branch that the change is proposed against.
+	//
+	// If empty, changes against any base branch match.
+	Base string
+
+	// Unique reports an [*AmbiguousChangeError]
+	// if more than one change matches
+	// instead of picking the most recently updated one.
+	Unique bool
+}
+
 // ListChangeCommentsOptions specifies options for filtering
 // and limiting comments listed by ListChangeComments.
 //
@@ -360,6 +720,14 @@ type SubmitChangeRequest struct {
 	// This must have already been pushed to the remote.
 	Head string // required
 
+	// HeadRepository is the repository that Head was pushed to
+	// if it's different from the repository the change is proposed to,
+	// e.g. a fork of the repository.
+	//
+	// If unset, Head is expected to be in the same repository.
+	// If set, it must be a RepositoryID for the same forge.
+	HeadRepository RepositoryID
+
 	// Draft specifies whether the change should be marked as a draft.
 	Draft bool
 
@@ -371,6 +739,21 @@ type SubmitChangeRequest struct {
 
 	// Assignees are optional users to assign to the change.
 	Assignees []string
+
+	// AutoMerge specifies whether the change should be merged
+	// automatically once it meets the repository's requirements,
+	// e.g. when its CI pipeline succeeds.
+	AutoMerge bool
+
+	// RemoveSourceBranch specifies whether Head should be deleted
+	// after the change is merged.
+	// If nil, the repository's default is used.
+	//
+	// Forges that don't support this per change ignore it.
+	RemoveSourceBranch *bool
+
+	// Repositories ignore Draft, Labels, Reviewers, Assignees, and AutoMerge
+	// if they don't report support for them in [Repository.Capabilities].
 }
 
 // SubmitChangeResult is the result of creating a new change in a repository.
@@ -402,6 +785,9 @@ type EditChangeOptions struct {
 	// AddAssignees are new users to assign to the change.
 	// Existing assignees associated with the change will not be modified.
 	AddAssignees []string
+
+	// As with SubmitChangeRequest, repositories ignore options
+	// for features they don't report support for.
 }
 
 // FindChangeItem is a single result from searching for changes in the
@@ -422,6 +808,12 @@ type FindChangeItem struct {
 	// HeadHash is the hash of the commit at the top of the change.
 	HeadHash git.Hash // required
 
+	// HeadName is the name of the branch
+	// that this change is proposed from.
+	//
+	// This may be empty if the forge does not report it.
+	HeadName string
+
 	// BaseName is the name of the base branch
 	// that this change is proposed against.
 	BaseName string // required
@@ -452,6 +844,24 @@ type ChangeTemplate struct {
 	Body string
 }
 
+// ChangeDetails is the current state of a change
+// reported by [Repository.ChangesDetails].
+type ChangeDetails struct {
+	State ChangeState
+
+	// BaseName is the name of the branch the change targets,
+	// or was merged into.
+	BaseName string
+
+	// MergeCommit is the commit that brought a merged change
+	// into its base branch: the merge commit, the squashed commit,
+	// or the last of the rebased commits.
+	//
+	// This is empty if the change isn't merged,
+	// or if the forge did not report it.
+	MergeCommit git.Hash
+}
+
 // ChangeState is the current state of a change.
 type ChangeState int
 
@@ -518,3 +928,46 @@ func (s *ChangeState) UnmarshalText(b []byte) error {
 	}
 	return nil
 }
+
+// ChangeDescription is the title and body of a change.
+type ChangeDescription struct {
+	// Subject is the title of the change.
+	Subject string
+
+	// Body is the description of the change.
+	// It may be empty.
+	Body string
+}
+
+// DiffStat summarizes the size of a change.
+type DiffStat struct {
+	// Additions is the number of lines added.
+	Additions int
+
+	// Deletions is the number of lines deleted.
+	Deletions int
+
+	// ChangedFiles is the number of files changed.
+	ChangedFiles int
+
+	// Incomplete is set if the forge did not report
+	// the lines changed in some of the files,
+	// e.g. because their diffs were too large.
+	// Additions and Deletions count only the other files.
+	Incomplete bool
+}
+
+// String formats the DiffStat for display, e.g. "+12/-3, 2 files".
+// Incomplete line counts are prefixed with "at least".
+func (s *DiffStat) String() string {
+	files := "files"
+	if s.ChangedFiles == 1 {
+		files = "file"
+	}
+
+	var atLeast string
+	if s.Incomplete {
+		atLeast = "at least "
+	}
+	return fmt.Sprintf("%s+%d/-%d, %d %v", atLeast, s.Additions, s.Deletions, s.ChangedFiles, files)
+}
@@ -65,6 +65,100 @@ func TestRegister(t *testing.T) {
 	})
 }
 
+func TestRegistry_FormatChangeID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockForge := forgetest.NewMockForge(ctrl)
+	mockForge.EXPECT().ID().Return(forgetest.FakeForgeID).AnyTimes()
+	mockForge.EXPECT().
+		FormatChangeID(forgetest.FakeChangeID(42)).
+		Return("PR #42")
+
+	md := &forgetest.FakeChangeMetadata{Number: 42}
+
+	var registry forge.Registry
+	unregister := registry.Register(mockForge)
+	assert.Equal(t, "PR #42", registry.FormatChangeID(md))
+
+	t.Run("UnknownForge", func(t *testing.T) {
+		unregister()
+		assert.Equal(t, "#42", registry.FormatChangeID(md))
+	})
+}
+
+func TestParseChangeNumber(t *testing.T) {
+	prefixes := []string{"PR #", "#"}
+	segments := []string{"pull"}
+
+	tests := []struct {
+		name string
+		give string
+		want int64
+	}{
+		{name: "Number", give: "42", want: 42},
+		{name: "Spaces", give: " 42\n", want: 42},
+		{name: "Prefix", give: "#42", want: 42},
+		{name: "LongPrefix", give: "PR #42", want: 42},
+		{name: "URL", give: "https://example.com/foo/bar/pull/42", want: 42},
+		{name: "URLTrailingSlash", give: "https://example.com/foo/bar/pull/42/", want: 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := forge.ParseChangeNumber(tt.give, prefixes, segments)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, give := range []string{
+			"",
+			"foo",
+			"!42",
+			"0",
+			"-1",
+			"https://example.com/foo/bar/issues/42",
+			"https://example.com/foo/bar/pull/42/files",
+		} {
+			_, err := forge.ParseChangeNumber(give, prefixes, segments)
+			assert.Error(t, err, "input: %q", give)
+		}
+	})
+}
+
+func TestParseChangeRef(t *testing.T) {
+	tests := []struct {
+		give   string
+		want   string
+		wantOK bool
+	}{
+		{give: "#123", want: "#123", wantOK: true},
+		{give: "!123", want: "!123", wantOK: true},
+		{give: "pr/123", want: "123", wantOK: true},
+		{give: "MR/123", want: "123", wantOK: true},
+		{
+			give:   "https://github.com/abhinav/git-spice/pull/123",
+			want:   "https://github.com/abhinav/git-spice/pull/123",
+			wantOK: true,
+		},
+		{give: "feature"},
+		{give: "123"},
+		{give: "pr/feature"},
+		{give: "#0"},
+		{give: "user/feature"},
+		{give: "ftp://example.com/pull/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, ok := forge.ParseChangeRef(tt.give)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestGetDisplayName(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -123,3 +217,41 @@ func TestChangeState(t *testing.T) {
 		})
 	})
 }
+
+func TestCapabilities_SupportsMergeStrategy(t *testing.T) {
+	caps := forge.Capabilities{
+		MergeStrategies: []forge.MergeStrategy{forge.MergeCommit, forge.SquashMerge},
+	}
+	assert.True(t, caps.SupportsMergeStrategy(forge.MergeCommit))
+	assert.True(t, caps.SupportsMergeStrategy(forge.SquashMerge))
+	assert.False(t, caps.SupportsMergeStrategy(forge.RebaseMerge))
+
+	for _, s := range []forge.MergeStrategy{forge.MergeCommit, forge.SquashMerge, forge.RebaseMerge} {
+		assert.True(t, forge.AllCapabilities.SupportsMergeStrategy(s), "%v", s)
+	}
+}
+
+func TestMergeStrategy_String(t *testing.T) {
+	assert.Equal(t, "merge", forge.MergeCommit.String())
+	assert.Equal(t, "squash", forge.SquashMerge.String())
+	assert.Equal(t, "rebase", forge.RebaseMerge.String())
+	assert.Equal(t, "MergeStrategy(42)", forge.MergeStrategy(42).String())
+}
+
+func TestDiffStat_String(t *testing.T) {
+	assert.Equal(t, "+12/-3, 2 files", (&forge.DiffStat{
+		Additions:    12,
+		Deletions:    3,
+		ChangedFiles: 2,
+	}).String())
+	assert.Equal(t, "+1/-0, 1 file", (&forge.DiffStat{
+		Additions:    1,
+		ChangedFiles: 1,
+	}).String())
+	assert.Equal(t, "at least +12/-3, 3 files", (&forge.DiffStat{
+		Additions:    12,
+		Deletions:    3,
+		ChangedFiles: 3,
+		Incomplete:   true,
+	}).String())
+}
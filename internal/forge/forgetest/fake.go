@@ -0,0 +1,865 @@
+package forgetest
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"sync"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/secret"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// FakeForgeID is the forge ID reported by [FakeForge].
+const FakeForgeID = "fake"
+
+// FakeForge is a [forge.Forge] that accompanies [FakeRepository].
+//
+// It knows how to serialize the change IDs and metadata
+// produced by FakeRepository.
+// It does not support authentication or opening repositories by URL.
+type FakeForge struct{}
+
+var _ forge.Forge = (*FakeForge)(nil)
+
+// ID reports the ID of the fake forge.
+func (*FakeForge) ID() string { return FakeForgeID }
+
+// CLIPlugin reports that the fake forge has no CLI flags.
+func (*FakeForge) CLIPlugin() any { return nil }
+
+// ParseRemoteURL always fails with [forge.ErrUnsupportedURL].
+func (*FakeForge) ParseRemoteURL(string) (forge.RepositoryID, error) {
+	return nil, forge.ErrUnsupportedURL
+}
+
+// OpenRepository is not supported by the fake forge.
+func (*FakeForge) OpenRepository(context.Context, forge.AuthenticationToken, forge.RepositoryID) (forge.Repository, error) {
+	return nil, errors.New("fake forge: OpenRepository is not supported")
+}
+
+// ChangeTemplatePaths reports no template paths.
+func (*FakeForge) ChangeTemplatePaths() []string { return nil }
+
+// MarshalChangeID serializes a [FakeChangeID].
+func (*FakeForge) MarshalChangeID(id forge.ChangeID) (json.RawMessage, error) {
+	return json.Marshal(id.(FakeChangeID))
+}
+
+// UnmarshalChangeID deserializes a [FakeChangeID].
+func (*FakeForge) UnmarshalChangeID(data json.RawMessage) (forge.ChangeID, error) {
+	var id FakeChangeID
+	if err := json.Unmarshal(data, &id); err != nil {
+		return nil, fmt.Errorf("unmarshal change ID: %w", err)
+	}
+	return id, nil
+}
+
+// FormatChangeID formats a [FakeChangeID] for display, e.g. "#123".
+func (*FakeForge) FormatChangeID(id forge.ChangeID) string {
+	return id.String()
+}
+
+// ParseChangeID parses a [FakeChangeID] from "123", "#123",
+// or a change URL.
+func (*FakeForge) ParseChangeID(s string) (forge.ChangeID, error) {
+	num, err := forge.ParseChangeNumber(s, []string{"#"}, []string{"changes"})
+	if err != nil {
+		return nil, err
+	}
+	return FakeChangeID(num), nil
+}
+
+// MarshalChangeMetadata serializes a [FakeChangeMetadata].
+func (*FakeForge) MarshalChangeMetadata(md forge.ChangeMetadata) (json.RawMessage, error) {
+	return json.Marshal(md)
+}
+
+// UnmarshalChangeMetadata deserializes a [FakeChangeMetadata].
+func (*FakeForge) UnmarshalChangeMetadata(data json.RawMessage) (forge.ChangeMetadata, error) {
+	var md FakeChangeMetadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, fmt.Errorf("unmarshal change metadata: %w", err)
+	}
+	return &md, nil
+}
+
+// AuthenticationFlow is not supported by the fake forge.
+func (*FakeForge) AuthenticationFlow(context.Context, ui.View) (forge.AuthenticationToken, error) {
+	return nil, errors.New("fake forge: authentication is not supported")
+}
+
+// SaveAuthenticationToken is a no-op.
+func (*FakeForge) SaveAuthenticationToken(secret.Stash, forge.AuthenticationToken) error {
+	return nil
+}
+
+// LoadAuthenticationToken is not supported by the fake forge.
+func (*FakeForge) LoadAuthenticationToken(secret.Stash) (forge.AuthenticationToken, error) {
+	return nil, errors.New("fake forge: authentication is not supported")
+}
+
+// ClearAuthenticationToken is a no-op.
+func (*FakeForge) ClearAuthenticationToken(secret.Stash) error {
+	return nil
+}
+
+// FakeChangeID identifies a change in a [FakeRepository].
+type FakeChangeID int
+
+var _ forge.ChangeID = FakeChangeID(0)
+
+func (id FakeChangeID) String() string {
+	return "#" + strconv.Itoa(int(id))
+}
+
+// FakeCommentID identifies a comment in a [FakeRepository].
+type FakeCommentID int
+
+var _ forge.ChangeCommentID = FakeCommentID(0)
+
+func (id FakeCommentID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// FakeChangeMetadata is the change metadata for a [FakeRepository].
+type FakeChangeMetadata struct {
+	Number            int `json:"number"`
+	NavigationComment int `json:"nav_comment,omitempty"`
+}
+
+var _ forge.ChangeMetadata = (*FakeChangeMetadata)(nil)
+
+// ForgeID reports [FakeForgeID].
+func (*FakeChangeMetadata) ForgeID() string { return FakeForgeID }
+
+// ChangeID reports the ID of the change.
+func (m *FakeChangeMetadata) ChangeID() forge.ChangeID {
+	return FakeChangeID(m.Number)
+}
+
+// NavigationCommentID reports the ID of the navigation comment, if any.
+func (m *FakeChangeMetadata) NavigationCommentID() forge.ChangeCommentID {
+	if m.NavigationComment == 0 {
+		return nil
+	}
+	return FakeCommentID(m.NavigationComment)
+}
+
+// SetNavigationCommentID sets the ID of the navigation comment.
+// id may be nil.
+func (m *FakeChangeMetadata) SetNavigationCommentID(id forge.ChangeCommentID) {
+	if id == nil {
+		m.NavigationComment = 0
+	} else {
+		m.NavigationComment = int(id.(FakeCommentID))
+	}
+}
+
+// FakeChange is a change request held by a [FakeRepository].
+type FakeChange struct {
+	Number    int
+	Subject   string
+	Body      string
+	Base      string
+	Head      string
+	HeadHash  git.Hash
+	HeadRepo  string // repository ID of Head if not this repository
+	Draft     bool
+	State     forge.ChangeState // defaults to forge.ChangeOpen
+	Merge     git.Hash          // reported by ChangesDetails if merged
+	Labels    []string
+	Reviewers []string
+	Assignees []string
+	DiffStat  forge.DiffStat // reported by ChangeDiffStat
+}
+
+// FakeComment is a comment posted on a change in a [FakeRepository].
+type FakeComment struct {
+	ID     int
+	Change int
+	Body   string
+}
+
+// FakeRepository is an in-memory [forge.Repository]
+// for use in handler tests.
+//
+// Unlike a gomock-based mock, it holds state:
+// changes submitted to it may be found, edited, commented on,
+// and merged afterwards.
+// Tests set up scenarios declaratively with methods like
+// [FakeRepository.AddChange] and [FakeRepository.MergeAfter],
+// run the code under test, and inspect the result with
+// [FakeRepository.Change] and [FakeRepository.Comments].
+//
+// The zero value is not valid. Use [NewFakeRepository].
+// FakeRepository is safe for concurrent use.
+type FakeRepository struct {
+	forge forge.Forge
+	caps  forge.Capabilities
+
+	mu          sync.Mutex
+	changes     map[int]*FakeChange
+	nextChange  int
+	comments    []*FakeComment
+	nextComment int
+	templates   []*forge.ChangeTemplate
+	user        string
+
+	// change number => pending state transitions
+	transitions map[int][]*fakeTransition
+
+	// method name => errors to return on upcoming calls
+	errors map[string][]error
+}
+
+var _ forge.Repository = (*FakeRepository)(nil)
+
+type fakeTransition struct {
+	polls int // number of ChangesStates calls remaining
+	state forge.ChangeState
+}
+
+// NewFakeRepository builds a new empty FakeRepository
+// that reports [FakeForge] as its forge.
+func NewFakeRepository() *FakeRepository {
+	return &FakeRepository{
+		forge:       new(FakeForge),
+		caps:        forge.AllCapabilities,
+		changes:     make(map[int]*FakeChange),
+		nextChange:  1,
+		nextComment: 1,
+		user:        "fake",
+		transitions: make(map[int][]*fakeTransition),
+		errors:      make(map[string][]error),
+	}
+}
+
+// WithForge changes the forge reported by the repository.
+// It returns the repository for chaining.
+func (r *FakeRepository) WithForge(f forge.Forge) *FakeRepository {
+	r.forge = f
+	return r
+}
+
+// WithCapabilities changes the capabilities reported by the repository.
+// By default, the repository reports [forge.AllCapabilities].
+// It returns the repository for chaining.
+func (r *FakeRepository) WithCapabilities(caps forge.Capabilities) *FakeRepository {
+	r.caps = caps
+	return r
+}
+
+// Forge reports the forge that owns this repository.
+func (r *FakeRepository) Forge() forge.Forge {
+	return r.forge
+}
+
+// Capabilities reports the capabilities set with [FakeRepository.WithCapabilities].
+func (r *FakeRepository) Capabilities() forge.Capabilities {
+	return r.caps
+}
+
+// AddChange adds an existing change to the repository,
+// and returns its ID.
+//
+// If c.Number is zero, a new number is assigned.
+// If c.State is zero, the change is open.
+func (r *FakeRepository) AddChange(c FakeChange) FakeChangeID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addChange(&c)
+}
+
+func (r *FakeRepository) addChange(c *FakeChange) FakeChangeID {
+	if c.Number == 0 {
+		c.Number = r.nextChange
+	}
+	r.nextChange = max(r.nextChange, c.Number+1)
+	if c.State == 0 {
+		c.State = forge.ChangeOpen
+	}
+	r.changes[c.Number] = c
+	return FakeChangeID(c.Number)
+}
+
+// Change returns a copy of the change with the given ID.
+// It returns false if the change does not exist.
+func (r *FakeRepository) Change(id forge.ChangeID) (FakeChange, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.changes[fakeChangeNumber(id)]
+	if !ok {
+		return FakeChange{}, false
+	}
+	return *c, true
+}
+
+// Changes returns copies of all changes in the repository,
+// ordered by change number.
+func (r *FakeRepository) Changes() []FakeChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := make([]FakeChange, 0, len(r.changes))
+	for _, c := range r.changes {
+		changes = append(changes, *c)
+	}
+	slices.SortFunc(changes, func(a, b FakeChange) int {
+		return a.Number - b.Number
+	})
+	return changes
+}
+
+// SetState changes the state of a change immediately.
+// Pending transitions for the change are discarded.
+func (r *FakeRepository) SetState(id forge.ChangeID, state forge.ChangeState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	num := fakeChangeNumber(id)
+	r.mustChange(num).State = state
+	delete(r.transitions, num)
+}
+
+// Merge marks a change as merged immediately.
+func (r *FakeRepository) Merge(id forge.ChangeID) {
+	r.SetState(id, forge.ChangeMerged)
+}
+
+// TransitionAfter schedules a change to enter the given state
+// after it has been reported by ChangesStates polls times.
+// For example, with polls=3, the first three ChangesStates calls
+// that include the change report its current state,
+// and the fourth reports the new state.
+//
+// Multiple transitions for the same change are applied in order,
+// each counting polls after the previous one took effect.
+func (r *FakeRepository) TransitionAfter(id forge.ChangeID, polls int, state forge.ChangeState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	num := fakeChangeNumber(id)
+	r.mustChange(num)
+	r.transitions[num] = append(r.transitions[num], &fakeTransition{
+		polls: polls,
+		state: state,
+	})
+}
+
+// MergeAfter schedules a change to be merged after polls
+// calls to ChangesStates that include it.
+// See [FakeRepository.TransitionAfter].
+func (r *FakeRepository) MergeAfter(id forge.ChangeID, polls int) {
+	r.TransitionAfter(id, polls, forge.ChangeMerged)
+}
+
+// FailNext makes the next call to the named [forge.Repository] method
+// (e.g. "SubmitChange") fail with the given error.
+//
+// Multiple calls queue up errors for successive calls.
+func (r *FakeRepository) FailNext(method string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errors[method] = append(r.errors[method], err)
+}
+
+// SetChangeTemplates sets the templates reported by ListChangeTemplates.
+func (r *FakeRepository) SetChangeTemplates(templates ...*forge.ChangeTemplate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.templates = templates
+}
+
+// Comments returns copies of all comments on the given change,
+// in the order they were posted.
+func (r *FakeRepository) Comments(id forge.ChangeID) []FakeComment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	num := fakeChangeNumber(id)
+	var comments []FakeComment
+	for _, c := range r.comments {
+		if c.Change == num {
+			comments = append(comments, *c)
+		}
+	}
+	return comments
+}
+
+// SubmitChange creates a new open change.
+func (r *FakeRepository) SubmitChange(_ context.Context, req forge.SubmitChangeRequest) (forge.SubmitChangeResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("SubmitChange"); err != nil {
+		return forge.SubmitChangeResult{}, err
+	}
+
+	change := &FakeChange{
+		Subject:   req.Subject,
+		Body:      req.Body,
+		Base:      req.Base,
+		Head:      req.Head,
+		Draft:     req.Draft,
+		Labels:    slices.Clone(req.Labels),
+		Reviewers: slices.Clone(req.Reviewers),
+		Assignees: slices.Clone(req.Assignees),
+	}
+	if req.HeadRepository != nil {
+		change.HeadRepo = req.HeadRepository.String()
+	}
+	id := r.addChange(change)
+	return forge.SubmitChangeResult{
+		ID:  id,
+		URL: fakeChangeURL(int(id)),
+	}, nil
+}
+
+// EditChange edits an existing change.
+func (r *FakeRepository) EditChange(_ context.Context, id forge.ChangeID, opts forge.EditChangeOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("EditChange"); err != nil {
+		return err
+	}
+
+	c, ok := r.changes[fakeChangeNumber(id)]
+	if !ok {
+		return fmt.Errorf("change %v: %w", id, forge.ErrNotFound)
+	}
+
+	if opts.Base != "" {
+		c.Base = opts.Base
+	}
+	if opts.Draft != nil {
+		c.Draft = *opts.Draft
+	}
+	c.Labels = appendMissing(c.Labels, opts.AddLabels...)
+	c.Reviewers = appendMissing(c.Reviewers, opts.AddReviewers...)
+	c.Assignees = appendMissing(c.Assignees, opts.AddAssignees...)
+	return nil
+}
+
+// FindChangesByBranch lists changes with the given head branch,
+// most recently created first.
+func (r *FakeRepository) FindChangesByBranch(_ context.Context, branch string, opts forge.FindChangesOptions) ([]*forge.FindChangeItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("FindChangesByBranch"); err != nil {
+		return nil, err
+	}
+
+	limit := opts.Limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	var matches []*FakeChange
+	for _, c := range r.changes {
+		if c.Head != branch {
+			continue
+		}
+		if opts.State != 0 && c.State != opts.State {
+			continue
+		}
+		matches = append(matches, c)
+	}
+	slices.SortFunc(matches, func(a, b *FakeChange) int {
+		return b.Number - a.Number
+	})
+
+	items := make([]*forge.FindChangeItem, 0, min(limit, len(matches)))
+	for _, c := range matches[:min(limit, len(matches))] {
+		items = append(items, c.findChangeItem())
+	}
+	return items, nil
+}
+
+// FindOpenChangeByHead finds the most recently created open change
+// with the given head branch and head repository,
+// and the given base branch if any.
+// It returns [forge.ErrNotFound] if there is no such change,
+// and [*forge.AmbiguousChangeError] if req.Unique is set
+// and there is more than one.
+func (r *FakeRepository) FindOpenChangeByHead(_ context.Context, req forge.FindOpenChangeByHeadRequest) (*forge.FindChangeItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("FindOpenChangeByHead"); err != nil {
+		return nil, err
+	}
+
+	var headRepo string
+	if req.HeadRepository != nil {
+		headRepo = req.HeadRepository.String()
+	}
+
+	var found []*FakeChange
+	for _, c := range r.changes {
+		if c.State != forge.ChangeOpen || c.Head != req.Head || c.HeadRepo != headRepo {
+			continue
+		}
+		if req.Base != "" && c.Base != req.Base {
+			continue
+		}
+		found = append(found, c)
+	}
+	if len(found) == 0 {
+		return nil, forge.ErrNotFound
+	}
+
+	slices.SortFunc(found, func(a, b *FakeChange) int {
+		return cmp.Compare(b.Number, a.Number)
+	})
+	if req.Unique && len(found) > 1 {
+		matches := make([]*forge.FindChangeItem, len(found))
+		for i, c := range found {
+			matches[i] = c.findChangeItem()
+		}
+		return nil, &forge.AmbiguousChangeError{Changes: matches}
+	}
+	return found[0].findChangeItem(), nil
+}
+
+// FindChangeByID finds a change by its ID.
+// It returns [forge.ErrNotFound] if the change does not exist.
+func (r *FakeRepository) FindChangeByID(_ context.Context, id forge.ChangeID) (*forge.FindChangeItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("FindChangeByID"); err != nil {
+		return nil, err
+	}
+
+	c, ok := r.changes[fakeChangeNumber(id)]
+	if !ok {
+		return nil, fmt.Errorf("change %v: %w", id, forge.ErrNotFound)
+	}
+	return c.findChangeItem(), nil
+}
+
+// ChangeDescription reports the Subject and Body of the given change.
+func (r *FakeRepository) ChangeDescription(_ context.Context, id forge.ChangeID) (*forge.ChangeDescription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("ChangeDescription"); err != nil {
+		return nil, err
+	}
+
+	c, ok := r.changes[fakeChangeNumber(id)]
+	if !ok {
+		return nil, fmt.Errorf("change %v: %w", id, forge.ErrNotFound)
+	}
+	return &forge.ChangeDescription{
+		Subject: c.Subject,
+		Body:    c.Body,
+	}, nil
+}
+
+// ChangesStates reports the states of the given changes.
+//
+// Each call counts as a poll for transitions
+// scheduled with [FakeRepository.TransitionAfter].
+func (r *FakeRepository) ChangesStates(_ context.Context, ids []forge.ChangeID) ([]forge.ChangeState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("ChangesStates"); err != nil {
+		return nil, err
+	}
+
+	states := make([]forge.ChangeState, len(ids))
+	for i, id := range ids {
+		c, err := r.pollChange(id)
+		if err != nil {
+			return nil, err
+		}
+		states[i] = c.State
+	}
+	return states, nil
+}
+
+// ChangesDetails reports the states of the given changes
+// and the merge commits of merged changes.
+//
+// Like ChangesStates, each call counts as a poll for transitions.
+func (r *FakeRepository) ChangesDetails(_ context.Context, ids []forge.ChangeID) ([]forge.ChangeDetails, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("ChangesDetails"); err != nil {
+		return nil, err
+	}
+
+	details := make([]forge.ChangeDetails, len(ids))
+	for i, id := range ids {
+		c, err := r.pollChange(id)
+		if err != nil {
+			return nil, err
+		}
+		details[i].State = c.State
+		details[i].BaseName = c.Base
+		if c.State == forge.ChangeMerged {
+			details[i].MergeCommit = c.Merge
+		}
+	}
+	return details, nil
+}
+
+// pollChange looks up a change for ChangesStates or ChangesDetails,
+// applying the next pending transition if it's due.
+//
+// r.mu must be held.
+func (r *FakeRepository) pollChange(id forge.ChangeID) (*FakeChange, error) {
+	num := fakeChangeNumber(id)
+	c, ok := r.changes[num]
+	if !ok {
+		return nil, fmt.Errorf("change %v: %w", id, forge.ErrNotFound)
+	}
+
+	if pending := r.transitions[num]; len(pending) > 0 {
+		next := pending[0]
+		if next.polls <= 0 {
+			c.State = next.state
+			r.transitions[num] = pending[1:]
+		} else {
+			next.polls--
+		}
+	}
+	return c, nil
+}
+
+// ChangeDiffStat reports the DiffStat of the given change.
+func (r *FakeRepository) ChangeDiffStat(_ context.Context, id forge.ChangeID) (*forge.DiffStat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("ChangeDiffStat"); err != nil {
+		return nil, err
+	}
+
+	c, ok := r.changes[fakeChangeNumber(id)]
+	if !ok {
+		return nil, fmt.Errorf("change %v: %w", id, forge.ErrNotFound)
+	}
+	stat := c.DiffStat
+	return &stat, nil
+}
+
+// PostChangeComment posts a new comment on a change.
+func (r *FakeRepository) PostChangeComment(_ context.Context, id forge.ChangeID, body string) (forge.ChangeCommentID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("PostChangeComment"); err != nil {
+		return nil, err
+	}
+
+	num := fakeChangeNumber(id)
+	if _, ok := r.changes[num]; !ok {
+		return nil, fmt.Errorf("change %v: %w", id, forge.ErrNotFound)
+	}
+
+	comment := &FakeComment{
+		ID:     r.nextComment,
+		Change: num,
+		Body:   body,
+	}
+	r.nextComment++
+	r.comments = append(r.comments, comment)
+	return FakeCommentID(comment.ID), nil
+}
+
+// UpdateChangeComment replaces the body of an existing comment.
+// It returns [forge.ErrNotFound] if the comment does not exist.
+func (r *FakeRepository) UpdateChangeComment(_ context.Context, id forge.ChangeCommentID, body string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("UpdateChangeComment"); err != nil {
+		return err
+	}
+
+	for _, c := range r.comments {
+		if c.ID == int(id.(FakeCommentID)) {
+			c.Body = body
+			return nil
+		}
+	}
+	return fmt.Errorf("comment %v: %w", id, forge.ErrNotFound)
+}
+
+// DeleteChangeComment deletes an existing comment.
+// It returns [forge.ErrNotFound] if the comment does not exist.
+func (r *FakeRepository) DeleteChangeComment(_ context.Context, id forge.ChangeCommentID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("DeleteChangeComment"); err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(r.comments, func(c *FakeComment) bool {
+		return c.ID == int(id.(FakeCommentID))
+	})
+	if idx < 0 {
+		return fmt.Errorf("comment %v: %w", id, forge.ErrNotFound)
+	}
+	r.comments = slices.Delete(r.comments, idx, idx+1)
+	return nil
+}
+
+// ListChangeComments lists comments on a change
+// matching the given options.
+// All comments are considered updatable.
+func (r *FakeRepository) ListChangeComments(
+	_ context.Context,
+	id forge.ChangeID,
+	opts *forge.ListChangeCommentsOptions,
+) iter.Seq2[*forge.ListChangeCommentItem, error] {
+	r.mu.Lock()
+	err := r.takeError("ListChangeComments")
+	var items []*forge.ListChangeCommentItem
+	if err == nil {
+		num := fakeChangeNumber(id)
+	commentLoop:
+		for _, c := range r.comments {
+			if c.Change != num {
+				continue
+			}
+			if opts != nil {
+				for _, re := range opts.BodyMatchesAll {
+					if !re.MatchString(c.Body) {
+						continue commentLoop
+					}
+				}
+			}
+			items = append(items, &forge.ListChangeCommentItem{
+				ID:   FakeCommentID(c.ID),
+				Body: c.Body,
+			})
+		}
+	}
+	r.mu.Unlock()
+
+	return func(yield func(*forge.ListChangeCommentItem, error) bool) {
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// NewChangeMetadata returns a [FakeChangeMetadata] for the change.
+func (r *FakeRepository) NewChangeMetadata(_ context.Context, id forge.ChangeID) (forge.ChangeMetadata, error) {
+	return &FakeChangeMetadata{
+		Number: fakeChangeNumber(id),
+	}, nil
+}
+
+// ListChangeTemplates reports templates set with
+// [FakeRepository.SetChangeTemplates].
+func (r *FakeRepository) ListChangeTemplates(context.Context) ([]*forge.ChangeTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("ListChangeTemplates"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(r.templates), nil
+}
+
+// WithUser changes the user reported by [FakeRepository.CurrentUser].
+// By default, the repository reports "fake".
+// It returns the repository for chaining.
+func (r *FakeRepository) WithUser(user string) *FakeRepository {
+	r.user = user
+	return r
+}
+
+// CurrentUser reports the user set with [FakeRepository.WithUser].
+func (r *FakeRepository) CurrentUser(context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.takeError("CurrentUser"); err != nil {
+		return "", err
+	}
+	return r.user, nil
+}
+
+// takeError pops the next queued error for the method, if any.
+// r.mu must be held.
+func (r *FakeRepository) takeError(method string) error {
+	errs := r.errors[method]
+	if len(errs) == 0 {
+		return nil
+	}
+	r.errors[method] = errs[1:]
+	return errs[0]
+}
+
+// mustChange returns the change with the given number,
+// panicking if it does not exist.
+// r.mu must be held.
+func (r *FakeRepository) mustChange(num int) *FakeChange {
+	c, ok := r.changes[num]
+	if !ok {
+		panic(fmt.Sprintf("fake repository: change #%d does not exist", num))
+	}
+	return c
+}
+
+func (c *FakeChange) findChangeItem() *forge.FindChangeItem {
+	return &forge.FindChangeItem{
+		ID:        FakeChangeID(c.Number),
+		URL:       fakeChangeURL(c.Number),
+		State:     c.State,
+		Subject:   c.Subject,
+		HeadHash:  c.HeadHash,
+		HeadName:  c.Head,
+		BaseName:  c.Base,
+		Draft:     c.Draft,
+		Labels:    slices.Clone(c.Labels),
+		Reviewers: slices.Clone(c.Reviewers),
+		Assignees: slices.Clone(c.Assignees),
+	}
+}
+
+func fakeChangeNumber(id forge.ChangeID) int {
+	return int(id.(FakeChangeID))
+}
+
+func fakeChangeURL(num int) string {
+	return "https://forge.example.com/changes/" + strconv.Itoa(num)
+}
+
+func appendMissing(items []string, add ...string) []string {
+	for _, a := range add {
+		if !slices.Contains(items, a) {
+			items = append(items, a)
+		}
+	}
+	return items
+}
@@ -0,0 +1,239 @@
+package forgetest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+)
+
+func TestFakeRepository_submitAndFind(t *testing.T) {
+	ctx := t.Context()
+	repo := forgetest.NewFakeRepository()
+
+	res, err := repo.SubmitChange(ctx, forge.SubmitChangeRequest{
+		Subject: "Add feature",
+		Base:    "main",
+		Head:    "feature",
+		Labels:  []string{"bug"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, forgetest.FakeChangeID(1), res.ID)
+
+	require.NoError(t, repo.EditChange(ctx, res.ID, forge.EditChangeOptions{
+		Base:      "develop",
+		Draft:     new(true),
+		AddLabels: []string{"bug", "feature"},
+	}))
+
+	items, err := repo.FindChangesByBranch(ctx, "feature", forge.FindChangesOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, &forge.FindChangeItem{
+		ID:       res.ID,
+		URL:      res.URL,
+		State:    forge.ChangeOpen,
+		Subject:  "Add feature",
+		HeadName: "feature",
+		BaseName: "develop",
+		Draft:    true,
+		Labels:   []string{"bug", "feature"},
+	}, items[0])
+
+	_, err = repo.FindChangeByID(ctx, forgetest.FakeChangeID(42))
+	assert.ErrorIs(t, err, forge.ErrNotFound)
+}
+
+func TestFakeRepository_FindChangesByBranch_state(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	merged := repo.AddChange(forgetest.FakeChange{
+		Head:  "feature",
+		State: forge.ChangeMerged,
+	})
+	open := repo.AddChange(forgetest.FakeChange{Head: "feature"})
+	repo.AddChange(forgetest.FakeChange{Head: "other"})
+
+	items, err := repo.FindChangesByBranch(t.Context(), "feature", forge.FindChangesOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, open, items[0].ID, "most recent first")
+	assert.Equal(t, merged, items[1].ID)
+
+	items, err = repo.FindChangesByBranch(t.Context(), "feature", forge.FindChangesOptions{
+		State: forge.ChangeOpen,
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, open, items[0].ID)
+}
+
+func TestFakeRepository_FindOpenChangeByHead(t *testing.T) {
+	ctx := t.Context()
+	repo := forgetest.NewFakeRepository()
+	repo.AddChange(forgetest.FakeChange{
+		Head:  "feature",
+		Base:  "main",
+		State: forge.ChangeClosed,
+	})
+	onMain := repo.AddChange(forgetest.FakeChange{Head: "feature", Base: "main"})
+	onDevelop := repo.AddChange(forgetest.FakeChange{Head: "feature", Base: "develop"})
+	repo.AddChange(forgetest.FakeChange{
+		Head:     "feature",
+		Base:     "main",
+		HeadRepo: "fork/repo",
+	})
+
+	item, err := repo.FindOpenChangeByHead(ctx, forge.FindOpenChangeByHeadRequest{
+		Head: "feature",
+		Base: "main",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, onMain, item.ID)
+
+	item, err = repo.FindOpenChangeByHead(ctx, forge.FindOpenChangeByHeadRequest{
+		Head: "feature",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, onDevelop, item.ID, "most recent first")
+
+	_, err = repo.FindOpenChangeByHead(ctx, forge.FindOpenChangeByHeadRequest{
+		Head:   "feature",
+		Unique: true,
+	})
+	var ambiguousErr *forge.AmbiguousChangeError
+	require.ErrorAs(t, err, &ambiguousErr)
+	if assert.Len(t, ambiguousErr.Changes, 2) {
+		assert.Equal(t, onDevelop, ambiguousErr.Changes[0].ID)
+		assert.Equal(t, onMain, ambiguousErr.Changes[1].ID)
+	}
+
+	_, err = repo.FindOpenChangeByHead(ctx, forge.FindOpenChangeByHeadRequest{
+		Head: "feature",
+		Base: "release",
+	})
+	assert.ErrorIs(t, err, forge.ErrNotFound)
+}
+
+func TestFakeRepository_MergeAfter(t *testing.T) {
+	ctx := t.Context()
+	repo := forgetest.NewFakeRepository()
+	pr1 := repo.AddChange(forgetest.FakeChange{Head: "feat1"})
+	pr2 := repo.AddChange(forgetest.FakeChange{Head: "feat2"})
+	repo.Merge(pr1)
+	repo.MergeAfter(pr2, 3)
+
+	var got [][]forge.ChangeState
+	for range 5 {
+		states, err := repo.ChangesStates(ctx, []forge.ChangeID{pr1, pr2})
+		require.NoError(t, err)
+		got = append(got, states)
+	}
+
+	assert.Equal(t, [][]forge.ChangeState{
+		{forge.ChangeMerged, forge.ChangeOpen},
+		{forge.ChangeMerged, forge.ChangeOpen},
+		{forge.ChangeMerged, forge.ChangeOpen},
+		{forge.ChangeMerged, forge.ChangeMerged},
+		{forge.ChangeMerged, forge.ChangeMerged},
+	}, got)
+}
+
+func TestFakeRepository_TransitionAfter_sequence(t *testing.T) {
+	ctx := t.Context()
+	repo := forgetest.NewFakeRepository()
+	id := repo.AddChange(forgetest.FakeChange{Head: "feature"})
+	repo.TransitionAfter(id, 1, forge.ChangeClosed)
+	repo.TransitionAfter(id, 0, forge.ChangeOpen)
+
+	var got []forge.ChangeState
+	for range 4 {
+		states, err := repo.ChangesStates(ctx, []forge.ChangeID{id})
+		require.NoError(t, err)
+		got = append(got, states[0])
+	}
+
+	assert.Equal(t, []forge.ChangeState{
+		forge.ChangeOpen,
+		forge.ChangeClosed,
+		forge.ChangeOpen,
+		forge.ChangeOpen,
+	}, got)
+}
+
+func TestFakeRepository_comments(t *testing.T) {
+	ctx := t.Context()
+	repo := forgetest.NewFakeRepository()
+	id := repo.AddChange(forgetest.FakeChange{Head: "feature"})
+
+	c1, err := repo.PostChangeComment(ctx, id, "first")
+	require.NoError(t, err)
+	c2, err := repo.PostChangeComment(ctx, id, "second")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.UpdateChangeComment(ctx, c1, "first, edited"))
+	require.NoError(t, repo.DeleteChangeComment(ctx, c2))
+	assert.ErrorIs(t, repo.DeleteChangeComment(ctx, c2), forge.ErrNotFound)
+
+	assert.Equal(t, []forgetest.FakeComment{
+		{ID: 1, Change: 1, Body: "first, edited"},
+	}, repo.Comments(id))
+
+	var bodies []string
+	for item, err := range repo.ListChangeComments(ctx, id, &forge.ListChangeCommentsOptions{
+		BodyMatchesAll: []*regexp.Regexp{regexp.MustCompile(`edited`)},
+	}) {
+		require.NoError(t, err)
+		bodies = append(bodies, item.Body)
+	}
+	assert.Equal(t, []string{"first, edited"}, bodies)
+}
+
+func TestFakeRepository_FailNext(t *testing.T) {
+	ctx := t.Context()
+	repo := forgetest.NewFakeRepository()
+	giveErr := errors.New("great sadness")
+	repo.FailNext("SubmitChange", giveErr)
+
+	_, err := repo.SubmitChange(ctx, forge.SubmitChangeRequest{
+		Subject: "Add feature",
+		Base:    "main",
+		Head:    "feature",
+	})
+	assert.ErrorIs(t, err, giveErr)
+
+	_, err = repo.SubmitChange(ctx, forge.SubmitChangeRequest{
+		Subject: "Add feature",
+		Base:    "main",
+		Head:    "feature",
+	})
+	assert.NoError(t, err)
+}
+
+func TestFakeForge_changeMetadata(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	f := repo.Forge()
+	assert.Equal(t, forgetest.FakeForgeID, f.ID())
+
+	md, err := repo.NewChangeMetadata(t.Context(), forgetest.FakeChangeID(3))
+	require.NoError(t, err)
+	md.SetNavigationCommentID(forgetest.FakeCommentID(7))
+
+	data, err := f.MarshalChangeMetadata(md)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"number": 3, "nav_comment": 7}`, string(data))
+
+	got, err := f.UnmarshalChangeMetadata(data)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+
+	idData, err := f.MarshalChangeID(md.ChangeID())
+	require.NoError(t, err)
+	id, err := f.UnmarshalChangeID(json.RawMessage(idData))
+	require.NoError(t, err)
+	assert.Equal(t, forgetest.FakeChangeID(3), id)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"flag"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -98,22 +99,29 @@ type IntegrationConfig struct {
 	CloseChange CloseChangeFunc // required
 
 	// Reviewers is a list of usernames that can be added as reviewers to changes.
-	Reviewers []string // required
+	// This is required if the repository supports reviewers.
+	Reviewers []string
 
 	// Assignees is a list of usernames that can be assigned to changes.
-	Assignees []string // required
+	// This is required if the repository supports assignees.
+	Assignees []string
 
 	// SetCommentsPageSize sets the page size for listing comments.
 	// This is used to test pagination.
 	SetCommentsPageSize func(testing.TB, int) // required
 
-	// BaseBranchMayBeAbsent indicates whether the forge allows
-	// base branches to be absent when submitting changes.
-	// (GitLab does this. It's not clear why.)
-	BaseBranchMayBeAbsent bool // optional
+	// ForkRemoteURL is the Git remote URL of a fork of RemoteURL
+	// that changes can be proposed from.
+	//
+	// Tests that need a fork are skipped if this is not set.
+	ForkRemoteURL string
 }
 
 // RunIntegration runs integration tests with the given configuration.
+//
+// Tests for optional features are skipped
+// if the repository does not report support for them
+// in [forge.Repository.Capabilities].
 func RunIntegration(t *testing.T, config IntegrationConfig) {
 	suite := &integrationSuite{
 		Forge: config.Forge,
@@ -121,6 +129,7 @@ func RunIntegration(t *testing.T, config IntegrationConfig) {
 			Update: Update,
 		},
 		RemoteURL:           config.RemoteURL,
+		ForkRemoteURL:       config.ForkRemoteURL,
 		openRepository:      config.OpenRepository,
 		MergeChange:         config.MergeChange,
 		CloseChange:         config.CloseChange,
@@ -129,6 +138,14 @@ func RunIntegration(t *testing.T, config IntegrationConfig) {
 		SetCommentsPageSize: config.SetCommentsPageSize,
 	}
 
+	// This must run before the other tests
+	// so that they can be skipped if unsupported.
+	caps := forge.AllCapabilities
+	t.Run("Capabilities", func(t *testing.T) {
+		caps = suite.OpenRepository(t).Capabilities()
+		t.Logf("Capabilities: %+v", caps)
+	})
+
 	t.Run("SubmitEditChange", func(t *testing.T) {
 		t.Parallel()
 
@@ -142,6 +159,7 @@ func RunIntegration(t *testing.T, config IntegrationConfig) {
 	})
 
 	t.Run("SubmitEditDraft", func(t *testing.T) {
+		requireCapability(t, caps.Draft, "draft changes")
 		t.Parallel()
 
 		suite.TestSubmitChangeDraft(t)
@@ -153,12 +171,33 @@ func RunIntegration(t *testing.T, config IntegrationConfig) {
 		suite.TestChangeStates(t)
 	})
 
+	t.Run("ChangesDetails", func(t *testing.T) {
+		skipUnrecorded(t)
+		t.Parallel()
+
+		suite.TestChangesDetails(t)
+	})
+
 	t.Run("FindChangesByBranchDoesNotExist", func(t *testing.T) {
 		t.Parallel()
 
 		suite.TestFindChangesByBranchDoesNotExist(t)
 	})
 
+	t.Run("FindOpenChangeByHead", func(t *testing.T) {
+		skipUnrecorded(t)
+		t.Parallel()
+
+		suite.TestFindOpenChangeByHead(t)
+	})
+
+	t.Run("ChangeDiffStat", func(t *testing.T) {
+		skipUnrecorded(t)
+		t.Parallel()
+
+		suite.TestChangeDiffStat(t)
+	})
+
 	// NOTE: ListChangeTemplates cannot run in parallel
 	// because it modifies the main branch.
 	t.Run("ListChangeTemplates", func(t *testing.T) {
@@ -166,26 +205,28 @@ func RunIntegration(t *testing.T, config IntegrationConfig) {
 	})
 
 	t.Run("SubmitEditLabels", func(t *testing.T) {
+		requireCapability(t, caps.Labels, "labels")
 		t.Parallel()
 
 		suite.TestSubmitEditLabels(t)
 	})
 
-	if !config.BaseBranchMayBeAbsent {
-		t.Run("SubmitBaseDoesNotExist", func(t *testing.T) {
-			t.Parallel()
+	t.Run("SubmitBaseDoesNotExist", func(t *testing.T) {
+		requireCapability(t, caps.RejectsMissingBase, "rejecting changes against missing bases")
+		t.Parallel()
 
-			suite.TestSubmitBaseDoesNotExist(t)
-		})
-	}
+		suite.TestSubmitBaseDoesNotExist(t)
+	})
 
 	t.Run("SubmitEditReviewers", func(t *testing.T) {
+		requireCapability(t, caps.Reviewers, "reviewers")
 		t.Parallel()
 
 		suite.TestSubmitEditReviewers(t)
 	})
 
 	t.Run("SubmitEditAssignees", func(t *testing.T) {
+		requireCapability(t, caps.Assignees, "assignees")
 		t.Parallel()
 
 		suite.TestSubmitEditAssignees(t)
@@ -198,6 +239,34 @@ func RunIntegration(t *testing.T, config IntegrationConfig) {
 	})
 }
 
+// requireCapability skips the test if the repository
+// does not support the named feature.
+func requireCapability(t *testing.T, supported bool, feature string) {
+	t.Helper()
+
+	if !supported {
+		t.Skipf("Repository does not support %s", feature)
+	}
+}
+
+// skipUnrecorded skips the test in replay mode
+// if no HTTP interactions were recorded for it.
+//
+// Use this for tests added after the fixtures for a forge
+// were last recorded.
+func skipUnrecorded(t *testing.T) {
+	t.Helper()
+
+	if Update() {
+		return
+	}
+
+	fixture := filepath.Join("testdata", "fixtures", t.Name()+".yaml")
+	if _, err := os.Stat(fixture); errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("No fixture recorded at %s: run with -update to record it", fixture)
+	}
+}
+
 type integrationSuite struct {
 	Forge forge.Forge
 
@@ -211,6 +280,10 @@ type integrationSuite struct {
 	// Example: "https://github.com/abhinav/test-repo"
 	RemoteURL string
 
+	// ForkRemoteURL is the Git remote URL of a fork of RemoteURL.
+	// It may be empty.
+	ForkRemoteURL string
+
 	// MergeChange merges a change.
 	MergeChange MergeChangeFunc
 
@@ -245,10 +318,10 @@ func (s *integrationSuite) OpenRepository(t *testing.T) forge.Repository {
 }
 
 func (s *integrationSuite) TestSubmitEditChange(t *testing.T) {
+	ns := NewNamespace(t)
+
 	// Name of the branch we're working with.
-	branchFixture := fixturetest.New(s.Fixtures, "branch", func() string {
-		return randomString(8)
-	})
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
 	// Commit hash of the commit we pushed to the branch.
 	commitHashFixture, setCommitHash := fixturetest.Stored[string](s.Fixtures, "firstCommitHash")
 
@@ -262,12 +335,8 @@ func (s *integrationSuite) TestSubmitEditChange(t *testing.T) {
 		testRepo.CheckoutBranch(branchName)
 		testRepo.WriteFile(branchName+".txt", randomString(32))
 		hash := testRepo.AddAllAndCommit("commit from test")
-		testRepo.Push(branchName)
+		testRepo.PushBranch(branchName)
 		setCommitHash(hash.String())
-
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(branchName)
-		})
 	}
 	commitHash := commitHashFixture.Get(t)
 	t.Logf("Got commit hash: %s", commitHash)
@@ -315,13 +384,11 @@ func (s *integrationSuite) TestSubmitEditChange(t *testing.T) {
 // Changes can be submitted with a non-main base,
 // and then edited to change the base to main.
 func (s *integrationSuite) TestSubmitChangeBase(t *testing.T) {
+	ns := NewNamespace(t)
+
 	// Fixture for branch and base names
-	branchFixture := fixturetest.New(s.Fixtures, "branch", func() string {
-		return randomString(8)
-	})
-	baseFixture := fixturetest.New(s.Fixtures, "base", func() string {
-		return randomString(8)
-	})
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
+	baseFixture := fixturetest.New(s.Fixtures, "base", ns.Name)
 
 	branchName := branchFixture.Get(t)
 	baseName := baseFixture.Get(t)
@@ -331,21 +398,14 @@ func (s *integrationSuite) TestSubmitChangeBase(t *testing.T) {
 		testRepo := newTestRepository(t, s.RemoteURL)
 
 		// Push the base branch at current main position
-		testRepo.Push("main:" + baseName)
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(baseName)
-		})
+		testRepo.PushBranchFrom("main", baseName)
 
 		// Create and push the feature branch
 		testRepo.CreateBranch(branchName)
 		testRepo.CheckoutBranch(branchName)
 		testRepo.WriteFile(branchName+".txt", randomString(32))
 		testRepo.AddAllAndCommit("commit from test")
-		testRepo.Push(branchName)
-
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(branchName)
-		})
+		testRepo.PushBranch(branchName)
 	}
 
 	repo := s.OpenRepository(t)
@@ -379,9 +439,9 @@ func (s *integrationSuite) TestSubmitChangeBase(t *testing.T) {
 
 // Changes can be submitted as drafts, and edited to toggle draft status.
 func (s *integrationSuite) TestSubmitChangeDraft(t *testing.T) {
-	branchFixture := fixturetest.New(s.Fixtures, "branch", func() string {
-		return randomString(8)
-	})
+	ns := NewNamespace(t)
+
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
 	branchName := branchFixture.Get(t)
 	t.Logf("Creating branch: %s", branchName)
 
@@ -392,11 +452,7 @@ func (s *integrationSuite) TestSubmitChangeDraft(t *testing.T) {
 		testRepo.CheckoutBranch(branchName)
 		testRepo.WriteFile(branchName+".txt", randomString(32))
 		testRepo.AddAllAndCommit("commit from test")
-		testRepo.Push(branchName)
-
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(branchName)
-		})
+		testRepo.PushBranch(branchName)
 	}
 
 	repo := s.OpenRepository(t)
@@ -443,16 +499,12 @@ func (s *integrationSuite) TestSubmitChangeDraft(t *testing.T) {
 }
 
 func (s *integrationSuite) TestChangeStates(t *testing.T) {
+	ns := NewNamespace(t)
+
 	// We'll create 3 PRs and put them each in a different state.
-	openBranchFixture := fixturetest.New(s.Fixtures, "openBranch", func() string {
-		return randomString(8)
-	})
-	mergedBranchFixture := fixturetest.New(s.Fixtures, "mergedBranch", func() string {
-		return randomString(8)
-	})
-	closedBranchFixture := fixturetest.New(s.Fixtures, "closedBranch", func() string {
-		return randomString(8)
-	})
+	openBranchFixture := fixturetest.New(s.Fixtures, "openBranch", ns.Name)
+	mergedBranchFixture := fixturetest.New(s.Fixtures, "mergedBranch", ns.Name)
+	closedBranchFixture := fixturetest.New(s.Fixtures, "closedBranch", ns.Name)
 
 	openBranch := openBranchFixture.Get(t)
 	mergedBranch := mergedBranchFixture.Get(t)
@@ -471,14 +523,8 @@ func (s *integrationSuite) TestChangeStates(t *testing.T) {
 			testRepo.CheckoutBranch(branch)
 			testRepo.WriteFile(branch+".txt", randomString(32))
 			testRepo.AddAllAndCommit("commit for " + branch)
-			testRepo.Push(branch)
+			testRepo.PushBranch(branch)
 		}
-
-		t.Cleanup(func() {
-			for _, branch := range []string{openBranch, mergedBranch, closedBranch} {
-				testRepo.DeleteRemoteBranch(branch)
-			}
-		})
 	}
 
 	repo := s.OpenRepository(t)
@@ -526,6 +572,231 @@ func (s *integrationSuite) TestChangeStates(t *testing.T) {
 	}, states, "change states should match expected")
 }
 
+// ChangesDetails reports the base branch of each change,
+// and the merge commit of merged changes.
+func (s *integrationSuite) TestChangesDetails(t *testing.T) {
+	ns := NewNamespace(t)
+
+	openBranchFixture := fixturetest.New(s.Fixtures, "openBranch", ns.Name)
+	mergedBranchFixture := fixturetest.New(s.Fixtures, "mergedBranch", ns.Name)
+
+	openBranch := openBranchFixture.Get(t)
+	mergedBranch := mergedBranchFixture.Get(t)
+
+	t.Logf("Creating branches: %s, %s", openBranch, mergedBranch)
+
+	if Update() {
+		testRepo := newTestRepository(t, s.RemoteURL)
+
+		for _, branch := range []string{openBranch, mergedBranch} {
+			testRepo.CheckoutBranch("main")
+			testRepo.CreateBranch(branch)
+			testRepo.CheckoutBranch(branch)
+			testRepo.WriteFile(branch+".txt", randomString(32))
+			testRepo.AddAllAndCommit("commit for " + branch)
+			testRepo.PushBranch(branch)
+		}
+	}
+
+	repo := s.OpenRepository(t)
+
+	openChange, err := repo.SubmitChange(t.Context(), forge.SubmitChangeRequest{
+		Subject: "Open " + openBranch,
+		Body:    "Open change",
+		Base:    "main",
+		Head:    openBranch,
+	})
+	require.NoError(t, err, "error creating open change")
+
+	mergedChange, err := repo.SubmitChange(t.Context(), forge.SubmitChangeRequest{
+		Subject: "Merged " + mergedBranch,
+		Body:    "Merged change",
+		Base:    "main",
+		Head:    mergedBranch,
+	})
+	require.NoError(t, err, "error creating merged change")
+
+	s.MergeChange(t, repo, mergedChange.ID)
+
+	details, err := repo.ChangesDetails(t.Context(), []forge.ChangeID{
+		openChange.ID,
+		mergedChange.ID,
+	})
+	require.NoError(t, err, "error fetching change details")
+	require.Len(t, details, 2)
+
+	assert.Equal(t, forge.ChangeDetails{
+		State:    forge.ChangeOpen,
+		BaseName: "main",
+	}, details[0], "open change should not have a merge commit")
+
+	assert.Equal(t, forge.ChangeMerged, details[1].State)
+	assert.Equal(t, "main", details[1].BaseName)
+	assert.NotEmpty(t, details[1].MergeCommit, "merged change should report its merge commit")
+}
+
+// FindOpenChangeByHead finds changes by head branch,
+// optionally narrowed down by base branch and head repository.
+func (s *integrationSuite) TestFindOpenChangeByHead(t *testing.T) {
+	ns := NewNamespace(t)
+
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
+	baseFixture := fixturetest.New(s.Fixtures, "base", ns.Name)
+
+	branchName := branchFixture.Get(t)
+	baseName := baseFixture.Get(t)
+	t.Logf("Creating branch: %s with base: %s", branchName, baseName)
+
+	if Update() {
+		testRepo := newTestRepository(t, s.RemoteURL)
+		testRepo.PushBranchFrom("main", baseName)
+		testRepo.CreateBranch(branchName)
+		testRepo.CheckoutBranch(branchName)
+		testRepo.WriteFile(branchName+".txt", randomString(32))
+		testRepo.AddAllAndCommit("commit from test")
+		testRepo.PushBranch(branchName)
+
+		// A branch with the same name in the fork.
+		if s.ForkRemoteURL != "" {
+			forkRepo := newTestRepository(t, s.ForkRemoteURL)
+			forkRepo.CreateBranch(branchName)
+			forkRepo.CheckoutBranch(branchName)
+			forkRepo.WriteFile(branchName+".txt", randomString(32))
+			forkRepo.AddAllAndCommit("commit from fork")
+			forkRepo.PushBranch(branchName)
+		}
+	}
+
+	repo := s.OpenRepository(t)
+
+	change, err := repo.SubmitChange(t.Context(), forge.SubmitChangeRequest{
+		Subject: "Testing " + branchName,
+		Body:    "Test PR",
+		Base:    baseName,
+		Head:    branchName,
+	})
+	require.NoError(t, err, "error creating PR")
+
+	t.Run("HeadAndBase", func(t *testing.T) {
+		found, err := repo.FindOpenChangeByHead(t.Context(), forge.FindOpenChangeByHeadRequest{
+			Head: branchName,
+			Base: baseName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, change.ID.String(), found.ID.String())
+		assert.Equal(t, baseName, found.BaseName)
+		assert.Equal(t, forge.ChangeOpen, found.State)
+	})
+
+	t.Run("HeadAndOtherBase", func(t *testing.T) {
+		_, err := repo.FindOpenChangeByHead(t.Context(), forge.FindOpenChangeByHeadRequest{
+			Head: branchName,
+			Base: "main",
+		})
+		assert.ErrorIs(t, err, forge.ErrNotFound)
+	})
+
+	t.Run("HeadOnly", func(t *testing.T) {
+		found, err := repo.FindOpenChangeByHead(t.Context(), forge.FindOpenChangeByHeadRequest{
+			Head:   branchName,
+			Unique: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, change.ID.String(), found.ID.String())
+	})
+
+	t.Run("Fork", func(t *testing.T) {
+		if s.ForkRemoteURL == "" {
+			t.Skip("No fork configured")
+		}
+
+		forkID, err := s.Forge.ParseRemoteURL(s.ForkRemoteURL)
+		require.NoError(t, err)
+
+		forkChange, err := repo.SubmitChange(t.Context(), forge.SubmitChangeRequest{
+			Subject:        "Testing " + branchName + " from fork",
+			Body:           "Test PR from fork",
+			Base:           "main",
+			Head:           branchName,
+			HeadRepository: forkID,
+		})
+		require.NoError(t, err, "error creating PR from fork")
+
+		found, err := repo.FindOpenChangeByHead(t.Context(), forge.FindOpenChangeByHeadRequest{
+			Head:           branchName,
+			HeadRepository: forkID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, forkChange.ID.String(), found.ID.String())
+
+		// Changes from the fork don't match
+		// changes from this repository and vice versa.
+		found, err = repo.FindOpenChangeByHead(t.Context(), forge.FindOpenChangeByHeadRequest{
+			Head:   branchName,
+			Unique: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, change.ID.String(), found.ID.String())
+
+		_, err = repo.FindOpenChangeByHead(t.Context(), forge.FindOpenChangeByHeadRequest{
+			Head:           branchName,
+			HeadRepository: forkID,
+			Base:           baseName,
+		})
+		assert.ErrorIs(t, err, forge.ErrNotFound)
+	})
+}
+
+// ChangeDiffStat reports the lines added and removed
+// and the number of files changed between the base and the head.
+func (s *integrationSuite) TestChangeDiffStat(t *testing.T) {
+	ns := NewNamespace(t)
+
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
+	baseFixture := fixturetest.New(s.Fixtures, "base", ns.Name)
+
+	branchName := branchFixture.Get(t)
+	baseName := baseFixture.Get(t)
+	t.Logf("Creating branch: %s with base: %s", branchName, baseName)
+
+	if Update() {
+		testRepo := newTestRepository(t, s.RemoteURL)
+		testRepo.CreateBranch(baseName)
+		testRepo.CheckoutBranch(baseName)
+		testRepo.WriteFile(baseName+".txt", "one", "two", "three")
+		testRepo.AddAllAndCommit("base commit")
+		testRepo.PushBranch(baseName)
+
+		// Change one line in the existing file
+		// and add a new file with two lines:
+		// +3/-1 across 2 files.
+		testRepo.CreateBranch(branchName)
+		testRepo.CheckoutBranch(branchName)
+		testRepo.WriteFile(baseName+".txt", "one", "2", "three")
+		testRepo.WriteFile(branchName+".txt", "foo", "bar")
+		testRepo.AddAllAndCommit("head commit")
+		testRepo.PushBranch(branchName)
+	}
+
+	repo := s.OpenRepository(t)
+
+	change, err := repo.SubmitChange(t.Context(), forge.SubmitChangeRequest{
+		Subject: "Testing " + branchName,
+		Body:    "Test PR",
+		Base:    baseName,
+		Head:    branchName,
+	})
+	require.NoError(t, err, "error creating PR")
+
+	stat, err := repo.ChangeDiffStat(t.Context(), change.ID)
+	require.NoError(t, err, "error fetching diff stat")
+	assert.Equal(t, &forge.DiffStat{
+		Additions:    3,
+		Deletions:    1,
+		ChangedFiles: 2,
+	}, stat)
+}
+
 // FindChangesByBranch returns no error, and an empty slice
 // when the branch does not exist.
 func (s *integrationSuite) TestFindChangesByBranchDoesNotExist(t *testing.T) {
@@ -537,6 +808,8 @@ func (s *integrationSuite) TestFindChangesByBranchDoesNotExist(t *testing.T) {
 }
 
 func (s *integrationSuite) TestListChangeTemplates(t *testing.T) {
+	ns := NewNamespace(t)
+
 	// Get the template paths from the forge.
 	// We'll use the first non-.md path as the directory for templates.
 	templatePaths := s.Forge.ChangeTemplatePaths()
@@ -591,10 +864,10 @@ func (s *integrationSuite) TestListChangeTemplates(t *testing.T) {
 	t.Run("TemplatesPresent", func(t *testing.T) {
 		// Generate template names.
 		emptyTemplateFixture := fixturetest.New(s.Fixtures, "empty-template", func() string {
-			return randomString(8) + ".md"
+			return ns.Name() + ".md"
 		})
 		nonEmptyTemplateFixture := fixturetest.New(s.Fixtures, "non-empty-template", func() string {
-			return randomString(8) + ".md"
+			return ns.Name() + ".md"
 		})
 
 		emptyTemplateName := emptyTemplateFixture.Get(t)
@@ -654,23 +927,17 @@ func (s *integrationSuite) TestListChangeTemplates(t *testing.T) {
 }
 
 func (s *integrationSuite) TestSubmitEditLabels(t *testing.T) {
-	label1Fixture := fixturetest.New(s.Fixtures, "label1", func() string {
-		return randomString(8)
-	})
-	label2Fixture := fixturetest.New(s.Fixtures, "label2", func() string {
-		return randomString(8)
-	})
-	label3Fixture := fixturetest.New(s.Fixtures, "label3", func() string {
-		return randomString(8)
-	})
+	ns := NewNamespace(t)
+
+	label1Fixture := fixturetest.New(s.Fixtures, "label1", ns.Name)
+	label2Fixture := fixturetest.New(s.Fixtures, "label2", ns.Name)
+	label3Fixture := fixturetest.New(s.Fixtures, "label3", ns.Name)
 
 	label1 := label1Fixture.Get(t)
 	label2 := label2Fixture.Get(t)
 	label3 := label3Fixture.Get(t)
 
-	branchFixture := fixturetest.New(s.Fixtures, "branch", func() string {
-		return randomString(8)
-	})
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
 	branchName := branchFixture.Get(t)
 	t.Logf("Creating branch: %s", branchName)
 
@@ -681,11 +948,7 @@ func (s *integrationSuite) TestSubmitEditLabels(t *testing.T) {
 		testRepo.CheckoutBranch(branchName)
 		testRepo.WriteFile(branchName+".txt", randomString(32))
 		testRepo.AddAllAndCommit("commit from test")
-		testRepo.Push(branchName)
-
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(branchName)
-		})
+		testRepo.PushBranch(branchName)
 	}
 
 	repo := s.OpenRepository(t)
@@ -733,12 +996,10 @@ func (s *integrationSuite) TestSubmitEditLabels(t *testing.T) {
 }
 
 func (s *integrationSuite) TestSubmitBaseDoesNotExist(t *testing.T) {
-	branchFixture := fixturetest.New(s.Fixtures, "branch", func() string {
-		return randomString(8)
-	})
-	baseBranchFixture := fixturetest.New(s.Fixtures, "base-branch", func() string {
-		return randomString(8)
-	})
+	ns := NewNamespace(t)
+
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
+	baseBranchFixture := fixturetest.New(s.Fixtures, "base-branch", ns.Name)
 
 	branchName := branchFixture.Get(t)
 	baseBranchName := baseBranchFixture.Get(t)
@@ -751,11 +1012,7 @@ func (s *integrationSuite) TestSubmitBaseDoesNotExist(t *testing.T) {
 		testRepo.CheckoutBranch(branchName)
 		testRepo.WriteFile(branchName+".txt", randomString(32))
 		testRepo.AddAllAndCommit("commit from test")
-		testRepo.Push(branchName)
-
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(branchName)
-		})
+		testRepo.PushBranch(branchName)
 	}
 
 	repo := s.OpenRepository(t)
@@ -772,14 +1029,14 @@ func (s *integrationSuite) TestSubmitBaseDoesNotExist(t *testing.T) {
 }
 
 func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
+	ns := NewNamespace(t)
+
 	require.NotEmpty(t, s.Reviewers, "test requires at least one reviewer")
 
 	t.Run("SubmitWithReviewer", func(t *testing.T) {
 		t.Parallel()
 
-		branchFixture := fixturetest.New(s.Fixtures, "branch-with-reviewer", func() string {
-			return randomString(8)
-		})
+		branchFixture := fixturetest.New(s.Fixtures, "branch-with-reviewer", ns.Name)
 		branchName := branchFixture.Get(t)
 		t.Logf("Creating branch: %s", branchName)
 
@@ -790,11 +1047,7 @@ func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
 			testRepo.CheckoutBranch(branchName)
 			testRepo.WriteFile(branchName+".txt", randomString(32))
 			testRepo.AddAllAndCommit("commit from test")
-			testRepo.Push(branchName)
-
-			t.Cleanup(func() {
-				testRepo.DeleteRemoteBranch(branchName)
-			})
+			testRepo.PushBranch(branchName)
 		}
 
 		repo := s.OpenRepository(t)
@@ -819,9 +1072,7 @@ func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
 	t.Run("AddReviewer", func(t *testing.T) {
 		t.Parallel()
 
-		branchFixture := fixturetest.New(s.Fixtures, "branch-no-reviewer", func() string {
-			return randomString(8)
-		})
+		branchFixture := fixturetest.New(s.Fixtures, "branch-no-reviewer", ns.Name)
 		branchName := branchFixture.Get(t)
 		t.Logf("Creating branch: %s", branchName)
 
@@ -832,11 +1083,7 @@ func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
 			testRepo.CheckoutBranch(branchName)
 			testRepo.WriteFile(branchName+".txt", randomString(32))
 			testRepo.AddAllAndCommit("commit from test")
-			testRepo.Push(branchName)
-
-			t.Cleanup(func() {
-				testRepo.DeleteRemoteBranch(branchName)
-			})
+			testRepo.PushBranch(branchName)
 		}
 
 		repo := s.OpenRepository(t)
@@ -873,9 +1120,7 @@ func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
 		t.Run("AddReviewersOneByOne", func(t *testing.T) {
 			t.Parallel()
 
-			branchFixture := fixturetest.New(s.Fixtures, "branch-no-reviewer-one-by-one", func() string {
-				return randomString(8)
-			})
+			branchFixture := fixturetest.New(s.Fixtures, "branch-no-reviewer-one-by-one", ns.Name)
 			branchName := branchFixture.Get(t)
 			t.Logf("Creating branch: %s", branchName)
 
@@ -886,11 +1131,7 @@ func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
 				testRepo.CheckoutBranch(branchName)
 				testRepo.WriteFile(branchName+".txt", randomString(32))
 				testRepo.AddAllAndCommit("commit from test")
-				testRepo.Push(branchName)
-
-				t.Cleanup(func() {
-					testRepo.DeleteRemoteBranch(branchName)
-				})
+				testRepo.PushBranch(branchName)
 			}
 
 			repo := s.OpenRepository(t)
@@ -927,14 +1168,14 @@ func (s *integrationSuite) TestSubmitEditReviewers(t *testing.T) {
 }
 
 func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
+	ns := NewNamespace(t)
+
 	require.NotEmpty(t, s.Assignees, "test requires at least one assignee")
 
 	t.Run("SubmitWithAssignee", func(t *testing.T) {
 		t.Parallel()
 
-		branchFixture := fixturetest.New(s.Fixtures, "branch-with-assignee", func() string {
-			return randomString(8)
-		})
+		branchFixture := fixturetest.New(s.Fixtures, "branch-with-assignee", ns.Name)
 		branchName := branchFixture.Get(t)
 		t.Logf("Creating branch: %s", branchName)
 
@@ -945,11 +1186,7 @@ func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
 			testRepo.CheckoutBranch(branchName)
 			testRepo.WriteFile(branchName+".txt", randomString(32))
 			testRepo.AddAllAndCommit("commit from test")
-			testRepo.Push(branchName)
-
-			t.Cleanup(func() {
-				testRepo.DeleteRemoteBranch(branchName)
-			})
+			testRepo.PushBranch(branchName)
 		}
 
 		repo := s.OpenRepository(t)
@@ -982,9 +1219,7 @@ func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
 	t.Run("AddAssignee", func(t *testing.T) {
 		t.Parallel()
 
-		branchFixture := fixturetest.New(s.Fixtures, "branch-no-assignee", func() string {
-			return randomString(8)
-		})
+		branchFixture := fixturetest.New(s.Fixtures, "branch-no-assignee", ns.Name)
 		branchName := branchFixture.Get(t)
 		t.Logf("Creating branch: %s", branchName)
 
@@ -995,11 +1230,7 @@ func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
 			testRepo.CheckoutBranch(branchName)
 			testRepo.WriteFile(branchName+".txt", randomString(32))
 			testRepo.AddAllAndCommit("commit from test")
-			testRepo.Push(branchName)
-
-			t.Cleanup(func() {
-				testRepo.DeleteRemoteBranch(branchName)
-			})
+			testRepo.PushBranch(branchName)
 		}
 
 		repo := s.OpenRepository(t)
@@ -1036,9 +1267,7 @@ func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
 		t.Run("AddAssigneesOneByOne", func(t *testing.T) {
 			t.Parallel()
 
-			branchFixture := fixturetest.New(s.Fixtures, "branch-no-assignee-one-by-one", func() string {
-				return randomString(8)
-			})
+			branchFixture := fixturetest.New(s.Fixtures, "branch-no-assignee-one-by-one", ns.Name)
 
 			branchName := branchFixture.Get(t)
 			t.Logf("Creating branch: %s", branchName)
@@ -1050,11 +1279,7 @@ func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
 				testRepo.CheckoutBranch(branchName)
 				testRepo.WriteFile(branchName+".txt", randomString(32))
 				testRepo.AddAllAndCommit("commit from test")
-				testRepo.Push(branchName)
-
-				t.Cleanup(func() {
-					testRepo.DeleteRemoteBranch(branchName)
-				})
+				testRepo.PushBranch(branchName)
 			}
 
 			repo := s.OpenRepository(t)
@@ -1085,11 +1310,11 @@ func (s *integrationSuite) TestSubmitEditAssignees(t *testing.T) {
 }
 
 func (s *integrationSuite) TestChangeComments(t *testing.T) {
+	ns := NewNamespace(t)
+
 	const TotalComments = 10
 
-	branchFixture := fixturetest.New(s.Fixtures, "branch", func() string {
-		return randomString(8)
-	})
+	branchFixture := fixturetest.New(s.Fixtures, "branch", ns.Name)
 	branchName := branchFixture.Get(t)
 	t.Logf("Creating branch: %s", branchName)
 
@@ -1100,11 +1325,7 @@ func (s *integrationSuite) TestChangeComments(t *testing.T) {
 		testRepo.CheckoutBranch(branchName)
 		testRepo.WriteFile(branchName+".txt", randomString(32))
 		testRepo.AddAllAndCommit("commit from test")
-		testRepo.Push(branchName)
-
-		t.Cleanup(func() {
-			testRepo.DeleteRemoteBranch(branchName)
-		})
+		testRepo.PushBranch(branchName)
 	}
 
 	repo := s.OpenRepository(t)
@@ -1311,6 +1532,24 @@ func (r *testRepository) Push(refspec string) {
 	}), "error pushing refspec: %s", refspec)
 }
 
+// PushBranch pushes the given local branch to a branch of the same name
+// on origin, and deletes it from origin when the test finishes.
+func (r *testRepository) PushBranch(name string) {
+	r.PushBranchFrom(name, name)
+}
+
+// PushBranchFrom pushes the given commit-ish to the named branch on origin,
+// and deletes the branch from origin when the test finishes.
+//
+// Use this instead of Push for branches that only the test uses
+// so that they don't pile up on the shared test repository.
+func (r *testRepository) PushBranchFrom(from, name string) {
+	r.Push(from + ":" + name)
+	r.t.Cleanup(func() {
+		r.DeleteRemoteBranch(name)
+	})
+}
+
 // DeleteRemoteBranch deletes a remote branch.
 func (r *testRepository) DeleteRemoteBranch(name string) {
 	ctx := r.ctx()
@@ -0,0 +1,80 @@
+package forgetest
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// _namespacePrefix is the prefix for all names generated by [Namespace].
+// Resources with this prefix on a test repository
+// were created by integration tests.
+const _namespacePrefix = "gs-test-"
+
+// _namespaceSlugLen is the maximum length of the portion of a name
+// derived from the test name.
+// This keeps names within limits imposed by forges,
+// e.g. GitHub's 50 character limit on label names.
+const _namespaceSlugLen = 24
+
+// Namespace generates names for resources that a test creates
+// on a shared test repository when recording fixtures,
+// e.g. branches, labels, and files.
+//
+// Names include the name of the test and a random suffix,
+// e.g. "gs-test-submiteditchange-x8Hq2mZa",
+// so that tests recording fixtures concurrently
+// (in the same process or in separate ones)
+// never pick the same name,
+// and resources left behind by an interrupted run
+// can be traced back to the test that created them.
+type Namespace struct {
+	prefix string
+}
+
+// NewNamespace builds a Namespace for the given test.
+func NewNamespace(t testing.TB) *Namespace {
+	return &Namespace{
+		prefix: _namespacePrefix + namespaceSlug(t.Name()) + "-",
+	}
+}
+
+// Name returns a new name in the namespace.
+// Each call returns a different name.
+//
+// It may be used as the generator for a fixture:
+//
+//	fixturetest.New(fixtures, "branch", ns.Name)
+func (ns *Namespace) Name() string {
+	return ns.prefix + randomString(8)
+}
+
+// namespaceSlug turns a test name into a short, lowercase string
+// that is safe for use in branch names, labels, and file names.
+//
+// The name of the top-level test is dropped if the test has subtests
+// as it's usually shared by all tests in a suite.
+func namespaceSlug(testName string) string {
+	if _, sub, ok := strings.Cut(testName, "/"); ok {
+		testName = sub
+	}
+
+	var (
+		slug strings.Builder
+		dash bool // whether the last rune written was a dash
+	)
+	for _, r := range strings.ToLower(testName) {
+		if slug.Len() >= _namespaceSlugLen {
+			break
+		}
+
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			slug.WriteRune(r)
+			dash = false
+		} else if !dash && slug.Len() > 0 {
+			slug.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(slug.String(), "-")
+}
@@ -0,0 +1,43 @@
+package forgetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	t.Run("SubmitEditChange", func(t *testing.T) {
+		ns := NewNamespace(t)
+
+		a, b := ns.Name(), ns.Name()
+		assert.NotEqual(t, a, b, "names must be unique")
+		for _, name := range []string{a, b} {
+			assert.True(t, strings.HasPrefix(name, "gs-test-submiteditchange-"), "got %q", name)
+			assert.Len(t, name, len("gs-test-submiteditchange-")+8)
+		}
+	})
+}
+
+func TestNamespaceSlug(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{"TestFoo", "testfoo"},
+		{"TestIntegration/SubmitEditChange", "submiteditchange"},
+		{"TestIntegration/SubmitEditReviewers/AddReviewer", "submiteditreviewers-addr"},
+		{"TestIntegration/Some_Name#01", "some-name-01"},
+		{"TestIntegration/A very long name that goes on", "a-very-long-name-that-go"},
+		{"TestIntegration/Trailing/", "trailing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got := namespaceSlug(tt.give)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), _namespaceSlugLen)
+		})
+	}
+}
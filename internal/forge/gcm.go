@@ -8,10 +8,16 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"go.abhg.dev/gs/internal/xec"
 )
 
+// CredentialHelperTimeout is the maximum time an external program
+// that hands out credentials (e.g. 'gh auth token', 'git credential fill')
+// may take before it's assumed to be stuck and stopped.
+const CredentialHelperTimeout = 2 * time.Minute
+
 // GCMCredential holds credentials retrieved from git-credential-manager.
 type GCMCredential struct {
 	// Username is the account identifier (may be empty).
@@ -30,6 +36,7 @@ func LoadGCMCredential(ctx context.Context, forgeURL string) (*GCMCredential, er
 
 	output, err := xec.Command(ctx, nil, "git", "credential", "fill").
 		WithStdinString(input).
+		WithTimeout(CredentialHelperTimeout).
 		Output()
 	if err != nil {
 		return nil, fmt.Errorf("git credential fill: %w", err)
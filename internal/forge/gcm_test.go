@@ -58,6 +58,17 @@ username=user
 `,
 			wantErr: true,
 		},
+		{
+			// GCM on Windows writes CRLF line endings.
+			name: "BitbucketCRLF",
+			output: "protocol=https\r\n" +
+				"host=bitbucket.org\r\n" +
+				"path=example/repo.git\r\n" +
+				"username=user@example.com\r\n" +
+				"password=ATCTT3xFfGN0oauth-token\r\n",
+			wantUsername: "user@example.com",
+			wantPassword: "ATCTT3xFfGN0oauth-token",
+		},
 		{
 			name: "MalformedLines",
 			output: `protocol=https
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.abhg.dev/gs/internal/forge"
+	"golang.org/x/oauth2"
+)
+
+// _appTokenRefreshMargin is how long before an installation token expires
+// that a new one is requested.
+// Tokens last an hour, so this leaves plenty of room
+// for requests that are in flight when it's refreshed.
+const _appTokenRefreshMargin = 5 * time.Minute
+
+// AppCredentials identify a GitHub App installation to authenticate as.
+type AppCredentials struct {
+	// AppID is the ID of the GitHub App.
+	AppID string // required
+
+	// PrivateKey is the private key of the GitHub App.
+	PrivateKey *rsa.PrivateKey // required
+
+	// InstallationID is the ID of the installation of the App
+	// to request tokens for.
+	//
+	// If zero, the installation is looked up
+	// from the repository being accessed.
+	InstallationID int64
+}
+
+// loadAppCredentials builds AppCredentials from the forge options.
+// It returns nil if a GitHub App is not configured.
+func (f *Forge) loadAppCredentials() (*AppCredentials, error) {
+	if f.Options.AppID == "" {
+		return nil, nil
+	}
+	if f.Options.AppPrivateKey == "" {
+		return nil, errors.New("GITHUB_APP_ID is set but GITHUB_APP_PRIVATE_KEY is not")
+	}
+
+	pemBytes := []byte(f.Options.AppPrivateKey)
+	if !strings.HasPrefix(strings.TrimSpace(f.Options.AppPrivateKey), "-----BEGIN") {
+		// Not a PEM block, so it's a path.
+		bs, err := os.ReadFile(f.Options.AppPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("read GitHub App private key: %w", err)
+		}
+		pemBytes = bs
+	}
+
+	key, err := parseAppPrivateKey(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse GitHub App private key: %w", err)
+	}
+
+	return &AppCredentials{
+		AppID:          f.Options.AppID,
+		PrivateKey:     key,
+		InstallationID: f.Options.AppInstallationID,
+	}, nil
+}
+
+// parseAppPrivateKey parses a PEM-encoded RSA private key.
+// GitHub issues PKCS #1 keys, but PKCS #8 keys are accepted
+// in case the key was converted.
+func parseAppPrivateKey(bs []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA key, got %T", key)
+	}
+	return rsaKey, nil
+}
+
+// AppTokenSource is an oauth2 token source that issues
+// installation access tokens for a GitHub App.
+//
+// Installation tokens expire after an hour.
+// Wrap the token source with [oauth2.ReuseTokenSource]
+// to use a token until it's about to expire.
+type AppTokenSource struct {
+	// Credentials identify the App installation.
+	Credentials *AppCredentials // required
+
+	// APIURL is the base URL of GitHub's REST API.
+	APIURL string // required
+
+	// Owner and Repo name the repository being accessed.
+	// They're used to look up the installation
+	// if Credentials doesn't specify one.
+	Owner, Repo string
+
+	// Client is the HTTP client used to make requests.
+	// Defaults to http.DefaultClient.
+	Client *http.Client
+
+	now func() time.Time // for testing
+}
+
+var _ oauth2.TokenSource = (*AppTokenSource)(nil)
+
+// Token requests a new installation access token.
+func (ts *AppTokenSource) Token() (*oauth2.Token, error) {
+	// No caller-provided context here; use Background.
+	ctx := context.Background()
+
+	jwt, err := ts.jwt()
+	if err != nil {
+		return nil, fmt.Errorf("sign GitHub App token: %w", err)
+	}
+
+	installationID := ts.Credentials.InstallationID
+	if installationID == 0 {
+		var res struct {
+			ID int64 `json:"id"`
+		}
+		path := "repos/" + url.PathEscape(ts.Owner) + "/" + url.PathEscape(ts.Repo) + "/installation"
+		if err := ts.do(ctx, http.MethodGet, path, jwt, &res); err != nil {
+			return nil, fmt.Errorf("find GitHub App installation for %v/%v: %w", ts.Owner, ts.Repo, err)
+		}
+		installationID = res.ID
+	}
+
+	var res struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := "app/installations/" + strconv.FormatInt(installationID, 10) + "/access_tokens"
+	if err := ts.do(ctx, http.MethodPost, path, jwt, &res); err != nil {
+		return nil, fmt.Errorf("create GitHub App installation token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: res.Token,
+		TokenType:   "token",
+		Expiry:      res.ExpiresAt,
+	}, nil
+}
+
+// jwt builds a JSON Web Token that authenticates as the App itself.
+// These are only good for requesting installation tokens.
+func (ts *AppTokenSource) jwt() (string, error) {
+	now := time.Now
+	if ts.now != nil {
+		now = ts.now
+	}
+	issuedAt := now().Add(-time.Minute) // allow for clock drift
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": issuedAt.Unix(),
+		// GitHub rejects tokens that last longer than 10 minutes.
+		"exp": issuedAt.Add(9 * time.Minute).Unix(),
+		"iss": ts.Credentials.AppID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, ts.Credentials.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + enc.EncodeToString(sig), nil
+}
+
+// do makes a REST API request authenticated with the given JWT
+// and decodes the JSON response into res.
+func (ts *AppTokenSource) do(ctx context.Context, method, path, jwt string, res any) error {
+	u, err := url.JoinPath(ts.APIURL, path)
+	if err != nil {
+		return fmt.Errorf("build URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	client := ts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, _maxErrorBody))
+		msg := resp.Status
+		if body := strings.TrimSpace(string(body)); body != "" {
+			msg += ": " + body
+		}
+		return forge.WrapError(errors.New(msg), forge.StatusError(resp.StatusCode))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// restAPIURL returns the base URL of GitHub's REST API.
+// GitHub Enterprise serves it from /api/v3 next to /api/graphql.
+func (f *Forge) restAPIURL() string {
+	apiURL := strings.TrimSuffix(f.APIURL(), "/")
+	if apiURL != DefaultAPIURL && strings.HasSuffix(apiURL, "/api") {
+		return apiURL + "/v3"
+	}
+	return apiURL
+}
@@ -0,0 +1,249 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/secret"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/ui"
+	"golang.org/x/oauth2"
+)
+
+func newTestAppKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	// Small key to keep the test fast. Never do this for real.
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	return key
+}
+
+// verifyAppJWT verifies a JWT signed by AppTokenSource
+// and returns its claims.
+func verifyAppJWT(t *testing.T, key *rsa.PrivateKey, jwt string) map[string]any {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+
+	bs, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(bs, &claims))
+	return claims
+}
+
+func TestAppTokenSource(t *testing.T) {
+	key := newTestAppKey(t)
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	var tokens atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/alice/example/installation", func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyAppJWT(t, key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		assert.Equal(t, "123", claims["iss"])
+		assert.Equal(t, float64(now.Add(-time.Minute).Unix()), claims["iat"])
+		assert.Equal(t, float64(now.Add(8*time.Minute).Unix()), claims["exp"])
+
+		_, _ = w.Write([]byte(`{"id": 42}`))
+	})
+	mux.HandleFunc("POST /app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		verifyAppJWT(t, key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+
+		n := tokens.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token":      "installation-token-" + strconv.Itoa(int(n)),
+			"expires_at": expiresAt,
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	ts := &AppTokenSource{
+		Credentials: &AppCredentials{AppID: "123", PrivateKey: key},
+		APIURL:      srv.URL,
+		Owner:       "alice",
+		Repo:        "example",
+		Client:      srv.Client(),
+		now:         func() time.Time { return now },
+	}
+
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "installation-token-1", tok.AccessToken)
+	assert.True(t, expiresAt.Equal(tok.Expiry))
+
+	t.Run("InstallationID", func(t *testing.T) {
+		ts := *ts
+		ts.Credentials = &AppCredentials{AppID: "123", PrivateKey: key, InstallationID: 42}
+		ts.Owner, ts.Repo = "", "" // lookup would fail
+
+		tok, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "installation-token-2", tok.AccessToken)
+	})
+
+	t.Run("NotInstalled", func(t *testing.T) {
+		ts := *ts
+		ts.Repo = "other"
+
+		_, err := ts.Token()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, forge.ErrNotFound)
+		assert.ErrorContains(t, err, "find GitHub App installation for alice/other")
+	})
+}
+
+func TestAppTokenSource_refresh(t *testing.T) {
+	key := newTestAppKey(t)
+
+	var tokens atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /app/installations/42/access_tokens", func(w http.ResponseWriter, _ *http.Request) {
+		tokens.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token": "installation-token",
+			// Already within the refresh margin.
+			"expires_at": time.Now().Add(_appTokenRefreshMargin / 2),
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	ts := oauth2.ReuseTokenSourceWithExpiry(nil, &AppTokenSource{
+		Credentials: &AppCredentials{AppID: "123", PrivateKey: key, InstallationID: 42},
+		APIURL:      srv.URL,
+		Client:      srv.Client(),
+	}, _appTokenRefreshMargin)
+
+	for range 3 {
+		_, err := ts.Token()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), tokens.Load(), "expiring token must be refreshed")
+}
+
+func TestParseAppPrivateKey(t *testing.T) {
+	key := newTestAppKey(t)
+
+	t.Run("PKCS1", func(t *testing.T) {
+		bs := pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})
+		got, err := parseAppPrivateKey(bs)
+		require.NoError(t, err)
+		assert.True(t, key.Equal(got))
+	})
+
+	t.Run("PKCS8", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		bs := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		got, err := parseAppPrivateKey(bs)
+		require.NoError(t, err)
+		assert.True(t, key.Equal(got))
+	})
+
+	t.Run("NotPEM", func(t *testing.T) {
+		_, err := parseAppPrivateKey([]byte("not a key"))
+		assert.ErrorContains(t, err, "no PEM data found")
+	})
+}
+
+func TestForge_appAuthentication(t *testing.T) {
+	key := newTestAppKey(t)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyPath, []byte(keyPEM), 0o600))
+
+	for _, privateKey := range []string{keyPEM, keyPath} {
+		f := Forge{
+			Options: Options{
+				AppID:             "123",
+				AppPrivateKey:     privateKey,
+				AppInstallationID: 42,
+			},
+			Log: silog.Nop(),
+		}
+
+		var stash secret.MemoryStash
+		tok, err := f.LoadAuthenticationToken(&stash)
+		require.NoError(t, err)
+
+		app := tok.(*AuthenticationToken).app
+		require.NotNil(t, app)
+		assert.Equal(t, "123", app.AppID)
+		assert.Equal(t, int64(42), app.InstallationID)
+		assert.True(t, key.Equal(app.PrivateKey))
+
+		// App credentials are never stored.
+		require.NoError(t, f.SaveAuthenticationToken(&stash, tok))
+		_, err = stash.LoadSecret(f.URL(), "token")
+		assert.ErrorIs(t, err, secret.ErrNotFound)
+
+		_, err = f.AuthenticationFlow(t.Context(), &ui.FileView{W: t.Output()})
+		assert.ErrorContains(t, err, "already authenticated")
+	}
+
+	t.Run("MissingKey", func(t *testing.T) {
+		f := Forge{Options: Options{AppID: "123"}, Log: silog.Nop()}
+		_, err := f.LoadAuthenticationToken(new(secret.MemoryStash))
+		assert.ErrorContains(t, err, "GITHUB_APP_PRIVATE_KEY is not")
+	})
+}
+
+func TestForge_restAPIURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{name: "Default", want: "https://api.github.com"},
+		{
+			name: "Enterprise",
+			opts: Options{URL: "https://github.example.com"},
+			want: "https://github.example.com/api/v3",
+		},
+		{
+			name: "ExplicitAPI",
+			opts: Options{APIURL: "https://api.example.com"},
+			want: "https://api.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Forge{Options: tt.opts}
+			assert.Equal(t, tt.want, f.restAPIURL())
+		})
+	}
+}
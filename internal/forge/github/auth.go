@@ -34,6 +34,10 @@ type AuthenticationToken struct {
 
 	// AccessToken is the GitHub access token.
 	AccessToken string `json:"access_token,omitempty"`
+
+	// app is set if authenticating as a GitHub App installation.
+	// These credentials come from the environment and are never stored.
+	app *AppCredentials
 }
 
 var _ forge.AuthenticationToken = (*AuthenticationToken)(nil)
@@ -73,6 +77,11 @@ func (f *Forge) AuthenticationFlow(ctx context.Context, view ui.View) (forge.Aut
 		log.Error("Unset GITHUB_TOKEN to login with a different method.")
 		return nil, errors.New("already authenticated")
 	}
+	if f.Options.AppID != "" {
+		log.Error("Already authenticated with GITHUB_APP_ID.")
+		log.Error("Unset GITHUB_APP_ID to login with a different method.")
+		return nil, errors.New("already authenticated")
+	}
 
 	oauthEndpoint, err := f.oauth2Endpoint()
 	if err != nil {
@@ -98,6 +107,9 @@ func (f *Forge) SaveAuthenticationToken(stash secret.Stash, t forge.Authenticati
 		// we should not save it to the stash.
 		return nil
 	}
+	if ght.app != nil {
+		return nil
+	}
 
 	bs, err := json.Marshal(ght)
 	if err != nil {
@@ -111,13 +123,22 @@ func (f *Forge) SaveAuthenticationToken(stash secret.Stash, t forge.Authenticati
 // LoadAuthenticationToken loads the authentication token from the stash.
 // Priority order:
 //  1. Environment variable (GITHUB_TOKEN)
-//  2. Stored token in secret stash
-//  3. git-credential-manager (GCM)
+//  2. GitHub App installation (GITHUB_APP_ID)
+//  3. Stored token in secret stash
+//  4. git-credential-manager (GCM)
 func (f *Forge) LoadAuthenticationToken(stash secret.Stash) (forge.AuthenticationToken, error) {
 	if f.Options.Token != "" {
 		return &AuthenticationToken{AccessToken: f.Options.Token}, nil
 	}
 
+	app, err := f.loadAppCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("load GitHub App credentials: %w", err)
+	}
+	if app != nil {
+		return &AuthenticationToken{app: app}, nil
+	}
+
 	var errs []error
 
 	// Try stored token.
@@ -417,7 +438,9 @@ type CLIAuthenticator struct {
 
 // Authenticate checks if the user is authenticated with GitHub CLI.
 func (a *CLIAuthenticator) Authenticate(ctx context.Context, _ ui.View) (*AuthenticationToken, error) {
-	cmd := xec.Command(ctx, nil, a.GH, "auth", "token").WithExecer(a.execer)
+	cmd := xec.Command(ctx, nil, a.GH, "auth", "token").
+		WithExecer(a.execer).
+		WithTimeout(forge.CredentialHelperTimeout)
 	if err := cmd.Run(); err != nil {
 		var exitErr *xec.ExitError
 		if errors.As(err, &exitErr) {
@@ -89,6 +89,24 @@ func (*Forge) UnmarshalChangeID(data json.RawMessage) (forge.ChangeID, error) {
 	return &pr, nil
 }
 
+// FormatChangeID formats a PR for display, e.g. "#123".
+func (*Forge) FormatChangeID(cid forge.ChangeID) string {
+	if pr, ok := cid.(*PR); ok {
+		return fmt.Sprintf("#%d", pr.Number)
+	}
+	return cid.String()
+}
+
+// ParseChangeID parses a PR number ("123" or "#123")
+// or a pull request URL.
+func (*Forge) ParseChangeID(s string) (forge.ChangeID, error) {
+	num, err := forge.ParseChangeNumber(s, []string{"#"}, []string{"pull"})
+	if err != nil {
+		return nil, err
+	}
+	return &PR{Number: int(num)}, nil
+}
+
 // PR uniquely identifies a PR in a GitHub repository.
 // It's a valid forge.ChangeID.
 type PR struct {
@@ -30,6 +30,27 @@ func TestPRString(t *testing.T) {
 	}).String())
 }
 
+func TestForge_FormatChangeID(t *testing.T) {
+	var f Forge
+	assert.Equal(t, "#42", f.FormatChangeID(&PR{Number: 42}))
+}
+
+func TestForge_ParseChangeID(t *testing.T) {
+	var f Forge
+	for _, give := range []string{
+		"42",
+		"#42",
+		"https://github.com/example/repo/pull/42",
+	} {
+		got, err := f.ParseChangeID(give)
+		require.NoError(t, err, "input: %q", give)
+		assert.Equal(t, &PR{Number: 42}, got, "input: %q", give)
+	}
+
+	_, err := f.ParseChangeID("https://github.com/example/repo/issues/42")
+	assert.Error(t, err)
+}
+
 func TestPRMarshal(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1,9 +1,13 @@
 package github
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/shurcooL/githubv4"
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/graphqlutil"
 )
 
@@ -11,6 +15,61 @@ func newGitHubEnterpriseClient(
 	url string,
 	httpClient *http.Client,
 ) *githubv4.Client {
-	httpClient.Transport = graphqlutil.WrapTransport(httpClient.Transport)
+	httpClient.Transport = &errorTransport{
+		t: graphqlutil.WrapTransport(httpClient.Transport),
+	}
 	return githubv4.NewEnterpriseClient(url, httpClient)
 }
+
+// errorTransport maps errors reported by the GitHub API
+// to the errors defined in the forge package.
+//
+// It must wrap a transport returned by graphqlutil.WrapTransport.
+type errorTransport struct {
+	t http.RoundTripper
+}
+
+var _ http.RoundTripper = (*errorTransport)(nil)
+
+// _maxErrorBody is the maximum number of bytes of a failed response
+// to include in an error message.
+const _maxErrorBody = 4 << 10
+
+func (t *errorTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	res, err := t.t.RoundTrip(r)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// The GraphQL client would fail with an unstructured error
+		// for these responses anyway.
+		// graphqlutil has already handled rate limited responses.
+		body, _ := io.ReadAll(io.LimitReader(res.Body, _maxErrorBody))
+		_ = res.Body.Close()
+
+		msg := res.Status
+		if body := strings.TrimSpace(string(body)); body != "" {
+			msg += ": " + body
+		}
+		return nil, forge.WrapError(errors.New(msg), forge.StatusError(res.StatusCode))
+
+	default:
+		return res, nil
+	}
+}
+
+// mapError maps GraphQL errors to the errors defined in the forge package.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, graphqlutil.ErrRateLimited):
+		return forge.WrapError(err, forge.ErrRateLimited)
+	case errors.Is(err, graphqlutil.ErrForbidden):
+		return forge.WrapError(err, forge.ErrPermission)
+	case errors.Is(err, graphqlutil.ErrNotFound):
+		return forge.WrapError(err, forge.ErrNotFound)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/netcheck"
+)
+
+func TestClientErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+
+		want error
+	}{
+		{
+			name:   "Unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"message": "Bad credentials"}`,
+			want:   forge.ErrUnauthorized,
+		},
+		{
+			name:   "Forbidden",
+			status: http.StatusForbidden,
+			body:   `{"message": "Resource not accessible by integration"}`,
+			want:   forge.ErrPermission,
+		},
+		{
+			name:   "SecondaryRateLimit",
+			status: http.StatusForbidden,
+			header: map[string]string{"Retry-After": "60"},
+			body:   `{"message": "You have exceeded a secondary rate limit."}`,
+			want:   forge.ErrRateLimited,
+		},
+		{
+			name:   "GraphQLForbidden",
+			status: http.StatusOK,
+			body:   `{"errors": [{"type": "FORBIDDEN", "message": "Permission denied."}]}`,
+			want:   forge.ErrPermission,
+		},
+		{
+			name:   "GraphQLNotFound",
+			status: http.StatusOK,
+			body:   `{"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository."}]}`,
+			want:   forge.ErrNotFound,
+		},
+		{
+			name:   "GraphQLRateLimited",
+			status: http.StatusOK,
+			body:   `{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded."}]}`,
+			want:   forge.ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer srv.Close()
+
+			client := newGitHubEnterpriseClient(srv.URL, &http.Client{})
+
+			var q struct {
+				Viewer struct {
+					Login string `graphql:"login"`
+				} `graphql:"viewer"`
+			}
+			err := client.Query(t.Context(), &q, nil)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	t.Run("UnauthorizedMessage", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"message": "Bad credentials"}`)
+		}))
+		defer srv.Close()
+
+		var q struct {
+			Viewer struct {
+				Login string `graphql:"login"`
+			} `graphql:"viewer"`
+		}
+		err := newGitHubEnterpriseClient(srv.URL, &http.Client{}).Query(t.Context(), &q, nil)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `401 Unauthorized: {"message": "Bad credentials"}`)
+		assert.True(t, netcheck.IsUnauthorized(err))
+	})
+}
@@ -63,7 +63,7 @@ func (r *Repository) PostChangeComment(
 		Body:      githubv4.String(markdown),
 	}
 
-	if err := r.client.Mutate(ctx, &m, input, nil); err != nil {
+	if err := r.mutateWithRetry(ctx, &m, input, nil); err != nil {
 		return nil, fmt.Errorf("post comment: %w", err)
 	}
 
@@ -96,7 +96,7 @@ func (r *Repository) UpdateChangeComment(
 		Body: githubv4.String(markdown),
 		ID:   gqlID,
 	}
-	if err := r.client.Mutate(ctx, &m, input, nil); err != nil {
+	if err := r.mutateWithRetry(ctx, &m, input, nil); err != nil {
 		if errors.Is(err, graphqlutil.ErrNotFound) {
 			return fmt.Errorf("update comment: %w", forge.ErrNotFound)
 		}
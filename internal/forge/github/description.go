@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// ChangeDescription reports the title and body of a pull request.
+func (r *Repository) ChangeDescription(ctx context.Context, id forge.ChangeID) (*forge.ChangeDescription, error) {
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				Title githubv4.String `graphql:"title"`
+				Body  githubv4.String `graphql:"body"`
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+
+	pr := mustPR(id)
+	if err := r.client.Query(ctx, &q, map[string]any{
+		"owner":  githubv4.String(r.owner),
+		"repo":   githubv4.String(r.repo),
+		"number": githubv4.Int(pr.Number),
+	}); err != nil {
+		return nil, fmt.Errorf("retrieve description: %w", err)
+	}
+
+	return &forge.ChangeDescription{
+		Subject: string(q.Repository.PullRequest.Title),
+		Body:    string(q.Repository.PullRequest.Body),
+	}, nil
+}
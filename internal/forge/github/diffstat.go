@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// ChangeDiffStat reports the number of lines added and deleted,
+// and the number of files changed by a pull request.
+func (r *Repository) ChangeDiffStat(ctx context.Context, id forge.ChangeID) (*forge.DiffStat, error) {
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				Additions    int `graphql:"additions"`
+				Deletions    int `graphql:"deletions"`
+				ChangedFiles int `graphql:"changedFiles"`
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+
+	pr := mustPR(id)
+	if err := r.client.Query(ctx, &q, map[string]any{
+		"owner":  githubv4.String(r.owner),
+		"repo":   githubv4.String(r.repo),
+		"number": githubv4.Int(pr.Number),
+	}); err != nil {
+		return nil, fmt.Errorf("retrieve diff stat: %w", err)
+	}
+
+	stat := q.Repository.PullRequest
+	return &forge.DiffStat{
+		Additions:    stat.Additions,
+		Deletions:    stat.Deletions,
+		ChangedFiles: stat.ChangedFiles,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestChangeDiffStat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"number": float64(42),
+		}, req.Variables)
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"additions":    12,
+						"deletions":    3,
+						"changedFiles": 2,
+					},
+				},
+			},
+		}))
+	}))
+	defer srv.Close()
+
+	repo, err := newRepository(
+		t.Context(), new(Forge),
+		"owner", "repo",
+		silogtest.New(t),
+		githubv4.NewEnterpriseClient(srv.URL, nil),
+		"repoID",
+	)
+	require.NoError(t, err)
+
+	stat, err := repo.ChangeDiffStat(t.Context(), &PR{Number: 42})
+	require.NoError(t, err)
+	assert.Equal(t, &forge.DiffStat{
+		Additions:    12,
+		Deletions:    3,
+		ChangedFiles: 2,
+	}, stat)
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/shurcooL/githubv4"
 	"go.abhg.dev/gs/internal/forge"
@@ -16,6 +17,7 @@ type findPRNode struct {
 	Title       githubv4.String           `graphql:"title"`
 	State       githubv4.PullRequestState `graphql:"state"`
 	HeadRefOid  githubv4.GitObjectID      `graphql:"headRefOid"`
+	HeadRefName githubv4.String           `graphql:"headRefName"`
 	BaseRefName githubv4.String           `graphql:"baseRefName"`
 	IsDraft     githubv4.Boolean          `graphql:"isDraft"`
 	Labels      struct {
@@ -76,6 +78,7 @@ func (n *findPRNode) toFindChangeItem() *forge.FindChangeItem {
 		Subject:   string(n.Title),
 		BaseName:  string(n.BaseRefName),
 		HeadHash:  git.Hash(n.HeadRefOid),
+		HeadName:  string(n.HeadRefName),
 		Draft:     bool(n.IsDraft),
 		Labels:    labels,
 		Reviewers: reviewers,
@@ -153,6 +156,66 @@ func (r *Repository) FindChangesByBranch(ctx context.Context, branch string, opt
 	return changes, nil
 }
 
+// FindOpenChangeByHead searches for an open change
+// with the given head branch and, optionally, base branch.
+func (r *Repository) FindOpenChangeByHead(ctx context.Context, req forge.FindOpenChangeByHeadRequest) (*forge.FindChangeItem, error) {
+	headOwner, headRepo := r.owner, r.repo
+	if req.HeadRepository != nil {
+		rid := mustRepositoryID(req.HeadRepository)
+		headOwner, headRepo = rid.owner, rid.name
+	}
+
+	var q struct {
+		Repository struct {
+			PullRequests struct {
+				Nodes []struct {
+					findPRNode
+
+					// headRefName matches branches in all forks.
+					// Use this to filter to the right one.
+					HeadRepository struct {
+						Name  githubv4.String `graphql:"name"`
+						Owner struct {
+							Login githubv4.String `graphql:"login"`
+						} `graphql:"owner"`
+					} `graphql:"headRepository"`
+				} `graphql:"nodes"`
+			} `graphql:"pullRequests(first: $limit, headRefName: $head, baseRefName: $base, states: [OPEN], orderBy: {field: UPDATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+
+	var base *githubv4.String
+	if req.Base != "" {
+		base = githubv4.NewString(githubv4.String(req.Base))
+	}
+
+	if err := r.client.Query(ctx, &q, map[string]any{
+		"owner": githubv4.String(r.owner),
+		"repo":  githubv4.String(r.repo),
+		"head":  githubv4.String(req.Head),
+		"base":  base,
+		"limit": githubv4.Int(10),
+	}); err != nil {
+		return nil, fmt.Errorf("find open change by head: %w", err)
+	}
+
+	var matches []*forge.FindChangeItem
+	for _, node := range q.Repository.PullRequests.Nodes {
+		if strings.EqualFold(string(node.HeadRepository.Owner.Login), headOwner) &&
+			strings.EqualFold(string(node.HeadRepository.Name), headRepo) {
+			matches = append(matches, node.toFindChangeItem())
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, forge.ErrNotFound
+	case len(matches) > 1 && req.Unique:
+		return nil, &forge.AmbiguousChangeError{Changes: matches}
+	default:
+		return matches[0], nil
+	}
+}
+
 // FindChangeByID searches for a change with the given ID.
 func (r *Repository) FindChangeByID(ctx context.Context, id forge.ChangeID) (*forge.FindChangeItem, error) {
 	var q struct {
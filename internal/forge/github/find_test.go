@@ -0,0 +1,164 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestFindOpenChangeByHead(t *testing.T) {
+	type prNode struct {
+		Number      int    `json:"number"`
+		URL         string `json:"url"`
+		State       string `json:"state"`
+		HeadRefName string `json:"headRefName"`
+		BaseRefName string `json:"baseRefName"`
+
+		HeadRepository struct {
+			Name  string `json:"name"`
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"headRepository"`
+	}
+
+	newNode := func(number int, base, owner string) prNode {
+		node := prNode{
+			Number:      number,
+			URL:         "https://github.com/owner/repo/pull/1",
+			State:       "OPEN",
+			HeadRefName: "feature",
+			BaseRefName: base,
+		}
+		node.HeadRepository.Name = "repo"
+		node.HeadRepository.Owner.Login = owner
+		return node
+	}
+
+	fork := &RepositoryID{owner: "contributor", name: "repo"}
+
+	tests := []struct {
+		name  string
+		req   forge.FindOpenChangeByHeadRequest
+		nodes []prNode // most recently updated first
+
+		wantBase   any // base variable sent to the API
+		wantNumber int // 0 if not found
+		ambiguous  bool
+	}{
+		{
+			name:       "HeadAndBase",
+			req:        forge.FindOpenChangeByHeadRequest{Head: "feature", Base: "main"},
+			nodes:      []prNode{newNode(1, "main", "owner")},
+			wantBase:   "main",
+			wantNumber: 1,
+		},
+		{
+			name: "HeadOnly",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature"},
+			nodes: []prNode{
+				newNode(2, "develop", "owner"),
+				newNode(1, "main", "owner"),
+			},
+			wantNumber: 2,
+		},
+		{
+			name: "SkipsForks",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature"},
+			nodes: []prNode{
+				newNode(2, "main", "contributor"),
+				newNode(1, "main", "owner"),
+			},
+			wantNumber: 1,
+		},
+		{
+			name: "Fork",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", HeadRepository: fork},
+			nodes: []prNode{
+				newNode(2, "main", "contributor"),
+				newNode(1, "main", "owner"),
+			},
+			wantNumber: 2,
+		},
+		{
+			name:  "NotFound",
+			req:   forge.FindOpenChangeByHeadRequest{Head: "feature", HeadRepository: fork},
+			nodes: []prNode{newNode(1, "main", "owner")},
+		},
+		{
+			name: "Unique",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Unique: true},
+			nodes: []prNode{
+				newNode(2, "main", "contributor"),
+				newNode(1, "main", "owner"),
+			},
+			wantNumber: 1,
+		},
+		{
+			name: "Ambiguous",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Unique: true},
+			nodes: []prNode{
+				newNode(2, "develop", "owner"),
+				newNode(1, "main", "owner"),
+			},
+			ambiguous: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Variables map[string]any `json:"variables"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "owner", req.Variables["owner"])
+				assert.Equal(t, "repo", req.Variables["repo"])
+				assert.Equal(t, "feature", req.Variables["head"])
+				assert.Equal(t, tt.wantBase, req.Variables["base"])
+
+				assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+					"data": map[string]any{
+						"repository": map[string]any{
+							"pullRequests": map[string]any{
+								"nodes": tt.nodes,
+							},
+						},
+					},
+				}))
+			}))
+			defer srv.Close()
+
+			repo, err := newRepository(
+				t.Context(), new(Forge),
+				"owner", "repo",
+				silogtest.New(t),
+				githubv4.NewEnterpriseClient(srv.URL, nil),
+				"repoID",
+			)
+			require.NoError(t, err)
+
+			change, err := repo.FindOpenChangeByHead(t.Context(), tt.req)
+			switch {
+			case tt.ambiguous:
+				var ambiguousErr *forge.AmbiguousChangeError
+				require.ErrorAs(t, err, &ambiguousErr)
+				assert.Len(t, ambiguousErr.Changes, len(tt.nodes))
+
+			case tt.wantNumber == 0:
+				assert.ErrorIs(t, err, forge.ErrNotFound)
+
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantNumber, mustPR(change.ID).Number)
+			}
+		})
+	}
+}
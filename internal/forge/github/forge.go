@@ -6,11 +6,13 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/shurcooL/githubv4"
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/forge/forgeurl"
+	"go.abhg.dev/gs/internal/httplog"
 	"go.abhg.dev/gs/internal/silog"
 	"golang.org/x/oauth2"
 )
@@ -36,6 +38,26 @@ type Options struct {
 	// Token is a fixed token used to authenticate with GitHub.
 	// This may be used to skip the login flow.
 	Token string `name:"github-token" hidden:"" env:"GITHUB_TOKEN" help:"GitHub API token"`
+
+	// AppID, AppPrivateKey, and AppInstallationID
+	// authenticate as a GitHub App installation,
+	// e.g. for bots that don't have a user account.
+	AppID             string `name:"github-app-id" hidden:"" env:"GITHUB_APP_ID" help:"ID of a GitHub App to authenticate as"`
+	AppPrivateKey     string `name:"github-app-private-key" hidden:"" env:"GITHUB_APP_PRIVATE_KEY" help:"Private key of the GitHub App in PEM format, or the path to a file containing it"`
+	AppInstallationID int64  `name:"github-app-installation-id" hidden:"" env:"GITHUB_APP_INSTALLATION_ID" help:"ID of the GitHub App installation. Looked up from the repository if unset."`
+
+	// HTTPHeaders, ClientCert, and ClientKey configure API requests
+	// for instances behind authenticating proxies.
+	// See [forge.HTTPOptions] for details.
+	HTTPHeaders []string `name:"github-http-header" hidden:"" config:"forge.github.httpHeader" sep:"\n" placeholder:"NAME: VALUE" help:"Additional header to send with GitHub API requests. May be repeated."`
+	ClientCert  string   `name:"github-client-cert" hidden:"" config:"forge.github.clientCert" placeholder:"FILE" help:"PEM-encoded client certificate to present to GitHub"`
+	ClientKey   string   `name:"github-client-key" hidden:"" config:"forge.github.clientKey" placeholder:"FILE" help:"PEM-encoded private key for the client certificate"`
+
+	// Proxy and CABundle configure API requests for networks
+	// that route traffic through a proxy, or intercept TLS connections.
+	// See [forge.HTTPOptions] for details.
+	Proxy    string `name:"github-proxy" hidden:"" config:"forge.github.proxy" env:"GITHUB_PROXY" placeholder:"URL" help:"Proxy to use for GitHub API requests. Defaults to HTTPS_PROXY."`
+	CABundle string `name:"github-ca-bundle" hidden:"" config:"forge.github.caBundle" env:"GITHUB_CA_BUNDLE" placeholder:"FILE" help:"PEM-encoded certificates of additional certificate authorities to trust for GitHub API requests"`
 }
 
 // Forge builds a GitHub Forge.
@@ -44,9 +66,17 @@ type Forge struct {
 
 	// Log specifies the logger to use.
 	Log *silog.Logger
+
+	// HTTPMiddleware wraps the transport used for API requests,
+	// e.g. to sign requests.
+	HTTPMiddleware []forge.HTTPMiddleware
 }
 
-var _ forge.Forge = (*Forge)(nil)
+var (
+	_ forge.Forge             = (*Forge)(nil)
+	_ forge.WithAPIURL        = (*Forge)(nil)
+	_ forge.WithHTTPTransport = (*Forge)(nil)
+)
 
 func (f *Forge) logger() *silog.Logger {
 	if f.Log == nil {
@@ -80,6 +110,18 @@ func (f *Forge) APIURL() string {
 	return DefaultAPIURL
 }
 
+// HTTPTransport returns the transport used for API requests.
+func (f *Forge) HTTPTransport() (http.RoundTripper, error) {
+	return (&forge.HTTPOptions{
+		Headers:    f.Options.HTTPHeaders,
+		ClientCert: f.Options.ClientCert,
+		ClientKey:  f.Options.ClientKey,
+		Proxy:      f.Options.Proxy,
+		CABundle:   f.Options.CABundle,
+		Middleware: f.HTTPMiddleware,
+	}).Transport(nil)
+}
+
 // ID reports a unique key for this forge.
 func (*Forge) ID() string { return "github" }
 
@@ -105,8 +147,25 @@ func (f *Forge) ParseRemoteURL(remoteURL string) (forge.RepositoryID, error) {
 func (f *Forge) OpenRepository(ctx context.Context, tok forge.AuthenticationToken, id forge.RepositoryID) (forge.Repository, error) {
 	rid := mustRepositoryID(id)
 
-	tokenSource := tok.(*AuthenticationToken).tokenSource()
-	ghc, err := newGitHubv4Client(ctx, f.APIURL(), tokenSource)
+	transport, err := f.HTTPTransport()
+	if err != nil {
+		return nil, fmt.Errorf("configure HTTP transport: %w", err)
+	}
+
+	ght := tok.(*AuthenticationToken)
+	tokenSource := ght.tokenSource()
+	if ght.app != nil {
+		tokenSource = oauth2.ReuseTokenSourceWithExpiry(nil, &AppTokenSource{
+			Credentials: ght.app,
+			APIURL:      f.restAPIURL(),
+			Owner:       rid.owner,
+			Repo:        rid.name,
+			Client: &http.Client{
+				Transport: httplog.WrapTransport(transport, f.logger()),
+			},
+		}, _appTokenRefreshMargin)
+	}
+	ghc, err := newGitHubv4Client(f.APIURL(), tokenSource, transport, f.logger())
 	if err != nil {
 		return nil, fmt.Errorf("create GitHub client: %w", err)
 	}
@@ -142,13 +201,23 @@ func (rid *RepositoryID) ChangeURL(id forge.ChangeID) string {
 	return fmt.Sprintf("%s/%s/%s/pull/%d", rid.url, owner, repo, prNum)
 }
 
-func newGitHubv4Client(ctx context.Context, apiURL string, tokenSource oauth2.TokenSource) (*githubv4.Client, error) {
+func newGitHubv4Client(
+	apiURL string,
+	tokenSource oauth2.TokenSource,
+	transport http.RoundTripper, // optional
+	log *silog.Logger,
+) (*githubv4.Client, error) {
 	graphQLAPIURL, err := url.JoinPath(apiURL, "/graphql")
 	if err != nil {
 		return nil, fmt.Errorf("build GraphQL API URL: %w", err)
 	}
 
-	httpClient := oauth2.NewClient(ctx, tokenSource)
+	httpClient := &http.Client{
+		Transport: httplog.WrapTransport(&oauth2.Transport{
+			Source: oauth2.ReuseTokenSource(nil, tokenSource),
+			Base:   transport,
+		}, log),
+	}
 	return newGitHubEnterpriseClient(graphQLAPIURL, httpClient), nil
 }
 
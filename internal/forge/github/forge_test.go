@@ -1,10 +1,14 @@
 package github
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog"
 )
 
 func TestURLs(t *testing.T) {
@@ -196,3 +200,43 @@ func TestChangeURL(t *testing.T) {
 	got := repoID.ChangeURL(&PR{Number: 123})
 	assert.Equal(t, "https://github.com/example/repo/pull/123", got)
 }
+
+func TestForge_OpenRepository_httpOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/graphql", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "secret", r.Header.Get("X-Proxy-Token"))
+		assert.Equal(t, "yes", r.Header.Get("X-Signed"))
+		_, _ = w.Write([]byte(`{"data": {"repository": {"id": "R_1"}}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	f := Forge{
+		Options: Options{
+			URL:         srv.URL,
+			HTTPHeaders: []string{"X-Proxy-Token: secret"},
+		},
+		HTTPMiddleware: []forge.HTTPMiddleware{
+			func(next http.RoundTripper) http.RoundTripper {
+				return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					req = req.Clone(req.Context())
+					req.Header.Set("X-Signed", "yes")
+					return next.RoundTrip(req)
+				})
+			},
+		},
+		Log: silog.Nop(),
+	}
+
+	repoID, err := f.ParseRemoteURL(srv.URL + "/alice/example.git")
+	require.NoError(t, err)
+
+	_, err = f.OpenRepository(t.Context(), &AuthenticationToken{AccessToken: "token"}, repoID)
+	require.NoError(t, err)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
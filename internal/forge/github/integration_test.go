@@ -2,7 +2,6 @@ package github_test
 
 import (
 	"context"
-	"crypto/rand"
 	"net/http"
 	"os"
 	"testing"
@@ -131,7 +130,7 @@ func TestIntegration(t *testing.T) {
 }
 
 func TestIntegration_Repository_LabelCreateDelete(t *testing.T) {
-	label := fixturetest.New(_fixtures, "label1", func() string { return randomString(8) }).Get(t)
+	label := fixturetest.New(_fixtures, "label1", forgetest.NewNamespace(t).Name).Get(t)
 
 	rec := newRecorder(t, t.Name())
 	ghc := newGitHubClient(rec.GetDefaultClient())
@@ -186,17 +185,3 @@ func TestIntegration_Repository_notFoundError(t *testing.T) {
 		assert.Contains(t, gqlError.Message, "abhinav/does-not-exist-repo")
 	}
 }
-
-const _alnum = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-
-// randomString generates a random alphanumeric string of length n.
-func randomString(n int) string {
-	b := make([]byte, n)
-	for i := range b {
-		var buf [1]byte
-		_, _ = rand.Read(buf[:])
-		idx := int(buf[0]) % len(_alnum)
-		b[i] = _alnum[idx]
-	}
-	return string(b)
-}
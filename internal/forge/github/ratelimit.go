@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"go.abhg.dev/gs/internal/graphqlutil"
+)
+
+var (
+	// _rateLimitRetries is the number of times a mutation is retried
+	// after it's rejected because of a rate limit.
+	_rateLimitRetries = 3
+
+	// _rateLimitBackoff is how long to wait before the first retry
+	// if GitHub doesn't say how long to wait.
+	// The wait doubles with each retry.
+	_rateLimitBackoff = 2 * time.Second
+
+	// _maxRateLimitWait is the longest we'll wait before a retry.
+	// Mutations that GitHub asks us to wait longer for are not retried.
+	_maxRateLimitWait = time.Minute
+)
+
+// mutateWithRetry runs a GraphQL mutation,
+// retrying it with backoff if GitHub rejects it
+// because of a primary or secondary rate limit.
+//
+// GitHub applies secondary rate limits to bursts of mutations,
+// e.g. when updating navigation comments for a large stack.
+func (r *Repository) mutateWithRetry(
+	ctx context.Context,
+	m any,
+	input githubv4.Input,
+	variables map[string]any,
+) error {
+	backoff := _rateLimitBackoff
+	for attempt := 0; ; attempt++ {
+		err := r.client.Mutate(ctx, m, input, variables)
+		if err == nil || !errors.Is(err, graphqlutil.ErrRateLimited) || attempt >= _rateLimitRetries {
+			return err
+		}
+
+		wait := backoff
+		var rateErr *graphqlutil.RateLimitError
+		if errors.As(err, &rateErr) && rateErr.RetryAfter > 0 {
+			wait = rateErr.RetryAfter
+		}
+		if wait > _maxRateLimitWait {
+			return err
+		}
+
+		r.log.Warn("Rate limited by GitHub, retrying", "after", wait, "error", err)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
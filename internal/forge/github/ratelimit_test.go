@@ -0,0 +1,91 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/graphqlutil"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/testing/stub"
+)
+
+func TestUpdateChangeComment_secondaryRateLimit(t *testing.T) {
+	t.Cleanup(stub.Value(&_rateLimitBackoff, time.Millisecond))
+
+	tests := []struct {
+		name     string
+		failures int // number of rate limited responses
+
+		wantErr      bool
+		wantRequests int
+	}{
+		{name: "NoFailures", failures: 0, wantRequests: 1},
+		{name: "Recovers", failures: 2, wantRequests: 3},
+		{name: "GivesUp", failures: 10, wantErr: true, wantRequests: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				if requests <= tt.failures {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = io.WriteString(w, `{"message": "You have exceeded a secondary rate limit."}`)
+					return
+				}
+
+				_, _ = io.WriteString(w, `{"data": {"updateIssueComment": {"issueComment": {"id": "commentID"}}}}`)
+			}))
+			defer srv.Close()
+
+			repo, err := newRepository(
+				t.Context(), new(Forge),
+				"owner", "repo",
+				silogtest.New(t),
+				newGitHubEnterpriseClient(srv.URL, &http.Client{}),
+				"repoID",
+			)
+			require.NoError(t, err)
+
+			err = repo.UpdateChangeComment(t.Context(), &PRComment{GQLID: "commentID"}, "hello")
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, graphqlutil.ErrRateLimited)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestUpdateChangeComment_rateLimitTooLong(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"message": "You have exceeded a secondary rate limit."}`)
+	}))
+	defer srv.Close()
+
+	repo, err := newRepository(
+		t.Context(), new(Forge),
+		"owner", "repo",
+		silogtest.New(t),
+		newGitHubEnterpriseClient(srv.URL, &http.Client{}),
+		"repoID",
+	)
+	require.NoError(t, err)
+
+	err = repo.UpdateChangeComment(t.Context(), &PRComment{GQLID: "commentID"}, "hello")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, graphqlutil.ErrRateLimited)
+	assert.Equal(t, 1, requests, "should not wait an hour to retry")
+}
@@ -58,6 +58,11 @@ func newRepository(
 // Forge returns the forge this repository belongs to.
 func (r *Repository) Forge() forge.Forge { return r.forge }
 
+// Capabilities reports the features supported by GitHub.
+func (r *Repository) Capabilities() forge.Capabilities {
+	return forge.AllCapabilities
+}
+
 // userID looks up a user's GraphQL ID by login.
 func (r *Repository) userID(ctx context.Context, login string) (githubv4.ID, error) {
 	var query struct {
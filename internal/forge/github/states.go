@@ -6,6 +6,7 @@ import (
 
 	"github.com/shurcooL/githubv4"
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
 )
 
 // ChangesStates retrieves the states of the given changes in bulk.
@@ -18,14 +19,9 @@ func (r *Repository) ChangesStates(ctx context.Context, ids []forge.ChangeID) ([
 		} `graphql:"nodes(ids: $ids)"`
 	}
 
-	gqlIDs := make([]githubv4.ID, len(ids))
-	for i, id := range ids {
-		pr := mustPR(id)
-		var err error
-		gqlIDs[i], err = r.graphQLID(ctx, pr)
-		if err != nil {
-			return nil, fmt.Errorf("resolve ID %v: %w", id, err)
-		}
+	gqlIDs, err := r.graphQLIDs(ctx, ids)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := r.client.Query(ctx, &q, map[string]any{"ids": gqlIDs}); err != nil {
@@ -39,3 +35,52 @@ func (r *Repository) ChangesStates(ctx context.Context, ids []forge.ChangeID) ([
 
 	return states, nil
 }
+
+// ChangesDetails retrieves the states of the given changes in bulk,
+// along with the merge commits of merged changes.
+func (r *Repository) ChangesDetails(ctx context.Context, ids []forge.ChangeID) ([]forge.ChangeDetails, error) {
+	var q struct {
+		Nodes []struct {
+			PullRequest struct {
+				State       githubv4.PullRequestState `graphql:"state"`
+				BaseRefName githubv4.String           `graphql:"baseRefName"`
+				MergeCommit *struct {
+					OID githubv4.GitObjectID `graphql:"oid"`
+				} `graphql:"mergeCommit"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"nodes(ids: $ids)"`
+	}
+
+	gqlIDs, err := r.graphQLIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.client.Query(ctx, &q, map[string]any{"ids": gqlIDs}); err != nil {
+		return nil, fmt.Errorf("retrieve change details: %w", err)
+	}
+
+	details := make([]forge.ChangeDetails, len(ids))
+	for i, pr := range q.Nodes {
+		details[i].State = forgeChangeState(pr.PullRequest.State)
+		details[i].BaseName = string(pr.PullRequest.BaseRefName)
+		if mc := pr.PullRequest.MergeCommit; mc != nil && details[i].State == forge.ChangeMerged {
+			details[i].MergeCommit = git.Hash(mc.OID)
+		}
+	}
+
+	return details, nil
+}
+
+// graphQLIDs resolves the GraphQL node IDs of the given pull requests.
+func (r *Repository) graphQLIDs(ctx context.Context, ids []forge.ChangeID) ([]githubv4.ID, error) {
+	gqlIDs := make([]githubv4.ID, len(ids))
+	for i, id := range ids {
+		var err error
+		gqlIDs[i], err = r.graphQLID(ctx, mustPR(id))
+		if err != nil {
+			return nil, fmt.Errorf("resolve ID %v: %w", id, err)
+		}
+	}
+	return gqlIDs, nil
+}
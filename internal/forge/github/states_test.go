@@ -0,0 +1,68 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestChangesDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []any{"PR_1", "PR_2", "PR_3"}, req.Variables["ids"])
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"nodes": []any{
+					map[string]any{
+						"state":       "OPEN",
+						"baseRefName": "main",
+						"mergeCommit": nil,
+					},
+					map[string]any{
+						"state":       "MERGED",
+						"baseRefName": "main",
+						"mergeCommit": map[string]any{"oid": "abc123"},
+					},
+					map[string]any{
+						"state":       "CLOSED",
+						"baseRefName": "feature",
+						"mergeCommit": nil,
+					},
+				},
+			},
+		}))
+	}))
+	defer srv.Close()
+
+	repo, err := newRepository(
+		t.Context(), new(Forge),
+		"owner", "repo",
+		silogtest.New(t),
+		githubv4.NewEnterpriseClient(srv.URL, nil),
+		"repoID",
+	)
+	require.NoError(t, err)
+
+	details, err := repo.ChangesDetails(t.Context(), []forge.ChangeID{
+		&PR{Number: 1, GQLID: "PR_1"},
+		&PR{Number: 2, GQLID: "PR_2"},
+		&PR{Number: 3, GQLID: "PR_3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []forge.ChangeDetails{
+		{State: forge.ChangeOpen, BaseName: "main"},
+		{State: forge.ChangeMerged, BaseName: "main", MergeCommit: "abc123"},
+		{State: forge.ChangeClosed, BaseName: "feature"},
+	}, details)
+}
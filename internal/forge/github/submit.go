@@ -28,6 +28,14 @@ func (r *Repository) SubmitChange(ctx context.Context, req forge.SubmitChangeReq
 		BaseRefName:  githubv4.String(req.Base),
 		HeadRefName:  githubv4.String(req.Head),
 	}
+	if req.HeadRepository != nil {
+		// For cross-repository pull requests,
+		// the head branch must be namespaced with the fork's owner.
+		headRepo := mustRepositoryID(req.HeadRepository)
+		if headRepo.owner != r.owner || headRepo.name != r.repo {
+			input.HeadRefName = githubv4.String(headRepo.owner + ":" + req.Head)
+		}
+	}
 	if req.Body != "" {
 		input.Body = (*githubv4.String)(&req.Body)
 	}
@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/shurcooL/githubv4"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// PRReviewThread is a ChangeThreadID for a GitHub PR review thread.
+type PRReviewThread struct {
+	GQLID githubv4.ID `json:"gqlID"`
+}
+
+var _ forge.ChangeThreadID = (*PRReviewThread)(nil)
+
+func (t *PRReviewThread) String() string {
+	return fmt.Sprint(t.GQLID)
+}
+
+func mustPRReviewThread(id forge.ChangeThreadID) *PRReviewThread {
+	t, ok := id.(*PRReviewThread)
+	if !ok {
+		panic(fmt.Sprintf("unexpected PR review thread type: %T", id))
+	}
+	return t
+}
+
+var _ forge.WithReviewThreads = (*Repository)(nil)
+
+// _listChangeThreadsPageSize is the number of review threads
+// requested per page.
+const _listChangeThreadsPageSize = 50
+
+// ListChangeThreads lists review threads on a PR.
+func (r *Repository) ListChangeThreads(
+	ctx context.Context,
+	id forge.ChangeID,
+) iter.Seq2[*forge.ChangeThread, error] {
+	gqlID, err := r.graphQLID(ctx, mustPR(id))
+	if err != nil {
+		return func(yield func(*forge.ChangeThread, error) bool) {
+			yield(nil, err)
+		}
+	}
+
+	return func(yield func(*forge.ChangeThread, error) bool) {
+		var q struct {
+			Node struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						PageInfo struct {
+							EndCursor   githubv4.String `graphql:"endCursor"`
+							HasNextPage bool            `graphql:"hasNextPage"`
+						} `graphql:"pageInfo"`
+
+						Nodes []struct {
+							ID         githubv4.ID `graphql:"id"`
+							Path       string      `graphql:"path"`
+							Line       *int        `graphql:"line"`
+							IsResolved bool        `graphql:"isResolved"`
+							IsOutdated bool        `graphql:"isOutdated"`
+
+							Comments struct {
+								Nodes []struct {
+									Body   string `graphql:"body"`
+									Author struct {
+										Login string `graphql:"login"`
+									} `graphql:"author"`
+								} `graphql:"nodes"`
+							} `graphql:"comments(first: 1)"`
+						} `graphql:"nodes"`
+					} `graphql:"reviewThreads(first: $first, after: $after)"`
+				} `graphql:"... on PullRequest"`
+			} `graphql:"node(id: $id)"`
+		}
+
+		variables := map[string]any{
+			"id":    gqlID,
+			"first": githubv4.Int(_listChangeThreadsPageSize),
+			"after": (*githubv4.String)(nil),
+		}
+
+		for pageNum := 1; true; pageNum++ {
+			if err := r.client.Query(ctx, &q, variables); err != nil {
+				yield(nil, fmt.Errorf("list review threads (page %d): %w", pageNum, err))
+				return
+			}
+
+			threads := q.Node.PullRequest.ReviewThreads
+			for _, node := range threads.Nodes {
+				thread := &forge.ChangeThread{
+					ID:       &PRReviewThread{GQLID: node.ID},
+					Path:     node.Path,
+					Resolved: node.IsResolved,
+					Outdated: node.IsOutdated,
+				}
+				if node.Line != nil {
+					thread.Line = *node.Line
+				}
+				if comments := node.Comments.Nodes; len(comments) > 0 {
+					thread.Body = comments[0].Body
+					thread.Author = comments[0].Author.Login
+				}
+
+				if !yield(thread, nil) {
+					return
+				}
+			}
+
+			if !threads.PageInfo.HasNextPage {
+				return
+			}
+			variables["after"] = threads.PageInfo.EndCursor
+		}
+	}
+}
+
+// ResolveThread resolves or unresolves a review thread on a PR.
+func (r *Repository) ResolveThread(ctx context.Context, id forge.ChangeThreadID, resolved bool) error {
+	threadID := mustPRReviewThread(id).GQLID
+
+	if resolved {
+		var m struct {
+			ResolveReviewThread struct {
+				Thread struct {
+					ID githubv4.ID `graphql:"id"`
+				} `graphql:"thread"`
+			} `graphql:"resolveReviewThread(input: $input)"`
+		}
+		input := githubv4.ResolveReviewThreadInput{ThreadID: threadID}
+		if err := r.mutateWithRetry(ctx, &m, input, nil); err != nil {
+			return fmt.Errorf("resolve review thread: %w", err)
+		}
+	} else {
+		var m struct {
+			UnresolveReviewThread struct {
+				Thread struct {
+					ID githubv4.ID `graphql:"id"`
+				} `graphql:"thread"`
+			} `graphql:"unresolveReviewThread(input: $input)"`
+		}
+		input := githubv4.UnresolveReviewThreadInput{ThreadID: threadID}
+		if err := r.mutateWithRetry(ctx, &m, input, nil); err != nil {
+			return fmt.Errorf("unresolve review thread: %w", err)
+		}
+	}
+
+	r.log.Debug("Updated review thread", "id", threadID, "resolved", resolved)
+	return nil
+}
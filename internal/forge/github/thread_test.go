@@ -0,0 +1,130 @@
+package github
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestListChangeThreads(t *testing.T) {
+	response := map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"reviewThreads": map[string]any{
+					"pageInfo": map[string]any{
+						"hasNextPage": false,
+					},
+					"nodes": []map[string]any{
+						{
+							"id":         "thread1",
+							"path":       "main.go",
+							"line":       42,
+							"isResolved": false,
+							"isOutdated": true,
+							"comments": map[string]any{
+								"nodes": []map[string]any{
+									{"body": "typo", "author": map[string]any{"login": "bob"}},
+								},
+							},
+						},
+						{
+							"id":         "thread2",
+							"path":       "README.md",
+							"line":       nil,
+							"isResolved": true,
+							"comments":   map[string]any{"nodes": []any{}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer srv.Close()
+
+	repo, err := newRepository(
+		t.Context(), new(Forge),
+		"owner", "repo",
+		silogtest.New(t),
+		githubv4.NewEnterpriseClient(srv.URL, nil),
+		"repoID",
+	)
+	require.NoError(t, err)
+
+	var got []*forge.ChangeThread
+	for thread, err := range repo.ListChangeThreads(t.Context(), &PR{Number: 1, GQLID: "prID"}) {
+		require.NoError(t, err)
+		got = append(got, thread)
+	}
+
+	assert.Equal(t, []*forge.ChangeThread{
+		{
+			ID:       &PRReviewThread{GQLID: "thread1"},
+			Path:     "main.go",
+			Line:     42,
+			Author:   "bob",
+			Body:     "typo",
+			Outdated: true,
+		},
+		{
+			ID:       &PRReviewThread{GQLID: "thread2"},
+			Path:     "README.md",
+			Resolved: true,
+		},
+	}, got)
+}
+
+func TestResolveThread(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolved bool
+		wantOp   string
+	}{
+		{name: "Resolve", resolved: true, wantOp: "resolveReviewThread"},
+		{name: "Unresolve", resolved: false, wantOp: "unresolveReviewThread"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				var req struct {
+					Query     string         `json:"query"`
+					Variables map[string]any `json:"variables"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &req))
+				gotQuery = req.Query
+				assert.Equal(t, map[string]any{"threadId": "thread1"}, req.Variables["input"])
+
+				_, _ = io.WriteString(w, `{"data": {}}`)
+			}))
+			defer srv.Close()
+
+			repo, err := newRepository(
+				t.Context(), new(Forge),
+				"owner", "repo",
+				silogtest.New(t),
+				githubv4.NewEnterpriseClient(srv.URL, nil),
+				"repoID",
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, repo.ResolveThread(t.Context(), &PRReviewThread{GQLID: "thread1"}, tt.resolved))
+			assert.Regexp(t, `\b`+tt.wantOp+`\(input: \$input\)`, gotQuery)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/xec"
 	"golang.org/x/oauth2"
 )
@@ -20,7 +21,9 @@ type CLITokenSource struct {
 // Token returns an oauth2 token using the GitHub CLI.
 func (ts *CLITokenSource) Token() (*oauth2.Token, error) {
 	ctx := context.Background()
-	cmd := xec.Command(ctx, nil, "gh", "auth", "token").WithExecer(ts.execer)
+	cmd := xec.Command(ctx, nil, "gh", "auth", "token").
+		WithExecer(ts.execer).
+		WithTimeout(forge.CredentialHelperTimeout)
 	bs, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("get token from gh CLI: %w", err)
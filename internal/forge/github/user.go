@@ -0,0 +1,22 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// CurrentUser reports the login of the authenticated user.
+func (r *Repository) CurrentUser(ctx context.Context) (string, error) {
+	var q struct {
+		Viewer struct {
+			Login githubv4.String `graphql:"login"`
+		} `graphql:"viewer"`
+	}
+
+	if err := r.client.Query(ctx, &q, nil); err != nil {
+		return "", fmt.Errorf("retrieve current user: %w", err)
+	}
+	return string(q.Viewer.Login), nil
+}
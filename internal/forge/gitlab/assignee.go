@@ -33,7 +33,7 @@ func (r *Repository) assigneeID(ctx context.Context, username string) (int64, er
 		Username: new(username),
 	}, gitlab.WithContext(ctx))
 	if err != nil {
-		return 0, fmt.Errorf("list users: %w", err)
+		return 0, fmt.Errorf("list users: %w", mapError(err))
 	}
 	if len(users) == 0 {
 		return 0, fmt.Errorf("user %q not found", username)
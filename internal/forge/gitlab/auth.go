@@ -477,7 +477,8 @@ func newGitLabCLI(gl string) *glabCLI {
 func (gc *glabCLI) Status(ctx context.Context, host string) (ok bool, err error) {
 	// This command exits with non-zero status if not authenticated.
 	cmd := xec.Command(ctx, nil, gc.GL, "auth", "status", "--hostname", host).
-		WithExecer(gc.execer)
+		WithExecer(gc.execer).
+		WithTimeout(forge.CredentialHelperTimeout)
 	if err := cmd.Run(); err != nil {
 		var exitErr *xec.ExitError
 		if errors.As(err, &exitErr) {
@@ -499,6 +500,7 @@ func (gc *glabCLI) Token(ctx context.Context, host string) (string, error) {
 	cmd := xec.Command(ctx, nil, gc.GL,
 		"auth", "status", "--hostname", host, "--show-token").
 		WithExecer(gc.execer).
+		WithTimeout(forge.CredentialHelperTimeout).
 		WithStderr(&stderr)
 	if err := cmd.Run(); err != nil {
 		var exitErr *xec.ExitError
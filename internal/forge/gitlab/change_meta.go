@@ -82,6 +82,24 @@ func (*Forge) UnmarshalChangeID(data json.RawMessage) (forge.ChangeID, error) {
 	return &id, nil
 }
 
+// FormatChangeID formats an MR for display, e.g. "!123".
+func (*Forge) FormatChangeID(id forge.ChangeID) string {
+	if mr, ok := id.(*MR); ok {
+		return fmt.Sprintf("!%d", mr.Number)
+	}
+	return id.String()
+}
+
+// ParseChangeID parses an MR number ("123" or "!123")
+// or a merge request URL.
+func (*Forge) ParseChangeID(s string) (forge.ChangeID, error) {
+	num, err := forge.ParseChangeNumber(s, []string{"!"}, []string{"merge_requests"})
+	if err != nil {
+		return nil, err
+	}
+	return &MR{Number: num}, nil
+}
+
 // MR uniquely identifies a Merge Request in GitLab.
 // It's a valid forge.ChangeID.
 type MR struct {
@@ -48,6 +48,27 @@ func TestMRString(t *testing.T) {
 	}).String())
 }
 
+func TestForge_FormatChangeID(t *testing.T) {
+	var f Forge
+	assert.Equal(t, "!42", f.FormatChangeID(&MR{Number: 42}))
+}
+
+func TestForge_ParseChangeID(t *testing.T) {
+	var f Forge
+	for _, give := range []string{
+		"42",
+		"!42",
+		"https://gitlab.com/example/repo/-/merge_requests/42",
+	} {
+		got, err := f.ParseChangeID(give)
+		require.NoError(t, err, "input: %q", give)
+		assert.Equal(t, &MR{Number: 42}, got, "input: %q", give)
+	}
+
+	_, err := f.ParseChangeID("#42")
+	assert.Error(t, err)
+}
+
 func TestMRMarshal(t *testing.T) {
 	tests := []struct {
 		name string
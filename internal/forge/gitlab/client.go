@@ -2,14 +2,20 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/httplog"
 	"go.abhg.dev/gs/internal/must"
+	"go.abhg.dev/gs/internal/silog"
 	"golang.org/x/oauth2"
 )
 
 type gitlabClient struct {
+	Discussions      discussionsService
 	MergeRequests    mergeRequestsService
 	Notes            notesService
 	Projects         projectsService
@@ -17,7 +23,13 @@ type gitlabClient struct {
 	Users            usersService
 }
 
-func newGitLabClient(ctx context.Context, baseURL string, tok *AuthenticationToken) (*gitlabClient, error) {
+func newGitLabClient(
+	ctx context.Context,
+	baseURL string,
+	tok *AuthenticationToken,
+	transport http.RoundTripper, // optional
+	log *silog.Logger,
+) (*gitlabClient, error) {
 	var authSource gitlab.AuthSource
 	switch tok.AuthType {
 	case AuthTypePAT, AuthTypeEnvironmentVariable:
@@ -46,11 +58,17 @@ func newGitLabClient(ctx context.Context, baseURL string, tok *AuthenticationTok
 	must.NotBeNilf(authSource,
 		"No source for authentication type: %v", tok.AuthType)
 
-	client, err := gitlab.NewAuthSourceClient(authSource, gitlab.WithBaseURL(baseURL))
+	client, err := gitlab.NewAuthSourceClient(authSource,
+		gitlab.WithBaseURL(baseURL),
+		gitlab.WithHTTPClient(&http.Client{
+			Transport: httplog.WrapTransport(transport, log),
+		}),
+	)
 	if err != nil {
 		return nil, err
 	}
 	return &gitlabClient{
+		Discussions:      client.Discussions,
 		MergeRequests:    client.MergeRequests,
 		Notes:            client.Notes,
 		ProjectTemplates: client.ProjectTemplates,
@@ -59,6 +77,21 @@ func newGitLabClient(ctx context.Context, baseURL string, tok *AuthenticationTok
 	}, nil
 }
 
+// mapError maps errors reported by the GitLab API
+// to the errors defined in the forge package.
+func mapError(err error) error {
+	if errors.Is(err, gitlab.ErrNotFound) {
+		// The client reports 404 responses with a sentinel error.
+		return forge.WrapError(err, forge.ErrNotFound)
+	}
+
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		return forge.WrapError(err, forge.StatusError(errResp.Response.StatusCode))
+	}
+	return err
+}
+
 type patAuthSource struct{ token string }
 
 var _ gitlab.AuthSource = (*patAuthSource)(nil)
@@ -103,10 +136,36 @@ type mergeRequestsService interface {
 		opt *gitlab.AcceptMergeRequestOptions,
 		options ...gitlab.RequestOptionFunc,
 	) (*gitlab.MergeRequest, *gitlab.Response, error)
+
+	ListMergeRequestDiffs(
+		pid any,
+		mergeRequest int64,
+		opt *gitlab.ListMergeRequestDiffsOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.MergeRequestDiff, *gitlab.Response, error)
 }
 
 var _ mergeRequestsService = gitlab.MergeRequestsServiceInterface(nil)
 
+// discussionsService allows listing and resolving
+// discussions (review threads) on merge requests.
+type discussionsService interface {
+	ListMergeRequestDiscussions(
+		pid any,
+		mergeRequest int64,
+		opt *gitlab.ListMergeRequestDiscussionsOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.Discussion, *gitlab.Response, error)
+
+	ResolveMergeRequestDiscussion(
+		pid any,
+		mergeRequest int64,
+		discussion string,
+		opt *gitlab.ResolveMergeRequestDiscussionOptions,
+		options ...gitlab.RequestOptionFunc,
+	) (*gitlab.Discussion, *gitlab.Response, error)
+}
+
 // notesService allows posting, listing, and fetching notes (comments)
 // on merge requests.
 type notesService interface {
@@ -1,6 +1,15 @@
 package gitlab
 
-import gitlab "gitlab.com/gitlab-org/api/client-go"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+)
 
 var (
 	_ mergeRequestsService    = (*gitlab.MergeRequestsService)(nil)
@@ -8,3 +17,44 @@ var (
 	_ projectsService         = (*gitlab.ProjectsService)(nil)
 	_ projectTemplatesService = (*gitlab.ProjectTemplatesService)(nil)
 )
+
+func TestMapError(t *testing.T) {
+	errorResponse := func(code int) error {
+		return &gitlab.ErrorResponse{
+			Response: &http.Response{
+				StatusCode: code,
+				Request: &http.Request{
+					Method: http.MethodGet,
+					URL:    &url.URL{Scheme: "https", Host: "gitlab.com", Path: "/api/v4/projects/1"},
+				},
+			},
+			Message: "{message: oops}",
+		}
+	}
+
+	tests := []struct {
+		name string
+		give error
+		want error
+	}{
+		{"NotFound", gitlab.ErrNotFound, forge.ErrNotFound},
+		{"Unauthorized", errorResponse(http.StatusUnauthorized), forge.ErrUnauthorized},
+		{"Forbidden", errorResponse(http.StatusForbidden), forge.ErrPermission},
+		{"Conflict", errorResponse(http.StatusConflict), forge.ErrConflict},
+		{"TooManyRequests", errorResponse(http.StatusTooManyRequests), forge.ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mapError(fmt.Errorf("wrapped: %w", tt.give))
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, tt.give)
+			assert.Equal(t, "wrapped: "+tt.give.Error(), err.Error())
+		})
+	}
+
+	t.Run("Other", func(t *testing.T) {
+		give := errorResponse(http.StatusInternalServerError)
+		assert.Same(t, give, mapError(give))
+	})
+}
@@ -58,7 +58,7 @@ func (r *Repository) PostChangeComment(
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("post comment: %w", err)
+		return nil, fmt.Errorf("post comment: %w", mapError(err))
 	}
 
 	r.log.Debug("Posted comment", "id", note.ID, "mr", mrNumber)
@@ -87,7 +87,7 @@ func (r *Repository) UpdateChangeComment(
 		if errors.Is(err, gitlab.ErrNotFound) {
 			return fmt.Errorf("update comment: %w", forge.ErrNotFound)
 		}
-		return fmt.Errorf("update comment: %w", err)
+		return fmt.Errorf("update comment: %w", mapError(err))
 	}
 	r.log.Debug("Updated comment",
 		"id", mrComment.Number,
@@ -110,7 +110,7 @@ func (r *Repository) DeleteChangeComment(
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return fmt.Errorf("delete comment: %w", err)
+		return fmt.Errorf("delete comment: %w", mapError(err))
 	}
 	r.log.Debug("Deleted comment", "id", mrComment.Number, "mr", mrComment.MRNumber)
 
@@ -171,7 +171,7 @@ func (r *Repository) ListChangeComments(
 				gitlab.WithContext(ctx),
 			)
 			if err != nil {
-				yield(nil, fmt.Errorf("list comments (page %d): %w", pageNum, err))
+				yield(nil, fmt.Errorf("list comments (page %d): %w", pageNum, mapError(err)))
 				return
 			}
 
@@ -155,7 +155,7 @@ func TestListChangeComments(t *testing.T) {
 			client, _ := newGitLabClient(t.Context(), srv.URL, &AuthenticationToken{
 				AuthType:    AuthTypePAT,
 				AccessToken: "token",
-			})
+			}, nil, silogtest.New(t))
 			repoID := int64(100)
 			repo, err := newRepository(
 				t.Context(), new(Forge),
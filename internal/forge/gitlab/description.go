@@ -0,0 +1,25 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// ChangeDescription reports the title and description of a merge request.
+func (r *Repository) ChangeDescription(ctx context.Context, id forge.ChangeID) (*forge.ChangeDescription, error) {
+	mr, _, err := r.client.MergeRequests.GetMergeRequest(
+		r.repoID, mustMR(id).Number, nil,
+		gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get merge request: %w", mapError(err))
+	}
+
+	return &forge.ChangeDescription{
+		Subject: mr.Title,
+		Body:    mr.Description,
+	}, nil
+}
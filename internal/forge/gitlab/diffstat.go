@@ -0,0 +1,63 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+var _listChangeDiffsPageSize = 100 // var for testing
+
+// ChangeDiffStat reports the number of lines added and deleted,
+// and the number of files changed by a merge request.
+//
+// GitLab doesn't report line counts for merge requests,
+// so this counts them from the diffs of the changed files.
+// Diffs of files that are too large are left out by GitLab,
+// so the result is marked incomplete if there are any.
+func (r *Repository) ChangeDiffStat(ctx context.Context, id forge.ChangeID) (*forge.DiffStat, error) {
+	mrNumber := mustMR(id).Number
+	opts := gitlab.ListMergeRequestDiffsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: int64(_listChangeDiffsPageSize),
+		},
+	}
+
+	var stat forge.DiffStat
+	for pageNum := 1; true; pageNum++ {
+		diffs, response, err := r.client.MergeRequests.ListMergeRequestDiffs(
+			r.repoID, mrNumber, &opts,
+			gitlab.WithContext(ctx),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("list diffs (page %d): %w", pageNum, mapError(err))
+		}
+
+		for _, diff := range diffs {
+			stat.ChangedFiles++
+			if diff.TooLarge || diff.Collapsed {
+				stat.Incomplete = true
+				continue
+			}
+
+			for line := range strings.Lines(diff.Diff) {
+				switch line[0] {
+				case '+':
+					stat.Additions++
+				case '-':
+					stat.Deletions++
+				}
+			}
+		}
+
+		if response.CurrentPage >= response.TotalPages {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	return &stat, nil
+}
@@ -0,0 +1,119 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/testing/stub"
+)
+
+func TestChangeDiffStat(t *testing.T) {
+	tests := []struct {
+		name  string
+		diffs []gitlab.MergeRequestDiff
+		want  forge.DiffStat
+	}{
+		{
+			name: "Complete",
+			diffs: []gitlab.MergeRequestDiff{
+				{NewPath: "a.txt", Diff: "@@ -1,2 +1,2 @@\n-foo\n+bar\n baz\n"},
+				{NewPath: "b.txt", NewFile: true, Diff: "@@ -0,0 +1,2 @@\n+one\n+two\n"},
+				{NewPath: "c.txt", DeletedFile: true, Diff: "@@ -1 +0,0 @@\n-gone\n"},
+			},
+			want: forge.DiffStat{
+				Additions:    3,
+				Deletions:    2,
+				ChangedFiles: 3,
+			},
+		},
+		{
+			name: "TooLarge",
+			diffs: []gitlab.MergeRequestDiff{
+				{NewPath: "a.txt", Diff: "@@ -1 +1 @@\n-foo\n+bar\n"},
+				{NewPath: "big.txt", TooLarge: true},
+				{NewPath: "c.txt", Diff: "@@ -0,0 +1 @@\n+new\n"},
+			},
+			want: forge.DiffStat{
+				Additions:    2,
+				Deletions:    1,
+				ChangedFiles: 3,
+				Incomplete:   true,
+			},
+		},
+		{
+			name: "Collapsed",
+			diffs: []gitlab.MergeRequestDiff{
+				{NewPath: "a.txt", Collapsed: true},
+				{NewPath: "b.txt", Diff: "@@ -1 +1 @@\n-foo\n+bar\n"},
+			},
+			want: forge.DiffStat{
+				Additions:    1,
+				Deletions:    1,
+				ChangedFiles: 2,
+				Incomplete:   true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Two diffs per page to exercise pagination.
+			t.Cleanup(stub.Value(&_listChangeDiffsPageSize, 2))
+			totalPages := (len(tt.diffs) + 1) / 2
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				enc := json.NewEncoder(w)
+				switch r.URL.Path {
+				case "/api/v4/projects/100":
+					assert.NoError(t, enc.Encode(newProject(100, gitlab.Ptr(gitlab.DeveloperPermissions), nil)))
+				case "/api/v4/user":
+					assert.NoError(t, enc.Encode(gitlab.User{ID: 1}))
+				case "/api/v4/projects/100/merge_requests/42/diffs":
+					page, err := strconv.Atoi(r.URL.Query().Get("page"))
+					if err != nil {
+						page = 1
+					}
+					start := (page - 1) * 2
+					end := min(start+2, len(tt.diffs))
+
+					w.Header().Set("X-Page", strconv.Itoa(page))
+					w.Header().Set("X-Total-Pages", strconv.Itoa(totalPages))
+					if page < totalPages {
+						w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+					}
+					assert.NoError(t, enc.Encode(tt.diffs[start:end]))
+				default:
+					t.Errorf("unexpected request: %v", r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			client, _ := newGitLabClient(t.Context(), srv.URL, &AuthenticationToken{
+				AuthType:    AuthTypePAT,
+				AccessToken: "token",
+			}, nil, silogtest.New(t))
+			repoID := int64(100)
+			repo, err := newRepository(
+				t.Context(), new(Forge),
+				"owner", "repo",
+				silogtest.New(t),
+				client,
+				&repositoryOptions{RepositoryID: &repoID},
+			)
+			require.NoError(t, err)
+
+			stat, err := repo.ChangeDiffStat(t.Context(), &MR{Number: 42})
+			require.NoError(t, err)
+			assert.Equal(t, &tt.want, stat)
+		})
+	}
+}
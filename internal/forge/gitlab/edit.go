@@ -50,7 +50,7 @@ func (r *Repository) EditChange(ctx context.Context, id forge.ChangeID, opts for
 			gitlab.WithContext(ctx),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("get merge request for update: %w", err)
+			return nil, fmt.Errorf("get merge request for update: %w", mapError(err))
 		}
 
 		return mergeRequest, nil
@@ -123,7 +123,7 @@ func (r *Repository) EditChange(ctx context.Context, id forge.ChangeID, opts for
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return fmt.Errorf("update merge request: %w", err)
+		return fmt.Errorf("update merge request: %w", mapError(err))
 	}
 	if len(logUpdates) > 0 {
 		r.log.Debug("Updated merge request",
@@ -40,6 +40,7 @@ func basicMergeRequestToFindChangeItem(mr *gitlab.BasicMergeRequest) *forge.Find
 		Subject:   mr.Title,
 		BaseName:  mr.TargetBranch,
 		HeadHash:  git.Hash(mr.SHA),
+		HeadName:  mr.SourceBranch,
 		Draft:     mr.Draft,
 		Labels:    labels,
 		Reviewers: reviewers,
@@ -78,6 +79,7 @@ func mergeRequestToFindChangeItem(mr *gitlab.MergeRequest) *forge.FindChangeItem
 		Subject:   mr.Title,
 		BaseName:  mr.TargetBranch,
 		HeadHash:  git.Hash(mr.SHA),
+		HeadName:  mr.SourceBranch,
 		Draft:     mr.Draft,
 		Labels:    labels,
 		Reviewers: reviewers,
@@ -135,7 +137,7 @@ func (r *Repository) FindChangesByBranch(ctx context.Context, branch string, opt
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("find changes by branch: %w", err)
+		return nil, fmt.Errorf("find changes by branch: %w", mapError(err))
 	}
 
 	changes := make([]*forge.FindChangeItem, len(requests))
@@ -146,6 +148,62 @@ func (r *Repository) FindChangesByBranch(ctx context.Context, branch string, opt
 	return changes, nil
 }
 
+// FindOpenChangeByHead searches for an open merge request
+// with the given source branch and, optionally, target branch.
+func (r *Repository) FindOpenChangeByHead(ctx context.Context, req forge.FindOpenChangeByHeadRequest) (*forge.FindChangeItem, error) {
+	// Merge requests from forks are listed in this project too,
+	// so filter by source project.
+	sourceProjectID := r.repoID
+	if req.HeadRepository != nil {
+		headRepo := mustRepositoryID(req.HeadRepository)
+		if headRepo.owner != r.owner || headRepo.name != r.repo {
+			project, _, err := r.client.Projects.GetProject(
+				headRepo.owner+"/"+headRepo.name, nil,
+				gitlab.WithContext(ctx),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("get head repository ID: %w", mapError(err))
+			}
+			sourceProjectID = project.ID
+		}
+	}
+
+	opt := &gitlab.ListProjectMergeRequestsOptions{
+		OrderBy:      new("updated_at"),
+		State:        new(mergeRequestState(forge.ChangeOpen)),
+		SourceBranch: new(req.Head),
+		ListOptions: gitlab.ListOptions{
+			PerPage: 10,
+		},
+	}
+	if req.Base != "" {
+		opt.TargetBranch = new(req.Base)
+	}
+
+	requests, _, err := r.client.MergeRequests.ListProjectMergeRequests(
+		r.repoID, opt,
+		gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find open change by head: %w", mapError(err))
+	}
+
+	var matches []*forge.FindChangeItem
+	for _, mr := range requests {
+		if mr.SourceProjectID == sourceProjectID {
+			matches = append(matches, basicMergeRequestToFindChangeItem(mr))
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, forge.ErrNotFound
+	case len(matches) > 1 && req.Unique:
+		return nil, &forge.AmbiguousChangeError{Changes: matches}
+	default:
+		return matches[0], nil
+	}
+}
+
 // FindChangeByID searches for a change with the given ID.
 func (r *Repository) FindChangeByID(ctx context.Context, id forge.ChangeID) (*forge.FindChangeItem, error) {
 	mr, _, err := r.client.MergeRequests.GetMergeRequest(
@@ -153,7 +211,7 @@ func (r *Repository) FindChangeByID(ctx context.Context, id forge.ChangeID) (*fo
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("find change by ID: %w", err)
+		return nil, fmt.Errorf("find change by ID: %w", mapError(err))
 	}
 
 	return mergeRequestToFindChangeItem(mr), nil
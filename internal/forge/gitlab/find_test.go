@@ -0,0 +1,156 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestFindOpenChangeByHead(t *testing.T) {
+	const (
+		projectID = 100
+		forkID    = 200
+	)
+
+	newMR := func(iid, sourceProjectID int64, target string) *gitlab.BasicMergeRequest {
+		return &gitlab.BasicMergeRequest{
+			IID:             iid,
+			State:           "opened",
+			SourceBranch:    "feature",
+			TargetBranch:    target,
+			SourceProjectID: sourceProjectID,
+		}
+	}
+
+	fork := &RepositoryID{owner: "contributor", name: "repo"}
+
+	tests := []struct {
+		name string
+		req  forge.FindOpenChangeByHeadRequest
+		mrs  []*gitlab.BasicMergeRequest // most recently updated first
+
+		wantTarget string // target_branch sent to the API
+		wantIID    int64  // 0 if not found
+		ambiguous  bool
+	}{
+		{
+			name:       "HeadAndBase",
+			req:        forge.FindOpenChangeByHeadRequest{Head: "feature", Base: "main"},
+			mrs:        []*gitlab.BasicMergeRequest{newMR(1, projectID, "main")},
+			wantTarget: "main",
+			wantIID:    1,
+		},
+		{
+			name: "HeadOnly",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature"},
+			mrs: []*gitlab.BasicMergeRequest{
+				newMR(2, projectID, "develop"),
+				newMR(1, projectID, "main"),
+			},
+			wantIID: 2,
+		},
+		{
+			name: "SkipsForks",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature"},
+			mrs: []*gitlab.BasicMergeRequest{
+				newMR(2, forkID, "main"),
+				newMR(1, projectID, "main"),
+			},
+			wantIID: 1,
+		},
+		{
+			name: "Fork",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", HeadRepository: fork},
+			mrs: []*gitlab.BasicMergeRequest{
+				newMR(2, forkID, "main"),
+				newMR(1, projectID, "main"),
+			},
+			wantIID: 2,
+		},
+		{
+			name: "NotFound",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", HeadRepository: fork},
+			mrs:  []*gitlab.BasicMergeRequest{newMR(1, projectID, "main")},
+		},
+		{
+			name: "Unique",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Unique: true},
+			mrs: []*gitlab.BasicMergeRequest{
+				newMR(2, forkID, "main"),
+				newMR(1, projectID, "main"),
+			},
+			wantIID: 1,
+		},
+		{
+			name: "Ambiguous",
+			req:  forge.FindOpenChangeByHeadRequest{Head: "feature", Unique: true},
+			mrs: []*gitlab.BasicMergeRequest{
+				newMR(2, projectID, "develop"),
+				newMR(1, projectID, "main"),
+			},
+			ambiguous: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				enc := json.NewEncoder(w)
+				switch r.URL.Path {
+				case "/api/v4/projects/100":
+					assert.NoError(t, enc.Encode(newProject(projectID, gitlab.Ptr(gitlab.DeveloperPermissions), nil)))
+				case "/api/v4/projects/contributor/repo":
+					assert.NoError(t, enc.Encode(newProject(forkID, nil, nil)))
+				case "/api/v4/user":
+					assert.NoError(t, enc.Encode(gitlab.User{ID: 1}))
+				case "/api/v4/projects/100/merge_requests":
+					query := r.URL.Query()
+					assert.Equal(t, "opened", query.Get("state"))
+					assert.Equal(t, "feature", query.Get("source_branch"))
+					assert.Equal(t, tt.wantTarget, query.Get("target_branch"))
+					assert.NoError(t, enc.Encode(tt.mrs))
+				default:
+					t.Errorf("unexpected request: %v", r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			client, _ := newGitLabClient(t.Context(), srv.URL, &AuthenticationToken{
+				AuthType:    AuthTypePAT,
+				AccessToken: "token",
+			}, nil, silogtest.New(t))
+			repoID := int64(projectID)
+			repo, err := newRepository(
+				t.Context(), new(Forge),
+				"owner", "repo",
+				silogtest.New(t),
+				client,
+				&repositoryOptions{RepositoryID: &repoID},
+			)
+			require.NoError(t, err)
+
+			change, err := repo.FindOpenChangeByHead(t.Context(), tt.req)
+			switch {
+			case tt.ambiguous:
+				var ambiguousErr *forge.AmbiguousChangeError
+				require.ErrorAs(t, err, &ambiguousErr)
+				assert.Len(t, ambiguousErr.Changes, len(tt.mrs))
+
+			case tt.wantIID == 0:
+				assert.ErrorIs(t, err, forge.ErrNotFound)
+
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantIID, mustMR(change.ID).Number)
+			}
+		})
+	}
+}
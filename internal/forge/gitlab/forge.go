@@ -6,6 +6,7 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"go.abhg.dev/gs/internal/forge"
@@ -41,6 +42,19 @@ type Options struct {
 	// RemoveSourceBranch specifies whether a branch should be deleted
 	// after its Merge Request is merged.
 	RemoveSourceBranch bool `name:"gitlab-remove-source-branch" hidden:"" config:"forge.gitlab.removeSourceBranch" default:"true" help:"Remove source branch after merging a merge request"`
+
+	// HTTPHeaders, ClientCert, and ClientKey configure API requests
+	// for instances behind authenticating proxies.
+	// See [forge.HTTPOptions] for details.
+	HTTPHeaders []string `name:"gitlab-http-header" hidden:"" config:"forge.gitlab.httpHeader" sep:"\n" placeholder:"NAME: VALUE" help:"Additional header to send with GitLab API requests. May be repeated."`
+	ClientCert  string   `name:"gitlab-client-cert" hidden:"" config:"forge.gitlab.clientCert" placeholder:"FILE" help:"PEM-encoded client certificate to present to GitLab"`
+	ClientKey   string   `name:"gitlab-client-key" hidden:"" config:"forge.gitlab.clientKey" placeholder:"FILE" help:"PEM-encoded private key for the client certificate"`
+
+	// Proxy and CABundle configure API requests for networks
+	// that route traffic through a proxy, or intercept TLS connections.
+	// See [forge.HTTPOptions] for details.
+	Proxy    string `name:"gitlab-proxy" hidden:"" config:"forge.gitlab.proxy" env:"GITLAB_PROXY" placeholder:"URL" help:"Proxy to use for GitLab API requests. Defaults to HTTPS_PROXY."`
+	CABundle string `name:"gitlab-ca-bundle" hidden:"" config:"forge.gitlab.caBundle" env:"GITLAB_CA_BUNDLE" placeholder:"FILE" help:"PEM-encoded certificates of additional certificate authorities to trust for GitLab API requests"`
 }
 
 // Forge builds a GitLab Forge.
@@ -49,9 +63,17 @@ type Forge struct {
 
 	// Log specifies the logger to use.
 	Log *silog.Logger
+
+	// HTTPMiddleware wraps the transport used for API requests,
+	// e.g. to sign requests.
+	HTTPMiddleware []forge.HTTPMiddleware
 }
 
-var _ forge.Forge = (*Forge)(nil)
+var (
+	_ forge.Forge             = (*Forge)(nil)
+	_ forge.WithAPIURL        = (*Forge)(nil)
+	_ forge.WithHTTPTransport = (*Forge)(nil)
+)
 
 func (f *Forge) logger() *silog.Logger {
 	if f.Log == nil {
@@ -72,6 +94,18 @@ func (f *Forge) APIURL() string {
 	return cmp.Or(f.Options.APIURL, f.URL())
 }
 
+// HTTPTransport returns the transport used for API requests.
+func (f *Forge) HTTPTransport() (http.RoundTripper, error) {
+	return (&forge.HTTPOptions{
+		Headers:    f.Options.HTTPHeaders,
+		ClientCert: f.Options.ClientCert,
+		ClientKey:  f.Options.ClientKey,
+		Proxy:      f.Options.Proxy,
+		CABundle:   f.Options.CABundle,
+		Middleware: f.HTTPMiddleware,
+	}).Transport(nil)
+}
+
 // ID reports a unique key for this forge.
 func (*Forge) ID() string { return "gitlab" }
 
@@ -99,7 +133,12 @@ func (f *Forge) ParseRemoteURL(remoteURL string) (forge.RepositoryID, error) {
 func (f *Forge) OpenRepository(ctx context.Context, token forge.AuthenticationToken, id forge.RepositoryID) (forge.Repository, error) {
 	rid := mustRepositoryID(id)
 
-	glc, err := newGitLabClient(ctx, f.APIURL(), token.(*AuthenticationToken))
+	transport, err := f.HTTPTransport()
+	if err != nil {
+		return nil, fmt.Errorf("configure HTTP transport: %w", err)
+	}
+
+	glc, err := newGitLabClient(ctx, f.APIURL(), token.(*AuthenticationToken), transport, f.logger())
 	if err != nil {
 		return nil, fmt.Errorf("create GitLab client: %w", err)
 	}
@@ -56,6 +56,7 @@ func newGitLabClient(
 	}
 	client, _ := gogitlab.NewClient(token, gogitlab.WithHTTPClient(httpClient))
 	return &gitlab.Client{
+		Discussions:      client.Discussions,
 		MergeRequests:    client.MergeRequests,
 		Notes:            client.Notes,
 		ProjectTemplates: client.ProjectTemplates,
@@ -133,8 +134,7 @@ func TestIntegration(t *testing.T) {
 		CloseChange: func(t *testing.T, repo forge.Repository, change forge.ChangeID) {
 			require.NoError(t, gitlab.CloseChange(t.Context(), repo.(*gitlab.Repository), change.(*gitlab.MR)))
 		},
-		SetCommentsPageSize:   gitlab.SetListChangeCommentsPageSize,
-		BaseBranchMayBeAbsent: true,
+		SetCommentsPageSize: gitlab.SetListChangeCommentsPageSize,
 		// Unfortunately, GitLab does not support multiple reviewers
 		// in the Free Tier so we can't test multi-reviewer MRs.
 		// If someone with a paid GitLab plan wants to help test
@@ -156,9 +156,7 @@ func TestIntegration_Repository_notFoundError(t *testing.T) {
 
 func TestIntegration_Repository_SubmitChange_removeSourceBranch(t *testing.T) {
 	ctx := t.Context()
-	branchFixture := fixturetest.New(_fixtures, "branch", func() string {
-		return randomString(8)
-	})
+	branchFixture := fixturetest.New(_fixtures, "branch", forgetest.NewNamespace(t).Name)
 
 	branchName := branchFixture.Get(t)
 	t.Logf("Creating branch: %s", branchName)
@@ -23,6 +23,7 @@ type Repository struct {
 
 	// Information about the current user:
 	userID   int64
+	username string
 	userRole gitlab.AccessLevelValue
 
 	removeSourceBranchOnMerge bool
@@ -58,12 +59,12 @@ func newRepository(
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("get repository ID: %w", err)
+		return nil, fmt.Errorf("get repository ID: %w", mapError(err))
 	}
 
 	user, _, err := client.Users.CurrentUser(gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("get current user: %w", err)
+		return nil, fmt.Errorf("get current user: %w", mapError(err))
 	}
 
 	var accessLevel gitlab.AccessLevelValue
@@ -81,6 +82,7 @@ func newRepository(
 		forge:    forge,
 		log:      log,
 		userID:   user.ID,
+		username: user.Username,
 		userRole: accessLevel,
 		repoID:   project.ID,
 
@@ -91,6 +93,21 @@ func newRepository(
 // Forge returns the forge this repository belongs to.
 func (r *Repository) Forge() forge.Forge { return r.forge }
 
+// Capabilities reports the features supported by GitLab.
+// GitLab cannot request reviews from groups,
+// and it accepts merge requests against branches
+// that don't exist in the repository.
+func (r *Repository) Capabilities() forge.Capabilities {
+	return forge.Capabilities{
+		Draft:           true,
+		Labels:          true,
+		Reviewers:       true,
+		Assignees:       true,
+		AutoMerge:       true, // merge when pipeline succeeds
+		MergeStrategies: []forge.MergeStrategy{forge.MergeCommit, forge.SquashMerge},
+	}
+}
+
 var _accessLevelNames = map[gitlab.AccessLevelValue]string{
 	gitlab.NoPermissions:            "none",
 	gitlab.MinimalAccessPermissions: "minimal",
@@ -122,7 +139,7 @@ func (r *Repository) resolveReviewerIDs(ctx context.Context, usernames []string)
 			Username: &username,
 		}, gitlab.WithContext(ctx))
 		if err != nil {
-			return nil, fmt.Errorf("lookup user %q: %w", username, err)
+			return nil, fmt.Errorf("lookup user %q: %w", username, mapError(err))
 		}
 
 		if len(users) == 0 {
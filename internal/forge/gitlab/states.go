@@ -1,15 +1,32 @@
 package gitlab
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
 )
 
 // ChangesStates retrieves the states of the given changes in bulk.
 func (r *Repository) ChangesStates(ctx context.Context, ids []forge.ChangeID) ([]forge.ChangeState, error) {
+	details, err := r.ChangesDetails(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]forge.ChangeState, len(details))
+	for i, d := range details {
+		states[i] = d.State
+	}
+	return states, nil
+}
+
+// ChangesDetails retrieves the states of the given changes in bulk,
+// along with the merge commits of merged changes.
+func (r *Repository) ChangesDetails(ctx context.Context, ids []forge.ChangeID) ([]forge.ChangeDetails, error) {
 	mrIDs := make([]int64, len(ids))
 	for i, id := range ids {
 		mrIDs[i] = mustMR(id).Number
@@ -20,7 +37,7 @@ func (r *Repository) ChangesStates(ctx context.Context, ids []forge.ChangeID) ([
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("query failed: %w", err)
+		return nil, fmt.Errorf("query failed: %w", mapError(err))
 	}
 
 	// create a map of MR IDs to MRs
@@ -29,20 +46,24 @@ func (r *Repository) ChangesStates(ctx context.Context, ids []forge.ChangeID) ([
 		mrMap[mr.IID] = mr
 	}
 
-	states := make([]forge.ChangeState, len(mrIDs))
+	details := make([]forge.ChangeDetails, len(mrIDs))
 	for i, id := range mrIDs {
 		mr := mrMap[id]
+		details[i].BaseName = mr.TargetBranch
 		switch mr.State {
 		case "opened":
-			states[i] = forge.ChangeOpen
+			details[i].State = forge.ChangeOpen
 		case "merged":
-			states[i] = forge.ChangeMerged
+			details[i].State = forge.ChangeMerged
+			// Fast-forward merges don't create a merge commit,
+			// but squashing still creates a new commit.
+			details[i].MergeCommit = git.Hash(cmp.Or(mr.MergeCommitSHA, mr.SquashCommitSHA))
 		case "closed":
-			states[i] = forge.ChangeClosed
+			details[i].State = forge.ChangeClosed
 		default:
-			states[i] = forge.ChangeOpen // default to open for unknown states
+			details[i].State = forge.ChangeOpen // default to open for unknown states
 		}
 	}
 
-	return states, nil
+	return details, nil
 }
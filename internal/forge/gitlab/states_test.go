@@ -0,0 +1,68 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestChangesDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		switch r.URL.Path {
+		case "/api/v4/projects/100":
+			assert.NoError(t, enc.Encode(newProject(100, gitlab.Ptr(gitlab.DeveloperPermissions), nil)))
+		case "/api/v4/user":
+			assert.NoError(t, enc.Encode(gitlab.User{ID: 1}))
+		case "/api/v4/projects/100/merge_requests":
+			assert.Equal(t, []string{"1", "2", "3", "4"}, r.URL.Query()["iids[]"])
+
+			// Return them out of order to verify matching by IID.
+			assert.NoError(t, enc.Encode([]*gitlab.BasicMergeRequest{
+				{IID: 4, State: "merged", TargetBranch: "main", SquashCommitSHA: "def456"},
+				{IID: 3, State: "closed", TargetBranch: "feature"},
+				{IID: 2, State: "merged", TargetBranch: "main", MergeCommitSHA: "abc123"},
+				{IID: 1, State: "opened", TargetBranch: "main"},
+			}))
+		default:
+			t.Errorf("unexpected request: %v", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, _ := newGitLabClient(t.Context(), srv.URL, &AuthenticationToken{
+		AuthType:    AuthTypePAT,
+		AccessToken: "token",
+	}, nil, silogtest.New(t))
+	repoID := int64(100)
+	repo, err := newRepository(
+		t.Context(), new(Forge),
+		"owner", "repo",
+		silogtest.New(t),
+		client,
+		&repositoryOptions{RepositoryID: &repoID},
+	)
+	require.NoError(t, err)
+
+	details, err := repo.ChangesDetails(t.Context(), []forge.ChangeID{
+		&MR{Number: 1},
+		&MR{Number: 2},
+		&MR{Number: 3},
+		&MR{Number: 4},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []forge.ChangeDetails{
+		{State: forge.ChangeOpen, BaseName: "main"},
+		{State: forge.ChangeMerged, BaseName: "main", MergeCommit: "abc123"},
+		{State: forge.ChangeClosed, BaseName: "feature"},
+		{State: forge.ChangeMerged, BaseName: "main", MergeCommit: "def456"},
+	}, details)
+}
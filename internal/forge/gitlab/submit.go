@@ -19,7 +19,9 @@ func (r *Repository) SubmitChange(ctx context.Context, req forge.SubmitChangeReq
 		TargetBranch: &req.Base,
 		SourceBranch: &req.Head,
 	}
-	if r.removeSourceBranchOnMerge {
+	if req.RemoveSourceBranch != nil {
+		input.RemoveSourceBranch = req.RemoveSourceBranch
+	} else if r.removeSourceBranchOnMerge {
 		input.RemoveSourceBranch = new(true)
 	}
 	if req.Body != "" {
@@ -48,17 +50,44 @@ func (r *Repository) SubmitChange(ctx context.Context, req forge.SubmitChangeReq
 		input.AssigneeIDs = &assigneeIDs
 	}
 
+	// Merge requests from forks are created in the fork (source project)
+	// and target this repository.
+	var sourceProject any = r.repoID
+	if req.HeadRepository != nil {
+		headRepo := mustRepositoryID(req.HeadRepository)
+		if headRepo.owner != r.owner || headRepo.name != r.repo {
+			sourceProject = headRepo.owner + "/" + headRepo.name
+			input.TargetProjectID = &r.repoID
+		}
+	}
+
 	request, _, err := r.client.MergeRequests.CreateMergeRequest(
-		r.repoID, input,
+		sourceProject, input,
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return forge.SubmitChangeResult{}, fmt.Errorf("create merge request: %w", err)
+		return forge.SubmitChangeResult{}, fmt.Errorf("create merge request: %w", mapError(err))
 	}
 	r.log.Debug("Created merge request",
 		"mr", request.IID,
 		"url", request.WebURL)
 
+	if req.AutoMerge {
+		// The merge request has already been created,
+		// so failing to set it to merge automatically isn't fatal.
+		// Merge requests from forks belong to this repository.
+		_, _, err := r.client.MergeRequests.AcceptMergeRequest(
+			r.repoID, request.IID,
+			&gitlab.AcceptMergeRequestOptions{AutoMerge: new(true)},
+			gitlab.WithContext(ctx),
+		)
+		if err != nil {
+			r.log.Warn("Could not set merge request to merge when pipeline succeeds",
+				"mr", request.IID,
+				"error", err)
+		}
+	}
+
 	return forge.SubmitChangeResult{
 		ID: &MR{
 			Number: request.IID,
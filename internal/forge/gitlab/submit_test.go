@@ -0,0 +1,116 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestSubmitChange_mergeOptions(t *testing.T) {
+	tests := []struct {
+		name               string
+		removeSourceBranch bool // repository default
+		req                forge.SubmitChangeRequest
+
+		wantRemoveSourceBranch *bool
+		wantAutoMerge          bool
+	}{
+		{
+			name:                   "Defaults",
+			wantRemoveSourceBranch: nil,
+		},
+		{
+			name:                   "RepositoryDefault",
+			removeSourceBranch:     true,
+			wantRemoveSourceBranch: new(true),
+		},
+		{
+			name:               "OverrideRepositoryDefault",
+			removeSourceBranch: true,
+			req: forge.SubmitChangeRequest{
+				RemoveSourceBranch: new(false),
+			},
+			wantRemoveSourceBranch: new(false),
+		},
+		{
+			name: "AutoMerge",
+			req: forge.SubmitChangeRequest{
+				AutoMerge: true,
+			},
+			wantAutoMerge: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotCreate gitlab.CreateMergeRequestOptions
+				gotAccept *gitlab.AcceptMergeRequestOptions
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				enc := json.NewEncoder(w)
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/100":
+					assert.NoError(t, enc.Encode(newProject(100, nil, nil)))
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v4/user":
+					assert.NoError(t, enc.Encode(gitlab.User{ID: 1}))
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects/100/merge_requests":
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&gotCreate))
+					assert.NoError(t, enc.Encode(gitlab.MergeRequest{
+						BasicMergeRequest: gitlab.BasicMergeRequest{IID: 42},
+					}))
+				case r.Method == http.MethodPut && r.URL.Path == "/api/v4/projects/100/merge_requests/42/merge":
+					gotAccept = new(gitlab.AcceptMergeRequestOptions)
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(gotAccept))
+					assert.NoError(t, enc.Encode(gitlab.MergeRequest{
+						BasicMergeRequest: gitlab.BasicMergeRequest{IID: 42},
+					}))
+				default:
+					t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			client, _ := newGitLabClient(t.Context(), srv.URL, &AuthenticationToken{
+				AuthType:    AuthTypePAT,
+				AccessToken: "token",
+			}, nil, silogtest.New(t))
+			repoID := int64(100)
+			repo, err := newRepository(
+				t.Context(), new(Forge),
+				"owner", "repo",
+				silogtest.New(t),
+				client,
+				&repositoryOptions{
+					RepositoryID:              &repoID,
+					RemoveSourceBranchOnMerge: tt.removeSourceBranch,
+				},
+			)
+			require.NoError(t, err)
+
+			req := tt.req
+			req.Subject = "Add feature"
+			req.Base = "main"
+			req.Head = "feature"
+			result, err := repo.SubmitChange(t.Context(), req)
+			require.NoError(t, err)
+			assert.Equal(t, &MR{Number: 42}, result.ID)
+
+			assert.Equal(t, tt.wantRemoveSourceBranch, gotCreate.RemoveSourceBranch)
+			if tt.wantAutoMerge {
+				require.NotNil(t, gotAccept, "merge request was not set to merge automatically")
+				assert.Equal(t, new(true), gotAccept.AutoMerge)
+			} else {
+				assert.Nil(t, gotAccept)
+			}
+		})
+	}
+}
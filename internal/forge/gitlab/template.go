@@ -25,7 +25,7 @@ func (r *Repository) ListChangeTemplates(ctx context.Context) ([]*forge.ChangeTe
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	var out []*forge.ChangeTemplate
@@ -0,0 +1,129 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+// MRDiscussion identifies a discussion (review thread) on a GitLab MR.
+//
+// MRDiscussion implements [forge.ChangeThreadID].
+type MRDiscussion struct {
+	// ID is the ID of the discussion.
+	ID string `json:"id"` // required
+
+	// MRNumber is the ID of the MR the discussion is on.
+	MRNumber int64 `json:"mr_number"` // required
+}
+
+var _ forge.ChangeThreadID = (*MRDiscussion)(nil)
+
+func mustMRDiscussion(id forge.ChangeThreadID) *MRDiscussion {
+	d, ok := id.(*MRDiscussion)
+	if !ok {
+		panic(fmt.Sprintf("unexpected MR discussion type: %T", id))
+	}
+	return d
+}
+
+func (d *MRDiscussion) String() string {
+	return d.ID
+}
+
+var _ forge.WithReviewThreads = (*Repository)(nil)
+
+// _listChangeThreadsPageSize is the number of discussions
+// requested per page.
+const _listChangeThreadsPageSize = 20
+
+// ListChangeThreads lists resolvable discussions on an MR.
+//
+// GitLab does not report whether a discussion is outdated.
+// A discussion is considered outdated
+// if it was started on a version of the MR other than the current one.
+func (r *Repository) ListChangeThreads(
+	ctx context.Context,
+	id forge.ChangeID,
+) iter.Seq2[*forge.ChangeThread, error] {
+	mrNumber := mustMR(id).Number
+
+	return func(yield func(*forge.ChangeThread, error) bool) {
+		mr, _, err := r.client.MergeRequests.GetMergeRequest(
+			r.repoID, mrNumber, nil,
+			gitlab.WithContext(ctx),
+		)
+		if err != nil {
+			yield(nil, fmt.Errorf("get merge request: %w", mapError(err)))
+			return
+		}
+
+		opts := gitlab.ListMergeRequestDiscussionsOptions{
+			ListOptions: gitlab.ListOptions{
+				PerPage: _listChangeThreadsPageSize,
+			},
+		}
+		for pageNum := 1; true; pageNum++ {
+			discussions, response, err := r.client.Discussions.ListMergeRequestDiscussions(
+				r.repoID, mrNumber, &opts,
+				gitlab.WithContext(ctx),
+			)
+			if err != nil {
+				yield(nil, fmt.Errorf("list discussions (page %d): %w", pageNum, mapError(err)))
+				return
+			}
+
+			for _, d := range discussions {
+				// Only the first note of a discussion
+				// has the position and resolution state we need.
+				if len(d.Notes) == 0 || !d.Notes[0].Resolvable {
+					continue
+				}
+				note := d.Notes[0]
+
+				thread := &forge.ChangeThread{
+					ID: &MRDiscussion{
+						ID:       d.ID,
+						MRNumber: mrNumber,
+					},
+					Author:   note.Author.Username,
+					Body:     note.Body,
+					Resolved: note.Resolved,
+				}
+				if pos := note.Position; pos != nil {
+					thread.Path = pos.NewPath
+					thread.Line = int(pos.NewLine)
+					thread.Outdated = pos.HeadSHA != "" && pos.HeadSHA != mr.SHA
+				}
+
+				if !yield(thread, nil) {
+					return
+				}
+			}
+
+			if response.CurrentPage >= response.TotalPages {
+				return
+			}
+			opts.Page = response.NextPage
+		}
+	}
+}
+
+// ResolveThread resolves or unresolves a discussion on an MR.
+func (r *Repository) ResolveThread(ctx context.Context, id forge.ChangeThreadID, resolved bool) error {
+	d := mustMRDiscussion(id)
+	_, _, err := r.client.Discussions.ResolveMergeRequestDiscussion(
+		r.repoID, d.MRNumber, d.ID,
+		&gitlab.ResolveMergeRequestDiscussionOptions{Resolved: &resolved},
+		gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("resolve discussion: %w", mapError(err))
+	}
+
+	r.log.Debug("Updated discussion", "id", d.ID, "mr", d.MRNumber, "resolved", resolved)
+	return nil
+}
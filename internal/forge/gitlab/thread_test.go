@@ -0,0 +1,163 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestListChangeThreads(t *testing.T) {
+	discussions := []gitlab.Discussion{
+		{
+			ID: "general",
+			Notes: []*gitlab.Note{
+				{Body: "looks good"},
+			},
+		},
+		{
+			ID: "current",
+			Notes: []*gitlab.Note{
+				{
+					Body:       "rename this",
+					Author:     gitlab.NoteAuthor{Username: "bob"},
+					Resolvable: true,
+					Position: &gitlab.NotePosition{
+						HeadSHA: "def",
+						NewPath: "main.go",
+						NewLine: 3,
+					},
+				},
+				{Body: "will do", Resolvable: true},
+			},
+		},
+		{
+			ID: "old",
+			Notes: []*gitlab.Note{
+				{
+					Body:       "typo",
+					Resolvable: true,
+					Resolved:   true,
+					Position: &gitlab.NotePosition{
+						HeadSHA: "abc",
+						NewPath: "README.md",
+						NewLine: 1,
+					},
+				},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		switch r.URL.Path {
+		case "/api/v4/projects/100":
+			assert.NoError(t, enc.Encode(newProject(100, gitlab.Ptr(gitlab.DeveloperPermissions), nil)))
+		case "/api/v4/user":
+			assert.NoError(t, enc.Encode(gitlab.User{ID: 1}))
+		case "/api/v4/projects/100/merge_requests/1":
+			assert.NoError(t, enc.Encode(gitlab.MergeRequest{
+				BasicMergeRequest: gitlab.BasicMergeRequest{IID: 1, SHA: "def"},
+			}))
+		case "/api/v4/projects/100/merge_requests/1/discussions":
+			assert.NoError(t, enc.Encode(discussions))
+		default:
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	repo := newTestThreadsRepository(t, srv.URL)
+
+	var got []*forge.ChangeThread
+	for thread, err := range repo.ListChangeThreads(t.Context(), &MR{Number: 1}) {
+		require.NoError(t, err)
+		got = append(got, thread)
+	}
+
+	assert.Equal(t, []*forge.ChangeThread{
+		{
+			ID:     &MRDiscussion{ID: "current", MRNumber: 1},
+			Path:   "main.go",
+			Line:   3,
+			Author: "bob",
+			Body:   "rename this",
+		},
+		{
+			ID:       &MRDiscussion{ID: "old", MRNumber: 1},
+			Path:     "README.md",
+			Line:     1,
+			Body:     "typo",
+			Resolved: true,
+			Outdated: true,
+		},
+	}, got)
+}
+
+func TestResolveThread(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolved bool
+	}{
+		{name: "Resolve", resolved: true},
+		{name: "Unresolve", resolved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotResolved *bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				enc := json.NewEncoder(w)
+				switch r.URL.Path {
+				case "/api/v4/projects/100":
+					assert.NoError(t, enc.Encode(newProject(100, gitlab.Ptr(gitlab.DeveloperPermissions), nil)))
+				case "/api/v4/user":
+					assert.NoError(t, enc.Encode(gitlab.User{ID: 1}))
+				case "/api/v4/projects/100/merge_requests/1/discussions/abc":
+					assert.Equal(t, http.MethodPut, r.Method)
+
+					var req gitlab.ResolveMergeRequestDiscussionOptions
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					gotResolved = req.Resolved
+
+					assert.NoError(t, enc.Encode(gitlab.Discussion{ID: "abc"}))
+				default:
+					t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+
+			repo := newTestThreadsRepository(t, srv.URL)
+			err := repo.ResolveThread(t.Context(), &MRDiscussion{ID: "abc", MRNumber: 1}, tt.resolved)
+			require.NoError(t, err)
+
+			require.NotNil(t, gotResolved)
+			assert.Equal(t, tt.resolved, *gotResolved)
+		})
+	}
+}
+
+func newTestThreadsRepository(t *testing.T, url string) *Repository {
+	client, err := newGitLabClient(t.Context(), url, &AuthenticationToken{
+		AuthType:    AuthTypePAT,
+		AccessToken: "token",
+	}, nil, silogtest.New(t))
+	require.NoError(t, err)
+
+	repoID := int64(100)
+	repo, err := newRepository(
+		t.Context(), new(Forge),
+		"owner", "repo",
+		silogtest.New(t),
+		client,
+		&repositoryOptions{RepositoryID: &repoID},
+	)
+	require.NoError(t, err)
+	return repo
+}
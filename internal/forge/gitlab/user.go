@@ -0,0 +1,9 @@
+package gitlab
+
+import "context"
+
+// CurrentUser reports the username of the authenticated user.
+// This was looked up when the repository was opened.
+func (r *Repository) CurrentUser(context.Context) (string, error) {
+	return r.username, nil
+}
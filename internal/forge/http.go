@@ -0,0 +1,200 @@
+package forge
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// HTTPMiddleware wraps the HTTP transport that a forge uses
+// to make API requests.
+//
+// Middleware may modify requests before passing them on
+// (e.g. to add headers or sign them),
+// or inspect responses before returning them.
+// It must not modify the request it was given; clone it instead.
+type HTTPMiddleware func(http.RoundTripper) http.RoundTripper
+
+// WithHTTPTransport is an optional interface that forges can implement
+// if they customize the HTTP transport used for API requests.
+// If implemented, the reachability check for [WithAPIURL]
+// uses the same transport so that it goes through
+// the same proxies with the same credentials.
+type WithHTTPTransport interface {
+	Forge
+
+	// HTTPTransport returns the transport used for API requests.
+	HTTPTransport() (http.RoundTripper, error)
+}
+
+// HTTPOptions configures the transport that a forge uses
+// to make API requests.
+// The zero value uses [http.DefaultTransport] unchanged.
+type HTTPOptions struct {
+	// Headers are additional headers to send with every request
+	// in the form "Name: Value".
+	//
+	// These are commonly needed by authenticating proxies
+	// placed in front of self-hosted forges.
+	Headers []string
+
+	// ClientCert and ClientKey are paths to a PEM-encoded
+	// certificate and private key to present to the server
+	// for mutual TLS authentication.
+	//
+	// ClientKey may be omitted if ClientCert contains the key as well.
+	ClientCert, ClientKey string
+
+	// Proxy is the URL of a proxy to send requests through,
+	// e.g. "http://proxy.example.com:8080".
+	//
+	// If unset, the proxy is picked from the
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables.
+	Proxy string
+
+	// CABundle is the path to a PEM-encoded file
+	// with certificates of additional certificate authorities to trust.
+	// The system's certificate authorities are trusted as well.
+	//
+	// Use this if a proxy intercepts TLS connections
+	// with certificates issued by a private certificate authority.
+	CABundle string
+
+	// Middleware wraps the transport.
+	// The first middleware in the list sees requests first,
+	// after Headers have been added.
+	Middleware []HTTPMiddleware
+}
+
+// Transport builds an [http.RoundTripper] from the given base transport.
+// If base is nil, [http.DefaultTransport] is used.
+//
+// Proxy, CABundle, and client certificates require the base transport
+// to be an [*http.Transport].
+func (o *HTTPOptions) Transport(base http.RoundTripper) (http.RoundTripper, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if o == nil {
+		return base, nil
+	}
+
+	transport := base
+	if o.Proxy != "" || o.CABundle != "" || o.ClientCert != "" || o.ClientKey != "" {
+		httpTransport, ok := base.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("proxy and TLS options require an *http.Transport, got %T", base)
+		}
+
+		// Don't modify the shared transport.
+		httpTransport = httpTransport.Clone()
+		if err := o.configureTransport(httpTransport); err != nil {
+			return nil, err
+		}
+		transport = httpTransport
+	}
+
+	// Wrap in reverse so that the first middleware is outermost.
+	for _, mw := range slices.Backward(o.Middleware) {
+		transport = mw(transport)
+	}
+
+	// Headers are added before middleware runs
+	// so that request signers see them.
+	if len(o.Headers) > 0 {
+		headers := make(http.Header, len(o.Headers))
+		for _, h := range o.Headers {
+			name, value, ok := strings.Cut(h, ":")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("bad header %q: expected 'Name: Value'", h)
+			}
+			headers.Add(name, strings.TrimSpace(value))
+		}
+		transport = &headerTransport{Base: transport, Headers: headers}
+	}
+
+	return transport, nil
+}
+
+// configureTransport applies the proxy and TLS options to t.
+func (o *HTTPOptions) configureTransport(t *http.Transport) error {
+	if o.Proxy != "" {
+		proxyURL, err := url.Parse(o.Proxy)
+		if err != nil {
+			return fmt.Errorf("bad proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return fmt.Errorf("bad proxy URL %q: expected scheme and host, e.g. http://proxy.example.com:8080", o.Proxy)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = new(tls.Config)
+	}
+
+	if o.CABundle != "" {
+		pemBytes, err := os.ReadFile(o.CABundle)
+		if err != nil {
+			return fmt.Errorf("read CA bundle: %w", err)
+		}
+
+		pool := t.TLSClientConfig.RootCAs
+		if pool == nil {
+			pool, err = x509.SystemCertPool()
+			if err != nil {
+				// Not available on all systems.
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
+		}
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return fmt.Errorf("CA bundle %v: no PEM-encoded certificates found", o.CABundle)
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+
+	if o.ClientCert != "" || o.ClientKey != "" {
+		certFile, keyFile := o.ClientCert, o.ClientKey
+		if certFile == "" {
+			return errors.New("client key specified without a client certificate")
+		}
+		if keyFile == "" {
+			keyFile = certFile
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("load client certificate: %w", err)
+		}
+		t.TLSClientConfig.Certificates = append(t.TLSClientConfig.Certificates, cert)
+	}
+
+	return nil
+}
+
+// headerTransport adds fixed headers to every request.
+// Headers already set on the request are left alone.
+type headerTransport struct {
+	Base    http.RoundTripper
+	Headers http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.Headers {
+		if _, ok := req.Header[name]; ok {
+			continue
+		}
+		req.Header[name] = slices.Clone(values)
+	}
+	return t.Base.RoundTrip(req)
+}
@@ -0,0 +1,252 @@
+package forge_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+)
+
+func TestHTTPOptions_headers(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	t.Cleanup(srv.Close)
+
+	transport, err := (&forge.HTTPOptions{
+		Headers: []string{
+			"X-Proxy-Token: secret",
+			"X-Multi: a",
+			"X-Multi: b",
+			"Authorization: Basic proxy",
+		},
+	}).Transport(nil)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	assert.Equal(t, "secret", got.Get("X-Proxy-Token"))
+	assert.Equal(t, []string{"a", "b"}, got.Values("X-Multi"))
+	assert.Equal(t, "Bearer token", got.Get("Authorization"),
+		"headers set on the request must not be overridden")
+	assert.Empty(t, req.Header.Get("X-Proxy-Token"),
+		"original request must not be modified")
+}
+
+func TestHTTPOptions_badHeader(t *testing.T) {
+	_, err := (&forge.HTTPOptions{Headers: []string{"no colon"}}).Transport(nil)
+	assert.ErrorContains(t, err, `bad header "no colon"`)
+}
+
+func TestHTTPOptions_middleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"first", "second"}, r.Header.Values("X-Order"))
+	}))
+	t.Cleanup(srv.Close)
+
+	addOrder := func(name string) forge.HTTPMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				// Headers are added before middleware runs.
+				assert.Equal(t, "value", req.Header.Get("X-Header"))
+
+				req = req.Clone(req.Context())
+				req.Header.Add("X-Order", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	transport, err := (&forge.HTTPOptions{
+		Headers:    []string{"X-Header: value"},
+		Middleware: []forge.HTTPMiddleware{addOrder("first"), addOrder("second")},
+	}).Transport(nil)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+}
+
+func TestHTTPOptions_clientCert(t *testing.T) {
+	certFile, keyFile := writeClientCert(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	// The test server's client trusts its certificate.
+	base := srv.Client().Transport
+
+	transport, err := (&forge.HTTPOptions{
+		ClientCert: certFile,
+		ClientKey:  keyFile,
+	}).Transport(base)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	assert.Empty(t, base.(*http.Transport).TLSClientConfig.Certificates,
+		"base transport must not be modified")
+
+	t.Run("KeyWithoutCert", func(t *testing.T) {
+		_, err := (&forge.HTTPOptions{ClientKey: keyFile}).Transport(nil)
+		assert.ErrorContains(t, err, "client key specified without a client certificate")
+	})
+
+	t.Run("NotHTTPTransport", func(t *testing.T) {
+		_, err := (&forge.HTTPOptions{
+			ClientCert: certFile,
+			ClientKey:  keyFile,
+		}).Transport(roundTripFunc(nil))
+		assert.ErrorContains(t, err, "proxy and TLS options require an *http.Transport")
+	})
+}
+
+func TestHTTPOptions_caBundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, transport http.RoundTripper) error {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		res, err := (&http.Client{Transport: transport}).Do(req)
+		if err == nil {
+			_ = res.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("Untrusted", func(t *testing.T) {
+		transport, err := (&forge.HTTPOptions{}).Transport(nil)
+		require.NoError(t, err)
+
+		var certErr *tls.CertificateVerificationError
+		assert.ErrorAs(t, get(t, transport), &certErr)
+	})
+
+	t.Run("Trusted", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: srv.Certificate().Raw,
+		}), 0o600))
+
+		transport, err := (&forge.HTTPOptions{CABundle: bundle}).Transport(nil)
+		require.NoError(t, err)
+		assert.NoError(t, get(t, transport))
+	})
+
+	t.Run("NoCertificates", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundle, []byte("not a certificate"), 0o600))
+
+		_, err := (&forge.HTTPOptions{CABundle: bundle}).Transport(nil)
+		assert.ErrorContains(t, err, "no PEM-encoded certificates found")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := (&forge.HTTPOptions{
+			CABundle: filepath.Join(t.TempDir(), "missing.pem"),
+		}).Transport(nil)
+		assert.ErrorContains(t, err, "read CA bundle")
+	})
+}
+
+func TestHTTPOptions_proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	t.Cleanup(proxy.Close)
+
+	transport, err := (&forge.HTTPOptions{Proxy: proxy.URL}).Transport(nil)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://forge.example.com/api", nil)
+	require.NoError(t, err)
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	assert.Equal(t, []string{"http://forge.example.com/api"}, proxied)
+
+	t.Run("BadURL", func(t *testing.T) {
+		_, err := (&forge.HTTPOptions{Proxy: "proxy.example.com"}).Transport(nil)
+		assert.ErrorContains(t, err, "expected scheme and host")
+	})
+}
+
+// writeClientCert writes a self-signed client certificate
+// and its key to files, and returns their paths.
+func writeClientCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+	}, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,128 @@
+package shamhub
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+// setUpAPITest sets up a ShamHub server with a repository alice/example
+// and returns an API token for alice,
+// and a function to create new changes in the repository.
+//
+// Each change adds a file named after its branch to main.
+func setUpAPITest(t *testing.T) (sh *ShamHub, token string, newChange func(branch string) int) {
+	t.Setenv("EDITOR", "false") // no editor popups
+	t.Setenv("USER", "test")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	ctx := t.Context()
+
+	sh, err := New(Config{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, sh.Close())
+	})
+
+	require.NoError(t, sh.RegisterUser("alice"))
+	token = loginAndGetToken(t, sh, "alice")
+
+	repoURL, err := sh.NewRepository("alice", "example")
+	require.NoError(t, err)
+
+	workDir := t.TempDir()
+	wt, err := git.Clone(ctx, repoURL, workDir, git.CloneOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+	require.NoError(t, wt.Commit(ctx, git.CommitRequest{
+		Message:    "Initial commit",
+		AllowEmpty: true,
+	}))
+	require.NoError(t, wt.Push(ctx, git.PushOptions{
+		Remote:  "origin",
+		Refspec: "main:main",
+	}))
+
+	newChange = func(branch string) int {
+		repo := wt.Repository()
+		require.NoError(t, repo.CreateBranch(ctx, git.CreateBranchRequest{
+			Name: branch,
+			Head: "main",
+		}))
+		require.NoError(t, wt.CheckoutBranch(ctx, branch))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(workDir, branch+".txt"),
+			[]byte(branch+"\n"), 0o644,
+		))
+		gitAdd(t, workDir, branch+".txt")
+		require.NoError(t, wt.Commit(ctx, git.CommitRequest{
+			Message: "Add " + branch,
+		}))
+		require.NoError(t, wt.Push(ctx, git.PushOptions{
+			Remote:  "origin",
+			Refspec: git.Refspec(branch + ":" + branch),
+		}))
+
+		var res submitChangeResponse
+		apiRequest(t, sh, token, http.MethodPost, "/alice/example/changes", submitChangeRequest{
+			Subject: "Add " + branch,
+			Base:    "main",
+			Head:    branch,
+		}, &res)
+		return res.Number
+	}
+
+	return sh, token, newChange
+}
+
+// apiRequest makes an authenticated request to the ShamHub API,
+// decoding the response into res if it's non-nil.
+// The request must succeed.
+func apiRequest(t *testing.T, sh *ShamHub, token, method, path string, req, res any) {
+	t.Helper()
+
+	status := apiRequestStatus(t, sh, token, method, path, req, res)
+	require.Equal(t, http.StatusOK, status, "%v %v", method, path)
+}
+
+// apiRequestStatus makes an authenticated request to the ShamHub API
+// and reports the HTTP status code of the response.
+// If the request succeeds and res is non-nil,
+// the response is decoded into it.
+func apiRequestStatus(t *testing.T, sh *ShamHub, token, method, path string, req, res any) int {
+	t.Helper()
+
+	var body bytes.Buffer
+	if req != nil {
+		require.NoError(t, json.NewEncoder(&body).Encode(req))
+	}
+
+	httpReq, err := http.NewRequest(method, sh.APIURL()+path, &body)
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authentication-Token", token)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK && res != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(res))
+	}
+	return resp.StatusCode
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/must"
@@ -45,8 +46,26 @@ func (sh *ShamHub) handleLogin(_ context.Context, req *loginRequest) (*loginResp
 	return nil, notFoundErrorf("user %q not found", req.Username)
 }
 
+// userContextKey is the context key for the name of the user
+// that made an API request.
+type userContextKey struct{}
+
+type userResponse struct {
+	Username string `json:"username"`
+}
+
+var _ = shamhubRESTHandler("GET /user", (*ShamHub).handleUser)
+
+func (sh *ShamHub) handleUser(ctx context.Context, _ struct{}) (*userResponse, error) {
+	username, _ := ctx.Value(userContextKey{}).(string)
+	if username == "" {
+		return nil, notFoundErrorf("not logged in")
+	}
+	return &userResponse{Username: username}, nil
+}
+
 type shamUser struct {
-	Username string
+	Username string `json:"username"`
 }
 
 // RegisterUser registers a new user against the Forge
@@ -65,6 +84,16 @@ func (sh *ShamHub) RegisterUser(username string) error {
 	return nil
 }
 
+// HasUser reports whether a user with the given username is registered.
+func (sh *ShamHub) HasUser(username string) bool {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	return slices.ContainsFunc(sh.users, func(u shamUser) bool {
+		return u.Username == username
+	})
+}
+
 // IssueToken issues an authentication token for the given username.
 // The user must already be registered.
 // This is a test helper method.
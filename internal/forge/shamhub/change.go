@@ -54,14 +54,14 @@ const (
 // shamBranch is a branch in a ShamHub-tracked repository.
 type shamBranch struct {
 	// Owner of the repository.
-	Owner string
+	Owner string `json:"owner"`
 
 	// Repo is the name of the repository
 	// under the owner's namespace.
-	Repo string
+	Repo string `json:"repo"`
 
 	// Name is the name of the branch.
-	Name string
+	Name string `json:"name"`
 }
 
 func (b *shamBranch) RepoID() repoID {
@@ -76,31 +76,36 @@ func (b *shamBranch) String() string {
 type shamChange struct {
 	// State is the current state of the change.
 	// It can be open, closed, or merged.
-	State shamChangeState
+	State shamChangeState `json:"state"`
 
 	// Number is the numeric identifier of the change.
 	// These increment monotonically.
-	Number int
+	Number int `json:"number"`
 
 	// Draft indicates that the change is not yet ready to be reviewed.
-	Draft bool
+	Draft bool `json:"draft"`
 
-	Subject string
-	Body    string
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
 
 	// Base and Head branches for the change.
 	// Head will merge into Base.
-	Base, Head *shamBranch
+	Base *shamBranch `json:"base"`
+	Head *shamBranch `json:"head"`
 
 	// Labels are the labels associated with the change.
-	Labels []string
+	Labels []string `json:"labels"`
 
 	// RequestedReviewers are the usernames of users
 	// from whom reviews have been requested.
-	RequestedReviewers []string
+	RequestedReviewers []string `json:"requestedReviewers"`
 
 	// Assignees are users assigned to the change.
-	Assignees []string
+	Assignees []string `json:"assignees"`
+
+	// MergeCommit is the commit created in Base
+	// when the change was merged.
+	MergeCommit string `json:"mergeCommit,omitempty"`
 }
 
 // Change is a change proposal against a repository.
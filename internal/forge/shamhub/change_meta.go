@@ -77,3 +77,18 @@ func (f *Forge) UnmarshalChangeID(data json.RawMessage) (forge.ChangeID, error)
 	}
 	return id, nil
 }
+
+// FormatChangeID formats the given change ID for display, e.g. "#123".
+func (f *Forge) FormatChangeID(id forge.ChangeID) string {
+	return id.String()
+}
+
+// ParseChangeID parses a change number ("123" or "#123")
+// or a change URL.
+func (f *Forge) ParseChangeID(s string) (forge.ChangeID, error) {
+	num, err := forge.ParseChangeNumber(s, []string{"#"}, []string{"change", "changes"})
+	if err != nil {
+		return nil, err
+	}
+	return ChangeID(num), nil
+}
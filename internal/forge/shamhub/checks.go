@@ -0,0 +1,246 @@
+package shamhub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/xec"
+)
+
+// CheckState is the state of a check run reported against a change.
+type CheckState string
+
+const (
+	// CheckPending indicates that a check has not finished yet.
+	CheckPending CheckState = "pending"
+
+	// CheckSuccess indicates that a check passed.
+	CheckSuccess CheckState = "success"
+
+	// CheckFailure indicates that a check failed.
+	CheckFailure CheckState = "failure"
+)
+
+// ParseCheckState parses a check state from its string form.
+func ParseCheckState(s string) (CheckState, error) {
+	switch state := CheckState(s); state {
+	case CheckPending, CheckSuccess, CheckFailure:
+		return state, nil
+	default:
+		return "", fmt.Errorf("unknown check state: %q", s)
+	}
+}
+
+// shamCheck is a check run reported against a commit
+// in a ShamHub repository.
+//
+// Checks are recorded against the commit at the head of a change
+// so that pushing new commits to the change resets its checks.
+type shamCheck struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+
+	// Commit is the hash of the commit that was checked.
+	Commit string `json:"commit"`
+
+	Name  string     `json:"name"`
+	State CheckState `json:"state"`
+}
+
+// ChangeCheck is a check run reported against a change.
+type ChangeCheck struct {
+	// Name identifies the check.
+	Name string `json:"name" yaml:"name"`
+
+	// State is the current state of the check.
+	State CheckState `json:"state" yaml:"state"`
+}
+
+// SetCheckRequest is a request to report the state of a check
+// against the current head of a change.
+type SetCheckRequest struct {
+	Owner, Repo string
+	Number      int
+
+	Name  string
+	State CheckState
+}
+
+// SetCheck reports the state of a check for a change,
+// replacing a prior report of the same check.
+func (sh *ShamHub) SetCheck(req SetCheckRequest) error {
+	if req.Owner == "" || req.Repo == "" || req.Number == 0 || req.Name == "" {
+		return errors.New("owner, repo, number, and name are required")
+	}
+	if _, err := ParseCheckState(string(req.State)); err != nil {
+		return err
+	}
+
+	change, ok := sh.findChange(req.Owner, req.Repo, req.Number)
+	if !ok {
+		return notFoundErrorf("change %s/%s#%d not found", req.Owner, req.Repo, req.Number)
+	}
+
+	commit, err := sh.changeHeadCommit(change)
+	if err != nil {
+		return err
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	check := shamCheck{
+		Owner:  req.Owner,
+		Repo:   req.Repo,
+		Commit: commit,
+		Name:   req.Name,
+		State:  req.State,
+	}
+	idx := slices.IndexFunc(sh.checks, func(c shamCheck) bool {
+		return c.Owner == check.Owner && c.Repo == check.Repo &&
+			c.Commit == check.Commit && c.Name == check.Name
+	})
+	if idx >= 0 {
+		sh.checks[idx] = check
+	} else {
+		sh.checks = append(sh.checks, check)
+	}
+
+	return nil
+}
+
+// ListChangeChecks reports the checks for the current head of a change,
+// sorted by name.
+func (sh *ShamHub) ListChangeChecks(owner, repo string, number int) ([]*ChangeCheck, error) {
+	change, ok := sh.findChange(owner, repo, number)
+	if !ok {
+		return nil, notFoundErrorf("change %s/%s#%d not found", owner, repo, number)
+	}
+
+	commit, err := sh.changeHeadCommit(change)
+	if err != nil {
+		return nil, err
+	}
+
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	var checks []*ChangeCheck
+	for _, c := range sh.checks {
+		if c.Owner == owner && c.Repo == repo && c.Commit == commit {
+			checks = append(checks, &ChangeCheck{Name: c.Name, State: c.State})
+		}
+	}
+	slices.SortFunc(checks, func(a, b *ChangeCheck) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return checks, nil
+}
+
+// combinedCheckState reports the overall state of a list of checks:
+// failure if any check failed, pending if any check is still running,
+// and success otherwise.
+//
+// A change without checks is considered successful.
+func combinedCheckState(checks []*ChangeCheck) CheckState {
+	state := CheckSuccess
+	for _, c := range checks {
+		switch c.State {
+		case CheckFailure:
+			return CheckFailure
+		case CheckPending:
+			state = CheckPending
+		}
+	}
+	return state
+}
+
+// findChange returns the change with the given number
+// proposed against the given repository.
+func (sh *ShamHub) findChange(owner, repo string, number int) (shamChange, bool) {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	for _, c := range sh.changes {
+		if c.Base.Owner == owner && c.Base.Repo == repo && c.Number == number {
+			return c, true
+		}
+	}
+	return shamChange{}, false
+}
+
+// changeHeadCommit returns the hash of the commit
+// at the head of the given change.
+func (sh *ShamHub) changeHeadCommit(change shamChange) (string, error) {
+	out, err := xec.Command(context.Background(), sh.log, sh.gitExe, "rev-parse", change.Head.Name).
+		WithDir(sh.repoDir(change.Head.Owner, change.Head.Repo)).
+		Output()
+	if err != nil {
+		return "", fmt.Errorf("resolve head of change #%d: %w", change.Number, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+var _ = shamhubRESTHandler("GET /{owner}/{repo}/change/{number}/checks", (*ShamHub).handleListChecks)
+
+type listChecksRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+}
+
+type listChecksResponse struct {
+	// State is the combined state of all checks.
+	State CheckState `json:"state"`
+
+	Checks []*ChangeCheck `json:"checks"`
+}
+
+func (sh *ShamHub) handleListChecks(_ context.Context, req *listChecksRequest) (*listChecksResponse, error) {
+	checks, err := sh.ListChangeChecks(req.Owner, req.Repo, req.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listChecksResponse{
+		State:  combinedCheckState(checks),
+		Checks: checks,
+	}, nil
+}
+
+var _ = shamhubRESTHandler("POST /{owner}/{repo}/change/{number}/checks", (*ShamHub).handleSetCheck)
+
+type setCheckRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+
+	Name  string     `json:"name"`
+	State CheckState `json:"state"`
+}
+
+type setCheckResponse struct{}
+
+func (sh *ShamHub) handleSetCheck(_ context.Context, req *setCheckRequest) (*setCheckResponse, error) {
+	if req.Name == "" {
+		return nil, badRequestErrorf("name is required")
+	}
+	if _, err := ParseCheckState(string(req.State)); err != nil {
+		return nil, badRequestErrorf("%v", err)
+	}
+
+	if err := sh.SetCheck(SetCheckRequest{
+		Owner:  req.Owner,
+		Repo:   req.Repo,
+		Number: req.Number,
+		Name:   req.Name,
+		State:  req.State,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &setCheckResponse{}, nil
+}
@@ -182,19 +182,7 @@ func (c *Cmd) Run(ts *testscript.TestScript, neg bool, args []string) {
 			DeleteBranch: *prune,
 			Squash:       *squash,
 		}
-		if at := ts.Getenv("GIT_COMMITTER_DATE"); at != "" {
-			t, err := time.Parse(time.RFC3339, at)
-			if err != nil {
-				ts.Fatalf("invalid time: %s", err)
-			}
-			req.Time = t
-		}
-		if name := ts.Getenv("GIT_COMMITTER_NAME"); name != "" {
-			req.CommitterName = name
-		}
-		if email := ts.Getenv("GIT_COMMITTER_EMAIL"); email != "" {
-			req.CommitterEmail = email
-		}
+		req.Time, req.CommitterName, req.CommitterEmail = committerFromEnv(ts)
 
 		ts.Check(sh.MergeChange(req))
 
@@ -223,6 +211,105 @@ func (c *Cmd) Run(ts *testscript.TestScript, neg bool, args []string) {
 		}
 		ts.Check(sh.RejectChange(req))
 
+	case "check":
+		if len(args) != 4 {
+			ts.Fatalf("usage: shamhub check <owner/repo> <pr> <name> <pending|success|failure>")
+		}
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		pr, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid PR number: %s", err)
+		}
+		state, err := ParseCheckState(args[3])
+		if err != nil {
+			ts.Fatalf("%s", err)
+		}
+
+		ts.Check(sh.SetCheck(SetCheckRequest{
+			Owner:  owner,
+			Repo:   repo,
+			Number: pr,
+			Name:   args[2],
+			State:  state,
+		}))
+
+	case "queue":
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+		c.runQueue(ts, sh, args)
+
+	case "fault":
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+		c.runFault(ts, sh, args)
+
+	case "latency":
+		if len(args) < 1 || len(args) > 2 {
+			ts.Fatalf("usage: shamhub latency <base> [<jitter>]")
+		}
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+
+		base, err := time.ParseDuration(args[0])
+		if err != nil {
+			ts.Fatalf("invalid latency: %s", err)
+		}
+
+		var jitter time.Duration
+		if len(args) > 1 {
+			jitter, err = time.ParseDuration(args[1])
+			if err != nil {
+				ts.Fatalf("invalid jitter: %s", err)
+			}
+		}
+
+		// A fixed seed keeps test scripts deterministic.
+		ts.Check(sh.SetLatency(base, jitter, 0))
+
+	case "draft", "ready":
+		if len(args) != 2 {
+			ts.Fatalf("usage: shamhub %s <owner/repo> <pr>", cmd)
+		}
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		pr, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid PR number: %s", err)
+		}
+
+		ts.Check(sh.SetChangeDraft(owner, repo, pr, cmd == "draft"))
+
+	case "label":
+		if len(args) != 3 || (args[0] != "create" && args[0] != "delete") {
+			ts.Fatalf("usage: shamhub label <create|delete> <owner/repo> <name>")
+		}
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[1])
+		if args[0] == "create" {
+			ts.Check(sh.CreateLabel(owner, repo, args[2]))
+		} else {
+			ts.Check(sh.DeleteLabel(owner, repo, args[2]))
+		}
+
+	case "thread":
+		if sh == nil {
+			ts.Fatalf("ShamHub not initialized")
+		}
+		c.runThread(ts, sh, args)
+
 	case "delete-comment":
 		if len(args) != 1 {
 			ts.Fatalf("usage: shamhub delete-comment <id>")
@@ -361,6 +448,94 @@ func (c *Cmd) Run(ts *testscript.TestScript, neg bool, args []string) {
 			}
 			give = changes[idx]
 
+		case "checks":
+			if len(args) != 1 {
+				ts.Fatalf("usage: shamhub dump checks <N>")
+			}
+
+			want, err := strconv.Atoi(args[0])
+			if err != nil {
+				ts.Fatalf("invalid change number: %s", err)
+			}
+
+			changes, err := sh.ListChanges()
+			if err != nil {
+				ts.Fatalf("list changes: %s", err)
+			}
+			idx := slices.IndexFunc(changes, func(c *Change) bool {
+				return c.Number == want
+			})
+			if idx < 0 {
+				ts.Fatalf("CR %d not found", want)
+			}
+
+			base := changes[idx].Base.Repo
+			checks, err := sh.ListChangeChecks(base.Owner, base.Name, want)
+			if err != nil {
+				ts.Fatalf("list checks: %s", err)
+			}
+
+			give = checks
+			encode = func(v any) error {
+				enc := yaml.NewEncoder(ts.Stdout())
+				enc.SetIndent(2)
+				return enc.Encode(v)
+			}
+
+		case "labels":
+			if len(args) != 1 {
+				ts.Fatalf("usage: shamhub dump labels <owner/repo>")
+			}
+
+			owner, repo := parseOwnerRepo(ts, args[0])
+			labels, err := sh.ListLabels(owner, repo)
+			if err != nil {
+				ts.Fatalf("list labels: %s", err)
+			}
+
+			give = labels
+			encode = func(v any) error {
+				enc := yaml.NewEncoder(ts.Stdout())
+				enc.SetIndent(2)
+				return enc.Encode(v)
+			}
+
+		case "threads":
+			if len(args) != 2 {
+				ts.Fatalf("usage: shamhub dump threads <owner/repo> <N>")
+			}
+
+			owner, repo := parseOwnerRepo(ts, args[0])
+			pr, err := strconv.Atoi(args[1])
+			if err != nil {
+				ts.Fatalf("invalid change number: %s", err)
+			}
+
+			threads, err := sh.ListReviewThreads(owner, repo, pr)
+			if err != nil {
+				ts.Fatalf("list review threads: %s", err)
+			}
+
+			give = threads
+			encode = func(v any) error {
+				enc := yaml.NewEncoder(ts.Stdout())
+				enc.SetIndent(2)
+				return enc.Encode(v)
+			}
+
+		case "queue":
+			if len(args) != 1 {
+				ts.Fatalf("usage: shamhub dump queue <owner/repo>")
+			}
+
+			owner, repo := parseOwnerRepo(ts, args[0])
+			give = sh.ListMergeQueue(owner, repo)
+			encode = func(v any) error {
+				enc := yaml.NewEncoder(ts.Stdout())
+				enc.SetIndent(2)
+				return enc.Encode(v)
+			}
+
 		default:
 			ts.Fatalf("unknown dump command: %s", cmd)
 		}
@@ -371,3 +546,212 @@ func (c *Cmd) Run(ts *testscript.TestScript, neg bool, args []string) {
 		ts.Fatalf("unknown command: %s", cmd)
 	}
 }
+
+// runQueue implements the 'shamhub queue' subcommands.
+func (c *Cmd) runFault(ts *testscript.TestScript, sh *ShamHub, args []string) {
+	if len(args) == 1 && args[0] == "clear" {
+		sh.ClearFaults()
+		return
+	}
+
+	logw, closeLogw := ioutil.PrintfWriter(ts.Logf, "shamhub fault: ")
+	ts.Defer(closeLogw)
+
+	flag := flag.NewFlagSet("shamhub fault", flag.ContinueOnError)
+	flag.SetOutput(logw)
+	flag.Usage = func() {
+		fmt.Fprintln(logw, "usage: shamhub fault [-method M] [-path P] [-skip N] [-times N] [-retry-after D] <status>")
+		fmt.Fprintln(logw, "       shamhub fault clear")
+	}
+
+	var fault Fault
+	flag.StringVar(&fault.Method, "method", "", "only fail requests with this HTTP method")
+	flag.StringVar(&fault.Path, "path", "", "only fail requests with this path prefix")
+	flag.IntVar(&fault.Skip, "skip", 0, "number of matching requests to let through first")
+	flag.IntVar(&fault.Times, "times", 1, "number of matching requests to fail")
+	flag.DurationVar(&fault.RetryAfter, "retry-after", 0, "value of the Retry-After header")
+	ts.Check(flag.Parse(args))
+	args = flag.Args()
+	if len(args) != 1 {
+		flag.Usage()
+		ts.Fatalf("expected 1 argument, got %d", len(args))
+	}
+
+	status, err := strconv.Atoi(args[0])
+	if err != nil {
+		ts.Fatalf("invalid status code: %s", err)
+	}
+	fault.Status = status
+
+	ts.Check(sh.InjectFault(fault))
+}
+
+func (c *Cmd) runQueue(ts *testscript.TestScript, sh *ShamHub, args []string) {
+	if len(args) == 0 {
+		ts.Fatalf("usage: shamhub queue <add|remove|run> [args ...]")
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "add":
+		logw, closeLogw := ioutil.PrintfWriter(ts.Logf, "shamhub queue add: ")
+		ts.Defer(closeLogw)
+
+		flag := flag.NewFlagSet("shamhub queue add", flag.ContinueOnError)
+		flag.SetOutput(logw)
+		flag.Usage = func() {
+			fmt.Fprintln(logw, "usage: shamhub queue add [-prune] [-squash] <owner/repo> <pr>")
+		}
+
+		prune := flag.Bool("prune", false, "prune the branch after merging")
+		squash := flag.Bool("squash", false, "squash-merge the commit")
+		ts.Check(flag.Parse(args))
+		args = flag.Args()
+		if len(args) != 2 {
+			flag.Usage()
+			ts.Fatalf("expected 2 arguments, got %d", len(args))
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		pr, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid PR number: %s", err)
+		}
+
+		ts.Check(sh.EnqueueChange(EnqueueChangeRequest{
+			Owner:        owner,
+			Repo:         repo,
+			Number:       pr,
+			Squash:       *squash,
+			DeleteBranch: *prune,
+		}))
+
+	case "remove":
+		if len(args) != 2 {
+			ts.Fatalf("usage: shamhub queue remove <owner/repo> <pr>")
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		pr, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid PR number: %s", err)
+		}
+
+		ts.Check(sh.DequeueChange(owner, repo, pr))
+
+	case "run":
+		if len(args) != 1 {
+			ts.Fatalf("usage: shamhub queue run <owner/repo>")
+		}
+
+		req := ProcessMergeQueueRequest{}
+		req.Owner, req.Repo = parseOwnerRepo(ts, args[0])
+		req.Time, req.CommitterName, req.CommitterEmail = committerFromEnv(ts)
+
+		res, err := sh.ProcessMergeQueue(req)
+		ts.Check(err)
+
+		// Report what happened so scripts can assert on it.
+		for _, n := range res.Merged {
+			fmt.Fprintf(ts.Stdout(), "merged #%d\n", n)
+		}
+		for _, n := range res.Ejected {
+			fmt.Fprintf(ts.Stdout(), "ejected #%d\n", n)
+		}
+
+	default:
+		ts.Fatalf("unknown queue command: %s", cmd)
+	}
+}
+
+// runThread implements the 'shamhub thread' subcommands.
+func (c *Cmd) runThread(ts *testscript.TestScript, sh *ShamHub, args []string) {
+	if len(args) == 0 {
+		ts.Fatalf("usage: shamhub thread <add|reply|resolve|unresolve> [args ...]")
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "add":
+		if len(args) != 4 {
+			ts.Fatalf("usage: shamhub thread add <owner/repo> <pr> <path>:<line> <body>")
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		pr, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid PR number: %s", err)
+		}
+
+		path, lineStr, ok := strings.Cut(args[2], ":")
+		if !ok {
+			ts.Fatalf("invalid anchor, expected <path>:<line>: %s", args[2])
+		}
+		line, err := strconv.Atoi(lineStr)
+		if err != nil {
+			ts.Fatalf("invalid line number: %s", err)
+		}
+
+		id, err := sh.AddReviewThread(AddReviewThreadRequest{
+			Owner:  owner,
+			Repo:   repo,
+			Number: pr,
+			Path:   path,
+			Line:   line,
+			Body:   args[3],
+		})
+		ts.Check(err)
+		fmt.Fprintln(ts.Stdout(), id)
+
+	case "reply":
+		if len(args) != 3 {
+			ts.Fatalf("usage: shamhub thread reply <owner/repo> <id> <body>")
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid thread ID: %s", err)
+		}
+
+		ts.Check(sh.ReplyToReviewThread(owner, repo, id, args[2]))
+
+	case "resolve", "unresolve":
+		if len(args) != 2 {
+			ts.Fatalf("usage: shamhub thread %s <owner/repo> <id>", cmd)
+		}
+
+		owner, repo := parseOwnerRepo(ts, args[0])
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			ts.Fatalf("invalid thread ID: %s", err)
+		}
+
+		ts.Check(sh.ResolveReviewThread(owner, repo, id, cmd == "resolve"))
+
+	default:
+		ts.Fatalf("unknown thread command: %s", cmd)
+	}
+}
+
+// parseOwnerRepo parses an "owner/repo" argument.
+func parseOwnerRepo(ts *testscript.TestScript, ownerRepo string) (owner, repo string) {
+	owner, repo, ok := strings.Cut(ownerRepo, "/")
+	if !ok {
+		ts.Fatalf("invalid owner/repo: %s", ownerRepo)
+	}
+	return owner, strings.TrimSuffix(repo, ".git")
+}
+
+// committerFromEnv returns the committer information
+// to use for merge commits made by ShamHub on behalf of a script.
+func committerFromEnv(ts *testscript.TestScript) (at time.Time, name, email string) {
+	if date := ts.Getenv("GIT_COMMITTER_DATE"); date != "" {
+		t, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			ts.Fatalf("invalid time: %s", err)
+		}
+		at = t
+	}
+	return at, ts.Getenv("GIT_COMMITTER_NAME"), ts.Getenv("GIT_COMMITTER_EMAIL")
+}
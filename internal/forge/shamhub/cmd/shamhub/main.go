@@ -0,0 +1,255 @@
+// shamhub runs a ShamHub server for manual testing of git-spice.
+//
+// ShamHub is a fake, GitHub-like forge used by git-spice's tests.
+// This command runs it as a long-lived local server
+// with its state persisted to disk,
+// so that submit, merge, and sync workflows
+// can be exercised without an account on a real forge.
+//
+// Usage:
+//
+//	shamhub serve [-dir DIR] [-user NAME]... [-repo OWNER/REPO]...
+//	shamhub merge [-squash] [-prune] OWNER/REPO NUMBER
+//
+// git-spice must be built with the "shamhub" build tag
+// to talk to the server.
+// See the README in the shamhub package for details.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"go.abhg.dev/gs/internal/forge/shamhub"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+func main() {
+	log := silog.New(os.Stderr, &silog.Options{
+		Level: silog.LevelInfo,
+	})
+
+	if err := run(log, os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatalf("shamhub: %v", err)
+	}
+}
+
+func run(log *silog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: shamhub <serve|merge> [args ...]")
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "serve":
+		return serve(log, args)
+	case "merge":
+		return merge(args)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func serve(log *silog.Logger, args []string) error {
+	var (
+		cfg   shamhub.Config
+		users []string
+		repos []string
+	)
+	cfg.Log = log
+
+	flags := flag.NewFlagSet("shamhub serve", flag.ContinueOnError)
+	flags.StringVar(&cfg.Dir, "dir", ".shamhub", "Directory to store repositories and state in")
+	flags.StringVar(&cfg.APIAddr, "api-addr", "localhost:8081", "Address for the API server")
+	flags.StringVar(&cfg.GitAddr, "git-addr", "localhost:8080", "Address for the Git server")
+	flags.Func("user", "Register a user if it doesn't exist (repeatable)", func(s string) error {
+		users = append(users, s)
+		return nil
+	})
+	flags.Func("repo", "Create an OWNER/REPO repository if it doesn't exist (repeatable)", func(s string) error {
+		repos = append(repos, s)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	sh, err := shamhub.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Set up users and repositories before waiting for a signal
+	// so that failures are reported right away.
+	if err := func() error {
+		for _, user := range users {
+			if sh.HasUser(user) {
+				continue
+			}
+			if err := sh.RegisterUser(user); err != nil {
+				return fmt.Errorf("register user: %w", err)
+			}
+			log.Infof("Registered user %v", user)
+		}
+
+		for _, ownerRepo := range repos {
+			owner, repo, ok := strings.Cut(ownerRepo, "/")
+			if !ok {
+				return fmt.Errorf("invalid repository %q: expected OWNER/REPO", ownerRepo)
+			}
+			if sh.HasRepository(owner, repo) {
+				continue
+			}
+			if _, err := sh.NewRepository(owner, repo); err != nil {
+				return fmt.Errorf("create repository: %w", err)
+			}
+			log.Infof("Created repository %v", sh.RepoURL(owner, repo))
+		}
+		return nil
+	}(); err != nil {
+		return errors.Join(err, sh.Close())
+	}
+
+	log.Infof("ShamHub is running with state in %v", cfg.Dir)
+	log.Infof("Use it from a git-spice built with '-tags shamhub' by setting:")
+	log.Infof("  export SHAMHUB_URL=%v", sh.GitURL())
+	log.Infof("  export SHAMHUB_API_URL=%v", sh.APIURL())
+	log.Infof("  export SHAMHUB_USERNAME=<user>")
+	log.Infof("Press Ctrl-C to stop.")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	log.Infof("Shutting down")
+	return sh.Close()
+}
+
+// merge merges a change on a running ShamHub server,
+// standing in for the "Merge" button on a real forge.
+//
+// It uses the same environment variables as git-spice
+// to locate the server and authenticate.
+func merge(args []string) error {
+	flags := flag.NewFlagSet("shamhub merge", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: shamhub merge [-prune] [-squash] OWNER/REPO NUMBER")
+		flags.PrintDefaults()
+	}
+	prune := flags.Bool("prune", false, "Delete the branch after merging")
+	squash := flags.Bool("squash", false, "Squash-merge the change")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	owner, repo, ok := strings.Cut(flags.Arg(0), "/")
+	if !ok {
+		return fmt.Errorf("invalid repository %q: expected OWNER/REPO", flags.Arg(0))
+	}
+	number, err := strconv.Atoi(flags.Arg(1))
+	if err != nil {
+		return fmt.Errorf("invalid change number: %w", err)
+	}
+
+	apiURL := os.Getenv("SHAMHUB_API_URL")
+	if apiURL == "" {
+		return errors.New("SHAMHUB_API_URL is required")
+	}
+	username := os.Getenv("SHAMHUB_USERNAME")
+	if username == "" {
+		return errors.New("SHAMHUB_USERNAME is required")
+	}
+
+	client := &apiClient{URL: apiURL}
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	if err := client.Post("login", map[string]string{"username": username}, &login); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	client.Token = login.Token
+
+	req := map[string]bool{
+		"squash":       *squash,
+		"deleteBranch": *prune,
+	}
+	path, err := url.JoinPath(owner, repo, "change", strconv.Itoa(number), "merge")
+	if err != nil {
+		return err
+	}
+	if err := client.Post(path, req, nil); err != nil {
+		return fmt.Errorf("merge %v/%v#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
+
+// apiClient is a minimal client for the ShamHub API.
+type apiClient struct {
+	URL   string
+	Token string
+}
+
+// Post sends req as JSON to the given path under the API URL,
+// and decodes the JSON response into res if it's non-nil.
+func (c *apiClient) Post(path string, req, res any) error {
+	u, err := url.JoinPath(c.URL, path)
+	if err != nil {
+		return fmt.Errorf("parse API URL: %w", err)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		httpReq.Header.Set("Authentication-Token", c.Token)
+	}
+
+	httpRes, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = httpRes.Body.Close() }()
+
+	resBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v: %s", httpRes.Status, bytes.TrimSpace(resBody))
+	}
+
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(resBody, res)
+}
@@ -60,9 +60,9 @@ func (id ChangeCommentID) String() string {
 }
 
 type shamComment struct {
-	ID     int
-	Change int
-	Body   string
+	ID     int    `json:"id"`
+	Change int    `json:"change"`
+	Body   string `json:"body"`
 }
 
 var (
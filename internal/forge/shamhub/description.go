@@ -0,0 +1,24 @@
+package shamhub
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.abhg.dev/gs/internal/forge"
+)
+
+func (r *forgeRepository) ChangeDescription(ctx context.Context, fid forge.ChangeID) (*forge.ChangeDescription, error) {
+	id := fid.(ChangeID)
+	u := r.apiURL.JoinPath(r.owner, r.repo, "change", strconv.Itoa(int(id)))
+
+	var res Change
+	if err := r.client.Get(ctx, u.String(), &res); err != nil {
+		return nil, fmt.Errorf("get change: %w", err)
+	}
+
+	return &forge.ChangeDescription{
+		Subject: res.Subject,
+		Body:    res.Body,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package shamhub
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/xec"
+)
+
+var _ = shamhubRESTHandler("GET /{owner}/{repo}/change/{number}/diffstat", (*ShamHub).handleChangeDiffStat)
+
+type changeDiffStatRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+}
+
+type changeDiffStatResponse struct {
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	ChangedFiles int `json:"changedFiles"`
+}
+
+func (sh *ShamHub) handleChangeDiffStat(ctx context.Context, req *changeDiffStatRequest) (*changeDiffStatResponse, error) {
+	owner, repo, num := req.Owner, req.Repo, req.Number
+	sh.mu.RLock()
+	var (
+		got   shamChange
+		found bool
+	)
+	for _, c := range sh.changes {
+		if c.Base.Owner == owner && c.Base.Repo == repo && c.Number == num {
+			got = c
+			found = true
+			break
+		}
+	}
+	sh.mu.RUnlock()
+
+	if !found {
+		return nil, notFoundErrorf("change %s/%s#%d not found", owner, repo, num)
+	}
+
+	repoDir := sh.repoDir(owner, repo)
+	head := got.Head.Name
+	if got.Head.Owner != owner || got.Head.Repo != repo {
+		// Head is in a fork. Fetch it without creating a branch.
+		forkDir := sh.repoDir(got.Head.Owner, got.Head.Repo)
+		if err := xec.Command(ctx, sh.log, sh.gitExe, "fetch", forkDir, got.Head.Name).
+			WithDir(repoDir).
+			Run(); err != nil {
+			return nil, fmt.Errorf("fetch from fork: %w", err)
+		}
+		head = "FETCH_HEAD"
+	}
+
+	out, err := xec.Command(ctx, sh.log, sh.gitExe, "diff", "--numstat", got.Base.Name+"..."+head).
+		WithDir(repoDir).
+		Output()
+	if err != nil {
+		return nil, fmt.Errorf("diff: %w", err)
+	}
+
+	var res changeDiffStatResponse
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		// Each line is "<added>\t<deleted>\t<path>".
+		// Binary files report "-" for both counts.
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+
+		res.ChangedFiles++
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			res.Additions += n
+		}
+		if n, err := strconv.Atoi(fields[1]); err == nil {
+			res.Deletions += n
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan diff: %w", err)
+	}
+
+	return &res, nil
+}
+
+func (r *forgeRepository) ChangeDiffStat(ctx context.Context, fid forge.ChangeID) (*forge.DiffStat, error) {
+	id := fid.(ChangeID)
+	u := r.apiURL.JoinPath(r.owner, r.repo, "change", strconv.Itoa(int(id)), "diffstat")
+
+	var res changeDiffStatResponse
+	if err := r.client.Get(ctx, u.String(), &res); err != nil {
+		return nil, fmt.Errorf("get diff stat: %w", err)
+	}
+
+	return &forge.DiffStat{
+		Additions:    res.Additions,
+		Deletions:    res.Deletions,
+		ChangedFiles: res.ChangedFiles,
+	}, nil
+}
@@ -19,6 +19,10 @@ type editChangeRequest struct {
 	Labels    []string `json:"labels,omitempty"`
 	Reviewers []string `json:"reviewers,omitempty"`
 	Assignees []string `json:"assignees,omitempty"`
+
+	// RemoveLabels are labels to remove from the change.
+	// Labels that aren't on the change are ignored.
+	RemoveLabels []string `json:"removeLabels,omitempty"`
 }
 
 type editChangeResponse struct{}
@@ -41,11 +45,18 @@ func (sh *ShamHub) handleEditChange(_ context.Context, req *editChangeRequest) (
 		return nil, notFoundErrorf("change %s/%s#%d not found", owner, repo, num)
 	}
 
+	// Like GitHub, only open changes may move
+	// between draft and ready for review.
+	draftChanged := req.Draft != nil && *req.Draft != sh.changes[changeIdx].Draft
+	if draftChanged && sh.changes[changeIdx].State != shamChangeOpen {
+		return nil, badRequestErrorf("change %s/%s#%d is not open", owner, repo, num)
+	}
+
 	if b := req.Base; b != nil {
 		sh.changes[changeIdx].Base.Name = *b
 	}
-	if d := req.Draft; d != nil {
-		sh.changes[changeIdx].Draft = *d
+	if draftChanged {
+		sh.changes[changeIdx].Draft = *req.Draft
 	}
 	if len(req.Labels) > 0 {
 		labels := sh.changes[changeIdx].Labels
@@ -55,6 +66,12 @@ func (sh *ShamHub) handleEditChange(_ context.Context, req *editChangeRequest) (
 			}
 		}
 		sh.changes[changeIdx].Labels = labels
+		sh.defineLabels(owner, repo, req.Labels)
+	}
+	if len(req.RemoveLabels) > 0 {
+		sh.changes[changeIdx].Labels = slices.DeleteFunc(sh.changes[changeIdx].Labels, func(l string) bool {
+			return slices.Contains(req.RemoveLabels, l)
+		})
 	}
 	if len(req.Reviewers) > 0 {
 		// Validate that all requested reviewers are registered users.
@@ -107,6 +124,18 @@ func (sh *ShamHub) handleEditChange(_ context.Context, req *editChangeRequest) (
 	return &editChangeResponse{}, nil // empty for now
 }
 
+// SetChangeDraft marks an open change as a draft
+// or as ready for review.
+func (sh *ShamHub) SetChangeDraft(owner, repo string, number int, draft bool) error {
+	_, err := sh.handleEditChange(context.Background(), &editChangeRequest{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		Draft:  &draft,
+	})
+	return err
+}
+
 func (r *forgeRepository) EditChange(ctx context.Context, fid forge.ChangeID, opts forge.EditChangeOptions) error {
 	var req editChangeRequest
 	if opts.Base != "" {
@@ -0,0 +1,181 @@
+package shamhub
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault is a failure injected into responses from the ShamHub API.
+// Use it to exercise error handling, retries, and backoff
+// in clients of the API deterministically.
+//
+// A fault matches requests by method and path.
+// It lets the first Skip matching requests through,
+// and then fails the next Times matching requests
+// before it's removed.
+type Fault struct {
+	// Method restricts the fault to requests with this HTTP method.
+	// If empty, requests with any method match.
+	Method string
+
+	// Path restricts the fault to requests
+	// whose URL path starts with this prefix.
+	// If empty, requests to any path match.
+	Path string
+
+	// Skip is the number of matching requests to let through
+	// before the fault takes effect.
+	// With Skip set to N-1, the Nth matching request fails.
+	Skip int
+
+	// Times is the number of matching requests to fail
+	// once the fault takes effect.
+	// Defaults to 1.
+	Times int
+
+	// Status is the HTTP status code to respond with.
+	// It must be a 4xx or 5xx status code.
+	Status int // required
+
+	// RetryAfter, if set, is reported in the Retry-After header
+	// of failed responses, rounded up to the nearest second.
+	//
+	// If unset, responses with status 429 (Too Many Requests)
+	// report a Retry-After of 1 second.
+	RetryAfter time.Duration
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) &&
+		strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// InjectFault adds a fault to the ShamHub API.
+// Faults are considered in the order they were added;
+// a request is failed by the first fault that takes effect on it.
+func (sh *ShamHub) InjectFault(f Fault) error {
+	if f.Status < 400 || f.Status > 599 {
+		return errors.New("fault status must be a 4xx or 5xx code")
+	}
+	if f.Skip < 0 || f.Times < 0 {
+		return errors.New("fault skip and times must not be negative")
+	}
+	if f.Times == 0 {
+		f.Times = 1
+	}
+
+	sh.faultMu.Lock()
+	defer sh.faultMu.Unlock()
+
+	sh.faults = append(sh.faults, &f)
+	return nil
+}
+
+// ClearFaults removes all faults from the ShamHub API,
+// including those that haven't taken effect yet.
+func (sh *ShamHub) ClearFaults() {
+	sh.faultMu.Lock()
+	defer sh.faultMu.Unlock()
+
+	sh.faults = nil
+}
+
+// SetLatency delays every ShamHub API response by at least base.
+// If jitter is positive, an additional random delay
+// in the range [0, jitter) is added to each response.
+//
+// The random delays are drawn from a generator seeded with seed,
+// so a sequence of requests sees the same delays from run to run.
+// Use zero for base and jitter to disable latency.
+func (sh *ShamHub) SetLatency(base, jitter time.Duration, seed uint64) error {
+	if base < 0 || jitter < 0 {
+		return errors.New("latency must not be negative")
+	}
+
+	sh.faultMu.Lock()
+	defer sh.faultMu.Unlock()
+
+	sh.latency = base
+	sh.jitter = jitter
+	sh.jitterRand = rand.New(rand.NewPCG(seed, seed))
+	return nil
+}
+
+// injectFault applies injected latency and faults to a request.
+// It reports whether a fault was written to w,
+// in which case the request must not be served.
+func (sh *ShamHub) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	delay, fault := sh.nextFault(r)
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	if fault == nil {
+		return false
+	}
+
+	retryAfter := fault.RetryAfter
+	if retryAfter == 0 && fault.Status == http.StatusTooManyRequests {
+		retryAfter = time.Second
+	}
+	if retryAfter > 0 {
+		secs := int((retryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(secs))
+	}
+
+	sh.log.Infof("ShamHub: injecting fault: %d %s", fault.Status, http.StatusText(fault.Status))
+	http.Error(w, "injected fault: "+http.StatusText(fault.Status), fault.Status)
+	return true
+}
+
+// nextFault reports the delay and fault, if any,
+// to apply to the given request,
+// updating the state of faults that match it.
+func (sh *ShamHub) nextFault(r *http.Request) (time.Duration, *Fault) {
+	sh.faultMu.Lock()
+	defer sh.faultMu.Unlock()
+
+	delay := sh.latency
+	if sh.jitter > 0 {
+		delay += time.Duration(sh.jitterRand.Int64N(int64(sh.jitter)))
+	}
+
+	// Every matching fault sees the request,
+	// but only the first one in effect fails it.
+	var failed *Fault
+	for _, f := range sh.faults {
+		if !f.matches(r) {
+			continue
+		}
+
+		if f.Skip > 0 {
+			f.Skip--
+			continue
+		}
+
+		if failed == nil {
+			failed = f
+		}
+	}
+
+	if failed == nil {
+		return delay, nil
+	}
+
+	fault := *failed
+	failed.Times--
+	sh.faults = slices.DeleteFunc(sh.faults, func(f *Fault) bool {
+		return f.Times <= 0
+	})
+	return delay, &fault
+}
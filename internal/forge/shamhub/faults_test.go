@@ -0,0 +1,124 @@
+package shamhub
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectFault(t *testing.T) {
+	sh, token, newChange := setUpAPITest(t)
+	newChange("feat1")
+
+	getChange := func() int {
+		return apiRequestStatus(t, sh, token, http.MethodGet, "/alice/example/change/1", nil, nil)
+	}
+
+	t.Run("SkipAndTimes", func(t *testing.T) {
+		require.NoError(t, sh.InjectFault(Fault{
+			Method: http.MethodGet,
+			Path:   "/alice/example/change/",
+			Skip:   1,
+			Times:  2,
+			Status: http.StatusInternalServerError,
+		}))
+
+		assert.Equal(t, []int{
+			http.StatusOK,
+			http.StatusInternalServerError,
+			http.StatusInternalServerError,
+			http.StatusOK,
+		}, []int{getChange(), getChange(), getChange(), getChange()})
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		require.NoError(t, sh.InjectFault(Fault{
+			Method: http.MethodPost,
+			Status: http.StatusInternalServerError,
+		}))
+		defer sh.ClearFaults()
+
+		assert.Equal(t, http.StatusOK, getChange())
+	})
+
+	t.Run("RetryAfter", func(t *testing.T) {
+		require.NoError(t, sh.InjectFault(Fault{Status: http.StatusTooManyRequests}))
+		require.NoError(t, sh.InjectFault(Fault{
+			Status:     http.StatusServiceUnavailable,
+			RetryAfter: 1500 * time.Millisecond,
+		}))
+
+		retryAfter := func() (int, string) {
+			req, err := http.NewRequest(http.MethodGet, sh.APIURL()+"/alice/example/change/1", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authentication-Token", token)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = res.Body.Close()
+			return res.StatusCode, res.Header.Get("Retry-After")
+		}
+
+		status, header := retryAfter()
+		assert.Equal(t, http.StatusTooManyRequests, status)
+		assert.Equal(t, "1", header, "default for 429")
+
+		status, header = retryAfter()
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, "2", header, "rounded up")
+
+		assert.Equal(t, http.StatusOK, getChange())
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		require.NoError(t, sh.InjectFault(Fault{
+			Status: http.StatusInternalServerError,
+			Times:  10,
+		}))
+		sh.ClearFaults()
+
+		assert.Equal(t, http.StatusOK, getChange())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Error(t, sh.InjectFault(Fault{Status: http.StatusOK}))
+		assert.Error(t, sh.InjectFault(Fault{Status: http.StatusInternalServerError, Skip: -1}))
+	})
+}
+
+func TestSetLatency(t *testing.T) {
+	delays := func(seed uint64) []time.Duration {
+		sh, err := New(Config{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = sh.Close() })
+		require.NoError(t, sh.SetLatency(10*time.Millisecond, 20*time.Millisecond, seed))
+
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		var ds []time.Duration
+		for range 5 {
+			d, fault := sh.nextFault(req)
+			assert.Nil(t, fault)
+			assert.GreaterOrEqual(t, d, 10*time.Millisecond)
+			assert.Less(t, d, 30*time.Millisecond)
+			ds = append(ds, d)
+		}
+		return ds
+	}
+
+	assert.Equal(t, delays(42), delays(42), "same seed should produce same delays")
+	assert.NotEqual(t, delays(1), delays(2), "different seeds should produce different delays")
+
+	t.Run("Applied", func(t *testing.T) {
+		sh, token, _ := setUpAPITest(t)
+		require.NoError(t, sh.SetLatency(50*time.Millisecond, 0, 0))
+
+		start := time.Now()
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/labels", nil, nil)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+}
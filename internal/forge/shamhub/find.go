@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
@@ -51,6 +52,11 @@ type findChangesByBranchRequest struct {
 
 	Limit int    `form:"limit" json:"-"`
 	State string `form:"state" json:"-"`
+
+	// Optional filters:
+	// base branch name, and head repository in the form "owner/repo".
+	Base     string `form:"base" json:"-"`
+	HeadRepo string `form:"head_repo" json:"-"`
 }
 
 func (sh *ShamHub) handleFindChangesByBranch(_ context.Context, req *findChangesByBranchRequest) ([]*Change, error) {
@@ -77,6 +83,21 @@ func (sh *ShamHub) handleFindChangesByBranch(_ context.Context, req *findChanges
 		filters = append(filters, func(c shamChange) bool { return c.State == s })
 	}
 
+	if base := req.Base; base != "" {
+		filters = append(filters, func(c shamChange) bool { return c.Base.Name == base })
+	}
+
+	if req.HeadRepo != "" {
+		headOwner, headRepo, ok := strings.Cut(req.HeadRepo, "/")
+		if !ok {
+			return nil, badRequestErrorf("invalid head_repo format, expected 'owner/repo'")
+		}
+		filters = append(filters,
+			func(c shamChange) bool { return c.Head.Owner == headOwner },
+			func(c shamChange) bool { return c.Head.Repo == headRepo },
+		)
+	}
+
 	var got []shamChange
 	sh.mu.RLock()
 nextChange:
@@ -142,6 +163,7 @@ func toFindChangeItem(c *Change) *forge.FindChangeItem {
 		URL:       c.URL,
 		Subject:   c.Subject,
 		HeadHash:  git.Hash(c.Head.Hash),
+		HeadName:  c.Head.Name,
 		BaseName:  c.Base.Name,
 		Draft:     c.Draft,
 		State:     state,
@@ -188,3 +210,37 @@ func (r *forgeRepository) FindChangesByBranch(ctx context.Context, branch string
 	}
 	return changes, nil
 }
+
+func (r *forgeRepository) FindOpenChangeByHead(ctx context.Context, req forge.FindOpenChangeByHeadRequest) (*forge.FindChangeItem, error) {
+	headRepo := r.owner + "/" + r.repo
+	if req.HeadRepository != nil {
+		headRepo = req.HeadRepository.(*RepositoryID).String()
+	}
+
+	u := r.apiURL.JoinPath(r.owner, r.repo, "changes", "by-branch", req.Head)
+	q := u.Query()
+	q.Set("state", "open")
+	q.Set("head_repo", headRepo)
+	if req.Base != "" {
+		q.Set("base", req.Base)
+	}
+	u.RawQuery = q.Encode()
+
+	var res []*Change
+	if err := r.client.Get(ctx, u.String(), &res); err != nil {
+		return nil, fmt.Errorf("find open change by head: %w", err)
+	}
+	if len(res) == 0 {
+		return nil, forge.ErrNotFound
+	}
+
+	// Changes are listed oldest first.
+	if req.Unique && len(res) > 1 {
+		matches := make([]*forge.FindChangeItem, len(res))
+		for i, c := range res {
+			matches[len(res)-1-i] = toFindChangeItem(c)
+		}
+		return nil, &forge.AmbiguousChangeError{Changes: matches}
+	}
+	return toFindChangeItem(res[len(res)-1]), nil
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/httplog"
 	"go.abhg.dev/gs/internal/must"
 	"go.abhg.dev/gs/internal/silog"
 )
@@ -71,7 +72,8 @@ func (f *Forge) ParseRemoteURL(remoteURL string) (forge.RepositoryID, error) {
 
 // OpenRepository opens the repository that this repository ID points to.
 func (f *Forge) OpenRepository(_ context.Context, token forge.AuthenticationToken, id forge.RepositoryID) (forge.Repository, error) {
-	return newRepository(f, token.(*AuthenticationToken), id.(*RepositoryID), http.DefaultClient)
+	httpClient := &http.Client{Transport: httplog.WrapTransport(nil, f.Log)}
+	return newRepository(f, token.(*AuthenticationToken), id.(*RepositoryID), httpClient)
 }
 
 // newRepository creates a new repository instance with the given HTTP client.
@@ -240,6 +240,10 @@ func (sh *ShamHub) apiHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		sh.log.Infof("ShamHub: %s %s", r.Method, r.URL.String())
 
+		if sh.injectFault(w, r) {
+			return
+		}
+
 		// Everything except /auth/login requires a token.
 		if r.URL.Path != "/login" {
 			token := r.Header.Get("Authentication-Token")
@@ -249,15 +253,24 @@ func (sh *ShamHub) apiHandler() http.Handler {
 			}
 
 			sh.mu.RLock()
-			_, ok := sh.tokens[token]
+			username, ok := sh.tokens[token]
 			sh.mu.RUnlock()
 			if !ok {
 				http.Error(w, "invalid token", http.StatusUnauthorized)
 				return
 			}
+			r = r.WithContext(context.WithValue(r.Context(), userContextKey{}, username))
 		}
 
 		mux.ServeHTTP(w, r)
+
+		// Requests other than GET may have changed the state.
+		// Persist it right away so that it survives a crash.
+		if r.Method != http.MethodGet {
+			if err := sh.saveState(); err != nil {
+				sh.log.Errorf("ShamHub: save state: %v", err)
+			}
+		}
 	})
 }
 
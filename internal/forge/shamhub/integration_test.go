@@ -29,6 +29,7 @@ func TestIntegration(t *testing.T) {
 	apiURLFixture, setAPIURL := fixturetest.Stored[string](_fixtures, "apiURL")
 	gitURLFixture, setGitURL := fixturetest.Stored[string](_fixtures, "gitURL")
 	repoURLFixture, setRepoURL := fixturetest.Stored[string](_fixtures, "repoURL")
+	forkURLFixture, setForkURL := fixturetest.Stored[string](_fixtures, "forkURL")
 	tokenFixture, setToken := fixturetest.Stored[string](_fixtures, "token")
 
 	var shamhub *ShamHub // non-nil only in update mode
@@ -94,6 +95,11 @@ func TestIntegration(t *testing.T) {
 			}), "failed to push")
 		}()
 
+		forkURL, err := shamhub.ForkRepository("abhinav", "test-repo", "test-user")
+		require.NoError(t, err)
+		t.Logf("Created fork at %s", forkURL)
+		setForkURL(forkURL)
+
 		// Register users for testing.
 		require.NoError(t, shamhub.RegisterUser("test-user"))
 		require.NoError(t, shamhub.RegisterUser("reviewer1"))
@@ -111,6 +117,7 @@ func TestIntegration(t *testing.T) {
 	apiURL := apiURLFixture.Get(t)
 	gitURL := gitURLFixture.Get(t)
 	repoURL := repoURLFixture.Get(t)
+	forkURL := forkURLFixture.Get(t)
 	token := tokenFixture.Get(t)
 
 	shamForge := &Forge{
@@ -122,8 +129,9 @@ func TestIntegration(t *testing.T) {
 	}
 
 	forgetest.RunIntegration(t, forgetest.IntegrationConfig{
-		RemoteURL: repoURL,
-		Forge:     shamForge,
+		RemoteURL:     repoURL,
+		ForkRemoteURL: forkURL,
+		Forge:         shamForge,
 		OpenRepository: func(t *testing.T, httpClient *http.Client) forge.Repository {
 			repoID, err := shamForge.ParseRemoteURL(repoURL)
 			require.NoError(t, err)
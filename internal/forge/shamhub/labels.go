@@ -0,0 +1,191 @@
+package shamhub
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// Labels are defined per repository.
+// Like GitHub, adding an unknown label to a change defines it,
+// and deleting a label removes it from all changes.
+
+// ListLabels reports the labels defined in a repository, sorted by name.
+func (sh *ShamHub) ListLabels(owner, repo string) ([]string, error) {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	idx := sh.repoIndex(owner, repo)
+	if idx < 0 {
+		return nil, notFoundErrorf("repository %s/%s not found", owner, repo)
+	}
+
+	labels := slices.Clone(sh.repos[idx].Labels)
+	slices.Sort(labels)
+	return labels, nil
+}
+
+// CreateLabel defines a new label in a repository.
+// It's an error if the label already exists.
+func (sh *ShamHub) CreateLabel(owner, repo, label string) error {
+	if label == "" {
+		return errors.New("label name is required")
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	idx := sh.repoIndex(owner, repo)
+	if idx < 0 {
+		return notFoundErrorf("repository %s/%s not found", owner, repo)
+	}
+	if slices.Contains(sh.repos[idx].Labels, label) {
+		return badRequestErrorf("label %q already exists in %s/%s", label, owner, repo)
+	}
+
+	sh.repos[idx].Labels = append(sh.repos[idx].Labels, label)
+	return nil
+}
+
+// DeleteLabel deletes a label from a repository
+// and removes it from all changes in that repository.
+func (sh *ShamHub) DeleteLabel(owner, repo, label string) error {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	idx := sh.repoIndex(owner, repo)
+	if idx < 0 {
+		return notFoundErrorf("repository %s/%s not found", owner, repo)
+	}
+
+	labelIdx := slices.Index(sh.repos[idx].Labels, label)
+	if labelIdx < 0 {
+		return notFoundErrorf("label %q not found in %s/%s", label, owner, repo)
+	}
+	sh.repos[idx].Labels = slices.Delete(sh.repos[idx].Labels, labelIdx, labelIdx+1)
+
+	for i, c := range sh.changes {
+		if c.Base.Owner == owner && c.Base.Repo == repo {
+			sh.changes[i].Labels = slices.DeleteFunc(c.Labels, func(l string) bool {
+				return l == label
+			})
+		}
+	}
+	return nil
+}
+
+// defineLabels defines the given labels in a repository
+// if they don't already exist.
+// The caller must hold sh.mu for writing.
+func (sh *ShamHub) defineLabels(owner, repo string, labels []string) {
+	idx := sh.repoIndex(owner, repo)
+	if idx < 0 {
+		return
+	}
+
+	for _, label := range labels {
+		if !slices.Contains(sh.repos[idx].Labels, label) {
+			sh.repos[idx].Labels = append(sh.repos[idx].Labels, label)
+		}
+	}
+}
+
+// repoIndex returns the index of a repository in sh.repos,
+// or -1 if it doesn't exist.
+// The caller must hold sh.mu.
+func (sh *ShamHub) repoIndex(owner, repo string) int {
+	return slices.IndexFunc(sh.repos, func(r shamRepo) bool {
+		return r.Owner == owner && r.Name == repo
+	})
+}
+
+var (
+	_ = shamhubRESTHandler("GET /{owner}/{repo}/labels", (*ShamHub).handleListLabels)
+	_ = shamhubRESTHandler("POST /{owner}/{repo}/labels", (*ShamHub).handleCreateLabel)
+	_ = shamhubRESTHandler("DELETE /{owner}/{repo}/labels/{name}", (*ShamHub).handleDeleteLabel)
+	_ = shamhubRESTHandler("DELETE /{owner}/{repo}/change/{number}/labels/{name}", (*ShamHub).handleRemoveChangeLabel)
+)
+
+type listLabelsRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+}
+
+type listLabelsResponse struct {
+	Labels []string `json:"labels"`
+}
+
+func (sh *ShamHub) handleListLabels(_ context.Context, req *listLabelsRequest) (*listLabelsResponse, error) {
+	labels, err := sh.ListLabels(req.Owner, req.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return &listLabelsResponse{Labels: labels}, nil
+}
+
+type createLabelRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+
+	Name string `json:"name"`
+}
+
+type createLabelResponse struct{}
+
+func (sh *ShamHub) handleCreateLabel(_ context.Context, req *createLabelRequest) (*createLabelResponse, error) {
+	if req.Name == "" {
+		return nil, badRequestErrorf("name is required")
+	}
+
+	if err := sh.CreateLabel(req.Owner, req.Repo, req.Name); err != nil {
+		return nil, err
+	}
+	return &createLabelResponse{}, nil
+}
+
+type deleteLabelRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+	Name  string `path:"name" json:"-"`
+}
+
+type deleteLabelResponse struct{}
+
+func (sh *ShamHub) handleDeleteLabel(_ context.Context, req *deleteLabelRequest) (*deleteLabelResponse, error) {
+	if err := sh.DeleteLabel(req.Owner, req.Repo, req.Name); err != nil {
+		return nil, err
+	}
+	return &deleteLabelResponse{}, nil
+}
+
+type removeChangeLabelRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+	Name   string `path:"name" json:"-"`
+}
+
+type removeChangeLabelResponse struct{}
+
+func (sh *ShamHub) handleRemoveChangeLabel(_ context.Context, req *removeChangeLabelRequest) (*removeChangeLabelResponse, error) {
+	owner, repo, num := req.Owner, req.Repo, req.Number
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	changeIdx := slices.IndexFunc(sh.changes, func(c shamChange) bool {
+		return c.Base.Owner == owner && c.Base.Repo == repo && c.Number == num
+	})
+	if changeIdx < 0 {
+		return nil, notFoundErrorf("change %s/%s#%d not found", owner, repo, num)
+	}
+
+	labels := sh.changes[changeIdx].Labels
+	labelIdx := slices.Index(labels, req.Name)
+	if labelIdx < 0 {
+		return nil, notFoundErrorf("change %s/%s#%d does not have label %q", owner, repo, num, req.Name)
+	}
+	sh.changes[changeIdx].Labels = slices.Delete(labels, labelIdx, labelIdx+1)
+
+	return &removeChangeLabelResponse{}, nil
+}
@@ -0,0 +1,290 @@
+package shamhub
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// shamQueued is a change waiting in the merge queue of its base repository.
+//
+// Changes in a repository's merge queue are merged in order
+// once their checks pass.
+type shamQueued struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+
+	// Options for merging the change.
+	Squash       bool `json:"squash"`
+	DeleteBranch bool `json:"deleteBranch"`
+}
+
+// EnqueueChangeRequest is a request to add a change to the merge queue
+// of the repository it was proposed against.
+type EnqueueChangeRequest struct {
+	Owner, Repo string
+	Number      int
+
+	// Squash and DeleteBranch are used when the change is merged.
+	// See [MergeChangeRequest] for details.
+	Squash       bool
+	DeleteBranch bool
+}
+
+// EnqueueChange adds an open change to the end of its merge queue.
+// If the change is already queued, its merge options are updated
+// without changing its position.
+func (sh *ShamHub) EnqueueChange(req EnqueueChangeRequest) error {
+	if req.Owner == "" || req.Repo == "" || req.Number == 0 {
+		return errors.New("owner, repo, and number are required")
+	}
+
+	change, ok := sh.findChange(req.Owner, req.Repo, req.Number)
+	if !ok {
+		return notFoundErrorf("change %s/%s#%d not found", req.Owner, req.Repo, req.Number)
+	}
+	if change.State != shamChangeOpen {
+		return badRequestErrorf("change %s/%s#%d is not open", req.Owner, req.Repo, req.Number)
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	entry := shamQueued{
+		Owner:        req.Owner,
+		Repo:         req.Repo,
+		Number:       req.Number,
+		Squash:       req.Squash,
+		DeleteBranch: req.DeleteBranch,
+	}
+	if idx := sh.queueIndex(req.Owner, req.Repo, req.Number); idx >= 0 {
+		sh.queue[idx] = entry
+	} else {
+		sh.queue = append(sh.queue, entry)
+	}
+	return nil
+}
+
+// DequeueChange removes a change from its merge queue.
+func (sh *ShamHub) DequeueChange(owner, repo string, number int) error {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	idx := sh.queueIndex(owner, repo, number)
+	if idx < 0 {
+		return notFoundErrorf("change %s/%s#%d is not queued", owner, repo, number)
+	}
+	sh.queue = slices.Delete(sh.queue, idx, idx+1)
+	return nil
+}
+
+// ListMergeQueue reports the numbers of changes
+// in the merge queue of a repository, in merge order.
+func (sh *ShamHub) ListMergeQueue(owner, repo string) []int {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	var numbers []int
+	for _, q := range sh.queue {
+		if q.Owner == owner && q.Repo == repo {
+			numbers = append(numbers, q.Number)
+		}
+	}
+	return numbers
+}
+
+// queueIndex returns the index of a change in sh.queue,
+// or -1 if it's not queued.
+// The caller must hold sh.mu.
+func (sh *ShamHub) queueIndex(owner, repo string, number int) int {
+	return slices.IndexFunc(sh.queue, func(q shamQueued) bool {
+		return q.Owner == owner && q.Repo == repo && q.Number == number
+	})
+}
+
+// ProcessMergeQueueRequest is a request to advance
+// the merge queue of a repository.
+type ProcessMergeQueueRequest struct {
+	Owner, Repo string
+
+	// Optional fields used for merge commits.
+	// See [MergeChangeRequest] for details.
+	Time                          time.Time
+	CommitterName, CommitterEmail string
+}
+
+// ProcessMergeQueueResult reports the outcome of processing a merge queue.
+type ProcessMergeQueueResult struct {
+	// Merged lists changes that were merged, in order.
+	Merged []int
+
+	// Ejected lists changes that were removed from the queue
+	// without being merged because their checks failed,
+	// they could not be merged cleanly,
+	// or they were closed or merged out of band.
+	Ejected []int
+}
+
+// ProcessMergeQueue merges changes from the front of a repository's
+// merge queue until it's empty or reaches a change with pending checks.
+//
+// Changes whose checks failed, or that cannot be merged,
+// are removed from the queue without being merged.
+func (sh *ShamHub) ProcessMergeQueue(req ProcessMergeQueueRequest) (*ProcessMergeQueueResult, error) {
+	if req.Owner == "" || req.Repo == "" {
+		return nil, errors.New("owner and repo are required")
+	}
+
+	var res ProcessMergeQueueResult
+	for {
+		sh.mu.RLock()
+		idx := slices.IndexFunc(sh.queue, func(q shamQueued) bool {
+			return q.Owner == req.Owner && q.Repo == req.Repo
+		})
+		var next shamQueued
+		if idx >= 0 {
+			next = sh.queue[idx]
+		}
+		sh.mu.RUnlock()
+		if idx < 0 {
+			return &res, nil
+		}
+
+		outcome, err := sh.processQueued(next, req)
+		if err != nil {
+			return &res, err
+		}
+
+		switch outcome {
+		case queueWait:
+			return &res, nil
+		case queueMerged:
+			res.Merged = append(res.Merged, next.Number)
+		case queueEjected:
+			res.Ejected = append(res.Ejected, next.Number)
+		}
+		if err := sh.DequeueChange(next.Owner, next.Repo, next.Number); err != nil {
+			return &res, err
+		}
+	}
+}
+
+// queueOutcome is the result of processing a queued change.
+type queueOutcome int
+
+const (
+	// queueWait indicates that the change must stay in the queue
+	// until its checks finish.
+	queueWait queueOutcome = iota
+
+	// queueMerged indicates that the change was merged.
+	queueMerged
+
+	// queueEjected indicates that the change
+	// must be removed from the queue without merging.
+	queueEjected
+)
+
+// processQueued attempts to merge a queued change.
+func (sh *ShamHub) processQueued(q shamQueued, req ProcessMergeQueueRequest) (queueOutcome, error) {
+	change, ok := sh.findChange(q.Owner, q.Repo, q.Number)
+	if !ok || change.State != shamChangeOpen {
+		return queueEjected, nil
+	}
+
+	checks, err := sh.ListChangeChecks(q.Owner, q.Repo, q.Number)
+	if err != nil {
+		return queueWait, err
+	}
+	switch combinedCheckState(checks) {
+	case CheckPending:
+		return queueWait, nil
+	case CheckFailure:
+		return queueEjected, nil
+	}
+
+	if err := sh.MergeChange(MergeChangeRequest{
+		Owner:          q.Owner,
+		Repo:           q.Repo,
+		Number:         q.Number,
+		Squash:         q.Squash,
+		DeleteBranch:   q.DeleteBranch,
+		Time:           req.Time,
+		CommitterName:  req.CommitterName,
+		CommitterEmail: req.CommitterEmail,
+	}); err != nil {
+		sh.log.Warn("Could not merge queued change",
+			"change", q.Number, "error", err)
+		return queueEjected, nil
+	}
+
+	return queueMerged, nil
+}
+
+var _ = shamhubRESTHandler("GET /{owner}/{repo}/queue", (*ShamHub).handleListMergeQueue)
+
+type listMergeQueueRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+}
+
+type listMergeQueueResponse struct {
+	// Numbers of queued changes, in merge order.
+	Changes []int `json:"changes"`
+}
+
+func (sh *ShamHub) handleListMergeQueue(_ context.Context, req *listMergeQueueRequest) (*listMergeQueueResponse, error) {
+	return &listMergeQueueResponse{
+		Changes: sh.ListMergeQueue(req.Owner, req.Repo),
+	}, nil
+}
+
+var _ = shamhubRESTHandler("POST /{owner}/{repo}/queue", (*ShamHub).handleEnqueueChange)
+
+type enqueueChangeRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+
+	Number       int  `json:"number"`
+	Squash       bool `json:"squash,omitempty"`
+	DeleteBranch bool `json:"deleteBranch,omitempty"`
+}
+
+type enqueueChangeResponse struct{}
+
+func (sh *ShamHub) handleEnqueueChange(_ context.Context, req *enqueueChangeRequest) (*enqueueChangeResponse, error) {
+	if req.Number == 0 {
+		return nil, badRequestErrorf("number is required")
+	}
+
+	if err := sh.EnqueueChange(EnqueueChangeRequest{
+		Owner:        req.Owner,
+		Repo:         req.Repo,
+		Number:       req.Number,
+		Squash:       req.Squash,
+		DeleteBranch: req.DeleteBranch,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &enqueueChangeResponse{}, nil
+}
+
+var _ = shamhubRESTHandler("DELETE /{owner}/{repo}/queue/{number}", (*ShamHub).handleDequeueChange)
+
+type dequeueChangeRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+}
+
+type dequeueChangeResponse struct{}
+
+func (sh *ShamHub) handleDequeueChange(_ context.Context, req *dequeueChangeRequest) (*dequeueChangeResponse, error) {
+	if err := sh.DequeueChange(req.Owner, req.Repo, req.Number); err != nil {
+		return nil, err
+	}
+	return &dequeueChangeResponse{}, nil
+}
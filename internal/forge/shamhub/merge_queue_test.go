@@ -0,0 +1,93 @@
+package shamhub
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueue(t *testing.T) {
+	sh, token, newChange := setUpAPITest(t)
+
+	feat1 := newChange("feat1")
+	feat2 := newChange("feat2")
+	feat3 := newChange("feat3")
+
+	t.Run("Checks", func(t *testing.T) {
+		var empty listChecksResponse
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/change/1/checks", nil, &empty)
+		assert.Equal(t, CheckSuccess, empty.State, "no checks is success")
+		assert.Empty(t, empty.Checks)
+
+		apiRequest(t, sh, token, http.MethodPost, "/alice/example/change/1/checks",
+			setCheckRequest{Name: "test", State: CheckPending}, nil)
+		apiRequest(t, sh, token, http.MethodPost, "/alice/example/change/1/checks",
+			setCheckRequest{Name: "lint", State: CheckSuccess}, nil)
+
+		var got listChecksResponse
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/change/1/checks", nil, &got)
+		assert.Equal(t, listChecksResponse{
+			State: CheckPending,
+			Checks: []*ChangeCheck{
+				{Name: "lint", State: CheckSuccess},
+				{Name: "test", State: CheckPending},
+			},
+		}, got)
+	})
+
+	t.Run("InvalidCheckState", func(t *testing.T) {
+		status := apiRequestStatus(t, sh, token, http.MethodPost, "/alice/example/change/1/checks",
+			setCheckRequest{Name: "test", State: "bogus"}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		for _, n := range []int{feat1, feat2, feat3} {
+			apiRequest(t, sh, token, http.MethodPost, "/alice/example/queue",
+				enqueueChangeRequest{Number: n, Squash: true}, nil)
+		}
+
+		var queue listMergeQueueResponse
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/queue", nil, &queue)
+		assert.Equal(t, []int{feat1, feat2, feat3}, queue.Changes)
+
+		// feat1 is waiting on its "test" check.
+		res, err := sh.ProcessMergeQueue(ProcessMergeQueueRequest{Owner: "alice", Repo: "example"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Merged)
+		assert.Empty(t, res.Ejected)
+
+		// feat1 passes, feat2 fails, feat3 has no checks.
+		require.NoError(t, sh.SetCheck(SetCheckRequest{
+			Owner: "alice", Repo: "example", Number: feat1,
+			Name: "test", State: CheckSuccess,
+		}))
+		require.NoError(t, sh.SetCheck(SetCheckRequest{
+			Owner: "alice", Repo: "example", Number: feat2,
+			Name: "test", State: CheckFailure,
+		}))
+
+		res, err = sh.ProcessMergeQueue(ProcessMergeQueueRequest{Owner: "alice", Repo: "example"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{feat1, feat3}, res.Merged)
+		assert.Equal(t, []int{feat2}, res.Ejected)
+		assert.Empty(t, sh.ListMergeQueue("alice", "example"))
+
+		changes, err := sh.ListChanges()
+		require.NoError(t, err)
+		merged := make(map[int]bool)
+		for _, c := range changes {
+			merged[c.Number] = c.Merged
+		}
+		assert.Equal(t, map[int]bool{feat1: true, feat2: false, feat3: true}, merged)
+	})
+
+	t.Run("Dequeue", func(t *testing.T) {
+		apiRequest(t, sh, token, http.MethodPost, "/alice/example/queue",
+			enqueueChangeRequest{Number: feat2}, nil)
+		apiRequest(t, sh, token, http.MethodDelete, "/alice/example/queue/2", nil, nil)
+		assert.Empty(t, sh.ListMergeQueue("alice", "example"))
+	})
+}
@@ -0,0 +1,106 @@
+package shamhub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// _stateFileName is the name of the file inside [Config.Dir]
+// that holds the state of the forge.
+const _stateFileName = "shamhub.json"
+
+// shamState is the state of a ShamHub server
+// that is persisted to disk when [Config.Dir] is set.
+//
+// Git repositories are not part of this state;
+// they're stored alongside it in the same directory.
+type shamState struct {
+	Changes       []shamChange       `json:"changes,omitempty"`
+	Users         []shamUser         `json:"users,omitempty"`
+	Comments      []shamComment      `json:"comments,omitempty"`
+	ReviewThreads []shamReviewThread `json:"reviewThreads,omitempty"`
+	Repos         []shamRepo         `json:"repos,omitempty"`
+	Checks        []shamCheck        `json:"checks,omitempty"`
+	Queue         []shamQueued       `json:"queue,omitempty"`
+
+	// Tokens are persisted so that clients
+	// stay logged in across restarts.
+	Tokens map[string]string `json:"tokens,omitempty"`
+}
+
+// loadState loads the persisted state of the forge, if any.
+// It's a no-op if the state file does not exist yet.
+func (sh *ShamHub) loadState() error {
+	bs, err := os.ReadFile(sh.stateFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var state shamState
+	if err := json.Unmarshal(bs, &state); err != nil {
+		return fmt.Errorf("decode %v: %w", sh.stateFile, err)
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	sh.changes = state.Changes
+	sh.users = state.Users
+	sh.comments = state.Comments
+	sh.reviewThreads = state.ReviewThreads
+	sh.repos = state.Repos
+	sh.checks = state.Checks
+	sh.queue = state.Queue
+	if state.Tokens != nil {
+		sh.tokens = state.Tokens
+	}
+	return nil
+}
+
+// saveState writes the state of the forge to disk.
+// It's a no-op if the forge is not persistent.
+func (sh *ShamHub) saveState() error {
+	if sh.stateFile == "" {
+		return nil
+	}
+
+	sh.mu.RLock()
+	bs, err := json.MarshalIndent(shamState{
+		Changes:       sh.changes,
+		Users:         sh.users,
+		Comments:      sh.comments,
+		ReviewThreads: sh.reviewThreads,
+		Repos:         sh.repos,
+		Checks:        sh.checks,
+		Queue:         sh.queue,
+		Tokens:        sh.tokens,
+	}, "", "  ")
+	sh.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+
+	// Write to a temporary file and rename it into place
+	// so that a crash never leaves a partially written file.
+	tmp, err := os.CreateTemp(filepath.Dir(sh.stateFile), _stateFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(bs); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), sh.stateFile)
+}
@@ -0,0 +1,71 @@
+package shamhub
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestPersistentState(t *testing.T) {
+	dir := t.TempDir()
+
+	sh, err := New(Config{Dir: dir, Log: silogtest.New(t)})
+	require.NoError(t, err)
+
+	require.NoError(t, sh.RegisterUser("alice"))
+	_, err = sh.NewRepository("alice", "example")
+	require.NoError(t, err)
+	token := loginAndGetToken(t, sh, "alice")
+
+	apiRequest(t, sh, token, http.MethodPost, "/alice/example/labels",
+		createLabelRequest{Name: "bug"}, nil)
+	assert.FileExists(t, filepath.Join(dir, _stateFileName),
+		"state must be saved after API requests")
+
+	require.NoError(t, sh.Close())
+	assert.DirExists(t, filepath.Join(dir, "git", "alice", "example.git"),
+		"repositories must be kept")
+
+	sh, err = New(Config{Dir: dir, Log: silogtest.New(t)})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, sh.Close()) }()
+
+	assert.True(t, sh.HasUser("alice"))
+	assert.False(t, sh.HasUser("bob"))
+	assert.True(t, sh.HasRepository("alice", "example"))
+	assert.False(t, sh.HasRepository("alice", "other"))
+
+	// Tokens issued before the restart are still valid.
+	var labels listLabelsResponse
+	apiRequest(t, sh, token, http.MethodGet, "/alice/example/labels", nil, &labels)
+	assert.Equal(t, []string{"bug"}, labels.Labels)
+}
+
+func TestHandleMergeChange(t *testing.T) {
+	sh, token, newChange := setUpAPITest(t)
+	feat := newChange("feat")
+
+	t.Run("NotFound", func(t *testing.T) {
+		status := apiRequestStatus(t, sh, token, http.MethodPost, "/alice/example/change/42/merge",
+			mergeChangeRequest{}, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	apiRequest(t, sh, token, http.MethodPost, "/alice/example/change/1/merge",
+		mergeChangeRequest{Squash: true}, nil)
+
+	var change Change
+	apiRequest(t, sh, token, http.MethodGet, "/alice/example/change/1", nil, &change)
+	assert.Equal(t, feat, change.Number)
+	assert.True(t, change.Merged)
+
+	t.Run("AlreadyMerged", func(t *testing.T) {
+		status := apiRequestStatus(t, sh, token, http.MethodPost, "/alice/example/change/1/merge",
+			mergeChangeRequest{}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"slices"
+	"strings"
 
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/silog"
@@ -14,11 +15,14 @@ import (
 
 // shamRepo is the internal representation of a repository.
 type shamRepo struct {
-	Owner string
-	Name  string
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
 
 	// If this is a fork, ForkOf points to the parent repository.
-	ForkOf *repoID
+	ForkOf *repoID `json:"forkOf"`
+
+	// Labels defined in the repository.
+	Labels []string `json:"labels"`
 }
 
 // Repository represents a repository on ShamHub.
@@ -37,6 +41,19 @@ func (sh *ShamHub) NewRepository(owner, repo string) (string, error) {
 	return sh.newRepository(owner, repo, nil /* forkOf */)
 }
 
+// HasRepository reports whether a repository with the given owner
+// and repo name exists.
+func (sh *ShamHub) HasRepository(owner, repo string) bool {
+	repo = strings.TrimSuffix(repo, ".git")
+
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	return slices.ContainsFunc(sh.repos, func(r shamRepo) bool {
+		return r.Owner == owner && r.Name == repo
+	})
+}
+
 // ForkRepository forks an existing repository
 // under a different owner.
 func (sh *ShamHub) ForkRepository(owner, repo, forkOwner string) (string, error) {
@@ -129,3 +146,8 @@ type forgeRepository struct {
 var _ forge.Repository = (*forgeRepository)(nil)
 
 func (r *forgeRepository) Forge() forge.Forge { return r.forge }
+
+// Capabilities reports the features supported by ShamHub.
+func (r *forgeRepository) Capabilities() forge.Capabilities {
+	return forge.AllCapabilities
+}
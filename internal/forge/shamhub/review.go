@@ -0,0 +1,382 @@
+package shamhub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/xec"
+)
+
+// shamReviewThread is a thread of review comments
+// anchored to a line of a file in a change.
+type shamReviewThread struct {
+	ID     int    `json:"id"`
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Change int    `json:"change"`
+
+	// Path is the path to the file relative to the repository root,
+	// and Line is the 1-indexed line in the file at the head of the change.
+	Path string `json:"path"`
+	Line int    `json:"line"`
+
+	Resolved bool `json:"resolved"`
+
+	// Anchor is the contents of the line when the thread was started.
+	// The thread is outdated if the line no longer matches.
+	Anchor string `json:"anchor"`
+
+	// Comments in the order they were made.
+	// The first comment started the thread.
+	Comments []string `json:"comments"`
+}
+
+// ReviewThread is a thread of review comments on a change.
+type ReviewThread struct {
+	// ID uniquely identifies the thread on the ShamHub server.
+	ID int `json:"id" yaml:"id"`
+
+	// Change is the number of the change the thread is on.
+	Change int `json:"change" yaml:"change"`
+
+	// Path and Line identify the line of the file
+	// the thread is anchored to.
+	Path string `json:"path" yaml:"path"`
+	Line int    `json:"line" yaml:"line"`
+
+	// Resolved indicates that the thread was marked resolved.
+	Resolved bool `json:"resolved" yaml:"resolved"`
+
+	// Outdated indicates that the line the thread is anchored to
+	// has changed since the thread was started.
+	Outdated bool `json:"outdated,omitempty" yaml:"outdated,omitempty"`
+
+	// Comments in the thread, oldest first.
+	Comments []string `json:"comments" yaml:"comments"`
+}
+
+func (t *shamReviewThread) toReviewThread() *ReviewThread {
+	return &ReviewThread{
+		ID:       t.ID,
+		Change:   t.Change,
+		Path:     t.Path,
+		Line:     t.Line,
+		Resolved: t.Resolved,
+		Comments: slices.Clone(t.Comments),
+	}
+}
+
+// AddReviewThreadRequest is a request to start a review thread
+// on a line of a file in a change.
+type AddReviewThreadRequest struct {
+	Owner, Repo string
+	Number      int
+
+	// Path and Line identify the line to comment on.
+	// The file must exist at the head of the change,
+	// and Line must be within it.
+	Path string
+	Line int
+
+	// Body is the first comment in the thread.
+	Body string
+}
+
+// AddReviewThread starts a new review thread on a change,
+// returning the ID of the thread.
+func (sh *ShamHub) AddReviewThread(req AddReviewThreadRequest) (int, error) {
+	if req.Owner == "" || req.Repo == "" || req.Number == 0 || req.Path == "" {
+		return 0, errors.New("owner, repo, number, and path are required")
+	}
+
+	change, ok := sh.findChange(req.Owner, req.Repo, req.Number)
+	if !ok {
+		return 0, notFoundErrorf("change %s/%s#%d not found", req.Owner, req.Repo, req.Number)
+	}
+
+	// Verify that the anchor exists at the head of the change.
+	lines, err := sh.changeFileLines(change, req.Path)
+	if err != nil {
+		return 0, badRequestErrorf("file %q not found in change #%d", req.Path, req.Number)
+	}
+	if req.Line < 1 || req.Line > len(lines) {
+		return 0, badRequestErrorf("line %d is outside %q (%d lines)", req.Line, req.Path, len(lines))
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	thread := shamReviewThread{
+		ID:       len(sh.reviewThreads) + 1,
+		Owner:    req.Owner,
+		Repo:     req.Repo,
+		Change:   req.Number,
+		Path:     req.Path,
+		Line:     req.Line,
+		Anchor:   lines[req.Line-1],
+		Comments: []string{req.Body},
+	}
+	sh.reviewThreads = append(sh.reviewThreads, thread)
+	return thread.ID, nil
+}
+
+// changeFileLines returns the lines of a file at the head of a change.
+func (sh *ShamHub) changeFileLines(change shamChange, path string) ([]string, error) {
+	contents, err := xec.Command(context.Background(), sh.log, sh.gitExe,
+		"show", change.Head.Name+":"+path).
+		WithDir(sh.repoDir(change.Head.Owner, change.Head.Repo)).
+		Output()
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n"), nil
+}
+
+// ReplyToReviewThread adds a comment to an existing review thread.
+func (sh *ShamHub) ReplyToReviewThread(owner, repo string, id int, body string) error {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	idx := sh.reviewThreadIndex(owner, repo, id)
+	if idx < 0 {
+		return notFoundErrorf("review thread %d not found in %s/%s", id, owner, repo)
+	}
+
+	sh.reviewThreads[idx].Comments = append(sh.reviewThreads[idx].Comments, body)
+	return nil
+}
+
+// ResolveReviewThread marks a review thread as resolved or unresolved.
+func (sh *ShamHub) ResolveReviewThread(owner, repo string, id int, resolved bool) error {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	idx := sh.reviewThreadIndex(owner, repo, id)
+	if idx < 0 {
+		return notFoundErrorf("review thread %d not found in %s/%s", id, owner, repo)
+	}
+
+	sh.reviewThreads[idx].Resolved = resolved
+	return nil
+}
+
+// ListReviewThreads reports the review threads on a change
+// in the order they were started.
+func (sh *ShamHub) ListReviewThreads(owner, repo string, number int) ([]*ReviewThread, error) {
+	change, ok := sh.findChange(owner, repo, number)
+	if !ok {
+		return nil, notFoundErrorf("change %s/%s#%d not found", owner, repo, number)
+	}
+
+	sh.mu.RLock()
+	var (
+		threads []*ReviewThread
+		anchors []string
+	)
+	for _, t := range sh.reviewThreads {
+		if t.Owner == owner && t.Repo == repo && t.Change == number {
+			threads = append(threads, t.toReviewThread())
+			anchors = append(anchors, t.Anchor)
+		}
+	}
+	sh.mu.RUnlock()
+
+	// A thread is outdated if the line it's on has changed,
+	// or the file no longer has that line.
+	fileLines := make(map[string][]string)
+	for i, t := range threads {
+		lines, ok := fileLines[t.Path]
+		if !ok {
+			lines, _ = sh.changeFileLines(change, t.Path)
+			fileLines[t.Path] = lines
+		}
+		t.Outdated = t.Line > len(lines) || lines[t.Line-1] != anchors[i]
+	}
+	return threads, nil
+}
+
+// reviewThreadIndex returns the index of a thread in sh.reviewThreads,
+// or -1 if it doesn't exist.
+// The caller must hold sh.mu.
+func (sh *ShamHub) reviewThreadIndex(owner, repo string, id int) int {
+	return slices.IndexFunc(sh.reviewThreads, func(t shamReviewThread) bool {
+		return t.Owner == owner && t.Repo == repo && t.ID == id
+	})
+}
+
+var (
+	_ = shamhubRESTHandler("GET /{owner}/{repo}/change/{number}/threads", (*ShamHub).handleListReviewThreads)
+	_ = shamhubRESTHandler("POST /{owner}/{repo}/change/{number}/threads", (*ShamHub).handleAddReviewThread)
+	_ = shamhubRESTHandler("POST /{owner}/{repo}/threads/{id}/replies", (*ShamHub).handleReplyToReviewThread)
+	_ = shamhubRESTHandler("PATCH /{owner}/{repo}/threads/{id}", (*ShamHub).handleResolveReviewThread)
+)
+
+type listReviewThreadsRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+
+	// State optionally filters threads:
+	// "resolved" or "unresolved".
+	State string `query:"state" json:"-"`
+}
+
+type listReviewThreadsResponse struct {
+	Threads []*ReviewThread `json:"threads"`
+}
+
+func (sh *ShamHub) handleListReviewThreads(_ context.Context, req *listReviewThreadsRequest) (*listReviewThreadsResponse, error) {
+	var want func(*ReviewThread) bool
+	switch req.State {
+	case "":
+		want = func(*ReviewThread) bool { return true }
+	case "resolved":
+		want = func(t *ReviewThread) bool { return t.Resolved }
+	case "unresolved":
+		want = func(t *ReviewThread) bool { return !t.Resolved }
+	default:
+		return nil, badRequestErrorf("invalid state: %q", req.State)
+	}
+
+	threads, err := sh.ListReviewThreads(req.Owner, req.Repo, req.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	threads = slices.DeleteFunc(threads, func(t *ReviewThread) bool {
+		return !want(t)
+	})
+	return &listReviewThreadsResponse{Threads: threads}, nil
+}
+
+type addReviewThreadRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Body string `json:"body"`
+}
+
+type addReviewThreadResponse struct {
+	ID int `json:"id"`
+}
+
+func (sh *ShamHub) handleAddReviewThread(_ context.Context, req *addReviewThreadRequest) (*addReviewThreadResponse, error) {
+	if req.Path == "" {
+		return nil, badRequestErrorf("path is required")
+	}
+
+	id, err := sh.AddReviewThread(AddReviewThreadRequest{
+		Owner:  req.Owner,
+		Repo:   req.Repo,
+		Number: req.Number,
+		Path:   req.Path,
+		Line:   req.Line,
+		Body:   req.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &addReviewThreadResponse{ID: id}, nil
+}
+
+type replyToReviewThreadRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+	ID    int    `path:"id" json:"-"`
+
+	Body string `json:"body"`
+}
+
+type replyToReviewThreadResponse struct{}
+
+func (sh *ShamHub) handleReplyToReviewThread(_ context.Context, req *replyToReviewThreadRequest) (*replyToReviewThreadResponse, error) {
+	if err := sh.ReplyToReviewThread(req.Owner, req.Repo, req.ID, req.Body); err != nil {
+		return nil, err
+	}
+	return &replyToReviewThreadResponse{}, nil
+}
+
+type resolveReviewThreadRequest struct {
+	Owner string `path:"owner" json:"-"`
+	Repo  string `path:"repo" json:"-"`
+	ID    int    `path:"id" json:"-"`
+
+	Resolved bool `json:"resolved"`
+}
+
+type resolveReviewThreadResponse struct{}
+
+func (sh *ShamHub) handleResolveReviewThread(_ context.Context, req *resolveReviewThreadRequest) (*resolveReviewThreadResponse, error) {
+	if err := sh.ResolveReviewThread(req.Owner, req.Repo, req.ID, req.Resolved); err != nil {
+		return nil, err
+	}
+	return &resolveReviewThreadResponse{}, nil
+}
+
+// ReviewThreadID uniquely identifies a review thread in a ShamHub repository.
+type ReviewThreadID int
+
+var _ forge.ChangeThreadID = ReviewThreadID(0)
+
+func (id ReviewThreadID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+var _ forge.WithReviewThreads = (*forgeRepository)(nil)
+
+func (r *forgeRepository) ListChangeThreads(
+	ctx context.Context,
+	id forge.ChangeID,
+) iter.Seq2[*forge.ChangeThread, error] {
+	return func(yield func(*forge.ChangeThread, error) bool) {
+		u := r.apiURL.JoinPath(r.owner, r.repo, "change", strconv.Itoa(int(id.(ChangeID))), "threads")
+
+		var res listReviewThreadsResponse
+		if err := r.client.Get(ctx, u.String(), &res); err != nil {
+			yield(nil, fmt.Errorf("list threads: %w", err))
+			return
+		}
+
+		for _, t := range res.Threads {
+			var body string
+			if len(t.Comments) > 0 {
+				body = t.Comments[0]
+			}
+
+			thread := &forge.ChangeThread{
+				ID:       ReviewThreadID(t.ID),
+				Path:     t.Path,
+				Line:     t.Line,
+				Body:     body,
+				Resolved: t.Resolved,
+				Outdated: t.Outdated,
+			}
+			if !yield(thread, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *forgeRepository) ResolveThread(ctx context.Context, id forge.ChangeThreadID, resolved bool) error {
+	tid := int(id.(ReviewThreadID))
+	u := r.apiURL.JoinPath(r.owner, r.repo, "threads", strconv.Itoa(tid))
+	req := resolveReviewThreadRequest{Resolved: resolved}
+	var res resolveReviewThreadResponse
+	if err := r.client.Patch(ctx, u.String(), req, &res); err != nil {
+		return fmt.Errorf("resolve thread: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package shamhub
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewThreads(t *testing.T) {
+	sh, token, newChange := setUpAPITest(t)
+	feat1 := newChange("feat1")
+
+	var added addReviewThreadResponse
+	apiRequest(t, sh, token, http.MethodPost, "/alice/example/change/1/threads",
+		addReviewThreadRequest{Path: "feat1.txt", Line: 1, Body: "typo"}, &added)
+	apiRequest(t, sh, token, http.MethodPost, "/alice/example/change/1/threads",
+		addReviewThreadRequest{Path: "feat1.txt", Line: 1, Body: "nit"}, nil)
+	apiRequest(t, sh, token, http.MethodPost, "/alice/example/threads/1/replies",
+		replyToReviewThreadRequest{Body: "fixed"}, nil)
+	apiRequest(t, sh, token, http.MethodPatch, "/alice/example/threads/1",
+		resolveReviewThreadRequest{Resolved: true}, nil)
+
+	t.Run("List", func(t *testing.T) {
+		var got listReviewThreadsResponse
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/change/1/threads", nil, &got)
+		assert.Equal(t, []*ReviewThread{
+			{
+				ID:       added.ID,
+				Change:   feat1,
+				Path:     "feat1.txt",
+				Line:     1,
+				Resolved: true,
+				Comments: []string{"typo", "fixed"},
+			},
+			{
+				ID:       2,
+				Change:   feat1,
+				Path:     "feat1.txt",
+				Line:     1,
+				Comments: []string{"nit"},
+			},
+		}, got.Threads)
+	})
+
+	t.Run("ListUnresolved", func(t *testing.T) {
+		var got listReviewThreadsResponse
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/change/1/threads?state=unresolved", nil, &got)
+		require.Len(t, got.Threads, 1)
+		assert.Equal(t, 2, got.Threads[0].ID)
+	})
+
+	t.Run("InvalidAnchor", func(t *testing.T) {
+		tests := []struct {
+			name string
+			req  addReviewThreadRequest
+		}{
+			{"MissingFile", addReviewThreadRequest{Path: "nope.txt", Line: 1}},
+			{"LineTooLarge", addReviewThreadRequest{Path: "feat1.txt", Line: 2}},
+			{"LineZero", addReviewThreadRequest{Path: "feat1.txt", Line: 0}},
+			{"NoPath", addReviewThreadRequest{Line: 1}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				status := apiRequestStatus(t, sh, token, http.MethodPost,
+					"/alice/example/change/1/threads", tt.req, nil)
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+		}
+	})
+
+	t.Run("UnknownThread", func(t *testing.T) {
+		status := apiRequestStatus(t, sh, token, http.MethodPatch, "/alice/example/threads/42",
+			resolveReviewThreadRequest{Resolved: true}, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestLabels(t *testing.T) {
+	sh, token, newChange := setUpAPITest(t)
+	feat1 := newChange("feat1")
+	feat2 := newChange("feat2")
+
+	listLabels := func(t *testing.T) []string {
+		var res listLabelsResponse
+		apiRequest(t, sh, token, http.MethodGet, "/alice/example/labels", nil, &res)
+		return res.Labels
+	}
+	changeLabels := func(t *testing.T, number int) []string {
+		changes, err := sh.ListChanges()
+		require.NoError(t, err)
+		for _, c := range changes {
+			if c.Number == number {
+				return c.Labels
+			}
+		}
+		t.Fatalf("change %d not found", number)
+		return nil
+	}
+
+	apiRequest(t, sh, token, http.MethodPost, "/alice/example/labels",
+		createLabelRequest{Name: "bug"}, nil)
+	assert.Equal(t, http.StatusBadRequest, apiRequestStatus(t, sh, token, http.MethodPost,
+		"/alice/example/labels", createLabelRequest{Name: "bug"}, nil),
+		"duplicate label")
+
+	// Labels added to changes are defined implicitly.
+	apiRequest(t, sh, token, http.MethodPatch, "/alice/example/change/1",
+		editChangeRequest{Labels: []string{"feature", "bug"}}, nil)
+	apiRequest(t, sh, token, http.MethodPatch, "/alice/example/change/2",
+		editChangeRequest{Labels: []string{"feature", "wip"}}, nil)
+	assert.Equal(t, []string{"bug", "feature", "wip"}, listLabels(t))
+
+	t.Run("RemoveFromChange", func(t *testing.T) {
+		apiRequest(t, sh, token, http.MethodDelete, "/alice/example/change/2/labels/wip", nil, nil)
+		assert.Equal(t, []string{"feature"}, changeLabels(t, feat2))
+		assert.Equal(t, http.StatusNotFound, apiRequestStatus(t, sh, token, http.MethodDelete,
+			"/alice/example/change/2/labels/wip", nil, nil))
+
+		apiRequest(t, sh, token, http.MethodPatch, "/alice/example/change/1",
+			editChangeRequest{RemoveLabels: []string{"bug", "unknown"}}, nil)
+		assert.Equal(t, []string{"feature"}, changeLabels(t, feat1))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		apiRequest(t, sh, token, http.MethodDelete, "/alice/example/labels/feature", nil, nil)
+		assert.Equal(t, []string{"bug", "wip"}, listLabels(t))
+		assert.Empty(t, changeLabels(t, feat1))
+		assert.Empty(t, changeLabels(t, feat2))
+	})
+}
+
+func TestDraftTransitions(t *testing.T) {
+	sh, token, newChange := setUpAPITest(t)
+	feat1 := newChange("feat1")
+
+	isDraft := func(t *testing.T) bool {
+		changes, err := sh.ListChanges()
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		return changes[0].Draft
+	}
+
+	require.NoError(t, sh.SetChangeDraft("alice", "example", feat1, true))
+	assert.True(t, isDraft(t))
+
+	draft := false
+	apiRequest(t, sh, token, http.MethodPatch, "/alice/example/change/1",
+		editChangeRequest{Draft: &draft}, nil)
+	assert.False(t, isDraft(t))
+
+	// Closed changes can't change draft state.
+	require.NoError(t, sh.RejectChange(RejectChangeRequest{
+		Owner: "alice", Repo: "example", Number: feat1,
+	}))
+	draft = true
+	assert.Equal(t, http.StatusBadRequest, apiRequestStatus(t, sh, token, http.MethodPatch,
+		"/alice/example/change/1", editChangeRequest{Draft: &draft}, nil))
+	assert.False(t, isDraft(t))
+}
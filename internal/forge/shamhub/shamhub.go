@@ -2,16 +2,22 @@
 //
 // It stores Git repositories in a temporary directory,
 // and provides a REST-like API for interacting with them.
+// Alternatively, it may store repositories and its state
+// in a persistent directory to survive restarts.
 package shamhub
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
 	"net/http/cgi"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/xec"
@@ -28,19 +34,32 @@ import (
 type ShamHub struct {
 	log *silog.Logger
 
-	gitRoot string // destination for Git repos
-	gitExe  string // path to git binary
+	gitRoot   string // destination for Git repos
+	gitExe    string // path to git binary
+	stateFile string // path to persisted state, if any
 
 	apiServer *httptest.Server // API server
 	gitServer *httptest.Server // Git HTTP remote
 
-	mu       sync.RWMutex
-	changes  []shamChange  // all changes
-	users    []shamUser    // all users
-	comments []shamComment // all comments
-	repos    []shamRepo    // all repositories
+	mu            sync.RWMutex
+	changes       []shamChange       // all changes
+	users         []shamUser         // all users
+	comments      []shamComment      // all comments
+	reviewThreads []shamReviewThread // all review threads
+	repos         []shamRepo         // all repositories
+	checks        []shamCheck        // all check runs
+	queue         []shamQueued       // changes in merge queues, in order
 
 	tokens map[string]string // token -> username
+
+	// faultMu guards fault injection state.
+	// It's separate from mu so that delays and faults
+	// don't block access to the rest of the server.
+	faultMu    sync.Mutex
+	faults     []*Fault      // pending faults, in order
+	latency    time.Duration // minimum delay for each response
+	jitter     time.Duration // maximum random delay on top of latency
+	jitterRand *rand.Rand    // source of jitter
 }
 
 // Config configures a ShamHub server.
@@ -49,6 +68,20 @@ type Config struct {
 	// If not set, we'll look for it in the PATH.
 	Git string
 
+	// Dir is a directory in which ShamHub persists
+	// its Git repositories and the state of the forge.
+	// State found in this directory is loaded on startup,
+	// and the directory is left in place when the server is closed.
+	//
+	// If not set, a temporary directory is used
+	// and deleted when the server is closed.
+	Dir string
+
+	// APIAddr and GitAddr are the addresses (host:port)
+	// on which the API and Git servers listen.
+	// If not set, random ports on the loopback interface are used.
+	APIAddr, GitAddr string
+
 	Log *silog.Logger
 }
 
@@ -68,19 +101,43 @@ func New(cfg Config) (*ShamHub, error) {
 		cfg.Git = gitExe
 	}
 
-	gitRoot, err := os.MkdirTemp("", "shamhub-git")
-	if err != nil {
-		return nil, err
+	sh := ShamHub{
+		log:    cfg.Log.With("module", "shamhub"),
+		gitExe: cfg.Git,
+		tokens: make(map[string]string),
 	}
 
-	sh := ShamHub{
-		log:     cfg.Log.With("module", "shamhub"),
-		gitRoot: gitRoot,
-		gitExe:  cfg.Git,
-		tokens:  make(map[string]string),
+	if cfg.Dir != "" {
+		// git http-backend runs in a different working directory.
+		dir, err := filepath.Abs(cfg.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve directory: %w", err)
+		}
+
+		sh.gitRoot = filepath.Join(dir, "git")
+		if err := os.MkdirAll(sh.gitRoot, 0o755); err != nil {
+			return nil, fmt.Errorf("create git root: %w", err)
+		}
+
+		sh.stateFile = filepath.Join(dir, _stateFileName)
+		if err := sh.loadState(); err != nil {
+			return nil, fmt.Errorf("load state: %w", err)
+		}
+	} else {
+		gitRoot, err := os.MkdirTemp("", "shamhub-git")
+		if err != nil {
+			return nil, err
+		}
+		sh.gitRoot = gitRoot
+	}
+
+	apiServer, err := newServer(cfg.APIAddr, sh.apiHandler())
+	if err != nil {
+		return nil, fmt.Errorf("start API server: %w", err)
 	}
-	sh.apiServer = httptest.NewServer(sh.apiHandler())
-	sh.gitServer = httptest.NewServer(&cgi.Handler{
+	sh.apiServer = apiServer
+
+	gitServer, err := newServer(cfg.GitAddr, &cgi.Handler{
 		// git-http-backend is a CGI script
 		// that can be used to serve Git repositories over HTTP.
 		Path: cfg.Git,
@@ -90,6 +147,11 @@ func New(cfg Config) (*ShamHub, error) {
 			"GIT_PROJECT_ROOT=" + sh.gitRoot,
 		},
 	})
+	if err != nil {
+		apiServer.Close()
+		return nil, fmt.Errorf("start Git server: %w", err)
+	}
+	sh.gitServer = gitServer
 
 	return &sh, nil
 }
@@ -99,6 +161,13 @@ func (sh *ShamHub) Close() error {
 	sh.apiServer.Close()
 	sh.gitServer.Close()
 
+	if sh.stateFile != "" {
+		if err := sh.saveState(); err != nil {
+			return fmt.Errorf("save state: %w", err)
+		}
+		return nil
+	}
+
 	if err := os.RemoveAll(sh.gitRoot); err != nil {
 		return fmt.Errorf("remove git root: %w", err)
 	}
@@ -106,6 +175,25 @@ func (sh *ShamHub) Close() error {
 	return nil
 }
 
+// newServer starts an HTTP server for the given handler
+// listening on addr, or on a random loopback port if addr is empty.
+func newServer(addr string, handler http.Handler) (*httptest.Server, error) {
+	if addr == "" {
+		return httptest.NewServer(handler), nil
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := httptest.NewUnstartedServer(handler)
+	_ = srv.Listener.Close()
+	srv.Listener = lis
+	srv.Start()
+	return srv, nil
+}
+
 // GitRoot returns the path to the root directory of the Git repositories.
 func (sh *ShamHub) GitRoot() string {
 	return sh.gitRoot
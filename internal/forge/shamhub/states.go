@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/xec"
 )
 
@@ -20,6 +21,13 @@ type statesRequest struct {
 
 type statesResponse struct {
 	States []string `json:"states"`
+
+	// Bases holds the base branch of each change.
+	Bases []string `json:"bases"`
+
+	// MergeCommits holds the merge commit for each merged change,
+	// and is empty for other changes.
+	MergeCommits []string `json:"mergeCommits"`
 }
 
 var _ = shamhubRESTHandler("POST /{owner}/{repo}/change/states", (*ShamHub).handleStates)
@@ -34,6 +42,8 @@ func (sh *ShamHub) handleStates(_ context.Context, req *statesRequest) (*statesR
 
 	sh.mu.RLock()
 	states := make([]string, len(changeNumToIdx))
+	bases := make([]string, len(changeNumToIdx))
+	mergeCommits := make([]string, len(changeNumToIdx))
 	for _, c := range sh.changes {
 		if c.Base.Owner == owner && c.Base.Repo == repo {
 			idx, ok := changeNumToIdx[c.Number]
@@ -47,7 +57,9 @@ func (sh *ShamHub) handleStates(_ context.Context, req *statesRequest) (*statesR
 				states[idx] = "closed"
 			case shamChangeMerged:
 				states[idx] = "merged"
+				mergeCommits[idx] = c.MergeCommit
 			}
+			bases[idx] = c.Base.Name
 			delete(changeNumToIdx, c.Number)
 
 			if len(changeNumToIdx) == 0 {
@@ -61,10 +73,27 @@ func (sh *ShamHub) handleStates(_ context.Context, req *statesRequest) (*statesR
 		return nil, notFoundErrorf("changes not found: %v", changeNumToIdx)
 	}
 
-	return &statesResponse{States: states}, nil
+	return &statesResponse{
+		States:       states,
+		Bases:        bases,
+		MergeCommits: mergeCommits,
+	}, nil
 }
 
 func (r *forgeRepository) ChangesStates(ctx context.Context, fids []forge.ChangeID) ([]forge.ChangeState, error) {
+	details, err := r.ChangesDetails(ctx, fids)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]forge.ChangeState, len(details))
+	for i, d := range details {
+		states[i] = d.State
+	}
+	return states, nil
+}
+
+func (r *forgeRepository) ChangesDetails(ctx context.Context, fids []forge.ChangeID) ([]forge.ChangeDetails, error) {
 	ids := make([]ChangeID, len(fids))
 	for i, fid := range fids {
 		ids[i] = fid.(ChangeID)
@@ -78,21 +107,27 @@ func (r *forgeRepository) ChangesStates(ctx context.Context, fids []forge.Change
 		return nil, fmt.Errorf("get states: %w", err)
 	}
 
-	states := make([]forge.ChangeState, len(res.States))
+	details := make([]forge.ChangeDetails, len(res.States))
 	for i, state := range res.States {
 		switch state {
 		case "open":
-			states[i] = forge.ChangeOpen
+			details[i].State = forge.ChangeOpen
 		case "closed":
-			states[i] = forge.ChangeClosed
+			details[i].State = forge.ChangeClosed
 		case "merged":
-			states[i] = forge.ChangeMerged
+			details[i].State = forge.ChangeMerged
 		default:
-			states[i] = forge.ChangeOpen // default to open for unknown states
+			details[i].State = forge.ChangeOpen // default to open for unknown states
+		}
+		if i < len(res.Bases) {
+			details[i].BaseName = res.Bases[i]
+		}
+		if i < len(res.MergeCommits) {
+			details[i].MergeCommit = git.Hash(res.MergeCommits[i])
 		}
 	}
 
-	return states, nil
+	return details, nil
 }
 
 // MergeChangeRequest is a request to merge an open change
@@ -268,5 +303,40 @@ func (sh *ShamHub) MergeChange(req MergeChangeRequest) error {
 	}
 
 	sh.changes[changeIdx].State = shamChangeMerged
+	sh.changes[changeIdx].MergeCommit = commit
 	return nil
 }
+
+type mergeChangeRequest struct {
+	Owner  string `path:"owner" json:"-"`
+	Repo   string `path:"repo" json:"-"`
+	Number int    `path:"number" json:"-"`
+
+	Squash       bool `json:"squash,omitempty"`
+	DeleteBranch bool `json:"deleteBranch,omitempty"`
+}
+
+type mergeChangeResponse struct{}
+
+var _ = shamhubRESTHandler("POST /{owner}/{repo}/change/{number}/merge", (*ShamHub).handleMergeChange)
+
+// handleMergeChange merges a change over the API.
+// This stands in for the "Merge" button on a real forge
+// when ShamHub is run with 'shamhub serve'.
+func (sh *ShamHub) handleMergeChange(_ context.Context, req *mergeChangeRequest) (*mergeChangeResponse, error) {
+	if _, ok := sh.findChange(req.Owner, req.Repo, req.Number); !ok {
+		return nil, notFoundErrorf("change %d not found in %s/%s", req.Number, req.Owner, req.Repo)
+	}
+
+	if err := sh.MergeChange(MergeChangeRequest{
+		Owner:        req.Owner,
+		Repo:         req.Repo,
+		Number:       req.Number,
+		Squash:       req.Squash,
+		DeleteBranch: req.DeleteBranch,
+	}); err != nil {
+		return nil, badRequestErrorf("merge change: %v", err)
+	}
+
+	return &mergeChangeResponse{}, nil
+}
@@ -88,6 +88,7 @@ func (sh *ShamHub) handleSubmitChange(ctx context.Context, req *submitChangeRequ
 		Assignees:          req.Assignees,
 	}
 	sh.changes = append(sh.changes, change)
+	sh.defineLabels(owner, repo, req.Labels)
 
 	return &submitChangeResponse{
 		Number: change.Number,
@@ -109,8 +110,13 @@ func (r *forgeRepository) SubmitChange(ctx context.Context, req forge.SubmitChan
 		Assignees: req.Assignees,
 	}
 
-	// For now, fork functionality is handled at the ShamHub server level
-	// Future enhancement: detect if head branch is from a fork
+	// The head branch may be in a fork of the target repository.
+	if req.HeadRepository != nil {
+		headRepo := req.HeadRepository.(*RepositoryID)
+		if headRepo.owner != r.owner || headRepo.repo != r.repo {
+			submitReq.HeadRepo = headRepo.String()
+		}
+	}
 
 	var res submitChangeResponse
 	if err := r.client.Post(ctx, u.String(), submitReq, &res); err != nil {
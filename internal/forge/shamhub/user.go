@@ -0,0 +1,16 @@
+package shamhub
+
+import (
+	"context"
+	"fmt"
+)
+
+func (r *forgeRepository) CurrentUser(ctx context.Context) (string, error) {
+	u := r.apiURL.JoinPath("user")
+
+	var res userResponse
+	if err := r.client.Get(ctx, u.String(), &res); err != nil {
+		return "", fmt.Errorf("get current user: %w", err)
+	}
+	return res.Username, nil
+}
@@ -50,6 +50,8 @@ type Node interface {
 //
 // currentIdx is the index of the current node in the nodes list.
 // It will be marked with [Printer.Marker].
+// If currentIdx is -1, there is no current node,
+// and all stacks in the list are printed from their bottoms up.
 //
 // opts can be used to customize the behavior of Print.
 // If opts is nil, default options are used.
@@ -94,6 +96,30 @@ func Print[N Node](w io.Writer, nodes []N, currentIdx int, opts *PrintOptions) {
 		return true
 	}
 
+	// For the upstacks, we'll need to traverse the graph
+	// and recursively write the upstacks.
+	// Indentation will increase for each subtree.
+	var visit func(int, int)
+	visit = func(nodeIdx, indent int) {
+		if !ok(nodeIdx) {
+			return
+		}
+
+		writeNode(nodeIdx, indent)
+		for _, aboveIdx := range aboves[nodeIdx] {
+			visit(aboveIdx, indent+1)
+		}
+	}
+
+	if currentIdx < 0 {
+		for idx, node := range nodes {
+			if node.BaseIdx() < 0 {
+				visit(idx, 0)
+			}
+		}
+		return
+	}
+
 	// Write the downstacks, not including the current node.
 	// This will change the indent level.
 	// The downstacks leading up to the current branch are always linear.
@@ -111,21 +137,113 @@ func Print[N Node](w io.Writer, nodes []N, currentIdx int, opts *PrintOptions) {
 		}
 	}
 
-	// For the upstacks, we'll need to traverse the graph
-	// and recursively write the upstacks.
-	// Indentation will increase for each subtree.
-	var visit func(int, int)
-	visit = func(nodeIdx, indent int) {
+	// Current branch and its upstacks.
+	visit(currentIdx, indent)
+}
+
+// PrintTree visualizes the entire stack that the current node is part of
+// as an ASCII tree.
+// Unlike [Print], this includes branches that are not in the path
+// to the current node, e.g. siblings of its downstack nodes.
+//
+// For example:
+//
+//	#123
+//	├── #124 ◀
+//	│   └── #125
+//	└── #126
+//
+// The output is plain text, not Markdown.
+// Place it inside a code block when posting to a Forge.
+//
+// currentIdx is the index of the current node in the nodes list.
+// If currentIdx is -1, all stacks in the list are printed.
+//
+// opts can be used to customize the behavior of PrintTree.
+// If opts is nil, default options are used.
+//
+// All Write errors are ignored. Use a Writer that doesn't fail.
+func PrintTree[N Node](w io.Writer, nodes []N, currentIdx int, opts *PrintOptions) {
+	marker := _marker
+	if opts != nil && opts.Marker != "" {
+		marker = opts.Marker
+	}
+
+	aboves := make([][]int, len(nodes))
+	for idx, node := range nodes {
+		baseIdx := node.BaseIdx()
+		if baseIdx >= 0 {
+			aboves[baseIdx] = append(aboves[baseIdx], idx)
+		}
+	}
+
+	// Guard against cycles like Print does.
+	visited := make([]bool, len(nodes))
+	ok := func(i int) bool {
+		if i < 0 || i >= len(nodes) || visited[i] {
+			return false
+		}
+		visited[i] = true
+		return true
+	}
+
+	// prefix is written before every line of the subtree,
+	// and branch connects the node to its base.
+	var visit func(nodeIdx int, prefix, branch string)
+	visit = func(nodeIdx int, prefix, branch string) {
 		if !ok(nodeIdx) {
 			return
 		}
 
-		writeNode(nodeIdx, indent)
-		for _, aboveIdx := range aboves[nodeIdx] {
-			visit(aboveIdx, indent+1)
+		_, _ = fmt.Fprintf(w, "%s%s%v", prefix, branch, nodes[nodeIdx].Value())
+		if nodeIdx == currentIdx {
+			_, _ = fmt.Fprintf(w, " %v", marker)
+		}
+		_, _ = io.WriteString(w, "\n")
+
+		switch branch {
+		case _treeBranch:
+			prefix += _treePipe
+		case _treeLastBranch:
+			prefix += _treeSpace
+		}
+
+		for i, aboveIdx := range aboves[nodeIdx] {
+			if i == len(aboves[nodeIdx])-1 {
+				visit(aboveIdx, prefix, _treeLastBranch)
+			} else {
+				visit(aboveIdx, prefix, _treeBranch)
+			}
 		}
 	}
 
-	// Current branch and its upstacks.
-	visit(currentIdx, indent)
+	if currentIdx < 0 {
+		for idx, node := range nodes {
+			if node.BaseIdx() < 0 {
+				visit(idx, "", "")
+			}
+		}
+		return
+	}
+
+	// Find the bottom of the current node's stack
+	// and print everything above it.
+	root := currentIdx
+	seen := make([]bool, len(nodes))
+	for {
+		seen[root] = true
+		base := nodes[root].BaseIdx()
+		if base < 0 || base >= len(nodes) || seen[base] {
+			break
+		}
+		root = base
+	}
+	visit(root, "", "")
 }
+
+const (
+	_treeBranch     = "├── "
+	_treeLastBranch = "└── "
+	_treePipe       = "│   "
+	_treeSpace      = "    "
+)
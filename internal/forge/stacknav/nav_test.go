@@ -24,6 +24,24 @@ func TestPrinter(t *testing.T) {
 				"- #123 ◀",
 			),
 		},
+		{
+			name: "NoCurrent",
+			graph: []Item{
+				{value: "#123", base: -1},
+				{value: "#124", base: 0},
+				{value: "#125", base: 0},
+				{value: "#126", base: -1},
+				{value: "#127", base: 3},
+			},
+			current: -1,
+			want: joinLines(
+				"- #123",
+				"    - #124",
+				"    - #125",
+				"- #126",
+				"    - #127",
+			),
+		},
 		{
 			name: "Downstack",
 			graph: []Item{
@@ -110,3 +128,93 @@ func (i Item) BaseIdx() int  { return i.base }
 func joinLines(lines ...string) string {
 	return strings.Join(lines, "\n") + "\n"
 }
+
+func TestPrintTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   []Item
+		current int
+		want    string
+	}{
+		{
+			name: "Single",
+			graph: []Item{
+				{value: "#123", base: -1},
+			},
+			current: 0,
+			want: joinLines(
+				"#123 ◀",
+			),
+		},
+		{
+			name: "Linear",
+			graph: []Item{
+				{value: "#123", base: -1},
+				{value: "#124", base: 0},
+				{value: "#125", base: 1},
+			},
+			current: 1,
+			want: joinLines(
+				"#123",
+				"└── #124 ◀",
+				"    └── #125",
+			),
+		},
+		{
+			name: "MidStack",
+			graph: []Item{
+				{value: "#123", base: -1}, // 0
+				{value: "#124", base: 0},  // 1
+				{value: "#125", base: 1},  // 2
+				{value: "#126", base: 0},  // 3
+				{value: "#127", base: 3},  // 4
+			},
+			// Unlike Print, the sibling (3) is shown.
+			current: 1,
+			want: joinLines(
+				"#123",
+				"├── #124 ◀",
+				"│   └── #125",
+				"└── #126",
+				"    └── #127",
+			),
+		},
+		{
+			name: "OtherStacksOmitted",
+			graph: []Item{
+				{value: "#123", base: -1},
+				{value: "#124", base: 0},
+				{value: "#125", base: -1},
+			},
+			current: 1,
+			want: joinLines(
+				"#123",
+				"└── #124 ◀",
+			),
+		},
+		{
+			name: "NoCurrent",
+			graph: []Item{
+				{value: "#123", base: -1},
+				{value: "#124", base: 0},
+				{value: "#125", base: 0},
+				{value: "#126", base: -1},
+			},
+			current: -1,
+			want: joinLines(
+				"#123",
+				"├── #124",
+				"└── #125",
+				"#126",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got strings.Builder
+			PrintTree(&got, tt.graph, tt.current, nil)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
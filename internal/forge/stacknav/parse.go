@@ -0,0 +1,138 @@
+package stacknav
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParsedNode is a node of a stack parsed by [Parse].
+type ParsedNode struct {
+	// Value is the text that was printed for the node,
+	// e.g. "#123" or "[#123](https://example.com/123)".
+	Value string
+
+	// Base is the index of the node below this one,
+	// or -1 if this is the bottom-most node of its stack.
+	Base int
+}
+
+// Parse parses a stack printed by [Print] or [PrintTree]
+// back into a list of nodes.
+// Text around the stack (e.g. a comment header) is ignored.
+//
+// It returns the index of the node that was marked as current,
+// or -1 if no node was marked.
+//
+// Values must not contain spaces:
+// Parse treats anything after the first space as a marker.
+func Parse(text string) (nodes []ParsedNode, current int, err error) {
+	current = -1
+
+	// parents[depth] is the index of the last node seen at that depth.
+	var parents []int
+	add := func(depth int, line string) error {
+		if depth > len(parents) {
+			return fmt.Errorf("unexpected indentation: %q", line)
+		}
+		parents = parents[:depth]
+
+		value, marker, _ := strings.Cut(line, " ")
+		base := -1
+		if depth > 0 {
+			base = parents[depth-1]
+		}
+
+		if strings.TrimSpace(marker) != "" {
+			if current >= 0 {
+				return fmt.Errorf("multiple current nodes: %q", line)
+			}
+			current = len(nodes)
+		}
+
+		parents = append(parents, len(nodes))
+		nodes = append(nodes, ParsedNode{Value: value, Base: base})
+		return nil
+	}
+
+	var inCodeBlock bool
+	for line := range strings.Lines(text) {
+		line = strings.TrimRight(line, " \t\r\n")
+		if strings.HasPrefix(line, "```") {
+			inCodeBlock = !inCodeBlock
+			if !inCodeBlock {
+				// Only one stack per comment.
+				break
+			}
+			continue
+		}
+
+		var depth int
+		if inCodeBlock {
+			// PrintTree output:
+			//
+			//	#123
+			//	├── #124 ◀
+			//	│   └── #125
+			depth, line = treeDepth(line)
+		} else {
+			// Print output:
+			//
+			//	- #123
+			//	    - #124 ◀
+			var ok bool
+			depth, line, ok = listDepth(line)
+			if !ok {
+				if len(nodes) > 0 {
+					break // end of the list
+				}
+				continue
+			}
+		}
+
+		if line == "" {
+			continue
+		}
+		if err := add(depth, line); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	return nodes, current, nil
+}
+
+// listDepth reports the depth of a Markdown list item
+// and its contents without the indentation and bullet.
+func listDepth(line string) (depth int, rest string, ok bool) {
+	for strings.HasPrefix(line, _indent) {
+		line = line[len(_indent):]
+		depth++
+	}
+	rest, ok = strings.CutPrefix(line, "- ")
+	return depth, rest, ok
+}
+
+// treeDepth reports the depth of a tree line
+// and its contents without the tree drawing characters.
+func treeDepth(line string) (depth int, rest string) {
+	for {
+		var ok bool
+		for _, prefix := range []string{_treePipe, _treeSpace, _treeBranch, _treeLastBranch} {
+			if rest, ok = strings.CutPrefix(line, prefix); ok {
+				line = rest
+				depth++
+				break
+			}
+		}
+		if !ok {
+			break
+		}
+	}
+
+	// Each prefix is 4 characters wide,
+	// so this guards against partial prefixes.
+	if r, _ := utf8.DecodeRuneInString(line); strings.ContainsRune("│├└─", r) {
+		return depth, ""
+	}
+	return depth, line
+}
@@ -0,0 +1,167 @@
+package stacknav
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		give        string
+		want        []ParsedNode
+		wantCurrent int
+	}{
+		{
+			name: "Empty",
+			give: "This change is part of the following stack:\n",
+		},
+		{
+			name: "List",
+			give: joinLines(
+				"This change is part of the following stack:",
+				"",
+				"- #123",
+				"    - #124 ◀",
+				"        - #125",
+				"        - #126",
+				"",
+				"<sub>Change managed by git-spice.</sub>",
+				"- #999",
+			),
+			want: []ParsedNode{
+				{Value: "#123", Base: -1},
+				{Value: "#124", Base: 0},
+				{Value: "#125", Base: 1},
+				{Value: "#126", Base: 1},
+			},
+			wantCurrent: 1,
+		},
+		{
+			name: "ListCustomMarker",
+			give: joinLines(
+				"- [#1](https://example.com/1)",
+				"    - [#2](https://example.com/2) <-- you are here",
+			),
+			want: []ParsedNode{
+				{Value: "[#1](https://example.com/1)", Base: -1},
+				{Value: "[#2](https://example.com/2)", Base: 0},
+			},
+			wantCurrent: 1,
+		},
+		{
+			name: "ListCRLF",
+			give: "- #1 ◀\r\n    - #2\r\n",
+			want: []ParsedNode{
+				{Value: "#1", Base: -1},
+				{Value: "#2", Base: 0},
+			},
+			wantCurrent: 0,
+		},
+		{
+			name: "Tree",
+			give: joinLines(
+				"This change is part of the following stack:",
+				"",
+				"```",
+				"#123",
+				"├── #124 ◀",
+				"│   └── #125",
+				"└── #126",
+				"```",
+			),
+			want: []ParsedNode{
+				{Value: "#123", Base: -1},
+				{Value: "#124", Base: 0},
+				{Value: "#125", Base: 1},
+				{Value: "#126", Base: 0},
+			},
+			wantCurrent: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, current, err := Parse(tt.give)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.want != nil {
+				assert.Equal(t, tt.wantCurrent, current)
+			} else {
+				assert.Equal(t, -1, current)
+			}
+		})
+	}
+}
+
+func TestParse_roundTrip(t *testing.T) {
+	graph := []Item{
+		{value: "#1", base: -1},
+		{value: "#2", base: 0},
+		{value: "#3", base: 1},
+		{value: "#4", base: 1},
+		{value: "#5", base: 3},
+	}
+
+	for _, current := range []int{0, 2, 4} {
+		for name, print := range map[string]func(*strings.Builder){
+			"List": func(sb *strings.Builder) { Print(sb, graph, current, nil) },
+			"Tree": func(sb *strings.Builder) {
+				sb.WriteString("```\n")
+				PrintTree(sb, graph, current, nil)
+				sb.WriteString("```\n")
+			},
+		} {
+			var sb strings.Builder
+			print(&sb)
+
+			got, gotCurrent, err := Parse(sb.String())
+			require.NoError(t, err, "%v/%d", name, current)
+			require.GreaterOrEqual(t, gotCurrent, 0, "%v/%d", name, current)
+			assert.Equal(t, graph[current].value, got[gotCurrent].Value, "%v/%d", name, current)
+
+			// Every node's base must match the original graph.
+			for _, node := range got {
+				want := indexOfValue(graph, node.Value)
+				require.GreaterOrEqual(t, want, 0, "%v/%d: unknown node %v", name, current, node.Value)
+				wantBase := ""
+				if b := graph[want].base; b >= 0 {
+					wantBase = graph[b].value
+				}
+				gotBase := ""
+				if node.Base >= 0 {
+					gotBase = got[node.Base].Value
+				}
+				assert.Equal(t, wantBase, gotBase, "%v/%d: base of %v", name, current, node.Value)
+			}
+		}
+	}
+}
+
+func TestParse_badIndentation(t *testing.T) {
+	_, _, err := Parse(joinLines(
+		"- #1",
+		"        - #2",
+	))
+	assert.ErrorContains(t, err, "unexpected indentation")
+}
+
+func TestParse_multipleCurrent(t *testing.T) {
+	_, _, err := Parse(joinLines(
+		"- #1 ◀",
+		"    - #2 ◀",
+	))
+	assert.ErrorContains(t, err, "multiple current nodes")
+}
+
+func indexOfValue(items []Item, value string) int {
+	for i, item := range items {
+		if item.value == value {
+			return i
+		}
+	}
+	return -1
+}
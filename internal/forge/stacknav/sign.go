@@ -0,0 +1,143 @@
+package stacknav
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// _signatureVersion identifies the signing scheme.
+// It's part of the signature line and the signed message
+// so that the scheme can be changed without confusing old verifiers.
+const _signatureVersion = "v1"
+
+var (
+	// ErrUnsigned indicates that a comment does not have a signature.
+	ErrUnsigned = errors.New("comment is not signed")
+
+	// ErrBadSignature indicates that a comment's signature
+	// does not match its contents.
+	// The comment was modified, was copied from another change,
+	// or was signed with a different key.
+	ErrBadSignature = errors.New("comment signature does not match")
+)
+
+// SignatureStyle specifies how the signature is embedded in a comment.
+type SignatureStyle int
+
+const (
+	// SignatureHTML embeds the signature in an HTML comment.
+	SignatureHTML SignatureStyle = iota
+
+	// SignatureMarkdown embeds the signature in a Markdown link definition
+	// for forges that don't support HTML comments.
+	SignatureMarkdown
+)
+
+// Both styles are invisible when the comment is rendered.
+var _signatureRe = regexp.MustCompile(
+	`(?m)^(?:<!-- gs:signature (\w+) ([0-9a-f]+) -->|\[gs-signature\]: # \((\w+) ([0-9a-f]+)\))[ \t]*$`,
+)
+
+// Signer signs navigation comments
+// so that tools reading them (e.g. in CI) can verify
+// that they were posted by git-spice,
+// and not written by hand to misrepresent a change's place in its stack.
+//
+// Signatures are HMACs keyed by a secret shared between
+// everyone who submits changes and the tools verifying them.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner builds a Signer for comments posted in the given repository.
+//
+// The signing key is derived from the secret and the repository,
+// so comments copied from another repository don't verify
+// even if it uses the same secret.
+// repo must identify the repository the same way for signers and verifiers,
+// e.g. "github:owner/repo".
+func NewSigner(secret, repo string) *Signer {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte("git-spice navigation comment\n" + repo))
+	return &Signer{key: mac.Sum(nil)}
+}
+
+// Sign returns the comment with a signature appended.
+// change identifies the change the comment is posted on (e.g. "#123")
+// so that the comment doesn't verify if copied to another change.
+func (s *Signer) Sign(change, comment string, style SignatureStyle) string {
+	sig := s.signature(change, comment)
+
+	var sb strings.Builder
+	sb.WriteString(comment)
+	if !strings.HasSuffix(comment, "\n") {
+		sb.WriteString("\n")
+	}
+	switch style {
+	case SignatureMarkdown:
+		sb.WriteString("[gs-signature]: # (" + _signatureVersion + " " + sig + ")\n")
+	default:
+		sb.WriteString("<!-- gs:signature " + _signatureVersion + " " + sig + " -->\n")
+	}
+	return sb.String()
+}
+
+// Verify verifies that a comment retrieved from the given change
+// was signed by a Signer with the same secret and repository.
+//
+// It returns [ErrUnsigned] if the comment has no signature,
+// and [ErrBadSignature] if the signature doesn't match.
+func (s *Signer) Verify(change, comment string) error {
+	// Forges may convert line endings when storing comments.
+	comment = strings.ReplaceAll(comment, "\r\n", "\n")
+
+	matches := _signatureRe.FindAllStringSubmatchIndex(comment, -1)
+	if len(matches) == 0 {
+		return ErrUnsigned
+	}
+
+	// Only the last signature counts:
+	// anything after it would be unsigned.
+	m := matches[len(matches)-1]
+	if strings.TrimSpace(comment[m[1]:]) != "" {
+		return ErrBadSignature
+	}
+
+	version, got := submatch(comment, m, 1), submatch(comment, m, 2)
+	if version == "" {
+		version, got = submatch(comment, m, 3), submatch(comment, m, 4)
+	}
+	if version != _signatureVersion {
+		return ErrBadSignature
+	}
+
+	want := s.signature(change, comment[:m[0]])
+	if !hmac.Equal([]byte(got), []byte(want)) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// signature computes the hex-encoded signature of a comment.
+func (s *Signer) signature(change, comment string) string {
+	// Forges may strip trailing whitespace or convert line endings,
+	// so neither is part of the signed message.
+	comment = strings.ReplaceAll(comment, "\r\n", "\n")
+	comment = strings.TrimRight(comment, " \t\n")
+
+	mac := hmac.New(sha256.New, s.key)
+	_, _ = mac.Write([]byte(_signatureVersion + "\n" + change + "\n" + comment))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func submatch(s string, m []int, group int) string {
+	start, end := m[2*group], m[2*group+1]
+	if start < 0 {
+		return ""
+	}
+	return s[start:end]
+}
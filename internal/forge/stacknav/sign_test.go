@@ -0,0 +1,72 @@
+package stacknav
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	const comment = "This change is part of the following stack:\n\n- #123 ◀\n    - #124\n"
+
+	signer := NewSigner("secret", "github:owner/repo")
+
+	for _, style := range []SignatureStyle{SignatureHTML, SignatureMarkdown} {
+		signed := signer.Sign("#123", comment, style)
+		require.True(t, strings.HasPrefix(signed, comment))
+
+		t.Run("Verify", func(t *testing.T) {
+			assert.NoError(t, signer.Verify("#123", signed))
+		})
+
+		t.Run("CRLF", func(t *testing.T) {
+			crlf := strings.ReplaceAll(signed, "\n", "\r\n")
+			assert.NoError(t, signer.Verify("#123", crlf))
+		})
+
+		t.Run("TrailingWhitespace", func(t *testing.T) {
+			assert.NoError(t, signer.Verify("#123", strings.TrimRight(signed, "\n")))
+			assert.NoError(t, signer.Verify("#123", signed+"\n\n"))
+		})
+
+		t.Run("Tampered", func(t *testing.T) {
+			tampered := strings.Replace(signed, "- #123 ◀", "- #100\n    - #123 ◀", 1)
+			assert.ErrorIs(t, signer.Verify("#123", tampered), ErrBadSignature)
+		})
+
+		t.Run("AppendedContent", func(t *testing.T) {
+			assert.ErrorIs(t, signer.Verify("#123", signed+"- #999\n"), ErrBadSignature)
+		})
+
+		t.Run("OtherChange", func(t *testing.T) {
+			assert.ErrorIs(t, signer.Verify("#124", signed), ErrBadSignature)
+		})
+
+		t.Run("OtherRepository", func(t *testing.T) {
+			other := NewSigner("secret", "github:owner/fork")
+			assert.ErrorIs(t, other.Verify("#123", signed), ErrBadSignature)
+		})
+
+		t.Run("OtherSecret", func(t *testing.T) {
+			other := NewSigner("guess", "github:owner/repo")
+			assert.ErrorIs(t, other.Verify("#123", signed), ErrBadSignature)
+		})
+	}
+
+	t.Run("Unsigned", func(t *testing.T) {
+		assert.ErrorIs(t, signer.Verify("#123", comment), ErrUnsigned)
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		signed := signer.Sign("#123", comment, SignatureHTML)
+		signed = strings.Replace(signed, "gs:signature v1", "gs:signature v2", 1)
+		assert.ErrorIs(t, signer.Verify("#123", signed), ErrBadSignature)
+	})
+
+	t.Run("NoTrailingNewline", func(t *testing.T) {
+		signed := signer.Sign("#123", strings.TrimSuffix(comment, "\n"), SignatureHTML)
+		assert.NoError(t, signer.Verify("#123", signed))
+	})
+}
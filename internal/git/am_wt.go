@@ -0,0 +1,26 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/must"
+)
+
+// ApplyMailbox applies the patches in the given mailboxes
+// to the current branch with 'git am',
+// creating a commit for each patch.
+//
+// If a patch does not apply,
+// the worktree is left in the middle of the operation.
+// Use 'git am --abort' to return to the original state.
+func (w *Worktree) ApplyMailbox(ctx context.Context, mailboxes ...string) error {
+	must.NotBeEmptyf(mailboxes, "at least one mailbox is required")
+
+	args := []string{"am", "--quiet"}
+	args = append(args, mailboxes...)
+	if err := w.gitCmd(ctx, args...).Run(); err != nil {
+		return fmt.Errorf("git am: %w", err)
+	}
+	return nil
+}
@@ -3,8 +3,12 @@ package git
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"iter"
+	"strings"
+
+	"go.abhg.dev/gs/internal/xec"
 )
 
 // LocalBranch represents a local branch in a repository.
@@ -231,3 +235,46 @@ func (r *Repository) SetBranchUpstream(
 	}
 	return nil
 }
+
+// BranchDescription reports the description of a local branch
+// as set with 'git branch --edit-description'.
+// Returns an empty string if the branch has no description.
+func (r *Repository) BranchDescription(ctx context.Context, branch string) (string, error) {
+	desc, err := r.gitCmd(ctx,
+		"config", "--get", "branch."+branch+".description",
+	).Output()
+	if err != nil {
+		// git-config exits with code 1 if the key is not set.
+		if exitErr := new(xec.ExitError); errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("git config: %w", err)
+	}
+	return strings.TrimSpace(string(desc)), nil
+}
+
+// SetBranchDescription sets the description of a local branch.
+// This is the same description that 'git branch --edit-description' edits.
+//
+// If description is empty, the description is removed.
+func (r *Repository) SetBranchDescription(ctx context.Context, branch, description string) error {
+	key := "branch." + branch + ".description"
+	if description == "" {
+		r.log.Debug("Removing branch description", "name", branch)
+		err := r.gitCmd(ctx, "config", "--unset", key).Run()
+		// git-config exits with code 5 if the key was not set.
+		if exitErr := new(xec.ExitError); errors.As(err, &exitErr) && exitErr.ExitCode() == 5 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("git config: %w", err)
+		}
+		return nil
+	}
+
+	r.log.Debug("Setting branch description", "name", branch)
+	if err := r.gitCmd(ctx, "config", key, description).Run(); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	return nil
+}
@@ -404,6 +404,43 @@ func TestBranchExists_doesNotLog(t *testing.T) {
 	assert.Empty(t, logBuffer.String())
 }
 
+func TestBranchDescription(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		git init
+		git add init.txt
+		git commit -m 'Initial commit'
+		git branch feature
+
+		-- init.txt --
+		Initial
+	`)))
+	require.NoError(t, err)
+
+	repo, err := git.Open(t.Context(), fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	desc, err := repo.BranchDescription(t.Context(), "feature")
+	require.NoError(t, err)
+	assert.Empty(t, desc)
+
+	require.NoError(t, repo.SetBranchDescription(t.Context(), "feature", "Add feature\n\nMore details."))
+	desc, err = repo.BranchDescription(t.Context(), "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "Add feature\n\nMore details.", desc)
+
+	require.NoError(t, repo.SetBranchDescription(t.Context(), "feature", ""))
+	desc, err = repo.BranchDescription(t.Context(), "feature")
+	require.NoError(t, err)
+	assert.Empty(t, desc)
+
+	// Removing a description that isn't set is not an error.
+	require.NoError(t, repo.SetBranchDescription(t.Context(), "feature", ""))
+}
+
 // joinSlash joins the given paths and converts it to slash-separated path.
 //
 // Use this when the result is always /-separated, e.g. for git paths.
@@ -0,0 +1,129 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// CherryCommit is a commit reported by [Repository.Cherry].
+type CherryCommit struct {
+	// Hash is the hash of the commit.
+	Hash Hash
+
+	// Applied reports whether upstream already has a commit
+	// that introduces the same change as this commit.
+	//
+	// Commits are compared by patch ID,
+	// so this is true for commits that were cherry-picked
+	// or rebased onto upstream.
+	Applied bool
+}
+
+// Cherry lists commits in head that are not reachable from upstream,
+// reporting for each whether an equivalent change
+// was already applied to upstream.
+//
+// See git-cherry(1) for details.
+func (r *Repository) Cherry(ctx context.Context, upstream, head string) iter.Seq2[CherryCommit, error] {
+	return func(yield func(CherryCommit, error) bool) {
+		cmd := r.gitCmd(ctx, "cherry", upstream, head)
+		for bs, err := range cmd.Lines() {
+			if err != nil {
+				yield(CherryCommit{}, fmt.Errorf("git cherry: %w", err))
+				return
+			}
+
+			// Output is in the form:
+			//
+			//	+ <hash>
+			//	- <hash>
+			//
+			// Where '-' indicates that the change is in upstream.
+			sign, hash, ok := strings.Cut(string(bs), " ")
+			if !ok || (sign != "+" && sign != "-") {
+				r.log.Warn("Bad cherry output", "line", string(bs))
+				continue
+			}
+
+			if !yield(CherryCommit{
+				Hash:    Hash(hash),
+				Applied: sign == "-",
+			}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// IsChangeApplied reports whether upstream already contains
+// the combined changes introduced by head since base,
+// even if the commits themselves are not reachable from upstream.
+//
+// This detects branches that were squash-merged or rebase-merged:
+// the changes base..head are compared by patch ID
+// against commits in upstream that are not in head.
+func (r *Repository) IsChangeApplied(ctx context.Context, upstream, base, head Hash) (bool, error) {
+	if r.IsAncestor(ctx, head, upstream) {
+		return true, nil // regular merge or fast-forward
+	}
+
+	// If every commit was individually applied (e.g. rebase-merge),
+	// we're done.
+	allApplied := true
+	var numCommits int
+	for commit, err := range r.Cherry(ctx, upstream.String(), head.String()) {
+		if err != nil {
+			return false, err
+		}
+		numCommits++
+		allApplied = allApplied && commit.Applied
+	}
+	if numCommits > 0 && allApplied {
+		return true, nil
+	}
+
+	// Otherwise, squash base..head into a single temporary commit
+	// and check whether upstream has a commit with the same change.
+	tree, err := r.PeelToTree(ctx, head.String())
+	if err != nil {
+		return false, fmt.Errorf("peel to tree: %w", err)
+	}
+	baseTree, err := r.PeelToTree(ctx, base.String())
+	if err != nil {
+		return false, fmt.Errorf("peel base to tree: %w", err)
+	}
+	if tree == baseTree {
+		return false, nil // no changes to compare
+	}
+
+	// Use a fixed identity so this works even if the user
+	// hasn't configured one, and don't sign the throwaway commit.
+	var env []string
+	env = _squashSignature.appendEnv("AUTHOR", env)
+	env = _squashSignature.appendEnv("COMMITTER", env)
+	out, err := r.gitCmd(ctx, "commit-tree", "--no-gpg-sign", "-p", base.String(), tree.String()).
+		AppendEnv(env...).
+		WithStdinString("squashed changes").
+		OutputChomp()
+	if err != nil {
+		return false, fmt.Errorf("commit-tree: %w", err)
+	}
+	squashed := Hash(out)
+
+	for commit, err := range r.Cherry(ctx, upstream.String(), squashed.String()) {
+		if err != nil {
+			return false, err
+		}
+		if commit.Hash == squashed {
+			return commit.Applied, nil
+		}
+	}
+	return false, nil
+}
+
+var _squashSignature = Signature{
+	Name:  "git-spice",
+	Email: "git-spice@localhost",
+}
@@ -0,0 +1,115 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/git/gittest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestIsChangeApplied(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		at '2025-03-16T18:19:20Z'
+
+		git init
+		git commit --allow-empty -m 'Initial commit'
+
+		git checkout -b merged
+		git add merged.txt
+		git commit -m 'Add merged'
+
+		git checkout -b squashed main
+		git add squashed1.txt
+		git commit -m 'Add squashed 1'
+		git add squashed2.txt
+		git commit -m 'Add squashed 2'
+
+		git checkout -b picked main
+		git add picked.txt
+		git commit -m 'Add picked'
+
+		git checkout -b unmerged main
+		git add unmerged.txt
+		git commit -m 'Add unmerged'
+
+		git checkout -b partial main
+		git add partial1.txt
+		git commit -m 'Add partial 1'
+		git add partial2.txt
+		git commit -m 'Add partial 2'
+
+		git checkout main
+		git merge --no-ff -m 'Merge merged' merged
+		git merge --squash squashed
+		git commit -m 'Squash squashed'
+		git cherry-pick picked
+		git cherry-pick partial~1
+
+		-- merged.txt --
+		merged
+		-- squashed1.txt --
+		squashed 1
+		-- squashed2.txt --
+		squashed 2
+		-- picked.txt --
+		picked
+		-- unmerged.txt --
+		unmerged
+		-- partial1.txt --
+		partial 1
+		-- partial2.txt --
+		partial 2
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	ctx := t.Context()
+	repo, err := git.Open(ctx, fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	peel := func(t *testing.T, ref string) git.Hash {
+		hash, err := repo.PeelToCommit(ctx, ref)
+		require.NoError(t, err)
+		return hash
+	}
+
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{branch: "merged", want: true},
+		{branch: "squashed", want: true},
+		{branch: "picked", want: true},
+		{branch: "unmerged", want: false},
+		{branch: "partial", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, err := repo.IsChangeApplied(ctx,
+				peel(t, "main"),
+				peel(t, "main~4"), // initial commit
+				peel(t, tt.branch),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Cherry", func(t *testing.T) {
+		var got []bool
+		for commit, err := range repo.Cherry(ctx, "main", "partial") {
+			require.NoError(t, err)
+			got = append(got, commit.Applied)
+		}
+		assert.Equal(t, []bool{true, false}, got)
+	})
+}
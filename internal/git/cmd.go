@@ -7,8 +7,10 @@ package git
 import (
 	"context"
 	"strings"
+	"time"
 
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/timing"
 	"go.abhg.dev/gs/internal/xec"
 )
 
@@ -18,6 +20,12 @@ type execer = xec.Execer
 
 var _realExec = xec.DefaultExecer
 
+// _networkTimeout is the maximum time a Git command
+// that talks to a remote (e.g. fetch, ls-remote) may run for.
+// This prevents a hung connection or credential prompt
+// from blocking git-spice indefinitely.
+const _networkTimeout = 10 * time.Minute
+
 type extraConfig struct {
 	Editor string // core.editor
 
@@ -73,5 +81,6 @@ func newGitCmd(ctx context.Context, log *silog.Logger, exec execer, args ...stri
 
 	return xec.Command(ctx, log, "git", args...).
 		WithExecer(exec).
-		WithLogPrefix(prefix)
+		WithLogPrefix(prefix).
+		WithTiming(timing.Git)
 }
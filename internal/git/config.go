@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"path/filepath"
 	"strings"
 
 	"go.abhg.dev/gs/internal/scanutil"
@@ -131,6 +132,13 @@ func (k ConfigKey) Name() string {
 type ConfigEntry struct {
 	Key   ConfigKey
 	Value string
+
+	// Origin is where the entry was defined
+	// in the format reported by 'git config --show-origin'.
+	// For example, "file:/home/user/.gitconfig" or "command line:".
+	//
+	// Origin is set only by [Config.ListOriginRegexp].
+	Origin string
 }
 
 // ListRegexp lists all configuration entries that match the given patterns.
@@ -140,12 +148,25 @@ func (cfg *Config) ListRegexp(ctx context.Context, patterns ...string) iter.Seq2
 	if len(patterns) > 0 {
 		pattern = strings.Join(patterns, "|")
 	}
-	return cfg.list(ctx, "--get-regexp", pattern)
+	return cfg.list(ctx, false /* origin */, "--get-regexp", pattern)
+}
+
+// ListOriginRegexp is a variant of [Config.ListRegexp]
+// that also reports the origin of each entry.
+//
+// Origins of entries defined in files are reported as "file:PATH".
+// Relative paths are resolved against [ConfigOptions.Dir].
+func (cfg *Config) ListOriginRegexp(ctx context.Context, patterns ...string) iter.Seq2[ConfigEntry, error] {
+	pattern := "."
+	if len(patterns) > 0 {
+		pattern = strings.Join(patterns, "|")
+	}
+	return cfg.list(ctx, true /* origin */, "--show-origin", "--get-regexp", pattern)
 }
 
 var _newline = []byte("\n")
 
-func (cfg *Config) list(ctx context.Context, args ...string) iter.Seq2[ConfigEntry, error] {
+func (cfg *Config) list(ctx context.Context, withOrigin bool, args ...string) iter.Seq2[ConfigEntry, error] {
 	log := cfg.log
 	args = append([]string{"config", "--null"}, args...)
 	return func(yield func(ConfigEntry, error) bool) {
@@ -157,6 +178,14 @@ func (cfg *Config) list(ctx context.Context, args ...string) iter.Seq2[ConfigEnt
 		//
 		//	key1\nvalue1\0
 		//	key2\nvalue2\0
+		//
+		// With --show-origin, each entry is preceded by its origin:
+		//
+		//	origin1\0key1\nvalue1\0
+		var (
+			origin     string
+			haveOrigin bool
+		)
 		for entry, err := range cmd.Scan(scanutil.SplitNull) {
 			if err != nil {
 				// git-config fails with a non-zero exit code if there are no matches.
@@ -169,6 +198,12 @@ func (cfg *Config) list(ctx context.Context, args ...string) iter.Seq2[ConfigEnt
 				return
 			}
 
+			if withOrigin && !haveOrigin {
+				origin, haveOrigin = cfg.resolveOrigin(string(entry)), true
+				continue
+			}
+			haveOrigin = false
+
 			key, value, ok := bytes.Cut(entry, _newline)
 			if !ok {
 				log.Warnf("skipping invalid entry: %q", entry)
@@ -176,11 +211,98 @@ func (cfg *Config) list(ctx context.Context, args ...string) iter.Seq2[ConfigEnt
 			}
 
 			if !yield(ConfigEntry{
-				Key:   ConfigKey(key),
-				Value: string(value),
+				Key:    ConfigKey(key),
+				Value:  string(value),
+				Origin: origin,
 			}, nil) {
 				return
 			}
 		}
 	}
 }
+
+// resolveOrigin resolves relative file paths in a git-config origin
+// against the directory that git-config ran in.
+func (cfg *Config) resolveOrigin(origin string) string {
+	path, ok := strings.CutPrefix(origin, "file:")
+	if !ok || cfg.dir == "" || filepath.IsAbs(path) {
+		return origin
+	}
+	return "file:" + filepath.Join(cfg.dir, path)
+}
+
+// ConfigSetOptions specifies options for [Config.Set].
+type ConfigSetOptions struct {
+	// Global writes the value to the user's global configuration
+	// instead of the configuration of the current repository.
+	Global bool
+}
+
+// Set sets the value of a configuration key,
+// replacing its existing value if any.
+//
+// If the key has multiple values, git-config refuses to replace them
+// and an error is returned.
+func (cfg *Config) Set(ctx context.Context, key ConfigKey, value string, opts *ConfigSetOptions) error {
+	if opts == nil {
+		opts = &ConfigSetOptions{}
+	}
+
+	args := []string{"config"}
+	if opts.Global {
+		args = append(args, "--global")
+	}
+	args = append(args, string(key), value)
+
+	if err := newGitCmd(ctx, cfg.log, cfg.exec, args...).
+		WithDir(cfg.dir).
+		AppendEnv(cfg.env...).
+		Run(); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	return nil
+}
+
+// Unset removes a configuration key.
+//
+// An error is returned if the key is not set,
+// or if it has multiple values.
+func (cfg *Config) Unset(ctx context.Context, key ConfigKey, opts *ConfigSetOptions) error {
+	if opts == nil {
+		opts = &ConfigSetOptions{}
+	}
+
+	args := []string{"config"}
+	if opts.Global {
+		args = append(args, "--global")
+	}
+	args = append(args, "--unset", string(key))
+
+	if err := newGitCmd(ctx, cfg.log, cfg.exec, args...).
+		WithDir(cfg.dir).
+		AppendEnv(cfg.env...).
+		Run(); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	return nil
+}
+
+// ListCommands lists the names of Git commands
+// that are built into Git or installed as git-* executables.
+//
+// Git ignores aliases that have the same name as these.
+func (cfg *Config) ListCommands(ctx context.Context) ([]string, error) {
+	var names []string
+	for line, err := range newGitCmd(ctx, cfg.log, cfg.exec, "--list-cmds=main,others").
+		WithDir(cfg.dir).
+		AppendEnv(cfg.env...).
+		Lines() {
+		if err != nil {
+			return nil, fmt.Errorf("git --list-cmds: %w", err)
+		}
+		if name := strings.TrimSpace(string(line)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
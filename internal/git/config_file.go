@@ -0,0 +1,261 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// ConfigFileEntry is a key defined in a git-config file.
+type ConfigFileEntry struct {
+	// Key is the canonical form of the key.
+	Key ConfigKey
+
+	// Line is the 1-indexed line that the key was defined on.
+	Line int
+}
+
+// ScanConfigFile reports the keys defined in a git-config file,
+// in the order they appear in the file.
+//
+// Values are not reported.
+// Use this to map entries reported by [Config.ListOriginRegexp]
+// back to the lines that defined them:
+// the Nth entry for a key from a file is the Nth definition of it.
+// Include directives are reported as regular keys and not followed.
+func ScanConfigFile(r io.Reader) iter.Seq2[ConfigFileEntry, error] {
+	return func(yield func(ConfigFileEntry, error) bool) {
+		scan := bufio.NewScanner(r)
+		var (
+			lineNum      int
+			section      string // section.subsection
+			continuation bool   // previous line ended with '\'
+		)
+		for scan.Scan() {
+			lineNum++
+			line := strings.TrimSuffix(scan.Text(), "\r")
+
+			if continuation {
+				continuation = valueContinues(line)
+				continue
+			}
+
+			line = strings.TrimLeft(line, " \t")
+			if rest, ok := strings.CutPrefix(line, "["); ok {
+				var err error
+				section, line, err = parseConfigSection(rest)
+				if err != nil {
+					yield(ConfigFileEntry{}, fmt.Errorf("line %d: %w", lineNum, err))
+					return
+				}
+
+				// A variable may follow the header on the same line.
+				line = strings.TrimLeft(line, " \t")
+			}
+
+			name := line[:configNameLen(line, false /* section */)]
+			if name == "" {
+				// Blank line, comment, or something we don't recognize.
+				continue
+			}
+			if section == "" {
+				yield(ConfigFileEntry{}, fmt.Errorf("line %d: key %q outside a section", lineNum, name))
+				return
+			}
+
+			if !yield(ConfigFileEntry{
+				Key:  ConfigKey(section + "." + name).Canonical(),
+				Line: lineNum,
+			}, nil) {
+				return
+			}
+
+			continuation = valueContinues(line[len(name):])
+		}
+
+		if err := scan.Err(); err != nil {
+			yield(ConfigFileEntry{}, err)
+		}
+	}
+}
+
+// ConfigLineFinder finds the lines in git-config files
+// that define entries reported by [Config.ListOriginRegexp].
+//
+// All entries for a key must be passed to Line
+// in the order that they were reported.
+// Files are read at most once.
+//
+// The zero value is ready to use.
+type ConfigLineFinder struct {
+	// Log is used to report files that could not be read.
+	// If nil, nothing is logged.
+	Log *silog.Logger
+
+	// lines maps file paths to the lines that define each key in them.
+	lines map[string]map[ConfigKey][]int
+
+	// seen maps origins to the number of entries seen so far
+	// for each key from that origin.
+	seen map[string]map[ConfigKey]int
+}
+
+// Line reports the 1-indexed line that defined the given entry.
+// It returns 0 if the entry was not defined in a file,
+// or if the line cannot be determined.
+func (f *ConfigLineFinder) Line(entry ConfigEntry) int {
+	key := entry.Key.Canonical()
+
+	if f.seen == nil {
+		f.seen = make(map[string]map[ConfigKey]int)
+	}
+	seen, ok := f.seen[entry.Origin]
+	if !ok {
+		seen = make(map[ConfigKey]int)
+		f.seen[entry.Origin] = seen
+	}
+	idx := seen[key]
+	seen[key]++
+
+	path, ok := strings.CutPrefix(entry.Origin, "file:")
+	if !ok {
+		return 0
+	}
+
+	if f.lines == nil {
+		f.lines = make(map[string]map[ConfigKey][]int)
+	}
+	lines, ok := f.lines[path]
+	if !ok {
+		lines = f.scan(path)
+		f.lines[path] = lines
+	}
+
+	if idx < len(lines[key]) {
+		return lines[key][idx]
+	}
+	return 0
+}
+
+func (f *ConfigLineFinder) scan(path string) map[ConfigKey][]int {
+	log := f.Log
+	if log == nil {
+		log = silog.Nop()
+	}
+
+	lines := make(map[ConfigKey][]int)
+	file, err := os.Open(path)
+	if err != nil {
+		log.Debug("Could not open configuration file", "path", path, "error", err)
+		return lines
+	}
+	defer func() { _ = file.Close() }()
+
+	for entry, err := range ScanConfigFile(file) {
+		if err != nil {
+			log.Debug("Could not read configuration file", "path", path, "error", err)
+			break
+		}
+		lines[entry.Key] = append(lines[entry.Key], entry.Line)
+	}
+	return lines
+}
+
+// FormatConfigOrigin formats the origin of a configuration entry
+// and the line it was defined on (if known) for display.
+// For example, "/home/user/.gitconfig:12" or "command line".
+func FormatConfigOrigin(origin string, line int) string {
+	path, ok := strings.CutPrefix(origin, "file:")
+	if !ok {
+		return strings.TrimSuffix(origin, ":")
+	}
+	if line > 0 {
+		path += ":" + strconv.Itoa(line)
+	}
+	return path
+}
+
+// parseConfigSection parses a section header
+// with the leading '[' already removed.
+// It returns the section and subsection joined by a '.',
+// and the rest of the line after the closing ']'.
+func parseConfigSection(s string) (section, rest string, err error) {
+	name := s[:configNameLen(s, true /* section */)]
+	s = s[len(name):]
+
+	// In the deprecated "[section.subsection]" syntax,
+	// the subsection is part of the name and is case-insensitive.
+	if rest, ok := strings.CutPrefix(s, "]"); ok {
+		return strings.ToLower(name), rest, nil
+	}
+
+	// [section "subsection"]
+	s = strings.TrimLeft(s, " \t")
+	s, ok := strings.CutPrefix(s, `"`)
+	if !ok {
+		return "", "", fmt.Errorf("invalid section header: [%s", s)
+	}
+
+	var sub strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				sub.WriteByte(s[i])
+			}
+		case '"':
+			rest, ok := strings.CutPrefix(s[i+1:], "]")
+			if !ok {
+				return "", "", fmt.Errorf("invalid section header: missing ']'")
+			}
+			return name + "." + sub.String(), rest, nil
+		default:
+			sub.WriteByte(c)
+		}
+	}
+	return "", "", fmt.Errorf("invalid section header: unterminated subsection")
+}
+
+// configNameLen returns the length of the section or variable name
+// at the start of s.
+// Names are made of alphanumeric characters and '-'.
+// Section names may also contain '.'.
+func configNameLen(s string, section bool) int {
+	for i := range len(s) {
+		c := s[i]
+		isAlnum := ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+		if !isAlnum && c != '-' && (!section || c != '.') {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// valueContinues reports whether the value portion of a line
+// ends with a '\' that continues it onto the next line.
+func valueContinues(s string) bool {
+	var quoted bool
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i == len(s)-1 {
+				return true
+			}
+			i++ // skip escaped character
+		case '"':
+			quoted = !quoted
+		case '#', ';':
+			if !quoted {
+				return false // rest is a comment
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/sliceutil"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestScanConfigFile(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want []ConfigFileEntry
+	}{
+		{name: "Empty"},
+		{
+			name: "Sections",
+			give: text.Dedent(`
+				# comment
+				[user]
+					name = Alice
+				; another comment
+
+				[Remote "Origin"]
+					URL = https://example.com
+					fetch = +refs/heads/*:refs/remotes/origin/*
+				[spice.Shorthand]
+					bco = branch checkout
+			`),
+			want: []ConfigFileEntry{
+				{Key: "user.name", Line: 3},
+				{Key: "remote.Origin.url", Line: 7},
+				{Key: "remote.Origin.fetch", Line: 8},
+				{Key: "spice.shorthand.bco", Line: 10},
+			},
+		},
+		{
+			name: "KeyAfterHeader",
+			give: "[core] editor = vim\n",
+			want: []ConfigFileEntry{
+				{Key: "core.editor", Line: 1},
+			},
+		},
+		{
+			name: "BooleanShorthand",
+			give: "[spice \"submit\"]\n\tdraft\n",
+			want: []ConfigFileEntry{
+				{Key: "spice.submit.draft", Line: 2},
+			},
+		},
+		{
+			name: "EscapedSubsection",
+			give: `[url "a\"b\\c"]` + "\n\tinsteadOf = x\n",
+			want: []ConfigFileEntry{
+				{Key: `url.a"b\c.insteadof`, Line: 2},
+			},
+		},
+		{
+			name: "Continuation",
+			give: text.Dedent(`
+				[alias]
+					lg = log \
+						--oneline
+					quoted = "foo \" \
+				bar"
+					comment = foo # not a continuation \
+					st = status
+			`),
+			want: []ConfigFileEntry{
+				{Key: "alias.lg", Line: 2},
+				{Key: "alias.quoted", Line: 4},
+				{Key: "alias.comment", Line: 6},
+				{Key: "alias.st", Line: 7},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sliceutil.CollectErr(ScanConfigFile(strings.NewReader(tt.give)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScanConfigFile_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		wantErr string
+	}{
+		{
+			name:    "OutsideSection",
+			give:    "name = Alice\n",
+			wantErr: `line 1: key "name" outside a section`,
+		},
+		{
+			name:    "UnterminatedSubsection",
+			give:    "[user]\n[remote \"origin]\n",
+			wantErr: "line 2: invalid section header: unterminated subsection",
+		},
+		{
+			name:    "MissingBracket",
+			give:    "[remote \"origin\"\n",
+			wantErr: "line 1: invalid section header: missing ']'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sliceutil.CollectErr(ScanConfigFile(strings.NewReader(tt.give)))
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfigLineFinder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(text.Dedent(`
+		[spice "submit"]
+			draft = true
+		[spice "log"]
+			all = true
+		[spice "submit"]
+			Draft = false
+	`)), 0o644))
+
+	origin := "file:" + path
+	var finder ConfigLineFinder
+	assert.Equal(t, 2, finder.Line(ConfigEntry{Key: "spice.submit.draft", Origin: origin}))
+	assert.Equal(t, 4, finder.Line(ConfigEntry{Key: "spice.log.all", Origin: origin}))
+	assert.Equal(t, 6, finder.Line(ConfigEntry{Key: "spice.submit.draft", Origin: origin}))
+
+	// More entries than the file defines.
+	assert.Zero(t, finder.Line(ConfigEntry{Key: "spice.submit.draft", Origin: origin}))
+
+	assert.Zero(t, finder.Line(ConfigEntry{Key: "spice.log.all", Origin: "command line:"}))
+	assert.Zero(t, finder.Line(ConfigEntry{Key: "spice.log.all", Origin: "file:does-not-exist"}))
+}
+
+func TestFormatConfigOrigin(t *testing.T) {
+	assert.Equal(t, "/home/user/.gitconfig:12", FormatConfigOrigin("file:/home/user/.gitconfig", 12))
+	assert.Equal(t, ".git/config", FormatConfigOrigin("file:.git/config", 0))
+	assert.Equal(t, "command line", FormatConfigOrigin("command line:", 0))
+}
@@ -3,8 +3,10 @@ package git
 import (
 	"bytes"
 	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -289,3 +291,155 @@ func TestIntegrationConfigListRegexp(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegrationConfigListOriginRegexp(t *testing.T) {
+	home := t.TempDir()
+	repoDir := t.TempDir()
+	env := []string{
+		"HOME=" + home,
+		"XDG_CONFIG_HOME=" + filepath.Join(home, ".config"),
+		"GIT_CONFIG_NOSYSTEM=1",
+	}
+
+	ctx := t.Context()
+	log := silogtest.New(t)
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "--global", "user.name", "Alice"},
+		{"config", "--local", "user.email", "alice@example.com"},
+	} {
+		err := newGitCmd(ctx, log, _realExec, args...).
+			WithDir(repoDir).
+			AppendEnv(env...).
+			Run()
+		require.NoError(t, err, "git: %v", args)
+	}
+
+	cfg := NewConfig(ConfigOptions{
+		Dir: repoDir,
+		Env: env,
+		Log: log,
+	})
+
+	got, err := sliceutil.CollectErr(cfg.ListOriginRegexp(ctx, `^user\.`))
+	require.NoError(t, err)
+	assert.Equal(t, []ConfigEntry{
+		{
+			Key:    "user.name",
+			Value:  "Alice",
+			Origin: "file:" + filepath.Join(home, ".gitconfig"),
+		},
+		{
+			Key:    "user.email",
+			Value:  "alice@example.com",
+			Origin: "file:" + filepath.Join(repoDir, ".git", "config"),
+		},
+	}, got)
+}
+
+func TestIntegrationConfigSet(t *testing.T) {
+	home := t.TempDir()
+	repoDir := t.TempDir()
+	env := []string{
+		"HOME=" + home,
+		"XDG_CONFIG_HOME=" + filepath.Join(home, ".config"),
+		"GIT_CONFIG_NOSYSTEM=1",
+	}
+
+	ctx := t.Context()
+	log := silogtest.New(t)
+	require.NoError(t, newGitCmd(ctx, log, _realExec, "init", "--quiet").
+		WithDir(repoDir).
+		AppendEnv(env...).
+		Run())
+
+	cfg := NewConfig(ConfigOptions{
+		Dir: repoDir,
+		Env: env,
+		Log: log,
+	})
+
+	require.NoError(t, cfg.Set(ctx, "user.name", "Alice", &ConfigSetOptions{Global: true}))
+	require.NoError(t, cfg.Set(ctx, "user.email", "alice@example.com", nil))
+	require.NoError(t, cfg.Set(ctx, "user.email", "alice@example.org", nil))
+
+	got, err := sliceutil.CollectErr(cfg.ListOriginRegexp(ctx, `^user\.`))
+	require.NoError(t, err)
+	assert.Equal(t, []ConfigEntry{
+		{
+			Key:    "user.name",
+			Value:  "Alice",
+			Origin: "file:" + filepath.Join(home, ".gitconfig"),
+		},
+		{
+			Key:    "user.email",
+			Value:  "alice@example.org",
+			Origin: "file:" + filepath.Join(repoDir, ".git", "config"),
+		},
+	}, got)
+
+	t.Run("MultipleValues", func(t *testing.T) {
+		require.NoError(t, newGitCmd(ctx, log, _realExec, "config", "--add", "user.email", "bob@example.com").
+			WithDir(repoDir).
+			AppendEnv(env...).
+			Run())
+
+		err := cfg.Set(ctx, "user.email", "carol@example.com", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestIntegrationConfigUnset(t *testing.T) {
+	home := t.TempDir()
+	repoDir := t.TempDir()
+	env := []string{
+		"HOME=" + home,
+		"XDG_CONFIG_HOME=" + filepath.Join(home, ".config"),
+		"GIT_CONFIG_NOSYSTEM=1",
+	}
+
+	ctx := t.Context()
+	log := silogtest.New(t)
+	require.NoError(t, newGitCmd(ctx, log, _realExec, "init", "--quiet").
+		WithDir(repoDir).
+		AppendEnv(env...).
+		Run())
+
+	cfg := NewConfig(ConfigOptions{
+		Dir: repoDir,
+		Env: env,
+		Log: log,
+	})
+
+	require.NoError(t, cfg.Set(ctx, "alias.foo", "log", &ConfigSetOptions{Global: true}))
+	require.NoError(t, cfg.Set(ctx, "alias.bar", "status", nil))
+
+	require.NoError(t, cfg.Unset(ctx, "alias.foo", &ConfigSetOptions{Global: true}))
+	require.NoError(t, cfg.Unset(ctx, "alias.bar", nil))
+
+	got, err := sliceutil.CollectErr(cfg.ListRegexp(ctx, `^alias\.`))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	assert.Error(t, cfg.Unset(ctx, "alias.foo", nil), "key is not set")
+}
+
+func TestIntegrationConfigListCommands(t *testing.T) {
+	// Shell scripts aren't executable on Windows.
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows")
+	}
+
+	binDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "git-frobnicate"), []byte("#!/bin/sh\n"), 0o755))
+
+	cfg := NewConfig(ConfigOptions{
+		Env: []string{"PATH=" + binDir + string(filepath.ListSeparator) + os.Getenv("PATH")},
+		Log: silogtest.New(t),
+	})
+
+	got, err := cfg.ListCommands(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, got, "commit")
+	assert.Contains(t, got, "frobnicate")
+}
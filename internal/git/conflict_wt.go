@@ -0,0 +1,125 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ConflictSide identifies one side of a merge conflict.
+//
+// During a rebase, "ours" is the commit being rebased onto,
+// and "theirs" is the commit being replayed on top of it.
+type ConflictSide int
+
+const (
+	// ConflictOurs selects the version from HEAD.
+	ConflictOurs ConflictSide = iota + 1
+
+	// ConflictTheirs selects the version from the commit being applied.
+	ConflictTheirs
+)
+
+func (s ConflictSide) String() string {
+	switch s {
+	case ConflictOurs:
+		return "ours"
+	case ConflictTheirs:
+		return "theirs"
+	default:
+		return "ConflictSide(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// ResolveConflict resolves a conflicted file
+// by taking the given side's version of it wholesale,
+// and marks it as resolved in the index.
+//
+// If the chosen side deleted the file, the file is deleted.
+func (w *Worktree) ResolveConflict(ctx context.Context, path string, side ConflictSide) error {
+	var flag string
+	switch side {
+	case ConflictOurs:
+		flag = "--ours"
+	case ConflictTheirs:
+		flag = "--theirs"
+	default:
+		return fmt.Errorf("unknown conflict side: %v", side)
+	}
+
+	if err := w.gitCmd(ctx, "checkout", flag, "--", path).Run(); err != nil {
+		// If the file is still unmerged,
+		// the chosen side does not have the file.
+		// Taking that side means deleting it.
+		out, lsErr := w.gitCmd(ctx, "ls-files", "--unmerged", "--", path).OutputChomp()
+		if lsErr != nil || out == "" {
+			return fmt.Errorf("checkout %v: %w", flag, err)
+		}
+		if err := w.gitCmd(ctx, "rm", "--quiet", "--", path).Run(); err != nil {
+			return fmt.Errorf("git rm: %w", err)
+		}
+		return nil
+	}
+
+	if err := w.gitCmd(ctx, "add", "--", path).Run(); err != nil {
+		return fmt.Errorf("git add: %w", err)
+	}
+	return nil
+}
+
+// Mergetool runs the user's configured merge tool
+// to resolve the conflicts in the given file.
+//
+// The tool is run attached to the terminal.
+// Git marks the file as resolved if the tool reports success.
+func (w *Worktree) Mergetool(ctx context.Context, path string) error {
+	cmd := w.gitCmd(ctx, "mergetool", "--no-prompt", "--", path).
+		WithStdin(os.Stdin).
+		WithStdout(os.Stdout).
+		WithStderr(os.Stderr)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git mergetool: %w", err)
+	}
+	return nil
+}
+
+// RerereEnabled reports whether 'git rerere' is enabled for the repository,
+// which makes Git record and replay conflict resolutions.
+func (r *Repository) RerereEnabled(ctx context.Context) bool {
+	out, err := r.gitCmd(ctx, "config", "--type=bool", "--get", "rerere.enabled").OutputChomp()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(out) == "true"
+}
+
+// EnableRerere enables 'git rerere' for the repository
+// by setting rerere.enabled in the repository's Git configuration.
+//
+// It also sets rerere.autoUpdate so that files
+// resolved from recorded resolutions are staged automatically.
+func (r *Repository) EnableRerere(ctx context.Context) error {
+	for _, kv := range [][2]string{
+		{"rerere.enabled", "true"},
+		{"rerere.autoUpdate", "true"},
+	} {
+		if err := r.gitCmd(ctx, "config", "--local", kv[0], kv[1]).Run(); err != nil {
+			return fmt.Errorf("set %v: %w", kv[0], err)
+		}
+	}
+	return nil
+}
+
+// Rerere runs 'git rerere' in the worktree.
+//
+// This records the conflicted state of files in an ongoing merge
+// (so that their resolutions can be recorded later),
+// and applies any previously recorded resolutions to them.
+func (w *Worktree) Rerere(ctx context.Context) error {
+	if err := w.gitCmd(ctx, "rerere").Run(); err != nil {
+		return fmt.Errorf("git rerere: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package git_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/git/gittest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestWorktree_ResolveConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		side git.ConflictSide
+		want string
+	}{
+		{name: "Ours", side: git.ConflictOurs, want: "main\n"},
+		{name: "Theirs", side: git.ConflictTheirs, want: "feature\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+				as 'Test <test@example.com>'
+				at '2024-05-21T20:30:40Z'
+
+				git init
+				git add foo.txt gone.txt
+				git commit -m 'Initial commit'
+
+				git checkout -b feature
+				cp $WORK/extra/feature.txt foo.txt
+				git add foo.txt
+				git rm gone.txt
+				git commit -m 'Change foo on feature'
+
+				git checkout main
+				cp $WORK/extra/main.txt foo.txt
+				cp $WORK/extra/main.txt gone.txt
+				git add foo.txt gone.txt
+				git commit -m 'Change foo on main'
+
+				-- foo.txt --
+				initial
+				-- gone.txt --
+				initial
+				-- extra/feature.txt --
+				feature
+				-- extra/main.txt --
+				main
+			`)))
+			require.NoError(t, err)
+			t.Cleanup(fixture.Cleanup)
+
+			login(t, "user")
+
+			ctx := t.Context()
+			wt, err := git.OpenWorktree(ctx, fixture.Dir(), git.OpenOptions{
+				Log: silogtest.New(t),
+			})
+			require.NoError(t, err)
+
+			err = wt.Rebase(ctx, git.RebaseRequest{
+				Branch:   "feature",
+				Upstream: "main",
+				Quiet:    true,
+			})
+			var rebaseErr *git.RebaseInterruptError
+			require.True(t, errors.As(err, &rebaseErr), "expected rebase interrupt, got %v", err)
+			assert.Equal(t, git.RebaseInterruptConflict, rebaseErr.Kind)
+
+			for _, path := range []string{"foo.txt", "gone.txt"} {
+				require.NoError(t, wt.ResolveConflict(ctx, path, tt.side))
+			}
+
+			got, err := os.ReadFile(filepath.Join(fixture.Dir(), "foo.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+
+			_, err = os.Stat(filepath.Join(fixture.Dir(), "gone.txt"))
+			if tt.side == git.ConflictTheirs {
+				assert.ErrorIs(t, err, os.ErrNotExist, "feature deleted gone.txt")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NoError(t, wt.RebaseContinue(ctx, &git.RebaseContinueOptions{
+				Editor: "true",
+			}))
+		})
+	}
+}
+
+func TestWorktree_Mergetool(t *testing.T) {
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2024-05-21T20:30:40Z'
+
+		git init
+		git add foo.txt
+		git commit -m 'Initial commit'
+
+		git checkout -b feature
+		cp $WORK/extra/feature.txt foo.txt
+		git add foo.txt
+		git commit -m 'Change foo on feature'
+
+		git checkout main
+		cp $WORK/extra/main.txt foo.txt
+		git add foo.txt
+		git commit -m 'Change foo on main'
+
+		-- foo.txt --
+		initial
+		-- extra/feature.txt --
+		feature
+		-- extra/main.txt --
+		main
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	home := login(t, "user")
+
+	// A merge tool that takes the version being applied.
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(text.Dedent(`
+		[merge]
+			tool = take-theirs
+		[mergetool]
+			keepBackup = false
+		[mergetool "take-theirs"]
+			cmd = cp "$REMOTE" "$MERGED"
+			trustExitCode = true
+	`)), 0o644))
+
+	ctx := t.Context()
+	wt, err := git.OpenWorktree(ctx, fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	err = wt.Rebase(ctx, git.RebaseRequest{
+		Branch:   "feature",
+		Upstream: "main",
+		Quiet:    true,
+	})
+	var rebaseErr *git.RebaseInterruptError
+	require.True(t, errors.As(err, &rebaseErr), "expected rebase interrupt, got %v", err)
+
+	require.NoError(t, wt.Mergetool(ctx, "foo.txt"))
+
+	got, err := os.ReadFile(filepath.Join(fixture.Dir(), "foo.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "feature\n", string(got))
+
+	for path, err := range wt.ListFilesPaths(ctx, &git.ListFilesOptions{Unmerged: true}) {
+		require.NoError(t, err)
+		t.Errorf("unexpected unmerged file: %v", path)
+	}
+}
+
+func TestRepository_EnableRerere(t *testing.T) {
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2024-05-21T20:30:40Z'
+
+		git init
+		git commit --allow-empty -m 'Initial commit'
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	login(t, "user")
+
+	ctx := t.Context()
+	repo, err := git.Open(ctx, fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	assert.False(t, repo.RerereEnabled(ctx))
+	require.NoError(t, repo.EnableRerere(ctx))
+	assert.True(t, repo.RerereEnabled(ctx))
+}
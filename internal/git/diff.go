@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"iter"
+	"strconv"
 
 	"go.abhg.dev/gs/internal/scanutil"
 	"go.abhg.dev/gs/internal/silog"
@@ -131,6 +132,59 @@ func (r *Repository) DiffTree(ctx context.Context, treeish1, treeish2 string) it
 	}
 }
 
+// DiffStat summarizes the size of a diff.
+type DiffStat struct {
+	// Additions is the number of lines added.
+	Additions int
+
+	// Deletions is the number of lines deleted.
+	Deletions int
+
+	// ChangedFiles is the number of files changed.
+	ChangedFiles int
+}
+
+// DiffStat reports the size of the changes made on head
+// since it diverged from base.
+// This is the diff that a change request would show
+// for merging head into base.
+//
+// Binary files count as changed files
+// but don't contribute any additions or deletions.
+func (r *Repository) DiffStat(ctx context.Context, base, head string) (DiffStat, error) {
+	var stat DiffStat
+	cmd := r.gitCmd(ctx, "diff", "--numstat", "-z", base+"..."+head, "--")
+	for line, err := range cmd.Scan(scanutil.SplitNull) {
+		if err != nil {
+			return DiffStat{}, fmt.Errorf("git diff: %w", err)
+		}
+
+		// Each entry is "<added>\t<deleted>\t<path>".
+		// Renames are "<added>\t<deleted>\t" followed by
+		// two NUL-terminated paths, which we'll skip over
+		// as they don't contain tabs.
+		added, rest, ok := bytes.Cut(line, []byte{'\t'})
+		if !ok {
+			continue
+		}
+		deleted, _, ok := bytes.Cut(rest, []byte{'\t'})
+		if !ok {
+			continue
+		}
+
+		stat.ChangedFiles++
+		// Binary files report "-" for both counts.
+		if n, err := strconv.Atoi(string(added)); err == nil {
+			stat.Additions += n
+		}
+		if n, err := strconv.Atoi(string(deleted)); err == nil {
+			stat.Deletions += n
+		}
+	}
+
+	return stat, nil
+}
+
 func parseDiffFileStatuses(r io.Reader, log *silog.Logger) ([]FileStatus, error) {
 	var files []FileStatus
 	scanner := bufio.NewScanner(r)
@@ -364,3 +364,67 @@ func TestRepository_DiffTree(t *testing.T) {
 		assert.ElementsMatch(t, expected, files)
 	})
 }
+
+func TestRepository_DiffStat(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2025-06-21T10:00:00Z'
+
+		git init
+		git add will-modify.txt
+		git add will-delete.txt
+		git add will-rename.txt
+		git commit -m 'Initial commit'
+
+		git checkout -b feature
+		cp $WORK/extra/modified.txt will-modify.txt
+		git add will-modify.txt
+		git rm will-delete.txt
+		git mv will-rename.txt renamed.txt
+		git add new-file.txt
+		git commit -m 'Feature changes'
+
+		git checkout main
+		git add another-file.txt
+		git commit -m 'Main changes'
+
+		-- will-modify.txt --
+		foo
+		bar
+		-- will-delete.txt --
+		will be deleted
+		-- will-rename.txt --
+		will be renamed
+		-- new-file.txt --
+		new in feature
+		also new in feature
+		-- another-file.txt --
+		new in main
+		-- extra/modified.txt --
+		foo
+		baz
+		qux
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	repo, err := git.Open(t.Context(), fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	// Changes on main since feature diverged are not included.
+	stat, err := repo.DiffStat(t.Context(), "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, git.DiffStat{
+		Additions:    4, // baz, qux, and two lines in new-file.txt
+		Deletions:    2, // bar and will-delete.txt
+		ChangedFiles: 4,
+	}, stat)
+
+	stat, err = repo.DiffStat(t.Context(), "main", "main")
+	require.NoError(t, err)
+	assert.Equal(t, git.DiffStat{}, stat)
+}
@@ -38,7 +38,8 @@ func (r *Repository) Fetch(ctx context.Context, opts FetchOptions) error {
 		args = append(args, refspec.String())
 	}
 
-	if err := r.gitCmd(ctx, args...).Run(); err != nil {
+	cmd := r.gitCmd(ctx, args...).WithTimeout(_networkTimeout)
+	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("fetch: %w", err)
 	}
 
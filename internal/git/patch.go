@@ -0,0 +1,154 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/must"
+)
+
+// FormatPatchRequest is a request to format commits as patches
+// suitable for submission by email.
+type FormatPatchRequest struct {
+	// Base and Head specify the commits to format:
+	// those reachable from Head but not from Base.
+	Base, Head string // required
+
+	// CoverLetter adds a cover letter before the patches
+	// with a summary of the commits.
+	//
+	// Its subject and body are placeholders
+	// that the caller is expected to fill in:
+	// "*** SUBJECT HERE ***" and "*** BLURB HERE ***".
+	CoverLetter bool
+
+	// SubjectPrefix replaces "PATCH" in the subject lines of the patches.
+	SubjectPrefix string
+}
+
+// FormatPatch writes the commits in the given range
+// to w as a series of patches in mbox format,
+// oldest first.
+// Nothing is written if there are no commits in the range.
+func (r *Repository) FormatPatch(ctx context.Context, req *FormatPatchRequest, w io.Writer) error {
+	must.NotBeBlankf(req.Base, "base must not be blank")
+	must.NotBeBlankf(req.Head, "head must not be blank")
+
+	args := []string{"format-patch", "--stdout"}
+	if req.CoverLetter {
+		args = append(args, "--cover-letter")
+	}
+	if req.SubjectPrefix != "" {
+		args = append(args, "--subject-prefix="+req.SubjectPrefix)
+	}
+	args = append(args, req.Base+".."+req.Head, "--")
+
+	if err := r.gitCmd(ctx, args...).WithStdout(w).Run(); err != nil {
+		return fmt.Errorf("git format-patch: %w", err)
+	}
+	return nil
+}
+
+// SplitMailbox splits the given mailboxes into one file per message
+// in dir, and returns the paths to those files in order.
+// Mailboxes may be mbox files or Maildir directories.
+func (r *Repository) SplitMailbox(ctx context.Context, dir string, mailboxes ...string) ([]string, error) {
+	must.NotBeEmptyf(mailboxes, "at least one mailbox is required")
+
+	args := []string{"mailsplit", "-o" + dir, "--"}
+	args = append(args, mailboxes...)
+	if err := r.gitCmd(ctx, args...).WithStdout(io.Discard).Run(); err != nil {
+		return nil, fmt.Errorf("git mailsplit: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read split messages: %w", err)
+	}
+
+	// Messages are numbered with zero-padding,
+	// so lexical order is message order.
+	paths := make([]string, 0, len(entries))
+	for _, ent := range entries {
+		if ent.Type().IsRegular() {
+			paths = append(paths, filepath.Join(dir, ent.Name()))
+		}
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// MailInfo is information extracted from a patch email
+// by [Repository.ReadMailInfo].
+type MailInfo struct {
+	// Subject is the subject of the email,
+	// including prefixes like "[PATCH 1/2]".
+	Subject string
+
+	// Author and Email identify the author of the patch.
+	Author, Email string
+
+	// Message is the body of the email preceding the patch.
+	Message string
+
+	// Patch is the diff in the email.
+	// It is empty for emails without a patch, e.g. cover letters.
+	Patch string
+}
+
+// ReadMailInfo extracts information from the email at the given path.
+func (r *Repository) ReadMailInfo(ctx context.Context, path string) (_ *MailInfo, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open message: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// git-mailinfo writes the message and the patch to files.
+	tmpDir, err := os.MkdirTemp("", "gs-mailinfo-")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	msgPath, patchPath := filepath.Join(tmpDir, "msg"), filepath.Join(tmpDir, "patch")
+
+	out, err := r.gitCmd(ctx, "mailinfo", "-k", msgPath, patchPath).
+		WithStdin(f).
+		Output()
+	if err != nil {
+		return nil, fmt.Errorf("git mailinfo: %w", err)
+	}
+
+	var info MailInfo
+	for line := range strings.Lines(string(out)) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Subject":
+			info.Subject = value
+		case "Author":
+			info.Author = value
+		case "Email":
+			info.Email = value
+		}
+	}
+
+	msg, err := os.ReadFile(msgPath)
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+	patch, err := os.ReadFile(patchPath)
+	if err != nil {
+		return nil, fmt.Errorf("read patch: %w", err)
+	}
+	info.Message = string(msg)
+	info.Patch = string(patch)
+	return &info, nil
+}
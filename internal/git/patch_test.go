@@ -0,0 +1,167 @@
+package git_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/git/gittest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/sliceutil"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestFormatPatch(t *testing.T) {
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2024-09-14T15:55:40Z'
+
+		git init
+		git commit --allow-empty -m 'Initial commit'
+		git checkout -b feat
+		git add a.txt
+		git commit -m 'Add a'
+		git add b.txt
+		git commit -m 'Add b'
+
+		-- a.txt --
+		a
+		-- b.txt --
+		b
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	repo, err := git.Open(t.Context(), fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+	ctx := t.Context()
+
+	t.Run("Patches", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, repo.FormatPatch(ctx, &git.FormatPatchRequest{
+			Base: "main",
+			Head: "feat",
+		}, &buf))
+
+		out := buf.String()
+		assert.Contains(t, out, "Subject: [PATCH 1/2] Add a\n")
+		assert.Contains(t, out, "Subject: [PATCH 2/2] Add b\n")
+		assert.Less(t, bytes.Index(buf.Bytes(), []byte("Add a")), bytes.Index(buf.Bytes(), []byte("Add b")),
+			"patches must be ordered oldest first")
+		assert.NotContains(t, out, "*** SUBJECT HERE ***")
+	})
+
+	t.Run("CoverLetter", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, repo.FormatPatch(ctx, &git.FormatPatchRequest{
+			Base:          "main",
+			Head:          "feat",
+			CoverLetter:   true,
+			SubjectPrefix: "PATCH v2",
+		}, &buf))
+
+		out := buf.String()
+		assert.Contains(t, out, "Subject: [PATCH v2 0/2] *** SUBJECT HERE ***\n")
+		assert.Contains(t, out, "*** BLURB HERE ***")
+		assert.Contains(t, out, "Subject: [PATCH v2 1/2] Add a\n")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, repo.FormatPatch(ctx, &git.FormatPatchRequest{
+			Base:        "feat",
+			Head:        "feat",
+			CoverLetter: true,
+		}, &buf))
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestMailboxRoundTrip(t *testing.T) {
+	t.Setenv("GIT_COMMITTER_NAME", "Test Committer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "committer@example.com")
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2024-09-14T15:55:40Z'
+
+		git init
+		git commit --allow-empty -m 'Initial commit'
+		git checkout -b feat
+		git add a.txt
+		git commit -m 'Add a' -m 'Details about a.'
+		git add b.txt
+		git commit -m 'Add b'
+		git checkout main
+
+		-- a.txt --
+		a
+		-- b.txt --
+		b
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	ctx := t.Context()
+	wt, err := git.OpenWorktree(ctx, fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+	repo := wt.Repository()
+
+	var mbox bytes.Buffer
+	require.NoError(t, repo.FormatPatch(ctx, &git.FormatPatchRequest{
+		Base:        "main",
+		Head:        "feat",
+		CoverLetter: true,
+	}, &mbox))
+	mboxPath := filepath.Join(t.TempDir(), "series.mbox")
+	require.NoError(t, os.WriteFile(mboxPath, mbox.Bytes(), 0o644))
+
+	paths, err := repo.SplitMailbox(ctx, t.TempDir(), mboxPath)
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+
+	infos := make([]*git.MailInfo, len(paths))
+	for idx, path := range paths {
+		infos[idx], err = repo.ReadMailInfo(ctx, path)
+		require.NoError(t, err)
+	}
+
+	t.Run("CoverLetter", func(t *testing.T) {
+		assert.Equal(t, "[PATCH 0/2] *** SUBJECT HERE ***", infos[0].Subject)
+		assert.Contains(t, infos[0].Message, "*** BLURB HERE ***")
+		assert.Empty(t, infos[0].Patch)
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		assert.Equal(t, "[PATCH 1/2] Add a", infos[1].Subject)
+		assert.Equal(t, "Test", infos[1].Author)
+		assert.Equal(t, "test@example.com", infos[1].Email)
+		assert.Equal(t, "Details about a.\n", infos[1].Message)
+		assert.Contains(t, infos[1].Patch, "+++ b/a.txt")
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		require.NoError(t, wt.ApplyMailbox(ctx, paths[1:]...))
+
+		subjects, err := sliceutil.CollectErr(repo.ListCommitsDetails(ctx,
+			git.CommitRangeFrom("HEAD").ExcludeFrom("feat~2").Reverse()))
+		require.NoError(t, err)
+		require.Len(t, subjects, 2)
+		assert.Equal(t, "Add a", subjects[0].Subject)
+		assert.Equal(t, "Add b", subjects[1].Subject)
+
+		headTree, err := repo.PeelToTree(ctx, "HEAD")
+		require.NoError(t, err)
+		featTree, err := repo.PeelToTree(ctx, "feat")
+		require.NoError(t, err)
+		assert.Equal(t, featTree, headTree)
+	})
+}
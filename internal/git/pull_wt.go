@@ -39,7 +39,8 @@ func (w *Worktree) Pull(ctx context.Context, opts PullOptions) error {
 		args = append(args, opts.Refspec.String())
 	}
 
-	if err := w.gitCmd(ctx, args...).Run(); err != nil {
+	cmd := w.gitCmd(ctx, args...).WithTimeout(_networkTimeout)
+	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("git pull: %w", err)
 	}
 
@@ -63,6 +63,8 @@ func (w *Worktree) Push(ctx context.Context, opts PushOptions) error {
 		args = append(args, opts.Refspec.String())
 	}
 
+	// Unlike other network operations, push has no timeout
+	// because it runs pre-push hooks, which may take arbitrarily long.
 	cmd := w.gitCmd(ctx, args...).CaptureStdout()
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("push: %w", err)
@@ -90,6 +90,12 @@ type RebaseRequest struct {
 	// with a list of rebase instructions to edit
 	// before starting the rebase operation.
 	Interactive bool
+
+	// GPGSign is true if rewritten commits should be signed.
+	//
+	// If false, commits are signed only if the user's Git configuration
+	// (e.g. commit.gpgSign) asks for it.
+	GPGSign bool
 }
 
 // Rebase runs a git rebase operation with the specified parameters.
@@ -142,6 +148,10 @@ func (w *Worktree) Rebase(ctx context.Context, req RebaseRequest) (err error) {
 	if req.Quiet {
 		args = append(args, "--quiet")
 	}
+	if req.GPGSign {
+		// git remembers this for 'rebase --continue'.
+		args = append(args, "--gpg-sign")
+	}
 	if req.Upstream != "" {
 		args = append(args, req.Upstream)
 	}
@@ -1,7 +1,10 @@
 package git
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"iter"
 	"strings"
 
 	"go.abhg.dev/gs/internal/silog"
@@ -97,3 +100,38 @@ func (r *Repository) SetRef(ctx context.Context, req SetRefRequest) error {
 	}
 	return r.gitCmd(ctx, args...).Run()
 }
+
+// Ref is a ref in the repository and the object it points to.
+type Ref struct {
+	// Name is the fully qualified name of the ref,
+	// e.g. "refs/heads/main".
+	Name string
+
+	// Hash is the object the ref points to.
+	// This is not always a commit.
+	Hash Hash
+}
+
+// ListRefs returns an iterator over refs in the repository
+// that start with the given prefix, e.g. "refs/remotes/origin/".
+// Refs are listed in lexicographic order.
+func (r *Repository) ListRefs(ctx context.Context, prefix string) iter.Seq2[Ref, error] {
+	return func(yield func(Ref, error) bool) {
+		cmd := r.gitCmd(ctx, "for-each-ref", "--format=%(objectname) %(refname)", prefix)
+		for bs, err := range cmd.Lines() {
+			if err != nil {
+				yield(Ref{}, fmt.Errorf("git for-each-ref: %w", err))
+				return
+			}
+
+			hash, name, ok := bytes.Cut(bytes.TrimSpace(bs), []byte{' '})
+			if !ok {
+				continue
+			}
+
+			if !yield(Ref{Name: string(name), Hash: Hash(hash)}, nil) {
+				return
+			}
+		}
+	}
+}
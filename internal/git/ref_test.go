@@ -296,3 +296,41 @@ func TestSetRef(t *testing.T) {
 		assert.NotEqual(t, feat1Hash, branchHead)
 	})
 }
+
+func TestListRefs(t *testing.T) {
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2024-09-14T15:55:40Z'
+
+		git init
+		git commit --allow-empty -m 'Initial commit'
+		git branch feat1
+		git branch feat2
+		git update-ref refs/custom/one HEAD
+		git update-ref refs/custom/nested/two HEAD
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	repo, err := git.Open(t.Context(), fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	head, err := repo.PeelToCommit(ctx, "HEAD")
+	require.NoError(t, err)
+
+	refs, err := sliceutil.CollectErr(repo.ListRefs(ctx, "refs/custom/"))
+	require.NoError(t, err)
+	assert.Equal(t, []git.Ref{
+		{Name: "refs/custom/nested/two", Hash: head},
+		{Name: "refs/custom/one", Hash: head},
+	}, refs)
+
+	t.Run("NoMatches", func(t *testing.T) {
+		refs, err := sliceutil.CollectErr(repo.ListRefs(ctx, "refs/nope/"))
+		require.NoError(t, err)
+		assert.Empty(t, refs)
+	})
+}
@@ -122,7 +122,7 @@ func (r *Repository) ListRemoteRefs(
 	args = append(args, opts.Patterns...)
 
 	return func(yield func(RemoteRef, error) bool) {
-		cmd := r.gitCmd(ctx, args...)
+		cmd := r.gitCmd(ctx, args...).WithTimeout(_networkTimeout)
 
 		for bs, err := range cmd.Lines() {
 			if err != nil {
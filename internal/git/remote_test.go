@@ -78,6 +78,9 @@ func TestRepositoryListRemoteRefsOptions(t *testing.T) {
 	mockExecer.EXPECT().
 		Kill(gomock.Any()).
 		Return(nil)
+	mockExecer.EXPECT().
+		Wait(gomock.Any()).
+		Return(nil)
 
 	opts := git.ListRemoteRefsOptions{
 		Heads:    true,
@@ -141,7 +141,8 @@ func Clone(ctx context.Context, url, dir string, opts CloneOptions) (*Worktree,
 	if opts.Log != nil {
 		opts.Log.Debug("Cloning repository", "url", url, "destination", dir)
 	}
-	cloneCmd := newGitCmd(ctx, opts.Log, opts.exec, "clone", url, dir)
+	cloneCmd := newGitCmd(ctx, opts.Log, opts.exec, "clone", url, dir).
+		WithTimeout(_networkTimeout)
 	if err := cloneCmd.Run(); err != nil {
 		return nil, fmt.Errorf("git clone: %w", err)
 	}
@@ -166,6 +167,13 @@ func newRepository(gitDir string, log *silog.Logger, exec execer) *Repository {
 	}
 }
 
+// GitDir returns the absolute path to the repository's .git directory.
+// For repositories with multiple worktrees,
+// this is the directory shared by all worktrees.
+func (r *Repository) GitDir() string {
+	return r.gitDir
+}
+
 // WithLogger returns a copy of the repository
 // that will use the given logger.
 func (r *Repository) WithLogger(log *silog.Logger) *Repository {
@@ -179,3 +187,13 @@ func (r *Repository) WithLogger(log *silog.Logger) *Repository {
 func (r *Repository) gitCmd(ctx context.Context, args ...string) *xec.Cmd {
 	return newGitCmd(ctx, r.log, r.exec, args...).WithDir(r.gitDir)
 }
+
+// IsShallow reports whether the repository is a shallow clone,
+// i.e. one with incomplete history.
+func (r *Repository) IsShallow(ctx context.Context) (bool, error) {
+	out, err := r.gitCmd(ctx, "rev-parse", "--is-shallow-repository").OutputChomp()
+	if err != nil {
+		return false, fmt.Errorf("rev-parse: %w", err)
+	}
+	return out == "true", nil
+}
@@ -73,6 +73,41 @@ func TestOpenWorktree_correctGitDirectory(t *testing.T) {
 		"worktrees should have different git directories")
 }
 
+func TestRepository_IsShallow(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test <test@example.com>'
+		at '2025-06-26T21:28:29Z'
+
+		mkdir repo
+		cd repo
+		git init
+		git commit --allow-empty -m 'Initial commit'
+		git commit --allow-empty -m 'Second commit'
+
+		cd ..
+		git clone --depth 1 file://$WORK/repo shallow
+	`)))
+	require.NoError(t, err)
+	dir := fixture.Dir()
+
+	ctx := t.Context()
+	for name, want := range map[string]bool{
+		"repo":    false,
+		"shallow": true,
+	} {
+		repo, err := Open(ctx, filepath.Join(dir, name), OpenOptions{
+			Log: silogtest.New(t),
+		})
+		require.NoError(t, err)
+
+		got, err := repo.IsShallow(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+}
+
 func TestExtraConfig_Args(t *testing.T) {
 	tests := []struct {
 		name string
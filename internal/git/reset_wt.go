@@ -30,6 +30,11 @@ const (
 	// ResetSoft resets HEAD to the specified commit,
 	// leaving the index and working tree unchanged.
 	ResetSoft
+
+	// ResetKeep resets HEAD, the index, and the working tree
+	// to the specified commit, keeping local changes.
+	// It fails if local changes conflict with the reset.
+	ResetKeep
 )
 
 func (m ResetMode) String() string {
@@ -40,6 +45,8 @@ func (m ResetMode) String() string {
 		return "hard"
 	case ResetSoft:
 		return "soft"
+	case ResetKeep:
+		return "keep"
 	case ResetModeUnset:
 		return "unset"
 	default:
@@ -82,6 +89,8 @@ func (w *Worktree) Reset(ctx context.Context, commit string, opts ResetOptions)
 		args = append(args, "--hard")
 	case ResetSoft:
 		args = append(args, "--soft")
+	case ResetKeep:
+		args = append(args, "--keep")
 	default:
 		must.Failf("unknown reset mode: %d", opts.Mode)
 	}
@@ -1,9 +1,13 @@
 package git
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"iter"
+
+	"go.abhg.dev/gs/internal/scanutil"
 )
 
 // ErrNoChanges is returned when there are no changes to stash.
@@ -60,3 +64,104 @@ func (w *Worktree) StashApply(ctx context.Context, stash string) error {
 
 	return nil
 }
+
+// StashPushOptions specifies options for StashPush.
+type StashPushOptions struct {
+	// Message is the message to record with the stash entry.
+	Message string
+
+	// IncludeUntracked includes untracked files in the stash,
+	// removing them from the working tree.
+	IncludeUntracked bool
+
+	// KeepIndex leaves changes already added to the index intact.
+	KeepIndex bool
+}
+
+// StashPush stashes local changes, stores them in the stash reflog,
+// and resets the working tree to match HEAD.
+// It returns the hash of the new stash entry.
+// Returns ErrNoChanges if there are no changes to stash.
+func (w *Worktree) StashPush(ctx context.Context, opts *StashPushOptions) (Hash, error) {
+	if opts == nil {
+		opts = &StashPushOptions{}
+	}
+
+	args := []string{"stash", "push"}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if opts.Message != "" {
+		args = append(args, "-m", opts.Message)
+	}
+
+	// 'git stash push' exits with a zero status
+	// even if there are no changes to stash.
+	// Compare the top of the stash before and after to tell.
+	before, _ := w.repo.revParse(ctx, "refs/stash")
+	if err := w.gitCmd(ctx, args...).CaptureStdout().Run(); err != nil {
+		return ZeroHash, fmt.Errorf("stash push: %w", err)
+	}
+
+	after, err := w.repo.revParse(ctx, "refs/stash")
+	if err != nil || after == before {
+		return ZeroHash, ErrNoChanges
+	}
+	return after, nil
+}
+
+// StashEntry is an entry in the stash reflog.
+type StashEntry struct {
+	// Name is the reflog name of the stash, e.g. "stash@{0}".
+	//
+	// This changes as new stashes are pushed or dropped.
+	Name string
+
+	// Hash is the hash of the stash commit.
+	Hash Hash
+
+	// Message is the message recorded with the stash.
+	Message string
+}
+
+// StashList returns an iterator over entries in the stash reflog,
+// most recent first.
+func (w *Worktree) StashList(ctx context.Context) iter.Seq2[StashEntry, error] {
+	return func(yield func(StashEntry, error) bool) {
+		cmd := w.gitCmd(ctx, "stash", "list", "-z", "--format=%gd%x00%H%x00%gs")
+		var fields [][]byte
+		for tok, err := range cmd.Scan(scanutil.SplitNull) {
+			if err != nil {
+				yield(StashEntry{}, fmt.Errorf("git stash list: %w", err))
+				return
+			}
+
+			fields = append(fields, bytes.Clone(tok))
+			if len(fields) < 3 {
+				continue
+			}
+
+			entry := StashEntry{
+				Name:    string(bytes.TrimSpace(fields[0])),
+				Hash:    Hash(fields[1]),
+				Message: string(fields[2]),
+			}
+			fields = fields[:0]
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// StashDrop removes an entry from the stash reflog.
+// stash is the reflog name of the entry, e.g. "stash@{0}".
+func (w *Worktree) StashDrop(ctx context.Context, stash string) error {
+	if err := w.gitCmd(ctx, "stash", "drop", stash).CaptureStdout().Run(); err != nil {
+		return fmt.Errorf("stash drop: %w", err)
+	}
+	return nil
+}
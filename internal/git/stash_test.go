@@ -297,3 +297,70 @@ func TestRepository_StashIntegration(t *testing.T) {
 		assert.Equal(t, git.ZeroHash, hash)
 	})
 }
+
+func TestWorktree_StashPush(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		as 'Test User <test@example.com>'
+		at '2025-08-23T06:07:08Z'
+
+		git init
+		git add tracked.txt
+		git commit -m 'Initial commit'
+
+		mv tracked.new.txt tracked.txt
+
+		-- tracked.txt --
+		original content
+		-- tracked.new.txt --
+		modified
+		-- untracked.txt --
+		untracked content
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	ctx := t.Context()
+	wt, err := git.OpenWorktree(ctx, fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	hash, err := wt.StashPush(ctx, &git.StashPushOptions{
+		Message:          "push with untracked",
+		IncludeUntracked: true,
+	})
+	require.NoError(t, err)
+	assert.Len(t, hash.String(), 40)
+	assert.NoFileExists(t, fixture.Dir()+"/untracked.txt")
+
+	var entries []git.StashEntry
+	for entry, err := range wt.StashList(ctx) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	assert.Equal(t, []git.StashEntry{
+		{
+			Name:    "stash@{0}",
+			Hash:    hash,
+			Message: "On main: push with untracked",
+		},
+	}, entries)
+
+	t.Run("NoChanges", func(t *testing.T) {
+		_, err := wt.StashPush(ctx, &git.StashPushOptions{
+			IncludeUntracked: true,
+		})
+		assert.ErrorIs(t, err, git.ErrNoChanges)
+	})
+
+	require.NoError(t, wt.StashApply(ctx, "stash@{0}"))
+	assert.FileExists(t, fixture.Dir()+"/untracked.txt")
+
+	require.NoError(t, wt.StashDrop(ctx, "stash@{0}"))
+	for _, err := range wt.StashList(ctx) {
+		require.NoError(t, err)
+		t.Fatal("stash list should be empty")
+	}
+}
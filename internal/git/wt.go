@@ -139,3 +139,51 @@ func (r *Repository) Worktrees(ctx context.Context) iter.Seq2[*WorktreeListItem,
 		}
 	}
 }
+
+// AddWorktreeRequest is a request to add a new worktree.
+type AddWorktreeRequest struct {
+	// Path is the directory at which the worktree will be created.
+	// It must not exist or be an empty directory.
+	Path string // required
+
+	// Commitish is the commit to check out in the new worktree.
+	// The worktree is always created in detached HEAD state,
+	// so this may refer to a branch checked out elsewhere.
+	Commitish string // required
+}
+
+// AddWorktree creates a new worktree for the repository
+// with the requested commit checked out in detached HEAD state.
+func (r *Repository) AddWorktree(ctx context.Context, req *AddWorktreeRequest) (*Worktree, error) {
+	if err := r.gitCmd(ctx,
+		"worktree", "add", "--detach", "--quiet", req.Path, req.Commitish,
+	).Run(); err != nil {
+		return nil, fmt.Errorf("git worktree add: %w", err)
+	}
+
+	return r.OpenWorktree(ctx, req.Path)
+}
+
+// RemoveWorktreeOptions specifies options for removing a worktree.
+type RemoveWorktreeOptions struct {
+	// Force removes the worktree even if it has local modifications
+	// or untracked files.
+	Force bool
+}
+
+// RemoveWorktree removes the worktree at the given path.
+func (r *Repository) RemoveWorktree(ctx context.Context, path string, opts *RemoveWorktreeOptions) error {
+	if opts == nil {
+		opts = &RemoveWorktreeOptions{}
+	}
+
+	args := []string{"worktree", "remove"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	args = append(args, path)
+	if err := r.gitCmd(ctx, args...).Run(); err != nil {
+		return fmt.Errorf("git worktree remove: %w", err)
+	}
+	return nil
+}
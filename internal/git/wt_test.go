@@ -91,3 +91,54 @@ func TestIntegrationWorktrees(t *testing.T) {
 		},
 	}, worktrees)
 }
+
+func TestRepository_AddWorktree(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := gittest.LoadFixtureScript([]byte(text.Dedent(`
+		at '2024-08-27T21:48:32Z'
+		git init
+		git add init.txt
+		git commit -m 'Initial commit'
+
+		git checkout -b feature
+		git add feature.txt
+		git commit -m 'Add feature'
+
+		-- init.txt --
+		Initial
+
+		-- feature.txt --
+		Contents of feature
+
+	`)))
+	require.NoError(t, err)
+	t.Cleanup(fixture.Cleanup)
+
+	ctx := t.Context()
+	repo, err := git.Open(ctx, fixture.Dir(), git.OpenOptions{
+		Log: silogtest.New(t),
+	})
+	require.NoError(t, err)
+
+	wtDir := filepath.Join(t.TempDir(), "wt")
+
+	// feature is checked out in the main worktree.
+	// The new worktree must still be able to check it out.
+	wt, err := repo.AddWorktree(ctx, &git.AddWorktreeRequest{
+		Path:      wtDir,
+		Commitish: "feature",
+	})
+	require.NoError(t, err)
+
+	wantDir, err := filepath.EvalSymlinks(wtDir)
+	require.NoError(t, err)
+	assert.Equal(t, wantDir, wt.RootDir())
+
+	_, err = wt.CurrentBranch(ctx)
+	assert.ErrorIs(t, err, git.ErrDetachedHead)
+	assert.FileExists(t, filepath.Join(wtDir, "feature.txt"))
+
+	require.NoError(t, repo.RemoveWorktree(ctx, wtDir, nil))
+	assert.NoDirExists(t, wtDir)
+}
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/tidwall/gjson"
 	"go.abhg.dev/gs/internal/must"
@@ -21,6 +23,7 @@ var (
 	ErrNotFound      = errors.New("not found")
 	ErrForbidden     = errors.New("forbidden")
 	ErrUnprocessable = errors.New("unprocessable")
+	ErrRateLimited   = errors.New("rate limited")
 )
 
 // graphQLTransport wraps an HTTP transport
@@ -43,6 +46,7 @@ var _ http.RoundTripper = (*graphQLTransport)(nil)
 //
 // The transport will now return errors that may be cast to
 // [Errors] or [Error] with errors.As.
+// Requests rejected because of a rate limit fail with a [RateLimitError].
 func WrapTransport(t http.RoundTripper) http.RoundTripper {
 	if t == nil {
 		t = http.DefaultTransport
@@ -53,10 +57,19 @@ func WrapTransport(t http.RoundTripper) http.RoundTripper {
 // RoundTrip handles a single HTTP round trip.
 func (t *graphQLTransport) RoundTrip(r *http.Request) (res *http.Response, err error) {
 	res, err = t.t.RoundTrip(r)
-	if err != nil || res.StatusCode != http.StatusOK {
+	if err != nil {
 		return res, err
 	}
 
+	switch res.StatusCode {
+	case http.StatusOK:
+		// Handled below.
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return checkRateLimit(res)
+	default:
+		return res, nil
+	}
+
 	buff := takeBuffer()
 	defer func() {
 		// If there was an error,
@@ -105,6 +118,92 @@ func (t *graphQLTransport) RoundTrip(r *http.Request) (res *http.Response, err e
 	return nil, gqlErrs
 }
 
+// _maxRateLimitBody is the maximum number of bytes
+// read from a response to check if it was rate limited.
+const _maxRateLimitBody = 64 << 10
+
+// checkRateLimit checks if a 403 or 429 response
+// reports that the client exceeded a rate limit,
+// returning a [RateLimitError] if so.
+//
+// GitHub reports secondary rate limits with a 403 response
+// that has a Retry-After header or mentions the limit in the body.
+// Other responses are returned unchanged.
+func checkRateLimit(res *http.Response) (*http.Response, error) {
+	body, readErr := io.ReadAll(io.LimitReader(res.Body, _maxRateLimitBody))
+	closeErr := res.Body.Close()
+	if err := errors.Join(readErr, closeErr); err != nil {
+		return nil, err
+	}
+
+	limited := res.StatusCode == http.StatusTooManyRequests ||
+		res.Header.Get("Retry-After") != "" ||
+		res.Header.Get("X-RateLimit-Remaining") == "0" ||
+		bytes.Contains(bytes.ToLower(body), []byte("rate limit"))
+	if !limited {
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		return res, nil
+	}
+
+	return nil, &RateLimitError{
+		StatusCode: res.StatusCode,
+		RetryAfter: retryAfter(res.Header, time.Now()),
+		Message:    strings.TrimSpace(gjson.GetBytes(body, "message").String()),
+	}
+}
+
+// retryAfter reports how long the server asked the client to wait
+// based on the Retry-After or X-RateLimit-Reset headers.
+// It returns zero if neither header is set.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if d := time.Unix(reset, 0).Sub(now); d > 0 {
+				return d
+			}
+		}
+	}
+
+	return 0
+}
+
+// RateLimitError indicates that a request was rejected
+// because the client exceeded a primary or secondary rate limit.
+// It matches [ErrRateLimited] with errors.Is.
+type RateLimitError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// RetryAfter is how long the server asked the client to wait
+	// before trying again.
+	// It is zero if the server didn't say.
+	RetryAfter time.Duration
+
+	// Message is the error message reported by the server, if any.
+	Message string
+}
+
+// Is reports whether target is [ErrRateLimited].
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+func (e *RateLimitError) Error() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "rate limited (status %d)", e.StatusCode)
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&s, ", retry after %v", e.RetryAfter)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&s, ": %s", e.Message)
+	}
+	return s.String()
+}
+
 // Errors is a list of GraphQL errors.
 type Errors []*Error
 
@@ -145,6 +244,8 @@ func (e *Error) Is(target error) bool {
 		return e.Type == "FORBIDDEN"
 	case ErrUnprocessable:
 		return e.Type == "UNPROCESSABLE"
+	case ErrRateLimited:
+		return e.Type == "RATE_LIMITED"
 	default:
 		return false
 	}
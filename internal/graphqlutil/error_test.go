@@ -7,6 +7,7 @@ import (
 	"sync"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -51,6 +52,84 @@ func TestResponseStatusCode(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
 }
 
+func TestRateLimitResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+
+		wantRetryAfter time.Duration
+		wantMessage    string
+	}{
+		{
+			name:           "SecondaryRateLimit",
+			status:         http.StatusForbidden,
+			header:         map[string]string{"Retry-After": "30"},
+			body:           `{"message": "You have exceeded a secondary rate limit."}`,
+			wantRetryAfter: 30 * time.Second,
+			wantMessage:    "You have exceeded a secondary rate limit.",
+		},
+		{
+			name:        "SecondaryRateLimitNoHeader",
+			status:      http.StatusForbidden,
+			body:        `{"message": "You have exceeded a secondary rate limit."}`,
+			wantMessage: "You have exceeded a secondary rate limit.",
+		},
+		{
+			name:   "TooManyRequests",
+			status: http.StatusTooManyRequests,
+			body:   "slow down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer srv.Close()
+
+			_, err := (&http.Client{
+				Transport: graphqlutil.WrapTransport(http.DefaultTransport),
+			}).Get(srv.URL)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, graphqlutil.ErrRateLimited)
+
+			var rateErr *graphqlutil.RateLimitError
+			require.ErrorAs(t, err, &rateErr)
+			assert.Equal(t, tt.status, rateErr.StatusCode)
+			assert.Equal(t, tt.wantRetryAfter, rateErr.RetryAfter)
+			assert.Equal(t, tt.wantMessage, rateErr.Message)
+		})
+	}
+}
+
+func TestForbiddenResponse(t *testing.T) {
+	const give = `{"message": "Resource not accessible by integration"}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, give)
+	}))
+	defer srv.Close()
+
+	res, err := (&http.Client{
+		Transport: graphqlutil.WrapTransport(http.DefaultTransport),
+	}).Get(srv.URL)
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	bs, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, give, string(bs))
+}
+
 func TestMalformedResponse(t *testing.T) {
 	const give = `{
 		"data": null,
@@ -132,6 +211,25 @@ func TestGraphQLResponse(t *testing.T) {
 				new(*graphqlutil.Error),
 			},
 		},
+		{
+			name: "rate limited",
+			body: `{
+				"data": null,
+				"errors": [
+					{
+						"type": "RATE_LIMITED",
+						"message": "API rate limit exceeded."
+					}
+				]
+			}`,
+			wantErrorIs: []error{
+				graphqlutil.ErrRateLimited,
+			},
+			wantErrorAs: []any{
+				new(graphqlutil.Errors),
+				new(*graphqlutil.Error),
+			},
+		},
 		{
 			name: "multiple errors",
 			body: `{
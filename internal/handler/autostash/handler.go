@@ -10,7 +10,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
+	"strings"
 
+	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/spice"
@@ -23,8 +26,11 @@ type GitWorktree interface {
 	CurrentBranch(ctx context.Context) (string, error)
 
 	StashCreate(ctx context.Context, message string) (git.Hash, error)
+	StashPush(ctx context.Context, opts *git.StashPushOptions) (git.Hash, error)
 	StashApply(ctx context.Context, stash string) error
 	StashStore(ctx context.Context, stash git.Hash, message string) error
+	StashList(ctx context.Context) iter.Seq2[git.StashEntry, error]
+	StashDrop(ctx context.Context, stash string) error
 
 	Reset(ctx context.Context, commit string, opts git.ResetOptions) error
 	CheckoutFiles(ctx context.Context, req *git.CheckoutFilesRequest) error
@@ -39,6 +45,10 @@ type Service interface {
 
 var _ Service = (*spice.Service)(nil)
 
+// MessagePrefix is the prefix of messages recorded with autostash entries.
+// Stash entries with this prefix are reported by [Handler.ListAutostashes].
+const MessagePrefix = "git-spice: autostash"
+
 // Handler manages automatic stashing and restoration of uncommitted changes.
 type Handler struct {
 	Log      *silog.Logger // required
@@ -90,6 +100,19 @@ type Options struct {
 	//
 	// By default, we do a hard reset to ensure a clean working tree.
 	ResetMode ResetMode
+
+	// Command is the git-spice command that requested the autostash,
+	// e.g. "repo restack".
+	//
+	// If set, it is recorded in the stash message
+	// so that leftover autostashes can be traced back to their origin.
+	Command string
+
+	// IncludeUntracked also stashes untracked files,
+	// removing them from the working tree until the stash is restored.
+	//
+	// This is ignored if ResetMode is ResetNone.
+	IncludeUntracked bool
 }
 
 // BeginAutostash starts an autostash session.
@@ -123,7 +146,10 @@ func (h *Handler) BeginAutostash(
 	opts *Options,
 ) (cleanup func(*error), err error) {
 	opts = cmp.Or(opts, &Options{})
-	opts.Message = cmp.Or(opts.Message, "git-spice: autostash before operation")
+	opts.Message = cmp.Or(opts.Message, MessagePrefix+" before operation")
+	if opts.Command != "" {
+		opts.Message += " (" + opts.Command + ")"
+	}
 
 	if opts.Branch == "" {
 		currentBranch, err := h.Worktree.CurrentBranch(ctx)
@@ -136,7 +162,22 @@ func (h *Handler) BeginAutostash(
 		opts.Branch = currentBranch
 	}
 
-	stashHash, err := h.Worktree.StashCreate(ctx, opts.Message)
+	// 'git stash create' cannot stash untracked files.
+	// Use 'git stash push' for those instead.
+	// This records the stash in the stash reflog
+	// and resets the working tree.
+	pushed := opts.IncludeUntracked && opts.ResetMode != ResetNone
+
+	var stashHash git.Hash
+	if pushed {
+		stashHash, err = h.Worktree.StashPush(ctx, &git.StashPushOptions{
+			Message:          opts.Message,
+			IncludeUntracked: true,
+			KeepIndex:        opts.ResetMode == ResetWorktree,
+		})
+	} else {
+		stashHash, err = h.Worktree.StashCreate(ctx, opts.Message)
+	}
 	if err != nil {
 		if !errors.Is(err, git.ErrNoChanges) {
 			return nil, fmt.Errorf("stash changes: %w", err)
@@ -149,22 +190,25 @@ func (h *Handler) BeginAutostash(
 
 	// We created a stash.
 	// Reset the working tree according to the mode.
-	switch opts.ResetMode {
-	case ResetHard:
+	switch {
+	case pushed:
+		// 'git stash push' has already reset the working tree.
+
+	case opts.ResetMode == ResetHard:
 		if err := h.Worktree.Reset(ctx, "HEAD", git.ResetOptions{
 			Mode: git.ResetHard,
 		}); err != nil {
 			return nil, fmt.Errorf("reset before operation: %w", err)
 		}
 
-	case ResetWorktree:
+	case opts.ResetMode == ResetWorktree:
 		if err := h.Worktree.CheckoutFiles(ctx, &git.CheckoutFilesRequest{
 			Pathspecs: []string{"."},
 		}); err != nil {
 			return nil, fmt.Errorf("restore working tree before operation: %w", err)
 		}
 
-	case ResetNone:
+	case opts.ResetMode == ResetNone:
 		// Do nothing.
 
 	default:
@@ -189,7 +233,15 @@ func (h *Handler) BeginAutostash(
 			return
 		}
 
-		// Failure: schedule stash restoration via RebaseRescue.
+		// Failure: record the stash in the stash reflog
+		// so that it can be recovered with 'gs stash pop'
+		// even if the operation is aborted,
+		// and schedule stash restoration via RebaseRescue.
+		if !pushed {
+			if err := h.Worktree.StashStore(ctx, stashHash, opts.Message); err != nil {
+				h.Log.Warn("Could not save autostash", "error", err)
+			}
+		}
 		*errPtr = h.Service.RebaseRescue(ctx, spice.RebaseRescueRequest{
 			Err:     *errPtr,
 			Command: []string{"internal", "autostash-pop", stashHash.String()},
@@ -200,28 +252,122 @@ func (h *Handler) BeginAutostash(
 }
 
 // RestoreAutostash tries to apply the stashed changes to the worktree.
+// If the stash was recorded in the stash reflog, it is dropped from there.
 // If the operation fails, it pushes the stashed changes
 // so that the user can run 'git stash pop' manually.
 func (h *Handler) RestoreAutostash(ctx context.Context, stashHash string) error {
-	err := h.Worktree.StashApply(ctx, stashHash)
+	entry, stored, err := h.findAutostash(ctx, git.Hash(stashHash))
+	if err != nil {
+		h.Log.Warn("Could not look up autostash in stash list", "error", err)
+	}
+
+	err = h.Worktree.StashApply(ctx, stashHash)
 	if err == nil {
 		h.Log.Info("Applied autostash")
+		if stored {
+			if err := h.Worktree.StashDrop(ctx, entry.Name); err != nil {
+				h.Log.Warn("Could not drop applied autostash", "stash", entry.Name, "error", err)
+			}
+		}
 		return nil
 	}
 
 	// If autostash apply fails,
 	// log the error, and save the stash for restoration.
 	h.Log.Error("Failed to apply autostashed changes", "error", err)
-	if err := h.Worktree.StashStore(ctx, git.Hash(stashHash), "git-spice: autostash failed to apply"); err != nil {
-		// If even stash store fails, there's nothing we can do.
-		// Tell the user to manually recover the stash.
-		h.Log.Error("Failed to save autostashed changes", "error", err)
-		h.Log.Errorf("You can try recovering them with 'git stash apply %s'", stashHash)
-		return errors.New("stashed changes could not be applied or saved")
+	if !stored {
+		if err := h.Worktree.StashStore(ctx, git.Hash(stashHash), MessagePrefix+" failed to apply"); err != nil {
+			// If even stash store fails, there's nothing we can do.
+			// Tell the user to manually recover the stash.
+			h.Log.Error("Failed to save autostashed changes", "error", err)
+			h.Log.Errorf("You can try recovering them with 'git stash apply %s'", stashHash)
+			return errors.New("stashed changes could not be applied or saved")
+		}
 	}
 
 	h.Log.Error("Your changes are safe in the stash. You can:")
-	h.Log.Error("- apply them with 'git stash pop';")
+	h.Log.Errorf("- apply them with 'git stash pop' or '%s stash pop';", cli.Name())
 	h.Log.Error("- or drop them with 'git stash drop'")
 	return errors.New("autostashed changes could not be applied")
 }
+
+// ListAutostashes lists autostash entries left behind in the stash reflog,
+// most recent first.
+//
+// Autostashes end up in the stash reflog if the operation that created them
+// failed or was aborted, or if they could not be applied afterwards.
+func (h *Handler) ListAutostashes(ctx context.Context) ([]git.StashEntry, error) {
+	var entries []git.StashEntry
+	for entry, err := range h.Worktree.StashList(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("list stashes: %w", err)
+		}
+
+		if isAutostashMessage(entry.Message) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// PopAutostash applies an autostash entry from the stash reflog
+// and drops it from the reflog.
+//
+// name is the reflog name of the entry, e.g. "stash@{1}".
+// If name is empty, the most recent autostash is used.
+// The entry is kept in the reflog if it could not be applied.
+func (h *Handler) PopAutostash(ctx context.Context, name string) error {
+	entries, err := h.ListAutostashes(ctx)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errors.New("no autostashes found")
+	}
+
+	entry := entries[0]
+	if name != "" {
+		var found bool
+		for _, e := range entries {
+			if e.Name == name {
+				entry, found = e, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%v is not an autostash", name)
+		}
+	}
+
+	if err := h.Worktree.StashApply(ctx, entry.Name); err != nil {
+		return fmt.Errorf("apply %v: %w", entry.Name, err)
+	}
+
+	if err := h.Worktree.StashDrop(ctx, entry.Name); err != nil {
+		return fmt.Errorf("drop %v: %w", entry.Name, err)
+	}
+
+	h.Log.Infof("Applied %v: %v", entry.Name, entry.Message)
+	return nil
+}
+
+// findAutostash looks for the stash with the given hash in the stash reflog.
+func (h *Handler) findAutostash(ctx context.Context, hash git.Hash) (git.StashEntry, bool, error) {
+	for entry, err := range h.Worktree.StashList(ctx) {
+		if err != nil {
+			return git.StashEntry{}, false, fmt.Errorf("list stashes: %w", err)
+		}
+
+		if entry.Hash == hash {
+			return entry, true, nil
+		}
+	}
+	return git.StashEntry{}, false, nil
+}
+
+func isAutostashMessage(msg string) bool {
+	// Stashes saved with 'git stash push' are recorded as
+	// "On <branch>: <message>".
+	return strings.HasPrefix(msg, MessagePrefix) ||
+		strings.Contains(msg, ": "+MessagePrefix)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"iter"
 	reflect "reflect"
 	"testing"
 
@@ -59,6 +60,9 @@ func TestHandler_AutoStash(t *testing.T) {
 		})
 		require.NoError(t, err)
 
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList())
 		mockWorktree.EXPECT().
 			StashApply(gomock.Any(), stashHash.String()).
 			Return(nil)
@@ -87,6 +91,9 @@ func TestHandler_AutoStash(t *testing.T) {
 		require.NoError(t, err)
 
 		stashErr := errors.New("sadness")
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList())
 		mockWorktree.EXPECT().
 			StashApply(gomock.Any(), stashHash.String()).
 			Return(stashErr)
@@ -114,6 +121,9 @@ func TestHandler_AutoStash_reset(t *testing.T) {
 			Reset(gomock.Any(), "HEAD", git.ResetOptions{
 				Mode: git.ResetHard,
 			}).Return(nil)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList())
 		mockWorktree.EXPECT().
 			StashApply(gomock.Any(), gomock.Any()).
 			Return(nil)
@@ -141,6 +151,9 @@ func TestHandler_AutoStash_reset(t *testing.T) {
 			CheckoutFiles(gomock.Any(), &git.CheckoutFilesRequest{
 				Pathspecs: []string{"."},
 			}).Return(nil)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList())
 		mockWorktree.EXPECT().
 			StashApply(gomock.Any(), gomock.Any()).
 			Return(nil)
@@ -183,6 +196,9 @@ func TestHandler_AutoStash_options(t *testing.T) {
 		require.NoError(t, err)
 
 		conflictErr := errors.New("sadness")
+		mockWorktree.EXPECT().
+			StashStore(gomock.Any(), stashHash, "git-spice: autostash before operation").
+			Return(nil)
 		mockService.EXPECT().
 			RebaseRescue(gomock.Any(), rebaseRescueMatcher{
 				Err:    conflictErr,
@@ -192,6 +208,283 @@ func TestHandler_AutoStash_options(t *testing.T) {
 	})
 }
 
+func TestHandler_AutoStash_includeUntracked(t *testing.T) {
+	t.Run("Hard", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		stashHash := git.Hash("stashhash")
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashPush(gomock.Any(), &git.StashPushOptions{
+				Message:          "autostash message (repo restack)",
+				IncludeUntracked: true,
+			}).
+			Return(stashHash, nil)
+
+		cleanup, err := (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).BeginAutostash(t.Context(), &Options{
+			Message:          "autostash message",
+			Branch:           "feature",
+			Command:          "repo restack",
+			ResetMode:        ResetHard,
+			IncludeUntracked: true,
+		})
+		require.NoError(t, err)
+
+		// The pushed stash is dropped from the reflog once applied.
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList(git.StashEntry{
+				Name:    "stash@{0}",
+				Hash:    stashHash,
+				Message: "On feature: autostash message (repo restack)",
+			}))
+		mockWorktree.EXPECT().
+			StashApply(gomock.Any(), stashHash.String()).
+			Return(nil)
+		mockWorktree.EXPECT().
+			StashDrop(gomock.Any(), "stash@{0}").
+			Return(nil)
+		cleanup(nil)
+	})
+
+	t.Run("Worktree", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashPush(gomock.Any(), &git.StashPushOptions{
+				Message:          "autostash message",
+				IncludeUntracked: true,
+				KeepIndex:        true,
+			}).
+			Return(git.Hash(""), git.ErrNoChanges)
+
+		cleanup, err := (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).BeginAutostash(t.Context(), &Options{
+			Message:          "autostash message",
+			Branch:           "feature",
+			ResetMode:        ResetWorktree,
+			IncludeUntracked: true,
+		})
+		require.NoError(t, err)
+		cleanup(nil) // should be no-op
+	})
+
+	t.Run("FailureNotStoredAgain", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		stashHash := git.Hash("stashhash")
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashPush(gomock.Any(), gomock.Any()).
+			Return(stashHash, nil)
+
+		mockService := NewMockService(mockCtrl)
+		cleanup, err := (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  mockService,
+		}).BeginAutostash(t.Context(), &Options{
+			Branch:           "feature",
+			IncludeUntracked: true,
+		})
+		require.NoError(t, err)
+
+		conflictErr := errors.New("sadness")
+		mockService.EXPECT().
+			RebaseRescue(gomock.Any(), rebaseRescueMatcher{
+				Err:    conflictErr,
+				Cmd:    []string{"internal", "autostash-pop", "stashhash"},
+				Branch: "feature",
+			}).Return(conflictErr)
+		cleanup(&conflictErr)
+	})
+}
+
+func TestHandler_RestoreAutostash_applyErrorAlreadyStored(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	var logBuf bytes.Buffer
+
+	stashHash := git.Hash("stashhash")
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		StashList(gomock.Any()).
+		Return(stashList(git.StashEntry{
+			Name:    "stash@{1}",
+			Hash:    stashHash,
+			Message: "git-spice: autostash before restacking",
+		}))
+	mockWorktree.EXPECT().
+		StashApply(gomock.Any(), stashHash.String()).
+		Return(errors.New("conflict"))
+
+	err := (&Handler{
+		Log:      silog.New(&logBuf, nil),
+		Worktree: mockWorktree,
+		Service:  NewMockService(mockCtrl),
+	}).RestoreAutostash(t.Context(), stashHash.String())
+	require.Error(t, err)
+	assert.Contains(t, logBuf.String(), "Your changes are safe in the stash")
+}
+
+func TestHandler_ListAutostashes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		StashList(gomock.Any()).
+		Return(stashList(
+			git.StashEntry{
+				Name:    "stash@{0}",
+				Hash:    "hash0",
+				Message: "On main: git-spice: autostash before restacking (repo restack)",
+			},
+			git.StashEntry{
+				Name:    "stash@{1}",
+				Hash:    "hash1",
+				Message: "WIP on main: 1234567 Unrelated",
+			},
+			git.StashEntry{
+				Name:    "stash@{2}",
+				Hash:    "hash2",
+				Message: "git-spice: autostash failed to apply",
+			},
+		))
+
+	entries, err := (&Handler{
+		Log:      silogtest.New(t),
+		Worktree: mockWorktree,
+		Service:  NewMockService(mockCtrl),
+	}).ListAutostashes(t.Context())
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"stash@{0}", "stash@{2}"}, names)
+}
+
+func TestHandler_PopAutostash(t *testing.T) {
+	entries := []git.StashEntry{
+		{
+			Name:    "stash@{0}",
+			Hash:    "hash0",
+			Message: "WIP on main: 1234567 Unrelated",
+		},
+		{
+			Name:    "stash@{1}",
+			Hash:    "hash1",
+			Message: "git-spice: autostash before commit fixup",
+		},
+		{
+			Name:    "stash@{2}",
+			Hash:    "hash2",
+			Message: "git-spice: autostash before restacking",
+		},
+	}
+
+	t.Run("MostRecent", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList(entries...))
+		mockWorktree.EXPECT().
+			StashApply(gomock.Any(), "stash@{1}").
+			Return(nil)
+		mockWorktree.EXPECT().
+			StashDrop(gomock.Any(), "stash@{1}").
+			Return(nil)
+
+		require.NoError(t, (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).PopAutostash(t.Context(), ""))
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList(entries...))
+		mockWorktree.EXPECT().
+			StashApply(gomock.Any(), "stash@{2}").
+			Return(nil)
+		mockWorktree.EXPECT().
+			StashDrop(gomock.Any(), "stash@{2}").
+			Return(nil)
+
+		require.NoError(t, (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).PopAutostash(t.Context(), "stash@{2}"))
+	})
+
+	t.Run("NotAutostash", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList(entries...))
+
+		err := (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).PopAutostash(t.Context(), "stash@{0}")
+		assert.ErrorContains(t, err, "stash@{0} is not an autostash")
+	})
+
+	t.Run("ApplyError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList(entries...))
+		mockWorktree.EXPECT().
+			StashApply(gomock.Any(), "stash@{1}").
+			Return(errors.New("conflict"))
+
+		err := (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).PopAutostash(t.Context(), "")
+		assert.ErrorContains(t, err, "apply stash@{1}")
+	})
+
+	t.Run("NoAutostashes", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		mockWorktree := NewMockGitWorktree(mockCtrl)
+		mockWorktree.EXPECT().
+			StashList(gomock.Any()).
+			Return(stashList(entries[0]))
+
+		err := (&Handler{
+			Log:      silogtest.New(t),
+			Worktree: mockWorktree,
+			Service:  NewMockService(mockCtrl),
+		}).PopAutostash(t.Context(), "")
+		assert.ErrorContains(t, err, "no autostashes found")
+	})
+}
+
 type rebaseRescueMatcher struct {
 	Err    error
 	Cmd    []string // nil means don't match
@@ -224,3 +517,13 @@ func (i rebaseRescueMatcher) Matches(x any) bool {
 
 	return true
 }
+
+func stashList(entries ...git.StashEntry) iter.Seq2[git.StashEntry, error] {
+	return func(yield func(git.StashEntry, error) bool) {
+		for _, e := range entries {
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
@@ -11,6 +11,7 @@ package autostash
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	git "go.abhg.dev/gs/internal/git"
@@ -234,6 +235,121 @@ func (c *MockGitWorktreeStashCreateCall) DoAndReturn(f func(context.Context, str
 	return c
 }
 
+// StashDrop mocks base method.
+func (m *MockGitWorktree) StashDrop(ctx context.Context, stash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashDrop", ctx, stash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StashDrop indicates an expected call of StashDrop.
+func (mr *MockGitWorktreeMockRecorder) StashDrop(ctx, stash any) *MockGitWorktreeStashDropCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashDrop", reflect.TypeOf((*MockGitWorktree)(nil).StashDrop), ctx, stash)
+	return &MockGitWorktreeStashDropCall{Call: call}
+}
+
+// MockGitWorktreeStashDropCall wrap *gomock.Call
+type MockGitWorktreeStashDropCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeStashDropCall) Return(arg0 error) *MockGitWorktreeStashDropCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeStashDropCall) Do(f func(context.Context, string) error) *MockGitWorktreeStashDropCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeStashDropCall) DoAndReturn(f func(context.Context, string) error) *MockGitWorktreeStashDropCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StashList mocks base method.
+func (m *MockGitWorktree) StashList(ctx context.Context) iter.Seq2[git.StashEntry, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashList", ctx)
+	ret0, _ := ret[0].(iter.Seq2[git.StashEntry, error])
+	return ret0
+}
+
+// StashList indicates an expected call of StashList.
+func (mr *MockGitWorktreeMockRecorder) StashList(ctx any) *MockGitWorktreeStashListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashList", reflect.TypeOf((*MockGitWorktree)(nil).StashList), ctx)
+	return &MockGitWorktreeStashListCall{Call: call}
+}
+
+// MockGitWorktreeStashListCall wrap *gomock.Call
+type MockGitWorktreeStashListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeStashListCall) Return(arg0 iter.Seq2[git.StashEntry, error]) *MockGitWorktreeStashListCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeStashListCall) Do(f func(context.Context) iter.Seq2[git.StashEntry, error]) *MockGitWorktreeStashListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeStashListCall) DoAndReturn(f func(context.Context) iter.Seq2[git.StashEntry, error]) *MockGitWorktreeStashListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StashPush mocks base method.
+func (m *MockGitWorktree) StashPush(ctx context.Context, opts *git.StashPushOptions) (git.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashPush", ctx, opts)
+	ret0, _ := ret[0].(git.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StashPush indicates an expected call of StashPush.
+func (mr *MockGitWorktreeMockRecorder) StashPush(ctx, opts any) *MockGitWorktreeStashPushCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashPush", reflect.TypeOf((*MockGitWorktree)(nil).StashPush), ctx, opts)
+	return &MockGitWorktreeStashPushCall{Call: call}
+}
+
+// MockGitWorktreeStashPushCall wrap *gomock.Call
+type MockGitWorktreeStashPushCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeStashPushCall) Return(arg0 git.Hash, arg1 error) *MockGitWorktreeStashPushCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeStashPushCall) Do(f func(context.Context, *git.StashPushOptions) (git.Hash, error)) *MockGitWorktreeStashPushCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeStashPushCall) DoAndReturn(f func(context.Context, *git.StashPushOptions) (git.Hash, error)) *MockGitWorktreeStashPushCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // StashStore mocks base method.
 func (m *MockGitWorktree) StashStore(ctx context.Context, stash git.Hash, message string) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	"io"
 
 	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/handler/track"
 	"go.abhg.dev/gs/internal/must"
@@ -48,6 +49,7 @@ type GitRepository interface {
 	CreateBranch(ctx context.Context, req git.CreateBranchRequest) error
 	PeelToCommit(ctx context.Context, ref string) (git.Hash, error)
 	SetBranchUpstream(ctx context.Context, branch, upstream string) error
+	Fetch(ctx context.Context, opts git.FetchOptions) error
 }
 
 // TrackHandler allows tracking new branches with git-spice.
@@ -59,6 +61,9 @@ type TrackHandler interface {
 type Service interface {
 	// VerifyRestacked checks if the branch is restacked.
 	VerifyRestacked(ctx context.Context, branch string) error
+
+	// LoadBranches loads all tracked branches.
+	LoadBranches(ctx context.Context) ([]spice.LoadBranchItem, error)
 }
 
 // Handler provides a central place for handling checkout operations.
@@ -70,11 +75,23 @@ type Handler struct {
 	Worktree   GitWorktree   // required
 	Track      TrackHandler  // required
 	Service    Service       // required
+
+	// OpenRemoteRepository opens the repository on the forge.
+	// It's used to resolve references to Change Requests
+	// (e.g. "#123" or a URL) into branch names.
+	//
+	// If unset, branch names are never treated as CR references.
+	OpenRemoteRepository func(context.Context) (forge.Repository, error) // optional
 }
 
 // Request is a request to checkout a branch.
 type Request struct {
 	// Branch is the name of the branch to checkout.
+	//
+	// This may also be a reference to a Change Request
+	// (e.g. "#123", "pr/123", or the URL of the CR)
+	// if OpenRemoteRepository is set and no such branch exists.
+	// The CR's head branch is fetched if it isn't tracked.
 	Branch string // required
 
 	// Options are the options for checking out the branch.
@@ -100,6 +117,23 @@ func (h *Handler) CheckoutBranch(ctx context.Context, req *Request) error {
 	must.NotBef(opts.DryRun && opts.Detach, "cannot use both dry-run and detach options")
 
 	log := h.Log
+
+	// changeRef is set if the branch was resolved from a CR reference
+	// by fetching the CR's head branch.
+	var changeRef string
+	if ref, ok := forge.ParseChangeRef(branch); ok && h.OpenRemoteRepository != nil {
+		if _, err := h.Repository.PeelToCommit(ctx, branch); err != nil {
+			resolved, fetched, err := h.resolveChangeBranch(ctx, ref)
+			if err != nil {
+				return fmt.Errorf("resolve %v: %w", branch, err)
+			}
+			branch = resolved
+			if fetched {
+				changeRef = ref
+			}
+		}
+	}
+
 	if branch != h.Store.Trunk() {
 		if err := h.Service.VerifyRestacked(ctx, branch); err != nil {
 			var restackErr *spice.BranchNeedsRestackError
@@ -137,21 +171,17 @@ func (h *Handler) CheckoutBranch(ctx context.Context, req *Request) error {
 				if !recovered {
 					return fmt.Errorf("branch %q does not exist", branch)
 				}
-				fallthrough // we just created the branch so it's not tracked
 
-			case errors.Is(err, state.ErrNotExist): // exists but not tracked
-				shouldTrack, err := req.ShouldTrack(branch)
-				if err != nil {
-					return fmt.Errorf("check if branch should be tracked: %w", err)
+				// If the branch was resolved from a CR,
+				// it was just created from the CR's head,
+				// so associate it with the CR if it's tracked.
+				if err := h.trackUntracked(ctx, req, branch, changeRef); err != nil {
+					return err
 				}
 
-				if shouldTrack {
-					err := h.Track.TrackBranch(ctx, &track.BranchRequest{
-						Branch: branch,
-					})
-					if err != nil {
-						log.Warn("Error tracking branch", "branch", branch, "error", err)
-					}
+			case errors.Is(err, state.ErrNotExist): // exists but not tracked
+				if err := h.trackUntracked(ctx, req, branch, ""); err != nil {
+					return err
 				}
 
 			default:
@@ -184,3 +214,83 @@ func (h *Handler) CheckoutBranch(ctx context.Context, req *Request) error {
 
 	return nil
 }
+
+// trackUntracked offers to track an untracked branch
+// with req.ShouldTrack.
+// If change is non-empty, the branch is associated with that CR.
+func (h *Handler) trackUntracked(ctx context.Context, req *Request, branch, change string) error {
+	shouldTrack, err := req.ShouldTrack(branch)
+	if err != nil {
+		return fmt.Errorf("check if branch should be tracked: %w", err)
+	}
+	if !shouldTrack {
+		return nil
+	}
+
+	if err := h.Track.TrackBranch(ctx, &track.BranchRequest{
+		Branch: branch,
+		Change: change,
+	}); err != nil {
+		h.Log.Warn("Error tracking branch", "branch", branch, "error", err)
+	}
+	return nil
+}
+
+// resolveChangeBranch resolves a CR reference to the name of a branch.
+//
+// Tracked branches are matched against their change metadata first.
+// Otherwise, the CR's head branch is fetched from the remote
+// so that it can be checked out, and fetched is set to true.
+func (h *Handler) resolveChangeBranch(ctx context.Context, ref string) (branch string, fetched bool, err error) {
+	remoteRepo, err := h.OpenRemoteRepository(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("open remote repository: %w", err)
+	}
+	f := remoteRepo.Forge()
+
+	id, err := f.ParseChangeID(ref)
+	if err != nil {
+		return "", false, fmt.Errorf("parse change: %w", err)
+	}
+	changeName := f.FormatChangeID(id)
+
+	branches, err := h.Service.LoadBranches(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("load branches: %w", err)
+	}
+	for _, b := range branches {
+		if b.Change == nil || b.Change.ForgeID() != f.ID() {
+			continue
+		}
+		if f.FormatChangeID(b.Change.ChangeID()) == changeName {
+			h.Log.Debugf("%v: tracked as branch %v", changeName, b.Name)
+			return b.Name, false, nil
+		}
+	}
+
+	change, err := remoteRepo.FindChangeByID(ctx, id)
+	if err != nil {
+		return "", false, fmt.Errorf("find change %v: %w", changeName, err)
+	}
+	if change.HeadName == "" {
+		return "", false, fmt.Errorf("%v: head branch is unknown", changeName)
+	}
+
+	remote, err := h.Store.Remote()
+	if err != nil {
+		return "", false, fmt.Errorf("get remote: %w", err)
+	}
+
+	upstream := remote + "/" + change.HeadName
+	if err := h.Repository.Fetch(ctx, git.FetchOptions{
+		Remote: remote,
+		Refspecs: []git.Refspec{
+			git.Refspec("+refs/heads/" + change.HeadName + ":refs/remotes/" + upstream),
+		},
+	}); err != nil {
+		return "", false, fmt.Errorf("fetch %v: %w", change.HeadName, err)
+	}
+
+	h.Log.Infof("%v: fetched branch %v", changeName, upstream)
+	return change.HeadName, true, nil
+}
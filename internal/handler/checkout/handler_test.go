@@ -2,6 +2,7 @@ package checkout
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/handler/track"
 	"go.abhg.dev/gs/internal/silog"
@@ -793,3 +796,141 @@ func TestHandler_CheckoutBranch_Verbose(t *testing.T) {
 		assert.NotContains(t, logBuffer.String(), "switched to branch")
 	})
 }
+
+func TestHandler_CheckoutBranch_changeRef(t *testing.T) {
+	remoteRepo := forgetest.NewFakeRepository()
+	trackedID := remoteRepo.AddChange(forgetest.FakeChange{
+		Base: "main", Head: "feature", HeadHash: "abc123",
+	})
+	remoteRepo.AddChange(forgetest.FakeChange{
+		Base: "main", Head: "their-feature", HeadHash: "def456",
+	})
+	openRemoteRepo := func(context.Context) (forge.Repository, error) {
+		return remoteRepo, nil
+	}
+
+	newHandler := func(t *testing.T) (*Handler, *MockGitRepository, *MockGitWorktree, *MockService, *MockTrackHandler) {
+		ctrl := gomock.NewController(t)
+		mockStore := NewMockStore(ctrl)
+		mockStore.EXPECT().Trunk().Return("main").AnyTimes()
+		mockStore.EXPECT().Remote().Return("origin", nil).AnyTimes()
+
+		mockRepo := NewMockGitRepository(ctrl)
+		mockWorktree := NewMockGitWorktree(ctrl)
+		mockService := NewMockService(ctrl)
+		mockTrack := NewMockTrackHandler(ctrl)
+
+		mockService.EXPECT().
+			LoadBranches(gomock.Any()).
+			Return([]spice.LoadBranchItem{
+				{Name: "unrelated", Base: "main"},
+				{
+					Name:   "feature",
+					Base:   "main",
+					Change: &forgetest.FakeChangeMetadata{Number: int(trackedID)},
+				},
+			}, nil).
+			AnyTimes()
+
+		return &Handler{
+			Stdout:               io.Discard,
+			Log:                  silog.Nop(),
+			Store:                mockStore,
+			Repository:           mockRepo,
+			Worktree:             mockWorktree,
+			Track:                mockTrack,
+			Service:              mockService,
+			OpenRemoteRepository: openRemoteRepo,
+		}, mockRepo, mockWorktree, mockService, mockTrack
+	}
+
+	t.Run("Tracked", func(t *testing.T) {
+		for _, ref := range []string{"#1", "pr/1", "PR/1", "https://forge.example.com/changes/1"} {
+			t.Run(ref, func(t *testing.T) {
+				handler, mockRepo, mockWorktree, mockService, _ := newHandler(t)
+				mockRepo.EXPECT().
+					PeelToCommit(gomock.Any(), ref).
+					Return(git.Hash(""), git.ErrNotExist)
+				mockService.EXPECT().
+					VerifyRestacked(gomock.Any(), "feature").
+					Return(nil)
+				mockWorktree.EXPECT().
+					CheckoutBranch(gomock.Any(), "feature").
+					Return(nil)
+
+				require.NoError(t, handler.CheckoutBranch(t.Context(), &Request{Branch: ref}))
+			})
+		}
+	})
+
+	t.Run("Untracked", func(t *testing.T) {
+		handler, mockRepo, mockWorktree, mockService, mockTrack := newHandler(t)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "#2").
+			Return(git.Hash(""), git.ErrNotExist)
+		mockRepo.EXPECT().
+			Fetch(gomock.Any(), git.FetchOptions{
+				Remote: "origin",
+				Refspecs: []git.Refspec{
+					"+refs/heads/their-feature:refs/remotes/origin/their-feature",
+				},
+			}).
+			Return(nil)
+		mockService.EXPECT().
+			VerifyRestacked(gomock.Any(), "their-feature").
+			Return(git.ErrNotExist)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "origin/their-feature").
+			Return(git.Hash("def456"), nil)
+		mockRepo.EXPECT().
+			CreateBranch(gomock.Any(), git.CreateBranchRequest{
+				Name: "their-feature",
+				Head: "def456",
+			}).
+			Return(nil)
+		mockRepo.EXPECT().
+			SetBranchUpstream(gomock.Any(), "their-feature", "origin/their-feature").
+			Return(nil)
+		mockTrack.EXPECT().
+			TrackBranch(gomock.Any(), &track.BranchRequest{
+				Branch: "their-feature",
+				Change: "#2",
+			}).
+			Return(nil)
+		mockWorktree.EXPECT().
+			CheckoutBranch(gomock.Any(), "their-feature").
+			Return(nil)
+
+		require.NoError(t, handler.CheckoutBranch(t.Context(), &Request{
+			Branch:      "#2",
+			ShouldTrack: func(string) (bool, error) { return true, nil },
+		}))
+	})
+
+	t.Run("BranchExists", func(t *testing.T) {
+		// A branch named like a CR reference takes precedence.
+		handler, mockRepo, mockWorktree, mockService, _ := newHandler(t)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "pr/1").
+			Return(git.Hash("abc123"), nil)
+		mockService.EXPECT().
+			VerifyRestacked(gomock.Any(), "pr/1").
+			Return(nil)
+		mockWorktree.EXPECT().
+			CheckoutBranch(gomock.Any(), "pr/1").
+			Return(nil)
+
+		require.NoError(t, handler.CheckoutBranch(t.Context(), &Request{Branch: "pr/1"}))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		handler, mockRepo, _, _, _ := newHandler(t)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "#42").
+			Return(git.Hash(""), git.ErrNotExist)
+
+		err := handler.CheckoutBranch(t.Context(), &Request{Branch: "#42"})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "find change #42")
+	})
+}
@@ -15,6 +15,7 @@ import (
 
 	git "go.abhg.dev/gs/internal/git"
 	track "go.abhg.dev/gs/internal/handler/track"
+	spice "go.abhg.dev/gs/internal/spice"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -80,6 +81,44 @@ func (c *MockGitRepositoryCreateBranchCall) DoAndReturn(f func(context.Context,
 	return c
 }
 
+// Fetch mocks base method.
+func (m *MockGitRepository) Fetch(ctx context.Context, opts git.FetchOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", ctx, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockGitRepositoryMockRecorder) Fetch(ctx, opts any) *MockGitRepositoryFetchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockGitRepository)(nil).Fetch), ctx, opts)
+	return &MockGitRepositoryFetchCall{Call: call}
+}
+
+// MockGitRepositoryFetchCall wrap *gomock.Call
+type MockGitRepositoryFetchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepositoryFetchCall) Return(arg0 error) *MockGitRepositoryFetchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepositoryFetchCall) Do(f func(context.Context, git.FetchOptions) error) *MockGitRepositoryFetchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepositoryFetchCall) DoAndReturn(f func(context.Context, git.FetchOptions) error) *MockGitRepositoryFetchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // PeelToCommit mocks base method.
 func (m *MockGitRepository) PeelToCommit(ctx context.Context, ref string) (git.Hash, error) {
 	m.ctrl.T.Helper()
@@ -343,6 +382,45 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// LoadBranches mocks base method.
+func (m *MockService) LoadBranches(ctx context.Context) ([]spice.LoadBranchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBranches", ctx)
+	ret0, _ := ret[0].([]spice.LoadBranchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBranches indicates an expected call of LoadBranches.
+func (mr *MockServiceMockRecorder) LoadBranches(ctx any) *MockServiceLoadBranchesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBranches", reflect.TypeOf((*MockService)(nil).LoadBranches), ctx)
+	return &MockServiceLoadBranchesCall{Call: call}
+}
+
+// MockServiceLoadBranchesCall wrap *gomock.Call
+type MockServiceLoadBranchesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceLoadBranchesCall) Return(arg0 []spice.LoadBranchItem, arg1 error) *MockServiceLoadBranchesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceLoadBranchesCall) Do(f func(context.Context) ([]spice.LoadBranchItem, error)) *MockServiceLoadBranchesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceLoadBranchesCall) DoAndReturn(f func(context.Context) ([]spice.LoadBranchItem, error)) *MockServiceLoadBranchesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // VerifyRestacked mocks base method.
 func (m *MockService) VerifyRestacked(ctx context.Context, branch string) error {
 	m.ctrl.T.Helper()
@@ -14,7 +14,10 @@ import (
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/handler/autostash"
 	"go.abhg.dev/gs/internal/handler/restack"
+	"go.abhg.dev/gs/internal/must"
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
 )
 
 // RestackHandler is a subset of the restack.Handler interface.
@@ -31,6 +34,7 @@ type GitRepository interface {
 	PeelToCommit(ctx context.Context, rev string) (git.Hash, error)
 	ReadCommit(ctx context.Context, commitish string) (*git.CommitObject, error)
 	DiffTree(ctx context.Context, treeish1, treeish2 string) iter.Seq2[git.FileStatus, error]
+	SetRef(ctx context.Context, req git.SetRefRequest) error
 }
 
 var _ GitRepository = (*git.Repository)(nil)
@@ -41,10 +45,20 @@ type GitWorktree interface {
 	DiffWork(ctx context.Context) iter.Seq2[git.FileStatus, error]
 	ListUntrackedFiles(ctx context.Context) iter.Seq2[string, error]
 	Reset(ctx context.Context, commit string, opts git.ResetOptions) error
+	CurrentBranch(ctx context.Context) (string, error)
+	RootDir() string
 }
 
 var _ GitWorktree = (*git.Worktree)(nil)
 
+// Service is a subset of the spice.Service interface.
+type Service interface {
+	LookupWorktrees(ctx context.Context, branches []string) (map[string]string, error)
+	RecordPickedCommits(ctx context.Context, name string, picks []state.PickedCommit) error
+}
+
+var _ Service = (*spice.Service)(nil)
+
 // AutostashHandler is a subset of the autostash.Handler interface.
 type AutostashHandler interface {
 	BeginAutostash(ctx context.Context, opts *autostash.Options) (cleanup func(*error), err error)
@@ -57,6 +71,7 @@ type Handler struct {
 	Log        *silog.Logger    // required
 	Repository GitRepository    // required
 	Worktree   GitWorktree      // required
+	Service    Service          // required
 	Restack    RestackHandler   // required
 	Autostash  AutostashHandler // required
 }
@@ -67,30 +82,57 @@ type Options struct {
 	SignCommits bool `default:"false" hidden:"" config:"@commit.gpgsign"`
 }
 
-// Request holds parameters for fixing up a commit.
+// Request holds parameters for a cherry-pick.
 type Request struct {
-	// Commit is the commit to cherry-pick.
-	Commit git.Hash // required
+	// Commits are the commits to cherry-pick,
+	// in the order they should be applied.
+	Commits []git.Hash // required
+
+	// From is the branch the commits are being picked from, if known.
+	// It's recorded alongside the picked commits.
+	From string
 
 	// Branch is the branch to cherry-pick onto.
 	// The HEAD of this branch is used as the base for the cherry-pick.
+	//
+	// The branch does not have to be checked out,
+	// but it must not be checked out in another worktree.
 	Branch string // required
 
 	Options *Options // optional
 }
 
-// CherryPickCommit applies the staged changes to the given commit
-// downstack from the current branch.
+// CherryPickCommit applies the changes introduced by the requested commits
+// to the given branch and restacks the branches upstack from it.
+// The picked commits are recorded in the branch's state.
+//
+// If any of the commits cannot be applied without a conflict,
+// the branch is left unchanged.
 func (h *Handler) CherryPickCommit(ctx context.Context, req *Request) (retErr error) {
 	req.Options = cmp.Or(req.Options, &Options{})
+	must.NotBeEmptyf(req.Commits, "at least one commit is required")
 
 	head, err := h.Repository.PeelToCommit(ctx, req.Branch)
 	if err != nil {
 		return fmt.Errorf("resolve branch %q: %w", req.Branch, err)
 	}
 
-	// Like git cherry-pick, refuse to run if there are staged changes.
-	if stagedFiles, err := h.Worktree.DiffIndex(ctx, head.String()); err != nil {
+	current, err := h.Worktree.CurrentBranch(ctx)
+	if err != nil && !errors.Is(err, git.ErrDetachedHead) {
+		return fmt.Errorf("determine current branch: %w", err)
+	}
+	checkedOut := current == req.Branch
+
+	if !checkedOut {
+		worktrees, err := h.Service.LookupWorktrees(ctx, []string{req.Branch})
+		if err != nil {
+			return fmt.Errorf("look up worktrees: %w", err)
+		}
+		if wt := worktrees[req.Branch]; wt != "" && wt != h.Worktree.RootDir() {
+			return fmt.Errorf("%v is checked out in another worktree (%v)", req.Branch, wt)
+		}
+	} else if stagedFiles, err := h.Worktree.DiffIndex(ctx, head.String()); err != nil {
+		// Like git cherry-pick, refuse to run if there are staged changes.
 		return fmt.Errorf("check staged changes: %w", err)
 	} else if len(stagedFiles) > 0 {
 		var files []string
@@ -106,18 +148,92 @@ func (h *Handler) CherryPickCommit(ctx context.Context, req *Request) (retErr er
 		return errors.New("cannot cherry-pick with staged changes")
 	}
 
-	commit, err := h.Repository.ReadCommit(ctx, req.Commit.String())
+	// Apply all commits in-memory first
+	// so that the branch is left alone if any of them fail.
+	tip := head
+	picks := make([]state.PickedCommit, 0, len(req.Commits))
+	subjects := make([]string, 0, len(req.Commits))
+	for _, commit := range req.Commits {
+		newCommit, subject, err := h.pickCommit(ctx, commit, tip, req.Options)
+		if err != nil {
+			return err
+		}
+
+		picks = append(picks, state.PickedCommit{
+			Commit: commit,
+			From:   req.From,
+			Hash:   newCommit,
+		})
+		subjects = append(subjects, subject)
+		tip = newCommit
+	}
+
+	if checkedOut {
+		if err := h.checkLocalChanges(ctx, head, tip); err != nil {
+			return err
+		}
+
+		// Stash any local changes,
+		// reset the branch and the worktree to the new commit,
+		// and restack upstack branches before unstashing.
+		cleanup, err := h.Autostash.BeginAutostash(ctx, &autostash.Options{
+			Message:   fmt.Sprintf("git-spice: autostash before commit pick %v", req.Commits[0].Short()),
+			Branch:    req.Branch,
+			ResetMode: autostash.ResetNone, // we do our own reset
+			Command:   "commit pick",
+		})
+		if err != nil {
+			return fmt.Errorf("autostash: %w", err)
+		}
+		defer cleanup(&retErr)
+
+		if err := h.Worktree.Reset(ctx, tip.String(), git.ResetOptions{
+			Mode: git.ResetHard,
+		}); err != nil {
+			return fmt.Errorf("reset index: %w", err)
+		}
+	} else {
+		if err := h.Repository.SetRef(ctx, git.SetRefRequest{
+			Ref:     "refs/heads/" + req.Branch,
+			Hash:    tip,
+			OldHash: head,
+			Reason:  "git-spice: cherry-pick",
+		}); err != nil {
+			return fmt.Errorf("update branch %v: %w", req.Branch, err)
+		}
+	}
+
+	for i, pick := range picks {
+		h.Log.Infof("%v: cherry-picked %v: %v", req.Branch, pick.Commit.Short(), subjects[i])
+	}
+
+	// The commits are already on the branch,
+	// so failing to record them isn't fatal.
+	if err := h.Service.RecordPickedCommits(ctx, req.Branch, picks); err != nil {
+		h.Log.Warn("Could not record cherry-picked commits", "branch", req.Branch, "error", err)
+	}
+
+	return h.Restack.RestackUpstack(ctx, req.Branch, &restack.UpstackOptions{
+		SkipStart: true,
+	})
+}
+
+// pickCommit creates a commit on top of head
+// with the changes introduced by the given commit.
+// It returns the new commit and the subject of the original commit.
+func (h *Handler) pickCommit(ctx context.Context, hash, head git.Hash, opts *Options) (git.Hash, string, error) {
+	commit, err := h.Repository.ReadCommit(ctx, hash.String())
 	if err != nil {
-		return fmt.Errorf("read commit %s: %w", req.Commit, err)
+		return "", "", fmt.Errorf("read commit %s: %w", hash, err)
 	}
 
 	switch len(commit.Parents) {
 	case 0:
-		return fmt.Errorf("cannot cherry-pick root commit: %s", req.Commit)
+		return "", "", fmt.Errorf("cannot cherry-pick root commit: %s", hash)
 	case 1:
 		// ok
 	default:
-		return fmt.Errorf("cannot cherry-pick merge commit: %s", req.Commit)
+		return "", "", fmt.Errorf("cannot cherry-pick merge commit: %s", hash)
 	}
 
 	parent := commit.Parents[0]
@@ -132,23 +248,42 @@ func (h *Handler) CherryPickCommit(ctx context.Context, req *Request) (retErr er
 	if err != nil {
 		var conflictErr *git.MergeTreeConflictError
 		if !errors.As(err, &conflictErr) {
-			return fmt.Errorf("merge trees: %w", err)
+			return "", "", fmt.Errorf("merge trees: %w", err)
 		}
 
-		h.Log.Errorf("Cannot pick %v onto %v", req.Commit.Short(), head.Short())
+		h.Log.Errorf("Cannot pick %v onto %v", hash.Short(), head.Short())
 		for _, detail := range conflictErr.Details {
 			h.Log.Errorf("  %s", detail.Message)
 		}
 		h.Log.Error("Try cherry-picking with:")
-		h.Log.Error("  git cherry-pick " + req.Commit.String())
+		h.Log.Error("  git cherry-pick " + hash.String())
 
 		files := slices.Sorted(conflictErr.Filenames())
-		return fmt.Errorf("merge conflict in files: %v", strings.Join(files, ", "))
+		return "", "", fmt.Errorf("merge conflict in files: %v", strings.Join(files, ", "))
+	}
+
+	newCommit, err := h.Repository.CommitTree(ctx, git.CommitTreeRequest{
+		Tree:    mergedTree,
+		Message: commit.Message(),
+		Parents: []git.Hash{head},
+		// git cherry-pick sets committer to the current user,
+		// leaving the author intact. We'll do the same.
+		Author:  &commit.Author,
+		GPGSign: opts.SignCommits,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("create commit: %w", err)
 	}
 
-	// If there are unstaged changes or untracked files in the worktree
-	// that overlap with files changed by the cherry-pick,
-	// we cannot proceed as this may lose local changes.
+	h.Log.Debug("cherry-pick commit created",
+		"old", hash,
+		"new", newCommit)
+	return newCommit, commit.Subject, nil
+}
+
+// checkLocalChanges verifies that moving the checked out branch
+// from head to tip won't overwrite unstaged changes or untracked files.
+func (h *Handler) checkLocalChanges(ctx context.Context, head, tip git.Hash) error {
 	localChanges := make(map[string]struct{})
 	for f, err := range h.Worktree.DiffWork(ctx) {
 		if err != nil {
@@ -162,70 +297,28 @@ func (h *Handler) CherryPickCommit(ctx context.Context, req *Request) (retErr er
 		}
 		localChanges[path] = struct{}{}
 	}
+	if len(localChanges) == 0 {
+		return nil
+	}
 
-	if len(localChanges) > 0 {
-		// Diff between HEAD and the prepared tree to see what files would change.
-		var conflicts []string
-		for f, err := range h.Repository.DiffTree(ctx, head.String(), mergedTree.String()) {
-			if err != nil {
-				return fmt.Errorf("diff prepared tree: %w", err)
-			}
-			if _, ok := localChanges[f.Path]; ok {
-				conflicts = append(conflicts, f.Path)
-			}
+	// Diff between HEAD and the prepared commit to see what files would change.
+	var conflicts []string
+	for f, err := range h.Repository.DiffTree(ctx, head.String(), tip.String()) {
+		if err != nil {
+			return fmt.Errorf("diff prepared tree: %w", err)
 		}
-
-		sort.Strings(conflicts)
-		if len(conflicts) > 0 {
-			h.Log.Error("Local changes would be overwritten by cherry-pick:")
-			for _, f := range conflicts {
-				h.Log.Error("  " + f)
-			}
-			return errors.New("cherry-pick would overwrite local changes")
+		if _, ok := localChanges[f.Path]; ok {
+			conflicts = append(conflicts, f.Path)
 		}
 	}
 
-	// Safe to proceed with these changes.
-	newCommit, err := h.Repository.CommitTree(ctx, git.CommitTreeRequest{
-		Tree:    mergedTree,
-		Message: commit.Message(),
-		Parents: []git.Hash{head},
-		// git cherry-pick sets committer to the current user,
-		// leaving the author intact. We'll do the same.
-		Author:  &commit.Author,
-		GPGSign: req.Options.SignCommits,
-	})
-	if err != nil {
-		return fmt.Errorf("create commit: %w", err)
-	}
-
-	h.Log.Debug("cherry-pick commit created",
-		"old", req.Commit,
-		"new", newCommit)
-
-	// Commit successful.
-	// Stash any local changes,
-	// reset the branch and the worktree to the new commit,
-	// and restack upstack branches before unstashing.
-	cleanup, err := h.Autostash.BeginAutostash(ctx, &autostash.Options{
-		Message:   fmt.Sprintf("git-spice: autostash before commit pick %v", req.Commit.Short()),
-		Branch:    req.Branch,
-		ResetMode: autostash.ResetNone, // we do our own reset
-	})
-	if err != nil {
-		return fmt.Errorf("autostash: %w", err)
-	}
-	defer cleanup(&retErr)
-
-	if err := h.Worktree.Reset(ctx, newCommit.String(), git.ResetOptions{
-		Mode: git.ResetHard,
-	}); err != nil {
-		return fmt.Errorf("reset index: %w", err)
+	sort.Strings(conflicts)
+	if len(conflicts) > 0 {
+		h.Log.Error("Local changes would be overwritten by cherry-pick:")
+		for _, f := range conflicts {
+			h.Log.Error("  " + f)
+		}
+		return errors.New("cherry-pick would overwrite local changes")
 	}
-
-	h.Log.Infof("%v: cherry-picked %v: %v", req.Branch, req.Commit.Short(), commit.Subject)
-
-	return h.Restack.RestackUpstack(ctx, req.Branch, &restack.UpstackOptions{
-		SkipStart: true,
-	})
+	return nil
 }
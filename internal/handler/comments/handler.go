@@ -0,0 +1,184 @@
+// Package comments implements a Handler to act on
+// review comments left on Change Requests.
+package comments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/must"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+//go:generate mockgen -destination mocks_test.go -package comments -typed . Service
+
+// Service is a subset of spice.Service.
+type Service interface {
+	LookupBranch(ctx context.Context, name string) (*spice.LookupBranchResponse, error)
+}
+
+var _ Service = (*spice.Service)(nil)
+
+// Handler acts on review comments on Change Requests.
+type Handler struct {
+	Log              *silog.Logger    // required
+	View             ui.View          // required
+	Service          Service          // required
+	RemoteRepository forge.Repository // required
+}
+
+// ResolveRequest is a request to resolve review threads
+// on the Change Request associated with a branch.
+type ResolveRequest struct {
+	// Branch whose Change Request's threads should be resolved.
+	Branch string // required
+
+	// All resolves all unresolved threads.
+	All bool
+
+	// Outdated resolves unresolved threads
+	// on lines that have since changed.
+	Outdated bool
+}
+
+// ResolveThreads resolves review threads
+// on the Change Request associated with a branch.
+//
+// Threads are selected with the All and Outdated options.
+// If neither is set, the user is prompted to pick threads
+// from the unresolved threads on the Change Request.
+func (h *Handler) ResolveThreads(ctx context.Context, req *ResolveRequest) error {
+	must.NotBeBlankf(req.Branch, "branch must not be blank")
+
+	branch, err := h.Service.LookupBranch(ctx, req.Branch)
+	if err != nil {
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+	if branch.Change == nil {
+		return fmt.Errorf("%v: branch has not been submitted", req.Branch)
+	}
+
+	repo, ok := h.RemoteRepository.(forge.WithReviewThreads)
+	if !ok {
+		return fmt.Errorf("%v: review threads are not supported", h.RemoteRepository.Forge().ID())
+	}
+
+	changeID := branch.Change.ChangeID()
+	changeName := forge.FormatChangeID(repo.Forge(), changeID)
+
+	var unresolved []*forge.ChangeThread
+	for thread, err := range repo.ListChangeThreads(ctx, changeID) {
+		if err != nil {
+			return fmt.Errorf("list review threads: %w", err)
+		}
+		if !thread.Resolved {
+			unresolved = append(unresolved, thread)
+		}
+	}
+	if len(unresolved) == 0 {
+		h.Log.Infof("%v: no unresolved threads", changeName)
+		return nil
+	}
+
+	var threads []*forge.ChangeThread
+	switch {
+	case req.All:
+		threads = unresolved
+
+	case req.Outdated:
+		for _, thread := range unresolved {
+			if thread.Outdated {
+				threads = append(threads, thread)
+			}
+		}
+
+	default:
+		threads, err = h.selectThreads(changeName, unresolved)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(threads) == 0 {
+		h.Log.Infof("%v: no threads to resolve", changeName)
+		return nil
+	}
+
+	var resolved int
+	for _, thread := range threads {
+		if err := repo.ResolveThread(ctx, thread.ID, true); err != nil {
+			h.Log.Warn("Could not resolve thread", "thread", thread.ID, "error", err)
+			continue
+		}
+		resolved++
+	}
+
+	h.Log.Infof("%v: resolved %d thread(s)", changeName, resolved)
+	if resolved < len(threads) {
+		return fmt.Errorf("could not resolve %d thread(s)", len(threads)-resolved)
+	}
+	return nil
+}
+
+// selectThreads prompts the user to pick threads to resolve.
+func (h *Handler) selectThreads(changeName string, threads []*forge.ChangeThread) ([]*forge.ChangeThread, error) {
+	if !ui.Interactive(h.View) {
+		return nil, errors.New("use --all or --outdated to pick threads to resolve")
+	}
+
+	opts := make([]ui.MultiSelectOption[*forge.ChangeThread], len(threads))
+	for i, thread := range threads {
+		opts[i] = ui.MultiSelectOption[*forge.ChangeThread]{
+			Value:    thread,
+			Selected: thread.Outdated,
+		}
+	}
+
+	field := ui.NewMultiSelect(renderThread).
+		WithTitle("Resolve threads").
+		WithDescription(fmt.Sprintf("Select threads on %v to resolve", changeName)).
+		WithOptions(opts...)
+	field.KeyMap.Toggle.SetHelp("space", "toggle")
+
+	if err := ui.Run(h.View, field); err != nil {
+		return nil, fmt.Errorf("select threads: %w", err)
+	}
+	return field.Value(), nil
+}
+
+func renderThread(w ui.Writer, _ int, opt ui.MultiSelectOption[*forge.ChangeThread]) {
+	if opt.Selected {
+		w.WriteString(ui.Glyph("☑", "[x]"))
+	} else {
+		w.WriteString(ui.Glyph("☐", "[ ]"))
+	}
+	w.WriteString(" ")
+
+	thread := opt.Value
+	if thread.Path != "" {
+		w.WriteString(thread.Path)
+		if thread.Line > 0 {
+			_, _ = fmt.Fprintf(w, ":%d", thread.Line)
+		}
+		w.WriteString(" ")
+	}
+	if thread.Outdated {
+		w.WriteString("(outdated) ")
+	}
+	w.WriteString(firstLine(thread.Body))
+}
+
+// firstLine returns the first non-blank line of s.
+func firstLine(s string) string {
+	for line := range strings.Lines(s) {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+func TestHandler_ResolveThreads(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads []*forge.ChangeThread
+		req     ResolveRequest
+
+		want    []string // IDs of threads that should be resolved
+		wantErr string
+	}{
+		{
+			name: "All",
+			threads: []*forge.ChangeThread{
+				{ID: threadID(1)},
+				{ID: threadID(2), Outdated: true},
+				{ID: threadID(3), Resolved: true},
+			},
+			req:  ResolveRequest{All: true},
+			want: []string{"1", "2"},
+		},
+		{
+			name: "Outdated",
+			threads: []*forge.ChangeThread{
+				{ID: threadID(1)},
+				{ID: threadID(2), Outdated: true},
+				{ID: threadID(3), Outdated: true, Resolved: true},
+			},
+			req:  ResolveRequest{Outdated: true},
+			want: []string{"2"},
+		},
+		{
+			name: "NoUnresolved",
+			threads: []*forge.ChangeThread{
+				{ID: threadID(1), Resolved: true},
+			},
+			req: ResolveRequest{All: true},
+		},
+		{
+			name: "NotInteractive",
+			threads: []*forge.ChangeThread{
+				{ID: threadID(1)},
+			},
+			wantErr: "use --all or --outdated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockService(gomock.NewController(t))
+			mockService.EXPECT().
+				LookupBranch(gomock.Any(), "feature").
+				Return(&spice.LookupBranchResponse{
+					Change: &forgetest.FakeChangeMetadata{Number: 1},
+				}, nil)
+
+			remoteRepo := &threadsRepository{
+				FakeRepository: forgetest.NewFakeRepository(),
+				threads:        tt.threads,
+			}
+
+			req := tt.req
+			req.Branch = "feature"
+			err := (&Handler{
+				Log:              silogtest.New(t),
+				View:             &ui.FileView{W: t.Output()},
+				Service:          mockService,
+				RemoteRepository: remoteRepo,
+			}).ResolveThreads(t.Context(), &req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, remoteRepo.resolved)
+		})
+	}
+}
+
+func TestHandler_ResolveThreads_unsupported(t *testing.T) {
+	mockService := NewMockService(gomock.NewController(t))
+	mockService.EXPECT().
+		LookupBranch(gomock.Any(), "feature").
+		Return(&spice.LookupBranchResponse{
+			Change: &forgetest.FakeChangeMetadata{Number: 1},
+		}, nil)
+
+	err := (&Handler{
+		Log:              silogtest.New(t),
+		View:             &ui.FileView{W: t.Output()},
+		Service:          mockService,
+		RemoteRepository: forgetest.NewFakeRepository(),
+	}).ResolveThreads(t.Context(), &ResolveRequest{Branch: "feature", All: true})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "review threads are not supported")
+}
+
+type threadID int
+
+func (id threadID) String() string { return strconv.Itoa(int(id)) }
+
+// threadsRepository is a FakeRepository with review threads.
+type threadsRepository struct {
+	*forgetest.FakeRepository
+
+	threads  []*forge.ChangeThread
+	resolved []string
+}
+
+var _ forge.WithReviewThreads = (*threadsRepository)(nil)
+
+func (r *threadsRepository) ListChangeThreads(
+	context.Context, forge.ChangeID,
+) iter.Seq2[*forge.ChangeThread, error] {
+	return func(yield func(*forge.ChangeThread, error) bool) {
+		for _, thread := range r.threads {
+			if !yield(thread, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *threadsRepository) ResolveThread(_ context.Context, id forge.ChangeThreadID, resolved bool) error {
+	if !resolved {
+		return errors.New("unexpected unresolve")
+	}
+	r.resolved = append(r.resolved, id.String())
+	return nil
+}
@@ -0,0 +1,347 @@
+// Package conflict implements an interactive assistant
+// for resolving conflicts that interrupt a rebase.
+//
+// The assistant shows the branch and commit that hit a conflict
+// and where they are in the stack,
+// offers to enable 'git rerere' so that resolutions are recorded,
+// and allows resolving conflicted files by taking one side wholesale
+// or with the user's merge tool.
+// If conflicts remain, the user may resolve them by hand
+// or abort the rebase.
+// With rerere enabled, conflicts that were resolved before
+// are resolved automatically when the same rebase is replayed.
+package conflict
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+//go:generate mockgen -package conflict -typed -destination mocks_test.go . GitRepository,GitWorktree
+
+// GitRepository is a subset of the git.Repository interface.
+type GitRepository interface {
+	PeelToCommit(ctx context.Context, ref string) (git.Hash, error)
+	CommitSubject(ctx context.Context, commitish string) (string, error)
+	RerereEnabled(ctx context.Context) bool
+	EnableRerere(ctx context.Context) error
+}
+
+var _ GitRepository = (*git.Repository)(nil)
+
+// GitWorktree is a subset of the git.Worktree interface.
+type GitWorktree interface {
+	ListFilesPaths(ctx context.Context, opts *git.ListFilesOptions) iter.Seq2[string, error]
+	ResolveConflict(ctx context.Context, path string, side git.ConflictSide) error
+	Mergetool(ctx context.Context, path string) error
+	Rerere(ctx context.Context) error
+	RebaseContinue(ctx context.Context, opts *git.RebaseContinueOptions) error
+	RebaseAbort(ctx context.Context) error
+}
+
+var _ GitWorktree = (*git.Worktree)(nil)
+
+// Handler assists with resolving rebase conflicts.
+type Handler struct {
+	Log        *silog.Logger // required
+	View       ui.View       // required
+	Repository GitRepository // required
+	Worktree   GitWorktree   // required
+
+	// rerereOffered is set after the user has been offered
+	// to enable rerere, so that they're asked at most once.
+	rerereOffered bool
+}
+
+// Request is a request to resolve a conflict that interrupted a rebase.
+type Request struct {
+	// Branch is the branch being rebased.
+	Branch string // required
+
+	// Base is the branch that Branch is being rebased onto.
+	Base string // required
+
+	// Err is the error that interrupted the rebase.
+	Err *git.RebaseInterruptError // required
+
+	// Pending lists the branches that will be restacked
+	// after Branch, in order.
+	// It's used to show where the conflict is in the stack.
+	Pending []string
+}
+
+// ErrAborted indicates that the user chose to abort the rebase
+// instead of resolving its conflicts.
+// The rebase has already been aborted when this is returned.
+var ErrAborted = errors.New("rebase aborted")
+
+// Action is the action chosen for a conflicted file.
+type Action int
+
+const (
+	// ActionManual leaves the file for the user to resolve by hand.
+	ActionManual Action = iota
+
+	// ActionOurs resolves the file with the base branch's version.
+	ActionOurs
+
+	// ActionTheirs resolves the file with the rebased branch's version.
+	ActionTheirs
+
+	// ActionMergetool opens the user's merge tool for the file.
+	ActionMergetool
+)
+
+// nextStep is what to do if conflicts remain
+// after going through all conflicted files.
+type nextStep int
+
+const (
+	// nextStepManual leaves the rebase interrupted
+	// for the user to resolve the conflicts by hand.
+	nextStepManual nextStep = iota
+
+	// nextStepAbort aborts the rebase.
+	nextStepAbort
+)
+
+// ResolveConflicts helps resolve the conflict that interrupted a rebase,
+// and continues the rebase if all conflicted files are resolved.
+//
+// It returns nil if the rebase ran to completion,
+// and [ErrAborted] if the user chose to abort it.
+// If the rebase is still interrupted,
+// it returns the [git.RebaseInterruptError] for the latest interruption.
+// This may be different from the error in the request
+// if the rebase hit more conflicts after continuing.
+//
+// The assistant does nothing if the view is not interactive,
+// or if the rebase was interrupted deliberately (e.g. by 'break').
+func (h *Handler) ResolveConflicts(ctx context.Context, req *Request) error {
+	rebaseErr := req.Err
+	for {
+		if rebaseErr.Kind != git.RebaseInterruptConflict || !ui.Interactive(h.View) {
+			return rebaseErr
+		}
+
+		done, err := h.resolveOnce(ctx, req)
+		if errors.Is(err, ErrAborted) {
+			return err
+		}
+		if err != nil {
+			h.Log.Warn("Conflict assistant failed", "error", err)
+			return rebaseErr
+		}
+		if !done {
+			return rebaseErr
+		}
+
+		err = h.Worktree.RebaseContinue(ctx, &git.RebaseContinueOptions{
+			Editor: "true", // keep the original commit message
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !errors.As(err, &rebaseErr) {
+			return fmt.Errorf("continue rebase: %w", err)
+		}
+		// Hit another conflict. Go again.
+	}
+}
+
+// resolveOnce handles the conflicted files of the commit being applied.
+// It reports whether all of them were resolved.
+func (h *Handler) resolveOnce(ctx context.Context, req *Request) (bool, error) {
+	files, err := h.unmergedFiles(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if len(files) == 0 {
+		// If rerere (with autoUpdate) resolved all conflicts,
+		// there's nothing left for the user to do.
+		h.Log.Infof("%v: conflicts resolved using recorded resolutions", req.Branch)
+		return true, nil
+	}
+
+	h.printSummary(ctx, req, files)
+
+	if err := h.offerRerere(ctx); err != nil {
+		return false, err
+	}
+
+	var unresolved int
+	for _, file := range files {
+		action := ActionManual
+		prompt := ui.NewSelect[Action]().
+			WithTitle(fmt.Sprintf("Resolve %v", file)).
+			WithDescription(fmt.Sprintf("Conflict while restacking %v onto %v", req.Branch, req.Base)).
+			WithValue(&action).
+			WithOptions(
+				ui.SelectOption[Action]{
+					Label: "Resolve manually",
+					Value: ActionManual,
+				},
+				ui.SelectOption[Action]{
+					Label: "Keep version from " + req.Base + " (ours)",
+					Value: ActionOurs,
+				},
+				ui.SelectOption[Action]{
+					Label: "Keep version from " + req.Branch + " (theirs)",
+					Value: ActionTheirs,
+				},
+				ui.SelectOption[Action]{
+					Label: "Open merge tool",
+					Value: ActionMergetool,
+				},
+			)
+		if err := ui.Run(h.View, prompt); err != nil {
+			return false, fmt.Errorf("prompt: %w", err)
+		}
+
+		var side git.ConflictSide
+		switch action {
+		case ActionManual:
+			unresolved++
+			continue
+		case ActionOurs:
+			side = git.ConflictOurs
+		case ActionTheirs:
+			side = git.ConflictTheirs
+		case ActionMergetool:
+			// git mergetool fails if the tool didn't resolve the file,
+			// e.g. if the user quit without saving.
+			if err := h.Worktree.Mergetool(ctx, file); err != nil {
+				h.Log.Warnf("%v: not resolved by merge tool: %v", file, err)
+				unresolved++
+			} else {
+				h.Log.Infof("%v: resolved using merge tool", file)
+			}
+			continue
+		default:
+			return false, fmt.Errorf("unknown action: %v", action)
+		}
+
+		if err := h.Worktree.ResolveConflict(ctx, file, side); err != nil {
+			return false, fmt.Errorf("resolve %v: %w", file, err)
+		}
+		h.Log.Infof("%v: resolved using %v version", file, side)
+	}
+
+	if unresolved == 0 {
+		return true, nil
+	}
+
+	next := nextStepManual
+	prompt := ui.NewSelect[nextStep]().
+		WithTitle("Conflicts remain").
+		WithDescription(fmt.Sprintf("%d of %d files still have conflicts", unresolved, len(files))).
+		WithValue(&next).
+		WithOptions(
+			ui.SelectOption[nextStep]{
+				Label: fmt.Sprintf("Resolve by hand, then run '%v rebase continue'", cli.Name()),
+				Value: nextStepManual,
+			},
+			ui.SelectOption[nextStep]{
+				Label: "Abort the rebase",
+				Value: nextStepAbort,
+			},
+		)
+	if err := ui.Run(h.View, prompt); err != nil {
+		return false, fmt.Errorf("prompt: %w", err)
+	}
+
+	if next == nextStepAbort {
+		if err := h.Worktree.RebaseAbort(ctx); err != nil {
+			return false, err
+		}
+		h.Log.Infof("%v: rebase aborted", req.Branch)
+		return false, ErrAborted
+	}
+
+	return false, nil
+}
+
+// printSummary describes the conflict:
+// the commit that hit it, which side is which,
+// the conflicted files, and the rest of the stack.
+func (h *Handler) printSummary(ctx context.Context, req *Request, files []string) {
+	commitDesc := "a commit"
+	if hash, err := h.Repository.PeelToCommit(ctx, "REBASE_HEAD"); err == nil {
+		commitDesc = hash.Short()
+		if subject, err := h.Repository.CommitSubject(ctx, hash.String()); err == nil {
+			commitDesc += " (" + subject + ")"
+		}
+	}
+
+	h.Log.Warnf("%v: conflict while applying %v onto %v", req.Branch, commitDesc, req.Base)
+	h.Log.Warnf("  ours:   %v, the base being restacked onto", req.Base)
+	h.Log.Warnf("  theirs: %v, the branch being restacked", req.Branch)
+	h.Log.Warn("Conflicted files:")
+	for _, f := range files {
+		h.Log.Warn("  " + silog.MaybeQuote(f))
+	}
+	if len(req.Pending) > 0 {
+		h.Log.Warnf("Waiting to be restacked: %v", strings.Join(req.Pending, ", "))
+	}
+
+	h.Log.Info("To resolve a file by hand, edit it and mark it resolved with 'git add',")
+	h.Log.Info("or open your merge tool with 'git mergetool'.")
+}
+
+// offerRerere offers to enable rerere if it isn't already enabled.
+// The user is asked at most once per Handler.
+func (h *Handler) offerRerere(ctx context.Context) error {
+	if h.rerereOffered || h.Repository.RerereEnabled(ctx) {
+		return nil
+	}
+	h.rerereOffered = true
+
+	enable := true
+	prompt := ui.NewConfirm().
+		WithTitle("Enable git rerere?").
+		WithDescription("Record conflict resolutions so that the same conflicts " +
+			"are resolved automatically if they come up again.").
+		WithValue(&enable)
+	if err := ui.Run(h.View, prompt); err != nil {
+		return fmt.Errorf("prompt: %w", err)
+	}
+	if !enable {
+		return nil
+	}
+
+	if err := h.Repository.EnableRerere(ctx); err != nil {
+		return fmt.Errorf("enable rerere: %w", err)
+	}
+
+	// rerere was not enabled when the conflict happened,
+	// so it did not record the conflicted state.
+	// Record it now so that the resolution is recorded on continue.
+	if err := h.Worktree.Rerere(ctx); err != nil {
+		return err
+	}
+	h.Log.Info("Enabled git rerere for this repository")
+	return nil
+}
+
+func (h *Handler) unmergedFiles(ctx context.Context) ([]string, error) {
+	var files []string
+	for path, err := range h.Worktree.ListFilesPaths(ctx, &git.ListFilesOptions{Unmerged: true}) {
+		if err != nil {
+			return nil, fmt.Errorf("list unmerged files: %w", err)
+		}
+		files = append(files, path)
+	}
+	slices.Sort(files)
+	return files, nil
+}
@@ -0,0 +1,335 @@
+package conflict
+
+import (
+	"errors"
+	"iter"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/text"
+	"go.abhg.dev/gs/internal/ui"
+	"go.abhg.dev/gs/internal/ui/uitest"
+)
+
+func TestHandler_ResolveConflicts_notInteractive(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rebaseErr := &git.RebaseInterruptError{
+		Kind: git.RebaseInterruptConflict,
+	}
+
+	err := (&Handler{
+		Log:        silogtest.New(t),
+		View:       &ui.FileView{W: t.Output()},
+		Repository: NewMockGitRepository(mockCtrl),
+		Worktree:   NewMockGitWorktree(mockCtrl),
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    rebaseErr,
+	})
+	assert.Same(t, rebaseErr, err)
+}
+
+func TestHandler_ResolveConflicts_deliberateInterrupt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rebaseErr := &git.RebaseInterruptError{
+		Kind: git.RebaseInterruptDeliberate,
+	}
+
+	err := (&Handler{
+		Log:        silogtest.New(t),
+		View:       robotView(t, ""),
+		Repository: NewMockGitRepository(mockCtrl),
+		Worktree:   NewMockGitWorktree(mockCtrl),
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    rebaseErr,
+	})
+	assert.Same(t, rebaseErr, err)
+}
+
+func TestHandler_ResolveConflicts_resolvedByRerere(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths())
+	mockWorktree.EXPECT().
+		RebaseContinue(gomock.Any(), &git.RebaseContinueOptions{Editor: "true"}).
+		Return(nil)
+
+	err := (&Handler{
+		Log:        silogtest.New(t),
+		View:       robotView(t, ""),
+		Repository: NewMockGitRepository(mockCtrl),
+		Worktree:   mockWorktree,
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict},
+	})
+	require.NoError(t, err)
+}
+
+func TestHandler_ResolveConflicts_pickSides(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockRepo := NewMockGitRepository(mockCtrl)
+	mockRepo.EXPECT().
+		PeelToCommit(gomock.Any(), "REBASE_HEAD").
+		Return(git.Hash("abcdef1234567890"), nil)
+	mockRepo.EXPECT().
+		CommitSubject(gomock.Any(), "abcdef1234567890").
+		Return("Add feature", nil)
+	mockRepo.EXPECT().
+		RerereEnabled(gomock.Any()).
+		Return(false)
+	mockRepo.EXPECT().
+		EnableRerere(gomock.Any()).
+		Return(nil)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths("b.txt", "a.txt"))
+	mockWorktree.EXPECT().
+		Rerere(gomock.Any()).
+		Return(nil)
+	mockWorktree.EXPECT().
+		ResolveConflict(gomock.Any(), "a.txt", git.ConflictOurs).
+		Return(nil)
+	mockWorktree.EXPECT().
+		ResolveConflict(gomock.Any(), "b.txt", git.ConflictTheirs).
+		Return(nil)
+	mockWorktree.EXPECT().
+		RebaseContinue(gomock.Any(), &git.RebaseContinueOptions{Editor: "true"}).
+		Return(nil)
+
+	err := (&Handler{
+		Log: silogtest.New(t),
+		View: robotView(t, `
+			true
+			===
+			"Keep version from main (ours)"
+			===
+			"Keep version from feature (theirs)"
+		`),
+		Repository: mockRepo,
+		Worktree:   mockWorktree,
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict},
+	})
+	require.NoError(t, err)
+}
+
+func TestHandler_ResolveConflicts_manual(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockRepo := NewMockGitRepository(mockCtrl)
+	mockRepo.EXPECT().
+		PeelToCommit(gomock.Any(), "REBASE_HEAD").
+		Return(git.Hash("abcdef1234567890"), nil)
+	mockRepo.EXPECT().
+		CommitSubject(gomock.Any(), "abcdef1234567890").
+		Return("Add feature", nil)
+	mockRepo.EXPECT().
+		RerereEnabled(gomock.Any()).
+		Return(true)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths("a.txt"))
+
+	rebaseErr := &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict}
+	err := (&Handler{
+		Log: silogtest.New(t),
+		View: robotView(t, `
+			"Resolve manually"
+			===
+			"Resolve by hand, then run 'gs rebase continue'"
+		`),
+		Repository: mockRepo,
+		Worktree:   mockWorktree,
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    rebaseErr,
+	})
+	assert.Same(t, rebaseErr, err)
+}
+
+func TestHandler_ResolveConflicts_nextConflict(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockRepo := NewMockGitRepository(mockCtrl)
+	mockRepo.EXPECT().
+		PeelToCommit(gomock.Any(), "REBASE_HEAD").
+		Return(git.Hash("abcdef1234567890"), nil).
+		Times(2)
+	mockRepo.EXPECT().
+		CommitSubject(gomock.Any(), "abcdef1234567890").
+		Return("Add feature", nil).
+		Times(2)
+	mockRepo.EXPECT().
+		RerereEnabled(gomock.Any()).
+		Return(true).
+		Times(2)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths("a.txt"))
+	mockWorktree.EXPECT().
+		ResolveConflict(gomock.Any(), "a.txt", git.ConflictTheirs).
+		Return(nil)
+
+	nextErr := &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict}
+	mockWorktree.EXPECT().
+		RebaseContinue(gomock.Any(), gomock.Any()).
+		Return(nextErr)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths("b.txt"))
+
+	err := (&Handler{
+		Log: silogtest.New(t),
+		View: robotView(t, `
+			"Keep version from feature (theirs)"
+			===
+			"Resolve manually"
+			===
+			"Resolve by hand, then run 'gs rebase continue'"
+		`),
+		Repository: mockRepo,
+		Worktree:   mockWorktree,
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict},
+	})
+	assert.Same(t, nextErr, err)
+}
+
+func TestHandler_ResolveConflicts_mergetool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockRepo := NewMockGitRepository(mockCtrl)
+	mockRepo.EXPECT().
+		PeelToCommit(gomock.Any(), "REBASE_HEAD").
+		Return(git.Hash("abcdef1234567890"), nil)
+	mockRepo.EXPECT().
+		CommitSubject(gomock.Any(), "abcdef1234567890").
+		Return("Add feature", nil)
+	mockRepo.EXPECT().
+		RerereEnabled(gomock.Any()).
+		Return(true)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths("a.txt", "b.txt"))
+	mockWorktree.EXPECT().
+		Mergetool(gomock.Any(), "a.txt").
+		Return(nil)
+	mockWorktree.EXPECT().
+		Mergetool(gomock.Any(), "b.txt").
+		Return(errors.New("merge of b.txt failed"))
+
+	// b.txt was not resolved, so the rebase is left interrupted.
+	rebaseErr := &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict}
+	err := (&Handler{
+		Log: silogtest.New(t),
+		View: robotView(t, `
+			"Open merge tool"
+			===
+			"Open merge tool"
+			===
+			"Resolve by hand, then run 'gs rebase continue'"
+		`),
+		Repository: mockRepo,
+		Worktree:   mockWorktree,
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch:  "feature",
+		Base:    "main",
+		Err:     rebaseErr,
+		Pending: []string{"feature2", "feature3"},
+	})
+	assert.Same(t, rebaseErr, err)
+}
+
+func TestHandler_ResolveConflicts_abort(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockRepo := NewMockGitRepository(mockCtrl)
+	mockRepo.EXPECT().
+		PeelToCommit(gomock.Any(), "REBASE_HEAD").
+		Return(git.Hash("abcdef1234567890"), nil)
+	mockRepo.EXPECT().
+		CommitSubject(gomock.Any(), "abcdef1234567890").
+		Return("Add feature", nil)
+	mockRepo.EXPECT().
+		RerereEnabled(gomock.Any()).
+		Return(true)
+
+	mockWorktree := NewMockGitWorktree(mockCtrl)
+	mockWorktree.EXPECT().
+		ListFilesPaths(gomock.Any(), &git.ListFilesOptions{Unmerged: true}).
+		Return(paths("a.txt"))
+	mockWorktree.EXPECT().
+		RebaseAbort(gomock.Any()).
+		Return(nil)
+
+	err := (&Handler{
+		Log: silogtest.New(t),
+		View: robotView(t, `
+			"Resolve manually"
+			===
+			"Abort the rebase"
+		`),
+		Repository: mockRepo,
+		Worktree:   mockWorktree,
+	}).ResolveConflicts(t.Context(), &Request{
+		Branch: "feature",
+		Base:   "main",
+		Err:    &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict},
+	})
+	assert.ErrorIs(t, err, ErrAborted)
+}
+
+func robotView(t *testing.T, fixture string) *uitest.RobotView {
+	t.Helper()
+
+	fixtureFile := filepath.Join(t.TempDir(), "fixture")
+	require.NoError(t, os.WriteFile(fixtureFile, []byte(text.Dedent(fixture)), 0o644))
+
+	view, err := uitest.NewRobotView(fixtureFile, &uitest.RobotViewOptions{
+		LogOutput: t.Output(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, view.Close())
+	})
+	return view
+}
+
+func paths(ps ...string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for _, p := range ps {
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
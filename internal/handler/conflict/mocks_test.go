@@ -0,0 +1,449 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: go.abhg.dev/gs/internal/handler/conflict (interfaces: GitRepository,GitWorktree)
+//
+// Generated by this command:
+//
+//	mockgen -package conflict -typed -destination mocks_test.go . GitRepository,GitWorktree
+//
+
+// Package conflict is a generated GoMock package.
+package conflict
+
+import (
+	context "context"
+	iter "iter"
+	reflect "reflect"
+
+	git "go.abhg.dev/gs/internal/git"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGitRepository is a mock of GitRepository interface.
+type MockGitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGitRepositoryMockRecorder is the mock recorder for MockGitRepository.
+type MockGitRepositoryMockRecorder struct {
+	mock *MockGitRepository
+}
+
+// NewMockGitRepository creates a new mock instance.
+func NewMockGitRepository(ctrl *gomock.Controller) *MockGitRepository {
+	mock := &MockGitRepository{ctrl: ctrl}
+	mock.recorder = &MockGitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitRepository) EXPECT() *MockGitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CommitSubject mocks base method.
+func (m *MockGitRepository) CommitSubject(ctx context.Context, commitish string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitSubject", ctx, commitish)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitSubject indicates an expected call of CommitSubject.
+func (mr *MockGitRepositoryMockRecorder) CommitSubject(ctx, commitish any) *MockGitRepositoryCommitSubjectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitSubject", reflect.TypeOf((*MockGitRepository)(nil).CommitSubject), ctx, commitish)
+	return &MockGitRepositoryCommitSubjectCall{Call: call}
+}
+
+// MockGitRepositoryCommitSubjectCall wrap *gomock.Call
+type MockGitRepositoryCommitSubjectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepositoryCommitSubjectCall) Return(arg0 string, arg1 error) *MockGitRepositoryCommitSubjectCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepositoryCommitSubjectCall) Do(f func(context.Context, string) (string, error)) *MockGitRepositoryCommitSubjectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepositoryCommitSubjectCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockGitRepositoryCommitSubjectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// EnableRerere mocks base method.
+func (m *MockGitRepository) EnableRerere(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableRerere", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableRerere indicates an expected call of EnableRerere.
+func (mr *MockGitRepositoryMockRecorder) EnableRerere(ctx any) *MockGitRepositoryEnableRerereCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableRerere", reflect.TypeOf((*MockGitRepository)(nil).EnableRerere), ctx)
+	return &MockGitRepositoryEnableRerereCall{Call: call}
+}
+
+// MockGitRepositoryEnableRerereCall wrap *gomock.Call
+type MockGitRepositoryEnableRerereCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepositoryEnableRerereCall) Return(arg0 error) *MockGitRepositoryEnableRerereCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepositoryEnableRerereCall) Do(f func(context.Context) error) *MockGitRepositoryEnableRerereCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepositoryEnableRerereCall) DoAndReturn(f func(context.Context) error) *MockGitRepositoryEnableRerereCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PeelToCommit mocks base method.
+func (m *MockGitRepository) PeelToCommit(ctx context.Context, ref string) (git.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeelToCommit", ctx, ref)
+	ret0, _ := ret[0].(git.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeelToCommit indicates an expected call of PeelToCommit.
+func (mr *MockGitRepositoryMockRecorder) PeelToCommit(ctx, ref any) *MockGitRepositoryPeelToCommitCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeelToCommit", reflect.TypeOf((*MockGitRepository)(nil).PeelToCommit), ctx, ref)
+	return &MockGitRepositoryPeelToCommitCall{Call: call}
+}
+
+// MockGitRepositoryPeelToCommitCall wrap *gomock.Call
+type MockGitRepositoryPeelToCommitCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepositoryPeelToCommitCall) Return(arg0 git.Hash, arg1 error) *MockGitRepositoryPeelToCommitCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepositoryPeelToCommitCall) Do(f func(context.Context, string) (git.Hash, error)) *MockGitRepositoryPeelToCommitCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepositoryPeelToCommitCall) DoAndReturn(f func(context.Context, string) (git.Hash, error)) *MockGitRepositoryPeelToCommitCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RerereEnabled mocks base method.
+func (m *MockGitRepository) RerereEnabled(ctx context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RerereEnabled", ctx)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RerereEnabled indicates an expected call of RerereEnabled.
+func (mr *MockGitRepositoryMockRecorder) RerereEnabled(ctx any) *MockGitRepositoryRerereEnabledCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RerereEnabled", reflect.TypeOf((*MockGitRepository)(nil).RerereEnabled), ctx)
+	return &MockGitRepositoryRerereEnabledCall{Call: call}
+}
+
+// MockGitRepositoryRerereEnabledCall wrap *gomock.Call
+type MockGitRepositoryRerereEnabledCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepositoryRerereEnabledCall) Return(arg0 bool) *MockGitRepositoryRerereEnabledCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepositoryRerereEnabledCall) Do(f func(context.Context) bool) *MockGitRepositoryRerereEnabledCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepositoryRerereEnabledCall) DoAndReturn(f func(context.Context) bool) *MockGitRepositoryRerereEnabledCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockGitWorktree is a mock of GitWorktree interface.
+type MockGitWorktree struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitWorktreeMockRecorder
+	isgomock struct{}
+}
+
+// MockGitWorktreeMockRecorder is the mock recorder for MockGitWorktree.
+type MockGitWorktreeMockRecorder struct {
+	mock *MockGitWorktree
+}
+
+// NewMockGitWorktree creates a new mock instance.
+func NewMockGitWorktree(ctrl *gomock.Controller) *MockGitWorktree {
+	mock := &MockGitWorktree{ctrl: ctrl}
+	mock.recorder = &MockGitWorktreeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitWorktree) EXPECT() *MockGitWorktreeMockRecorder {
+	return m.recorder
+}
+
+// ListFilesPaths mocks base method.
+func (m *MockGitWorktree) ListFilesPaths(ctx context.Context, opts *git.ListFilesOptions) iter.Seq2[string, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFilesPaths", ctx, opts)
+	ret0, _ := ret[0].(iter.Seq2[string, error])
+	return ret0
+}
+
+// ListFilesPaths indicates an expected call of ListFilesPaths.
+func (mr *MockGitWorktreeMockRecorder) ListFilesPaths(ctx, opts any) *MockGitWorktreeListFilesPathsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFilesPaths", reflect.TypeOf((*MockGitWorktree)(nil).ListFilesPaths), ctx, opts)
+	return &MockGitWorktreeListFilesPathsCall{Call: call}
+}
+
+// MockGitWorktreeListFilesPathsCall wrap *gomock.Call
+type MockGitWorktreeListFilesPathsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeListFilesPathsCall) Return(arg0 iter.Seq2[string, error]) *MockGitWorktreeListFilesPathsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeListFilesPathsCall) Do(f func(context.Context, *git.ListFilesOptions) iter.Seq2[string, error]) *MockGitWorktreeListFilesPathsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeListFilesPathsCall) DoAndReturn(f func(context.Context, *git.ListFilesOptions) iter.Seq2[string, error]) *MockGitWorktreeListFilesPathsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mergetool mocks base method.
+func (m *MockGitWorktree) Mergetool(ctx context.Context, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mergetool", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mergetool indicates an expected call of Mergetool.
+func (mr *MockGitWorktreeMockRecorder) Mergetool(ctx, path any) *MockGitWorktreeMergetoolCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mergetool", reflect.TypeOf((*MockGitWorktree)(nil).Mergetool), ctx, path)
+	return &MockGitWorktreeMergetoolCall{Call: call}
+}
+
+// MockGitWorktreeMergetoolCall wrap *gomock.Call
+type MockGitWorktreeMergetoolCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeMergetoolCall) Return(arg0 error) *MockGitWorktreeMergetoolCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeMergetoolCall) Do(f func(context.Context, string) error) *MockGitWorktreeMergetoolCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeMergetoolCall) DoAndReturn(f func(context.Context, string) error) *MockGitWorktreeMergetoolCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RebaseAbort mocks base method.
+func (m *MockGitWorktree) RebaseAbort(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseAbort", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebaseAbort indicates an expected call of RebaseAbort.
+func (mr *MockGitWorktreeMockRecorder) RebaseAbort(ctx any) *MockGitWorktreeRebaseAbortCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseAbort", reflect.TypeOf((*MockGitWorktree)(nil).RebaseAbort), ctx)
+	return &MockGitWorktreeRebaseAbortCall{Call: call}
+}
+
+// MockGitWorktreeRebaseAbortCall wrap *gomock.Call
+type MockGitWorktreeRebaseAbortCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeRebaseAbortCall) Return(arg0 error) *MockGitWorktreeRebaseAbortCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeRebaseAbortCall) Do(f func(context.Context) error) *MockGitWorktreeRebaseAbortCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeRebaseAbortCall) DoAndReturn(f func(context.Context) error) *MockGitWorktreeRebaseAbortCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RebaseContinue mocks base method.
+func (m *MockGitWorktree) RebaseContinue(ctx context.Context, opts *git.RebaseContinueOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseContinue", ctx, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebaseContinue indicates an expected call of RebaseContinue.
+func (mr *MockGitWorktreeMockRecorder) RebaseContinue(ctx, opts any) *MockGitWorktreeRebaseContinueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseContinue", reflect.TypeOf((*MockGitWorktree)(nil).RebaseContinue), ctx, opts)
+	return &MockGitWorktreeRebaseContinueCall{Call: call}
+}
+
+// MockGitWorktreeRebaseContinueCall wrap *gomock.Call
+type MockGitWorktreeRebaseContinueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeRebaseContinueCall) Return(arg0 error) *MockGitWorktreeRebaseContinueCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeRebaseContinueCall) Do(f func(context.Context, *git.RebaseContinueOptions) error) *MockGitWorktreeRebaseContinueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeRebaseContinueCall) DoAndReturn(f func(context.Context, *git.RebaseContinueOptions) error) *MockGitWorktreeRebaseContinueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rerere mocks base method.
+func (m *MockGitWorktree) Rerere(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rerere", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rerere indicates an expected call of Rerere.
+func (mr *MockGitWorktreeMockRecorder) Rerere(ctx any) *MockGitWorktreeRerereCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rerere", reflect.TypeOf((*MockGitWorktree)(nil).Rerere), ctx)
+	return &MockGitWorktreeRerereCall{Call: call}
+}
+
+// MockGitWorktreeRerereCall wrap *gomock.Call
+type MockGitWorktreeRerereCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeRerereCall) Return(arg0 error) *MockGitWorktreeRerereCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeRerereCall) Do(f func(context.Context) error) *MockGitWorktreeRerereCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeRerereCall) DoAndReturn(f func(context.Context) error) *MockGitWorktreeRerereCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResolveConflict mocks base method.
+func (m *MockGitWorktree) ResolveConflict(ctx context.Context, path string, side git.ConflictSide) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveConflict", ctx, path, side)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveConflict indicates an expected call of ResolveConflict.
+func (mr *MockGitWorktreeMockRecorder) ResolveConflict(ctx, path, side any) *MockGitWorktreeResolveConflictCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveConflict", reflect.TypeOf((*MockGitWorktree)(nil).ResolveConflict), ctx, path, side)
+	return &MockGitWorktreeResolveConflictCall{Call: call}
+}
+
+// MockGitWorktreeResolveConflictCall wrap *gomock.Call
+type MockGitWorktreeResolveConflictCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitWorktreeResolveConflictCall) Return(arg0 error) *MockGitWorktreeResolveConflictCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitWorktreeResolveConflictCall) Do(f func(context.Context, string, git.ConflictSide) error) *MockGitWorktreeResolveConflictCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitWorktreeResolveConflictCall) DoAndReturn(f func(context.Context, string, git.ConflictSide) error) *MockGitWorktreeResolveConflictCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
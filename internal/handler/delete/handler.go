@@ -54,6 +54,7 @@ type Service interface {
 	ListAbove(ctx context.Context, branch string) ([]string, error)
 	BranchOnto(ctx context.Context, req *spice.BranchOntoRequest) error
 	RebaseRescue(ctx context.Context, req spice.RebaseRescueRequest) error
+	CheckProtected(name string) error
 }
 
 var _ Service = (*spice.Service)(nil)
@@ -102,6 +103,13 @@ func (h *Handler) DeleteBranches(ctx context.Context, req *Request) error {
 		}
 	}
 
+	// Refuse to delete anything if any of the branches are protected.
+	for _, branch := range req.Branches {
+		if err := h.Service.CheckProtected(branch); err != nil {
+			return err
+		}
+	}
+
 	// name to branch info
 	branchesToDelete := make(map[string]*branchInfo, len(req.Branches))
 	for _, branch := range req.Branches {
@@ -0,0 +1,305 @@
+// Package guard implements a Handler that checks
+// whether Change Requests in a stack are merged in order.
+//
+// It's intended to run in CI, and relies only on the forge:
+// the stack is read from the navigation comments
+// that git-spice posts on Change Requests.
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"regexp"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/stacknav"
+	"go.abhg.dev/gs/internal/handler/submit"
+	"go.abhg.dev/gs/internal/must"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// Handler checks the merge order of Change Requests in a stack.
+type Handler struct {
+	Log              *silog.Logger    // required
+	RemoteRepository forge.Repository // required
+
+	// Signer verifies navigation comments.
+	// Comments that don't verify are ignored.
+	//
+	// If nil, navigation comments are trusted as is.
+	// Anyone who can comment on a Change Request can then
+	// misrepresent its position in the stack.
+	Signer *stacknav.Signer
+
+	// Trunk is the name of the trunk branch.
+	//
+	// If set, Change Requests at the bottom of their stack
+	// must be proposed against it.
+	Trunk string
+}
+
+// Violation is a problem found by a check.
+type Violation struct {
+	// Change is the Change Request with the problem.
+	Change forge.ChangeID
+
+	// Message describes the problem.
+	Message string
+}
+
+func (v *Violation) String() string {
+	return v.Change.String() + ": " + v.Message
+}
+
+// CheckChange reports problems that make it unsafe
+// to merge the given Change Request now:
+//
+//   - a Change Request below it in its stack is still open
+//   - it's proposed against the branch of a merged Change Request
+//     below it, so merging it won't land its changes in trunk
+//   - with Trunk set, it's not proposed against trunk
+//
+// It reports no problems for Change Requests that aren't open.
+func (h *Handler) CheckChange(ctx context.Context, id forge.ChangeID) ([]*Violation, error) {
+	must.NotBeNilf(id, "change ID must not be nil")
+
+	s, err := h.loadStack(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	change := s.Changes[s.Current]
+	if change.State != forge.ChangeOpen {
+		h.Log.Infof("%v: not open, nothing to check", id)
+		return nil, nil
+	}
+
+	var violations []*Violation
+	report := func(format string, args ...any) {
+		violations = append(violations, &Violation{
+			Change:  id,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var blocked bool
+	for base := range s.Downstack(s.Current) {
+		b := s.Changes[base]
+		switch {
+		case b.State == forge.ChangeOpen:
+			report("%v below it in the stack is still open", b.ID)
+			blocked = true
+		case b.HeadName != "" && b.HeadName == change.BaseName:
+			report("proposed against %v, the branch of %v, which is %v",
+				change.BaseName, b.ID, b.State)
+			blocked = true
+		}
+	}
+
+	// Only worth reporting if there's nothing more specific.
+	if !blocked && h.Trunk != "" && change.BaseName != h.Trunk {
+		report("proposed against %v, not %v", change.BaseName, h.Trunk)
+	}
+
+	return violations, nil
+}
+
+// CheckStack reports problems with the order of Change Requests
+// in the stack that the open Change Request for the given branch
+// is part of:
+//
+//   - an open Change Request is not proposed against
+//     the branch of the nearest open Change Request below it
+//   - the bottom-most open Change Request is proposed against
+//     the branch of a merged or closed Change Request,
+//     or with Trunk set, not against trunk
+//
+// It reports no problems if the branch doesn't have an open Change Request.
+func (h *Handler) CheckStack(ctx context.Context, branch string) ([]*Violation, error) {
+	must.NotBeBlankf(branch, "branch must not be blank")
+
+	changes, err := h.RemoteRepository.FindChangesByBranch(ctx, branch, forge.FindChangesOptions{
+		State: forge.ChangeOpen,
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find changes for %v: %w", branch, err)
+	}
+	if len(changes) == 0 {
+		h.Log.Infof("%v: no open change requests, nothing to check", branch)
+		return nil, nil
+	}
+
+	s, err := h.loadStack(ctx, changes[0].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []*Violation
+	for idx, change := range s.Changes {
+		if change.State != forge.ChangeOpen {
+			continue
+		}
+
+		report := func(format string, args ...any) {
+			violations = append(violations, &Violation{
+				Change:  change.ID,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		// Nearest open change below this one, if any,
+		// and the change below it whose branch it's proposed against.
+		openBase, staleBase := -1, -1
+		for base := range s.Downstack(idx) {
+			b := s.Changes[base]
+			if b.State == forge.ChangeOpen {
+				openBase = base
+				break
+			}
+			if b.HeadName != "" && b.HeadName == change.BaseName {
+				staleBase = base
+			}
+		}
+
+		switch {
+		case openBase >= 0:
+			b := s.Changes[openBase]
+			if b.HeadName != "" && change.BaseName != b.HeadName {
+				report("proposed against %v, expected %v (the branch of %v)",
+					change.BaseName, b.HeadName, b.ID)
+			}
+
+		case staleBase >= 0:
+			b := s.Changes[staleBase]
+			report("proposed against %v, the branch of %v, which is %v",
+				change.BaseName, b.ID, b.State)
+
+		case h.Trunk != "" && change.BaseName != h.Trunk:
+			report("proposed against %v, not %v", change.BaseName, h.Trunk)
+		}
+	}
+
+	return violations, nil
+}
+
+// stack is a stack of Change Requests read from a navigation comment.
+type stack struct {
+	Changes []*forge.FindChangeItem
+	Bases   []int // Bases[i] is the index of the change below Changes[i], or -1
+	Current int   // index of the change the comment was posted on
+}
+
+// Downstack iterates over the indexes of changes below the given one,
+// starting with the one immediately below it.
+func (s *stack) Downstack(idx int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for base := s.Bases[idx]; base >= 0; base = s.Bases[base] {
+			if !yield(base) {
+				return
+			}
+		}
+	}
+}
+
+// loadStack loads the stack that the given Change Request is part of.
+// If the Change Request doesn't have a navigation comment,
+// the stack holds only the Change Request itself.
+func (h *Handler) loadStack(ctx context.Context, id forge.ChangeID) (*stack, error) {
+	change, err := h.RemoteRepository.FindChangeByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("find change %v: %w", id, err)
+	}
+
+	body, err := h.navigationComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if body == "" {
+		h.Log.Warnf("%v: no navigation comment found, stack is unknown", id)
+		return &stack{
+			Changes: []*forge.FindChangeItem{change},
+			Bases:   []int{-1},
+		}, nil
+	}
+
+	nodes, current, err := stacknav.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%v: parse navigation comment: %w", id, err)
+	}
+	if current < 0 {
+		return nil, fmt.Errorf("%v: navigation comment does not mark the current change", id)
+	}
+
+	f := h.RemoteRepository.Forge()
+	s := &stack{
+		Changes: make([]*forge.FindChangeItem, len(nodes)),
+		Bases:   make([]int, len(nodes)),
+		Current: current,
+	}
+	for idx, node := range nodes {
+		s.Bases[idx] = node.Base
+
+		nodeID, err := f.ParseChangeID(stripLink(node.Value))
+		if err != nil {
+			return nil, fmt.Errorf("%v: navigation comment: %w", id, err)
+		}
+
+		if idx == current {
+			if nodeID.String() != id.String() {
+				return nil, fmt.Errorf("%v: navigation comment marks %v as the current change", id, nodeID)
+			}
+			s.Changes[idx] = change
+			continue
+		}
+
+		item, err := h.RemoteRepository.FindChangeByID(ctx, nodeID)
+		if err != nil {
+			return nil, fmt.Errorf("find change %v: %w", nodeID, err)
+		}
+		s.Changes[idx] = item
+	}
+
+	return s, nil
+}
+
+// navigationComment returns the body of the navigation comment
+// on the given Change Request, or an empty string if there isn't one.
+func (h *Handler) navigationComment(ctx context.Context, id forge.ChangeID) (string, error) {
+	var body string
+	for item, err := range h.RemoteRepository.ListChangeComments(ctx, id, &forge.ListChangeCommentsOptions{
+		BodyMatchesAll: submit.NavCommentRegexes(),
+	}) {
+		if err != nil {
+			return "", fmt.Errorf("list comments on %v: %w", id, err)
+		}
+
+		if h.Signer != nil {
+			if err := h.Signer.Verify(id.String(), item.Body); err != nil {
+				if !errors.Is(err, stacknav.ErrUnsigned) && !errors.Is(err, stacknav.ErrBadSignature) {
+					return "", fmt.Errorf("verify comment %v: %w", item.ID, err)
+				}
+				h.Log.Warnf("%v: ignoring navigation comment %v: %v", id, item.ID, err)
+				continue
+			}
+		}
+
+		// If there are multiple, the most recent one wins.
+		body = item.Body
+	}
+	return body, nil
+}
+
+// _linkRe matches a Markdown link, e.g. "[#123](https://example.com/123)".
+var _linkRe = regexp.MustCompile(`^\[([^\]]+)\]\([^)]*\)$`)
+
+// stripLink returns the text of a Markdown link,
+// or s as is if it isn't one.
+func stripLink(s string) string {
+	if m := _linkRe.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	return s
+}
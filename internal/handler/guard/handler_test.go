@@ -0,0 +1,295 @@
+package guard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/forge/stacknav"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+// navComment builds a navigation comment for a stack
+// given as indented lines, e.g. "- #1", "    - #2 ◀".
+func navComment(lines ...string) string {
+	return "This change is part of the following stack:\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		"<sub>Change managed by [git-spice](https://abhinav.github.io/git-spice/).</sub>\n" +
+		"<!-- gs:navigation comment -->\n"
+}
+
+// addStack adds a linear stack of changes on main to repo,
+// posting navigation comments on each,
+// and returns their IDs from the bottom up.
+func addStack(t *testing.T, repo *forgetest.FakeRepository, heads ...string) []forge.ChangeID {
+	t.Helper()
+
+	var ids []forge.ChangeID
+	base := "main"
+	for _, head := range heads {
+		ids = append(ids, repo.AddChange(forgetest.FakeChange{
+			Subject: head,
+			Base:    base,
+			Head:    head,
+		}))
+		base = head
+	}
+
+	for cur, id := range ids {
+		var lines []string
+		for idx, other := range ids {
+			line := strings.Repeat("    ", idx) + "- " + other.String()
+			if idx == cur {
+				line += " ◀"
+			}
+			lines = append(lines, line)
+		}
+		_, err := repo.PostChangeComment(t.Context(), id, navComment(lines...))
+		require.NoError(t, err)
+	}
+
+	return ids
+}
+
+func violationStrings(vs []*Violation) []string {
+	var out []string
+	for _, v := range vs {
+		out = append(out, v.String())
+	}
+	return out
+}
+
+// checkChange runs CheckChange for the given change
+// and returns the violations it reports.
+func checkChange(t *testing.T, repo *forgetest.FakeRepository, trunk string, id forge.ChangeID) []string {
+	t.Helper()
+
+	got, err := (&Handler{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+		Trunk:            trunk,
+	}).CheckChange(t.Context(), id)
+	require.NoError(t, err)
+	return violationStrings(got)
+}
+
+func TestHandler_CheckChange(t *testing.T) {
+	t.Run("Bottom", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+
+		assert.Empty(t, checkChange(t, repo, "main", ids[0]))
+	})
+
+	t.Run("DownstackOpen", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+
+		assert.Equal(t, []string{
+			"#3: #2 below it in the stack is still open",
+			"#3: #1 below it in the stack is still open",
+		}, checkChange(t, repo, "", ids[2]))
+	})
+
+	t.Run("DownstackMergedNotRetargeted", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+		repo.Merge(ids[0])
+
+		assert.Equal(t, []string{
+			"#2: proposed against feat1, the branch of #1, which is merged",
+		}, checkChange(t, repo, "", ids[1]))
+	})
+
+	t.Run("DownstackMergedRetargeted", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+		repo.Merge(ids[0])
+		require.NoError(t, repo.EditChange(t.Context(), ids[1], forge.EditChangeOptions{Base: "main"}))
+
+		assert.Empty(t, checkChange(t, repo, "main", ids[1]))
+	})
+
+	t.Run("NotTrunk", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+
+		assert.Equal(t, []string{
+			"#1: proposed against main, not develop",
+		}, checkChange(t, repo, "develop", ids[0]))
+	})
+
+	t.Run("NotOpen", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+		repo.SetState(ids[2], forge.ChangeClosed)
+
+		assert.Empty(t, checkChange(t, repo, "", ids[2]))
+	})
+}
+
+func TestHandler_CheckChange_noComment(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	id := repo.AddChange(forgetest.FakeChange{Base: "feat1", Head: "feat2"})
+
+	got, err := (&Handler{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+		Trunk:            "main",
+	}).CheckChange(t.Context(), id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#1: proposed against feat1, not main"}, violationStrings(got))
+}
+
+func TestHandler_CheckChange_linkedChanges(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	bottom := repo.AddChange(forgetest.FakeChange{Base: "main", Head: "feat1"})
+	top := repo.AddChange(forgetest.FakeChange{Base: "feat1", Head: "feat2"})
+	_, err := repo.PostChangeComment(t.Context(), top, navComment(
+		"- [#1](https://example.com/changes/1)",
+		"    - [#2](https://example.com/changes/2) ◀",
+	))
+	require.NoError(t, err)
+
+	got, err := (&Handler{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+	}).CheckChange(t.Context(), top)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#2: " + bottom.String() + " below it in the stack is still open"}, violationStrings(got))
+}
+
+func TestHandler_CheckChange_wrongCurrent(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	repo.AddChange(forgetest.FakeChange{Base: "main", Head: "feat1"})
+	top := repo.AddChange(forgetest.FakeChange{Base: "feat1", Head: "feat2"})
+	_, err := repo.PostChangeComment(t.Context(), top, navComment(
+		"- #1 ◀",
+		"    - #2",
+	))
+	require.NoError(t, err)
+
+	_, err = (&Handler{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+	}).CheckChange(t.Context(), top)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "navigation comment marks #1 as the current change")
+}
+
+func TestHandler_CheckChange_signed(t *testing.T) {
+	signer := stacknav.NewSigner("secret", "fake:repo")
+
+	repo := forgetest.NewFakeRepository()
+	bottom := repo.AddChange(forgetest.FakeChange{Base: "main", Head: "feat1"})
+	top := repo.AddChange(forgetest.FakeChange{Base: "feat1", Head: "feat2"})
+
+	// Signed comment with the real stack.
+	_, err := repo.PostChangeComment(t.Context(), top, signer.Sign(top.String(), navComment(
+		"- #1",
+		"    - #2 ◀",
+	), stacknav.SignatureHTML))
+	require.NoError(t, err)
+
+	// Unsigned comment posted afterwards claims #2 is at the bottom.
+	_, err = repo.PostChangeComment(t.Context(), top, navComment("- #2 ◀"))
+	require.NoError(t, err)
+
+	handler := Handler{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+	}
+
+	t.Run("Unverified", func(t *testing.T) {
+		got, err := handler.CheckChange(t.Context(), top)
+		require.NoError(t, err)
+		assert.Empty(t, got, "forged comment must win without a signer")
+	})
+
+	t.Run("Verified", func(t *testing.T) {
+		handler := handler
+		handler.Signer = signer
+
+		got, err := handler.CheckChange(t.Context(), top)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"#2: " + bottom.String() + " below it in the stack is still open"}, violationStrings(got))
+	})
+}
+
+// checkStack runs CheckStack for the stack of the given branch
+// and returns the violations it reports.
+func checkStack(t *testing.T, repo *forgetest.FakeRepository, trunk, branch string) []string {
+	t.Helper()
+
+	got, err := (&Handler{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+		Trunk:            trunk,
+	}).CheckStack(t.Context(), branch)
+	require.NoError(t, err)
+	return violationStrings(got)
+}
+
+func TestHandler_CheckStack(t *testing.T) {
+	t.Run("Ordered", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		addStack(t, repo, "feat1", "feat2", "feat3")
+
+		assert.Empty(t, checkStack(t, repo, "main", "feat2"))
+	})
+
+	t.Run("Misordered", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+		require.NoError(t, repo.EditChange(t.Context(), ids[2], forge.EditChangeOptions{Base: "feat1"}))
+
+		assert.Equal(t, []string{
+			"#3: proposed against feat1, expected feat2 (the branch of #2)",
+		}, checkStack(t, repo, "", "feat1"))
+	})
+
+	t.Run("BottomMergedNotRetargeted", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+		repo.Merge(ids[0])
+
+		assert.Equal(t, []string{
+			"#2: proposed against feat1, the branch of #1, which is merged",
+		}, checkStack(t, repo, "main", "feat3"))
+	})
+
+	t.Run("BottomMergedRetargeted", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		ids := addStack(t, repo, "feat1", "feat2", "feat3")
+		repo.Merge(ids[0])
+		require.NoError(t, repo.EditChange(t.Context(), ids[1], forge.EditChangeOptions{Base: "main"}))
+
+		assert.Empty(t, checkStack(t, repo, "main", "feat3"))
+	})
+
+	t.Run("NotTrunk", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		addStack(t, repo, "feat1", "feat2", "feat3")
+
+		assert.Equal(t, []string{
+			"#1: proposed against main, not develop",
+		}, checkStack(t, repo, "develop", "feat3"))
+	})
+
+	t.Run("NoChange", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		addStack(t, repo, "feat1", "feat2", "feat3")
+
+		assert.Empty(t, checkStack(t, repo, "", "unknown"))
+	})
+}
+
+func TestStripLink(t *testing.T) {
+	assert.Equal(t, "#1", stripLink("[#1](https://example.com/1)"))
+	assert.Equal(t, "#1", stripLink("#1"))
+	assert.Equal(t, "[#1]", stripLink("[#1]"))
+}
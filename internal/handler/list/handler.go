@@ -34,6 +34,8 @@ var _ GitRepository = (*git.Repository)(nil)
 type Store interface {
 	Remote() (string, error)
 	Trunk() string
+	LoadCache(ctx context.Context, name string, v any) error
+	SaveCache(ctx context.Context, name string, v any) error
 }
 
 var _ Store = (*state.Store)(nil)
@@ -66,6 +68,8 @@ type Handler struct {
 // Options holds command line options for the log command.
 type Options struct {
 	All bool `short:"a" long:"all" config:"log.all" help:"Show all tracked branches, not just the current stack."`
+
+	Archived bool `long:"archived" released:"unreleased" help:"Show archived branches."`
 }
 
 // Include specifies what additional information to include in the response.
@@ -89,7 +93,19 @@ const (
 	// branches that have an associated ChangeID.
 	IncludeChangeState
 
-	needsRemoteID = IncludeChangeURL | IncludeChangeState
+	// IncludeChangeDiffStat includes the size of the associated change
+	// for branches that have an associated ChangeID.
+	IncludeChangeDiffStat
+
+	// IncludeCachedChangeState includes the forge change state
+	// last seen by IncludeChangeState for branches
+	// that have an associated ChangeID.
+	// This does not make any network requests.
+	//
+	// Ignored if IncludeChangeState is set.
+	IncludeCachedChangeState
+
+	needsRemoteID = IncludeChangeURL | IncludeChangeState | IncludeChangeDiffStat
 )
 
 // BranchesRequest holds the parameters for the log command.
@@ -126,10 +142,23 @@ type BranchItem struct {
 	Commits []git.CommitDetail // only if IncludeCommits is set
 
 	// ChangeID is the ID of the associated change, if any.
-	ChangeID    forge.ChangeID
-	ChangeURL   string            // only if IncludeChangeURL is set
-	ChangeState forge.ChangeState // populated if RemoteRepository is available
-	PushStatus  *PushStatus       // only if IncludePushStatus is set
+	ChangeID forge.ChangeID
+
+	// ChangeDisplayID is ChangeID formatted for display
+	// following the conventions of its forge, e.g. "!123" for GitLab.
+	// Set only if ChangeID is set.
+	ChangeDisplayID string
+
+	// Note is the free-form note attached to the branch, if any.
+	Note string
+
+	// Archived indicates that the branch is archived.
+	Archived bool
+
+	ChangeURL      string            // only if IncludeChangeURL is set
+	ChangeState    forge.ChangeState // only if IncludeChangeState or IncludeCachedChangeState is set
+	ChangeDiffStat *forge.DiffStat   // only if IncludeChangeDiffStat is set
+	PushStatus     *PushStatus       // only if IncludePushStatus is set
 
 	// Worktree is the absolute path to the worktree where this branch is checked out.
 	// Empty if the branch is not checked out.
@@ -268,16 +297,19 @@ func (h *Handler) ListBranches(ctx context.Context, req *BranchesRequest) (*Bran
 				}
 
 				item.Base = branch.Base
+				item.Note = branch.Note
+				item.Archived = branch.Archived
 
 				if branch.Change != nil {
 					item.ChangeID = branch.Change.ChangeID()
+					item.ChangeDisplayID = h.Forges.FormatChangeID(branch.Change)
 					if req.Include&IncludeChangeURL != 0 {
 						item.ChangeURL = changeURL(item.ChangeID)
 					}
 				}
 
 				if req.Include&IncludePushStatus != 0 && branch.UpstreamBranch != "" {
-					upstream := getRemote() + "/" + branch.UpstreamBranch
+					upstream := cmp.Or(branch.UpstreamRemote, getRemote()) + "/" + branch.UpstreamBranch
 					ahead, behind, err := h.Repository.CommitAheadBehind(ctx, upstream, string(branch.Head))
 					if err == nil {
 						item.PushStatus = &PushStatus{
@@ -321,6 +353,9 @@ func (h *Handler) ListBranches(ctx context.Context, req *BranchesRequest) (*Bran
 		}
 	}
 	for branch := range branchesToLog {
+		if !req.Options.Archived && !showArchived(branchGraph, branch, req.Branch) {
+			continue
+		}
 		entryc <- branchLogEntry{Name: branch}
 	}
 	close(entryc)
@@ -351,13 +386,25 @@ func (h *Handler) ListBranches(ctx context.Context, req *BranchesRequest) (*Bran
 		baseItem.Aboves = append(baseItem.Aboves, idx)
 	}
 
+	openRemoteRepo := sync.OnceValues(func() (forge.Repository, error) {
+		return h.OpenRemoteRepository(ctx, remoteForge, remoteRepoID)
+	})
+
 	// If requested and possible, batch-resolve ChangeState for items with ChangeID.
 	if req.Include&IncludeChangeState != 0 && remoteForge != nil {
 		// Try to load change states, but don't fail the whole operation
 		// if something goes wrong.
-		if err := h.loadChangeStates(ctx, remoteForge, remoteRepoID, items); err != nil {
+		if err := h.loadChangeStates(ctx, openRemoteRepo, items); err != nil {
 			log.Warn("Could not load change states", "error", err)
 		}
+	} else if req.Include&IncludeCachedChangeState != 0 {
+		h.loadCachedChangeStates(ctx, items)
+	}
+
+	if req.Include&IncludeChangeDiffStat != 0 && remoteForge != nil {
+		if err := h.loadChangeDiffStats(ctx, openRemoteRepo, items); err != nil {
+			log.Warn("Could not load change sizes", "error", err)
+		}
 	}
 
 	return &BranchesResponse{
@@ -366,10 +413,26 @@ func (h *Handler) ListBranches(ctx context.Context, req *BranchesRequest) (*Bran
 	}, nil
 }
 
+// showArchived reports whether a branch should be listed
+// when archived branches are hidden.
+//
+// Archived branches are listed only if they're the current branch,
+// or if they're needed to connect a listed branch to trunk.
+func showArchived(graph *spice.BranchGraph, name, current string) bool {
+	for upstack := range graph.Upstack(name) {
+		if upstack == current {
+			return true
+		}
+		if item, ok := graph.Lookup(upstack); ok && !item.Archived {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *Handler) loadChangeStates(
 	ctx context.Context,
-	remoteForge forge.Forge,
-	remoteRepoID forge.RepositoryID,
+	openRemoteRepo func() (forge.Repository, error),
 	branches []*BranchItem,
 ) error {
 	// Collect IDs in the same order as items for stable mapping.
@@ -387,7 +450,7 @@ func (h *Handler) loadChangeStates(
 		return nil
 	}
 
-	remoteRepo, err := h.OpenRemoteRepository(ctx, remoteForge, remoteRepoID)
+	remoteRepo, err := openRemoteRepo()
 	if err != nil {
 		return fmt.Errorf("open remote repository: %w", err)
 	}
@@ -401,5 +464,90 @@ func (h *Handler) loadChangeStates(
 		branches[idx].ChangeState = states[j]
 	}
 
+	h.cacheChangeStates(ctx, branches)
+	return nil
+}
+
+// _changeStatesCache is the name of the cache entry
+// holding the last seen states of change requests.
+//
+// It maps change IDs to their states.
+const _changeStatesCache = "list/changeStates"
+
+// cacheChangeStates records the change states of the given branches
+// for use with IncludeCachedChangeState.
+// Failures are logged and otherwise ignored.
+func (h *Handler) cacheChangeStates(ctx context.Context, branches []*BranchItem) {
+	cached := make(map[string]forge.ChangeState)
+	if err := h.Store.LoadCache(ctx, _changeStatesCache, &cached); err != nil && !errors.Is(err, state.ErrNotExist) {
+		h.Log.Debug("Could not load cached change states", "error", err)
+	}
+
+	var changed bool
+	for _, b := range branches {
+		if b.ChangeID == nil || b.ChangeState == 0 {
+			continue
+		}
+		key := b.ChangeID.String()
+		if cached[key] != b.ChangeState {
+			cached[key] = b.ChangeState
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	if err := h.Store.SaveCache(ctx, _changeStatesCache, cached); err != nil {
+		h.Log.Debug("Could not cache change states", "error", err)
+	}
+}
+
+// loadCachedChangeStates fills in change states
+// from those last recorded by cacheChangeStates.
+func (h *Handler) loadCachedChangeStates(ctx context.Context, branches []*BranchItem) {
+	var cached map[string]forge.ChangeState
+	if err := h.Store.LoadCache(ctx, _changeStatesCache, &cached); err != nil {
+		if !errors.Is(err, state.ErrNotExist) {
+			h.Log.Debug("Could not load cached change states", "error", err)
+		}
+		return
+	}
+
+	for _, b := range branches {
+		if b.ChangeID != nil {
+			b.ChangeState = cached[b.ChangeID.String()]
+		}
+	}
+}
+
+func (h *Handler) loadChangeDiffStats(
+	ctx context.Context,
+	openRemoteRepo func() (forge.Repository, error),
+	branches []*BranchItem,
+) error {
+	var remoteRepo forge.Repository
+	for _, b := range branches {
+		if b.ChangeID == nil {
+			continue
+		}
+
+		if remoteRepo == nil {
+			var err error
+			remoteRepo, err = openRemoteRepo()
+			if err != nil {
+				return fmt.Errorf("open remote repository: %w", err)
+			}
+		}
+
+		// A change that can't be sized shouldn't hide the others.
+		stat, err := remoteRepo.ChangeDiffStat(ctx, b.ChangeID)
+		if err != nil {
+			h.Log.Warn("Could not load change size", "branch", b.Name, "error", err)
+			continue
+		}
+		b.ChangeDiffStat = stat
+	}
+
 	return nil
 }
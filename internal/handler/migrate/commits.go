@@ -0,0 +1,157 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// readGhstack reads a stack of commits managed by ghstack
+// from the given branch.
+//
+// ghstack pushes each commit to gh/<user>/<N>/orig,
+// and proposes a pull request from gh/<user>/<N>/head.
+// Each commit becomes a local branch gh/<user>/<N>
+// that takes over the head branch and its pull request.
+func (h *Handler) readGhstack(ctx context.Context, branch string) ([]*importBranch, error) {
+	remote, err := h.Store.Remote()
+	if err != nil {
+		if errors.Is(err, state.ErrNotExist) {
+			return nil, errors.New("ghstack branches are read from the remote, but no remote is configured")
+		}
+		return nil, fmt.Errorf("get remote: %w", err)
+	}
+
+	// Pull request URLs are recorded in commit messages
+	// and uniquely identify the ghstack branch for a commit
+	// even if the commit was amended since it was pushed.
+	remotePrefix := "refs/remotes/" + remote + "/"
+	prBranches := make(map[string]string) // PR URL -> gh/<user>/<N>
+	for ref, err := range h.Repository.ListRefs(ctx, remotePrefix+"gh/") {
+		if err != nil {
+			return nil, fmt.Errorf("list ghstack branches: %w", err)
+		}
+
+		name, ok := strings.CutSuffix(strings.TrimPrefix(ref.Name, remotePrefix), "/orig")
+		if !ok {
+			continue
+		}
+
+		commit, err := h.Repository.ReadCommit(ctx, ref.Hash.String())
+		if err != nil {
+			return nil, fmt.Errorf("read %v: %w", ref.Name, err)
+		}
+		if pr := ghstackPullRequest(commit.Body); pr != "" {
+			prBranches[pr] = name
+		}
+	}
+
+	return h.readCommitStack(ctx, branch, func(commit *git.CommitObject) (*importBranch, error) {
+		pr := ghstackPullRequest(commit.Body)
+		if pr == "" {
+			return nil, nil
+		}
+
+		name, ok := prBranches[pr]
+		if !ok {
+			return nil, fmt.Errorf("no ghstack branch found for %v: fetch from %v and try again", pr, remote)
+		}
+
+		return &importBranch{
+			Name:           name,
+			UpstreamBranch: name + "/head",
+			Change:         pr,
+		}, nil
+	})
+}
+
+// ghstackPullRequest returns the URL of the pull request
+// recorded in a commit message by ghstack,
+// or an empty string if there isn't one.
+func ghstackPullRequest(body string) string {
+	return commitTrailer(body, "Pull Request resolved")
+}
+
+// readSpr reads a stack of commits managed by spr
+// from the given branch.
+//
+// spr tags each commit with a commit-id trailer,
+// and proposes a pull request from spr/<trunk>/<commit-id>.
+// Each commit becomes a local branch of the same name.
+// Pull requests are found by their head branch.
+func (h *Handler) readSpr(ctx context.Context, branch string) ([]*importBranch, error) {
+	trunk := h.Store.Trunk()
+	return h.readCommitStack(ctx, branch, func(commit *git.CommitObject) (*importBranch, error) {
+		id := commitTrailer(commit.Body, "commit-id")
+		if id == "" {
+			return nil, nil
+		}
+
+		name := "spr/" + trunk + "/" + id
+		return &importBranch{
+			Name:           name,
+			UpstreamBranch: name,
+		}, nil
+	})
+}
+
+// readCommitStack builds a stack of branches
+// from the commits on the given branch,
+// with one branch per commit, each based on the one before it.
+//
+// newBranch returns the branch for a commit
+// with the Name and optionally UpstreamBranch and Change set,
+// or nil if the commit isn't managed by the tool.
+// The stack ends at the first such commit.
+func (h *Handler) readCommitStack(
+	ctx context.Context,
+	branch string,
+	newBranch func(*git.CommitObject) (*importBranch, error),
+) ([]*importBranch, error) {
+	commits, err := h.listStackCommits(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	base := h.Store.Trunk()
+	var branches []*importBranch
+	for idx, commit := range commits {
+		b, err := newBranch(commit)
+		if err != nil {
+			return nil, fmt.Errorf("%v (%v): %w", commit.Hash.Short(), commit.Subject, err)
+		}
+		if b == nil {
+			h.Log.Warnf("%v (%v): commit was not submitted with the tool", commit.Hash.Short(), commit.Subject)
+			h.Log.Warnf("Leaving %d commit(s) from here on untracked on %v", len(commits)-idx, branch)
+			break
+		}
+
+		b.Base = base
+		b.Head = commit.Hash
+		branches = append(branches, b)
+		base = b.Name
+	}
+	return branches, nil
+}
+
+// commitTrailer returns the value of the last line in a commit message body
+// in the form "key: value", or an empty string if there isn't one.
+// Keys are matched case-insensitively.
+//
+// Unlike git-interpret-trailers(1), this doesn't require the line
+// to be in the final paragraph of the message
+// as some tools don't place them there.
+func commitTrailer(body, key string) string {
+	var value string
+	for line := range strings.Lines(body) {
+		k, v, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(k), key) {
+			value = strings.TrimSpace(v)
+		}
+	}
+	return value
+}
@@ -0,0 +1,138 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+)
+
+// _graphiteMetadataRefPrefix is the prefix of refs
+// in which Graphite stores per-branch metadata.
+const _graphiteMetadataRefPrefix = "refs/branch-metadata/"
+
+// graphiteBranchMetadata is the metadata that Graphite stores
+// for each branch as a JSON blob
+// at refs/branch-metadata/<branch>.
+type graphiteBranchMetadata struct {
+	ParentBranchName string `json:"parentBranchName"`
+
+	PRInfo *struct {
+		Number int `json:"number"`
+	} `json:"prInfo"`
+}
+
+// graphiteRepoConfig is Graphite's repository configuration,
+// stored at .git/.graphite_repo_config.
+type graphiteRepoConfig struct {
+	Trunk string `json:"trunk"`
+}
+
+// readGraphite reads branches tracked by Graphite.
+//
+// Graphite pushes branches under the same name,
+// so each branch's upstream branch is its own name.
+func (h *Handler) readGraphite(ctx context.Context) ([]*importBranch, error) {
+	trunk, err := h.graphiteTrunk()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*importBranch)
+	for ref, err := range h.Repository.ListRefs(ctx, _graphiteMetadataRefPrefix) {
+		if err != nil {
+			return nil, fmt.Errorf("list metadata refs: %w", err)
+		}
+		name := strings.TrimPrefix(ref.Name, _graphiteMetadataRefPrefix)
+
+		var buf bytes.Buffer
+		if err := h.Repository.ReadObject(ctx, git.BlobType, ref.Hash, &buf); err != nil {
+			return nil, fmt.Errorf("read metadata for %v: %w", name, err)
+		}
+
+		var md graphiteBranchMetadata
+		if err := json.Unmarshal(buf.Bytes(), &md); err != nil {
+			h.Log.Warnf("%v: skipping branch with unrecognized metadata: %v", name, err)
+			continue
+		}
+		if md.ParentBranchName == "" {
+			// Graphite stores metadata for trunk too.
+			continue
+		}
+
+		b := &importBranch{
+			Name:           name,
+			Base:           md.ParentBranchName,
+			UpstreamBranch: name,
+		}
+		if b.Base == trunk {
+			b.Base = h.Store.Trunk()
+		}
+		if md.PRInfo != nil && md.PRInfo.Number > 0 {
+			b.Change = strconv.Itoa(md.PRInfo.Number)
+		}
+		byName[name] = b
+	}
+
+	return sortBranches(byName), nil
+}
+
+// graphiteTrunk reports the name of the trunk branch according to Graphite.
+// If Graphite was not configured, the trunk is assumed to be
+// the same as git-spice's.
+func (h *Handler) graphiteTrunk() (string, error) {
+	bs, err := os.ReadFile(filepath.Join(h.Repository.GitDir(), ".graphite_repo_config"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return h.Store.Trunk(), nil
+		}
+		return "", fmt.Errorf("read Graphite configuration: %w", err)
+	}
+
+	var cfg graphiteRepoConfig
+	if err := json.Unmarshal(bs, &cfg); err != nil {
+		return "", fmt.Errorf("parse Graphite configuration: %w", err)
+	}
+	if cfg.Trunk == "" {
+		return h.Store.Trunk(), nil
+	}
+	return cfg.Trunk, nil
+}
+
+// sortBranches returns the given branches
+// ordered so that each branch appears after its base.
+// Branches at the same depth are sorted by name.
+func sortBranches(byName map[string]*importBranch) []*importBranch {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	sorted := make([]*importBranch, 0, len(byName))
+	visited := make(map[string]bool, len(byName))
+	var visit func(name string)
+	visit = func(name string) {
+		b, ok := byName[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true // guards against cycles
+
+		visit(b.Base)
+		sorted = append(sorted, b)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return sorted
+}
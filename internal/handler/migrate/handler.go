@@ -0,0 +1,349 @@
+// Package migrate implements the Handler for 'gs migrate',
+// which imports stacks managed by other stacking tools.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/must"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// GitRepository provides read and write access to the Git repository.
+type GitRepository interface {
+	PeelToCommit(ctx context.Context, ref string) (git.Hash, error)
+	ListCommits(ctx context.Context, commits git.CommitRange) iter.Seq2[git.Hash, error]
+	ReadCommit(ctx context.Context, commitish string) (*git.CommitObject, error)
+	ListRefs(ctx context.Context, prefix string) iter.Seq2[git.Ref, error]
+	ReadObject(ctx context.Context, typ git.Type, hash git.Hash, dst io.Writer) error
+	BranchExists(ctx context.Context, branch string) bool
+	CreateBranch(ctx context.Context, req git.CreateBranchRequest) error
+	GitDir() string
+}
+
+var _ GitRepository = (*git.Repository)(nil)
+
+// Store is the storage for git-spice's state.
+type Store interface {
+	// Trunk reports the name of the trunk branch.
+	Trunk() string
+
+	// Remote reports the name of the remote,
+	// or [state.ErrNotExist] if there isn't one.
+	Remote() (string, error)
+
+	// LookupBranch returns information about a tracked branch.
+	LookupBranch(ctx context.Context, name string) (*state.LookupResponse, error)
+
+	// BeginBranchTx begins a transaction for modifying branch state.
+	BeginBranchTx() *state.BranchTx
+}
+
+var _ Store = (*state.Store)(nil)
+
+// Handler implements the business logic for 'gs migrate'.
+type Handler struct {
+	Log        *silog.Logger // required
+	Repository GitRepository // required
+	Store      Store         // required
+
+	// OpenRemoteRepository opens the forge repository
+	// for the repository's remote.
+	//
+	// If unset or if it fails,
+	// branches are migrated without their change requests.
+	// The change requests will be detected on the next submit.
+	OpenRemoteRepository func(context.Context) (forge.Repository, error)
+}
+
+// Source is a stacking tool from which branches can be migrated.
+type Source string
+
+const (
+	// Graphite migrates branches tracked by Graphite (gt),
+	// using the metadata it stores in refs/branch-metadata/.
+	Graphite Source = "graphite"
+
+	// Ghstack migrates a stack of commits managed by ghstack.
+	// Each commit becomes a branch
+	// that takes over the commit's pull request.
+	Ghstack Source = "ghstack"
+
+	// Spr migrates a stack of commits managed by spr.
+	// Each commit becomes a branch
+	// that takes over the commit's pull request.
+	Spr Source = "spr"
+)
+
+// Sources lists all supported sources.
+var Sources = []Source{Graphite, Ghstack, Spr}
+
+// Request is a request to migrate branches from another tool.
+type Request struct {
+	// From is the tool to migrate from.
+	From Source // required
+
+	// Branch is the branch holding the stack of commits to migrate
+	// for tools that manage a stack of commits on a single branch
+	// (ghstack and spr).
+	//
+	// It is ignored for other tools.
+	Branch string
+}
+
+// importBranch is a branch found in another tool's metadata.
+type importBranch struct {
+	// Name is the name of the local branch.
+	Name string
+
+	// Base is the name of the branch this one is stacked on.
+	Base string
+
+	// Head is the commit at which to create the branch
+	// if it does not already exist.
+	// If empty, the branch must already exist.
+	Head git.Hash
+
+	// UpstreamBranch is the name of the branch in the remote
+	// that the tool pushed this branch to, if known.
+	UpstreamBranch string
+
+	// Change is a reference to the change request for this branch
+	// (a number or URL), if known.
+	Change string
+}
+
+// Migrate tracks branches managed by another stacking tool with git-spice.
+//
+// Branches that are already tracked by git-spice are left alone.
+func (h *Handler) Migrate(ctx context.Context, req *Request) error {
+	must.NotBeBlankf(string(req.From), "source must not be blank")
+
+	var (
+		branches []*importBranch
+		err      error
+	)
+	switch req.From {
+	case Graphite:
+		branches, err = h.readGraphite(ctx)
+	case Ghstack:
+		branches, err = h.readGhstack(ctx, req.Branch)
+	case Spr:
+		branches, err = h.readSpr(ctx, req.Branch)
+	default:
+		return fmt.Errorf("unsupported source: %q", req.From)
+	}
+	if err != nil {
+		return fmt.Errorf("read %v metadata: %w", req.From, err)
+	}
+
+	branches, err = h.filterBranches(ctx, branches)
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		h.Log.Infof("No %v branches to migrate", req.From)
+		return nil
+	}
+
+	upserts := make([]state.UpsertRequest, 0, len(branches))
+	for _, b := range branches {
+		if b.Head != "" && !h.Repository.BranchExists(ctx, b.Name) {
+			if err := h.Repository.CreateBranch(ctx, git.CreateBranchRequest{
+				Name: b.Name,
+				Head: b.Head.String(),
+			}); err != nil {
+				return fmt.Errorf("create branch %v: %w", b.Name, err)
+			}
+			h.Log.Infof("%v: created branch at %v", b.Name, b.Head.Short())
+		}
+
+		baseHash, err := h.Repository.PeelToCommit(ctx, b.Base)
+		if err != nil {
+			return fmt.Errorf("resolve base %v of %v: %w", b.Base, b.Name, err)
+		}
+
+		upsert := state.UpsertRequest{
+			Name:     b.Name,
+			Base:     b.Base,
+			BaseHash: baseHash,
+		}
+		if b.UpstreamBranch != "" {
+			upsert.UpstreamBranch = &b.UpstreamBranch
+		}
+		upserts = append(upserts, upsert)
+	}
+
+	h.associateChanges(ctx, branches, upserts)
+
+	tx := h.Store.BeginBranchTx()
+	for _, upsert := range upserts {
+		if err := tx.Upsert(ctx, upsert); err != nil {
+			return fmt.Errorf("track %v: %w", upsert.Name, err)
+		}
+	}
+	if err := tx.Commit(ctx, fmt.Sprintf("migrate %d branches from %v", len(upserts), req.From)); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+
+	for _, b := range branches {
+		h.Log.Infof("%v: tracking with base %v", b.Name, b.Base)
+	}
+	return nil
+}
+
+// filterBranches drops branches that are already tracked,
+// and branches that can't be migrated,
+// e.g. because their base will not be tracked.
+//
+// branches must be ordered so that bases appear before branches on them.
+func (h *Handler) filterBranches(ctx context.Context, branches []*importBranch) ([]*importBranch, error) {
+	trunk := h.Store.Trunk()
+	tracked := func(name string) (bool, error) {
+		if name == trunk {
+			return true, nil
+		}
+		_, err := h.Store.LookupBranch(ctx, name)
+		if err == nil {
+			return true, nil
+		}
+		if errors.Is(err, state.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("look up %v: %w", name, err)
+	}
+
+	var migrated []*importBranch
+	for _, b := range branches {
+		if b.Name == trunk {
+			continue
+		}
+
+		if ok, err := tracked(b.Name); err != nil {
+			return nil, err
+		} else if ok {
+			h.Log.Infof("%v: already tracked, skipping", b.Name)
+			continue
+		}
+
+		if b.Head == "" && !h.Repository.BranchExists(ctx, b.Name) {
+			h.Log.Warnf("%v: branch does not exist locally, skipping", b.Name)
+			continue
+		}
+
+		baseMigrated := slices.ContainsFunc(migrated, func(m *importBranch) bool {
+			return m.Name == b.Base
+		})
+		if !baseMigrated {
+			if ok, err := tracked(b.Base); err != nil {
+				return nil, err
+			} else if !ok {
+				h.Log.Warnf("%v: base %v is not tracked, skipping", b.Name, b.Base)
+				continue
+			}
+		}
+
+		migrated = append(migrated, b)
+	}
+	return migrated, nil
+}
+
+// associateChanges looks up change requests for migrated branches
+// and records them in the corresponding upsert requests.
+//
+// Failures are not fatal:
+// change requests that can't be resolved here
+// are detected by name on the next submit.
+func (h *Handler) associateChanges(ctx context.Context, branches []*importBranch, upserts []state.UpsertRequest) {
+	if !slices.ContainsFunc(branches, func(b *importBranch) bool {
+		return b.Change != "" || b.UpstreamBranch != ""
+	}) || h.OpenRemoteRepository == nil {
+		return
+	}
+
+	remoteRepo, err := h.OpenRemoteRepository(ctx)
+	if err != nil {
+		h.Log.Warn("Could not open remote repository. Change requests will be detected on the next submit.", "error", err)
+		return
+	}
+	f := remoteRepo.Forge()
+
+	for idx, b := range branches {
+		var id forge.ChangeID
+		switch {
+		case b.Change != "":
+			id, err = f.ParseChangeID(b.Change)
+			if err != nil {
+				h.Log.Warnf("%v: could not parse change %q: %v", b.Name, b.Change, err)
+				continue
+			}
+
+		case b.UpstreamBranch != "":
+			change, err := remoteRepo.FindOpenChangeByHead(ctx, forge.FindOpenChangeByHeadRequest{
+				Head: b.UpstreamBranch,
+			})
+			if err != nil {
+				if !errors.Is(err, forge.ErrNotFound) {
+					h.Log.Warnf("%v: could not find change: %v", b.Name, err)
+				}
+				continue
+			}
+			id = change.ID
+
+		default:
+			continue
+		}
+
+		md, err := remoteRepo.NewChangeMetadata(ctx, id)
+		if err != nil {
+			h.Log.Warnf("%v: could not get metadata for %v: %v", b.Name, f.FormatChangeID(id), err)
+			continue
+		}
+		metadata, err := f.MarshalChangeMetadata(md)
+		if err != nil {
+			h.Log.Warnf("%v: could not record %v: %v", b.Name, f.FormatChangeID(id), err)
+			continue
+		}
+
+		upserts[idx].ChangeForge = md.ForgeID()
+		upserts[idx].ChangeMetadata = metadata
+		h.Log.Infof("%v: associated with %v", b.Name, f.FormatChangeID(id))
+	}
+}
+
+// listStackCommits lists commits on the given branch that aren't in trunk,
+// from the bottom of the stack to the top.
+func (h *Handler) listStackCommits(ctx context.Context, branch string) ([]*git.CommitObject, error) {
+	head, err := h.Repository.PeelToCommit(ctx, branch)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %v: %w", branch, err)
+	}
+
+	trunkHash, err := h.Repository.PeelToCommit(ctx, h.Store.Trunk())
+	if err != nil {
+		return nil, fmt.Errorf("resolve trunk: %w", err)
+	}
+
+	var commits []*git.CommitObject
+	commitRange := git.CommitRangeFrom(head).ExcludeFrom(trunkHash).Reverse()
+	for hash, err := range h.Repository.ListCommits(ctx, commitRange) {
+		if err != nil {
+			return nil, fmt.Errorf("list commits: %w", err)
+		}
+
+		commit, err := h.Repository.ReadCommit(ctx, hash.String())
+		if err != nil {
+			return nil, fmt.Errorf("read commit %v: %w", hash.Short(), err)
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
@@ -0,0 +1,78 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitTrailer(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		key  string
+		want string
+	}{
+		{name: "Empty", body: "", key: "commit-id", want: ""},
+		{
+			name: "Simple",
+			body: "Some details.\n\ncommit-id: abc123\n",
+			key:  "commit-id",
+			want: "abc123",
+		},
+		{
+			name: "NoSpace",
+			body: "commit-id:abc123",
+			key:  "commit-id",
+			want: "abc123",
+		},
+		{
+			name: "CaseInsensitive",
+			body: "Commit-Id: abc123",
+			key:  "commit-id",
+			want: "abc123",
+		},
+		{
+			name: "LastWins",
+			body: "commit-id: abc123\ncommit-id: def456\n",
+			key:  "commit-id",
+			want: "def456",
+		},
+		{
+			name: "NotInFinalParagraph",
+			body: "Pull Request resolved: https://github.com/foo/bar/pull/1\n\nMore details.\n",
+			key:  "Pull Request resolved",
+			want: "https://github.com/foo/bar/pull/1",
+		},
+		{
+			name: "OtherKey",
+			body: "Signed-off-by: Test <test@example.com>",
+			key:  "commit-id",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, commitTrailer(tt.body, tt.key))
+		})
+	}
+}
+
+func TestSortBranches(t *testing.T) {
+	byName := map[string]*importBranch{
+		"feat3": {Name: "feat3", Base: "feat2"},
+		"feat1": {Name: "feat1", Base: "main"},
+		"feat2": {Name: "feat2", Base: "feat1"},
+		"other": {Name: "other", Base: "main"},
+		"cycA":  {Name: "cycA", Base: "cycB"},
+		"cycB":  {Name: "cycB", Base: "cycA"},
+	}
+
+	var names []string
+	for _, b := range sortBranches(byName) {
+		names = append(names, b.Name)
+	}
+
+	assert.Equal(t, []string{"cycB", "cycA", "feat1", "feat2", "feat3", "other"}, names)
+}
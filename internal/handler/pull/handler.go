@@ -0,0 +1,294 @@
+// Package pull implements a Handler to pull stacks of Change Requests
+// created by someone else into the local repository.
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/track"
+	"go.abhg.dev/gs/internal/must"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// _maxStackDepth is the maximum number of Change Requests
+// that will be walked to find the bottom of a stack.
+// This guards against cycles in the forge's data.
+const _maxStackDepth = 100
+
+// Store provides access to the git-spice state.
+type Store interface {
+	// Trunk returns the name of the trunk branch.
+	Trunk() string
+	Remote() (string, error)
+}
+
+// GitRepository provides access to the Git repository methods
+// that do not require a worktree.
+type GitRepository interface {
+	CreateBranch(ctx context.Context, req git.CreateBranchRequest) error
+	PeelToCommit(ctx context.Context, ref string) (git.Hash, error)
+	SetBranchUpstream(ctx context.Context, branch, upstream string) error
+	SetRef(ctx context.Context, req git.SetRefRequest) error
+	IsAncestor(ctx context.Context, a, b git.Hash) bool
+	Fetch(ctx context.Context, opts git.FetchOptions) error
+}
+
+// GitWorktree allows changing which branch is checked out.
+type GitWorktree interface {
+	CurrentBranch(ctx context.Context) (string, error)
+	CheckoutBranch(ctx context.Context, branch string) error
+}
+
+// TrackHandler allows tracking new branches with git-spice.
+type TrackHandler interface {
+	TrackBranch(ctx context.Context, req *track.BranchRequest) error
+}
+
+// Handler pulls stacks of Change Requests from the forge.
+type Handler struct {
+	Log        *silog.Logger // required
+	Store      Store         // required
+	Repository GitRepository // required
+	Worktree   GitWorktree   // required
+	Track      TrackHandler  // required
+
+	// OpenRemoteRepository opens the repository on the forge.
+	OpenRemoteRepository func(context.Context) (forge.Repository, error) // required
+}
+
+// StackRequest is a request to pull a stack of Change Requests.
+type StackRequest struct {
+	// Change is a reference to the topmost Change Request of the stack,
+	// e.g. "#123" or the URL of the CR.
+	Change string // required
+
+	// NoCheckout prevents checking out the topmost branch
+	// after the stack has been pulled.
+	NoCheckout bool
+}
+
+// stackItem is a Change Request in a stack being pulled.
+type stackItem struct {
+	id     forge.ChangeID
+	change *forge.FindChangeItem
+}
+
+// PullStack pulls the stack of Change Requests ending at the given CR.
+//
+// Starting at the given CR, it follows the base branch of each CR
+// to an open CR with that branch as its head,
+// stopping when it reaches the trunk
+// or a base branch without an open CR.
+// The head branches of all CRs found this way are fetched,
+// and the stack is tracked locally with the same bases,
+// with each branch associated with its CR.
+//
+// Branches that already exist locally are fast-forwarded
+// to their remote counterparts if possible,
+// and left alone otherwise.
+func (h *Handler) PullStack(ctx context.Context, req *StackRequest) error {
+	must.NotBeBlankf(req.Change, "change must not be blank")
+
+	remoteRepo, err := h.OpenRemoteRepository(ctx)
+	if err != nil {
+		return fmt.Errorf("open remote repository: %w", err)
+	}
+	f := remoteRepo.Forge()
+
+	id, err := f.ParseChangeID(req.Change)
+	if err != nil {
+		return fmt.Errorf("parse change: %w", err)
+	}
+
+	stack, err := h.findStack(ctx, remoteRepo, id)
+	if err != nil {
+		return err
+	}
+
+	remote, err := h.Store.Remote()
+	if err != nil {
+		return fmt.Errorf("get remote: %w", err)
+	}
+
+	refspecs := make([]git.Refspec, len(stack))
+	for i, item := range stack {
+		head := item.change.HeadName
+		refspecs[i] = git.Refspec("+refs/heads/" + head + ":refs/remotes/" + remote + "/" + head)
+	}
+	if err := h.Repository.Fetch(ctx, git.FetchOptions{
+		Remote:   remote,
+		Refspecs: refspecs,
+	}); err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	currentBranch, err := h.Worktree.CurrentBranch(ctx)
+	if err != nil && !errors.Is(err, git.ErrDetachedHead) {
+		return fmt.Errorf("get current branch: %w", err)
+	}
+
+	base := h.Store.Trunk()
+	for _, item := range stack {
+		branch := item.change.HeadName
+		changeName := f.FormatChangeID(item.id)
+
+		matches, err := h.updateBranch(ctx, remote, branch, currentBranch)
+		if err != nil {
+			return fmt.Errorf("%v: %w", changeName, err)
+		}
+
+		trackReq := track.BranchRequest{
+			Branch: branch,
+			Base:   base,
+		}
+		// The CR can only be associated with the branch
+		// if the branch matches the CR's head.
+		if matches {
+			trackReq.Change = changeName
+		}
+		if err := h.Track.TrackBranch(ctx, &trackReq); err != nil {
+			return fmt.Errorf("track %v: %w", branch, err)
+		}
+
+		base = branch
+	}
+
+	top := stack[len(stack)-1].change.HeadName
+	h.Log.Infof("Pulled %d branches from %v", len(stack), f.FormatChangeID(id))
+	if req.NoCheckout || top == currentBranch {
+		return nil
+	}
+
+	if err := h.Worktree.CheckoutBranch(ctx, top); err != nil {
+		return fmt.Errorf("checkout %v: %w", top, err)
+	}
+	return nil
+}
+
+// findStack finds the stack of Change Requests ending at the given CR.
+// The returned stack is ordered from bottom to top.
+func (h *Handler) findStack(
+	ctx context.Context,
+	remoteRepo forge.Repository,
+	id forge.ChangeID,
+) ([]stackItem, error) {
+	f := remoteRepo.Forge()
+	trunk := h.Store.Trunk()
+
+	change, err := remoteRepo.FindChangeByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("find change %v: %w", f.FormatChangeID(id), err)
+	}
+
+	var (
+		stack []stackItem
+		seen  = make(map[string]struct{})
+	)
+	for {
+		changeName := f.FormatChangeID(id)
+		if change.HeadName == "" {
+			return nil, fmt.Errorf("%v: head branch is unknown", changeName)
+		}
+		if change.HeadName == trunk {
+			return nil, fmt.Errorf("%v: cannot pull trunk branch %v", changeName, trunk)
+		}
+		if _, ok := seen[change.HeadName]; ok || len(stack) >= _maxStackDepth {
+			return nil, fmt.Errorf("%v: could not find the bottom of the stack", changeName)
+		}
+		seen[change.HeadName] = struct{}{}
+		stack = append(stack, stackItem{id: id, change: change})
+
+		baseName := change.BaseName
+		if baseName == trunk {
+			break
+		}
+
+		// The base is another CR only if that CR is still open.
+		// If it was merged or closed, restacking onto trunk
+		// is the right thing to do.
+		baseChanges, err := remoteRepo.FindChangesByBranch(ctx, baseName, forge.FindChangesOptions{
+			State: forge.ChangeOpen,
+			Limit: 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("find changes for %v: %w", baseName, err)
+		}
+		if len(baseChanges) == 0 {
+			h.Log.Warnf("%v: base branch %v does not have an open CR, using %v instead", changeName, baseName, trunk)
+			break
+		}
+
+		change = baseChanges[0]
+		id = change.ID
+	}
+
+	slices.Reverse(stack)
+	return stack, nil
+}
+
+// updateBranch creates or fast-forwards a local branch
+// to match the remote branch with the same name.
+//
+// It reports whether the local branch matches the remote branch
+// after the update.
+func (h *Handler) updateBranch(ctx context.Context, remote, branch, currentBranch string) (bool, error) {
+	upstream := remote + "/" + branch
+	remoteHash, err := h.Repository.PeelToCommit(ctx, "refs/remotes/"+upstream)
+	if err != nil {
+		return false, fmt.Errorf("resolve %v: %w", upstream, err)
+	}
+
+	localHash, err := h.Repository.PeelToCommit(ctx, "refs/heads/"+branch)
+	if err != nil {
+		if !errors.Is(err, git.ErrNotExist) {
+			return false, fmt.Errorf("resolve %v: %w", branch, err)
+		}
+
+		if err := h.Repository.CreateBranch(ctx, git.CreateBranchRequest{
+			Name: branch,
+			Head: remoteHash.String(),
+		}); err != nil {
+			return false, fmt.Errorf("create branch %v: %w", branch, err)
+		}
+		if err := h.Repository.SetBranchUpstream(ctx, branch, upstream); err != nil {
+			// Non-fatal error; just log it.
+			h.Log.Error("Error setting upstream for branch",
+				"name", branch, "upstream", upstream, "error", err)
+		}
+
+		h.Log.Infof("%v: created from %v", branch, upstream)
+		return true, nil
+	}
+
+	switch {
+	case localHash == remoteHash:
+		return true, nil
+
+	case !h.Repository.IsAncestor(ctx, localHash, remoteHash):
+		h.Log.Warnf("%v: local branch has diverged from %v, leaving it as is", branch, upstream)
+		return false, nil
+
+	case branch == currentBranch:
+		// Moving the checked out branch would leave the worktree
+		// out of sync with it.
+		h.Log.Warnf("%v: checked out and behind %v, leaving it as is", branch, upstream)
+		return false, nil
+	}
+
+	if err := h.Repository.SetRef(ctx, git.SetRefRequest{
+		Ref:     "refs/heads/" + branch,
+		Hash:    remoteHash,
+		OldHash: localHash,
+		Reason:  "pull from " + upstream,
+	}); err != nil {
+		return false, fmt.Errorf("update branch %v: %w", branch, err)
+	}
+
+	h.Log.Infof("%v: fast-forwarded to %v", branch, upstream)
+	return true, nil
+}
@@ -0,0 +1,247 @@
+// Package refresh implements re-resolution of the change requests
+// associated with tracked branches.
+package refresh
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+//go:generate mockgen -destination mocks_test.go -package refresh -typed . Service
+
+// Store provides write access to the state store.
+type Store interface {
+	BeginBranchTx() *state.BranchTx
+}
+
+var _ Store = (*state.Store)(nil)
+
+// Service is a subset of spice.Service.
+type Service interface {
+	LookupBranch(ctx context.Context, name string) (*spice.LookupBranchResponse, error)
+}
+
+var _ Service = (*spice.Service)(nil)
+
+// Handler re-resolves the change requests associated with branches
+// and repairs stale change metadata.
+type Handler struct {
+	Log              *silog.Logger    // required
+	Store            Store            // required
+	Service          Service          // required
+	RemoteRepository forge.Repository // required
+
+	// FindRemoteRepositoryID identifies the forge repository for a remote
+	// without opening it.
+	// This is used only for branches pushed to a fork.
+	// Such branches are skipped if this is unset.
+	FindRemoteRepositoryID func(ctx context.Context, remote string) (forge.Forge, forge.RepositoryID, error)
+}
+
+// Request is a request to refresh the change metadata of branches.
+type Request struct {
+	// Branches to refresh.
+	Branches []string // required
+}
+
+// Response is the result of refreshing branches.
+type Response struct {
+	// Updated lists branches whose change metadata was updated,
+	// in the order they were requested.
+	Updated []string
+
+	// Failed lists branches that could not be refreshed.
+	// Failures are logged but do not fail the operation.
+	Failed []string
+}
+
+// branchUpdate is a pending update to a branch's change metadata.
+type branchUpdate struct {
+	Name     string
+	ForgeID  string
+	Metadata json.RawMessage
+}
+
+// RefreshBranches looks up open change requests
+// by the name of each branch's upstream branch
+// in the repository it was pushed to,
+// and updates the stored change metadata to match.
+//
+// For a branch that is already associated with a change request,
+// the association is replaced if the branch's upstream branch
+// now has a different open change request,
+// e.g. because the original was closed and re-created.
+//
+// In either case, a change request is associated with a branch
+// only if its head matches the branch's head
+// or the commit that was last pushed for it.
+//
+// Branches for which no open change request is found are left unchanged.
+func (h *Handler) RefreshBranches(ctx context.Context, req *Request) (*Response, error) {
+	updates := make([]*branchUpdate, len(req.Branches))
+	failed := make([]bool, len(req.Branches))
+
+	var wg sync.WaitGroup
+	idxc := make(chan int)
+	for range min(runtime.GOMAXPROCS(0), len(req.Branches)) {
+		wg.Go(func() {
+			for idx := range idxc {
+				name := req.Branches[idx]
+				update, err := h.refreshBranch(ctx, name)
+				if err != nil {
+					h.Log.Warn("Could not refresh branch", "branch", name, "error", err)
+					failed[idx] = true
+					continue
+				}
+				updates[idx] = update
+			}
+		})
+	}
+	for idx := range req.Branches {
+		idxc <- idx
+	}
+	close(idxc)
+	wg.Wait()
+
+	var res Response
+	for idx, ok := range failed {
+		if ok {
+			res.Failed = append(res.Failed, req.Branches[idx])
+		}
+	}
+
+	updates = slices.DeleteFunc(updates, func(u *branchUpdate) bool {
+		return u == nil
+	})
+	if len(updates) == 0 {
+		return &res, nil
+	}
+
+	tx := h.Store.BeginBranchTx()
+	res.Updated = make([]string, 0, len(updates))
+	for _, u := range updates {
+		if err := tx.Upsert(ctx, state.UpsertRequest{
+			Name:           u.Name,
+			ChangeForge:    u.ForgeID,
+			ChangeMetadata: u.Metadata,
+		}); err != nil {
+			return nil, fmt.Errorf("update %v: %w", u.Name, err)
+		}
+		res.Updated = append(res.Updated, u.Name)
+	}
+
+	if err := tx.Commit(ctx, "refresh change metadata"); err != nil {
+		return nil, fmt.Errorf("update state: %w", err)
+	}
+
+	return &res, nil
+}
+
+// refreshBranch returns the new change metadata for the given branch,
+// or nil if it does not need to be updated.
+func (h *Handler) refreshBranch(ctx context.Context, name string) (*branchUpdate, error) {
+	log := h.Log
+	remoteForge := h.RemoteRepository.Forge()
+
+	branch, err := h.Service.LookupBranch(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup branch: %w", err)
+	}
+
+	var currentID forge.ChangeID
+	if branch.Change != nil {
+		if forgeID := branch.Change.ForgeID(); forgeID != remoteForge.ID() {
+			log.Debugf("%v: change belongs to a different forge (%v), skipping", name, forgeID)
+			return nil, nil
+		}
+		currentID = branch.Change.ChangeID()
+	}
+
+	// Only CRs from the repository the branch was pushed to are considered.
+	// Branches with the same name in other forks are unrelated.
+	var headRepo forge.RepositoryID
+	if branch.UpstreamRemote != "" {
+		headRepo, err = h.forkRepositoryID(ctx, branch.UpstreamRemote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	upstreamBranch := cmp.Or(branch.UpstreamBranch, name)
+	change, err := h.RemoteRepository.FindOpenChangeByHead(ctx, forge.FindOpenChangeByHeadRequest{
+		Head:           upstreamBranch,
+		HeadRepository: headRepo,
+	})
+	if err != nil {
+		if errors.Is(err, forge.ErrNotFound) {
+			log.Debugf("%v: no open CRs found for %v", name, upstreamBranch)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find changes: %w", err)
+	}
+
+	if currentID != nil && change.ID.String() == currentID.String() {
+		log.Debugf("%v: %v is up-to-date", name, remoteForge.FormatChangeID(currentID))
+		return nil, nil
+	}
+
+	// The CR must be for a commit we know about:
+	// the branch's head, or the last commit we pushed.
+	if change.HeadHash != branch.Head && (branch.UpstreamHash == "" || change.HeadHash != branch.UpstreamHash) {
+		log.Debugf("%v: ignoring %v: remote HEAD (%v) does not match local HEAD (%v)",
+			name, remoteForge.FormatChangeID(change.ID), change.HeadHash.Short(), branch.Head.Short())
+		return nil, nil
+	}
+
+	md, err := h.RemoteRepository.NewChangeMetadata(ctx, change.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get change metadata: %w", err)
+	}
+
+	metadata, err := remoteForge.MarshalChangeMetadata(md)
+	if err != nil {
+		return nil, fmt.Errorf("marshal change metadata: %w", err)
+	}
+
+	if currentID != nil {
+		log.Infof("%v: replacing %v with %v: %v", name,
+			remoteForge.FormatChangeID(currentID), remoteForge.FormatChangeID(change.ID), change.URL)
+	} else {
+		log.Infof("%v: associating with %v: %v", name,
+			remoteForge.FormatChangeID(change.ID), change.URL)
+	}
+
+	return &branchUpdate{
+		Name:     name,
+		ForgeID:  md.ForgeID(),
+		Metadata: metadata,
+	}, nil
+}
+
+// forkRepositoryID identifies the forge repository of the given remote.
+func (h *Handler) forkRepositoryID(ctx context.Context, remote string) (forge.RepositoryID, error) {
+	if h.FindRemoteRepositoryID == nil {
+		return nil, fmt.Errorf("cannot look up CRs for branches pushed to remote %v", remote)
+	}
+
+	f, repoID, err := h.FindRemoteRepositoryID(ctx, remote)
+	if err != nil {
+		return nil, fmt.Errorf("identify remote %v: %w", remote, err)
+	}
+
+	if got, want := f.ID(), h.RemoteRepository.Forge().ID(); got != want {
+		return nil, fmt.Errorf("remote %v is hosted on %v, not %v", remote, got, want)
+	}
+	return repoID, nil
+}
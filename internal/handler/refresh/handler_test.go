@@ -0,0 +1,229 @@
+package refresh
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/spice/state/statetest"
+)
+
+func TestHandler_RefreshBranches(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// Existing change associated with the branch, if any.
+		current int
+		changes []forgetest.FakeChange
+
+		// Expected change number after the refresh,
+		// or zero if the branch should not be associated with any change.
+		want int
+	}{
+		{
+			name:    "UpToDate",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc"},
+			},
+			want: 1,
+		},
+		{
+			name:    "Recreated",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc", State: forge.ChangeClosed},
+				{Number: 2, Head: "feature", HeadHash: "abc"},
+			},
+			want: 2,
+		},
+		{
+			name:    "RecreatedMismatchedHead",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc", State: forge.ChangeClosed},
+				{Number: 2, Head: "feature", HeadHash: "def"},
+			},
+			want: 1,
+		},
+		{
+			name:    "RecreatedAtLastPush",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc", State: forge.ChangeClosed},
+				{Number: 2, Head: "feature", HeadHash: "pushed"},
+			},
+			want: 2,
+		},
+		{
+			name:    "ForkWithSameBranchName",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc", State: forge.ChangeClosed},
+				{Number: 2, Head: "feature", HeadHash: "abc", HeadRepo: "stranger/example"},
+			},
+			want: 1,
+		},
+		{
+			name:    "NoOpenChanges",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc", State: forge.ChangeMerged},
+			},
+			want: 1,
+		},
+		{
+			name:    "MultipleOpenChanges",
+			current: 1,
+			changes: []forgetest.FakeChange{
+				{Number: 2, Head: "feature", HeadHash: "abc", Base: "main"},
+				{Number: 3, Head: "feature", HeadHash: "abc", Base: "develop"},
+			},
+			want: 3, // most recent
+		},
+		{
+			name: "AssociateMatchingHead",
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "abc"},
+			},
+			want: 1,
+		},
+		{
+			name: "IgnoreMismatchedHead",
+			changes: []forgetest.FakeChange{
+				{Number: 1, Head: "feature", HeadHash: "def"},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			log := silogtest.New(t)
+			store := statetest.NewMemoryStore(t, "main", "origin", log)
+
+			remoteRepo := forgetest.NewFakeRepository()
+			for _, c := range tt.changes {
+				remoteRepo.AddChange(c)
+			}
+
+			var currentChange forge.ChangeMetadata
+			if tt.current != 0 {
+				currentChange = &forgetest.FakeChangeMetadata{Number: tt.current}
+			}
+
+			mockService := NewMockService(gomock.NewController(t))
+			mockService.EXPECT().
+				LookupBranch(gomock.Any(), "feature").
+				Return(&spice.LookupBranchResponse{
+					Base:         "main",
+					Head:         git.Hash("abc"),
+					UpstreamHash: git.Hash("pushed"),
+					Change:       currentChange,
+				}, nil)
+
+			require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+				Upserts: []state.UpsertRequest{{
+					Name:     "feature",
+					Base:     "main",
+					BaseHash: "123",
+				}},
+			}))
+
+			res, err := (&Handler{
+				Log:              log,
+				Store:            store,
+				Service:          mockService,
+				RemoteRepository: remoteRepo,
+			}).RefreshBranches(ctx, &Request{
+				Branches: []string{"feature"},
+			})
+			require.NoError(t, err)
+
+			updated := tt.want != tt.current
+			if updated {
+				assert.Equal(t, []string{"feature"}, res.Updated)
+			} else {
+				assert.Empty(t, res.Updated)
+				return
+			}
+
+			got, err := store.LookupBranch(ctx, "feature")
+			require.NoError(t, err)
+			assert.Equal(t, forgetest.FakeForgeID, got.ChangeForge)
+
+			var md forgetest.FakeChangeMetadata
+			require.NoError(t, json.Unmarshal(got.ChangeMetadata, &md))
+			assert.Equal(t, tt.want, md.Number)
+		})
+	}
+}
+
+func TestHandler_RefreshBranches_fork(t *testing.T) {
+	ctx := t.Context()
+	log := silogtest.New(t)
+	store := statetest.NewMemoryStore(t, "main", "origin", log)
+	mockCtrl := gomock.NewController(t)
+
+	remoteRepo := forgetest.NewFakeRepository()
+	remoteRepo.AddChange(forgetest.FakeChange{Number: 1, Head: "feature", HeadHash: "abc", HeadRepo: "alice/example", State: forge.ChangeClosed})
+	// Same branch name in an unrelated fork and in the upstream repository.
+	remoteRepo.AddChange(forgetest.FakeChange{Number: 2, Head: "feature", HeadHash: "abc", HeadRepo: "stranger/example"})
+	remoteRepo.AddChange(forgetest.FakeChange{Number: 3, Head: "feature", HeadHash: "abc"})
+	remoteRepo.AddChange(forgetest.FakeChange{Number: 4, Head: "feature", HeadHash: "abc", HeadRepo: "alice/example"})
+
+	mockService := NewMockService(mockCtrl)
+	mockService.EXPECT().
+		LookupBranch(gomock.Any(), "feature").
+		Return(&spice.LookupBranchResponse{
+			Base:           "main",
+			Head:           git.Hash("abc"),
+			UpstreamBranch: "feature",
+			UpstreamRemote: "fork",
+			Change:         &forgetest.FakeChangeMetadata{Number: 1},
+		}, nil)
+
+	forkID := forgetest.NewMockRepositoryID(mockCtrl)
+	forkID.EXPECT().String().Return("alice/example").AnyTimes()
+
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{{
+			Name:     "feature",
+			Base:     "main",
+			BaseHash: "123",
+		}},
+	}))
+
+	res, err := (&Handler{
+		Log:              log,
+		Store:            store,
+		Service:          mockService,
+		RemoteRepository: remoteRepo,
+		FindRemoteRepositoryID: func(_ context.Context, remote string) (forge.Forge, forge.RepositoryID, error) {
+			assert.Equal(t, "fork", remote)
+			return remoteRepo.Forge(), forkID, nil
+		},
+	}).RefreshBranches(ctx, &Request{
+		Branches: []string{"feature"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature"}, res.Updated)
+
+	got, err := store.LookupBranch(ctx, "feature")
+	require.NoError(t, err)
+
+	var md forgetest.FakeChangeMetadata
+	require.NoError(t, json.Unmarshal(got.ChangeMetadata, &md))
+	assert.Equal(t, 4, md.Number)
+}
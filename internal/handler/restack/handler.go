@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/conflict"
 	"go.abhg.dev/gs/internal/iterutil"
 	"go.abhg.dev/gs/internal/must"
 	"go.abhg.dev/gs/internal/silog"
@@ -17,7 +19,7 @@ import (
 	"go.abhg.dev/gs/internal/spice/state"
 )
 
-//go:generate mockgen -package restack -destination mocks_test.go . GitWorktree,Service
+//go:generate mockgen -package restack -destination mocks_test.go . GitWorktree,Service,ConflictHandler
 
 // GitWorktree is a subet of the git.Worktree interface.
 type GitWorktree interface {
@@ -40,12 +42,23 @@ type Service interface {
 	RebaseRescue(ctx context.Context, req spice.RebaseRescueRequest) error
 }
 
+// ConflictHandler is a subset of the conflict.Handler interface.
+type ConflictHandler interface {
+	ResolveConflicts(ctx context.Context, req *conflict.Request) error
+}
+
+var _ ConflictHandler = (*conflict.Handler)(nil)
+
 // Handler implements various restack operations.
 type Handler struct {
 	Log      *silog.Logger // required
 	Worktree GitWorktree   // required
 	Store    Store         // required
 	Service  Service       // required
+
+	// Conflict, if set, is given a chance to resolve conflicts
+	// that interrupt a restack before the operation is aborted.
+	Conflict ConflictHandler
 }
 
 // Scope specifies which branches are affected
@@ -93,6 +106,11 @@ type Request struct {
 	//
 	// Defaults to ScopeBranch.
 	Scope Scope
+
+	// Progress reports progress as each branch is restacked,
+	// and the status of every branch at the end.
+	// Use this for operations that may restack many branches.
+	Progress bool
 }
 
 // Restack restacks one or more branches according to the request.
@@ -151,6 +169,12 @@ func (h *Handler) Restack(ctx context.Context, req *Request) (int, error) {
 		}
 	}
 
+	var progress *progress // nil if progress is not reported
+	if req.Progress {
+		progress = newProgress(h.Log)
+		defer progress.Print()
+	}
+
 	// If any of the branches to be restacked
 	// are checked out in another Git worktree,
 	// we cannot restack anything upstack from that branch.
@@ -168,10 +192,20 @@ func (h *Handler) Restack(ctx context.Context, req *Request) (int, error) {
 		}
 
 		if info, ok := branchGraph.Lookup(branch); ok {
+			// Archived branches are restacked
+			// only if they were explicitly requested.
+			if info.Archived && branch != req.Branch {
+				h.Log.Debugf("%v: archived, skipping", branch)
+				progress.Skip(branch, "archived")
+				skipped[branch] = struct{}{}
+				continue
+			}
+
 			if _, baseSkipped := skipped[info.Base]; baseSkipped {
 				// Base branch not being restacked,
 				// so skip this as well.
 				h.Log.Warnf("%v: base branch %v was not restacked, skipping", branch, info.Base)
+				progress.Skip(branch, "base branch "+info.Base+" was not restacked")
 				skipped[branch] = struct{}{}
 				continue
 			}
@@ -184,24 +218,91 @@ func (h *Handler) Restack(ctx context.Context, req *Request) (int, error) {
 		if branchWT != "" && branchWT != currentWT {
 			// Checked out in another worktree.
 			h.Log.Warnf("%v: checked out in another worktree (%v), skipping", branch, branchWT)
+			progress.Skip(branch, "checked out in another worktree")
 			skipped[branch] = struct{}{}
 			continue
 		}
 
+		progress.Add(branch)
 		branchesToActuallyRestack = append(branchesToActuallyRestack, branch)
 	}
 	branchesToRestack = branchesToActuallyRestack
 
+	// If interrupted, we'll stop before the next branch,
+	// and return to the starting branch
+	// so that the continue command can pick up from there.
+	canCheckout := requestBranchWT == "" || requestBranchWT == currentWT
+	interrupted := func(pending []string, cause error) error {
+		for _, branch := range pending {
+			progress.Set(branch, "not restacked: interrupted")
+		}
+
+		if canCheckout {
+			ctx := context.WithoutCancel(ctx)
+			if err := h.Worktree.CheckoutBranch(ctx, req.Branch); err != nil {
+				h.Log.Warn("Could not check out starting branch", "branch", req.Branch, "error", err)
+			}
+		}
+
+		h.Log.Errorf("Interrupted before restacking: %v", strings.Join(pending, ", "))
+		h.Log.Errorf("Run '%s %s' from %v to continue.",
+			cli.Name(), strings.Join(req.ContinueCommand, " "), req.Branch)
+		return fmt.Errorf("restack interrupted: %w", cause)
+	}
+
 	var restackCount int
 loop:
-	for _, branch := range branchesToRestack {
+	for idx, branch := range branchesToRestack {
+		if err := ctx.Err(); err != nil {
+			return 0, interrupted(branchesToRestack[idx:], err)
+		}
+
+		progress.Start(branch)
 		res, err := h.Service.Restack(ctx, branch)
 		if err != nil {
-			var rebaseErr *git.RebaseInterruptError
+			var (
+				rebaseErr    *git.RebaseInterruptError
+				protectedErr *spice.ProtectedBranchError
+			)
 			switch {
 			case errors.As(err, &rebaseErr):
+				if h.Conflict != nil {
+					var base string
+					if info, ok := branchGraph.Lookup(branch); ok {
+						base = info.Base
+					}
+
+					err := h.Conflict.ResolveConflicts(ctx, &conflict.Request{
+						Branch:  branch,
+						Base:    base,
+						Err:     rebaseErr,
+						Pending: branchesToRestack[idx+1:],
+					})
+					if err == nil {
+						// Conflicts resolved and rebase finished.
+						// Restack again to record the new base hash.
+						if _, err := h.Service.Restack(ctx, branch); err != nil && !errors.Is(err, spice.ErrAlreadyRestacked) {
+							return 0, fmt.Errorf("restack branch %q: %w", branch, err)
+						}
+
+						h.Log.Infof("%v: restacked on %v", branch, base)
+						progress.Set(branch, "restacked on "+base+" after resolving conflicts")
+						restackCount++
+						continue loop
+					}
+
+					if errors.Is(err, conflict.ErrAborted) {
+						progress.Set(branch, "conflicted: rebase aborted")
+						return 0, err
+					}
+					if !errors.As(err, &rebaseErr) {
+						return 0, fmt.Errorf("resolve conflicts in %q: %w", branch, err)
+					}
+				}
+
 				// If the rebase is interrupted by a conflict,
 				// we'll resume by re-running this command.
+				progress.Set(branch, "conflicted")
 				return 0, h.Service.RebaseRescue(ctx, spice.RebaseRescueRequest{
 					Err:     rebaseErr,
 					Command: req.ContinueCommand,
@@ -215,18 +316,33 @@ loop:
 
 			case errors.Is(err, spice.ErrAlreadyRestacked):
 				h.Log.Infof("%v: branch does not need to be restacked.", branch)
+				progress.Set(branch, "up to date")
+				continue loop
+
+			case errors.As(err, &protectedErr):
+				// Fail if this was the only branch requested.
+				// Otherwise, leave it as-is and restack the rest.
+				if req.Scope == ScopeBranch {
+					return 0, err
+				}
+				h.Log.Warnf("%v: protected branch, not restacking", branch)
+				progress.Set(branch, "protected: not restacked")
 				continue loop
 
 			default:
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return 0, interrupted(branchesToRestack[idx:], ctxErr)
+				}
 				return 0, fmt.Errorf("restack branch %q: %w", branch, err)
 			}
 		}
 
 		h.Log.Infof("%v: restacked on %v", branch, res.Base)
+		progress.Set(branch, "restacked on "+res.Base)
 		restackCount++
 	}
 
-	if requestBranchWT != "" && requestBranchWT != currentWT {
+	if !canCheckout {
 		h.Log.Warnf("%v: checked out in another worktree (%v), not checking out here", req.Branch, requestBranchWT)
 	} else if restackCount > 0 {
 		if err := h.Worktree.CheckoutBranch(ctx, req.Branch); err != nil {
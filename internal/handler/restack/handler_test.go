@@ -12,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/conflict"
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/spice/state"
@@ -473,6 +474,120 @@ func TestHandler_Restack(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, 0, count)
 	})
+
+	t.Run("ConflictResolved", func(t *testing.T) {
+		log := silog.Nop()
+		ctrl := gomock.NewController(t)
+
+		rebaseErr := &git.RebaseInterruptError{
+			Kind: git.RebaseInterruptConflict,
+		}
+
+		mockService := NewMockService(ctrl)
+		mockService.EXPECT().
+			BranchGraph(gomock.Any(), gomock.Any()).
+			Return(newBranchGraphBuilder("main").
+				Branch("feature", "main").
+				Build(t), nil)
+		gomock.InOrder(
+			mockService.EXPECT().
+				Restack(gomock.Any(), "feature").
+				Return(nil, rebaseErr),
+			mockService.EXPECT().
+				Restack(gomock.Any(), "feature").
+				Return(nil, spice.ErrAlreadyRestacked),
+		)
+
+		mockConflict := NewMockConflictHandler(ctrl)
+		mockConflict.EXPECT().
+			ResolveConflicts(gomock.Any(), &conflict.Request{
+				Branch:  "feature",
+				Base:    "main",
+				Err:     rebaseErr,
+				Pending: []string{},
+			}).
+			Return(nil)
+
+		mockWorktree := NewMockGitWorktree(ctrl)
+		mockWorktree.EXPECT().
+			RootDir().
+			Return(t.TempDir())
+		mockWorktree.EXPECT().
+			CheckoutBranch(gomock.Any(), "feature").
+			Return(nil)
+
+		handler := &Handler{
+			Log:      log,
+			Worktree: mockWorktree,
+			Store:    statetest.NewMemoryStore(t, "main", "", log),
+			Service:  mockService,
+			Conflict: mockConflict,
+		}
+
+		count, err := handler.Restack(t.Context(), &Request{
+			Branch:          "feature",
+			ContinueCommand: []string{"false"},
+			Scope:           ScopeBranch,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ConflictUnresolved", func(t *testing.T) {
+		log := silog.Nop()
+		ctrl := gomock.NewController(t)
+
+		rebaseErr := &git.RebaseInterruptError{
+			Kind: git.RebaseInterruptConflict,
+		}
+		nextErr := &git.RebaseInterruptError{
+			Kind: git.RebaseInterruptConflict,
+		}
+
+		mockService := NewMockService(ctrl)
+		mockService.EXPECT().
+			BranchGraph(gomock.Any(), gomock.Any()).
+			Return(newBranchGraphBuilder("main").
+				Branch("feature", "main").
+				Build(t), nil)
+		mockService.EXPECT().
+			Restack(gomock.Any(), "feature").
+			Return(nil, rebaseErr)
+		mockService.EXPECT().
+			RebaseRescue(gomock.Any(), spice.RebaseRescueRequest{
+				Err:     nextErr,
+				Command: []string{"false"},
+				Branch:  "feature",
+				Message: `interrupted: restack branch "feature"`,
+			}).
+			Return(nil)
+
+		mockConflict := NewMockConflictHandler(ctrl)
+		mockConflict.EXPECT().
+			ResolveConflicts(gomock.Any(), gomock.Any()).
+			Return(nextErr)
+
+		mockWorktree := NewMockGitWorktree(ctrl)
+		mockWorktree.EXPECT().
+			RootDir().
+			Return(t.TempDir())
+
+		handler := &Handler{
+			Log:      log,
+			Worktree: mockWorktree,
+			Store:    statetest.NewMemoryStore(t, "main", "", log),
+			Service:  mockService,
+			Conflict: mockConflict,
+		}
+
+		count, err := handler.Restack(t.Context(), &Request{
+			Branch:          "feature",
+			ContinueCommand: []string{"false"},
+			Scope:           ScopeBranch,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
 }
 
 func TestHandler_Restack_trunk(t *testing.T) {
@@ -697,6 +812,94 @@ func TestHandler_Restack_skipCheckedOut(t *testing.T) {
 	})
 }
 
+func TestHandler_Restack_skipArchived(t *testing.T) {
+	t.Run("Upstack", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, &silog.Options{Level: silog.LevelDebug})
+		ctrl := gomock.NewController(t)
+
+		mockService := NewMockService(ctrl)
+		mockService.EXPECT().
+			BranchGraph(gomock.Any(), gomock.Any()).
+			Return(newBranchGraphBuilder("main").
+				Branch("feature1", "main").
+				Archived("feature2", "feature1").
+				Archived("feature3", "feature2").
+				Branch("feature4", "feature1").
+				Build(t), nil)
+		mockService.EXPECT().
+			Restack(gomock.Any(), "feature1").
+			Return(&spice.RestackResponse{Base: "main"}, nil)
+		mockService.EXPECT().
+			Restack(gomock.Any(), "feature4").
+			Return(&spice.RestackResponse{Base: "feature1"}, nil)
+
+		mockWorktree := NewMockGitWorktree(ctrl)
+		mockWorktree.EXPECT().
+			RootDir().
+			Return(t.TempDir())
+		mockWorktree.EXPECT().
+			CheckoutBranch(gomock.Any(), "feature1").
+			Return(nil)
+
+		handler := &Handler{
+			Log:      log,
+			Worktree: mockWorktree,
+			Store:    statetest.NewMemoryStore(t, "main", "", log),
+			Service:  mockService,
+		}
+
+		count, err := handler.Restack(t.Context(), &Request{
+			Branch:          "feature1",
+			ContinueCommand: []string{"false"},
+			Scope:           ScopeUpstack,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		assert.Contains(t, logBuffer.String(), "feature2: archived, skipping")
+		assert.Contains(t, logBuffer.String(), "feature3: archived, skipping")
+	})
+
+	t.Run("ExplicitBranch", func(t *testing.T) {
+		log := silog.Nop()
+		ctrl := gomock.NewController(t)
+
+		mockService := NewMockService(ctrl)
+		mockService.EXPECT().
+			BranchGraph(gomock.Any(), gomock.Any()).
+			Return(newBranchGraphBuilder("main").
+				Archived("feature", "main").
+				Build(t), nil)
+		mockService.EXPECT().
+			Restack(gomock.Any(), "feature").
+			Return(&spice.RestackResponse{Base: "main"}, nil)
+
+		mockWorktree := NewMockGitWorktree(ctrl)
+		mockWorktree.EXPECT().
+			RootDir().
+			Return(t.TempDir())
+		mockWorktree.EXPECT().
+			CheckoutBranch(gomock.Any(), "feature").
+			Return(nil)
+
+		handler := &Handler{
+			Log:      log,
+			Worktree: mockWorktree,
+			Store:    statetest.NewMemoryStore(t, "main", "", log),
+			Service:  mockService,
+		}
+
+		count, err := handler.Restack(t.Context(), &Request{
+			Branch:          "feature",
+			ContinueCommand: []string{"false"},
+			Scope:           ScopeBranch,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
 func TestHandler_Restack_errors(t *testing.T) {
 	t.Run("BranchGraph", func(t *testing.T) {
 		log := silog.Nop()
@@ -867,6 +1070,15 @@ func (b *branchGraphBuilder) Branch(name, base string) *branchGraphBuilder {
 	return b
 }
 
+func (b *branchGraphBuilder) Archived(name, base string) *branchGraphBuilder {
+	b.items = append(b.items, spice.BranchGraphItem{
+		Name:     name,
+		Base:     base,
+		Archived: true,
+	})
+	return b
+}
+
 func (b *branchGraphBuilder) Worktree(branch, wt string) *branchGraphBuilder {
 	b.worktrees[branch] = wt
 	return b
@@ -908,3 +1120,116 @@ func (b *branchLoaderStub) LookupWorktrees(_ context.Context, branches []string)
 	}
 	return wts, nil
 }
+
+func TestHandler_Restack_progress(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log := silog.New(&logBuffer, nil)
+	ctrl := gomock.NewController(t)
+
+	rebaseErr := &git.RebaseInterruptError{Kind: git.RebaseInterruptConflict}
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().
+		BranchGraph(gomock.Any(), gomock.Any()).
+		Return(newBranchGraphBuilder("main").
+			Branch("feature1", "main").
+			Branch("feature2", "feature1").
+			Branch("feature3", "feature2").
+			Build(t), nil)
+	mockService.EXPECT().
+		Restack(gomock.Any(), "feature1").
+		Return(&spice.RestackResponse{Base: "main"}, nil)
+	mockService.EXPECT().
+		Restack(gomock.Any(), "feature2").
+		Return(nil, rebaseErr)
+	mockService.EXPECT().
+		RebaseRescue(gomock.Any(), gomock.Any()).
+		Return(rebaseErr)
+
+	mockWorktree := NewMockGitWorktree(ctrl)
+	mockWorktree.EXPECT().
+		RootDir().
+		Return(t.TempDir())
+
+	handler := &Handler{
+		Log:      log,
+		Worktree: mockWorktree,
+		Store:    statetest.NewMemoryStore(t, "main", "", log),
+		Service:  mockService,
+	}
+
+	_, err := handler.Restack(t.Context(), &Request{
+		Branch:          "main",
+		ContinueCommand: []string{"repo", "restack"},
+		Scope:           ScopeUpstackExclusive,
+		Progress:        true,
+	})
+	require.ErrorIs(t, err, rebaseErr)
+
+	output := logBuffer.String()
+	assert.Contains(t, output, "[1/3] feature1: restacking")
+	assert.Contains(t, output, "[2/3] feature2: restacking")
+	assert.NotContains(t, output, "[3/3]")
+	assert.Contains(t, output, "Restack summary:")
+	assert.Contains(t, output, "  feature1  restacked on main")
+	assert.Contains(t, output, "  feature2  conflicted")
+	assert.Contains(t, output, "  feature3  not restacked")
+}
+
+func TestHandler_Restack_interrupted(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log := silog.New(&logBuffer, nil)
+	ctrl := gomock.NewController(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().
+		BranchGraph(gomock.Any(), gomock.Any()).
+		Return(newBranchGraphBuilder("main").
+			Branch("feature1", "main").
+			Branch("feature2", "feature1").
+			Branch("feature3", "feature2").
+			Build(t), nil)
+	mockService.EXPECT().
+		Restack(gomock.Any(), "feature1").
+		DoAndReturn(func(context.Context, string) (*spice.RestackResponse, error) {
+			// Interrupted while restacking feature1.
+			// That step finishes, but nothing after it runs.
+			cancel()
+			return &spice.RestackResponse{Base: "main"}, nil
+		})
+
+	mockWorktree := NewMockGitWorktree(ctrl)
+	mockWorktree.EXPECT().
+		RootDir().
+		Return(t.TempDir())
+	mockWorktree.EXPECT().
+		CheckoutBranch(gomock.Any(), "feature1").
+		DoAndReturn(func(ctx context.Context, _ string) error {
+			assert.NoError(t, ctx.Err(), "checkout must not be canceled")
+			return nil
+		})
+
+	handler := &Handler{
+		Log:      log,
+		Worktree: mockWorktree,
+		Store:    statetest.NewMemoryStore(t, "main", "", log),
+		Service:  mockService,
+	}
+
+	_, err := handler.Restack(ctx, &Request{
+		Branch:          "feature1",
+		ContinueCommand: []string{"upstack", "restack"},
+		Scope:           ScopeUpstack,
+		Progress:        true,
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	output := logBuffer.String()
+	assert.Contains(t, output, "Interrupted before restacking: feature2, feature3")
+	assert.Contains(t, output, "upstack restack' from feature1 to continue.")
+	assert.Contains(t, output, "  feature1  restacked on main")
+	assert.Contains(t, output, "  feature2  not restacked: interrupted")
+	assert.Contains(t, output, "  feature3  not restacked: interrupted")
+}
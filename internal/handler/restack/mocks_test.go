@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: go.abhg.dev/gs/internal/handler/restack (interfaces: GitWorktree,Service)
+// Source: go.abhg.dev/gs/internal/handler/restack (interfaces: GitWorktree,Service,ConflictHandler)
 //
 // Generated by this command:
 //
-//	mockgen -package restack -destination mocks_test.go . GitWorktree,Service
+//	mockgen -package restack -destination mocks_test.go . GitWorktree,Service,ConflictHandler
 //
 
 // Package restack is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	conflict "go.abhg.dev/gs/internal/handler/conflict"
 	spice "go.abhg.dev/gs/internal/spice"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -151,3 +152,41 @@ func (mr *MockServiceMockRecorder) Restack(ctx, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restack", reflect.TypeOf((*MockService)(nil).Restack), ctx, name)
 }
+
+// MockConflictHandler is a mock of ConflictHandler interface.
+type MockConflictHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockConflictHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockConflictHandlerMockRecorder is the mock recorder for MockConflictHandler.
+type MockConflictHandlerMockRecorder struct {
+	mock *MockConflictHandler
+}
+
+// NewMockConflictHandler creates a new mock instance.
+func NewMockConflictHandler(ctrl *gomock.Controller) *MockConflictHandler {
+	mock := &MockConflictHandler{ctrl: ctrl}
+	mock.recorder = &MockConflictHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConflictHandler) EXPECT() *MockConflictHandlerMockRecorder {
+	return m.recorder
+}
+
+// ResolveConflicts mocks base method.
+func (m *MockConflictHandler) ResolveConflicts(ctx context.Context, req *conflict.Request) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveConflicts", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveConflicts indicates an expected call of ResolveConflicts.
+func (mr *MockConflictHandlerMockRecorder) ResolveConflicts(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveConflicts", reflect.TypeOf((*MockConflictHandler)(nil).ResolveConflicts), ctx, req)
+}
@@ -0,0 +1,105 @@
+package restack
+
+import (
+	"os"
+	"time"
+
+	"go.abhg.dev/gs/internal/silog"
+)
+
+var _timeNow = time.Now
+
+func init() {
+	now := os.Getenv("GIT_SPICE_NOW")
+	if now != "" {
+		t, err := time.Parse(time.RFC3339, now)
+		if err == nil {
+			_timeNow = func() time.Time {
+				return t
+			}
+		}
+	}
+}
+
+// progress reports the progress of a restack operation
+// over many branches: a line as each branch is restacked,
+// and a table with the status of every branch at the end.
+//
+// All methods are no-ops on a nil progress.
+type progress struct {
+	log   *silog.Logger
+	start time.Time
+
+	total int // number of branches to restack
+	count int // number of branches started so far
+
+	branches []string          // all branches in restack order
+	statuses map[string]string // branch => status
+}
+
+func newProgress(log *silog.Logger) *progress {
+	return &progress{
+		log:      log,
+		start:    _timeNow(),
+		statuses: make(map[string]string),
+	}
+}
+
+// Add adds a branch that will be restacked.
+func (p *progress) Add(branch string) {
+	if p == nil {
+		return
+	}
+
+	p.total++
+	p.Set(branch, "not restacked")
+}
+
+// Skip records a branch that won't be restacked.
+func (p *progress) Skip(branch, reason string) {
+	if p == nil {
+		return
+	}
+
+	p.Set(branch, "skipped: "+reason)
+}
+
+// Start reports that the given branch is being restacked.
+func (p *progress) Start(branch string) {
+	if p == nil {
+		return
+	}
+
+	p.count++
+	elapsed := _timeNow().Sub(p.start).Round(100 * time.Millisecond)
+	p.log.Infof("[%d/%d] %v: restacking (%v elapsed)", p.count, p.total, branch, elapsed)
+}
+
+// Set records the status of a branch.
+func (p *progress) Set(branch, status string) {
+	if p == nil {
+		return
+	}
+
+	if _, ok := p.statuses[branch]; !ok {
+		p.branches = append(p.branches, branch)
+	}
+	p.statuses[branch] = status
+}
+
+// Print prints the status of all branches.
+func (p *progress) Print() {
+	if p == nil || len(p.branches) == 0 {
+		return
+	}
+
+	var width int
+	for _, branch := range p.branches {
+		width = max(width, len(branch))
+	}
+
+	p.log.Info("Restack summary:")
+	for _, branch := range p.branches {
+		p.log.Infof("  %-*s  %v", width, branch, p.statuses[branch])
+	}
+}
@@ -249,9 +249,15 @@ func (h *Handler) SplitBranch(ctx context.Context, req *BranchRequest) (*BranchR
 			"base", finalBase+"@"+finalBaseHash.String())
 	}
 
+	var changeID string
+	if branchInfo.Change != nil {
+		changeForge, _ := h.FindForge(branchInfo.Change.ForgeID())
+		changeID = forge.FormatChangeID(changeForge, branchInfo.Change.ChangeID())
+	}
+
 	if branchInfo.Change != nil && !ui.Interactive(h.View) {
 		h.Log.Info("Branch has an associated CR. Leaving it assigned to the original branch.",
-			"cr", branchInfo.Change.ChangeID())
+			"cr", changeID)
 	} else if branchInfo.Change != nil {
 		branchNames := make([]string, 0, len(opts.At)+1)
 		for _, split := range opts.At {
@@ -265,7 +271,7 @@ func (h *Handler) SplitBranch(ctx context.Context, req *BranchRequest) (*BranchR
 
 		var changeBranch string
 		prompt := ui.NewSelect[string]().
-			WithTitle(fmt.Sprintf("Assign CR %v to branch", branchInfo.Change.ChangeID())).
+			WithTitle(fmt.Sprintf("Assign CR %v to branch", changeID)).
 			WithDescription("Branch being split has an open CR assigned to it.\n" +
 				"Select which branch should take over the CR.").
 			WithValue(&changeBranch).
@@ -281,10 +287,11 @@ func (h *Handler) SplitBranch(ctx context.Context, req *BranchRequest) (*BranchR
 				changeBranch,
 				branchInfo.Change,
 				branchInfo.UpstreamBranch,
+				branchInfo.UpstreamRemote,
 				branchTx,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("transfer CR %v to %v: %w", branchInfo.Change.ChangeID(), changeBranch, err)
+				return nil, fmt.Errorf("transfer CR %v to %v: %w", changeID, changeBranch, err)
 			}
 
 			defer func() {
@@ -334,7 +341,7 @@ func (h *Handler) prepareChangeMetadataTransfer(
 	ctx context.Context,
 	fromBranch, toBranch string,
 	meta forge.ChangeMetadata,
-	upstreamBranch string,
+	upstreamBranch, upstreamRemote string,
 	tx *state.BranchTx,
 ) (transfer func(), _ error) {
 	forgeID := meta.ForgeID()
@@ -343,9 +350,13 @@ func (h *Handler) prepareChangeMetadataTransfer(
 		return nil, fmt.Errorf("unknown forge: %v", forgeID)
 	}
 
-	remote, err := h.Store.Remote()
-	if err != nil {
-		return nil, fmt.Errorf("get remote: %w", err)
+	remote := upstreamRemote
+	if remote == "" {
+		var err error
+		remote, err = h.Store.Remote()
+		if err != nil {
+			return nil, fmt.Errorf("get remote: %w", err)
+		}
 	}
 
 	metaJSON, err := f.MarshalChangeMetadata(meta)
@@ -369,6 +380,7 @@ func (h *Handler) prepareChangeMetadataTransfer(
 		ChangeMetadata: metaJSON,
 		ChangeForge:    forgeID,
 		UpstreamBranch: &toUpstreamBranch,
+		UpstreamRemote: &upstreamRemote,
 	}); err != nil {
 		return nil, fmt.Errorf("set change metadata on %v: %w", toBranch, err)
 	}
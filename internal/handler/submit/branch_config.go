@@ -0,0 +1,128 @@
+package submit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+// _navCommentMarkerKey is the configuration key for the marker
+// used in navigation comments.
+const _navCommentMarkerKey = "spice.submit.navigationCommentStyle.marker"
+
+// _navCommentLayoutKey is the configuration key for the layout
+// of the stack in navigation comments.
+const _navCommentLayoutKey = "spice.submit.navigationCommentStyle.layout"
+
+// _branchConfigOverrides lists configuration keys
+// that may be overridden per branch,
+// and how to apply each override to the submit options.
+//
+// Only options that are normally set through configuration
+// (hidden flags) are listed here,
+// so that flags like --draft or --label always take precedence.
+var _branchConfigOverrides = map[string]func(*Options, []string) error{
+	"spice.submit.branchDescription": func(opts *Options, values []string) (err error) {
+		opts.BranchDescription, err = strconv.ParseBool(lastValue(values))
+		return err
+	},
+	"spice.submit.conventionalCommits": func(opts *Options, values []string) (err error) {
+		opts.ConventionalCommits, err = strconv.ParseBool(lastValue(values))
+		return err
+	},
+	"spice.submit.draft": func(opts *Options, values []string) (err error) {
+		opts.DraftDefault, err = strconv.ParseBool(lastValue(values))
+		return err
+	},
+	"spice.submit.includeNote": func(opts *Options, values []string) (err error) {
+		opts.IncludeNote, err = strconv.ParseBool(lastValue(values))
+		return err
+	},
+	"spice.submit.perCommit": func(opts *Options, values []string) (err error) {
+		opts.PerCommit, err = strconv.ParseBool(lastValue(values))
+		return err
+	},
+	"spice.submit.label": func(opts *Options, values []string) error {
+		opts.ConfiguredLabels = splitValues(values)
+		return nil
+	},
+	"spice.submit.reviewers": func(opts *Options, values []string) error {
+		opts.ConfiguredReviewers = splitValues(values)
+		return nil
+	},
+	"spice.submit.reviewers.addWhen": func(opts *Options, values []string) error {
+		return opts.ReviewersAddWhen.UnmarshalText([]byte(lastValue(values)))
+	},
+	"spice.submit.assignees": func(opts *Options, values []string) error {
+		opts.ConfiguredAssignees = splitValues(values)
+		return nil
+	},
+	"spice.submit.template": func(opts *Options, values []string) error {
+		opts.Template = lastValue(values)
+		return nil
+	},
+	_navCommentMarkerKey: func(opts *Options, values []string) error {
+		opts.NavCommentMarker = lastValue(values)
+		return nil
+	},
+	_navCommentLayoutKey: func(opts *Options, values []string) error {
+		return opts.NavCommentLayout.UnmarshalText([]byte(lastValue(values)))
+	},
+}
+
+// BranchConfigKeys returns the configuration keys
+// that may be overridden per branch, in sorted order.
+func BranchConfigKeys() []string {
+	return slices.Sorted(maps.Keys(_branchConfigOverrides))
+}
+
+// applyBranchConfig returns a copy of the given options
+// with the given branch overrides applied.
+// Overrides of keys that cannot be overridden are ignored.
+func applyBranchConfig(log *silog.Logger, opts *Options, cfg spice.BranchConfig) (*Options, error) {
+	if len(cfg) == 0 {
+		return opts, nil
+	}
+
+	newOpts := *opts
+	for _, key := range cfg.Keys() {
+		v := cfg[key]
+		apply, ok := _branchConfigOverrides[key]
+		if !ok {
+			log.Warnf("%v: ignoring override of %v: not supported", v.Branch, key)
+			continue
+		}
+
+		if err := apply(&newOpts, v.Values); err != nil {
+			return nil, fmt.Errorf("%v: override of %v: %w", v.Branch, key, err)
+		}
+	}
+	return &newOpts, nil
+}
+
+// lastValue returns the value in effect for a single-valued key.
+func lastValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// splitValues splits comma-separated values
+// the same way they're split when read from git-config.
+func splitValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for item := range strings.SplitSeq(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package submit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+func TestApplyBranchConfig(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		opts := &Options{DraftDefault: true}
+		got, err := applyBranchConfig(silogtest.New(t), opts, nil)
+		require.NoError(t, err)
+		assert.Same(t, opts, got)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		opts := &Options{
+			Labels:              []string{"flag"},
+			ConfiguredLabels:    []string{"global"},
+			ConfiguredReviewers: []string{"alice"},
+			Template:            "default.md",
+		}
+		got, err := applyBranchConfig(silogtest.New(t), opts, spice.BranchConfig{
+			"spice.submit.draft": {
+				Values: []string{"true"},
+				Branch: "feat1",
+			},
+			"spice.submit.label": {
+				Values: []string{"net", "backend, urgent"},
+				Branch: "feat1",
+			},
+			"spice.submit.reviewers": {
+				Values: []string{"bob"},
+				Branch: "feat2",
+			},
+			"spice.submit.reviewers.addWhen": {
+				Values: []string{"ready"},
+				Branch: "feat2",
+			},
+			"spice.submit.navigationCommentStyle.marker": {
+				Values: []string{"👉"},
+				Branch: "feat1",
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, &Options{
+			Labels:              []string{"flag"},
+			ConfiguredLabels:    []string{"net", "backend", "urgent"},
+			ConfiguredReviewers: []string{"bob"},
+			ReviewersAddWhen:    ReviewersAddWhenReady,
+			DraftDefault:        true,
+			NavCommentMarker:    "👉",
+			Template:            "default.md",
+		}, got)
+
+		// The original options are left unchanged.
+		assert.False(t, opts.DraftDefault)
+		assert.Equal(t, []string{"global"}, opts.ConfiguredLabels)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		opts := &Options{}
+		got, err := applyBranchConfig(silog.New(&logBuffer, nil), opts, spice.BranchConfig{
+			"spice.submit.publish": {
+				Values: []string{"false"},
+				Branch: "feat1",
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, opts, got)
+		assert.Contains(t, logBuffer.String(), "feat1: ignoring override of spice.submit.publish")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := applyBranchConfig(silogtest.New(t), &Options{}, spice.BranchConfig{
+			"spice.submit.draft": {
+				Values: []string{"maybe"},
+				Branch: "feat1",
+			},
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "feat1: override of spice.submit.draft")
+	})
+}
@@ -0,0 +1,82 @@
+package submit
+
+import (
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// changeExtras are optional properties of a change request
+// that not all forges support.
+type changeExtras struct {
+	Draft     *bool // nil if unchanged
+	Labels    []string
+	Reviewers []string
+	Assignees []string
+	AutoMerge bool
+}
+
+// dropUnsupported removes the properties from extras
+// that the given capabilities don't allow,
+// logging a warning for each kind of property that was dropped.
+func dropUnsupported(
+	log *silog.Logger,
+	branch string,
+	f forge.Forge,
+	caps forge.Capabilities,
+	extras changeExtras,
+) changeExtras {
+	warn := func(feature string, ignored []string) {
+		log.Warnf("%v: %v does not support %v; ignoring: %v",
+			branch, f.ID(), feature, strings.Join(ignored, ", "))
+	}
+
+	if extras.Draft != nil && !caps.Draft {
+		if *extras.Draft {
+			log.Warnf("%v: %v does not support draft change requests; ignoring draft status", branch, f.ID())
+		}
+		extras.Draft = nil
+	}
+
+	if len(extras.Labels) > 0 && !caps.Labels {
+		warn("labels", extras.Labels)
+		extras.Labels = nil
+	}
+
+	if len(extras.Assignees) > 0 && !caps.Assignees {
+		warn("assignees", extras.Assignees)
+		extras.Assignees = nil
+	}
+
+	if extras.AutoMerge && !caps.AutoMerge {
+		log.Warnf("%v: %v does not support merging change requests automatically; ignoring", branch, f.ID())
+		extras.AutoMerge = false
+	}
+
+	switch {
+	case len(extras.Reviewers) == 0:
+		// Nothing to check.
+
+	case !caps.Reviewers:
+		warn("reviewers", extras.Reviewers)
+		extras.Reviewers = nil
+
+	case !caps.TeamReviewers:
+		// Team reviewers are specified as "org/team".
+		var teams []string
+		extras.Reviewers = slices.DeleteFunc(slices.Clone(extras.Reviewers), func(r string) bool {
+			if strings.Contains(r, "/") {
+				teams = append(teams, r)
+				return true
+			}
+			return false
+		})
+		if len(teams) > 0 {
+			warn("team reviewers", teams)
+		}
+	}
+
+	return extras
+}
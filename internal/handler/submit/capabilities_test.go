@@ -0,0 +1,62 @@
+package submit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+func TestDropUnsupported(t *testing.T) {
+	yes := true
+	extras := changeExtras{
+		Draft:     &yes,
+		Labels:    []string{"bug"},
+		Reviewers: []string{"alice", "org/team", "bob"},
+		Assignees: []string{"carol"},
+		AutoMerge: true,
+	}
+
+	t.Run("AllSupported", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		got := dropUnsupported(silog.New(&logBuffer, nil), "feat", new(forgetest.FakeForge), forge.AllCapabilities, extras)
+		assert.Equal(t, extras, got)
+		assert.Empty(t, logBuffer.String())
+	})
+
+	t.Run("NoneSupported", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		got := dropUnsupported(silog.New(&logBuffer, nil), "feat", new(forgetest.FakeForge), forge.Capabilities{}, extras)
+		assert.Equal(t, changeExtras{}, got)
+
+		output := logBuffer.String()
+		assert.Contains(t, output, "feat: fake does not support draft change requests")
+		assert.Contains(t, output, "feat: fake does not support labels; ignoring: bug")
+		assert.Contains(t, output, "feat: fake does not support assignees; ignoring: carol")
+		assert.Contains(t, output, "feat: fake does not support reviewers; ignoring: alice, org/team, bob")
+		assert.Contains(t, output, "feat: fake does not support merging change requests automatically")
+	})
+
+	t.Run("NoTeamReviewers", func(t *testing.T) {
+		caps := forge.AllCapabilities
+		caps.TeamReviewers = false
+
+		var logBuffer bytes.Buffer
+		got := dropUnsupported(silog.New(&logBuffer, nil), "feat", new(forgetest.FakeForge), caps, extras)
+		assert.Equal(t, []string{"alice", "bob"}, got.Reviewers)
+		assert.Equal(t, []string{"alice", "org/team", "bob"}, extras.Reviewers, "input must not be modified")
+		assert.Contains(t, logBuffer.String(), "feat: fake does not support team reviewers; ignoring: org/team")
+	})
+
+	t.Run("NoDraftNotRequested", func(t *testing.T) {
+		no := false
+
+		var logBuffer bytes.Buffer
+		got := dropUnsupported(silog.New(&logBuffer, nil), "feat", new(forgetest.FakeForge), forge.Capabilities{}, changeExtras{Draft: &no})
+		assert.Nil(t, got.Draft)
+		assert.Empty(t, logBuffer.String())
+	})
+}
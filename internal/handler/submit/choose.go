@@ -0,0 +1,138 @@
+package submit
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// branchState is the state of a branch
+// relative to its Change Request(s).
+type branchState int
+
+const (
+	// branchStateNew is a branch that hasn't been submitted yet.
+	branchStateNew branchState = iota
+
+	// branchStateOutdated is a branch that was submitted,
+	// but has changed since.
+	branchStateOutdated
+
+	// branchStateUpToDate is a branch whose CR
+	// matches the local branch.
+	branchStateUpToDate
+)
+
+func (s branchState) String() string {
+	switch s {
+	case branchStateNew:
+		return "New"
+	case branchStateOutdated:
+		return "Needs update"
+	case branchStateUpToDate:
+		return "Up-to-date"
+	default:
+		return "Unknown"
+	}
+}
+
+// chooseBranches prompts the user to pick which of the given branches
+// to submit, with the branches grouped by their state.
+// Branches that are new or need to be updated are selected by default.
+//
+// The returned branches are in the same order as the input.
+func (h *Handler) chooseBranches(ctx context.Context, branches []string) ([]string, error) {
+	if !ui.Interactive(h.View) {
+		return nil, fmt.Errorf("cannot pick branches to submit: %w", ui.ErrPrompt)
+	}
+
+	remote, err := h.Remote(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get remote: %w", err)
+	}
+
+	type branchItem struct {
+		Name  string
+		State branchState
+	}
+
+	items := make([]branchItem, len(branches))
+	for i, name := range branches {
+		b, err := h.Service.LookupBranch(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("lookup branch %v: %w", name, err)
+		}
+		items[i] = branchItem{
+			Name:  name,
+			State: h.branchState(ctx, remote, b),
+		}
+	}
+	// Keep stack order within each group.
+	slices.SortStableFunc(items, func(a, b branchItem) int {
+		return cmp.Compare(a.State, b.State)
+	})
+
+	opts := make([]ui.MultiSelectOption[string], len(items))
+	for i, item := range items {
+		opts[i] = ui.MultiSelectOption[string]{
+			Value:    item.Name,
+			Selected: item.State != branchStateUpToDate,
+			Group:    item.State.String(),
+		}
+	}
+
+	field := ui.NewMultiSelect(func(w ui.Writer, _ int, opt ui.MultiSelectOption[string]) {
+		if opt.Selected {
+			w.WriteString(ui.Glyph("☑", "[x]"))
+		} else {
+			w.WriteString(ui.Glyph("☐", "[ ]"))
+		}
+		w.WriteString(" ")
+		w.WriteString(opt.Value)
+	}).
+		WithTitle("Select branches").
+		WithDescription("Select branches to submit").
+		WithOptions(opts...)
+	field.KeyMap.Toggle.SetHelp("space", "toggle")
+
+	if err := ui.Run(h.View, field); err != nil {
+		return nil, fmt.Errorf("select branches: %w", err)
+	}
+
+	chosen := field.Value()
+	return slices.DeleteFunc(slices.Clone(branches), func(name string) bool {
+		return !slices.Contains(chosen, name)
+	}), nil
+}
+
+// branchState reports the state of a branch relative to its CR(s).
+//
+// A submitted branch is up-to-date if the remote branch
+// last fetched from the remote matches the local branch.
+func (h *Handler) branchState(ctx context.Context, remote string, b *spice.LookupBranchResponse) branchState {
+	if changes := b.CommitChanges; len(changes) > 0 {
+		// Submitted one CR per commit.
+		if changes[len(changes)-1].Hash == b.Head {
+			return branchStateUpToDate
+		}
+		return branchStateOutdated
+	}
+
+	if b.Change == nil {
+		return branchStateNew
+	}
+	if b.UpstreamBranch == "" {
+		return branchStateOutdated
+	}
+
+	pushRemote := cmp.Or(b.UpstreamRemote, remote)
+	upstreamHash, err := h.Repository.PeelToCommit(ctx, pushRemote+"/"+b.UpstreamBranch)
+	if err != nil || upstreamHash != b.Head {
+		return branchStateOutdated
+	}
+	return branchStateUpToDate
+}
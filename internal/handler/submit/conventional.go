@@ -0,0 +1,139 @@
+package submit
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+)
+
+// _conventionalSubjectRe matches the subject of a Conventional Commit:
+//
+//	type(scope)!: description
+//
+// The scope and the "!" are optional.
+var _conventionalSubjectRe = regexp.MustCompile(`^([A-Za-z][\w-]*)(?:\(([^()]+)\))?(!)?: +(\S.*)$`)
+
+// _breakingFooterRe matches a breaking change footer in a commit body.
+// The note continues until the next blank line.
+var _breakingFooterRe = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: *(.+(?:\n.+)*)`)
+
+// conventionalCommit is a commit message
+// following the Conventional Commits specification.
+//
+// See https://www.conventionalcommits.org/.
+type conventionalCommit struct {
+	Type        string // e.g. "feat"
+	Scope       string // optional
+	Description string
+
+	// Breaking is true if the commit is marked with "!"
+	// or has a BREAKING CHANGE footer.
+	Breaking bool
+
+	// BreakingNote is the text of the BREAKING CHANGE footer, if any.
+	BreakingNote string
+}
+
+// parseConventionalCommit parses a commit message
+// as a Conventional Commit.
+// It returns false if the subject doesn't follow the format.
+func parseConventionalCommit(msg git.CommitMessage) (conventionalCommit, bool) {
+	m := _conventionalSubjectRe.FindStringSubmatch(msg.Subject)
+	if m == nil {
+		return conventionalCommit{}, false
+	}
+
+	cc := conventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       strings.TrimSpace(m[2]),
+		Breaking:    m[3] == "!",
+		Description: m[4],
+	}
+	if fm := _breakingFooterRe.FindStringSubmatch(msg.Body); fm != nil {
+		cc.Breaking = true
+		cc.BreakingNote = strings.TrimSpace(fm[1])
+	}
+	return cc, true
+}
+
+// conventionalChangeDefaults builds the default title and body
+// of a change request from Conventional Commit messages.
+// msgs must be in reverse chronological order,
+// as returned by CommitMessageRange.
+//
+// The title is the subject of the oldest Conventional Commit,
+// marked as breaking if any commit is.
+// The body summarizes the types and scopes of the commits,
+// lists breaking changes, and lists all commit subjects.
+//
+// It returns false if none of the commits are Conventional Commits.
+func conventionalChangeDefaults(msgs []git.CommitMessage) (title, body string, ok bool) {
+	var (
+		types, scopes []string
+		breaking      []string // notes for breaking changes
+		subjects      []string
+		first         *conventionalCommit
+		anyBreaking   bool
+	)
+	for i := len(msgs) - 1; i >= 0; i-- {
+		msg := msgs[i]
+		subjects = append(subjects, msg.Subject)
+
+		cc, ok := parseConventionalCommit(msg)
+		if !ok {
+			continue
+		}
+		if first == nil {
+			first = &cc
+		}
+		if !slices.Contains(types, cc.Type) {
+			types = append(types, cc.Type)
+		}
+		if cc.Scope != "" && !slices.Contains(scopes, cc.Scope) {
+			scopes = append(scopes, cc.Scope)
+		}
+		if cc.Breaking {
+			anyBreaking = true
+			note := cc.BreakingNote
+			if note == "" {
+				note = cc.Description
+			}
+			breaking = append(breaking, note)
+		}
+	}
+	if first == nil {
+		return "", "", false
+	}
+
+	title = first.Type
+	if first.Scope != "" {
+		title += "(" + first.Scope + ")"
+	}
+	if anyBreaking {
+		title += "!"
+	}
+	title += ": " + first.Description
+
+	var sb strings.Builder
+	sb.WriteString("**Type:** " + strings.Join(types, ", ") + "\n")
+	if len(scopes) > 0 {
+		sb.WriteString("**Scope:** " + strings.Join(scopes, ", ") + "\n")
+	}
+
+	if len(breaking) > 0 {
+		sb.WriteString("\n## Breaking changes\n\n")
+		for _, note := range breaking {
+			// Indent continuation lines so they stay in the list item.
+			sb.WriteString("- " + strings.ReplaceAll(note, "\n", "\n  ") + "\n")
+		}
+	}
+
+	sb.WriteString("\n## Commits\n\n")
+	for _, subject := range subjects {
+		sb.WriteString("- " + subject + "\n")
+	}
+
+	return title, strings.TrimSuffix(sb.String(), "\n"), true
+}
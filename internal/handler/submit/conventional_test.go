@@ -0,0 +1,125 @@
+package submit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  git.CommitMessage
+
+		want   conventionalCommit
+		wantOK bool
+	}{
+		{
+			name:   "Plain",
+			msg:    git.CommitMessage{Subject: "Add a feature"},
+			wantOK: false,
+		},
+		{
+			name:   "TypeOnly",
+			msg:    git.CommitMessage{Subject: "fix: handle empty input"},
+			want:   conventionalCommit{Type: "fix", Description: "handle empty input"},
+			wantOK: true,
+		},
+		{
+			name: "Scope",
+			msg:  git.CommitMessage{Subject: "Feat(api): add endpoint"},
+			want: conventionalCommit{
+				Type:        "feat",
+				Scope:       "api",
+				Description: "add endpoint",
+			},
+			wantOK: true,
+		},
+		{
+			name: "BreakingMarker",
+			msg:  git.CommitMessage{Subject: "refactor(cli)!: rename flags"},
+			want: conventionalCommit{
+				Type:        "refactor",
+				Scope:       "cli",
+				Description: "rename flags",
+				Breaking:    true,
+			},
+			wantOK: true,
+		},
+		{
+			name: "BreakingFooter",
+			msg: git.CommitMessage{
+				Subject: "feat: drop old config",
+				Body: text.Dedent(`
+					The old format has been deprecated for a while.
+
+					BREAKING CHANGE: spice.foo is no longer read.
+					Use spice.bar instead.
+
+					Refs: #123
+				`),
+			},
+			want: conventionalCommit{
+				Type:         "feat",
+				Description:  "drop old config",
+				Breaking:     true,
+				BreakingNote: "spice.foo is no longer read.\nUse spice.bar instead.",
+			},
+			wantOK: true,
+		},
+		{
+			name:   "MissingDescription",
+			msg:    git.CommitMessage{Subject: "fix:"},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseConventionalCommit(tt.msg)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConventionalChangeDefaults(t *testing.T) {
+	t.Run("NoConventionalCommits", func(t *testing.T) {
+		_, _, ok := conventionalChangeDefaults([]git.CommitMessage{
+			{Subject: "Add a feature"},
+		})
+		assert.False(t, ok)
+	})
+
+	t.Run("Multiple", func(t *testing.T) {
+		// Newest first.
+		title, body, ok := conventionalChangeDefaults([]git.CommitMessage{
+			{Subject: "docs: explain the new endpoint"},
+			{Subject: "Fix typo"},
+			{
+				Subject: "fix(api): reject empty names",
+				Body:    "BREAKING CHANGE: empty names are now an error.",
+			},
+			{Subject: "feat(api): add endpoint"},
+		})
+		assert.True(t, ok)
+		assert.Equal(t, "feat(api)!: add endpoint", title)
+		assert.Equal(t, text.Dedent(`
+			**Type:** feat, fix, docs
+			**Scope:** api
+
+			## Breaking changes
+
+			- empty names are now an error.
+
+			## Commits
+
+			- feat(api): add endpoint
+			- fix(api): reject empty names
+			- Fix typo
+			- docs: explain the new endpoint
+		`), body)
+	})
+}
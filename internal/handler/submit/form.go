@@ -42,11 +42,16 @@ func newBranchSubmitForm(
 	}
 }
 
-func (f *branchSubmitForm) titleField(title *string, commits []git.CommitMessage) ui.Field {
+func (f *branchSubmitForm) titleField(title *string, commits []git.CommitMessage, diffStat *forge.DiffStat) ui.Field {
+	desc := "Short summary of the change"
+	if diffStat != nil {
+		desc += " (" + diffStat.String() + ")"
+	}
+
 	input := ui.NewInput().
 		WithValue(title).
 		WithTitle("Title").
-		WithDescription("Short summary of the change").
+		WithDescription(desc).
 		WithValidate(func(s string) error {
 			if strings.TrimSpace(s) == "" {
 				return errors.New("title cannot be blank")
@@ -0,0 +1,140 @@
+package submit
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/spice"
+)
+
+// GuardRailAction specifies what happens
+// when a branch violates a submit guard rail.
+type GuardRailAction int
+
+const (
+	// GuardRailWarn logs a warning and submits the branch anyway.
+	// This is the default.
+	GuardRailWarn GuardRailAction = iota
+
+	// GuardRailBlock refuses to submit the branch
+	// unless --force is used.
+	GuardRailBlock
+)
+
+var _ encoding.TextUnmarshaler = (*GuardRailAction)(nil)
+
+// String returns the string representation of the GuardRailAction.
+func (a GuardRailAction) String() string {
+	switch a {
+	case GuardRailWarn:
+		return "warn"
+	case GuardRailBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalText decodes a GuardRailAction from text.
+// It supports "warn" and "block" values.
+func (a *GuardRailAction) UnmarshalText(bs []byte) error {
+	switch strings.ToLower(string(bs)) {
+	case "warn":
+		*a = GuardRailWarn
+	case "block":
+		*a = GuardRailBlock
+	default:
+		return fmt.Errorf("invalid value %q: expected warn or block", bs)
+	}
+	return nil
+}
+
+// checkGuardRails verifies that the branch satisfies
+// the guard rails configured in opts.
+//
+// Violations are logged as warnings,
+// or reported as an error if the guard rails are set to block
+// and the submission is not forced.
+func (h *Handler) checkGuardRails(
+	ctx context.Context,
+	name string,
+	branch *spice.LookupBranchResponse,
+	opts *Options,
+) error {
+	if opts.MaxStackDepth <= 0 && opts.MaxChangedLines <= 0 && !opts.CheckEmptyBranch {
+		return nil // nothing to check
+	}
+
+	var violations []string
+	if opts.CheckEmptyBranch && branch.Head == branch.BaseHash {
+		violations = append(violations, "branch has no commits")
+	}
+
+	if opts.MaxStackDepth > 0 {
+		depth, err := h.stackDepth(ctx, name, branch)
+		if err != nil {
+			return fmt.Errorf("determine stack depth: %w", err)
+		}
+		if depth > opts.MaxStackDepth {
+			violations = append(violations, fmt.Sprintf(
+				"stack depth %d exceeds maximum of %d", depth, opts.MaxStackDepth))
+		}
+	}
+
+	if opts.MaxChangedLines > 0 {
+		stat, err := h.Repository.DiffStat(ctx, branch.BaseRef(), name)
+		if err != nil {
+			return fmt.Errorf("compute size of change: %w", err)
+		}
+		if lines := stat.Additions + stat.Deletions; lines > opts.MaxChangedLines {
+			violations = append(violations, fmt.Sprintf(
+				"%d changed lines exceeds maximum of %d", lines, opts.MaxChangedLines))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if opts.GuardRails != GuardRailBlock || opts.Force {
+		for _, v := range violations {
+			h.Log.Warnf("%v: %v", name, v)
+		}
+		return nil
+	}
+
+	for _, v := range violations {
+		h.Log.Errorf("%v: %v", name, v)
+	}
+	h.Log.Errorf("Fix the branch, or try again with --force to submit anyway.")
+	return errors.New("branch violates submit guard rails")
+}
+
+// stackDepth reports the number of branches between trunk
+// and the given branch, including the branch itself.
+// A branch based directly on trunk has a depth of 1.
+func (h *Handler) stackDepth(
+	ctx context.Context,
+	name string,
+	branch *spice.LookupBranchResponse,
+) (int, error) {
+	trunk := h.Store.Trunk()
+	seen := map[string]struct{}{name: {}}
+	depth := 1
+	for base := branch.Base; base != trunk; depth++ {
+		if _, ok := seen[base]; ok {
+			return 0, fmt.Errorf("cycle detected at %v", base)
+		}
+		seen[base] = struct{}{}
+
+		b, err := h.Service.LookupBranch(ctx, base)
+		if err != nil {
+			return 0, fmt.Errorf("lookup %v: %w", base, err)
+		}
+		base = b.Base
+	}
+	return depth, nil
+}
@@ -0,0 +1,175 @@
+package submit
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGuardRailAction_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    GuardRailAction
+		wantErr string
+	}{
+		{give: "warn", want: GuardRailWarn},
+		{give: "block", want: GuardRailBlock},
+		{give: "BLOCK", want: GuardRailBlock},
+		{give: "fail", wantErr: `invalid value "fail": expected warn or block`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			var got GuardRailAction
+			err := got.UnmarshalText([]byte(tt.give))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, strings.ToLower(tt.give), got.String())
+		})
+	}
+}
+
+func TestHandler_checkGuardRails(t *testing.T) {
+	// Stack: main -> feat1 -> feat2 -> feat3
+	bases := map[string]string{
+		"feat1": "main",
+		"feat2": "feat1",
+		"feat3": "feat2",
+	}
+
+	feat3 := &spice.LookupBranchResponse{
+		Base:     "feat2",
+		BaseHash: "abc",
+		Head:     "def",
+	}
+	empty := &spice.LookupBranchResponse{
+		Base:     "feat2",
+		BaseHash: "abc",
+		Head:     "abc",
+	}
+
+	tests := []struct {
+		name   string
+		branch *spice.LookupBranchResponse
+		opts   Options
+
+		wantErr bool
+		wantLog []string
+	}{
+		{
+			name:   "Disabled",
+			branch: empty,
+		},
+		{
+			name:   "WithinLimits",
+			branch: feat3,
+			opts: Options{
+				GuardRails:       GuardRailBlock,
+				MaxStackDepth:    3,
+				MaxChangedLines:  20,
+				CheckEmptyBranch: true,
+			},
+		},
+		{
+			name:    "StackDepth/Warn",
+			branch:  feat3,
+			opts:    Options{MaxStackDepth: 2},
+			wantLog: []string{"feat3: stack depth 3 exceeds maximum of 2"},
+		},
+		{
+			name:    "StackDepth/Block",
+			branch:  feat3,
+			opts:    Options{GuardRails: GuardRailBlock, MaxStackDepth: 2},
+			wantErr: true,
+			wantLog: []string{"feat3: stack depth 3 exceeds maximum of 2", "--force"},
+		},
+		{
+			name:   "StackDepth/BlockForced",
+			branch: feat3,
+			opts: Options{
+				GuardRails:    GuardRailBlock,
+				MaxStackDepth: 2,
+				Force:         true,
+			},
+			wantLog: []string{"feat3: stack depth 3 exceeds maximum of 2"},
+		},
+		{
+			name:    "ChangedLines",
+			branch:  feat3,
+			opts:    Options{GuardRails: GuardRailBlock, MaxChangedLines: 10},
+			wantErr: true,
+			wantLog: []string{"feat3: 15 changed lines exceeds maximum of 10"},
+		},
+		{
+			name:    "EmptyBranch",
+			branch:  empty,
+			opts:    Options{CheckEmptyBranch: true},
+			wantLog: []string{"feat3: branch has no commits"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockStore := NewMockStore(ctrl)
+			mockStore.EXPECT().Trunk().Return("main").AnyTimes()
+
+			mockService := NewMockService(ctrl)
+			mockService.EXPECT().
+				LookupBranch(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, name string) (*spice.LookupBranchResponse, error) {
+					return &spice.LookupBranchResponse{Base: bases[name]}, nil
+				}).
+				AnyTimes()
+
+			var logBuffer bytes.Buffer
+			h := &Handler{
+				Log:     silog.New(&logBuffer, nil),
+				Store:   mockStore,
+				Service: mockService,
+				Repository: &diffStatRepository{
+					Stat: git.DiffStat{Additions: 10, Deletions: 5},
+				},
+			}
+
+			err := h.checkGuardRails(t.Context(), "feat3", tt.branch, &tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, "guard rails")
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, want := range tt.wantLog {
+				assert.Contains(t, logBuffer.String(), want)
+			}
+		})
+	}
+}
+
+// diffStatRepository is a GitRepository
+// that reports a fixed DiffStat for all branches.
+// Other methods are not implemented.
+type diffStatRepository struct {
+	GitRepository
+
+	Stat git.DiffStat
+}
+
+func (r *diffStatRepository) DiffStat(context.Context, string, string) (git.DiffStat, error) {
+	return r.Stat, nil
+}
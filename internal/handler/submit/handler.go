@@ -8,6 +8,7 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"iter"
 	"os"
 	"slices"
 	"sort"
@@ -19,6 +20,7 @@ import (
 	"go.abhg.dev/gs/internal/browser"
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/stacknav"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/iterutil"
 	"go.abhg.dev/gs/internal/must"
@@ -37,7 +39,13 @@ type GitRepository interface {
 	SetBranchUpstream(ctx context.Context, branch string, upstream string) error
 	Var(ctx context.Context, name string) (string, error)
 	CommitMessageRange(ctx context.Context, start string, stop string) ([]git.CommitMessage, error)
+	DiffStat(ctx context.Context, base, head string) (git.DiffStat, error)
 	RemoteFetchRefspecs(ctx context.Context, remote string) ([]git.Refspec, error)
+	IsAncestor(ctx context.Context, a, b git.Hash) bool
+	BranchDescription(ctx context.Context, branch string) (string, error)
+	ListCommits(ctx context.Context, commits git.CommitRange) iter.Seq2[git.Hash, error]
+	ReadCommit(ctx context.Context, commitish string) (*git.CommitObject, error)
+	ListRemoteRefs(ctx context.Context, remote string, opts *git.ListRemoteRefsOptions) iter.Seq2[git.RemoteRef, error]
 }
 
 var _ GitRepository = (*git.Repository)(nil)
@@ -69,6 +77,7 @@ type Service interface {
 	LookupBranch(ctx context.Context, name string) (*spice.LookupBranchResponse, error)
 	UnusedBranchName(ctx context.Context, remote string, branch string) (string, error)
 	ListChangeTemplates(context.Context, string, forge.Repository) ([]*forge.ChangeTemplate, error)
+	BranchConfig(ctx context.Context, name string) (spice.BranchConfig, error)
 }
 
 var _ Service = (*spice.Service)(nil)
@@ -89,8 +98,15 @@ type Handler struct {
 	// this whole memoize thing is a bit of a hack
 	FindRemote           func(ctx context.Context) (string, error)                          // required
 	OpenRemoteRepository func(ctx context.Context, remote string) (forge.Repository, error) // required
-	remote               memoizedValue[string]
-	remoteRepository     memoizedValue[forge.Repository]
+
+	// FindRemoteRepositoryID identifies the forge repository for a remote
+	// without opening it.
+	// This is used only when pushing branches to a fork.
+	FindRemoteRepositoryID func(ctx context.Context, remote string) (forge.Forge, forge.RepositoryID, error)
+
+	remote           memoizedValue[string]
+	remoteRepository memoizedValue[forge.Repository]
+	currentUser      memoizedValue[string]
 }
 
 // Remote returns the remote name for the current repository,
@@ -114,6 +130,16 @@ func (h *Handler) RemoteRepository(ctx context.Context) (forge.Repository, error
 	})
 }
 
+// formatChangeID formats the given change ID for display
+// following the conventions of the remote repository's forge.
+func (h *Handler) formatChangeID(ctx context.Context, id forge.ChangeID) string {
+	remoteRepo, err := h.RemoteRepository(ctx)
+	if err != nil {
+		return id.String()
+	}
+	return forge.FormatChangeID(remoteRepo.Forge(), id)
+}
+
 type memoizedValue[T any] struct {
 	once  sync.Once
 	value T
@@ -143,12 +169,42 @@ type Options struct {
 	NavCommentSync      NavCommentSync      `name:"nav-comment-sync" config:"submit.navigationCommentSync" enum:"branch,downstack" default:"branch" hidden:"" help:"Which navigation comment to sync. Must be one of: branch, downstack."`
 	NavCommentDownstack NavCommentDownstack `name:"nav-comment-downstack" config:"submit.navigationComment.downstack" enum:"all,open" default:"all" hidden:"" help:"Which downstack CRs to include in navigation comments. Must be one of: all, open."`
 	NavCommentMarker    string              `name:"nav-comment-marker" config:"submit.navigationCommentStyle.marker" hidden:"" help:"Marker to use for the current change in navigation comments. Defaults to '◀'."`
+	NavCommentLayout    NavCommentLayout    `name:"nav-comment-layout" config:"submit.navigationCommentStyle.layout" enum:"list,tree" default:"list" hidden:"" released:"unreleased" help:"How to lay out the stack in navigation comments. Must be one of: list, tree."`
+
+	// NavCommentSigningKey is a secret used to sign navigation comments
+	// so that CI can tell them apart from comments written by hand.
+	NavCommentSigningKey string `name:"nav-comment-signing-key" config:"submit.navigationComment.signingKey" env:"GIT_SPICE_NAV_COMMENT_SIGNING_KEY" hidden:"" released:"unreleased" help:"Secret used to sign navigation comments so that they can be verified in CI."`
 
 	SkipRestackCheck SkipRestackCheck `config:"submit.skipRestackCheck" hidden:"" help:"When to skip the restack check. Must be one of: never, trunk, always." default:"never"`
 
-	Force      bool  `help:"Force push, bypassing safety checks"`
-	NoVerify   bool  `help:"Bypass pre-push hooks when pushing to the remote." released:"v0.15.0"`
-	UpdateOnly *bool `short:"u" negatable:"" help:"Only update existing change requests, do not create new ones"`
+	// GuardRails controls what happens when a branch
+	// violates one of the guard rails below.
+	GuardRails       GuardRailAction `config:"submit.guardRails" enum:"warn,block" default:"warn" hidden:"" released:"unreleased" help:"What to do when a branch violates a guard rail. Must be one of: warn, block."`
+	MaxStackDepth    int             `config:"submit.guardRails.maxStackDepth" hidden:"" released:"unreleased" help:"Maximum number of branches between trunk and a submitted branch. 0 disables the check."`
+	MaxChangedLines  int             `config:"submit.guardRails.maxChangedLines" hidden:"" released:"unreleased" help:"Maximum number of lines changed by a single change request. 0 disables the check."`
+	CheckEmptyBranch bool            `config:"submit.guardRails.emptyBranch" hidden:"" default:"false" released:"unreleased" help:"Whether to check for branches that have no commits."`
+
+	Force      bool   `help:"Force push, bypassing safety checks"`
+	PushRemote string `name:"push-remote" placeholder:"REMOTE" config:"submit.pushRemote" released:"unreleased" help:"Push new branches to this remote instead, e.g. a fork. Change requests are still created in the repository's remote."`
+	NoVerify   bool   `help:"Bypass pre-push hooks when pushing to the remote." released:"v0.15.0"`
+	UpdateOnly *bool  `short:"u" negatable:"" help:"Only update existing change requests, do not create new ones"`
+
+	// NoPush skips pushing branches, assuming they were already pushed
+	// to the remote by other means, e.g. CI.
+	NoPush bool `name:"no-push" config:"submit.noPush" released:"unreleased" help:"Don't push branches. Create or update CRs for branches that were already pushed, e.g. by CI."`
+
+	// UpstreamBranchTemplate maps names of local branches
+	// to the names they're pushed as when they're first submitted.
+	UpstreamBranchTemplate string `config:"submit.upstreamBranchTemplate" hidden:"" released:"unreleased" help:"Template for the names of new branches in the remote, e.g. 'users/{user}/{branch}'."`
+
+	// UserNamespace places new branches in the remote
+	// under the forge username of the current user
+	// to avoid collisions with other users' branches.
+	UserNamespace bool `config:"submit.userNamespace" hidden:"" default:"false" released:"unreleased" help:"Push new branches under the forge username of the current user, e.g. 'alice/feature'."`
+
+	// PerCommit submits each commit of a branch as its own CR
+	// instead of one CR for the whole branch.
+	PerCommit bool `name:"per-commit" config:"submit.perCommit" hidden:"" default:"false" released:"unreleased" help:"Submit each commit of a branch as its own change request."`
 
 	// DraftDefault is used to set the default draft value
 	// when creating new Change Requests.
@@ -170,9 +226,30 @@ type Options struct {
 	Assignees           []string `short:"a" name:"assign" placeholder:"ASSIGNEE" help:"Assign the change request to these users. Pass multiple times or separate with commas." released:"v0.21.0"`
 	ConfiguredAssignees []string `name:"configured-assignees" help:"Default assignees to add to change requests." hidden:"" config:"submit.assignees" released:"v0.21.0"` // merged with Assignees
 
+	// MergeWhenPipelineSucceeds sets new change requests
+	// to merge automatically once their CI pipelines succeed.
+	MergeWhenPipelineSucceeds bool `name:"merge-when-pipeline-succeeds" negatable:"" config:"submit.mergeWhenPipelineSucceeds" released:"unreleased" help:"Merge new change requests automatically when their pipelines succeed. GitLab only."`
+
+	// RemoveSourceBranch overrides whether the branch of a new change request
+	// is deleted after it's merged.
+	// If unset, the forge's default is used.
+	RemoveSourceBranch *bool `name:"remove-source-branch" negatable:"" config:"submit.removeSourceBranch" released:"unreleased" help:"Whether to delete the branch after new change requests are merged. Defaults to spice.forge.gitlab.removeSourceBranch. GitLab only."`
+
 	// ListTemplatesTimeout controls the timeout for listing CR templates.
 	ListTemplatesTimeout time.Duration `hidden:"" config:"submit.listTemplatesTimeout" help:"Timeout for listing CR templates" default:"1s"`
 
+	// IncludeNote appends the branch's note, if any,
+	// to the default body of new change requests.
+	IncludeNote bool `hidden:"" config:"submit.includeNote" help:"Append the branch note to the body of new change requests." default:"false" released:"unreleased"`
+
+	// ConventionalCommits derives the default title and body
+	// of new change requests from Conventional Commit messages.
+	ConventionalCommits bool `hidden:"" config:"submit.conventionalCommits" help:"Build the default title and body of new change requests from Conventional Commit messages." default:"false" released:"unreleased"`
+
+	// BranchDescription uses the branch's Git description, if any,
+	// as the default title and body of new change requests.
+	BranchDescription bool `hidden:"" config:"submit.branchDescription" help:"Use the branch description as the default title and body of new change requests." default:"true" released:"unreleased"`
+
 	// Template specifies the template to use when multiple templates are available.
 	// If set, this template will be automatically selected instead of prompting the user.
 	// The value should match the filename of one of the available templates.
@@ -289,6 +366,50 @@ func (d *NavCommentDownstack) UnmarshalText(bs []byte) error {
 	return nil
 }
 
+// NavCommentLayout specifies how the stack is laid out
+// in navigation comments.
+type NavCommentLayout int
+
+const (
+	// NavCommentLayoutList lists the CRs below and above the current CR
+	// as a Markdown list.
+	//
+	// This is the default.
+	NavCommentLayoutList NavCommentLayout = iota
+
+	// NavCommentLayoutTree draws the entire stack
+	// that the current CR is part of as an ASCII tree,
+	// including CRs that are not directly above or below it.
+	NavCommentLayoutTree
+)
+
+var _ encoding.TextUnmarshaler = (*NavCommentLayout)(nil)
+
+// String returns the string representation of the NavCommentLayout.
+func (l NavCommentLayout) String() string {
+	switch l {
+	case NavCommentLayoutList:
+		return "list"
+	case NavCommentLayoutTree:
+		return "tree"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalText decodes NavCommentLayout from text.
+func (l *NavCommentLayout) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "list":
+		*l = NavCommentLayoutList
+	case "tree":
+		*l = NavCommentLayoutTree
+	default:
+		return fmt.Errorf("invalid value %q: expected list or tree", bs)
+	}
+	return nil
+}
+
 // ReviewersAddWhen specifies when configured reviewers
 // should be added to change requests.
 type ReviewersAddWhen int
@@ -337,6 +458,9 @@ func (r *ReviewersAddWhen) UnmarshalText(bs []byte) error {
 // that are only available to batch submit operations.
 type BatchOptions struct {
 	UpdateOnlyDefault bool `config:"submit.updateOnly" help:"Default value for --update-only in batch submit operations." hidden:"" default:"false"`
+
+	// Choose prompts the user to pick which branches to submit.
+	Choose bool `name:"choose" released:"unreleased" help:"Pick which branches to submit from a list grouped by whether they're new, need updates, or are up-to-date."`
 }
 
 // BatchRequest is a request to submit one or more change requests.
@@ -350,7 +474,6 @@ type BatchRequest struct {
 // creating or updating change requests as needed.
 func (h *Handler) SubmitBatch(ctx context.Context, req *BatchRequest) error {
 	opts := cmp.Or(req.Options, &Options{})
-	mergeConfiguredOptions(opts)
 
 	batchOpts := cmp.Or(req.BatchOptions, &BatchOptions{})
 	if batchOpts.UpdateOnlyDefault && opts.UpdateOnly == nil {
@@ -359,28 +482,69 @@ func (h *Handler) SubmitBatch(ctx context.Context, req *BatchRequest) error {
 		opts.UpdateOnly = &batchOpts.UpdateOnlyDefault
 	}
 
-	var branchesToComment []string
-	for _, branch := range req.Branches {
+	branches := req.Branches
+	if batchOpts.Choose {
+		var err error
+		branches, err = h.chooseBranches(ctx, branches)
+		if err != nil {
+			return err
+		}
+		if len(branches) == 0 {
+			h.Log.Info("No branches selected")
+			return nil
+		}
+	}
+
+	var (
+		branchesToComment []string
+		reassociated      bool
+	)
+	for idx, branch := range branches {
+		if err := ctx.Err(); err != nil {
+			return h.batchInterrupted(branches[:idx], branches[idx:], err)
+		}
+
 		// Shallow copy the options because submitBranch may modify them.
 		opts := *opts
 
 		status, err := h.submitBranch(
 			ctx,
 			branch,
-			&submitOptions{Options: &opts},
+			&submitOptions{
+				Options:      &opts,
+				SkipArchived: true,
+			},
 		)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return h.batchInterrupted(branches[:idx], branches[idx:], ctxErr)
+			}
 			return fmt.Errorf("submit branch %s: %w", branch, err)
 		}
 		if status.Submitted {
 			branchesToComment = append(branchesToComment, branch)
 		}
+		reassociated = reassociated || status.Reassociated
 	}
 
 	if len(branchesToComment) == 0 || opts.DryRun {
 		return nil // nothing to do
 	}
 
+	if reassociated {
+		// Other CRs in the stack refer to the replaced CR.
+		var err error
+		branchesToComment, err = withStacks(ctx, h.Service, branchesToComment)
+		if err != nil {
+			return err
+		}
+	}
+
+	signer, err := h.navCommentSigner(ctx, opts)
+	if err != nil {
+		return err
+	}
+
 	return updateNavigationComments(
 		ctx,
 		h.Store, h.Service, h.Log,
@@ -388,11 +552,28 @@ func (h *Handler) SubmitBatch(ctx context.Context, req *BatchRequest) error {
 		opts.NavCommentSync,
 		opts.NavCommentDownstack,
 		opts.NavCommentMarker,
+		opts.NavCommentLayout,
+		signer,
 		branchesToComment,
 		h.RemoteRepository,
 	)
 }
 
+// batchInterrupted reports a batch submit operation
+// that was interrupted before it could submit all branches.
+//
+// Navigation comments are not updated for an interrupted batch.
+// Submitting the same branches again will resume the operation:
+// branches that were already submitted will be left unchanged.
+func (h *Handler) batchInterrupted(done, pending []string, cause error) error {
+	if len(done) > 0 {
+		h.Log.Infof("Submitted: %v", strings.Join(done, ", "))
+	}
+	h.Log.Errorf("Interrupted before submitting: %v", strings.Join(pending, ", "))
+	h.Log.Error("Run the same command again to submit the remaining branches.")
+	return fmt.Errorf("submit interrupted: %w", cause)
+}
+
 // Request is a request to submit a single branch to a remote repository.
 type Request struct {
 	// Branch is the name of the branch to submit.
@@ -409,7 +590,6 @@ type Request struct {
 // creating or updating a change request as needed.
 func (h *Handler) Submit(ctx context.Context, req *Request) error {
 	opts := cmp.Or(req.Options, &Options{})
-	mergeConfiguredOptions(opts)
 	status, err := h.submitBranch(
 		ctx,
 		req.Branch,
@@ -428,6 +608,20 @@ func (h *Handler) Submit(ctx context.Context, req *Request) error {
 		return nil
 	}
 
+	branchesToComment := []string{req.Branch}
+	if status.Reassociated {
+		// Other CRs in the stack refer to the replaced CR.
+		branchesToComment, err = withStacks(ctx, h.Service, branchesToComment)
+		if err != nil {
+			return err
+		}
+	}
+
+	signer, err := h.navCommentSigner(ctx, opts)
+	if err != nil {
+		return err
+	}
+
 	return updateNavigationComments(
 		ctx,
 		h.Store, h.Service, h.Log,
@@ -435,7 +629,9 @@ func (h *Handler) Submit(ctx context.Context, req *Request) error {
 		opts.NavCommentSync,
 		opts.NavCommentDownstack,
 		opts.NavCommentMarker,
-		[]string{req.Branch},
+		opts.NavCommentLayout,
+		signer,
+		branchesToComment,
 		h.RemoteRepository,
 	)
 }
@@ -447,12 +643,23 @@ type submitStatus struct {
 	// If yes, comments will be added or updated
 	// based on the NavComment option.
 	Submitted bool
+
+	// Reassociated indicates that the branch's CR was replaced
+	// by another CR for the same branch.
+	//
+	// Navigation comments in the rest of its stack
+	// still refer to the old CR, so they must be updated too.
+	Reassociated bool
 }
 
 type submitOptions struct {
 	*Options
 
 	Title, Body string
+
+	// SkipArchived specifies that archived branches
+	// should be skipped instead of submitted.
+	SkipArchived bool
 }
 
 func (h *Handler) submitBranch(
@@ -472,6 +679,23 @@ func (h *Handler) submitBranch(
 		return status, fmt.Errorf("lookup branch: %w", err)
 	}
 
+	if branch.Archived && opts.SkipArchived {
+		log.Infof("%v: archived, skipping", branchToSubmit)
+		return status, nil
+	}
+
+	// Overrides set on the branch or its downstack
+	// take precedence over git-config.
+	branchConfig, err := svc.BranchConfig(ctx, branchToSubmit)
+	if err != nil {
+		return status, fmt.Errorf("load branch configuration: %w", err)
+	}
+	opts.Options, err = applyBranchConfig(log, opts.Options, branchConfig)
+	if err != nil {
+		return status, err
+	}
+	mergeConfiguredOptions(opts.Options)
+
 	// Refuse to submit if the branch is not restacked.
 	if !opts.Force {
 		if err := svc.VerifyRestacked(ctx, branchToSubmit); err != nil {
@@ -495,6 +719,10 @@ func (h *Handler) submitBranch(
 		}
 	}
 
+	if err := h.checkGuardRails(ctx, branchToSubmit, branch, opts.Options); err != nil {
+		return status, err
+	}
+
 	// Various code paths down below should call this
 	// if the branch is being published as a CR (new or existing)
 	// so it should get a nav comment.
@@ -515,6 +743,23 @@ func (h *Handler) submitBranch(
 		return status, fmt.Errorf("get remote: %w", err)
 	}
 
+	// Branches may be pushed to a different remote than the one
+	// change requests are created in, e.g. a fork.
+	// Branches that were already pushed stay with their remote.
+	pushRemote := remote
+	if branch.UpstreamBranch != "" {
+		pushRemote = cmp.Or(branch.UpstreamRemote, remote)
+	} else if opts.PushRemote != "" {
+		pushRemote = opts.PushRemote
+	}
+
+	// upstreamRemote is the value recorded in the state store:
+	// empty unless the branch is pushed to a different remote.
+	var upstreamRemote string
+	if pushRemote != remote {
+		upstreamRemote = pushRemote
+	}
+
 	// TODO:
 	// Encapsulate (localBranch, upstreamBranch) in a struct.
 
@@ -527,7 +772,7 @@ func (h *Handler) submitBranch(
 		// to the same remote, use that.
 		if upstream, err := h.Repository.BranchUpstream(ctx, branchToSubmit); err == nil {
 			// origin/branch -> branch
-			if b, ok := strings.CutPrefix(upstream, remote+"/"); ok {
+			if b, ok := strings.CutPrefix(upstream, pushRemote+"/"); ok {
 				upstreamBranch = b
 				log.Infof("%v: Using upstream name '%v'", branchToSubmit, upstreamBranch)
 				log.Infof("%v: If this is incorrect, cancel this operation and run 'git branch --unset-upstream %v'.", branchToSubmit, branchToSubmit)
@@ -535,15 +780,64 @@ func (h *Handler) submitBranch(
 		}
 	}
 
+	// Branches that were never pushed are pushed
+	// under a name derived from the configured template.
+	newUpstreamBranch := upstreamBranch
+	if newUpstreamBranch == "" {
+		newUpstreamBranch, err = h.upstreamBranchName(ctx, opts.UpstreamBranchTemplate, opts.UserNamespace, branchToSubmit)
+		if err != nil {
+			return status, err
+		}
+	}
+
 	// Similarly, if the branch's base has a different name upstream,
 	// use that name instead.
 	upstreamBase := branch.Base
-	if branch.Base != h.Store.Trunk() {
+	if ub := branch.UpstreamBase; ub != nil {
+		// Based on a branch that only exists in the remote.
+		upstreamBase = ub.Branch
+		if opts.Publish && ub.Remote != remote {
+			log.Errorf("%v: upstream base %v is not in remote '%v'.", branchToSubmit, ub, remote)
+			log.Errorf("Submit %v after %v has been merged, or with --no-publish to only push it.", branchToSubmit, ub)
+			return status, errors.New("upstream base is in a different remote")
+		}
+	} else if branch.Base != h.Store.Trunk() {
 		baseBranch, err := svc.LookupBranch(ctx, branch.Base)
 		if err != nil {
 			return status, fmt.Errorf("lookup base branch: %w", err)
 		}
-		upstreamBase = cmp.Or(baseBranch.UpstreamBranch, branch.Base)
+		// A base submitted one CR per commit
+		// is represented by the CR for its last commit.
+		upstreamBase = cmp.Or(baseBranch.UpstreamBranch, lastCommitBranch(baseBranch.CommitChanges), branch.Base)
+
+		// Change requests cannot be based on branches in a fork.
+		// The branch can be submitted once its base has been merged.
+		if opts.Publish && baseBranch.UpstreamRemote != "" && baseBranch.UpstreamRemote != remote {
+			log.Errorf("%v: base branch %v was pushed to remote '%v'.", branchToSubmit, branch.Base, baseBranch.UpstreamRemote)
+			log.Errorf("Change requests in '%v' cannot be based on branches in other remotes.", remote)
+			log.Errorf("Submit %v after %v has been merged, or with --no-publish to only push it.", branchToSubmit, branch.Base)
+			return status, errors.New("base branch was pushed to a different remote")
+		}
+	}
+
+	if opts.NoPush && !opts.Publish {
+		return status, errors.New("--no-push cannot be used with --no-publish")
+	}
+
+	if opts.PerCommit {
+		if opts.NoPush {
+			return status, errors.New("cannot submit one CR per commit with --no-push")
+		}
+		if pushRemote != remote {
+			return status, errors.New("cannot submit one CR per commit to a different push remote")
+		}
+
+		return status, h.submitCommits(ctx, &commitsRequest{
+			Branch:       branchToSubmit,
+			Info:         branch,
+			Remote:       remote,
+			UpstreamBase: upstreamBase,
+		}, opts)
 	}
 
 	var existingChange *forge.FindChangeItem
@@ -551,101 +845,55 @@ func (h *Handler) submitBranch(
 		// If the branch doesn't have a CR associated with it,
 		// we'll probably need to create one,
 		// but verify that there isn't already one open.
-		// If the branch doesn't have a CR associated with it,
-		// we'll probably need to create one,
-		// but verify that there isn't already one open.
+		// It may have been created outside of git-spice.
 		remoteRepo, err := h.RemoteRepository(ctx)
 		if err != nil {
 			return status, fmt.Errorf("discover CR for %s: %w", branchToSubmit, err)
 		}
 
+		var headRepo forge.RepositoryID
+		if pushRemote != remote {
+			headRepo, err = h.pushRepositoryID(ctx, pushRemote, remoteRepo)
+			if err != nil {
+				return status, fmt.Errorf("discover CR for %s: %w", branchToSubmit, err)
+			}
+		}
+
 		// Search for a CR associated with the branch's upstream branch
 		// or the branch name itself if we don't have an upstream branch.
 		// In case of the latter, we'll need to verify that the HEAD matches.
-		crBranch := cmp.Or(upstreamBranch, branchToSubmit)
-		changes, err := remoteRepo.FindChangesByBranch(ctx, crBranch, forge.FindChangesOptions{
-			State: forge.ChangeOpen,
-			Limit: 3,
-		})
-		if err != nil {
-			return status, fmt.Errorf("list changes: %w", err)
+		findReq := forge.FindOpenChangeByHeadRequest{
+			Head:           newUpstreamBranch,
+			HeadRepository: headRepo,
+			Base:           upstreamBase,
 		}
+		change, err := h.findOpenChange(ctx, branchToSubmit, remoteRepo, findReq)
+
+		switch {
+		case errors.Is(err, forge.ErrNotFound):
+			// No CRs found, one will be created later.
 
-		switch len(changes) {
-		case 0:
-			// No PRs found, one will be created later.
+		case err != nil:
+			return status, fmt.Errorf("find existing CR for %s: %w", branchToSubmit, err)
 
-		case 1:
+		case upstreamBranch == "" && change.HeadHash != commitHash:
 			// If matching by local branch name, verify that the HEAD matches.
 			// If not, pretend we didn't find a matching CR.
-			if upstreamBranch == "" {
-				change := changes[0]
-				if change.HeadHash != commitHash {
-					log.Infof("%v: Ignoring CR %v with the same branch name: remote HEAD (%v) does not match local HEAD (%v)",
-						branchToSubmit, change.ID, change.HeadHash, commitHash)
-					log.Infof("%v: If this is incorrect, cancel this operation, 'git pull' the branch, and retry.", branchToSubmit)
-					break
-				}
-				upstreamBranch = branchToSubmit
-			}
+			log.Infof("%v: Ignoring CR %v with the same branch name: remote HEAD (%v) does not match local HEAD (%v)",
+				branchToSubmit, forge.FormatChangeID(remoteRepo.Forge(), change.ID), change.HeadHash, commitHash)
+			log.Infof("%v: If this is incorrect, cancel this operation, 'git pull' the branch, and retry.", branchToSubmit)
+
+		default:
+			upstreamBranch = findReq.Head
 
 			// A CR was found, but it wasn't associated with the branch.
 			// It was probably created manually.
 			// We'll associate it now.
-			existingChange = changes[0]
-			log.Infof("%v: Found existing CR %v", branchToSubmit, existingChange.ID)
-
-			md, err := remoteRepo.NewChangeMetadata(ctx, existingChange.ID)
-			if err != nil {
-				return status, fmt.Errorf("get change metadata: %w", err)
-			}
-
-			// If we're importing an existing CR,
-			// also check if there's a stack navigation comment to import.
-			listCommentOpts := forge.ListChangeCommentsOptions{
-				BodyMatchesAll: _navCommentRegexes,
-				CanUpdate:      true,
-			}
-
-			for comment, err := range remoteRepo.ListChangeComments(ctx, existingChange.ID, &listCommentOpts) {
-				if err != nil {
-					log.Warn("Could not list comments for CR. Ignoring existing comments.", "cr", existingChange.ID, "error", err)
-					break
-				}
-
-				log.Infof("%v: Found existing navigation comment: %v", branchToSubmit, comment.ID)
-				md.SetNavigationCommentID(comment.ID)
-				break
-			}
-
-			// TODO: this should all happen in Service, probably.
-			changeMeta, err := remoteRepo.Forge().MarshalChangeMetadata(md)
-			if err != nil {
-				return status, fmt.Errorf("marshal change metadata: %w", err)
-			}
-
-			tx := h.Store.BeginBranchTx()
-			msg := fmt.Sprintf("%v: associate existing CR", branchToSubmit)
-			if err := tx.Upsert(ctx, state.UpsertRequest{
-				Name:           branchToSubmit,
-				ChangeForge:    md.ForgeID(),
-				ChangeMetadata: changeMeta,
-				UpstreamBranch: &upstreamBranch,
-			}); err != nil {
-				return status, fmt.Errorf("%s: %w", msg, err)
-			}
-
-			if err := tx.Commit(ctx, msg); err != nil {
-				return status, fmt.Errorf("update state: %w", err)
+			existingChange = change
+			log.Infof("%v: Found existing CR %v", branchToSubmit, forge.FormatChangeID(remoteRepo.Forge(), existingChange.ID))
+			if err := h.associateChange(ctx, branchToSubmit, remoteRepo, change.ID, upstreamBranch, upstreamRemote); err != nil {
+				return status, err
 			}
-
-		default:
-			// GitHub doesn't allow multiple PRs for the same branch
-			// with the same base branch.
-			// If we get here, it means there are multiple PRs open
-			// with different base branches.
-			return status, fmt.Errorf("multiple open change requests for %s", branchToSubmit)
-			// TODO: Ask the user to pick one and associate it with the branch.
 		}
 	} else if branch.Change != nil {
 		remoteRepo, err := h.RemoteRepository(ctx)
@@ -671,10 +919,34 @@ func (h *Handler) submitBranch(
 				state = "closed"
 			}
 
-			log.Infof("%v: Ignoring CR %v as it was %s: %v", branchToSubmit, change.ID, state, change.URL)
+			log.Infof("%v: Ignoring CR %v as it was %s: %v",
+				branchToSubmit, forge.FormatChangeID(remoteRepo.Forge(), change.ID), state, change.URL)
 			// TODO:
 			// We could offer to reopen the CR if it was closed,
 			// but not if it was merged.
+
+			// A closed CR may have been replaced by a new one
+			// for the same branch, e.g. by a teammate.
+			if change.State == forge.ChangeClosed && upstreamBranch != "" {
+				reopened, err := h.findReopenedChange(ctx, &reopenedChangeRequest{
+					Branch:         branchToSubmit,
+					RemoteRepo:     remoteRepo,
+					Closed:         change,
+					UpstreamBranch: upstreamBranch,
+					UpstreamRemote: upstreamRemote,
+					PushRemote:     pushRemote,
+					Remote:         remote,
+					Base:           upstreamBase,
+					DryRun:         opts.DryRun,
+				})
+				if err != nil {
+					return status, err
+				}
+				if reopened != nil {
+					existingChange = reopened
+					status.Reassociated = true
+				}
+			}
 		}
 	}
 
@@ -694,14 +966,19 @@ func (h *Handler) submitBranch(
 
 	// At this point, existingChange is nil only if we need to create a new CR.
 	if existingChange == nil {
+		if upstreamBranch == "" && opts.NoPush {
+			// The branch was pushed by someone else,
+			// presumably under the expected name.
+			upstreamBranch = newUpstreamBranch
+		}
 		if upstreamBranch == "" {
-			unique, err := svc.UnusedBranchName(ctx, remote, branchToSubmit)
+			unique, err := svc.UnusedBranchName(ctx, pushRemote, newUpstreamBranch)
 			if err != nil {
 				return status, fmt.Errorf("find unique branch name: %w", err)
 			}
 
-			if unique != branchToSubmit {
-				log.Infof("%v: Branch name already in use in remote '%v'", branchToSubmit, remote)
+			if unique != newUpstreamBranch {
+				log.Infof("%v: Branch name already in use in remote '%v'", branchToSubmit, pushRemote)
 				log.Infof("%v: Using upstream name '%v' instead", branchToSubmit, unique)
 			}
 			upstreamBranch = unique
@@ -730,9 +1007,9 @@ func (h *Handler) submitBranch(
 		// Otherwise, we will push to origin/feature,
 		// but won't have a local refs/remotes/origin/feature
 		// to track it after a 'git fetch'.
-		if refspecs, err := h.Repository.RemoteFetchRefspecs(ctx, remote); err != nil {
+		if refspecs, err := h.Repository.RemoteFetchRefspecs(ctx, pushRemote); err != nil {
 			log.Warn("Unable to verify remote's fetch refspecs",
-				"remote", remote,
+				"remote", pushRemote,
 				"error", err)
 		} else {
 			wantMatch := "refs/heads/" + upstreamBranch
@@ -745,7 +1022,7 @@ func (h *Handler) submitBranch(
 			}
 
 			if !hasMatch && !opts.Force {
-				log.Errorf("Remote '%v' has refspecs:", remote)
+				log.Errorf("Remote '%v' has refspecs:", pushRemote)
 				for _, refspec := range refspecs {
 					log.Errorf("  - %v", refspec)
 				}
@@ -755,11 +1032,11 @@ func (h *Handler) submitBranch(
 				log.Error("To fix this, you can do one of the following:")
 				log.Errorf("1. Manually add a fetch refspec for just this branch:")
 				log.Errorf("       git config --add remote.%v.fetch +refs/heads/%v:refs/remotes/%v/%v",
-					remote, upstreamBranch, remote, upstreamBranch)
+					pushRemote, upstreamBranch, pushRemote, upstreamBranch)
 				log.Errorf("2. Prefix all your branches with your username (e.g. '%v/%v'),", user, upstreamBranch)
 				log.Errorf("   and add a fetch refspec to fetch all branches under that prefix:")
 				log.Errorf("       git config --add remote.%v.fetch '+refs/heads/%v/*:refs/remotes/%v/%v/*'",
-					remote, user, remote, user)
+					pushRemote, user, pushRemote, user)
 				log.Errorf("   You can configure git-spice to automatically add this prefix for future branches with:")
 				log.Errorf("       git config --global spice.branchCreate.prefix %v/", user)
 				log.Errorf("3. Use the --force flag to push anyway (not recommended).")
@@ -776,6 +1053,14 @@ func (h *Handler) submitBranch(
 				return status, fmt.Errorf("prepare publish: %w", err)
 			}
 
+			var headRepo forge.RepositoryID
+			if pushRemote != remote {
+				headRepo, err = h.pushRepositoryID(ctx, pushRemote, remoteRepo)
+				if err != nil {
+					return status, fmt.Errorf("prepare publish: %w", err)
+				}
+			}
+
 			// TODO: Refactor:
 			// NoPublish and DryRun are checked repeatedly.
 			// Extract the logic that needs them into no-ops
@@ -786,6 +1071,8 @@ func (h *Handler) submitBranch(
 				remote, // TODO: need this?
 				remoteRepo,
 				upstreamBranch, branch.Base, upstreamBase,
+				headRepo,
+				branch.Note,
 				opts,
 			)
 			if err != nil {
@@ -793,29 +1080,46 @@ func (h *Handler) submitBranch(
 			}
 		}
 
-		pushOpts := git.PushOptions{
-			Remote: remote,
-			Refspec: git.Refspec(
-				commitHash.String() + ":refs/heads/" + upstreamBranch,
-			),
-			Force:    opts.Force,
-			NoVerify: opts.NoVerify,
-		}
+		if opts.NoPush {
+			// The CR will be created from whatever is in the remote,
+			// so that's what we record as pushed.
+			commitHash, err = h.pushedHash(ctx, branchToSubmit, pushRemote, upstreamBranch, commitHash)
+			if err != nil {
+				return status, err
+			}
+		} else {
+			pushOpts := git.PushOptions{
+				Remote: pushRemote,
+				Refspec: git.Refspec(
+					commitHash.String() + ":refs/heads/" + upstreamBranch,
+				),
+				Force:    opts.Force,
+				NoVerify: opts.NoVerify,
+			}
+
+			// If we've already pushed this branch before,
+			// we'll need a force push.
+			// Use a --force-with-lease to avoid
+			// overwriting someone else's changes.
+			if !opts.Force {
+				existingHash, _ := h.Repository.PeelToCommit(ctx, pushRemote+"/"+upstreamBranch)
 
-		// If we've already pushed this branch before,
-		// we'll need a force push.
-		// Use a --force-with-lease to avoid
-		// overwriting someone else's changes.
-		if !opts.Force {
-			existingHash, err := h.Repository.PeelToCommit(ctx, remote+"/"+upstreamBranch)
-			if err == nil {
-				pushOpts.ForceWithLease = upstreamBranch + ":" + existingHash.String()
+				var err error
+				pushOpts.ForceWithLease, err = h.pushLease(ctx,
+					branchToSubmit, upstreamBranch,
+					commitHash, existingHash, branch.UpstreamHash)
+				if err != nil {
+					return status, err
+				}
 			}
-		}
 
-		err = h.Worktree.Push(ctx, pushOpts)
-		if err != nil {
-			return status, fmt.Errorf("push branch: %w", err)
+			err = h.Worktree.Push(ctx, pushOpts)
+			if err != nil {
+				if pushOpts.ForceWithLease != "" {
+					log.Error("Push failed. Branch may have been updated by someone else. Try with --force.")
+				}
+				return status, fmt.Errorf("push branch: %w", err)
+			}
 		}
 
 		// At this point, even if any other operation fails,
@@ -824,8 +1128,13 @@ func (h *Handler) submitBranch(
 		upsert := state.UpsertRequest{
 			Name:           branchToSubmit,
 			UpstreamBranch: &upstreamBranch,
+			UpstreamRemote: &upstreamRemote,
+			UpstreamHash:   &commitHash,
 		}
 		defer func() {
+			// Record the push even if the operation was interrupted
+			// so that state matches what's on the remote.
+			ctx := context.WithoutCancel(ctx)
 			msg := "branch submit " + branchToSubmit
 			tx := h.Store.BeginBranchTx()
 			err := errors.Join(
@@ -840,9 +1149,9 @@ func (h *Handler) submitBranch(
 			}
 		}()
 
-		upstream := remote + "/" + upstreamBranch
+		upstream := pushRemote + "/" + upstreamBranch
 		if err := h.Repository.SetBranchUpstream(ctx, branchToSubmit, upstream); err != nil {
-			log.Warn("Could not set upstream", "branch", branchToSubmit, "remote", remote, "error", err)
+			log.Warn("Could not set upstream", "branch", branchToSubmit, "remote", pushRemote, "error", err)
 		}
 
 		if prepared != nil {
@@ -852,8 +1161,10 @@ func (h *Handler) submitBranch(
 			}
 			openURL = changeURL
 
+			// The CR now exists, so finish recording it
+			// even if the operation was interrupted.
 			remoteRepo := prepared.remoteRepo
-			changeMeta, err := remoteRepo.NewChangeMetadata(ctx, changeID)
+			changeMeta, err := remoteRepo.NewChangeMetadata(context.WithoutCancel(ctx), changeID)
 			if err != nil {
 				return status, fmt.Errorf("get change metadata: %w", err)
 			}
@@ -884,19 +1195,16 @@ func (h *Handler) submitBranch(
 			log.Warnf("Ignoring --no-publish: %s was already published: %s", branchToSubmit, existingChange.URL)
 		}
 
-		// Check base and HEAD are up-to-date.
+		// remoteRepo is guaranteed to be available at this point
+		// because the existing CR was found through it.
+		remoteRepo, err := h.RemoteRepository(ctx)
+		if err != nil {
+			return status, fmt.Errorf("open remote repository: %w", err)
+		}
+
 		pull := existingChange
+		pullID := h.formatChangeID(ctx, pull.ID)
 		openURL = pull.URL
-		var updates []string
-		if pull.HeadHash != commitHash {
-			updates = append(updates, "push branch")
-		}
-		if pull.BaseName != upstreamBase {
-			updates = append(updates, "set base to "+upstreamBase)
-		}
-		if opts.Draft != nil && pull.Draft != *opts.Draft {
-			updates = append(updates, "set draft to "+strconv.FormatBool(*opts.Draft))
-		}
 
 		// Determine the effective draft status for reviewer handling.
 		// If user is changing draft status, use the new value;
@@ -905,21 +1213,44 @@ func (h *Handler) submitBranch(
 		if opts.Draft != nil {
 			effectiveDraft = *opts.Draft
 		}
-		reviewers := effectiveReviewers(opts.Options, effectiveDraft)
+
+		extras := dropUnsupported(log, branchToSubmit, remoteRepo.Forge(), remoteRepo.Capabilities(), changeExtras{
+			Draft:     opts.Draft,
+			Labels:    opts.Labels,
+			Reviewers: effectiveReviewers(opts.Options, effectiveDraft),
+			Assignees: opts.Assignees,
+		})
+
+		// Check base and HEAD are up-to-date.
+		var updates []string
+		if pull.HeadHash != commitHash {
+			if opts.NoPush {
+				log.Warnf("%v: CR %v is at %v, not %v: not pushing: --no-push",
+					branchToSubmit, pullID, pull.HeadHash.Short(), commitHash.Short())
+			} else {
+				updates = append(updates, "push branch")
+			}
+		}
+		if pull.BaseName != upstreamBase {
+			updates = append(updates, "set base to "+upstreamBase)
+		}
+		if extras.Draft != nil && pull.Draft != *extras.Draft {
+			updates = append(updates, "set draft to "+strconv.FormatBool(*extras.Draft))
+		}
 
 		// TODO:
 		// We _probably_ don't need to check for existing
 		// reviewers, assignees, etc. because the API contract
 		// is specifically that these are additive.
 
-		if len(opts.Assignees) > 0 {
+		if len(extras.Assignees) > 0 {
 			existingAssigneeSet := make(map[string]struct{}, len(pull.Assignees))
 			for _, assignee := range pull.Assignees {
 				existingAssigneeSet[assignee] = struct{}{}
 			}
 
 			var assigneesToAdd []string
-			for _, assignee := range opts.Assignees {
+			for _, assignee := range extras.Assignees {
 				if _, exists := existingAssigneeSet[assignee]; !exists {
 					assigneesToAdd = append(assigneesToAdd, assignee)
 				}
@@ -931,13 +1262,13 @@ func (h *Handler) submitBranch(
 		}
 
 		// Check for labels that would be added.
-		if len(opts.Labels) > 0 {
+		if len(extras.Labels) > 0 {
 			existingLabelSet := make(map[string]struct{}, len(pull.Labels))
 			for _, label := range pull.Labels {
 				existingLabelSet[label] = struct{}{}
 			}
 			var labelsToAdd []string
-			for _, label := range opts.Labels {
+			for _, label := range extras.Labels {
 				if _, exists := existingLabelSet[label]; !exists {
 					labelsToAdd = append(labelsToAdd, label)
 				}
@@ -949,13 +1280,13 @@ func (h *Handler) submitBranch(
 		}
 
 		// Check for reviewers that would be added.
-		if len(reviewers) > 0 {
+		if len(extras.Reviewers) > 0 {
 			existingReviewerSet := make(map[string]struct{}, len(pull.Reviewers))
 			for _, reviewer := range pull.Reviewers {
 				existingReviewerSet[reviewer] = struct{}{}
 			}
 			var reviewersToAdd []string
-			for _, reviewer := range reviewers {
+			for _, reviewer := range extras.Reviewers {
 				if _, exists := existingReviewerSet[reviewer]; !exists {
 					reviewersToAdd = append(reviewersToAdd, reviewer)
 				}
@@ -967,21 +1298,21 @@ func (h *Handler) submitBranch(
 		}
 
 		if len(updates) == 0 {
-			log.Infof("CR %v is up-to-date: %s", pull.ID, pull.URL)
+			log.Infof("CR %v is up-to-date: %s", pullID, pull.URL)
 			return status, nil
 		}
 
 		if opts.DryRun {
-			log.Infof("WOULD update CR %v:", pull.ID)
+			log.Infof("WOULD update CR %v:", pullID)
 			for _, update := range updates {
 				log.Infof("  - %s", update)
 			}
 			return status, nil
 		}
 
-		if pull.HeadHash != commitHash {
+		if pull.HeadHash != commitHash && !opts.NoPush {
 			pushOpts := git.PushOptions{
-				Remote: remote,
+				Remote: pushRemote,
 				Refspec: git.Refspec(
 					commitHash.String() + ":refs/heads/" + upstreamBranch,
 				),
@@ -990,10 +1321,21 @@ func (h *Handler) submitBranch(
 			}
 			if !opts.Force {
 				// Force push, but only if the ref is exactly
-				// where we think it is.
-				existingHash, err := h.Repository.PeelToCommit(ctx, remote+"/"+upstreamBranch)
-				if err == nil {
-					pushOpts.ForceWithLease = upstreamBranch + ":" + existingHash.String()
+				// where the forge says it is,
+				// and that's where we last pushed it.
+				// Branches submitted before the last push was recorded
+				// fall back to the remote tracking branch.
+				lastPushed := branch.UpstreamHash
+				if lastPushed == "" {
+					lastPushed, _ = h.Repository.PeelToCommit(ctx, pushRemote+"/"+upstreamBranch)
+				}
+
+				var err error
+				pushOpts.ForceWithLease, err = h.pushLease(ctx,
+					branchToSubmit, upstreamBranch,
+					commitHash, pull.HeadHash, lastPushed)
+				if err != nil {
+					return status, err
 				}
 			}
 
@@ -1001,29 +1343,36 @@ func (h *Handler) submitBranch(
 				log.Error("Push failed. Branch may have been updated by someone else. Try with --force.")
 				return status, fmt.Errorf("push branch: %w", err)
 			}
+
+			tx := h.Store.BeginBranchTx()
+			if err := errors.Join(
+				tx.Upsert(ctx, state.UpsertRequest{
+					Name:         branchToSubmit,
+					UpstreamHash: &commitHash,
+				}),
+				tx.Commit(ctx, "branch submit "+branchToSubmit),
+			); err != nil {
+				log.Warn("Could not update branch state",
+					"branch", branchToSubmit,
+					"error", err)
+			}
 		}
 
 		if len(updates) > 0 {
 			editOpts := forge.EditChangeOptions{
 				Base:         upstreamBase,
-				Draft:        opts.Draft,
-				AddLabels:    opts.Labels,
-				AddReviewers: reviewers,
-				AddAssignees: opts.Assignees,
-			}
-
-			// remoteRepo is guaranteed to be available at this point.
-			remoteRepo, err := h.RemoteRepository(ctx)
-			if err != nil {
-				return status, fmt.Errorf("edit CR %v: %w", pull.ID, err)
+				Draft:        extras.Draft,
+				AddLabels:    extras.Labels,
+				AddReviewers: extras.Reviewers,
+				AddAssignees: extras.Assignees,
 			}
 
 			if err := remoteRepo.EditChange(ctx, pull.ID, editOpts); err != nil {
-				return status, fmt.Errorf("edit CR %v: %w", pull.ID, err)
+				return status, fmt.Errorf("edit CR %v: %w", pullID, err)
 			}
 		}
 
-		log.Infof("Updated %v: %s", pull.ID, pull.URL)
+		log.Infof("Updated %v: %s", pullID, pull.URL)
 	}
 
 	return status, nil
@@ -1035,6 +1384,8 @@ func (h *Handler) prepareBranch(
 	remoteName string,
 	remoteRepo forge.Repository,
 	upstreamBranch, baseBranch, upstreamBase string,
+	headRepo forge.RepositoryID,
+	note string,
 	opts *submitOptions,
 ) (*preparedBranch, error) {
 	// Fetch the template while we're prompting the other fields.
@@ -1115,11 +1466,54 @@ func (h *Handler) prepareBranch(
 		}
 	}
 
+	if opts.ConventionalCommits {
+		if title, body, ok := conventionalChangeDefaults(msgs); ok {
+			defaultTitle = title
+			defaultBody.Reset()
+			defaultBody.WriteString(body)
+		}
+	}
+
+	if opts.BranchDescription {
+		desc, err := h.Repository.BranchDescription(ctx, branchToSubmit)
+		if err != nil {
+			h.Log.Warn("Could not read branch description", "error", err)
+		}
+
+		// Like a commit message, the first line is the title
+		// and the rest is the body.
+		if title, body, _ := strings.Cut(desc, "\n"); title != "" {
+			defaultTitle = strings.TrimSpace(title)
+			defaultBody.Reset()
+			defaultBody.WriteString(strings.TrimSpace(body))
+		}
+	}
+
+	if note = strings.TrimSpace(note); opts.IncludeNote && note != "" {
+		if defaultBody.Len() > 0 {
+			defaultBody.WriteString("\n\n")
+		}
+		defaultBody.WriteString(note)
+	}
+
 	var fields []ui.Field
 	form := newBranchSubmitForm(ctx, h.Service, h.Repository, remoteRepo, h.Log, opts.Options)
 	if opts.Title == "" {
+		// Show the size of the change alongside the title
+		// so that it may be gauged before submitting.
+		var diffStat *forge.DiffStat
+		if stat, err := h.Repository.DiffStat(ctx, baseBranch, branchToSubmit); err != nil {
+			h.Log.Debug("Could not compute size of change", "error", err)
+		} else {
+			diffStat = &forge.DiffStat{
+				Additions:    stat.Additions,
+				Deletions:    stat.Deletions,
+				ChangedFiles: stat.ChangedFiles,
+			}
+		}
+
 		opts.Title = defaultTitle
-		fields = append(fields, form.titleField(&opts.Title, msgs))
+		fields = append(fields, form.titleField(&opts.Title, msgs, diffStat))
 	}
 
 	if opts.Body == "" {
@@ -1199,24 +1593,81 @@ func (h *Handler) prepareBranch(
 		draft = *opts.Draft
 	}
 
+	extras := dropUnsupported(h.Log, branchToSubmit, remoteRepo.Forge(), remoteRepo.Capabilities(), changeExtras{
+		Draft:     &draft,
+		Labels:    opts.Labels,
+		Reviewers: effectiveReviewers(opts.Options, draft),
+		Assignees: opts.Assignees,
+		AutoMerge: opts.MergeWhenPipelineSucceeds,
+	})
+
 	if err := h.Store.SavePreparedBranch(ctx, &storePrepared); err != nil {
 		h.Log.Warn("Could not save prepared branch. Will be unable to recover CR metadata if the push fails.", "error", err)
 	}
 
 	return &preparedBranch{
 		PreparedBranch: storePrepared,
-		draft:          draft,
+		draft:          extras.Draft != nil && *extras.Draft,
 		head:           upstreamBranch,
+		headRepo:       headRepo,
 		base:           upstreamBase,
 		remoteRepo:     remoteRepo,
 		store:          h.Store,
 		log:            h.Log,
-		labels:         opts.Labels,
-		reviewers:      effectiveReviewers(opts.Options, draft),
-		assignees:      opts.Assignees,
+		labels:         extras.Labels,
+		reviewers:      extras.Reviewers,
+		assignees:      extras.Assignees,
+		autoMerge:      extras.AutoMerge,
+		removeSource:   opts.RemoveSourceBranch,
 	}, nil
 }
 
+// navCommentSigner returns the signer for navigation comments,
+// or nil if navigation comments should not be signed.
+func (h *Handler) navCommentSigner(ctx context.Context, opts *Options) (*stacknav.Signer, error) {
+	if opts.NavCommentSigningKey == "" || opts.NavComment == NavCommentNever {
+		return nil, nil
+	}
+
+	remote, err := h.Remote(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.FindRemoteRepositoryID == nil {
+		return nil, errors.New("cannot sign navigation comments: repository unknown")
+	}
+	f, repoID, err := h.FindRemoteRepositoryID(ctx, remote)
+	if err != nil {
+		return nil, fmt.Errorf("identify remote %v: %w", remote, err)
+	}
+
+	return stacknav.NewSigner(opts.NavCommentSigningKey, f.ID()+":"+repoID.String()), nil
+}
+
+// pushRepositoryID identifies the forge repository of pushRemote,
+// verifying that it's hosted on the same forge as remoteRepo.
+func (h *Handler) pushRepositoryID(
+	ctx context.Context,
+	pushRemote string,
+	remoteRepo forge.Repository,
+) (forge.RepositoryID, error) {
+	if h.FindRemoteRepositoryID == nil {
+		return nil, fmt.Errorf("cannot publish branches pushed to remote %v", pushRemote)
+	}
+
+	f, repoID, err := h.FindRemoteRepositoryID(ctx, pushRemote)
+	if err != nil {
+		return nil, fmt.Errorf("identify remote %v: %w", pushRemote, err)
+	}
+
+	if got, want := f.ID(), remoteRepo.Forge().ID(); got != want {
+		return nil, fmt.Errorf("remote %v is hosted on %v, not %v", pushRemote, got, want)
+	}
+
+	return repoID, nil
+}
+
 func listChangeTemplates(
 	ctx context.Context,
 	svc Service,
@@ -1250,12 +1701,16 @@ type preparedBranch struct {
 	state.PreparedBranch
 
 	head      string
+	headRepo  forge.RepositoryID // nil if same as remoteRepo
 	base      string
 	draft     bool
 	labels    []string
 	reviewers []string
 	assignees []string
 
+	autoMerge    bool
+	removeSource *bool // nil to use the forge default
+
 	remoteRepo forge.Repository
 	store      Store
 	log        *silog.Logger
@@ -1263,14 +1718,17 @@ type preparedBranch struct {
 
 func (b *preparedBranch) Publish(ctx context.Context) (forge.ChangeID, string, error) {
 	result, err := b.remoteRepo.SubmitChange(ctx, forge.SubmitChangeRequest{
-		Subject:   b.Subject,
-		Body:      b.Body,
-		Head:      b.head,
-		Base:      b.base,
-		Draft:     b.draft,
-		Labels:    b.labels,
-		Reviewers: b.reviewers,
-		Assignees: b.assignees,
+		Subject:            b.Subject,
+		Body:               b.Body,
+		Head:               b.head,
+		HeadRepository:     b.headRepo,
+		Base:               b.base,
+		Draft:              b.draft,
+		Labels:             b.labels,
+		Reviewers:          b.reviewers,
+		Assignees:          b.assignees,
+		AutoMerge:          b.autoMerge,
+		RemoveSourceBranch: b.removeSource,
 	})
 	if err != nil {
 		// If the branch could not be submitted because the base branch
@@ -1288,6 +1746,6 @@ func (b *preparedBranch) Publish(ctx context.Context) (forge.ChangeID, string, e
 		b.log.Warn("Could not clear prepared branch", "error", err)
 	}
 
-	b.log.Infof("Created %v: %s", result.ID, result.URL)
+	b.log.Infof("Created %v: %s", forge.FormatChangeID(b.remoteRepo.Forge(), result.ID), result.URL)
 	return result.ID, result.URL, nil
 }
@@ -1,6 +1,7 @@
 package submit
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/silog/silogtest"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -193,3 +195,21 @@ func TestEffectiveReviewers(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmitBatch_interrupted(t *testing.T) {
+	var logBuffer bytes.Buffer
+	handler := &Handler{Log: silog.New(&logBuffer, nil)}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := handler.SubmitBatch(ctx, &BatchRequest{
+		Branches:     []string{"feature1", "feature2"},
+		BatchOptions: &BatchOptions{},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	output := logBuffer.String()
+	assert.Contains(t, output, "Interrupted before submitting: feature1, feature2")
+	assert.Contains(t, output, "Run the same command again")
+}
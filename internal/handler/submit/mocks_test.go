@@ -42,6 +42,45 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// BranchConfig mocks base method.
+func (m *MockService) BranchConfig(ctx context.Context, name string) (spice.BranchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchConfig", ctx, name)
+	ret0, _ := ret[0].(spice.BranchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchConfig indicates an expected call of BranchConfig.
+func (mr *MockServiceMockRecorder) BranchConfig(ctx, name any) *MockServiceBranchConfigCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchConfig", reflect.TypeOf((*MockService)(nil).BranchConfig), ctx, name)
+	return &MockServiceBranchConfigCall{Call: call}
+}
+
+// MockServiceBranchConfigCall wrap *gomock.Call
+type MockServiceBranchConfigCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceBranchConfigCall) Return(arg0 spice.BranchConfig, arg1 error) *MockServiceBranchConfigCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceBranchConfigCall) Do(f func(context.Context, string) (spice.BranchConfig, error)) *MockServiceBranchConfigCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceBranchConfigCall) DoAndReturn(f func(context.Context, string) (spice.BranchConfig, error)) *MockServiceBranchConfigCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListChangeTemplates mocks base method.
 func (m *MockService) ListChangeTemplates(arg0 context.Context, arg1 string, arg2 forge.Repository) ([]*forge.ChangeTemplate, error) {
 	m.ctrl.T.Helper()
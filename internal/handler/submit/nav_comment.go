@@ -15,6 +15,7 @@ import (
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/forge/stacknav"
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/spice/state"
 )
 
@@ -82,6 +83,9 @@ func (f NavCommentWhen) String() string {
 // Where the arrow indicates the current branch.
 // For cases where this is the first time we're posting the comment,
 // we'll need to also update the store to record the comment ID for later.
+//
+// If signer is non-nil, each comment is signed
+// for the change that it's posted on.
 func updateNavigationComments(
 	ctx context.Context,
 	store Store,
@@ -91,6 +95,8 @@ func updateNavigationComments(
 	navCommentSync NavCommentSync,
 	navCommentDownstack NavCommentDownstack,
 	navCommentMarker string,
+	navCommentLayout NavCommentLayout,
+	signer *stacknav.Signer,
 	submittedBranches []string,
 	getRemoteRepo func(context.Context) (forge.Repository, error),
 ) error {
@@ -107,14 +113,7 @@ func updateNavigationComments(
 		return fmt.Errorf("get remote repository: %w", err)
 	}
 
-	// Create URL formatter for forges that need explicit links.
-	// Forges like GitHub auto-link "#123" to PRs, but Bitbucket doesn't.
-	var urlFormatter func(forge.ChangeID) string
-	if repo, ok := remoteRepo.(forge.WithChangeURL); ok {
-		urlFormatter = func(id forge.ChangeID) string {
-			return fmt.Sprintf("[%s](%s)", id.String(), repo.ChangeURL(id))
-		}
-	}
+	urlFormatter := changeLinkFormatter(remoteRepo)
 
 	// Look up branch graph once, and share between all syncs.
 	trackedBranches, err := svc.LoadBranches(ctx)
@@ -282,7 +281,7 @@ func updateNavigationComments(
 	handlePostComment := func(post *postComment) error {
 		commentID, err := remoteRepo.PostChangeComment(ctx, post.Change, post.Body)
 		if err != nil {
-			return err
+			return &navCommentRequestError{Err: err}
 		}
 
 		meta := post.Meta
@@ -316,7 +315,7 @@ func updateNavigationComments(
 		recreatable := errors.Is(err, forge.ErrNotFound) ||
 			errors.Is(err, forge.ErrCommentCannotUpdate)
 		if !recreatable {
-			return err
+			return &navCommentRequestError{Err: err}
 		}
 
 		log.Info("Recreating navigation comment",
@@ -336,6 +335,16 @@ func updateNavigationComments(
 		return nil
 	}
 
+	// Comments that the forge failed to post or update
+	// are retried one at a time after the concurrent pass
+	// so that a transient failure (e.g. a rate limit)
+	// doesn't leave part of the stack with stale comments.
+	var (
+		failedMu      sync.Mutex // guards failedPosts and failedUpdates
+		failedPosts   []*postComment
+		failedUpdates []*updateComment
+	)
+
 	postc := make(chan *postComment)
 	updatec := make(chan *updateComment)
 	for range min(runtime.GOMAXPROCS(0), len(branchesToSync)) {
@@ -351,10 +360,21 @@ func updateNavigationComments(
 					}
 
 					if err := handlePostComment(post); err != nil {
-						log.Warn("Error posting comment",
+						if !isNavCommentRequestError(err) {
+							log.Warn("Error posting comment",
+								"change", post.Change.String(),
+								"error", err,
+							)
+							continue
+						}
+
+						log.Debug("Error posting comment, will retry",
 							"change", post.Change.String(),
 							"error", err,
 						)
+						failedMu.Lock()
+						failedPosts = append(failedPosts, post)
+						failedMu.Unlock()
 						continue
 					}
 
@@ -365,10 +385,21 @@ func updateNavigationComments(
 					}
 
 					if err := handleUpdateComment(update); err != nil {
-						log.Warn("Error updating comment",
+						if !isNavCommentRequestError(err) {
+							log.Warn("Error updating comment",
+								"change", update.Change.String(),
+								"error", err,
+							)
+							continue
+						}
+
+						log.Debug("Error updating comment, will retry",
 							"change", update.Change.String(),
 							"error", err,
 						)
+						failedMu.Lock()
+						failedUpdates = append(failedUpdates, update)
+						failedMu.Unlock()
 						continue
 					}
 				}
@@ -388,7 +419,29 @@ func updateNavigationComments(
 		}
 
 		info := infos[idx]
-		commentBody := generateStackNavigationComment(nodes, idx, navCommentMarker, remoteRepo.Forge())
+		branchConfig := spice.ResolveBranchConfig(trackedBranches, info.Branch)
+		marker := navCommentMarker
+		if values, ok := branchConfig.Get(_navCommentMarkerKey); ok {
+			marker = lastValue(values)
+		}
+		layout := navCommentLayout
+		if values, ok := branchConfig.Get(_navCommentLayoutKey); ok {
+			if err := layout.UnmarshalText([]byte(lastValue(values))); err != nil {
+				log.Warn("Ignoring invalid navigation comment layout",
+					"branch", info.Branch,
+					"error", err,
+				)
+				layout = navCommentLayout
+			}
+		}
+		commentBody := generateStackNavigationComment(nodes, idx, marker, layout, remoteRepo.Forge())
+		if signer != nil {
+			commentBody = signer.Sign(
+				info.Meta.ChangeID().String(),
+				commentBody,
+				navCommentSignatureStyle(remoteRepo.Forge()),
+			)
+		}
 		if info.Meta.NavigationCommentID() == nil {
 			postc <- &postComment{
 				Branch: info.Branch,
@@ -410,6 +463,35 @@ func updateNavigationComments(
 	close(updatec)
 	wg.Wait()
 
+	if numFailed := len(failedPosts) + len(failedUpdates); numFailed > 0 {
+		log.Infof("Retrying %d navigation comment(s)", numFailed)
+
+		var stale []string
+		for _, post := range failedPosts {
+			if err := handlePostComment(post); err != nil {
+				log.Warn("Error posting comment",
+					"change", post.Change.String(),
+					"error", err,
+				)
+				stale = append(stale, post.Change.String())
+			}
+		}
+		for _, update := range failedUpdates {
+			if err := handleUpdateComment(update); err != nil {
+				log.Warn("Error updating comment",
+					"change", update.Change.String(),
+					"error", err,
+				)
+				stale = append(stale, update.Change.String())
+			}
+		}
+
+		if len(stale) > 0 {
+			log.Warnf("Navigation comments may be out of date: %v", strings.Join(stale, ", "))
+			log.Warn("Run the same command again to update them.")
+		}
+	}
+
 	var msg strings.Builder
 	msg.WriteString("Post stack navigation comments\n\n")
 	for _, name := range upserted {
@@ -423,6 +505,104 @@ func updateNavigationComments(
 	return nil
 }
 
+// navCommentRequestError indicates that the forge failed
+// to post or update a navigation comment.
+//
+// Only these failures are retried.
+// Retrying other failures, e.g. failing to record the ID of a posted comment,
+// could post duplicate comments.
+type navCommentRequestError struct {
+	Err error
+}
+
+func (e *navCommentRequestError) Error() string { return e.Err.Error() }
+
+func (e *navCommentRequestError) Unwrap() error { return e.Err }
+
+func isNavCommentRequestError(err error) bool {
+	var reqErr *navCommentRequestError
+	return errors.As(err, &reqErr)
+}
+
+// withStacks returns the given branches
+// followed by all other tracked branches in the same stacks.
+//
+// This is used to refresh navigation comments across a stack
+// when one of its CRs changes in a way that other comments refer to.
+func withStacks(ctx context.Context, svc Service, branches []string) ([]string, error) {
+	tracked, err := svc.LoadBranches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked branches: %w", err)
+	}
+
+	baseOf := make(map[string]string, len(tracked))
+	aboves := make(map[string][]string)
+	for _, b := range tracked {
+		baseOf[b.Name] = b.Base
+		aboves[b.Base] = append(aboves[b.Base], b.Name)
+	}
+
+	seen := make(map[string]struct{})
+	for _, name := range branches {
+		seen[name] = struct{}{}
+	}
+
+	inStack := make(map[string]struct{})
+	for _, name := range branches {
+		// Walk down to the bottom of the stack,
+		// and then collect everything above it.
+		root := name
+		for {
+			base, ok := baseOf[root]
+			if !ok {
+				break
+			}
+			if _, ok := baseOf[base]; !ok {
+				break // base is trunk or untracked
+			}
+			root = base
+		}
+
+		pending := []string{root}
+		for len(pending) > 0 {
+			b := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			if _, ok := inStack[b]; ok {
+				continue
+			}
+			inStack[b] = struct{}{}
+			pending = append(pending, aboves[b]...)
+		}
+	}
+
+	result := slices.Clone(branches)
+	for _, b := range tracked {
+		if _, ok := seen[b.Name]; ok {
+			continue
+		}
+		if _, ok := inStack[b.Name]; ok {
+			result = append(result, b.Name)
+		}
+	}
+	return result, nil
+}
+
+// changeLinkFormatter returns a function that formats changes
+// as Markdown links for forges that need explicit links.
+// Forges like GitHub auto-link "#123" to PRs, but Bitbucket doesn't.
+//
+// It returns nil if the forge links change references on its own.
+func changeLinkFormatter(remoteRepo forge.Repository) func(forge.ChangeID) string {
+	repo, ok := remoteRepo.(forge.WithChangeURL)
+	if !ok {
+		return nil
+	}
+
+	return func(id forge.ChangeID) string {
+		return fmt.Sprintf("[%s](%s)", id.String(), repo.ChangeURL(id))
+	}
+}
+
 type stackedChange struct {
 	Change forge.ChangeID
 
@@ -445,6 +625,14 @@ func (s *stackedChange) Value() string {
 	return s.Change.String()
 }
 
+// plainStackedChange is a stackedChange rendered without Markdown links,
+// for use inside code blocks.
+type plainStackedChange struct{ *stackedChange }
+
+var _ stacknav.Node = plainStackedChange{}
+
+func (s plainStackedChange) Value() string { return s.Change.String() }
+
 const (
 	_commentHeader = "This change is part of the following stack:"
 	_commentFooter = "<sub>Change managed by [git-spice](https://abhinav.github.io/git-spice/).</sub>"
@@ -464,10 +652,28 @@ var _navCommentRegexes = []*regexp.Regexp{
 	regexp.MustCompile(`(?m)^(\Q` + _commentMarker + `\E|\Q` + _markdownCommentMarker + `\E)$`),
 }
 
+// navCommentSignatureStyle reports how to embed signatures
+// in navigation comments posted to the given forge.
+func navCommentSignatureStyle(f forge.Forge) stacknav.SignatureStyle {
+	// Forges with a non-HTML marker don't support HTML comments.
+	if fc, ok := f.(forge.WithCommentFormat); ok && fc.CommentFormat().Marker != "" {
+		return stacknav.SignatureMarkdown
+	}
+	return stacknav.SignatureHTML
+}
+
+// NavCommentRegexes returns regular expressions
+// that must all match a comment for it to be a navigation comment.
+// Use these with [forge.ListChangeCommentsOptions] to find them.
+func NavCommentRegexes() []*regexp.Regexp {
+	return slices.Clone(_navCommentRegexes)
+}
+
 func generateStackNavigationComment(
 	nodes []*stackedChange,
 	current int,
 	marker string,
+	layout NavCommentLayout,
 	f forge.Forge,
 ) string {
 	footer := _commentFooter
@@ -490,7 +696,22 @@ func generateStackNavigationComment(
 	if marker != "" {
 		opts = &stacknav.PrintOptions{Marker: marker}
 	}
-	stacknav.Print(&sb, nodes, current, opts)
+	switch layout {
+	case NavCommentLayoutTree:
+		// Links don't work inside code blocks,
+		// so always use plain change references.
+		plain := make([]plainStackedChange, len(nodes))
+		for i, n := range nodes {
+			plain[i] = plainStackedChange{n}
+		}
+
+		sb.WriteString("```\n")
+		stacknav.PrintTree(&sb, plain, current, opts)
+		sb.WriteString("```\n")
+
+	default:
+		stacknav.Print(&sb, nodes, current, opts)
+	}
 
 	sb.WriteString("\n")
 	sb.WriteString(footer)
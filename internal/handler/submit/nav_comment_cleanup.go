@@ -0,0 +1,275 @@
+package submit
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// NavCommentCleanup specifies what to do with the navigation comments
+// on change requests after the stack they're part of has fully merged.
+type NavCommentCleanup int
+
+const (
+	// NavCommentCleanupNone leaves navigation comments as is.
+	// This is the default.
+	NavCommentCleanupNone NavCommentCleanup = iota
+
+	// NavCommentCleanupStrike strikes out the stack
+	// in navigation comments.
+	NavCommentCleanupStrike
+
+	// NavCommentCleanupCollapse collapses the stack
+	// in navigation comments into a <details> block.
+	//
+	// Forges that don't support HTML in comments
+	// fall back to NavCommentCleanupStrike.
+	NavCommentCleanupCollapse
+
+	// NavCommentCleanupDelete deletes navigation comments.
+	NavCommentCleanupDelete
+)
+
+var _ encoding.TextUnmarshaler = (*NavCommentCleanup)(nil)
+
+// UnmarshalText decodes a NavCommentCleanup from text.
+// It supports "none", "strike", "collapse", and "delete" values.
+func (c *NavCommentCleanup) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "none":
+		*c = NavCommentCleanupNone
+	case "strike":
+		*c = NavCommentCleanupStrike
+	case "collapse":
+		*c = NavCommentCleanupCollapse
+	case "delete":
+		*c = NavCommentCleanupDelete
+	default:
+		return fmt.Errorf("invalid value %q: expected none, strike, collapse, or delete", bs)
+	}
+	return nil
+}
+
+func (c NavCommentCleanup) String() string {
+	switch c {
+	case NavCommentCleanupNone:
+		return "none"
+	case NavCommentCleanupStrike:
+		return "strike"
+	case NavCommentCleanupCollapse:
+		return "collapse"
+	case NavCommentCleanupDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Header used in place of _commentHeader in navigation comments
+// that have been cleaned up.
+//
+// Because it doesn't match _navCommentRegexes,
+// cleaned up comments are not picked up again
+// by later cleanups or submissions.
+const _mergedCommentHeader = "This change was part of a stack that has since been merged."
+
+// NavCommentCleaner cleans up the navigation comments
+// posted on change requests that have been merged.
+type NavCommentCleaner struct {
+	Log              *silog.Logger    // required
+	RemoteRepository forge.Repository // required
+}
+
+// CleanupNavCommentsRequest is a request to clean up navigation comments.
+type CleanupNavCommentsRequest struct {
+	// Changes whose navigation comments should be cleaned up.
+	Changes []forge.ChangeID // required
+
+	// Mode specifies how to clean up the comments.
+	// Nothing is done for NavCommentCleanupNone.
+	Mode NavCommentCleanup
+}
+
+// CleanupNavCommentsResponse is the result of cleaning up
+// navigation comments.
+type CleanupNavCommentsResponse struct {
+	// Cleaned lists changes that had at least one comment cleaned up,
+	// in the order they were requested.
+	Cleaned []forge.ChangeID
+
+	// Failed lists changes whose comments could not be cleaned up.
+	// Failures are logged but do not fail the operation.
+	Failed []forge.ChangeID
+}
+
+// ParseChangeID parses a change ID for the remote repository's forge
+// from a user-provided string, e.g. "#123" or a URL.
+func (c *NavCommentCleaner) ParseChangeID(s string) (forge.ChangeID, error) {
+	return c.RemoteRepository.Forge().ParseChangeID(s)
+}
+
+// CleanupNavigationComments finds the navigation comments
+// on each of the requested changes and strikes out, collapses,
+// or deletes them.
+//
+// Comments that were already cleaned up are left alone.
+func (c *NavCommentCleaner) CleanupNavigationComments(
+	ctx context.Context,
+	req *CleanupNavCommentsRequest,
+) (*CleanupNavCommentsResponse, error) {
+	var resp CleanupNavCommentsResponse
+	if req.Mode == NavCommentCleanupNone {
+		return &resp, nil
+	}
+
+	remoteForge := c.RemoteRepository.Forge()
+	mode := req.Mode
+	if mode == NavCommentCleanupCollapse {
+		// <details> is HTML, so forges with a non-HTML marker
+		// can't render it.
+		if fc, ok := remoteForge.(forge.WithCommentFormat); ok && fc.CommentFormat().Marker != "" {
+			c.Log.Debug("Forge does not support collapsing comments. Striking out instead.",
+				"forge", remoteForge.ID())
+			mode = NavCommentCleanupStrike
+		}
+	}
+
+	for _, id := range req.Changes {
+		cleaned, err := c.cleanupChange(ctx, id, mode)
+		if err != nil {
+			c.Log.Warn("Could not clean up navigation comments",
+				"change", forge.FormatChangeID(remoteForge, id),
+				"error", err,
+			)
+			resp.Failed = append(resp.Failed, id)
+			continue
+		}
+
+		if cleaned {
+			resp.Cleaned = append(resp.Cleaned, id)
+		}
+	}
+
+	return &resp, nil
+}
+
+// cleanupChange cleans up the navigation comments on a single change.
+// It reports whether any comments were cleaned up.
+func (c *NavCommentCleaner) cleanupChange(
+	ctx context.Context,
+	id forge.ChangeID,
+	mode NavCommentCleanup,
+) (bool, error) {
+	listOpts := forge.ListChangeCommentsOptions{
+		BodyMatchesAll: _navCommentRegexes,
+		CanUpdate:      true,
+	}
+
+	// Collect comments before modifying any of them
+	// so we're not editing comments while paginating through them.
+	var comments []*forge.ListChangeCommentItem
+	for comment, err := range c.RemoteRepository.ListChangeComments(ctx, id, &listOpts) {
+		if err != nil {
+			return false, fmt.Errorf("list comments: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	changeName := forge.FormatChangeID(c.RemoteRepository.Forge(), id)
+	for _, comment := range comments {
+		switch mode {
+		case NavCommentCleanupDelete:
+			if err := c.RemoteRepository.DeleteChangeComment(ctx, comment.ID); err != nil {
+				return false, fmt.Errorf("delete comment %v: %w", comment.ID, err)
+			}
+			c.Log.Infof("%v: deleted navigation comment", changeName)
+
+		default:
+			body := cleanupNavigationComment(comment.Body, mode)
+			if err := c.RemoteRepository.UpdateChangeComment(ctx, comment.ID, body); err != nil {
+				return false, fmt.Errorf("update comment %v: %w", comment.ID, err)
+			}
+			c.Log.Infof("%v: cleaned up navigation comment", changeName)
+		}
+	}
+
+	return len(comments) > 0, nil
+}
+
+// cleanupNavigationComment rewrites the body of a navigation comment
+// for a stack that has been merged.
+//
+// For NavCommentCleanupStrike, it turns:
+//
+//	This change is part of the following stack:
+//
+//	- #123
+//	    - #124 ◀
+//
+//	<sub>Change managed by [git-spice](https://abhinav.github.io/git-spice/).</sub>
+//	<!-- gs:navigation comment -->
+//
+// Into:
+//
+//	This change was part of a stack that has since been merged.
+//
+//	- ~~#123~~
+//	    - ~~#124 ◀~~
+//
+//	<sub>Change managed by [git-spice](https://abhinav.github.io/git-spice/).</sub>
+//	<!-- gs:navigation comment -->
+//
+// For NavCommentCleanupCollapse, the header and the list
+// are placed inside a <details> block instead.
+// The footer and the marker are retained as-is in both cases.
+func cleanupNavigationComment(body string, mode NavCommentCleanup) string {
+	var (
+		list []string // list items
+		rest []string // everything after the list
+	)
+	for line := range strings.Lines(body) {
+		line = strings.TrimRight(line, "\r\n")
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case line == _commentHeader:
+			// Replaced below.
+
+		case len(rest) == 0 && strings.HasPrefix(trimmed, "- "):
+			if mode == NavCommentCleanupStrike {
+				indent := line[:len(line)-len(trimmed)]
+				item := strings.TrimPrefix(trimmed, "- ")
+				line = indent + "- ~~" + item + "~~"
+			}
+			list = append(list, line)
+
+		case line == "" && len(rest) == 0:
+			// Blank lines around the list are re-added below.
+
+		default:
+			rest = append(rest, line)
+		}
+	}
+
+	var sb strings.Builder
+	if mode == NavCommentCleanupCollapse {
+		sb.WriteString("<details>\n")
+		sb.WriteString("<summary>" + _mergedCommentHeader + "</summary>\n\n")
+	} else {
+		sb.WriteString(_mergedCommentHeader + "\n\n")
+	}
+	for _, line := range list {
+		sb.WriteString(line + "\n")
+	}
+	if mode == NavCommentCleanupCollapse {
+		sb.WriteString("\n</details>\n")
+	}
+	sb.WriteString("\n")
+	for _, line := range rest {
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
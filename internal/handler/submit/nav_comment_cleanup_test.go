@@ -0,0 +1,170 @@
+package submit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestNavCommentCleaner_CleanupNavigationComments(t *testing.T) {
+	navComment := joinLines(
+		_commentHeader,
+		"",
+		"- #1",
+		"    - #2 ◀",
+		"",
+		_commentFooter,
+		_commentMarker,
+	)
+
+	tests := []struct {
+		name string
+		mode NavCommentCleanup
+		want []forgetest.FakeComment // comments on #2 afterwards
+	}{
+		{
+			name: "None",
+			mode: NavCommentCleanupNone,
+			want: []forgetest.FakeComment{
+				{ID: 1, Change: 2, Body: navComment},
+				{ID: 2, Change: 2, Body: "LGTM"},
+			},
+		},
+		{
+			name: "Strike",
+			mode: NavCommentCleanupStrike,
+			want: []forgetest.FakeComment{
+				{ID: 1, Change: 2, Body: joinLines(
+					_mergedCommentHeader,
+					"",
+					"- ~~#1~~",
+					"    - ~~#2 ◀~~",
+					"",
+					_commentFooter,
+					_commentMarker,
+				)},
+				{ID: 2, Change: 2, Body: "LGTM"},
+			},
+		},
+		{
+			name: "Collapse",
+			mode: NavCommentCleanupCollapse,
+			want: []forgetest.FakeComment{
+				{ID: 1, Change: 2, Body: joinLines(
+					"<details>",
+					"<summary>"+_mergedCommentHeader+"</summary>",
+					"",
+					"- #1",
+					"    - #2 ◀",
+					"",
+					"</details>",
+					"",
+					_commentFooter,
+					_commentMarker,
+				)},
+				{ID: 2, Change: 2, Body: "LGTM"},
+			},
+		},
+		{
+			name: "Delete",
+			mode: NavCommentCleanupDelete,
+			want: []forgetest.FakeComment{
+				{ID: 2, Change: 2, Body: "LGTM"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := forgetest.NewFakeRepository()
+			id := repo.AddChange(forgetest.FakeChange{Number: 2, Head: "feat2"})
+			_, err := repo.PostChangeComment(t.Context(), id, navComment)
+			require.NoError(t, err)
+			_, err = repo.PostChangeComment(t.Context(), id, "LGTM")
+			require.NoError(t, err)
+
+			cleaner := &NavCommentCleaner{
+				Log:              silogtest.New(t),
+				RemoteRepository: repo,
+			}
+			req := &CleanupNavCommentsRequest{
+				Changes: []forge.ChangeID{id},
+				Mode:    tt.mode,
+			}
+			res, err := cleaner.CleanupNavigationComments(t.Context(), req)
+			require.NoError(t, err)
+			assert.Empty(t, res.Failed)
+			assert.Equal(t, tt.want, repo.Comments(id))
+
+			// Cleaned up comments are not touched again.
+			res, err = cleaner.CleanupNavigationComments(t.Context(), req)
+			require.NoError(t, err)
+			assert.Empty(t, res.Cleaned)
+			assert.Equal(t, tt.want, repo.Comments(id))
+		})
+	}
+}
+
+func TestNavCommentCleaner_CleanupNavigationComments_failure(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	id1 := repo.AddChange(forgetest.FakeChange{Number: 1, Head: "feat1"})
+	id2 := repo.AddChange(forgetest.FakeChange{Number: 2, Head: "feat2"})
+	for _, id := range []forge.ChangeID{id1, id2} {
+		_, err := repo.PostChangeComment(t.Context(), id, joinLines(
+			_commentHeader,
+			"",
+			"- #1",
+			"    - #2",
+			"",
+			_commentFooter,
+			_commentMarker,
+		))
+		require.NoError(t, err)
+	}
+	repo.FailNext("DeleteChangeComment", errors.New("great sadness"))
+
+	res, err := (&NavCommentCleaner{
+		Log:              silogtest.New(t),
+		RemoteRepository: repo,
+	}).CleanupNavigationComments(t.Context(), &CleanupNavCommentsRequest{
+		Changes: []forge.ChangeID{id1, id2},
+		Mode:    NavCommentCleanupDelete,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []forge.ChangeID{id1}, res.Failed)
+	assert.Equal(t, []forge.ChangeID{id2}, res.Cleaned)
+	assert.Len(t, repo.Comments(id1), 1)
+	assert.Empty(t, repo.Comments(id2))
+}
+
+func TestNavCommentCleanup_StringMarshal(t *testing.T) {
+	tests := []struct {
+		give string
+		want NavCommentCleanup
+	}{
+		{"none", NavCommentCleanupNone},
+		{"strike", NavCommentCleanupStrike},
+		{"collapse", NavCommentCleanupCollapse},
+		{"delete", NavCommentCleanupDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			var got NavCommentCleanup
+			require.NoError(t, got.UnmarshalText([]byte(tt.give)))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.give, got.String())
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		var c NavCommentCleanup
+		require.Error(t, c.UnmarshalText([]byte("unknown")))
+		assert.Equal(t, "unknown", NavCommentCleanup(42).String())
+	})
+}
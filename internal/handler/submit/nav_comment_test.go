@@ -450,6 +450,8 @@ func TestUpdateNavigationComments(t *testing.T) {
 				tt.sync,
 				tt.downstack,
 				"",
+				NavCommentLayoutList,
+				nil,
 				tt.submit,
 				func(context.Context) (forge.Repository, error) {
 					return mockRemoteRepo, nil
@@ -562,6 +564,8 @@ func TestUpdateNavigationComments_deletedExternally(t *testing.T) {
 			NavCommentSyncBranch,
 			NavCommentDownstackAll,
 			"",
+			NavCommentLayoutList,
+			nil,
 			[]string{"feat1"},
 			func(context.Context) (forge.Repository, error) {
 				return mockRemoteRepo, nil
@@ -677,6 +681,8 @@ func TestUpdateNavigationComments_deletedExternally(t *testing.T) {
 			NavCommentSyncDownstack,
 			NavCommentDownstackAll,
 			"",
+			NavCommentLayoutList,
+			nil,
 			[]string{"feat3"},
 			func(context.Context) (forge.Repository, error) {
 				return mockRemoteRepo, nil
@@ -784,7 +790,7 @@ func TestGenerateStackNavigationComment(t *testing.T) {
 				tt.want + "\n" +
 				_commentFooter + "\n" +
 				_commentMarker + "\n"
-			got := generateStackNavigationComment(tt.graph, tt.current, "", nil)
+			got := generateStackNavigationComment(tt.graph, tt.current, "", NavCommentLayoutList, nil)
 			assert.Equal(t, want, got)
 
 			// Sanity check: All generated comments must match
@@ -804,7 +810,7 @@ func TestGenerateStackNavigationComment(t *testing.T) {
 		}
 		graph[0].Aboves = []int{1}
 
-		got := generateStackNavigationComment(graph, 1, "<-- you are here", nil)
+		got := generateStackNavigationComment(graph, 1, "<-- you are here", NavCommentLayoutList, nil)
 		want := _commentHeader + "\n\n" +
 			joinLines(
 				"- #123",
@@ -814,6 +820,38 @@ func TestGenerateStackNavigationComment(t *testing.T) {
 			_commentMarker + "\n"
 		assert.Equal(t, want, got)
 	})
+
+	t.Run("TreeLayout", func(t *testing.T) {
+		graph := []*stackedChange{
+			{Change: _changeID("123"), Base: -1},
+			{Change: _changeID("124"), Base: 0},
+			{Change: _changeID("125"), Base: 0},
+		}
+		graph[0].Aboves = []int{1, 2}
+		for _, n := range graph {
+			// Links are never used inside the code block.
+			n.urlFormatter = func(id forge.ChangeID) string {
+				return "[" + id.String() + "](https://example.com)"
+			}
+		}
+
+		got := generateStackNavigationComment(graph, 1, "", NavCommentLayoutTree, nil)
+		want := _commentHeader + "\n\n" +
+			joinLines(
+				"```",
+				"#123",
+				"├── #124 ◀",
+				"└── #125",
+				"```",
+			) + "\n" +
+			_commentFooter + "\n" +
+			_commentMarker + "\n"
+		assert.Equal(t, want, got)
+
+		for _, re := range _navCommentRegexes {
+			assert.True(t, re.MatchString(got), "regexp %q failed", re)
+		}
+	})
 }
 
 func TestNavigationCommentWhen_StringMarshal(t *testing.T) {
@@ -948,3 +986,59 @@ func (s _changeID) String() string {
 func joinLines(lines ...string) string {
 	return strings.Join(lines, "\n") + "\n"
 }
+
+func TestWithStacks(t *testing.T) {
+	// trunk
+	//  ├─ feat1 ── feat2 ─┬─ feat3
+	//  │                  └─ feat4
+	//  └─ other1 ── other2
+	tracked := []spice.LoadBranchItem{
+		{Name: "feat1", Base: "main"},
+		{Name: "feat2", Base: "feat1"},
+		{Name: "feat3", Base: "feat2"},
+		{Name: "feat4", Base: "feat2"},
+		{Name: "other1", Base: "main"},
+		{Name: "other2", Base: "other1"},
+	}
+
+	tests := []struct {
+		name     string
+		branches []string
+		want     []string
+	}{
+		{
+			name:     "Bottom",
+			branches: []string{"feat1"},
+			want:     []string{"feat1", "feat2", "feat3", "feat4"},
+		},
+		{
+			name:     "Middle",
+			branches: []string{"feat3"},
+			want:     []string{"feat3", "feat1", "feat2", "feat4"},
+		},
+		{
+			name:     "MultipleStacks",
+			branches: []string{"other2", "feat4"},
+			want:     []string{"other2", "feat4", "feat1", "feat2", "feat3", "other1"},
+		},
+		{
+			name:     "Untracked",
+			branches: []string{"unknown"},
+			want:     []string{"unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockService := NewMockService(mockCtrl)
+			mockService.EXPECT().
+				LoadBranches(gomock.Any()).
+				Return(tracked, nil)
+
+			got, err := withStacks(t.Context(), mockService, tt.branches)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// commitsRequest is a request to submit each commit of a branch
+// as its own Change Request.
+type commitsRequest struct {
+	// Branch is the name of the branch being submitted.
+	Branch string // required
+
+	// Info is information about the branch.
+	Info *spice.LookupBranchResponse // required
+
+	// Remote is the remote to push the commits to.
+	Remote string // required
+
+	// UpstreamBase is the name of the base branch in the remote
+	// that the bottom-most commit's CR is proposed against.
+	UpstreamBase string // required
+}
+
+// submitCommits submits each commit of a branch as its own CR,
+// for teams that review changes one commit at a time.
+//
+// Each commit is pushed to its own branch in the remote,
+// and its CR is based on the CR for the commit before it,
+// so each CR shows only the changes in that commit.
+// The mapping from commits to CRs is recorded in the branch's state.
+//
+// On subsequent submissions, commits are matched to their CRs
+// by hash, or by subject if they were amended or rebased.
+// Commits that were inserted, removed, or reordered
+// cause the CRs to be renumbered: their bases are updated
+// to match the new order of commits.
+func (h *Handler) submitCommits(ctx context.Context, req *commitsRequest, opts *submitOptions) error {
+	log := h.Log
+	branchName := req.Branch
+
+	if !opts.Publish {
+		return errors.New("cannot submit one CR per commit with --no-publish")
+	}
+
+	baseHash, err := h.Repository.PeelToCommit(ctx, req.Info.BaseRef())
+	if err != nil {
+		return fmt.Errorf("resolve base: %w", err)
+	}
+
+	var commits []*git.CommitObject
+	commitRange := git.CommitRangeFrom(req.Info.Head).ExcludeFrom(baseHash).Reverse()
+	for hash, err := range h.Repository.ListCommits(ctx, commitRange) {
+		if err != nil {
+			return fmt.Errorf("list commits: %w", err)
+		}
+
+		commit, err := h.Repository.ReadCommit(ctx, hash.String())
+		if err != nil {
+			return fmt.Errorf("read commit %v: %w", hash.Short(), err)
+		}
+		commits = append(commits, commit)
+	}
+	if len(commits) == 0 {
+		log.Infof("%v: no commits to submit", branchName)
+		return nil
+	}
+
+	matches, dropped := matchCommitChanges(commits, req.Info.CommitChanges)
+
+	// Commit branches are named after the branch's name in the remote.
+	upstreamName, err := h.upstreamBranchName(ctx, opts.UpstreamBranchTemplate, opts.UserNamespace, branchName)
+	if err != nil {
+		return err
+	}
+
+	// New commits must not reuse the remote branch of a commit
+	// that was dropped from the branch: its CR may still be open.
+	taken := make(map[string]struct{})
+	if slices.Contains(matches, nil) {
+		opts := git.ListRemoteRefsOptions{
+			Heads:    true,
+			Patterns: []string{"refs/heads/" + upstreamName + "-*"},
+		}
+		for ref, err := range h.Repository.ListRemoteRefs(ctx, req.Remote, &opts) {
+			if err != nil {
+				return fmt.Errorf("list remote branches: %w", err)
+			}
+			taken[strings.TrimPrefix(ref.Name, "refs/heads/")] = struct{}{}
+		}
+	}
+	upstreamBranches := commitUpstreamBranches(upstreamName, matches, taken)
+
+	remoteRepo, err := h.RemoteRepository(ctx)
+	if err != nil {
+		return fmt.Errorf("open remote repository: %w", err)
+	}
+	f := remoteRepo.Forge()
+
+	changeIDs := make([]forge.ChangeID, len(commits))
+	for i, match := range matches {
+		if match == nil || len(match.ChangeMetadata) == 0 {
+			continue
+		}
+
+		md, err := f.UnmarshalChangeMetadata(match.ChangeMetadata)
+		if err != nil {
+			log.Warn("Corrupt change metadata for commit; creating a new CR",
+				"commit", commits[i].Hash.Short(), "error", err)
+			matches[i] = nil
+			continue
+		}
+		changeIDs[i] = md.ChangeID()
+	}
+
+	for _, c := range dropped {
+		if md, err := f.UnmarshalChangeMetadata(c.ChangeMetadata); err == nil {
+			log.Warnf("%v: %q is no longer on the branch. Close %v if it's not needed.",
+				branchName, c.Subject, forge.FormatChangeID(f, md.ChangeID()))
+		}
+	}
+
+	if opts.DryRun {
+		base := req.UpstreamBase
+		for i, commit := range commits {
+			position := commitPosition(i, len(commits))
+			switch match := matches[i]; {
+			case changeIDs[i] == nil:
+				log.Infof("%v: %v WOULD create a CR for %q", branchName, position, commit.Subject)
+			case match.Hash != commit.Hash || match.UpstreamBase != base:
+				log.Infof("%v: %v WOULD update CR %v", branchName, position, forge.FormatChangeID(f, changeIDs[i]))
+			}
+			base = upstreamBranches[i]
+		}
+		return nil
+	}
+
+	// Record what was pushed and published
+	// even if a later step fails,
+	// so that state matches what's on the remote.
+	// A commit that was pushed, but doesn't have a CR yet,
+	// is recorded without change metadata.
+	pending := make([]*state.CommitChange, len(commits))
+	for i, match := range matches {
+		if match != nil {
+			c := *match
+			pending[i] = &c
+		}
+	}
+	defer func() {
+		changes := make([]state.CommitChange, 0, len(pending))
+		for _, c := range pending {
+			if c != nil {
+				changes = append(changes, *c)
+			}
+		}
+
+		ctx := context.WithoutCancel(ctx)
+		tx := h.Store.BeginBranchTx()
+		if err := errors.Join(
+			tx.Upsert(ctx, state.UpsertRequest{
+				Name:          branchName,
+				CommitChanges: &changes,
+			}),
+			tx.Commit(ctx, "branch submit "+branchName),
+		); err != nil {
+			log.Warn("Could not update branch state",
+				"branch", branchName,
+				"error", err)
+		}
+	}()
+
+	// Push all commits before touching CRs
+	// so that the new bases exist when CRs are re-linked.
+	for i, commit := range commits {
+		match := matches[i]
+		if match != nil && match.Hash == commit.Hash {
+			continue
+		}
+
+		upstreamBranch := upstreamBranches[i]
+		pushOpts := git.PushOptions{
+			Remote:   req.Remote,
+			Refspec:  git.Refspec(commit.Hash.String() + ":refs/heads/" + upstreamBranch),
+			Force:    opts.Force,
+			NoVerify: opts.NoVerify,
+		}
+		if match != nil && !opts.Force {
+			// Only overwrite the version of the commit we last pushed.
+			pushOpts.ForceWithLease = upstreamBranch + ":" + match.Hash.String()
+		}
+
+		if err := h.Worktree.Push(ctx, pushOpts); err != nil {
+			if pushOpts.ForceWithLease != "" {
+				log.Error("Push failed. Branch may have been updated by someone else. Try with --force.")
+			}
+			return fmt.Errorf("push %v: %w", upstreamBranch, err)
+		}
+
+		if pending[i] == nil {
+			pending[i] = &state.CommitChange{UpstreamBranch: upstreamBranch}
+		}
+		pending[i].Hash = commit.Hash
+		pending[i].Subject = commit.Subject
+	}
+
+	extras := dropUnsupported(log, branchName, f, remoteRepo.Capabilities(), changeExtras{
+		Labels:    opts.Labels,
+		Reviewers: effectiveReviewers(opts.Options, opts.DraftDefault),
+		Assignees: opts.Assignees,
+	})
+	draft := opts.DraftDefault
+	if opts.Draft != nil {
+		draft = *opts.Draft
+	}
+
+	base := req.UpstreamBase
+	for i, commit := range commits {
+		match := matches[i]
+		upstreamBranch := upstreamBranches[i]
+		position := commitPosition(i, len(commits))
+
+		change := pending[i]
+		if id := changeIDs[i]; id != nil {
+			changeName := forge.FormatChangeID(f, id)
+			switch {
+			case match.UpstreamBase != base:
+				if err := remoteRepo.EditChange(ctx, id, forge.EditChangeOptions{
+					Base: base,
+				}); err != nil {
+					return fmt.Errorf("edit CR %v: %w", changeName, err)
+				}
+				log.Infof("%v: %v Updated %v", branchName, position, changeName)
+
+			case match.Hash != commit.Hash:
+				log.Infof("%v: %v Updated %v", branchName, position, changeName)
+
+			default:
+				log.Infof("%v: %v CR %v is up-to-date", branchName, position, changeName)
+			}
+		} else {
+			result, err := remoteRepo.SubmitChange(ctx, forge.SubmitChangeRequest{
+				Subject:   commit.Subject,
+				Body:      commit.Body,
+				Base:      base,
+				Head:      upstreamBranch,
+				Draft:     draft,
+				Labels:    extras.Labels,
+				Reviewers: extras.Reviewers,
+				Assignees: extras.Assignees,
+			})
+			if err != nil {
+				return fmt.Errorf("create CR for %q: %w", commit.Subject, err)
+			}
+			log.Infof("%v: %v Created %v: %s", branchName, position, forge.FormatChangeID(f, result.ID), result.URL)
+
+			md, err := remoteRepo.NewChangeMetadata(context.WithoutCancel(ctx), result.ID)
+			if err != nil {
+				return fmt.Errorf("get change metadata: %w", err)
+			}
+			change.ChangeMetadata, err = f.MarshalChangeMetadata(md)
+			if err != nil {
+				return fmt.Errorf("marshal change metadata: %w", err)
+			}
+			change.ChangeForge = md.ForgeID()
+		}
+
+		change.Subject = commit.Subject
+		change.UpstreamBase = base
+		base = upstreamBranch
+	}
+
+	return nil
+}
+
+// matchCommitChanges pairs commits with the changes
+// previously submitted for them.
+//
+// A commit is matched to a change by hash if it hasn't changed,
+// and by subject otherwise, e.g. after it was amended or rebased.
+// The returned slice has an entry for each commit,
+// which is nil if the commit doesn't have a change yet.
+// Changes that didn't match any commit are returned separately.
+func matchCommitChanges(
+	commits []*git.CommitObject,
+	changes []state.CommitChange,
+) (matches []*state.CommitChange, dropped []state.CommitChange) {
+	matches = make([]*state.CommitChange, len(commits))
+	used := make([]bool, len(changes))
+
+	match := func(same func(*git.CommitObject, *state.CommitChange) bool) {
+		for i, commit := range commits {
+			if matches[i] != nil {
+				continue
+			}
+			for j := range changes {
+				if !used[j] && same(commit, &changes[j]) {
+					matches[i] = &changes[j]
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+	match(func(commit *git.CommitObject, change *state.CommitChange) bool {
+		return commit.Hash == change.Hash
+	})
+	match(func(commit *git.CommitObject, change *state.CommitChange) bool {
+		return commit.Subject == change.Subject
+	})
+
+	for j, change := range changes {
+		if !used[j] {
+			dropped = append(dropped, change)
+		}
+	}
+	return matches, dropped
+}
+
+// commitUpstreamBranches returns the names of the remote branches
+// for each commit of a branch.
+//
+// Commits that were previously submitted keep their remote branch.
+// New commits are pushed to "<branch>-<n>",
+// using the lowest n that isn't already in use
+// by another commit or by a branch in the taken set.
+func commitUpstreamBranches(branch string, matches []*state.CommitChange, taken map[string]struct{}) []string {
+	names := make([]string, len(matches))
+	used := maps.Clone(taken)
+	if used == nil {
+		used = make(map[string]struct{}, len(matches))
+	}
+	for i, match := range matches {
+		if match != nil {
+			names[i] = match.UpstreamBranch
+			used[match.UpstreamBranch] = struct{}{}
+		}
+	}
+
+	next := 1
+	for i := range names {
+		if names[i] != "" {
+			continue
+		}
+
+		for {
+			name := branch + "-" + strconv.Itoa(next)
+			next++
+			if _, ok := used[name]; !ok {
+				names[i] = name
+				used[name] = struct{}{}
+				break
+			}
+		}
+	}
+	return names
+}
+
+// lastCommitBranch returns the remote branch holding the last commit
+// of a branch submitted with one CR per commit,
+// or an empty string if it wasn't submitted that way.
+func lastCommitBranch(changes []state.CommitChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	return changes[len(changes)-1].UpstreamBranch
+}
+
+// commitPosition formats the position of a commit in a branch,
+// e.g. "[2/3]".
+func commitPosition(idx, total int) string {
+	return fmt.Sprintf("[%d/%d]", idx+1, total)
+}
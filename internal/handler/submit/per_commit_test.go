@@ -0,0 +1,75 @@
+package submit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+func TestMatchCommitChanges(t *testing.T) {
+	changes := []state.CommitChange{
+		{Hash: "aaa", Subject: "Add foo", UpstreamBranch: "feat-1"},
+		{Hash: "bbb", Subject: "Add bar", UpstreamBranch: "feat-2"},
+		{Hash: "ccc", Subject: "Add baz", UpstreamBranch: "feat-3"},
+	}
+
+	// bar was amended, baz was dropped,
+	// and a new commit was inserted before foo.
+	commits := []*git.CommitObject{
+		{Hash: "ddd", Subject: "Add qux"},
+		{Hash: "aaa", Subject: "Add foo"},
+		{Hash: "eee", Subject: "Add bar"},
+	}
+
+	matches, dropped := matchCommitChanges(commits, changes)
+	assert.Equal(t, []*state.CommitChange{nil, &changes[0], &changes[1]}, matches)
+	assert.Equal(t, []state.CommitChange{changes[2]}, dropped)
+}
+
+func TestMatchCommitChanges_hashBeforeSubject(t *testing.T) {
+	changes := []state.CommitChange{
+		{Hash: "aaa", Subject: "Fix typo", UpstreamBranch: "feat-1"},
+		{Hash: "bbb", Subject: "Fix typo", UpstreamBranch: "feat-2"},
+	}
+
+	// The first commit was amended,
+	// and must not take the unchanged commit's CR.
+	commits := []*git.CommitObject{
+		{Hash: "ccc", Subject: "Fix typo"},
+		{Hash: "bbb", Subject: "Fix typo"},
+	}
+
+	matches, dropped := matchCommitChanges(commits, changes)
+	assert.Equal(t, []*state.CommitChange{&changes[0], &changes[1]}, matches)
+	assert.Empty(t, dropped)
+}
+
+func TestCommitUpstreamBranches(t *testing.T) {
+	matches := []*state.CommitChange{
+		nil,
+		{UpstreamBranch: "feat-1"},
+		nil,
+		{UpstreamBranch: "feat-3"},
+		nil,
+	}
+
+	t.Run("Free", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"feat-2", "feat-1", "feat-4", "feat-3", "feat-5",
+		}, commitUpstreamBranches("feat", matches, nil))
+	})
+
+	t.Run("Taken", func(t *testing.T) {
+		taken := map[string]struct{}{
+			"feat-1": {},
+			"feat-2": {},
+			"feat-3": {},
+		}
+		assert.Equal(t, []string{
+			"feat-4", "feat-1", "feat-5", "feat-3", "feat-6",
+		}, commitUpstreamBranches("feat", matches, taken))
+		assert.Len(t, taken, 3, "taken must not be modified")
+	})
+}
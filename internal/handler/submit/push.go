@@ -0,0 +1,198 @@
+package submit
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// PushRequest is a request to push branches to their remotes
+// without creating or updating change requests.
+type PushRequest struct {
+	// Branches are the branches to push, in order.
+	Branches []string // required
+
+	// ForceWithLease allows overwriting the remote branch
+	// if it's where git-spice last saw it.
+	//
+	// Without this, only fast-forward pushes are allowed.
+	ForceWithLease bool
+
+	// NoVerify bypasses pre-push hooks.
+	NoVerify bool
+
+	// UpstreamBranchTemplate and UserNamespace determine the names
+	// of branches that were never pushed.
+	// See [Options.UpstreamBranchTemplate] and [Options.UserNamespace].
+	UpstreamBranchTemplate string
+	UserNamespace          bool
+}
+
+// Push pushes the given branches to the remotes they were submitted to.
+//
+// Branches that were never pushed are pushed to the repository's remote
+// under the same name as the local branch,
+// or the name derived from UpstreamBranchTemplate and UserNamespace,
+// if available.
+// No change requests are created or updated.
+func (h *Handler) Push(ctx context.Context, req *PushRequest) error {
+	for _, name := range req.Branches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := h.pushBranch(ctx, name, req); err != nil {
+			return fmt.Errorf("push %v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) pushBranch(ctx context.Context, branchName string, req *PushRequest) error {
+	if branchName == h.Store.Trunk() {
+		return errors.New("cannot push trunk")
+	}
+
+	log := h.Log
+	branch, err := h.Service.LookupBranch(ctx, branchName)
+	if err != nil {
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	commitHash, err := h.Repository.PeelToCommit(ctx, branchName)
+	if err != nil {
+		return fmt.Errorf("peel to commit: %w", err)
+	}
+
+	remote, err := h.Remote(ctx)
+	if err != nil {
+		return fmt.Errorf("get remote: %w", err)
+	}
+
+	pushRemote := cmp.Or(branch.UpstreamRemote, remote)
+	var upstreamRemote string
+	if pushRemote != remote {
+		upstreamRemote = pushRemote
+	}
+
+	upstreamBranch := branch.UpstreamBranch
+	if upstreamBranch == "" {
+		if upstream, err := h.Repository.BranchUpstream(ctx, branchName); err == nil {
+			upstreamBranch, _ = strings.CutPrefix(upstream, pushRemote+"/")
+			if upstreamBranch == upstream {
+				upstreamBranch = "" // tracking a different remote
+			}
+		}
+	}
+	if upstreamBranch == "" {
+		name, err := h.upstreamBranchName(ctx, req.UpstreamBranchTemplate, req.UserNamespace, branchName)
+		if err != nil {
+			return err
+		}
+
+		upstreamBranch, err = h.Service.UnusedBranchName(ctx, pushRemote, name)
+		if err != nil {
+			return fmt.Errorf("find unique branch name: %w", err)
+		}
+		if upstreamBranch != name {
+			log.Infof("%v: Branch name already in use in remote '%v'", branchName, pushRemote)
+			log.Infof("%v: Using upstream name '%v' instead", branchName, upstreamBranch)
+		}
+	}
+
+	remoteHash, _ := h.Repository.PeelToCommit(ctx, pushRemote+"/"+upstreamBranch)
+	if remoteHash == commitHash {
+		log.Infof("%v: %v/%v is up-to-date", branchName, pushRemote, upstreamBranch)
+		return nil
+	}
+
+	pushOpts := git.PushOptions{
+		Remote: pushRemote,
+		Refspec: git.Refspec(
+			commitHash.String() + ":refs/heads/" + upstreamBranch,
+		),
+		NoVerify: req.NoVerify,
+	}
+	if req.ForceWithLease {
+		pushOpts.ForceWithLease, err = h.pushLease(ctx,
+			branchName, upstreamBranch,
+			commitHash, remoteHash, branch.UpstreamHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := h.Worktree.Push(ctx, pushOpts); err != nil {
+		if req.ForceWithLease {
+			log.Error("Push failed. Branch may have been updated by someone else.")
+		} else {
+			log.Error("Push failed. Use --force-with-lease to overwrite the remote branch.")
+		}
+		return fmt.Errorf("push branch: %w", err)
+	}
+
+	tx := h.Store.BeginBranchTx()
+	if err := errors.Join(
+		tx.Upsert(ctx, state.UpsertRequest{
+			Name:           branchName,
+			UpstreamBranch: &upstreamBranch,
+			UpstreamRemote: &upstreamRemote,
+			UpstreamHash:   &commitHash,
+		}),
+		tx.Commit(ctx, "branch push "+branchName),
+	); err != nil {
+		log.Warn("Could not update branch state",
+			"branch", branchName,
+			"error", err)
+	}
+
+	upstream := pushRemote + "/" + upstreamBranch
+	if err := h.Repository.SetBranchUpstream(ctx, branchName, upstream); err != nil {
+		log.Warn("Could not set upstream", "branch", branchName, "remote", pushRemote, "error", err)
+	}
+
+	log.Infof("%v: pushed to %v", branchName, upstream)
+	return nil
+}
+
+// pushedHash reports the commit at upstreamBranch in the given remote
+// for a branch that is submitted with --no-push.
+//
+// It fails if the branch wasn't pushed,
+// and warns if it was pushed at a different commit than localHash.
+func (h *Handler) pushedHash(
+	ctx context.Context,
+	branchName, remote, upstreamBranch string,
+	localHash git.Hash,
+) (git.Hash, error) {
+	ref := "refs/heads/" + upstreamBranch
+	var remoteHash git.Hash
+	for r, err := range h.Repository.ListRemoteRefs(ctx, remote, &git.ListRemoteRefsOptions{
+		Heads:    true,
+		Patterns: []string{ref},
+	}) {
+		if err != nil {
+			return "", fmt.Errorf("list remote branches: %w", err)
+		}
+		if r.Name == ref {
+			remoteHash = r.Hash
+		}
+	}
+
+	if remoteHash == "" {
+		h.Log.Errorf("%v: branch '%v' was not found in remote '%v'.", branchName, upstreamBranch, remote)
+		h.Log.Error("Push it first, or submit without --no-push.")
+		return "", errors.New("branch was not pushed")
+	}
+
+	if remoteHash != localHash {
+		h.Log.Warnf("%v: %v/%v is at %v, not %v", branchName, remote, upstreamBranch, remoteHash.Short(), localHash.Short())
+		h.Log.Warnf("%v: The CR will use the pushed commit", branchName)
+	}
+	return remoteHash, nil
+}
@@ -0,0 +1,76 @@
+package submit
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// pushLease returns the value of --force-with-lease to use
+// when pushing commitHash to a branch that may already exist on the remote.
+// It returns an empty string if the branch was never pushed.
+//
+// remoteHash is the last known head of the remote branch, if any.
+// lastPushed is the last commit git-spice pushed to it, if known.
+//
+// If the remote branch was updated since git-spice last pushed it,
+// and pushing would discard those commits,
+// the user is asked whether to overwrite them.
+// An error is returned if they decline or can't be asked.
+func (h *Handler) pushLease(
+	ctx context.Context,
+	branchName, upstreamBranch string,
+	commitHash, remoteHash, lastPushed git.Hash,
+) (string, error) {
+	expect := cmp.Or(remoteHash, lastPushed)
+	if expect == "" {
+		return "", nil
+	}
+
+	diverged := lastPushed != "" && remoteHash != "" &&
+		remoteHash != lastPushed && remoteHash != commitHash &&
+		// Nothing is lost if the new commits
+		// were already pulled into the branch.
+		!h.Repository.IsAncestor(ctx, remoteHash, commitHash)
+	if diverged {
+		if err := h.confirmOverwrite(branchName, upstreamBranch, remoteHash, lastPushed); err != nil {
+			return "", err
+		}
+	}
+
+	return upstreamBranch + ":" + expect.String(), nil
+}
+
+// confirmOverwrite asks the user whether to overwrite
+// commits that someone else pushed to the upstream branch.
+func (h *Handler) confirmOverwrite(
+	branchName, upstreamBranch string,
+	remoteHash, lastPushed git.Hash,
+) error {
+	log := h.Log
+	log.Warnf("%v: %v was updated by someone else since it was last submitted", branchName, upstreamBranch)
+	log.Warnf("  last submitted: %v", lastPushed.Short())
+	log.Warnf("  remote:         %v", remoteHash.Short())
+
+	if !ui.Interactive(h.View) {
+		log.Error("Pull their changes into the branch, or use --force to overwrite them.")
+		return errors.New("remote branch has diverged")
+	}
+
+	var overwrite bool
+	field := ui.NewConfirm().
+		WithTitle("Overwrite remote changes?").
+		WithDescription(fmt.Sprintf("Pushing %v will discard commits on %v that aren't in your branch.", branchName, upstreamBranch)).
+		WithValue(&overwrite)
+	if err := ui.Run(h.View, field); err != nil {
+		return fmt.Errorf("run prompt: %w", err)
+	}
+	if !overwrite {
+		return errors.New("operation aborted")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+type reopenedChangeRequest struct {
+	Branch     string           // required
+	RemoteRepo forge.Repository // required
+
+	// Closed is the CR currently associated with the branch.
+	// It must be closed but not merged.
+	Closed *forge.FindChangeItem // required
+
+	// UpstreamBranch is the branch that Closed was submitted from.
+	UpstreamBranch string // required
+
+	// UpstreamRemote is the remote that UpstreamBranch was pushed to
+	// if it isn't the default remote.
+	UpstreamRemote string
+
+	// PushRemote and Remote are the remote the branch is pushed to,
+	// and the remote CRs are opened against.
+	PushRemote, Remote string // required
+
+	// Base is the expected base branch of the CR.
+	Base string
+
+	DryRun bool
+}
+
+// findReopenedChange looks for an open CR that replaced a closed CR
+// for the same upstream branch.
+// This happens if the CR was closed and another one was opened
+// for the branch, for example, by a teammate.
+//
+// If one is found and the user agrees (or can't be asked),
+// it's associated with the branch and returned.
+// Returns nil if there is no such CR, or if the user declined.
+func (h *Handler) findReopenedChange(ctx context.Context, req *reopenedChangeRequest) (*forge.FindChangeItem, error) {
+	log := h.Log
+	remoteRepo := req.RemoteRepo
+
+	var headRepo forge.RepositoryID
+	if req.PushRemote != req.Remote {
+		var err error
+		headRepo, err = h.pushRepositoryID(ctx, req.PushRemote, remoteRepo)
+		if err != nil {
+			return nil, fmt.Errorf("discover CR for %s: %w", req.Branch, err)
+		}
+	}
+
+	findReq := forge.FindOpenChangeByHeadRequest{
+		Head:           req.UpstreamBranch,
+		HeadRepository: headRepo,
+		Base:           req.Base,
+	}
+	change, err := h.findOpenChange(ctx, req.Branch, remoteRepo, findReq)
+	switch {
+	case errors.Is(err, forge.ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("find open CR for %s: %w", req.Branch, err)
+	case forge.FormatChangeID(remoteRepo.Forge(), change.ID) ==
+		forge.FormatChangeID(remoteRepo.Forge(), req.Closed.ID):
+		return nil, nil
+	}
+
+	oldID := forge.FormatChangeID(remoteRepo.Forge(), req.Closed.ID)
+	newID := forge.FormatChangeID(remoteRepo.Forge(), change.ID)
+	log.Infof("%v: Found open CR %v for the same branch: %v", req.Branch, newID, change.URL)
+	if req.DryRun {
+		log.Infof("WOULD replace %v with %v for %v", oldID, newID, req.Branch)
+		return nil, nil
+	}
+
+	if ui.Interactive(h.View) {
+		use := true
+		field := ui.NewConfirm().
+			WithTitle(fmt.Sprintf("Use %v for %v?", newID, req.Branch)).
+			WithDescription(fmt.Sprintf("%v was closed. Associate the branch with %v instead.", oldID, newID)).
+			WithValue(&use)
+		if err := ui.Run(h.View, field); err != nil {
+			return nil, fmt.Errorf("run prompt: %w", err)
+		}
+		if !use {
+			return nil, nil
+		}
+	}
+
+	if err := h.associateChange(ctx, req.Branch, remoteRepo, change.ID, req.UpstreamBranch, req.UpstreamRemote); err != nil {
+		return nil, err
+	}
+	log.Infof("%v: replacing %v with %v", req.Branch, oldID, newID)
+	return change, nil
+}
+
+// findOpenChange looks for an open CR for a branch.
+//
+// A CR against req.Base is preferred.
+// Failing that, a CR against another base is accepted
+// if it's the only one: it'll be retargeted when it's updated.
+func (h *Handler) findOpenChange(
+	ctx context.Context,
+	branch string,
+	remoteRepo forge.Repository,
+	req forge.FindOpenChangeByHeadRequest,
+) (*forge.FindChangeItem, error) {
+	change, err := remoteRepo.FindOpenChangeByHead(ctx, req)
+	if req.Base == "" || !errors.Is(err, forge.ErrNotFound) {
+		return change, err
+	}
+
+	f := remoteRepo.Forge()
+	wantBase := req.Base
+	req.Base = ""
+	req.Unique = true
+	change, err = remoteRepo.FindOpenChangeByHead(ctx, req)
+	if err != nil {
+		var ambiguousErr *forge.AmbiguousChangeError
+		if errors.As(err, &ambiguousErr) {
+			h.Log.Errorf("%v: found multiple open CRs for %v, but none against %v:", branch, req.Head, wantBase)
+			for _, c := range ambiguousErr.Changes {
+				h.Log.Errorf("  - %v (base: %v): %v", forge.FormatChangeID(f, c.ID), c.BaseName, c.URL)
+			}
+			h.Log.Errorf("%v: close the CRs that don't apply and try again", branch)
+		}
+		return nil, err
+	}
+
+	h.Log.Infof("%v: Found CR %v against %v instead of %v: %v",
+		branch, forge.FormatChangeID(f, change.ID), change.BaseName, wantBase, change.URL)
+	return change, nil
+}
+
+// associateChange associates an existing CR with a branch,
+// importing its stack navigation comment if it has one.
+func (h *Handler) associateChange(
+	ctx context.Context,
+	branchName string,
+	remoteRepo forge.Repository,
+	changeID forge.ChangeID,
+	upstreamBranch, upstreamRemote string,
+) error {
+	log := h.Log
+	md, err := remoteRepo.NewChangeMetadata(ctx, changeID)
+	if err != nil {
+		return fmt.Errorf("get change metadata: %w", err)
+	}
+
+	// If we're importing an existing CR,
+	// also check if there's a stack navigation comment to import.
+	listCommentOpts := forge.ListChangeCommentsOptions{
+		BodyMatchesAll: _navCommentRegexes,
+		CanUpdate:      true,
+	}
+
+	for comment, err := range remoteRepo.ListChangeComments(ctx, changeID, &listCommentOpts) {
+		if err != nil {
+			log.Warn("Could not list comments for CR. Ignoring existing comments.", "cr", changeID, "error", err)
+			break
+		}
+
+		log.Infof("%v: Found existing navigation comment: %v", branchName, comment.ID)
+		md.SetNavigationCommentID(comment.ID)
+		break
+	}
+
+	// TODO: this should all happen in Service, probably.
+	changeMeta, err := remoteRepo.Forge().MarshalChangeMetadata(md)
+	if err != nil {
+		return fmt.Errorf("marshal change metadata: %w", err)
+	}
+
+	tx := h.Store.BeginBranchTx()
+	msg := fmt.Sprintf("%v: associate existing CR", branchName)
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name:           branchName,
+		ChangeForge:    md.ForgeID(),
+		ChangeMetadata: changeMeta,
+		UpstreamBranch: &upstreamBranch,
+		UpstreamRemote: &upstreamRemote,
+	}); err != nil {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	if err := tx.Commit(ctx, msg); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+	return nil
+}
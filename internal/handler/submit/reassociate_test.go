@@ -0,0 +1,63 @@
+package submit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestHandler_findOpenChange(t *testing.T) {
+	findReq := forge.FindOpenChangeByHeadRequest{
+		Head: "feature",
+		Base: "feat1",
+	}
+
+	t.Run("PreferBase", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		repo.AddChange(forgetest.FakeChange{Number: 1, Head: "feature", Base: "main"})
+		repo.AddChange(forgetest.FakeChange{Number: 2, Head: "feature", Base: "feat1"})
+		repo.AddChange(forgetest.FakeChange{Number: 3, Head: "feature", Base: "feat2"})
+
+		h := &Handler{Log: silogtest.New(t)}
+		change, err := h.findOpenChange(t.Context(), "feature", repo, findReq)
+		require.NoError(t, err)
+		assert.Equal(t, "#2", forge.FormatChangeID(repo.Forge(), change.ID))
+	})
+
+	t.Run("OtherBase", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		repo.AddChange(forgetest.FakeChange{Number: 1, Head: "feature", Base: "main"})
+		repo.AddChange(forgetest.FakeChange{Number: 2, Head: "other", Base: "feat1"})
+
+		h := &Handler{Log: silogtest.New(t)}
+		change, err := h.findOpenChange(t.Context(), "feature", repo, findReq)
+		require.NoError(t, err)
+		assert.Equal(t, "#1", forge.FormatChangeID(repo.Forge(), change.ID))
+		assert.Equal(t, "main", change.BaseName)
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		repo.AddChange(forgetest.FakeChange{Number: 1, Head: "feature", Base: "main"})
+		repo.AddChange(forgetest.FakeChange{Number: 2, Head: "feature", Base: "feat2"})
+
+		h := &Handler{Log: silogtest.New(t)}
+		_, err := h.findOpenChange(t.Context(), "feature", repo, findReq)
+		var ambiguousErr *forge.AmbiguousChangeError
+		require.ErrorAs(t, err, &ambiguousErr)
+		assert.Len(t, ambiguousErr.Changes, 2)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := forgetest.NewFakeRepository()
+		repo.AddChange(forgetest.FakeChange{Number: 1, Head: "other", Base: "feat1"})
+
+		h := &Handler{Log: silogtest.New(t)}
+		_, err := h.findOpenChange(t.Context(), "feature", repo, findReq)
+		assert.ErrorIs(t, err, forge.ErrNotFound)
+	})
+}
@@ -0,0 +1,269 @@
+package submit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/stacknav"
+	"go.abhg.dev/gs/internal/must"
+)
+
+// DescribeStackRequest is a request to post a description
+// of a stack of change requests.
+type DescribeStackRequest struct {
+	// Branches in the stack, excluding trunk.
+	//
+	// Branches that haven't been submitted are ignored.
+	Branches []string // required
+}
+
+const (
+	_describeHeader = "This stack consists of the following changes, from the bottom up:"
+	_describeMarker = "<!-- gs:stack description -->"
+)
+
+// Alternate marker for forges that don't support HTML comments.
+const _markdownDescribeMarker = "[gs]: # (stack description)"
+
+// Regular expressions that must ALL match a comment
+// for it to be considered a stack description comment.
+var _describeCommentRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^\Q` + _describeHeader + `\E$`),
+	regexp.MustCompile(`(?m)^(\Q` + _describeMarker + `\E|\Q` + _markdownDescribeMarker + `\E)$`),
+}
+
+// DescribeStack posts a comment describing the whole stack
+// on the topmost change requests of the stack,
+// or updates the comment if it was posted before.
+//
+// The comment lists all change requests in the stack
+// (including merged downstack change requests)
+// with their titles and states, in dependency order.
+func (h *Handler) DescribeStack(ctx context.Context, req *DescribeStackRequest) error {
+	must.NotBeEmptyf(req.Branches, "branches must not be empty")
+
+	remoteRepo, err := h.RemoteRepository(ctx)
+	if err != nil {
+		return fmt.Errorf("get remote repository: %w", err)
+	}
+	remoteForge := remoteRepo.Forge()
+
+	trackedBranches, err := h.Service.LoadBranches(ctx)
+	if err != nil {
+		return fmt.Errorf("list tracked branches: %w", err)
+	}
+
+	type describedChange struct {
+		Change forge.ChangeID
+		Base   int // -1 = no base CR
+		Aboves []int
+		Merged bool // true for merged downstack CRs
+
+		// Set after the change is looked up.
+		Item *forge.FindChangeItem
+	}
+
+	var (
+		changes      []*describedChange
+		idxByBranch  = make(map[string]int) // branch -> index in changes
+		baseByBranch = make(map[string]string)
+		downstacks   = make(map[string][]json.RawMessage)
+	)
+	for _, b := range trackedBranches {
+		if b.Change == nil || !slices.Contains(req.Branches, b.Name) {
+			continue
+		}
+
+		idxByBranch[b.Name] = len(changes)
+		baseByBranch[b.Name] = b.Base
+		downstacks[b.Name] = b.MergedDownstack
+		changes = append(changes, &describedChange{
+			Change: b.Change.ChangeID(),
+			Base:   -1,
+		})
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("no submitted branches in the stack")
+	}
+
+	// Connect changes to their bases.
+	// Branches at the bottom of the stack are connected
+	// to their merged downstack CRs instead.
+	for _, branch := range req.Branches {
+		idx, ok := idxByBranch[branch]
+		if !ok {
+			continue
+		}
+
+		if baseIdx, ok := idxByBranch[baseByBranch[branch]]; ok {
+			changes[idx].Base = baseIdx
+			changes[baseIdx].Aboves = append(changes[baseIdx].Aboves, idx)
+			continue
+		}
+
+		lastIdx := -1
+		for _, crJSON := range downstacks[branch] {
+			id, err := remoteForge.UnmarshalChangeID(crJSON)
+			if err != nil {
+				h.Log.Warn("Skipping invalid downstack change",
+					"branch", branch,
+					"change", string(crJSON),
+					"error", err,
+				)
+				continue
+			}
+
+			mergedIdx := len(changes)
+			changes = append(changes, &describedChange{
+				Change: id,
+				Base:   lastIdx,
+				Merged: true,
+			})
+			if lastIdx != -1 {
+				changes[lastIdx].Aboves = append(changes[lastIdx].Aboves, mergedIdx)
+			}
+			lastIdx = mergedIdx
+		}
+
+		if lastIdx != -1 {
+			changes[idx].Base = lastIdx
+			changes[lastIdx].Aboves = append(changes[lastIdx].Aboves, idx)
+		}
+	}
+
+	for _, c := range changes {
+		item, err := remoteRepo.FindChangeByID(ctx, c.Change)
+		if err != nil {
+			return fmt.Errorf("find change %v: %w", forge.FormatChangeID(remoteForge, c.Change), err)
+		}
+		c.Item = item
+	}
+
+	urlFormatter := changeLinkFormatter(remoteRepo)
+
+	nodes := make([]*describedNode, len(changes))
+	for idx, c := range changes {
+		state := c.Item.State.String()
+		if c.Item.State == forge.ChangeOpen && c.Item.Draft {
+			state = "draft"
+		}
+
+		nodes[idx] = &describedNode{
+			Base:         c.Base,
+			Change:       c.Change,
+			Subject:      c.Item.Subject,
+			State:        state,
+			urlFormatter: urlFormatter,
+		}
+	}
+	body := generateStackDescription(nodes, remoteForge)
+
+	// Post on the topmost open CRs of the stack.
+	// There may be more than one if the stack branches out.
+	for _, c := range changes {
+		if c.Merged || len(c.Aboves) > 0 || c.Item.State != forge.ChangeOpen {
+			continue
+		}
+
+		changeName := forge.FormatChangeID(remoteForge, c.Change)
+		if err := h.upsertStackDescription(ctx, remoteRepo, c.Change, body); err != nil {
+			return fmt.Errorf("%v: %w", changeName, err)
+		}
+		h.Log.Infof("%v: updated stack description", changeName)
+	}
+
+	return nil
+}
+
+// upsertStackDescription updates the stack description comment
+// on the given change, or posts a new one if there isn't one.
+func (h *Handler) upsertStackDescription(
+	ctx context.Context,
+	remoteRepo forge.Repository,
+	id forge.ChangeID,
+	body string,
+) error {
+	listOpts := forge.ListChangeCommentsOptions{
+		BodyMatchesAll: _describeCommentRegexes,
+		CanUpdate:      true,
+	}
+	for comment, err := range remoteRepo.ListChangeComments(ctx, id, &listOpts) {
+		if err != nil {
+			return fmt.Errorf("list comments: %w", err)
+		}
+
+		if err := remoteRepo.UpdateChangeComment(ctx, comment.ID, body); err != nil {
+			return fmt.Errorf("update comment: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := remoteRepo.PostChangeComment(ctx, id, body); err != nil {
+		return fmt.Errorf("post comment: %w", err)
+	}
+	return nil
+}
+
+type describedNode struct {
+	Base    int
+	Change  forge.ChangeID
+	Subject string
+	State   string
+
+	// urlFormatter, if set, formats the change as a markdown link.
+	urlFormatter func(forge.ChangeID) string
+}
+
+var _ stacknav.Node = (*describedNode)(nil)
+
+func (n *describedNode) BaseIdx() int { return n.Base }
+
+func (n *describedNode) Value() string {
+	change := n.Change.String()
+	if n.urlFormatter != nil {
+		change = n.urlFormatter(n.Change)
+	}
+	return fmt.Sprintf("%v %v (%v)", change, n.Subject, n.State)
+}
+
+// generateStackDescription generates the body of a stack description
+// in the form:
+//
+//	This stack consists of the following changes, from the bottom up:
+//
+//	- #123 Add feature (merged)
+//	    - #124 Refactor feature (open)
+//	        - #125 Use feature (draft)
+//
+//	<sub>Change managed by [git-spice](https://abhinav.github.io/git-spice/).</sub>
+//	<!-- gs:stack description -->
+func generateStackDescription(nodes []*describedNode, f forge.Forge) string {
+	footer := _commentFooter
+	marker := _describeMarker
+	if fc, ok := f.(forge.WithCommentFormat); ok {
+		format := fc.CommentFormat()
+		if format.Footer != "" {
+			footer = format.Footer
+		}
+		if format.Marker != "" {
+			// The forge doesn't support HTML comments.
+			marker = _markdownDescribeMarker
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(_describeHeader)
+	sb.WriteString("\n\n")
+	stacknav.Print(&sb, nodes, -1, nil)
+	sb.WriteString("\n")
+	sb.WriteString(footer)
+	sb.WriteString("\n")
+	sb.WriteString(marker)
+	sb.WriteString("\n")
+	return sb.String()
+}
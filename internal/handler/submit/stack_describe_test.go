@@ -0,0 +1,142 @@
+package submit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/spice"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_DescribeStack(t *testing.T) {
+	repo := forgetest.NewFakeRepository()
+	repo.AddChange(forgetest.FakeChange{Number: 1, Subject: "Add feature", State: forge.ChangeMerged})
+	repo.AddChange(forgetest.FakeChange{Number: 2, Subject: "Refactor feature"})
+	repo.AddChange(forgetest.FakeChange{Number: 3, Subject: "Use feature", Draft: true})
+	repo.AddChange(forgetest.FakeChange{Number: 4, Subject: "Document feature"})
+	repo.AddChange(forgetest.FakeChange{Number: 5, Subject: "Unrelated"})
+
+	mergedJSON, err := json.Marshal(forgetest.FakeChangeID(1))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().
+		LoadBranches(gomock.Any()).
+		Return([]spice.LoadBranchItem{
+			{
+				Name:            "feat2",
+				Base:            "main",
+				Change:          &forgetest.FakeChangeMetadata{Number: 2},
+				MergedDownstack: []json.RawMessage{mergedJSON},
+			},
+			{
+				Name:   "feat3",
+				Base:   "feat2",
+				Change: &forgetest.FakeChangeMetadata{Number: 3},
+			},
+			{
+				Name:   "feat4",
+				Base:   "feat2",
+				Change: &forgetest.FakeChangeMetadata{Number: 4},
+			},
+			{Name: "feat5", Base: "feat4"}, // not submitted
+			{
+				Name:   "other",
+				Base:   "main",
+				Change: &forgetest.FakeChangeMetadata{Number: 5},
+			},
+		}, nil).
+		Times(2)
+
+	handler := &Handler{
+		Log:     silogtest.New(t),
+		Service: mockService,
+		FindRemote: func(context.Context) (string, error) {
+			return "origin", nil
+		},
+		OpenRemoteRepository: func(context.Context, string) (forge.Repository, error) {
+			return repo, nil
+		},
+	}
+
+	req := &DescribeStackRequest{
+		Branches: []string{"feat2", "feat3", "feat4", "feat5"},
+	}
+	require.NoError(t, handler.DescribeStack(t.Context(), req))
+
+	want := joinLines(
+		_describeHeader,
+		"",
+		"- #1 Add feature (merged)",
+		"    - #2 Refactor feature (open)",
+		"        - #3 Use feature (draft)",
+		"        - #4 Document feature (open)",
+		"",
+		_commentFooter,
+		_describeMarker,
+	)
+	for _, id := range []forgetest.FakeChangeID{3, 4} {
+		assert.Equal(t, []forgetest.FakeComment{
+			{ID: int(id) - 2, Change: int(id), Body: want},
+		}, repo.Comments(id), "change %v", id)
+	}
+	for _, id := range []forgetest.FakeChangeID{1, 2, 5} {
+		assert.Empty(t, repo.Comments(id), "change %v", id)
+	}
+
+	// Describing again updates the existing comments.
+	require.NoError(t, repo.EditChange(t.Context(), forgetest.FakeChangeID(3), forge.EditChangeOptions{
+		Draft: new(bool),
+	}))
+	require.NoError(t, handler.DescribeStack(t.Context(), req))
+
+	want = joinLines(
+		_describeHeader,
+		"",
+		"- #1 Add feature (merged)",
+		"    - #2 Refactor feature (open)",
+		"        - #3 Use feature (open)",
+		"        - #4 Document feature (open)",
+		"",
+		_commentFooter,
+		_describeMarker,
+	)
+	for _, id := range []forgetest.FakeChangeID{3, 4} {
+		assert.Equal(t, []forgetest.FakeComment{
+			{ID: int(id) - 2, Change: int(id), Body: want},
+		}, repo.Comments(id), "change %v", id)
+	}
+}
+
+func TestHandler_DescribeStack_notSubmitted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().
+		LoadBranches(gomock.Any()).
+		Return([]spice.LoadBranchItem{
+			{Name: "feat1", Base: "main"},
+		}, nil)
+
+	handler := &Handler{
+		Log:     silogtest.New(t),
+		Service: mockService,
+		FindRemote: func(context.Context) (string, error) {
+			return "origin", nil
+		},
+		OpenRemoteRepository: func(context.Context, string) (forge.Repository, error) {
+			return forgetest.NewFakeRepository(), nil
+		},
+	}
+
+	err := handler.DescribeStack(t.Context(), &DescribeStackRequest{
+		Branches: []string{"feat1"},
+	})
+	assert.ErrorContains(t, err, "no submitted branches")
+}
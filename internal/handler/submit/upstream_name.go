@@ -0,0 +1,77 @@
+package submit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/spice"
+)
+
+// upstreamBranchName returns the name under which a branch
+// that was never pushed should be pushed to the remote.
+//
+// This is the branch name itself
+// unless a template was configured with spice.submit.upstreamBranchTemplate.
+// With spice.submit.userNamespace, the name is additionally placed
+// under the forge username of the current user.
+// The name may still have to be made unique in the remote.
+func (h *Handler) upstreamBranchName(
+	ctx context.Context,
+	tmpl string,
+	userNamespace bool,
+	branch string,
+) (string, error) {
+	name := branch
+	if tmpl != "" {
+		data := spice.UpstreamBranchData{Branch: branch}
+		if strings.Contains(tmpl, "{user}") {
+			ident, err := h.Repository.Var(ctx, "GIT_AUTHOR_IDENT")
+			if err != nil {
+				return "", fmt.Errorf("get author: %w", err)
+			}
+			data.User, err = spice.IdentUser(ident)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		var err error
+		name, err = spice.ExpandUpstreamBranchTemplate(tmpl, data)
+		if err != nil {
+			return "", fmt.Errorf("spice.submit.upstreamBranchTemplate: %w", err)
+		}
+	}
+
+	if userNamespace {
+		user, err := h.forgeUser(ctx)
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(name, user+"/") {
+			name = user + "/" + name
+		}
+	}
+
+	return name, nil
+}
+
+// forgeUser returns the username of the current user on the forge,
+// memoizing the result.
+func (h *Handler) forgeUser(ctx context.Context) (string, error) {
+	return h.currentUser.Get(func() (string, error) {
+		remoteRepo, err := h.RemoteRepository(ctx)
+		if err != nil {
+			return "", fmt.Errorf("open remote repository: %w", err)
+		}
+
+		user, err := remoteRepo.CurrentUser(ctx)
+		if err != nil {
+			return "", fmt.Errorf("get current user: %w", err)
+		}
+		if user == "" {
+			return "", fmt.Errorf("%v did not report a username", remoteRepo.Forge().ID())
+		}
+		return user, nil
+	})
+}
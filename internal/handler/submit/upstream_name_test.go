@@ -0,0 +1,57 @@
+package submit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestHandlerUpstreamBranchName(t *testing.T) {
+	tests := []struct {
+		name          string
+		tmpl          string
+		userNamespace bool
+		branch        string
+		want          string
+	}{
+		{name: "Default", branch: "feat", want: "feat"},
+		{name: "Template", tmpl: "wip/{branch}", branch: "feat", want: "wip/feat"},
+		{name: "UserNamespace", userNamespace: true, branch: "feat", want: "alice/feat"},
+		{
+			name:          "TemplateAndUserNamespace",
+			tmpl:          "wip/{branch}",
+			userNamespace: true,
+			branch:        "feat",
+			want:          "alice/wip/feat",
+		},
+		{
+			name:          "AlreadyNamespaced",
+			userNamespace: true,
+			branch:        "alice/feat",
+			want:          "alice/feat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{
+				Log: silogtest.New(t),
+				FindRemote: func(context.Context) (string, error) {
+					return "origin", nil
+				},
+				OpenRemoteRepository: func(context.Context, string) (forge.Repository, error) {
+					return forgetest.NewFakeRepository().WithUser("alice"), nil
+				},
+			}
+
+			got, err := handler.upstreamBranchName(t.Context(), tt.tmpl, tt.userNamespace, tt.branch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
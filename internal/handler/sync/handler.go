@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"iter"
 	"runtime"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 
 	"go.abhg.dev/gs/internal/cli"
@@ -18,6 +20,9 @@ import (
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/graph"
 	branchdel "go.abhg.dev/gs/internal/handler/delete"
+	"go.abhg.dev/gs/internal/handler/refresh"
+	"go.abhg.dev/gs/internal/handler/restack"
+	"go.abhg.dev/gs/internal/handler/submit"
 	"go.abhg.dev/gs/internal/must"
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/spice"
@@ -31,10 +36,17 @@ type GitRepository interface {
 	LocalBranches(ctx context.Context, opts *git.LocalBranchesOptions) iter.Seq2[git.LocalBranch, error]
 	OpenWorktree(ctx context.Context, dir string) (*git.Worktree, error) // TODO: GitWorktree
 	IsAncestor(ctx context.Context, ancestor, descendant git.Hash) bool
+	IsChangeApplied(ctx context.Context, upstream, base, head git.Hash) (bool, error)
 	Fetch(ctx context.Context, opts git.FetchOptions) error
 	CountCommits(ctx context.Context, commitRange git.CommitRange) (int, error)
 	DeleteBranch(ctx context.Context, name string, opts git.BranchDeleteOptions) error // TODO:specialize to delete remote branch?
 	RemoteURL(ctx context.Context, remote string) (string, error)
+	ReadCommit(ctx context.Context, commitish string) (*git.CommitObject, error)
+	ListRemoteRefs(ctx context.Context, remote string, opts *git.ListRemoteRefsOptions) iter.Seq2[git.RemoteRef, error]
+	SetRef(ctx context.Context, req git.SetRefRequest) error
+	MergeBase(ctx context.Context, a, b string) (git.Hash, error)
+	BranchUpstream(ctx context.Context, branch string) (string, error)
+	Var(ctx context.Context, name string) (string, error)
 }
 
 var _ GitRepository = (*git.Repository)(nil)
@@ -44,6 +56,7 @@ type GitWorktree interface {
 	CurrentBranch(ctx context.Context) (string, error)
 	Pull(ctx context.Context, opts git.PullOptions) error
 	CheckoutBranch(ctx context.Context, name string) error
+	Reset(ctx context.Context, commit string, opts git.ResetOptions) error
 	RootDir() string
 }
 
@@ -61,6 +74,8 @@ var _ Store = (*state.Store)(nil)
 type Service interface {
 	LoadBranches(ctx context.Context) ([]spice.LoadBranchItem, error)
 	ListAbove(ctx context.Context, name string) ([]string, error)
+	ArchiveBranch(ctx context.Context, name string) error
+	ClearUpstreamBase(ctx context.Context, name string) error
 }
 
 var _ Service = (*spice.Service)(nil)
@@ -73,8 +88,23 @@ type DeleteHandler interface {
 // RestackHandler allows restacking the current stack.
 type RestackHandler interface {
 	RestackStack(ctx context.Context, branch string) error
+	RestackUpstack(ctx context.Context, branch string, opts *restack.UpstackOptions) error
 }
 
+// RefreshHandler allows refreshing the change metadata of branches.
+type RefreshHandler interface {
+	RefreshBranches(context.Context, *refresh.Request) (*refresh.Response, error)
+}
+
+var _ RefreshHandler = (*refresh.Handler)(nil)
+
+// NavCommentCleaner cleans up navigation comments on merged change requests.
+type NavCommentCleaner interface {
+	CleanupNavigationComments(context.Context, *submit.CleanupNavCommentsRequest) (*submit.CleanupNavCommentsResponse, error)
+}
+
+var _ NavCommentCleaner = (*submit.NavCommentCleaner)(nil)
+
 // Handler implements syncing commands.
 type Handler struct {
 	Log        *silog.Logger  // required
@@ -89,6 +119,10 @@ type Handler struct {
 	Remote string // required
 	// RemoteRepository is set only if remote refers to a supported forge.
 	RemoteRepository forge.Repository // optional
+	// Refresh is required if RemoteRepository is set.
+	Refresh RefreshHandler // optional
+	// NavComments is required if RemoteRepository is set.
+	NavComments NavCommentCleaner // optional
 }
 
 // ClosedChanges specifies how to handle closed Change Requests.
@@ -150,8 +184,21 @@ func (c ClosedChanges) String() string {
 type TrunkOptions struct {
 	// TODO: flag to not delete merged branches?
 
-	Restack       bool          `help:"Restack the current stack after syncing"`
-	ClosedChanges ClosedChanges `default:"ask" config:"repoSync.closedChanges" enum:"ask,ignore" help:"How to handle closed change requests. One of 'ask' and 'ignore'." hidden:""`
+	Restack        bool          `help:"Restack the current stack after syncing"`
+	ClosedChanges  ClosedChanges `default:"ask" config:"repoSync.closedChanges" enum:"ask,ignore" help:"How to handle closed change requests. One of 'ask' and 'ignore'." hidden:""`
+	RefreshChanges bool          `default:"true" config:"repoSync.refreshChanges" released:"unreleased" help:"Whether to re-resolve change requests of submitted branches by their upstream branch before checking their status." hidden:""`
+
+	NavCommentCleanup submit.NavCommentCleanup `name:"nav-comment-cleanup" default:"none" config:"submit.navigationCommentCleanup" enum:"none,strike,collapse,delete" released:"unreleased" help:"What to do with navigation comments after a stack fully merges. One of 'none', 'strike', 'collapse', and 'delete'." hidden:""`
+
+	StaleBranches  StaleBranches `default:"ignore" config:"repoSync.staleBranches" enum:"ignore,warn,archive,delete" released:"unreleased" help:"How to handle branches with no new commits and no open change request. One of 'ignore', 'warn', 'archive', and 'delete'." hidden:""`
+	StaleAfterDays int           `default:"30" config:"repoSync.staleAfterDays" released:"unreleased" help:"Number of days after which a branch with no new commits and no open change request is considered stale." hidden:""`
+
+	RemoteRewrites RemoteRewrites `default:"ignore" config:"repoSync.remoteRewrites" enum:"ignore,ask,adopt" released:"unreleased" help:"How to handle submitted branches that were force-pushed by someone else. One of 'ignore', 'ask', and 'adopt'." hidden:""`
+
+	// UpstreamBranchTemplate and UserNamespace are used
+	// to guess the remote names of branches that were pushed outside of git-spice.
+	UpstreamBranchTemplate string `config:"submit.upstreamBranchTemplate" released:"unreleased" help:"Template for the names of new branches in the remote, e.g. 'users/{user}/{branch}'." hidden:""`
+	UserNamespace          bool   `config:"submit.userNamespace" released:"unreleased" help:"Push new branches under the forge username of the current user." hidden:""`
 }
 
 // SyncTrunk syncs the trunk branch with the remote repository,
@@ -320,6 +367,17 @@ func (h *Handler) SyncTrunk(ctx context.Context, opts *TrunkOptions) error {
 		return fmt.Errorf("list tracked branches: %w", err)
 	}
 
+	if h.RemoteRepository != nil && opts.RefreshChanges && h.refreshChanges(ctx, candidates) {
+		// Reload to pick up the repaired change metadata.
+		candidates, err = h.Service.LoadBranches(ctx)
+		if err != nil {
+			return fmt.Errorf("list tracked branches: %w", err)
+		}
+	}
+
+	// Branches based on remote branches need those to be fetched.
+	rebased := h.syncUpstreamBases(ctx, candidates, trunkEndHash)
+
 	var branchesToDelete []branchDeletion
 	if h.RemoteRepository == nil {
 		// Unsupported forge.
@@ -345,7 +403,7 @@ func (h *Handler) SyncTrunk(ctx context.Context, opts *TrunkOptions) error {
 		}
 	} else {
 		// Supported forge. Check for merged CRs and upstream branches.
-		branchesToDelete, err = h.findForgeFinishedBranches(ctx, candidates, opts.ClosedChanges)
+		branchesToDelete, err = h.findForgeFinishedBranches(ctx, candidates, trunkEndHash, opts.ClosedChanges, opts.NavCommentCleanup, opts.UpstreamBranchTemplate, opts.UserNamespace)
 		if err != nil {
 			return fmt.Errorf("find finished CRs: %w", err)
 		}
@@ -355,13 +413,63 @@ func (h *Handler) SyncTrunk(ctx context.Context, opts *TrunkOptions) error {
 		return err
 	}
 
-	if opts.Restack {
+	deleted := make(map[string]struct{}, len(branchesToDelete))
+	for _, b := range branchesToDelete {
+		deleted[b.BranchName] = struct{}{}
+	}
+	remaining := slices.DeleteFunc(slices.Clone(candidates), func(b spice.LoadBranchItem) bool {
+		_, ok := deleted[b.Name]
+		return ok
+	})
+
+	if opts.StaleBranches != StaleBranchesIgnore {
+		if err := h.cleanupStaleBranches(ctx, remaining, trunkEndHash, opts.StaleBranches, opts.StaleAfterDays); err != nil {
+			return err
+		}
+	}
+
+	rebased = slices.DeleteFunc(rebased, func(name string) bool {
+		_, ok := deleted[name]
+		return ok
+	})
+
+	if opts.RemoteRewrites != RemoteRewritesIgnore {
+		// Adopted branches need their upstacks restacked.
+		for _, name := range h.adoptRemoteRewrites(ctx, remaining, opts.RemoteRewrites) {
+			if !slices.Contains(rebased, name) {
+				rebased = append(rebased, name)
+			}
+		}
+	}
+
+	if !opts.Restack {
+		for _, name := range rebased {
+			log.Infof("%v: run '%s upstack restack --branch %v' to restack it", name, cli.Name(), name)
+		}
+	} else {
 		// current branch may have changed after deletion
 		// of merged branches.
-		currentBranch, err := h.Worktree.CurrentBranch(ctx)
-		if err != nil {
-			log.Warn("Failed to get current branch, skipping restack", "error", err)
+		currentBranch, currentErr := h.Worktree.CurrentBranch(ctx)
+
+		// Branches whose upstream base changed
+		// or that were reset to the remote
+		// may not be in the current stack.
+		// Restacking them checks them out.
+		for _, name := range rebased {
+			if err := h.Restack.RestackUpstack(ctx, name, nil); err != nil {
+				return err
+			}
+		}
+
+		if currentErr != nil {
+			log.Warn("Failed to get current branch, skipping restack", "error", currentErr)
 		} else {
+			if len(rebased) > 0 {
+				if err := h.Worktree.CheckoutBranch(ctx, currentBranch); err != nil {
+					return fmt.Errorf("checkout %v: %w", currentBranch, err)
+				}
+			}
+
 			// TODO: if the merged branch leaves us on trunk
 			// --restack will end up restacking all known branches.
 			return h.Restack.RestackStack(ctx, currentBranch)
@@ -371,25 +479,62 @@ func (h *Handler) SyncTrunk(ctx context.Context, opts *TrunkOptions) error {
 	return nil
 }
 
+// refreshChanges repairs stale change metadata for submitted branches,
+// e.g. if a CR was closed and re-created for the same branch.
+// It reports whether any branches were updated.
+//
+// Failures are logged and otherwise ignored.
+func (h *Handler) refreshChanges(ctx context.Context, branches []spice.LoadBranchItem) bool {
+	must.NotBeNilf(h.Refresh, "Refresh is required if RemoteRepository is set")
+
+	var submitted []string
+	for _, b := range branches {
+		if b.Change != nil {
+			submitted = append(submitted, b.Name)
+		}
+	}
+	if len(submitted) == 0 {
+		return false
+	}
+
+	res, err := h.Refresh.RefreshBranches(ctx, &refresh.Request{
+		Branches: submitted,
+	})
+	if err != nil {
+		h.Log.Warn("Could not refresh change metadata", "error", err)
+		return false
+	}
+	if len(res.Updated) == 0 {
+		return false
+	}
+
+	// Navigation comments in the affected stacks
+	// still refer to the replaced CRs.
+	for _, name := range res.Updated {
+		h.Log.Infof("%v: to update navigation comments, check it out and run '%v stack submit --update-only'", name, cli.Name())
+	}
+	return true
+}
+
 // findLocalMergedBranches finds branches that have been merged
-// by inspecting what's reachable from the trunk.
+// by inspecting the contents of the trunk.
 //
-// This will only work for merges and fast-forwards.
-// Squash or rebase merges will need to be handled manually by the user.
+// Merges and fast-forwards are detected by reachability.
+// Squash and rebase merges are detected by comparing patch IDs
+// of the branch's changes against commits in trunk.
 func (h *Handler) findLocalMergedBranches(
 	ctx context.Context,
 	knownBranches []spice.LoadBranchItem,
 	trunkHash git.Hash,
 ) ([]branchDeletion, error) {
-	// Find branches that have been merged by checking
-	// if they are reachable from the trunk.
 	var branchesToDelete []branchDeletion
 	for _, b := range knownBranches {
-		if h.Repository.IsAncestor(ctx, b.Head, trunkHash) {
+		if h.changesInTrunk(ctx, b.Name, b.BaseHash, b.Head, trunkHash) {
 			h.Log.Infof("%v was merged", b.Name)
 			branchesToDelete = append(branchesToDelete, branchDeletion{
-				BranchName:   b.Name,
-				UpstreamName: b.UpstreamBranch,
+				BranchName:     b.Name,
+				UpstreamName:   b.UpstreamBranch,
+				UpstreamRemote: b.UpstreamRemote,
 			})
 		}
 	}
@@ -397,28 +542,63 @@ func (h *Handler) findLocalMergedBranches(
 	return branchesToDelete, nil
 }
 
+// changesInTrunk reports whether the changes in base..head
+// are already in trunk.
+// This is true if the branch was merged, squash-merged, or rebase-merged.
+//
+// Failures are logged and treated as the changes not being in trunk.
+func (h *Handler) changesInTrunk(ctx context.Context, name string, base, head, trunkHash git.Hash) bool {
+	if h.Repository.IsAncestor(ctx, head, trunkHash) {
+		return true
+	}
+	if base == "" || base == git.ZeroHash {
+		return false
+	}
+
+	applied, err := h.Repository.IsChangeApplied(ctx, trunkHash, base, head)
+	if err != nil {
+		h.Log.Debug("Could not check whether changes are in trunk", "branch", name, "error", err)
+		return false
+	}
+	return applied
+}
+
 func (h *Handler) findForgeFinishedBranches(
 	ctx context.Context,
 	knownBranches []spice.LoadBranchItem,
+	trunkHash git.Hash,
 	closedChangeHandling ClosedChanges,
+	navCommentCleanup submit.NavCommentCleanup,
+	upstreamBranchTemplate string,
+	userNamespace bool,
 ) ([]branchDeletion, error) {
 	type submittedBranch struct {
 		Name string
 
 		Base            string
+		BaseHash        git.Hash
+		Head            git.Hash
 		MergedDownstack []json.RawMessage
 
 		Change forge.ChangeID
 		State  forge.ChangeState
 
-		// Branch name pushed to the remote.
+		// MergeCommit is the commit that merged the CR, if known,
+		// and MergeBase is the branch it was merged into.
+		MergeCommit git.Hash
+		MergeBase   string
+
+		// Branch name pushed to the remote,
+		// and the remote it was pushed to if not the default.
 		UpstreamBranch string
+		UpstreamRemote string
 	}
 
 	type trackedBranch struct {
 		Name string
 
 		Base            string
+		BaseHash        git.Hash
 		MergedDownstack []json.RawMessage
 
 		Change        forge.ChangeID
@@ -426,8 +606,10 @@ func (h *Handler) findForgeFinishedBranches(
 		RemoteHeadSHA git.Hash
 		LocalHeadSHA  git.Hash
 
-		// Branch name pushed to the remote.
+		// Branch name pushed to the remote,
+		// and the remote it was pushed to if not the default.
 		UpstreamBranch string
+		UpstreamRemote string
 	}
 
 	// There are two kinds of branches under consideration:
@@ -442,6 +624,11 @@ func (h *Handler) findForgeFinishedBranches(
 	//
 	// We'll try to do these checks concurrently.
 
+	upstreamName, err := h.upstreamNamer(ctx, upstreamBranchTemplate, userNamespace)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		submittedBranches []*submittedBranch
 		trackedBranches   []*trackedBranch
@@ -449,15 +636,23 @@ func (h *Handler) findForgeFinishedBranches(
 	for _, b := range knownBranches {
 		upstreamBranch := b.UpstreamBranch
 		if upstreamBranch == "" {
-			upstreamBranch = b.Name
+			// Never pushed by git-spice.
+			// Assume it was pushed under the name we would use.
+			upstreamBranch, err = upstreamName(b.Name)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if b.Change != nil {
 			b := &submittedBranch{
 				Name:            b.Name,
 				Base:            b.Base,
+				BaseHash:        b.BaseHash,
+				Head:            b.Head,
 				Change:          b.Change.ChangeID(),
 				UpstreamBranch:  upstreamBranch,
+				UpstreamRemote:  b.UpstreamRemote,
 				MergedDownstack: b.MergedDownstack,
 			}
 			submittedBranches = append(submittedBranches, b)
@@ -469,7 +664,9 @@ func (h *Handler) findForgeFinishedBranches(
 			b := &trackedBranch{
 				Name:            b.Name,
 				Base:            b.Base,
+				BaseHash:        b.BaseHash,
 				UpstreamBranch:  upstreamBranch,
+				UpstreamRemote:  b.UpstreamRemote,
 				MergedDownstack: b.MergedDownstack,
 			}
 			trackedBranches = append(trackedBranches, b)
@@ -484,14 +681,16 @@ func (h *Handler) findForgeFinishedBranches(
 				changeIDs[i] = b.Change
 			}
 
-			states, err := h.RemoteRepository.ChangesStates(ctx, changeIDs)
+			details, err := h.RemoteRepository.ChangesDetails(ctx, changeIDs)
 			if err != nil {
 				h.Log.Error("Failed to query CR status", "error", err)
 				return
 			}
 
-			for i, state := range states {
-				submittedBranches[i].State = state
+			for i, d := range details {
+				submittedBranches[i].State = d.State
+				submittedBranches[i].MergeCommit = d.MergeCommit
+				submittedBranches[i].MergeBase = d.BaseName
 			}
 		})
 	}
@@ -548,10 +747,12 @@ func (h *Handler) findForgeFinishedBranches(
 		Name           string
 		Base           string
 		UpstreamBranch string
+		UpstreamRemote string
 		ChangeID       forge.ChangeID
 		Merged         bool // true if merged, false if closed
 	}
 
+	remoteForge := h.RemoteRepository.Forge()
 	finishedBranches := make(map[string]finishedBranch) // name -> branch
 	mergedDownstacks := make(map[string][]json.RawMessage)
 	for _, branch := range submittedBranches {
@@ -560,18 +761,39 @@ func (h *Handler) findForgeFinishedBranches(
 			continue // not merged yet
 
 		case forge.ChangeClosed:
+			closed := finishedBranch{
+				Name:           branch.Name,
+				Base:           branch.Base,
+				UpstreamBranch: branch.UpstreamBranch,
+				UpstreamRemote: branch.UpstreamRemote,
+				ChangeID:       branch.Change,
+				Merged:         false, // closed, not merged
+			}
+			// Note: Don't propagate mergedDownstacks for closed changes
+
 			if closedChangeHandling == ClosedChangesIgnore {
-				h.Log.Infof("%v: %v was closed but not merged, ignoring", branch.Name, branch.Change)
+				h.Log.Infof("%v: %v was closed but not merged, ignoring", branch.Name, forge.FormatChangeID(remoteForge, branch.Change))
 				continue
-			} else if !ui.Interactive(h.View) {
-				h.Log.Warnf("%v: %v was closed but not merged.", branch.Name, branch.Change)
+			}
+
+			// The CR may have been closed after its changes
+			// were squash-merged into trunk out of band.
+			// There's nothing left to keep in that case.
+			if h.changesInTrunk(ctx, branch.Name, branch.BaseHash, branch.Head, trunkHash) {
+				h.Log.Infof("%v: %v was closed, but its changes are already in %v", branch.Name, forge.FormatChangeID(remoteForge, branch.Change), h.Store.Trunk())
+				finishedBranches[branch.Name] = closed
+				continue
+			}
+
+			if !ui.Interactive(h.View) {
+				h.Log.Warnf("%v: %v was closed but not merged.", branch.Name, forge.FormatChangeID(remoteForge, branch.Change))
 				continue
 			}
 
 			var shouldDelete bool
 			prompt := ui.NewConfirm().
 				WithTitle(fmt.Sprintf("Delete %v?", branch.Name)).
-				WithDescription(fmt.Sprintf("%v was closed but not merged.", branch.Change)).
+				WithDescription(fmt.Sprintf("%v was closed but not merged.", forge.FormatChangeID(remoteForge, branch.Change))).
 				WithValue(&shouldDelete)
 			if err := ui.Run(h.View, prompt); err != nil {
 				h.Log.Warn("Skipping branch", "branch", branch.Name, "error", err)
@@ -579,22 +801,28 @@ func (h *Handler) findForgeFinishedBranches(
 			}
 
 			if shouldDelete {
-				finishedBranches[branch.Name] = finishedBranch{
-					Name:           branch.Name,
-					Base:           branch.Base,
-					UpstreamBranch: branch.UpstreamBranch,
-					ChangeID:       branch.Change,
-					Merged:         false, // closed, not merged
-				}
-				// Note: Don't propagate mergedDownstacks for closed changes
+				finishedBranches[branch.Name] = closed
 			}
 
 		case forge.ChangeMerged:
-			h.Log.Infof("%v: %v was merged", branch.Name, branch.Change)
+			h.Log.Infof("%v: %v was merged", branch.Name, forge.FormatChangeID(remoteForge, branch.Change))
+
+			// If the CR was merged into trunk,
+			// but the local trunk doesn't have the merge commit yet,
+			// deleting the branch would leave its upstacks
+			// without its changes when they're restacked.
+			trunk := h.Store.Trunk()
+			if branch.MergeBase == trunk && branch.MergeCommit != "" &&
+				!h.Repository.IsAncestor(ctx, branch.MergeCommit, trunkHash) {
+				h.Log.Warnf("%v: not deleting: %v does not include merge commit %v yet", branch.Name, trunk, branch.MergeCommit.Short())
+				continue
+			}
+
 			finishedBranches[branch.Name] = finishedBranch{
 				Name:           branch.Name,
 				Base:           branch.Base,
 				UpstreamBranch: branch.UpstreamBranch,
+				UpstreamRemote: branch.UpstreamRemote,
 				ChangeID:       branch.Change,
 				Merged:         true, // merged
 			}
@@ -612,19 +840,29 @@ func (h *Handler) findForgeFinishedBranches(
 			Name:           branch.Name,
 			Base:           branch.Base,
 			UpstreamBranch: branch.UpstreamBranch,
+			UpstreamRemote: branch.UpstreamRemote,
 			ChangeID:       branch.Change,
 			Merged:         true, // merged
 		}
 		mergedDownstacks[branch.Name] = branch.MergedDownstack
 
 		if branch.RemoteHeadSHA == branch.LocalHeadSHA {
-			h.Log.Infof("%v: %v was merged", branch.Name, branch.Change)
+			h.Log.Infof("%v: %v was merged", branch.Name, forge.FormatChangeID(remoteForge, branch.Change))
+			finishedBranches[branch.Name] = finished
+			continue
+		}
+
+		// The heads may differ if the branch was rebased locally
+		// after it was pushed, but its changes may still be in trunk,
+		// e.g. if the CR was squash-merged.
+		if h.changesInTrunk(ctx, branch.Name, branch.BaseHash, branch.LocalHeadSHA, trunkHash) {
+			h.Log.Infof("%v: %v was merged", branch.Name, forge.FormatChangeID(remoteForge, branch.Change))
 			finishedBranches[branch.Name] = finished
 			continue
 		}
 
 		mismatchMsg := fmt.Sprintf("%v was merged but local SHA (%v) does not match remote SHA (%v)",
-			branch.Change, branch.LocalHeadSHA.Short(), branch.RemoteHeadSHA.Short())
+			forge.FormatChangeID(remoteForge, branch.Change), branch.LocalHeadSHA.Short(), branch.RemoteHeadSHA.Short())
 
 		// If the remote head SHA doesn't match the local head SHA,
 		// there may be local commits that haven't been pushed yet.
@@ -677,6 +915,11 @@ func (h *Handler) findForgeFinishedBranches(
 	// This is done in topological order (branches closer to trunk first)
 	// so that if two consecutive branches were merged,
 	// both changes are bubbled up.
+	//
+	// Merged branches without upstacks are the tops of stacks
+	// that have fully merged. Their merged downstacks
+	// and their own CRs make up the CRs of the whole stack.
+	var fullyMergedChanges []forge.ChangeID
 	for _, name := range topoBranches {
 		branch, ok := finishedBranches[name]
 		must.Bef(ok, "topologically sorted branch %q must be finished", name)
@@ -689,6 +932,19 @@ func (h *Handler) findForgeFinishedBranches(
 			continue
 		}
 
+		if len(aboves) == 0 {
+			for _, crJSON := range mergedDownstacks[name] {
+				changeID, err := remoteForge.UnmarshalChangeID(crJSON)
+				if err != nil {
+					h.Log.Warn("Skipping invalid downstack change",
+						"branch", name, "change", string(crJSON), "error", err)
+					continue
+				}
+				fullyMergedChanges = append(fullyMergedChanges, changeID)
+			}
+			fullyMergedChanges = append(fullyMergedChanges, branch.ChangeID)
+		}
+
 		changeIDJSON, err := h.RemoteRepository.Forge().MarshalChangeID(branch.ChangeID)
 		if err != nil {
 			h.Log.Warn("Unable to serialize ChangeID for merged branch. Not propagating to merge history.",
@@ -731,20 +987,83 @@ func (h *Handler) findForgeFinishedBranches(
 		h.Log.Warn("Unable to propagated merged downstacks", "error", err)
 	}
 
+	if navCommentCleanup != submit.NavCommentCleanupNone && len(fullyMergedChanges) > 0 {
+		must.NotBeNilf(h.NavComments, "NavComments is required if RemoteRepository is set")
+		// Failures are logged by the cleaner.
+		_, err := h.NavComments.CleanupNavigationComments(ctx, &submit.CleanupNavCommentsRequest{
+			Changes: fullyMergedChanges,
+			Mode:    navCommentCleanup,
+		})
+		if err != nil {
+			h.Log.Warn("Unable to clean up navigation comments", "error", err)
+		}
+	}
+
 	branchesToDelete := make([]branchDeletion, 0, len(finishedBranches))
 	for _, branch := range finishedBranches {
 		branchesToDelete = append(branchesToDelete, branchDeletion{
-			BranchName:   branch.Name,
-			UpstreamName: branch.UpstreamBranch,
+			BranchName:     branch.Name,
+			UpstreamName:   branch.UpstreamBranch,
+			UpstreamRemote: branch.UpstreamRemote,
 		})
 	}
 
 	return branchesToDelete, nil
 }
 
+// upstreamNamer returns a function that reports the name
+// a branch would be pushed as by git-spice
+// per spice.submit.upstreamBranchTemplate and spice.submit.userNamespace.
+func (h *Handler) upstreamNamer(
+	ctx context.Context,
+	tmpl string,
+	userNamespace bool,
+) (func(branch string) (string, error), error) {
+	var data spice.UpstreamBranchData
+	if strings.Contains(tmpl, "{user}") {
+		ident, err := h.Repository.Var(ctx, "GIT_AUTHOR_IDENT")
+		if err != nil {
+			return nil, fmt.Errorf("get author: %w", err)
+		}
+		data.User, err = spice.IdentUser(ident)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var namespace string
+	if userNamespace {
+		user, err := h.RemoteRepository.CurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get current user: %w", err)
+		}
+		namespace = user + "/"
+	}
+
+	return func(branch string) (string, error) {
+		name := branch
+		if tmpl != "" {
+			data.Branch = branch
+			var err error
+			name, err = spice.ExpandUpstreamBranchTemplate(tmpl, data)
+			if err != nil {
+				return "", fmt.Errorf("spice.submit.upstreamBranchTemplate: %w", err)
+			}
+		}
+		if !strings.HasPrefix(name, namespace) {
+			name = namespace + name
+		}
+		return name, nil
+	}, nil
+}
+
 type branchDeletion struct {
 	BranchName   string
 	UpstreamName string
+
+	// UpstreamRemote is the remote that the branch was pushed to
+	// if it's not the repository's remote.
+	UpstreamRemote string
 }
 
 func (h *Handler) deleteBranches(ctx context.Context, branchesToDelete []branchDeletion) error {
@@ -757,7 +1076,7 @@ func (h *Handler) deleteBranches(ctx context.Context, branchesToDelete []branchD
 	for i, b := range branchesToDelete {
 		allBranchNames[i] = b.BranchName
 		if b.UpstreamName != "" {
-			upstreamByName[b.BranchName] = b.UpstreamName
+			upstreamByName[b.BranchName] = cmp.Or(b.UpstreamRemote, h.Remote) + "/" + b.UpstreamName
 		}
 	}
 
@@ -788,12 +1107,11 @@ func (h *Handler) deleteBranches(ctx context.Context, branchesToDelete []branchD
 	// Also delete the remote tracking branch for this branch
 	// if it still exists.
 	for _, branchName := range deleteBranchNames {
-		upstreamName, ok := upstreamByName[branchName]
+		remoteBranch, ok := upstreamByName[branchName]
 		if !ok {
 			continue // no upstream branch, nothing to delete
 		}
 
-		remoteBranch := h.Remote + "/" + upstreamName
 		if _, err := h.Repository.PeelToCommit(ctx, remoteBranch); err == nil {
 			if err := h.Repository.DeleteBranch(ctx, remoteBranch, git.BranchDeleteOptions{
 				Remote: true,
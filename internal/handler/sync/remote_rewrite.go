@@ -0,0 +1,369 @@
+package sync
+
+import (
+	"cmp"
+	"context"
+	"encoding"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// RemoteRewrites specifies how to handle submitted branches
+// that were force-pushed by someone else, e.g. after a restack.
+type RemoteRewrites int
+
+const (
+	// RemoteRewritesIgnore does not look for rewritten branches.
+	// This is the default.
+	RemoteRewritesIgnore RemoteRewrites = iota
+
+	// RemoteRewritesAsk prompts whether to adopt
+	// the rewritten version of each branch.
+	RemoteRewritesAsk
+
+	// RemoteRewritesAdopt adopts the rewritten version
+	// of each branch without prompting.
+	RemoteRewritesAdopt
+)
+
+var (
+	_ encoding.TextUnmarshaler = (*RemoteRewrites)(nil)
+	_ encoding.TextMarshaler   = (*RemoteRewrites)(nil)
+)
+
+// UnmarshalText decodes a RemoteRewrites from text.
+// It supports "ignore", "ask", and "adopt" values.
+func (r *RemoteRewrites) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "ignore":
+		*r = RemoteRewritesIgnore
+	case "ask":
+		*r = RemoteRewritesAsk
+	case "adopt":
+		*r = RemoteRewritesAdopt
+	default:
+		return fmt.Errorf("invalid value %q: expected 'ignore', 'ask', or 'adopt'", bs)
+	}
+	return nil
+}
+
+// MarshalText encodes a RemoteRewrites to text.
+func (r RemoteRewrites) MarshalText() ([]byte, error) {
+	switch r {
+	case RemoteRewritesIgnore:
+		return []byte("ignore"), nil
+	case RemoteRewritesAsk:
+		return []byte("ask"), nil
+	case RemoteRewritesAdopt:
+		return []byte("adopt"), nil
+	default:
+		return nil, fmt.Errorf("invalid value: %d", int(r))
+	}
+}
+
+func (r RemoteRewrites) String() string {
+	switch r {
+	case RemoteRewritesIgnore:
+		return "ignore"
+	case RemoteRewritesAsk:
+		return "ask"
+	case RemoteRewritesAdopt:
+		return "adopt"
+	default:
+		return fmt.Sprintf("RemoteRewrites(%d)", int(r))
+	}
+}
+
+// remoteRewrite is a submitted branch
+// whose upstream branch was force-pushed.
+type remoteRewrite struct {
+	Branch   spice.LoadBranchItem
+	Upstream string   // e.g. "origin/feature"
+	OldHash  git.Hash // upstream before fetching
+	NewHash  git.Hash // upstream after fetching
+}
+
+// adoptRemoteRewrites fetches the upstream branches of submitted branches
+// and looks for ones that were force-pushed by someone else,
+// e.g. because they restacked the branch.
+//
+// A rewritten branch is reset to its upstream
+// if that won't lose any local changes:
+// the local branch must not have commits
+// that aren't in the rewritten upstream (by patch ID).
+//
+// It returns the names of branches that were reset.
+// These and their upstacks need to be restacked.
+//
+// Failures are logged and otherwise ignored.
+func (h *Handler) adoptRemoteRewrites(
+	ctx context.Context,
+	branches []spice.LoadBranchItem,
+	mode RemoteRewrites,
+) []string {
+	log := h.Log
+
+	currentBranch, err := h.Worktree.CurrentBranch(ctx)
+	if err != nil {
+		currentBranch = "" // detached head
+	}
+
+	// Branches checked out in other worktrees can't be reset from here.
+	otherWorktrees := make(map[string]string)
+	for branch, err := range h.Repository.LocalBranches(ctx, nil) {
+		if err != nil {
+			log.Warn("Could not list branches", "error", err)
+			return nil
+		}
+		if branch.Worktree != "" && branch.Worktree != h.Worktree.RootDir() {
+			otherWorktrees[branch.Name] = branch.Worktree
+		}
+	}
+
+	var adopted []remoteRewrite
+	for _, rw := range h.findRemoteRewrites(ctx, branches) {
+		b := rw.Branch
+		if !h.remoteRewriteSafe(ctx, rw) {
+			log.Warnf("%v: %v was force-pushed, but the local branch has changes not in it: leaving it as is", b.Name, rw.Upstream)
+			continue
+		}
+
+		if wt, ok := otherWorktrees[b.Name]; ok {
+			log.Warnf("%v: %v was force-pushed, but the branch is checked out in %v: leaving it as is", b.Name, rw.Upstream, wt)
+			continue
+		}
+
+		if mode == RemoteRewritesAsk {
+			var adopt bool
+			prompt := ui.NewConfirm().
+				WithTitle(fmt.Sprintf("Reset %v to %v?", b.Name, rw.Upstream)).
+				WithDescription(fmt.Sprintf("%v was force-pushed by someone else.", rw.Upstream)).
+				WithValue(&adopt)
+			if err := ui.Run(h.View, prompt); err != nil {
+				log.Warn("Skipping branch", "branch", b.Name, "error", err)
+				continue
+			}
+			if !adopt {
+				continue
+			}
+		}
+
+		if err := h.resetToRemoteRewrite(ctx, rw, b.Name == currentBranch); err != nil {
+			log.Warn("Could not adopt rewritten branch", "branch", b.Name, "error", err)
+			continue
+		}
+
+		log.Infof("%v: reset to %v, which was force-pushed by someone else", b.Name, rw.Upstream)
+		adopted = append(adopted, rw)
+	}
+	h.updateAdoptedBaseHashes(ctx, adopted)
+
+	names := make([]string, len(adopted))
+	for i, rw := range adopted {
+		names[i] = rw.Branch.Name
+	}
+	return names
+}
+
+// findRemoteRewrites fetches the upstream branches of the given branches
+// and returns those that were not fast-forwarded.
+//
+// Upstream branches that were deleted from the remote are skipped.
+func (h *Handler) findRemoteRewrites(
+	ctx context.Context,
+	branches []spice.LoadBranchItem,
+) []remoteRewrite {
+	var (
+		candidates        []remoteRewrite
+		upstreamsByRemote = make(map[string][]string) // remote => branch names
+	)
+	for _, b := range branches {
+		remote, upstreamBranch := cmp.Or(b.UpstreamRemote, h.Remote), b.UpstreamBranch
+		if upstreamBranch == "" {
+			// Branches that were pulled but not submitted
+			// only have an upstream in git-config.
+			upstream, err := h.Repository.BranchUpstream(ctx, b.Name)
+			if err != nil {
+				continue
+			}
+			var ok bool
+			upstreamBranch, ok = strings.CutPrefix(upstream, remote+"/")
+			if !ok {
+				continue
+			}
+		}
+
+		upstream := remote + "/" + upstreamBranch
+		oldHash, err := h.Repository.PeelToCommit(ctx, "refs/remotes/"+upstream)
+		if err != nil {
+			// Without a previous value, we can't tell if it was rewritten.
+			continue
+		}
+
+		candidates = append(candidates, remoteRewrite{
+			Branch:   b,
+			Upstream: upstream,
+			OldHash:  oldHash,
+		})
+		upstreamsByRemote[remote] = append(upstreamsByRemote[remote], upstreamBranch)
+	}
+
+	for remote, upstreams := range upstreamsByRemote {
+		// Fetching a branch that no longer exists
+		// would fail the fetch for all of them.
+		existing, err := h.existingRemoteBranches(ctx, remote, upstreams)
+		if err != nil {
+			h.Log.Warn("Could not list upstream branches", "remote", remote, "error", err)
+			return nil
+		}
+
+		var specs []git.Refspec
+		for _, name := range upstreams {
+			if _, ok := existing[name]; !ok {
+				h.Log.Debugf("%v/%v: deleted from remote, not checking for rewrites", remote, name)
+				continue
+			}
+			specs = append(specs, git.Refspec("+refs/heads/"+name+":refs/remotes/"+remote+"/"+name))
+		}
+		if len(specs) == 0 {
+			continue
+		}
+
+		if err := h.Repository.Fetch(ctx, git.FetchOptions{
+			Remote:   remote,
+			Refspecs: specs,
+		}); err != nil {
+			h.Log.Warn("Could not fetch upstream branches", "remote", remote, "error", err)
+			return nil
+		}
+	}
+
+	var rewrites []remoteRewrite
+	for _, rw := range candidates {
+		newHash, err := h.Repository.PeelToCommit(ctx, "refs/remotes/"+rw.Upstream)
+		if err != nil {
+			continue // deleted upstream
+		}
+		// Upstreams deleted from the remote weren't fetched,
+		// so they're unchanged here.
+		if newHash == rw.OldHash ||
+			newHash == rw.Branch.Head ||
+			h.Repository.IsAncestor(ctx, rw.OldHash, newHash) {
+			continue // not rewritten
+		}
+
+		rw.NewHash = newHash
+		rewrites = append(rewrites, rw)
+	}
+	return rewrites
+}
+
+// existingRemoteBranches reports which of the given branches
+// still exist in the remote.
+func (h *Handler) existingRemoteBranches(
+	ctx context.Context,
+	remote string,
+	names []string,
+) (map[string]struct{}, error) {
+	existing := make(map[string]struct{}, len(names))
+	refs := h.Repository.ListRemoteRefs(ctx, remote, &git.ListRemoteRefsOptions{
+		Heads:    true,
+		Patterns: names,
+	})
+	for ref, err := range refs {
+		if err != nil {
+			return nil, err
+		}
+		if name, ok := strings.CutPrefix(ref.Name, "refs/heads/"); ok {
+			existing[name] = struct{}{}
+		}
+	}
+	return existing, nil
+}
+
+// remoteRewriteSafe reports whether resetting a branch
+// to its rewritten upstream won't lose any local changes.
+func (h *Handler) remoteRewriteSafe(ctx context.Context, rw remoteRewrite) bool {
+	b := rw.Branch
+	if b.Head == rw.OldHash {
+		return true // no local changes
+	}
+
+	// If the branch was built on top of the old upstream,
+	// only the new commits need to be in the new upstream.
+	// Otherwise, all of the branch's changes do.
+	base := b.BaseHash
+	if h.Repository.IsAncestor(ctx, rw.OldHash, b.Head) {
+		base = rw.OldHash
+	}
+
+	applied, err := h.Repository.IsChangeApplied(ctx, rw.NewHash, base, b.Head)
+	if err != nil {
+		h.Log.Warn("Could not compare with rewritten upstream", "branch", b.Name, "error", err)
+		return false
+	}
+	return applied
+}
+
+// resetToRemoteRewrite resets a branch to its rewritten upstream.
+func (h *Handler) resetToRemoteRewrite(ctx context.Context, rw remoteRewrite, checkedOut bool) error {
+	if checkedOut {
+		// Keep uncommitted changes, if any.
+		if err := h.Worktree.Reset(ctx, rw.NewHash.String(), git.ResetOptions{
+			Mode:  git.ResetKeep,
+			Quiet: true,
+		}); err != nil {
+			return fmt.Errorf("reset: %w", err)
+		}
+		return nil
+	}
+
+	if err := h.Repository.SetRef(ctx, git.SetRefRequest{
+		Ref:     "refs/heads/" + rw.Branch.Name,
+		Hash:    rw.NewHash,
+		OldHash: rw.Branch.Head,
+		Reason:  "adopt " + rw.Upstream,
+	}); err != nil {
+		return fmt.Errorf("update branch: %w", err)
+	}
+	return nil
+}
+
+// updateAdoptedBaseHashes records where each adopted branch
+// forks from its base.
+//
+// The upstream was likely rebased onto a different version of the base,
+// so the recorded base hash is out of date.
+// This is done after all branches have been reset
+// so that bases that were adopted too are accounted for.
+func (h *Handler) updateAdoptedBaseHashes(ctx context.Context, adopted []remoteRewrite) {
+	if len(adopted) == 0 {
+		return
+	}
+
+	tx := h.Store.BeginBranchTx()
+	for _, rw := range adopted {
+		b := rw.Branch
+		baseHash, err := h.Repository.MergeBase(ctx, b.BaseRef(), rw.NewHash.String())
+		if err != nil {
+			h.Log.Warn("Could not find merge base", "branch", b.Name, "error", err)
+			continue
+		}
+
+		if err := tx.Upsert(ctx, state.UpsertRequest{
+			Name:     b.Name,
+			BaseHash: baseHash,
+		}); err != nil {
+			h.Log.Warn("Could not update base hash", "branch", b.Name, "error", err)
+		}
+	}
+
+	if err := tx.Commit(ctx, "adopt rewritten upstream branches"); err != nil {
+		h.Log.Warn("Could not update state", "error", err)
+	}
+}
@@ -0,0 +1,44 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteRewrites_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		give string
+		want RemoteRewrites
+	}{
+		{"ignore", RemoteRewritesIgnore},
+		{"ask", RemoteRewritesAsk},
+		{"adopt", RemoteRewritesAdopt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			var got RemoteRewrites
+			require.NoError(t, got.UnmarshalText([]byte(tt.give)))
+			assert.Equal(t, tt.want, got)
+
+			bs, err := got.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tt.give, string(bs))
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		var r RemoteRewrites
+		err := r.UnmarshalText([]byte("reset"))
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected 'ignore', 'ask', or 'adopt'")
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := RemoteRewrites(42).MarshalText()
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value: 42")
+	})
+}
@@ -0,0 +1,280 @@
+package sync
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/graph"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+var _timeNow = time.Now
+
+func init() {
+	now := os.Getenv("GIT_SPICE_NOW")
+	if now != "" {
+		t, err := time.Parse(time.RFC3339, now)
+		if err == nil {
+			_timeNow = func() time.Time {
+				return t
+			}
+		}
+	}
+}
+
+// StaleBranches specifies how to handle stale branches during sync.
+//
+// A branch is stale if it has no commits that aren't already in trunk,
+// it doesn't have an open Change Request,
+// and its head commit is older than a configured number of days.
+type StaleBranches int
+
+const (
+	// StaleBranchesIgnore does not look for stale branches.
+	// This is the default.
+	StaleBranchesIgnore StaleBranches = iota
+
+	// StaleBranchesWarn reports stale branches without changing them.
+	StaleBranchesWarn
+
+	// StaleBranchesArchive archives stale branches.
+	StaleBranchesArchive
+
+	// StaleBranchesDelete prompts to delete stale branches.
+	// Branches are never deleted without a prompt.
+	StaleBranchesDelete
+)
+
+var (
+	_ encoding.TextUnmarshaler = (*StaleBranches)(nil)
+	_ encoding.TextMarshaler   = (*StaleBranches)(nil)
+)
+
+// UnmarshalText decodes a StaleBranches from text.
+// It supports "ignore", "warn", "archive", and "delete" values.
+func (s *StaleBranches) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "ignore":
+		*s = StaleBranchesIgnore
+	case "warn":
+		*s = StaleBranchesWarn
+	case "archive":
+		*s = StaleBranchesArchive
+	case "delete":
+		*s = StaleBranchesDelete
+	default:
+		return fmt.Errorf("invalid value %q: expected 'ignore', 'warn', 'archive', or 'delete'", bs)
+	}
+	return nil
+}
+
+// MarshalText encodes a StaleBranches to text.
+func (s StaleBranches) MarshalText() ([]byte, error) {
+	switch s {
+	case StaleBranchesIgnore, StaleBranchesWarn, StaleBranchesArchive, StaleBranchesDelete:
+		return []byte(s.String()), nil
+	default:
+		return nil, fmt.Errorf("invalid value: %d", int(s))
+	}
+}
+
+func (s StaleBranches) String() string {
+	switch s {
+	case StaleBranchesIgnore:
+		return "ignore"
+	case StaleBranchesWarn:
+		return "warn"
+	case StaleBranchesArchive:
+		return "archive"
+	case StaleBranchesDelete:
+		return "delete"
+	default:
+		return fmt.Sprintf("StaleBranches(%d)", int(s))
+	}
+}
+
+// cleanupStaleBranches finds stale branches among the given branches
+// and handles them according to mode.
+//
+// Failures are logged and otherwise ignored
+// as they should not fail the sync operation.
+func (h *Handler) cleanupStaleBranches(
+	ctx context.Context,
+	branches []spice.LoadBranchItem,
+	trunkHash git.Hash,
+	mode StaleBranches,
+	afterDays int,
+) error {
+	if mode == StaleBranchesIgnore {
+		return nil
+	}
+
+	if mode != StaleBranchesDelete {
+		// Archived branches were already cleaned up.
+		branches = slices.DeleteFunc(slices.Clone(branches), func(b spice.LoadBranchItem) bool {
+			return b.Archived
+		})
+	}
+
+	stale := h.findStaleBranches(ctx, branches, trunkHash, time.Duration(max(afterDays, 0))*24*time.Hour)
+	if len(stale) == 0 {
+		return nil
+	}
+
+	switch mode {
+	case StaleBranchesWarn:
+		for _, b := range stale {
+			h.Log.Warnf("%v: stale: no new commits and no open change request for %d days", b.Name, afterDays)
+		}
+		h.Log.Warnf("Use '%[1]s branch archive' or '%[1]s branch delete' to clean them up.", cli.Name())
+
+	case StaleBranchesArchive:
+		// ArchiveBranch requires branches above to be archived first,
+		// so archive from the top of each stack down.
+		names := make([]string, len(stale))
+		baseOf := make(map[string]string, len(stale))
+		for i, b := range stale {
+			names[i] = b.Name
+			baseOf[b.Name] = b.Base
+		}
+		names = graph.Toposort(names, func(name string) (string, bool) {
+			_, ok := baseOf[baseOf[name]]
+			return baseOf[name], ok
+		})
+		slices.Reverse(names)
+
+		for _, name := range names {
+			if err := h.Service.ArchiveBranch(ctx, name); err != nil {
+				var activeErr *spice.ActiveUpstackError
+				if errors.As(err, &activeErr) {
+					h.Log.Infof("%v: stale, but not archived because it has active branches above it: %v",
+						name, strings.Join(activeErr.Upstack, ", "))
+				} else {
+					h.Log.Warn("Could not archive stale branch", "branch", name, "error", err)
+				}
+				continue
+			}
+			h.Log.Infof("%v: archived stale branch", name)
+		}
+
+	case StaleBranchesDelete:
+		names := make([]string, len(stale))
+		for i, b := range stale {
+			names[i] = b.Name
+		}
+
+		desc := fmt.Sprintf("No new commits and no open change request for %d days: %v",
+			afterDays, strings.Join(names, ", "))
+		if !ui.Interactive(h.View) {
+			h.Log.Warnf("Not deleting stale branches without a prompt. %v", desc)
+			return nil
+		}
+
+		var shouldDelete bool
+		prompt := ui.NewConfirm().
+			WithTitle(fmt.Sprintf("Delete %d stale branch(es)?", len(stale))).
+			WithDescription(desc).
+			WithValue(&shouldDelete)
+		if err := ui.Run(h.View, prompt); err != nil {
+			h.Log.Warn("Not deleting stale branches", "error", err)
+			return nil
+		}
+		if !shouldDelete {
+			return nil
+		}
+
+		deletions := make([]branchDeletion, len(stale))
+		for i, b := range stale {
+			deletions[i] = branchDeletion{
+				BranchName:     b.Name,
+				UpstreamName:   b.UpstreamBranch,
+				UpstreamRemote: b.UpstreamRemote,
+			}
+		}
+		if err := h.deleteBranches(ctx, deletions); err != nil {
+			return fmt.Errorf("delete stale branches: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findStaleBranches returns the branches that have no commits
+// that aren't reachable from trunk,
+// no open Change Request,
+// and a head commit older than the given age.
+func (h *Handler) findStaleBranches(
+	ctx context.Context,
+	branches []spice.LoadBranchItem,
+	trunkHash git.Hash,
+	age time.Duration,
+) []spice.LoadBranchItem {
+	cutoff := _timeNow().Add(-age)
+
+	var (
+		stale     []spice.LoadBranchItem
+		changeIdx []int // indexes in stale with a change request
+	)
+	for _, b := range branches {
+		if !h.Repository.IsAncestor(ctx, b.Head, trunkHash) {
+			continue // has commits ahead of trunk
+		}
+
+		commit, err := h.Repository.ReadCommit(ctx, b.Head.String())
+		if err != nil {
+			h.Log.Warn("Could not read branch head", "branch", b.Name, "error", err)
+			continue
+		}
+		if commit.Committer.Time.After(cutoff) {
+			continue
+		}
+
+		if b.Change != nil {
+			if h.RemoteRepository == nil {
+				// Can't verify that the change request isn't open.
+				h.Log.Debug("Skipping branch with change request on unsupported remote", "branch", b.Name)
+				continue
+			}
+			changeIdx = append(changeIdx, len(stale))
+		}
+		stale = append(stale, b)
+	}
+
+	if len(changeIdx) > 0 {
+		changeIDs := make([]forge.ChangeID, len(changeIdx))
+		for i, idx := range changeIdx {
+			changeIDs[i] = stale[idx].Change.ChangeID()
+		}
+
+		states, err := h.RemoteRepository.ChangesStates(ctx, changeIDs)
+		if err != nil {
+			h.Log.Warn("Could not query change request states. Skipping branches with change requests.", "error", err)
+			states = nil
+		}
+
+		open := make(map[int]bool, len(changeIdx))
+		for i, idx := range changeIdx {
+			open[idx] = i >= len(states) || states[i] == forge.ChangeOpen
+		}
+
+		var keep []spice.LoadBranchItem
+		for idx, b := range stale {
+			if !open[idx] {
+				keep = append(keep, b)
+			}
+		}
+		stale = keep
+	}
+
+	return stale
+}
@@ -0,0 +1,63 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleBranches_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		give string
+		want StaleBranches
+	}{
+		{"ignore", StaleBranchesIgnore},
+		{"warn", StaleBranchesWarn},
+		{"archive", StaleBranchesArchive},
+		{"delete", StaleBranchesDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			var got StaleBranches
+			require.NoError(t, got.UnmarshalText([]byte(tt.give)))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		var s StaleBranches
+		err := s.UnmarshalText([]byte("invalid"))
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value")
+		assert.ErrorContains(t, err, "expected 'ignore', 'warn', 'archive', or 'delete'")
+	})
+}
+
+func TestStaleBranches_MarshalText(t *testing.T) {
+	tests := []struct {
+		give StaleBranches
+		want string
+	}{
+		{StaleBranchesIgnore, "ignore"},
+		{StaleBranchesWarn, "warn"},
+		{StaleBranchesArchive, "archive"},
+		{StaleBranchesDelete, "delete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := tt.give.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		s := StaleBranches(42)
+		_, err := s.MarshalText()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value: 42")
+	})
+}
@@ -0,0 +1,142 @@
+package sync
+
+import (
+	"context"
+	"slices"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// syncUpstreamBases fetches the remote branches
+// that tracked branches are based on (see [spice.Service.SetUpstreamBase]).
+// Branches whose upstream base was merged or deleted
+// are moved onto trunk.
+//
+// It returns the names of branches whose base changed as a result,
+// in sorted order.
+// These need to be restacked.
+//
+// Failures are logged and otherwise ignored.
+func (h *Handler) syncUpstreamBases(
+	ctx context.Context,
+	branches []spice.LoadBranchItem,
+	trunkHash git.Hash,
+) []string {
+	log := h.Log
+	trunk := h.Store.Trunk()
+
+	var updated []string
+	for _, b := range branches {
+		ub := b.UpstreamBase
+		if ub == nil {
+			continue
+		}
+
+		startHash, err := h.Repository.PeelToCommit(ctx, ub.Ref())
+		if err != nil {
+			startHash = git.ZeroHash
+		}
+
+		exists, err := h.remoteBranchExists(ctx, ub)
+		if err != nil {
+			log.Warn("Could not check upstream base", "branch", b.Name, "upstreamBase", ub, "error", err)
+			continue
+		}
+
+		merged := !exists
+		if exists {
+			if err := h.Repository.Fetch(ctx, git.FetchOptions{
+				Remote: ub.Remote,
+				Refspecs: []git.Refspec{
+					git.Refspec("+refs/heads/" + ub.Branch + ":" + ub.Ref()),
+				},
+			}); err != nil {
+				log.Warn("Could not fetch upstream base", "branch", b.Name, "upstreamBase", ub, "error", err)
+				continue
+			}
+
+			endHash, err := h.Repository.PeelToCommit(ctx, ub.Ref())
+			if err != nil {
+				log.Warn("Could not resolve upstream base", "branch", b.Name, "upstreamBase", ub, "error", err)
+				continue
+			}
+
+			merged = h.Repository.IsAncestor(ctx, endHash, trunkHash) ||
+				h.upstreamBaseChangeMerged(ctx, ub)
+			if !merged && endHash != startHash {
+				log.Infof("%v: upstream base %v was updated", b.Name, ub)
+				updated = append(updated, b.Name)
+				continue
+			}
+		}
+
+		if !merged {
+			continue
+		}
+
+		if err := h.Service.ClearUpstreamBase(ctx, b.Name); err != nil {
+			log.Warn("Could not move branch onto trunk", "branch", b.Name, "error", err)
+			continue
+		}
+
+		if exists {
+			log.Infof("%v: upstream base %v was merged, moving onto %v", b.Name, ub, trunk)
+		} else {
+			log.Infof("%v: upstream base %v was deleted, moving onto %v", b.Name, ub, trunk)
+		}
+		updated = append(updated, b.Name)
+	}
+
+	slices.Sort(updated)
+	return updated
+}
+
+// remoteBranchExists reports whether the upstream base
+// still exists in its remote.
+func (h *Handler) remoteBranchExists(ctx context.Context, ub *state.UpstreamBase) (bool, error) {
+	refs := h.Repository.ListRemoteRefs(ctx, ub.Remote, &git.ListRemoteRefsOptions{
+		Heads:    true,
+		Patterns: []string{ub.Branch},
+	})
+	for ref, err := range refs {
+		if err != nil {
+			return false, err
+		}
+		if ref.Name == "refs/heads/"+ub.Branch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// upstreamBaseChangeMerged reports whether the forge knows about
+// a merged change request for the upstream base.
+// This catches squash merges where the remote branch was kept around.
+func (h *Handler) upstreamBaseChangeMerged(ctx context.Context, ub *state.UpstreamBase) bool {
+	if h.RemoteRepository == nil || ub.Remote != h.Remote {
+		return false
+	}
+
+	changes, err := h.RemoteRepository.FindChangesByBranch(ctx, ub.Branch, forge.FindChangesOptions{
+		Limit: 10,
+	})
+	if err != nil {
+		h.Log.Warn("Failed to list changes", "branch", ub.Branch, "error", err)
+		return false
+	}
+
+	// If there's an open change for the branch, it's still in use.
+	merged := false
+	for _, c := range changes {
+		switch c.State {
+		case forge.ChangeOpen:
+			return false
+		case forge.ChangeMerged:
+			merged = true
+		}
+	}
+	return merged
+}
@@ -2,8 +2,10 @@ package track
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/git"
@@ -21,6 +23,11 @@ type BranchRequest struct {
 	// Base is the name of the base branch this branch merges into.
 	// If not provided, it will be guessed based on other tracked branches.
 	Base string // optional
+
+	// Change is a reference to an existing change request
+	// to associate with the branch, e.g. a number or URL.
+	// The change's head must match the branch.
+	Change string // optional
 }
 
 // TrackBranch tracks a branch defined in the Git repository.
@@ -50,14 +57,28 @@ func (h *Handler) TrackBranch(ctx context.Context, req *BranchRequest) error {
 		return fmt.Errorf("peel to commit: %w", err)
 	}
 
-	msg := fmt.Sprintf("track %v with base %v", req.Branch, req.Base)
-	tx := store.BeginBranchTx()
-	if err := tx.Upsert(ctx, state.UpsertRequest{
+	upsert := state.UpsertRequest{
 		Name:     req.Branch,
 		Base:     req.Base,
 		BaseHash: baseHash,
-	},
-	); err != nil {
+	}
+	if req.Change != "" {
+		change, err := h.resolveChange(ctx, req.Branch, req.Change)
+		if err != nil {
+			return err
+		}
+		upsert.ChangeForge = change.forgeID
+		upsert.ChangeMetadata = change.metadata
+		if change.upstreamBranch != "" {
+			upsert.UpstreamBranch = &change.upstreamBranch
+			upsert.UpstreamRemote = &change.upstreamRemote
+			upsert.UpstreamHash = &change.headHash
+		}
+	}
+
+	msg := fmt.Sprintf("track %v with base %v", req.Branch, req.Base)
+	tx := store.BeginBranchTx()
+	if err := tx.Upsert(ctx, upsert); err != nil {
 		return fmt.Errorf("%s: %w", msg, err)
 	}
 	if err := tx.Commit(ctx, msg); err != nil {
@@ -79,6 +100,99 @@ func (h *Handler) TrackBranch(ctx context.Context, req *BranchRequest) error {
 	return nil
 }
 
+type resolvedChange struct {
+	forgeID  string
+	metadata json.RawMessage
+	headHash git.Hash
+
+	// upstreamBranch is the name of the CR's head branch.
+	// It's empty if the forge did not report it.
+	upstreamBranch string
+
+	// upstreamRemote is the remote that upstreamBranch lives in,
+	// or empty if it's the default remote.
+	upstreamRemote string
+}
+
+// resolveChange looks up the change request referenced by ref
+// and verifies that its head matches the given branch.
+func (h *Handler) resolveChange(ctx context.Context, branch, ref string) (*resolvedChange, error) {
+	must.NotBeNilf(h.OpenRemoteRepository, "OpenRemoteRepository is required to associate a change")
+
+	remoteRepo, err := h.OpenRemoteRepository(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open remote repository: %w", err)
+	}
+	f := remoteRepo.Forge()
+
+	id, err := f.ParseChangeID(ref)
+	if err != nil {
+		return nil, fmt.Errorf("parse change: %w", err)
+	}
+
+	change, err := remoteRepo.FindChangeByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("find change %v: %w", f.FormatChangeID(id), err)
+	}
+
+	head, err := h.Repository.PeelToCommit(ctx, branch)
+	if err != nil {
+		return nil, fmt.Errorf("peel to commit: %w", err)
+	}
+
+	if change.HeadHash != head {
+		h.Log.Errorf("%v: head of CR %v (%v) does not match the branch (%v)",
+			branch, f.FormatChangeID(change.ID), change.HeadHash.Short(), head.Short())
+		h.Log.Errorf("%v: push or pull the branch so that they match, and try again", branch)
+		return nil, errors.New("change does not match branch")
+	}
+
+	md, err := remoteRepo.NewChangeMetadata(ctx, change.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get change metadata: %w", err)
+	}
+
+	metadata, err := f.MarshalChangeMetadata(md)
+	if err != nil {
+		return nil, fmt.Errorf("marshal change metadata: %w", err)
+	}
+
+	h.Log.Infof("%v: associating with CR %v: %v", branch, f.FormatChangeID(change.ID), change.URL)
+	return &resolvedChange{
+		forgeID:        md.ForgeID(),
+		metadata:       metadata,
+		headHash:       head,
+		upstreamBranch: change.HeadName,
+		upstreamRemote: h.headRemote(ctx, branch, change.HeadName),
+	}, nil
+}
+
+// headRemote reports the remote that a CR's head branch lives in
+// if it's not the default remote, e.g. a fork.
+//
+// The branch's configured upstream is used to tell:
+// it must point to the CR's head branch in another remote.
+func (h *Handler) headRemote(ctx context.Context, branch, headName string) string {
+	if headName == "" {
+		return ""
+	}
+
+	upstream, err := h.Repository.BranchUpstream(ctx, branch)
+	if err != nil {
+		return ""
+	}
+
+	remote, ok := strings.CutSuffix(upstream, "/"+headName)
+	if !ok {
+		return ""
+	}
+
+	if defaultRemote, err := h.Store.Remote(); err == nil && remote == defaultRemote {
+		return ""
+	}
+	return remote
+}
+
 func guessBaseBranch(
 	ctx context.Context,
 	store Store,
@@ -3,12 +3,15 @@ package track
 import (
 	"bytes"
 	"cmp"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/git/gittest"
 	"go.abhg.dev/gs/internal/silog"
@@ -234,6 +237,196 @@ func TestHandler_TrackBranch(t *testing.T) {
 	})
 }
 
+func TestHandler_TrackBranch_change(t *testing.T) {
+	t.Run("Associates", func(t *testing.T) {
+		log := silog.Nop()
+		store := statetest.NewMemoryStore(t, "main", "origin", log)
+
+		remoteRepo := forgetest.NewFakeRepository()
+		remoteRepo.AddChange(forgetest.FakeChange{
+			Number:   42,
+			Subject:  "Add feature",
+			Base:     "main",
+			Head:     "renamed-feature",
+			HeadHash: "feature123",
+		})
+
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockGitRepository(ctrl)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "main").
+			Return(git.Hash("main123"), nil)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "feature").
+			Return(git.Hash("feature123"), nil)
+		mockRepo.EXPECT().
+			BranchUpstream(gomock.Any(), "feature").
+			Return("", git.ErrNotExist)
+
+		mockService := NewMockService(ctrl)
+		mockService.EXPECT().
+			VerifyRestacked(gomock.Any(), "feature").
+			Return(nil)
+
+		handler := &Handler{
+			Log:        log,
+			Repository: mockRepo,
+			Store:      store,
+			Service:    mockService,
+			View:       &ui.FileView{W: t.Output()},
+			OpenRemoteRepository: func(context.Context) (forge.Repository, error) {
+				return remoteRepo, nil
+			},
+		}
+
+		err := handler.TrackBranch(t.Context(), &BranchRequest{
+			Branch: "feature",
+			Base:   "main",
+			Change: "https://forge.example.com/changes/42",
+		})
+		require.NoError(t, err)
+
+		branch, err := store.LookupBranch(t.Context(), "feature")
+		require.NoError(t, err)
+		assert.Equal(t, forgetest.FakeForgeID, branch.ChangeForge)
+		assert.JSONEq(t, `{"number": 42}`, string(branch.ChangeMetadata))
+		assert.Equal(t, "renamed-feature", branch.UpstreamBranch)
+		assert.Empty(t, branch.UpstreamRemote)
+		assert.Equal(t, git.Hash("feature123"), branch.UpstreamHash)
+	})
+
+	t.Run("Fork", func(t *testing.T) {
+		log := silog.Nop()
+		store := statetest.NewMemoryStore(t, "main", "origin", log)
+
+		remoteRepo := forgetest.NewFakeRepository()
+		remoteRepo.AddChange(forgetest.FakeChange{
+			Number:   42,
+			Subject:  "Add feature",
+			Base:     "main",
+			Head:     "feature",
+			HeadHash: "feature123",
+		})
+
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockGitRepository(ctrl)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "main").
+			Return(git.Hash("main123"), nil)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "feature").
+			Return(git.Hash("feature123"), nil)
+		mockRepo.EXPECT().
+			BranchUpstream(gomock.Any(), "feature").
+			Return("fork/feature", nil)
+
+		mockService := NewMockService(ctrl)
+		mockService.EXPECT().
+			VerifyRestacked(gomock.Any(), "feature").
+			Return(nil)
+
+		handler := &Handler{
+			Log:        log,
+			Repository: mockRepo,
+			Store:      store,
+			Service:    mockService,
+			View:       &ui.FileView{W: t.Output()},
+			OpenRemoteRepository: func(context.Context) (forge.Repository, error) {
+				return remoteRepo, nil
+			},
+		}
+
+		err := handler.TrackBranch(t.Context(), &BranchRequest{
+			Branch: "feature",
+			Base:   "main",
+			Change: "#42",
+		})
+		require.NoError(t, err)
+
+		branch, err := store.LookupBranch(t.Context(), "feature")
+		require.NoError(t, err)
+		assert.Equal(t, "feature", branch.UpstreamBranch)
+		assert.Equal(t, "fork", branch.UpstreamRemote)
+	})
+
+	t.Run("HeadMismatch", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, nil)
+		store := statetest.NewMemoryStore(t, "main", "", log)
+
+		remoteRepo := forgetest.NewFakeRepository()
+		remoteRepo.AddChange(forgetest.FakeChange{
+			Number:   42,
+			Subject:  "Add feature",
+			Base:     "main",
+			Head:     "feature",
+			HeadHash: "other456",
+		})
+
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockGitRepository(ctrl)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "main").
+			Return(git.Hash("main123"), nil)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "feature").
+			Return(git.Hash("feature123"), nil)
+
+		handler := &Handler{
+			Log:        log,
+			Repository: mockRepo,
+			Store:      store,
+			Service:    NewMockService(ctrl),
+			View:       &ui.FileView{W: t.Output()},
+			OpenRemoteRepository: func(context.Context) (forge.Repository, error) {
+				return remoteRepo, nil
+			},
+		}
+
+		err := handler.TrackBranch(t.Context(), &BranchRequest{
+			Branch: "feature",
+			Base:   "main",
+			Change: "#42",
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "change does not match branch")
+		assert.Contains(t, logBuffer.String(), "does not match the branch")
+
+		// The branch must not be tracked.
+		_, err = store.LookupBranch(t.Context(), "feature")
+		assert.ErrorIs(t, err, state.ErrNotExist)
+	})
+
+	t.Run("InvalidChange", func(t *testing.T) {
+		log := silog.Nop()
+		store := statetest.NewMemoryStore(t, "main", "", log)
+
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockGitRepository(ctrl)
+		mockRepo.EXPECT().
+			PeelToCommit(gomock.Any(), "main").
+			Return(git.Hash("main123"), nil)
+
+		handler := &Handler{
+			Log:        log,
+			Repository: mockRepo,
+			Store:      store,
+			Service:    NewMockService(ctrl),
+			View:       &ui.FileView{W: t.Output()},
+			OpenRemoteRepository: func(context.Context) (forge.Repository, error) {
+				return forgetest.NewFakeRepository(), nil
+			},
+		}
+
+		err := handler.TrackBranch(t.Context(), &BranchRequest{
+			Branch: "feature",
+			Base:   "main",
+			Change: "not-a-change",
+		})
+		assert.ErrorContains(t, err, "parse change")
+	})
+}
+
 func TestGuessBaseBranch(t *testing.T) {
 	type trackedBranch struct {
 		name string
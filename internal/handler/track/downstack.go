@@ -410,19 +410,28 @@ func (v *downstackDiscoveryView) SelectBaseBranch(
 		}
 	}
 
-	var selected *string
-	prompt := ui.NewSelect[*string]().
+	options := make([]ui.SelectOption[string], 0, len(candidateBranches)+1)
+	for _, candidate := range candidateBranches {
+		options = append(options, ui.SelectOption[string]{
+			Label: candidate,
+			Value: candidate,
+		})
+	}
+	// An empty value means skip.
+	options = append(options, ui.SelectOption[string]{
+		Label:        "None of these",
+		PaddingAbove: 1,
+	})
+
+	var selected string
+	prompt := ui.NewFuzzyList[string]().
 		WithTitle(fmt.Sprintf("Track %v with base", branchName)).
 		WithDescription(description.String()).
 		WithValue(&selected).
-		With(ui.OptionalComparableOptions("None of these", &candidateBranches[0], candidateBranches...))
+		WithOptions(options...)
 	if err := ui.Run(v.View, prompt); err != nil {
 		return "", fmt.Errorf("prompt for base branch: %w", err)
 	}
 
-	if selected == nil {
-		return "", nil
-	}
-
-	return *selected, nil
+	return selected, nil
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"iter"
 
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/spice"
@@ -19,6 +20,7 @@ type GitRepository interface {
 	PeelToCommit(ctx context.Context, ref string) (git.Hash, error)
 	ListCommits(ctx context.Context, commits git.CommitRange) iter.Seq2[git.Hash, error]
 	LocalBranches(ctx context.Context, opts *git.LocalBranchesOptions) iter.Seq2[git.LocalBranch, error]
+	BranchUpstream(ctx context.Context, branch string) (string, error)
 }
 
 var _ GitRepository = (*git.Repository)(nil)
@@ -28,6 +30,9 @@ type Store interface {
 	// Trunk reports the name of the trunk branch.
 	Trunk() string
 
+	// Remote reports the name of the default remote.
+	Remote() (string, error)
+
 	// ListBranches lists all tracked branches.
 	ListBranches(ctx context.Context) iter.Seq2[string, error]
 
@@ -54,4 +59,11 @@ type Handler struct {
 	Repository GitRepository // required
 	Store      Store         // required
 	Service    Service       // required
+
+	// OpenRemoteRepository opens the forge repository
+	// for the repository's remote.
+	//
+	// This is required only to associate existing change requests
+	// with BranchRequest.Change.
+	OpenRemoteRepository func(context.Context) (forge.Repository, error)
 }
@@ -42,6 +42,45 @@ func (m *MockGitRepository) EXPECT() *MockGitRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BranchUpstream mocks base method.
+func (m *MockGitRepository) BranchUpstream(ctx context.Context, branch string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchUpstream", ctx, branch)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchUpstream indicates an expected call of BranchUpstream.
+func (mr *MockGitRepositoryMockRecorder) BranchUpstream(ctx, branch any) *MockGitRepositoryBranchUpstreamCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchUpstream", reflect.TypeOf((*MockGitRepository)(nil).BranchUpstream), ctx, branch)
+	return &MockGitRepositoryBranchUpstreamCall{Call: call}
+}
+
+// MockGitRepositoryBranchUpstreamCall wrap *gomock.Call
+type MockGitRepositoryBranchUpstreamCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGitRepositoryBranchUpstreamCall) Return(arg0 string, arg1 error) *MockGitRepositoryBranchUpstreamCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGitRepositoryBranchUpstreamCall) Do(f func(context.Context, string) (string, error)) *MockGitRepositoryBranchUpstreamCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGitRepositoryBranchUpstreamCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockGitRepositoryBranchUpstreamCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListCommits mocks base method.
 func (m *MockGitRepository) ListCommits(ctx context.Context, commits git.CommitRange) iter.Seq2[git.Hash, error] {
 	m.ctrl.T.Helper()
@@ -0,0 +1,55 @@
+// Package httplog logs and times HTTP requests made by forge clients.
+package httplog
+
+import (
+	"net/http"
+	"time"
+
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/timing"
+)
+
+// transport wraps an HTTP transport
+// to log and time every request.
+type transport struct {
+	t   http.RoundTripper // required
+	log *silog.Logger
+}
+
+var _ http.RoundTripper = (*transport)(nil)
+
+// WrapTransport wraps an HTTP transport
+// so that it logs every request it makes
+// with its method, URL, status, and duration.
+//
+// Requests are logged only if the logger is at [silog.LevelTrace].
+// Request and response headers and bodies are never logged.
+//
+// The duration of every request is also recorded as [timing.Forge]
+// with the [timing.Recorder] in the request's context, if any.
+func WrapTransport(t http.RoundTripper, log *silog.Logger) http.RoundTripper {
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	return &transport{t: t, log: log}
+}
+
+// RoundTrip handles a single HTTP round trip.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.t.RoundTrip(req)
+	duration := time.Since(start)
+
+	timing.Record(req.Context(), timing.Forge, duration)
+	if t.log.Level() > silog.LevelTrace {
+		return res, err
+	}
+
+	msg := req.Method + " " + req.URL.Redacted()
+	if err != nil {
+		t.log.Log(silog.LevelTrace, msg, "duration", duration, "error", err)
+	} else {
+		t.log.Log(silog.LevelTrace, msg, "status", res.StatusCode, "duration", duration)
+	}
+	return res, err
+}
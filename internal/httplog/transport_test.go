@@ -0,0 +1,79 @@
+package httplog_test
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/httplog"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/timing"
+)
+
+func TestWrapTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	t.Run("Trace", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, &silog.Options{Level: silog.LevelTrace})
+		client := &http.Client{Transport: httplog.WrapTransport(srv.Client().Transport, log)}
+
+		res, err := client.Get(srv.URL + "/foo?bar=baz")
+		require.NoError(t, err)
+		_ = res.Body.Close()
+
+		assert.Regexp(t,
+			`^TRC GET `+srv.URL+`/foo\?bar=baz  status=418 duration=\S+\n$`,
+			logBuffer.String())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		require.NoError(t, ln.Close())
+
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, &silog.Options{Level: silog.LevelTrace})
+		client := &http.Client{Transport: httplog.WrapTransport(nil, log)}
+
+		_, err = client.Get("http://" + addr)
+		require.Error(t, err)
+		assert.Regexp(t, `^TRC GET http://`+addr+`  duration=\S+ error=.*connection refused`, logBuffer.String())
+	})
+
+	t.Run("Debug", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, &silog.Options{Level: silog.LevelDebug})
+		client := &http.Client{Transport: httplog.WrapTransport(srv.Client().Transport, log)}
+
+		res, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+
+		assert.Empty(t, logBuffer.String())
+	})
+}
+
+func TestWrapTransport_timing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+
+	rec := timing.NewRecorder()
+	client := &http.Client{Transport: httplog.WrapTransport(srv.Client().Transport, nil)}
+
+	req, err := http.NewRequestWithContext(timing.WithRecorder(t.Context(), rec), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Equal(t, 1, rec.Stat(timing.Forge).Count)
+}
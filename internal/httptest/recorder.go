@@ -3,8 +3,11 @@
 package httptest
 
 import (
+	"bytes"
+	"io"
 	"maps"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -22,6 +25,12 @@ type TransportRecorderOptions struct {
 	Update func() bool
 
 	Matcher func(*http.Request, cassette.Request) bool
+
+	// Sanitizers remove sensitive values from fixtures when recording.
+	//
+	// When replaying, they're applied to requests before matching
+	// so that tests may send the original values.
+	Sanitizers []Sanitizer
 }
 
 // NewTransportRecorder builds a new HTTP request recorder/replayer
@@ -68,6 +77,7 @@ func NewTransportRecorder(
 				delete(i.Response.Headers, k)
 			}
 
+			applySanitizers(i, opts.Sanitizers)
 			return nil
 		}
 	}
@@ -76,6 +86,9 @@ func NewTransportRecorder(
 	if opts.Matcher != nil {
 		matcher = opts.Matcher
 	}
+	if mode == recorder.ModeReplayOnly && len(opts.Sanitizers) > 0 {
+		matcher = sanitizingMatcher(t, matcher, opts.Sanitizers)
+	}
 
 	rec, err := recorder.New(filepath.Join("testdata", "fixtures", name),
 		recorder.WithMode(mode),
@@ -91,3 +104,33 @@ func NewTransportRecorder(
 
 	return rec
 }
+
+// sanitizingMatcher wraps a matcher to match a sanitized copy of the request
+// against recorded requests, which were sanitized when recorded.
+func sanitizingMatcher(
+	t testing.TB,
+	match func(*http.Request, cassette.Request) bool,
+	sanitizers []Sanitizer,
+) func(*http.Request, cassette.Request) bool {
+	return func(r *http.Request, i cassette.Request) bool {
+		sanitized := r.Clone(r.Context())
+
+		u, err := url.Parse(sanitizeText(r.URL.String(), sanitizers))
+		require.NoError(t, err, "sanitized URL is invalid")
+		sanitized.URL = u
+		sanitized.Host = sanitizeText(r.Host, sanitizers)
+
+		if r.Body != nil && r.Body != http.NoBody {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.NoError(t, r.Body.Close())
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			sanitizedBody := sanitizeText(string(body), sanitizers)
+			sanitized.Body = io.NopCloser(strings.NewReader(sanitizedBody))
+			sanitized.ContentLength = int64(len(sanitizedBody))
+		}
+
+		return match(sanitized, i)
+	}
+}
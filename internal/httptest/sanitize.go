@@ -0,0 +1,168 @@
+package httptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/dnaeon/go-vcr.v4/pkg/cassette"
+)
+
+// Sanitizer rewrites text recorded in fixtures
+// to remove sensitive values, e.g. owner and repository names or tokens.
+//
+// Sanitizers are applied to request URLs, forms, and bodies,
+// and to response bodies.
+// Response bodies that hold JSON are decoded first
+// so that sanitizers see strings with JSON escapes resolved.
+type Sanitizer func(string) string
+
+// ReplaceSanitizer builds a Sanitizer that replaces all occurrences
+// of from with to.
+//
+// Occurrences of from that are URL-encoded (as a query or path component)
+// or escaped as inside a JSON string are also replaced,
+// with to encoded the same way.
+func ReplaceSanitizer(from, to string) Sanitizer {
+	pairs := []string{from, to}
+	for _, encode := range []func(string) string{
+		url.QueryEscape,
+		url.PathEscape,
+		jsonEscape,
+	} {
+		if encFrom := encode(from); encFrom != from {
+			pairs = append(pairs, encFrom, encode(to))
+		}
+	}
+
+	// strings.Replacer picks the earliest, longest match at each position,
+	// so an encoded form is never half-replaced by a shorter one.
+	replacer := strings.NewReplacer(pairs...)
+	return replacer.Replace
+}
+
+// RegexpSanitizer builds a Sanitizer that replaces matches of re
+// with repl, following the rules of [regexp.Regexp.ReplaceAllString].
+func RegexpSanitizer(re *regexp.Regexp, repl string) Sanitizer {
+	return func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	}
+}
+
+// jsonEscape returns s escaped as it would appear inside a JSON string,
+// without the surrounding quotes.
+func jsonEscape(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s) // can't fail for strings
+
+	// Drop the quotes and the trailing newline.
+	escaped := strings.TrimSuffix(buf.String(), "\n")
+	return escaped[1 : len(escaped)-1]
+}
+
+// applySanitizers applies the given sanitizers
+// to the recorded request and response.
+func applySanitizers(i *cassette.Interaction, sanitizers []Sanitizer) {
+	if len(sanitizers) == 0 {
+		return
+	}
+
+	i.Request.URL = sanitizeText(i.Request.URL, sanitizers)
+	i.Request.Host = sanitizeText(i.Request.Host, sanitizers)
+	i.Request.RequestURI = sanitizeText(i.Request.RequestURI, sanitizers)
+	i.Request.Body = sanitizeText(i.Request.Body, sanitizers)
+	for k, vs := range i.Request.Form {
+		for idx, v := range vs {
+			vs[idx] = sanitizeText(v, sanitizers)
+		}
+		i.Request.Form[k] = vs
+	}
+
+	// Request bodies are sanitized as text
+	// because matchers may compare them byte-for-byte.
+	// Response bodies are only ever decoded,
+	// so it's safe to re-encode them.
+	if body, ok := sanitizeJSON(i.Response.Body, sanitizers); ok {
+		i.Response.Body = body
+	} else {
+		i.Response.Body = sanitizeText(i.Response.Body, sanitizers)
+	}
+	if i.Response.ContentLength > 0 {
+		i.Response.ContentLength = int64(len(i.Response.Body))
+	}
+}
+
+func sanitizeText(s string, sanitizers []Sanitizer) string {
+	for _, sanitize := range sanitizers {
+		s = sanitize(s)
+	}
+	return s
+}
+
+// sanitizeJSON applies sanitizers to all strings in a JSON document,
+// including object keys.
+//
+// It returns false if body is not a JSON document.
+// The document is re-encoded only if a sanitizer changed something.
+func sanitizeJSON(body string, sanitizers []Sanitizer) (string, bool) {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", false
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return "", false // trailing data
+	}
+
+	v, changed := sanitizeJSONValue(v, sanitizers)
+	if !changed {
+		// Sanitizers may still match text outside JSON strings,
+		// e.g. in numbers.
+		return sanitizeText(body, sanitizers), true
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), true
+}
+
+func sanitizeJSONValue(v any, sanitizers []Sanitizer) (_ any, changed bool) {
+	switch v := v.(type) {
+	case string:
+		s := sanitizeText(v, sanitizers)
+		return s, s != v
+
+	case []any:
+		for idx, item := range v {
+			var itemChanged bool
+			v[idx], itemChanged = sanitizeJSONValue(item, sanitizers)
+			changed = changed || itemChanged
+		}
+		return v, changed
+
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			newKey := sanitizeText(key, sanitizers)
+			newItem, itemChanged := sanitizeJSONValue(item, sanitizers)
+			changed = changed || itemChanged || newKey != key
+			out[newKey] = newItem
+		}
+		return out, changed
+
+	default:
+		return v, false
+	}
+}
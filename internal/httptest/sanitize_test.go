@@ -0,0 +1,154 @@
+package httptest
+
+import (
+	"io"
+	"net/http"
+	stdhttptest "net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/dnaeon/go-vcr.v4/pkg/cassette"
+)
+
+func TestReplaceSanitizer(t *testing.T) {
+	sanitize := ReplaceSanitizer("alice smith/repo", "owner/repo")
+
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{"Plain", "repos/alice smith/repo/pulls", "repos/owner/repo/pulls"},
+		{"QueryEscaped", "?q=repo%3Aalice+smith%2Frepo", "?q=repo%3Aowner%2Frepo"},
+		{"PathEscaped", "/projects/alice%20smith%2Frepo", "/projects/owner%2Frepo"},
+		{"Unrelated", "repos/bob/repo", "repos/bob/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitize(tt.give))
+		})
+	}
+
+	t.Run("JSONEscaped", func(t *testing.T) {
+		sanitize := ReplaceSanitizer(`say "hi"`, "greeting")
+		assert.Equal(t, `{"body":"greeting"}`, sanitize(`{"body":"say \"hi\""}`))
+	})
+}
+
+func TestRegexpSanitizer(t *testing.T) {
+	sanitize := RegexpSanitizer(regexp.MustCompile(`ghp_[A-Za-z0-9]+`), "ghp_REDACTED")
+	assert.Equal(t, "token ghp_REDACTED used", sanitize("token ghp_abc123XYZ used"))
+}
+
+func TestApplySanitizers(t *testing.T) {
+	sanitizers := []Sanitizer{ReplaceSanitizer("alice/secret-repo", "owner/repo")}
+
+	t.Run("JSONResponse", func(t *testing.T) {
+		i := &cassette.Interaction{
+			Request: cassette.Request{
+				URL:  "https://api.example.com/repos/alice%2Fsecret-repo?x=1",
+				Body: `{"query":"repo:alice/secret-repo"}`,
+			},
+			Response: cassette.Response{
+				// The slash is escaped as a Unicode code point,
+				// so string replacement on the raw body would miss it.
+				Body:          `{"full_name":"alice\u002fsecret-repo","id":42}`,
+				ContentLength: 47,
+			},
+		}
+
+		applySanitizers(i, sanitizers)
+		assert.Equal(t, "https://api.example.com/repos/owner%2Frepo?x=1", i.Request.URL)
+		assert.Equal(t, `{"query":"repo:owner/repo"}`, i.Request.Body)
+		assert.JSONEq(t, `{"full_name":"owner/repo","id":42}`, i.Response.Body)
+		assert.Equal(t, int64(len(i.Response.Body)), i.Response.ContentLength)
+	})
+
+	t.Run("JSONKeys", func(t *testing.T) {
+		i := &cassette.Interaction{
+			Response: cassette.Response{
+				Body: `{"data":{"alice/secret-repo":{"stars":1}}}`,
+			},
+		}
+
+		applySanitizers(i, sanitizers)
+		assert.JSONEq(t, `{"data":{"owner/repo":{"stars":1}}}`, i.Response.Body)
+	})
+
+	t.Run("JSONUnchanged", func(t *testing.T) {
+		body := `{ "b": 1, "a": "unrelated" }`
+		i := &cassette.Interaction{Response: cassette.Response{Body: body}}
+
+		applySanitizers(i, sanitizers)
+		assert.Equal(t, body, i.Response.Body, "unchanged documents must not be re-encoded")
+	})
+
+	t.Run("TextResponse", func(t *testing.T) {
+		i := &cassette.Interaction{
+			Response: cassette.Response{Body: "cloned alice/secret-repo"},
+		}
+
+		applySanitizers(i, sanitizers)
+		assert.Equal(t, "cloned owner/repo", i.Response.Body)
+	})
+}
+
+func TestNewTransportRecorder_sanitizers(t *testing.T) {
+	srv := stdhttptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"path":"`+r.URL.Path+`","echo":"`+string(body)+`","token":"ghp_abc123"}`)
+	}))
+	defer srv.Close()
+
+	t.Chdir(t.TempDir())
+
+	opts := TransportRecorderOptions{
+		Sanitizers: []Sanitizer{
+			ReplaceSanitizer("alice", "owner"),
+			RegexpSanitizer(regexp.MustCompile(`ghp_\w+`), "ghp_REDACTED"),
+		},
+	}
+	request := func(t *testing.T, client *http.Client) string {
+		res, err := client.Post(srv.URL+"/repos/alice", "text/plain", strings.NewReader("hello alice"))
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("Record", func(t *testing.T) {
+		opts := opts
+		opts.Update = func() bool { return true }
+		rec := NewTransportRecorder(t, "sanitized", opts)
+
+		// Responses are sanitized when recording too
+		// so that tests see the same values in both modes.
+		assert.JSONEq(t,
+			`{"path":"/repos/owner","echo":"hello owner","token":"ghp_REDACTED"}`,
+			request(t, rec.GetDefaultClient()))
+	})
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "fixtures", "sanitized.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(fixture), "alice")
+	assert.NotContains(t, string(fixture), "ghp_abc123")
+
+	t.Run("Replay", func(t *testing.T) {
+		srv.Close() // must not be contacted
+
+		rec := NewTransportRecorder(t, "sanitized", opts)
+		assert.JSONEq(t,
+			`{"path":"/repos/owner","echo":"hello owner","token":"ghp_REDACTED"}`,
+			request(t, rec.GetDefaultClient()),
+			"requests with original values must match sanitized fixtures")
+	})
+}
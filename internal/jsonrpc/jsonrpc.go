@@ -0,0 +1,220 @@
+// Package jsonrpc implements a minimal JSON-RPC 2.0 server
+// that exchanges messages framed with Content-Length headers,
+// the same framing used by the Language Server Protocol.
+//
+// Requests are handled one at a time, in the order they're received.
+// Notifications may be sent to the client at any time.
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	InternalError  = -32603
+)
+
+// Error is a JSON-RPC error.
+// Handlers may return an *Error to control the error code
+// reported to the client.
+// Other errors are reported as [InternalError].
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// HandlerFunc handles a request with the given parameters.
+// The returned value is encoded as the result of the request.
+//
+// params is nil if the request did not include parameters.
+type HandlerFunc func(ctx context.Context, params json.RawMessage) (any, error)
+
+// message is a JSON-RPC request, response, or notification.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *Error           `json:"error,omitempty"`
+}
+
+// Server serves JSON-RPC requests from a reader
+// and writes responses and notifications to a writer.
+//
+// Register handlers with [Server.Handle] before calling [Server.Serve].
+type Server struct {
+	r   *bufio.Reader
+	log *silog.Logger
+
+	mu sync.Mutex // guards w
+	w  io.Writer
+
+	handlers map[string]HandlerFunc
+}
+
+// NewServer builds a server that reads requests from r
+// and writes responses to w.
+func NewServer(r io.Reader, w io.Writer, log *silog.Logger) *Server {
+	return &Server{
+		r:        bufio.NewReader(r),
+		w:        w,
+		log:      log,
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Handle registers the handler for the given method.
+func (s *Server) Handle(method string, h HandlerFunc) {
+	s.handlers[method] = h
+}
+
+// Notify sends a notification to the client.
+// It is safe to call concurrently with [Server.Serve].
+func (s *Server) Notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("encode params: %w", err)
+	}
+	return s.write(&message{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  raw,
+	})
+}
+
+// Serve handles requests until the reader is exhausted
+// or the context is canceled.
+// It returns nil if the reader was exhausted.
+func (s *Server) Serve(ctx context.Context) error {
+	for ctx.Err() == nil {
+		body, err := s.readFrame()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var req message
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := s.reply(nil, nil, &Error{Code: ParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.JSONRPC != "2.0" || req.Method == "" {
+			if err := s.reply(req.ID, nil, &Error{Code: InvalidRequest, Message: "invalid request"}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := s.dispatch(ctx, &req)
+		if req.ID == nil {
+			// Notifications don't get responses.
+			if err != nil {
+				s.log.Warn("Notification failed", "method", req.Method, "error", err)
+			}
+			continue
+		}
+
+		var rpcErr *Error
+		if err != nil && !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: InternalError, Message: err.Error()}
+		}
+		if err := s.reply(req.ID, result, rpcErr); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *Server) dispatch(ctx context.Context, req *message) (any, error) {
+	h, ok := s.handlers[req.Method]
+	if !ok {
+		return nil, &Error{Code: MethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	s.log.Debug("Handling request", "method", req.Method)
+	return h(ctx, req.Params)
+}
+
+func (s *Server) reply(id *json.RawMessage, result any, rpcErr *Error) error {
+	if id == nil {
+		// Responses to unidentifiable requests use a null ID.
+		null := json.RawMessage("null")
+		id = &null
+	}
+	msg := &message{JSONRPC: "2.0", ID: id, Error: rpcErr}
+	if rpcErr == nil {
+		// A successful response must always have a result.
+		msg.Result = result
+		if result == nil {
+			msg.Result = json.RawMessage("null")
+		}
+	}
+	return s.write(msg)
+}
+
+func (s *Server) write(msg *message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	return nil
+}
+
+// readFrame reads the body of the next message.
+// It returns io.EOF if there are no more messages.
+func (s *Server) readFrame() ([]byte, error) {
+	header, err := textproto.NewReader(s.r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) == 0 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	lengthStr := header.Get("Content-Length")
+	if lengthStr == "" {
+		return nil, errors.New("missing Content-Length header")
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(lengthStr))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("bad Content-Length: %q", lengthStr)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.r, body); err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,143 @@
+package jsonrpc_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/jsonrpc"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+func TestServer(t *testing.T) {
+	var in strings.Builder
+	writeFrame(&in, `{"jsonrpc":"2.0","id":1,"method":"add","params":[1,2]}`)
+	writeFrame(&in, `{"jsonrpc":"2.0","id":"two","method":"nope"}`)
+	writeFrame(&in, `{"jsonrpc":"2.0","id":3,"method":"fail"}`)
+	writeFrame(&in, `{"jsonrpc":"2.0","id":4,"method":"add","params":"x"}`)
+	writeFrame(&in, `{"jsonrpc":"2.0","method":"add","params":[3,4]}`) // notification
+	writeFrame(&in, `{"jsonrpc":"2.0","id":5,"method":"nothing"}`)
+	writeFrame(&in, `not json`)
+	writeFrame(&in, `{"id":6,"method":"add"}`)
+
+	var out strings.Builder
+	srv := jsonrpc.NewServer(strings.NewReader(in.String()), &out, silogtest.New(t))
+
+	var added []int
+	srv.Handle("add", func(_ context.Context, params json.RawMessage) (any, error) {
+		var nums []int
+		if err := json.Unmarshal(params, &nums); err != nil {
+			return nil, &jsonrpc.Error{Code: jsonrpc.InvalidParams, Message: err.Error()}
+		}
+		var sum int
+		for _, n := range nums {
+			sum += n
+		}
+		added = append(added, sum)
+		return sum, nil
+	})
+	srv.Handle("fail", func(context.Context, json.RawMessage) (any, error) {
+		return nil, errors.New("great sadness")
+	})
+	srv.Handle("nothing", func(context.Context, json.RawMessage) (any, error) {
+		return nil, nil
+	})
+
+	require.NoError(t, srv.Serve(t.Context()))
+	assert.Equal(t, []int{3, 7}, added)
+
+	assert.Equal(t, []string{
+		`{"jsonrpc":"2.0","id":1,"result":3}`,
+		`{"jsonrpc":"2.0","id":"two","error":{"code":-32601,"message":"method not found: nope"}}`,
+		`{"jsonrpc":"2.0","id":3,"error":{"code":-32603,"message":"great sadness"}}`,
+		`{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"json: cannot unmarshal string into Go value of type []int"}}`,
+		`{"jsonrpc":"2.0","id":5,"result":null}`,
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"invalid character 'o' in literal null (expecting 'u')"}}`,
+		`{"jsonrpc":"2.0","id":6,"error":{"code":-32600,"message":"invalid request"}}`,
+	}, readFrames(t, out.String()))
+}
+
+func TestServer_notify(t *testing.T) {
+	pr, pw := io.Pipe()
+	var out strings.Builder
+	srv := jsonrpc.NewServer(pr, &out, silogtest.New(t))
+
+	done := make(chan error)
+	go func() { done <- srv.Serve(t.Context()) }()
+
+	require.NoError(t, srv.Notify("store/changed", map[string]string{"hash": "abc"}))
+	require.NoError(t, pw.Close())
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []string{
+		`{"jsonrpc":"2.0","method":"store/changed","params":{"hash":"abc"}}`,
+	}, readFrames(t, out.String()))
+}
+
+func TestServer_badFrame(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		wantErr string
+	}{
+		{
+			name:    "MissingLength",
+			give:    "Content-Type: application/json\r\n\r\n{}",
+			wantErr: "missing Content-Length",
+		},
+		{
+			name:    "BadLength",
+			give:    "Content-Length: x\r\n\r\n{}",
+			wantErr: "bad Content-Length",
+		},
+		{
+			name:    "ShortBody",
+			give:    "Content-Length: 10\r\n\r\n{}",
+			wantErr: "read body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := jsonrpc.NewServer(strings.NewReader(tt.give), io.Discard, silogtest.New(t))
+			err := srv.Serve(t.Context())
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func writeFrame(w io.Writer, body string) {
+	_, _ = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func readFrames(t *testing.T, s string) []string {
+	t.Helper()
+
+	r := bufio.NewReader(strings.NewReader(s))
+	var bodies []string
+	for {
+		header, err := textproto.NewReader(r).ReadMIMEHeader()
+		if errors.Is(err, io.EOF) {
+			return bodies
+		}
+		require.NoError(t, err)
+
+		length, err := strconv.Atoi(header.Get("Content-Length"))
+		require.NoError(t, err)
+
+		body := make([]byte, length)
+		_, err = io.ReadFull(r, body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+	}
+}
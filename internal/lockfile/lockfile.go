@@ -0,0 +1,183 @@
+// Package lockfile implements advisory locks between processes
+// backed by a file that records the process holding the lock.
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LockedError is returned by [Acquire]
+// if the lock is held by another process.
+type LockedError struct {
+	// Path is the path to the lock file.
+	Path string
+
+	// PID is the ID of the process holding the lock.
+	// It is 0 if the lock file could not be read.
+	PID int
+}
+
+func (e *LockedError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("%v: locked by another process", e.Path)
+	}
+	return fmt.Sprintf("%v: locked by process %d", e.Path, e.PID)
+}
+
+// _maxAttempts is the number of times Acquire tries to take the lock
+// if it finds stale lock files left behind by processes that exited.
+const _maxAttempts = 3
+
+// Lock is an advisory lock held by the current process.
+type Lock struct {
+	path string
+}
+
+// Acquire takes the lock at the given path for the current process.
+// It returns a [*LockedError] if the lock is held by another process.
+//
+// Locks left behind by processes that are no longer running
+// are removed automatically.
+func Acquire(path string) (*Lock, error) {
+	return acquire(path, os.Getpid())
+}
+
+func acquire(path string, pid int) (*Lock, error) {
+	for range _maxAttempts {
+		err := create(path, pid)
+		if err == nil {
+			return &Lock{path: path}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("create lock file: %w", err)
+		}
+
+		owner, err := readPID(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// Released since we tried to take it.
+			continue
+		case err != nil:
+			// Possibly being written by another process.
+			return nil, &LockedError{Path: path}
+		case owner != pid && processExists(owner):
+			return nil, &LockedError{Path: path, PID: owner}
+		}
+
+		// The process holding the lock is gone.
+		if err := removeStale(path, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, &LockedError{Path: path}
+}
+
+// Release releases the lock.
+// It is safe to call Release more than once.
+func (l *Lock) Release() error {
+	if l == nil || l.path == "" {
+		return nil
+	}
+
+	path := l.path
+	l.path = ""
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove lock file: %w", err)
+	}
+	return nil
+}
+
+// Remove forcibly removes the lock at the given path,
+// regardless of which process holds it.
+// It is not an error if the lock isn't held.
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// create atomically creates a lock file at path holding the given PID.
+// It fails with fs.ErrExist if the file already exists.
+//
+// The PID is written to a temporary file first,
+// which is then linked into place,
+// so that other processes never observe a partially written lock file.
+func create(path string, pid int) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.Remove(tmp.Name()))
+	}()
+
+	if _, err := tmp.WriteString(strconv.Itoa(pid) + "\n"); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Link(tmp.Name(), path)
+}
+
+// removeStale removes the lock file at path
+// if it's still held by the given process, which is no longer running.
+//
+// Another process may have replaced the stale lock with its own
+// since we read it, so the file is first moved out of the way atomically,
+// and deleted only if it still belongs to the stale owner.
+// Otherwise, it's put back and a [*LockedError] is returned.
+func removeStale(path string, owner int) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".stale.*")
+	if err != nil {
+		return fmt.Errorf("remove stale lock file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		if rmErr := os.Remove(tmpPath); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	}()
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("remove stale lock file: %w", err)
+	}
+
+	if err := os.Rename(path, tmpPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Another process already removed it.
+			return nil
+		}
+		return fmt.Errorf("remove stale lock file: %w", err)
+	}
+
+	if got, err := readPID(tmpPath); err == nil && got == owner {
+		return nil
+	}
+
+	// The lock changed hands since we read it: put it back.
+	// This fails only if a third process created a lock file
+	// while ours was moved out of the way.
+	if err := os.Link(tmpPath, path); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("restore lock file: %w", err)
+	}
+	pid, _ := readPID(path)
+	return &LockedError{Path: path, PID: pid}
+}
+
+func readPID(path string) (int, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(bs)))
+}
@@ -0,0 +1,164 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	lock, err := Acquire(path)
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(bs))
+
+	require.NoError(t, lock.Release())
+	assert.NoFileExists(t, path)
+	require.NoError(t, lock.Release(), "second release")
+
+	// Can be taken again after release.
+	lock, err = Acquire(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary files must be cleaned up")
+}
+
+func TestAcquire_locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	// The test binary's parent is running for the duration of the test.
+	owner := os.Getppid()
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(owner)+"\n"), 0o644))
+
+	_, err := Acquire(path)
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, path, lockedErr.Path)
+	assert.Equal(t, owner, lockedErr.PID)
+	assert.ErrorContains(t, err, "locked by process "+strconv.Itoa(owner))
+
+	// Lock file is left alone.
+	assert.FileExists(t, path)
+
+	require.NoError(t, Remove(path))
+	lock, err := Acquire(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestAcquire_unreadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o644))
+
+	_, err := Acquire(path)
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Zero(t, lockedErr.PID)
+	assert.ErrorContains(t, err, "locked by another process")
+}
+
+func TestAcquire_stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	// Larger than any PID the system will hand out.
+	require.NoError(t, os.WriteFile(path, []byte("1073741824\n"), 0o644))
+
+	lock, err := Acquire(path)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, lock.Release()) }()
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(bs))
+}
+
+func TestAcquire_staleRace(t *testing.T) {
+	// Two live processes race to replace the same stale lock:
+	// the test binary and its parent.
+	pids := []int{os.Getpid(), os.Getppid()}
+
+	for range 100 {
+		path := filepath.Join(t.TempDir(), "test.lock")
+		require.NoError(t, os.WriteFile(path, []byte("1073741824\n"), 0o644))
+
+		var (
+			wg    sync.WaitGroup
+			ready sync.WaitGroup
+			start = make(chan struct{})
+			locks = make([]*Lock, len(pids))
+			errs  = make([]error, len(pids))
+		)
+		for i, pid := range pids {
+			ready.Add(1)
+			wg.Go(func() {
+				ready.Done()
+				<-start
+				locks[i], errs[i] = acquire(path, pid)
+			})
+		}
+		ready.Wait()
+		close(start)
+		wg.Wait()
+
+		var winners []int
+		for i, err := range errs {
+			if err == nil {
+				winners = append(winners, pids[i])
+				continue
+			}
+
+			var lockedErr *LockedError
+			require.ErrorAs(t, err, &lockedErr)
+		}
+		require.Len(t, winners, 1, "exactly one process must hold the lock")
+
+		owner, err := readPID(path)
+		require.NoError(t, err)
+		assert.Equal(t, winners[0], owner)
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "temporary files must be cleaned up")
+	}
+}
+
+func TestRemoveStale_replaced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	const stale = 1073741824
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(stale)+"\n"), 0o644))
+
+	// Another process replaces the stale lock with its own
+	// after we've read the stale owner, but before we remove it.
+	lock, err := acquire(path, os.Getppid())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, lock.Release()) }()
+
+	err = removeStale(path, stale)
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, os.Getppid(), lockedErr.PID)
+
+	owner, err := readPID(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getppid(), owner, "new lock must be left alone")
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must be cleaned up")
+}
+
+func TestRemove_notExist(t *testing.T) {
+	assert.NoError(t, Remove(filepath.Join(t.TempDir(), "test.lock")))
+}
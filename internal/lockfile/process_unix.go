@@ -0,0 +1,16 @@
+//go:build unix
+
+package lockfile
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processExists reports whether a process with the given ID is running.
+func processExists(pid int) bool {
+	// Signal 0 checks for the process without signaling it.
+	// EPERM means the process exists but belongs to another user.
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package lockfile
+
+import "os"
+
+// processExists reports whether a process with the given ID is running.
+func processExists(pid int) bool {
+	// On Windows, FindProcess opens a handle to the process,
+	// which fails if the process doesn't exist.
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
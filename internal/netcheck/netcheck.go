@@ -0,0 +1,236 @@
+// Package netcheck diagnoses problems reaching remote servers
+// so that they can be reported with actionable messages.
+package netcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"syscall"
+	"time"
+)
+
+// DefaultTimeout is a reasonable timeout for [Check].
+// It's long enough for slow connections,
+// but short enough that users aren't left waiting
+// when the network is down.
+const DefaultTimeout = 5 * time.Second
+
+// Problem is a kind of problem reaching a server.
+type Problem int
+
+const (
+	// Unknown is a problem that could not be classified.
+	Unknown Problem = iota
+
+	// DNS indicates that the server's hostname could not be resolved.
+	DNS
+
+	// Proxy indicates that the configured proxy could not be used.
+	Proxy
+
+	// TLS indicates that a secure connection could not be established,
+	// usually because the server's certificate could not be verified.
+	TLS
+
+	// Timeout indicates that the server did not respond in time.
+	Timeout
+
+	// Refused indicates that the server refused the connection.
+	Refused
+
+	// Auth indicates that the server was reachable,
+	// but rejected the provided credentials.
+	Auth
+)
+
+// String returns a short description of the problem.
+func (p Problem) String() string {
+	switch p {
+	case DNS:
+		return "DNS lookup failed"
+	case Proxy:
+		return "proxy error"
+	case TLS:
+		return "TLS error"
+	case Timeout:
+		return "timed out"
+	case Refused:
+		return "connection refused"
+	case Auth:
+		return "authentication failed"
+	default:
+		return "network error"
+	}
+}
+
+// Hint suggests how to resolve the problem.
+func (p Problem) Hint() string {
+	switch p {
+	case DNS:
+		return "Check your network connection and DNS settings."
+	case Proxy:
+		return "Check the HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables."
+	case TLS:
+		return "Check that the server's certificate is trusted by your system, " +
+			"or set SSL_CERT_FILE to a file with the certificate."
+	case Timeout:
+		return "Check your network connection, or try again later."
+	case Refused:
+		return "Check that the server URL is correct and that the server is running."
+	case Auth:
+		return "Your credentials may have expired or been revoked."
+	default:
+		return "Check your network connection."
+	}
+}
+
+// Error is returned when a server could not be reached.
+type Error struct {
+	// URL is the URL that was checked.
+	URL string
+
+	// Problem is the kind of problem encountered.
+	Problem Problem
+
+	// Err is the underlying error.
+	Err error
+}
+
+var _ error = (*Error)(nil)
+
+func (e *Error) Error() string {
+	host := e.URL
+	if u, err := url.Parse(e.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	// url.Error repeats the method and URL.
+	// Report only the underlying error.
+	err := e.Err
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	if host == "" {
+		return fmt.Sprintf("%v: %v", e.Problem, err)
+	}
+	return fmt.Sprintf("%v: %v: %v", host, e.Problem, err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Check sends a HEAD request to the given URL
+// and reports an [*Error] if the server could not be reached
+// within the given timeout.
+//
+// Any HTTP response means the server is reachable,
+// except 407 (Proxy Authentication Required).
+func Check(ctx context.Context, client *http.Client, rawURL string, timeout time.Duration) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		// Cancellation by the caller is not a network problem.
+		if ctxErr := context.Cause(ctx); errors.Is(ctxErr, context.Canceled) {
+			return ctxErr
+		}
+		return &Error{URL: rawURL, Problem: Classify(err), Err: err}
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode == http.StatusProxyAuthRequired {
+		return &Error{
+			URL:     rawURL,
+			Problem: Proxy,
+			Err:     errors.New(res.Status),
+		}
+	}
+
+	return nil
+}
+
+// Classify reports the kind of problem behind an error
+// returned by an HTTP request.
+func Classify(err error) Problem {
+	if err == nil {
+		return Unknown
+	}
+
+	var (
+		opErr        *net.OpError
+		dnsErr       *net.DNSError
+		netErr       net.Error
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostErr      x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+	)
+	switch {
+	// Failure to resolve or reach the proxy
+	// is a proxy problem, not a DNS or connection problem,
+	// so check for it first.
+	case errors.As(err, &opErr) && opErr.Op == "proxyconnect":
+		return Proxy
+
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsTimeout {
+			return Timeout
+		}
+		return DNS
+
+	case errors.As(err, &verifyErr),
+		errors.As(err, &authorityErr),
+		errors.As(err, &hostErr),
+		errors.As(err, &invalidErr),
+		errors.As(err, &recordErr),
+		errors.As(err, &alertErr):
+		return TLS
+
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return Timeout
+
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return Refused
+	}
+
+	if IsUnauthorized(err) {
+		return Auth
+	}
+
+	return Unknown
+}
+
+// _unauthorizedRegexp matches error messages from forge API clients
+// that indicate rejected credentials.
+// None of them expose the HTTP status code in a structured way.
+var _unauthorizedRegexp = regexp.MustCompile(`(?i)\b401\b|unauthorized|bad credentials`)
+
+// IsUnauthorized reports whether err indicates
+// that the server rejected the provided credentials.
+func IsUnauthorized(err error) bool {
+	return err != nil && _unauthorizedRegexp.MatchString(err.Error())
+}
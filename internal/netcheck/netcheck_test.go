@@ -0,0 +1,182 @@
+package netcheck_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/netcheck"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("Reachable", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			// Any response counts.
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		assert.NoError(t, netcheck.Check(t.Context(), srv.Client(), srv.URL, time.Second))
+	})
+
+	t.Run("ProxyAuthRequired", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+		}))
+		defer srv.Close()
+
+		err := netcheck.Check(t.Context(), srv.Client(), srv.URL, time.Second)
+		assertProblem(t, netcheck.Proxy, err)
+	})
+
+	t.Run("Refused", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		require.NoError(t, ln.Close())
+
+		err = netcheck.Check(t.Context(), nil, "http://"+addr, time.Second)
+		assertProblem(t, netcheck.Refused, err)
+		assert.ErrorContains(t, err, addr+": connection refused: ")
+	})
+
+	t.Run("UntrustedCertificate", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer srv.Close()
+
+		// The default client doesn't trust the test server's certificate.
+		err := netcheck.Check(t.Context(), http.DefaultClient, srv.URL, time.Second)
+		assertProblem(t, netcheck.TLS, err)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			<-done
+		}))
+		defer srv.Close()
+		defer close(done)
+
+		err := netcheck.Check(t.Context(), srv.Client(), srv.URL, 10*time.Millisecond)
+		assertProblem(t, netcheck.Timeout, err)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		err := netcheck.Check(ctx, srv.Client(), srv.URL, time.Second)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		var netErr *netcheck.Error
+		assert.False(t, errors.As(err, &netErr), "cancellation is not a network problem")
+	})
+}
+
+func TestClassify(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Head", URL: "https://example.com", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want netcheck.Problem
+	}{
+		{
+			name: "DNS",
+			err:  urlErr(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}}),
+			want: netcheck.DNS,
+		},
+		{
+			name: "DNSTimeout",
+			err:  urlErr(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}}),
+			want: netcheck.Timeout,
+		},
+		{
+			name: "ProxyDNS",
+			err:  urlErr(&net.OpError{Op: "proxyconnect", Err: &net.DNSError{Err: "no such host", Name: "proxy.example.com"}}),
+			want: netcheck.Proxy,
+		},
+		{
+			name: "UnknownAuthority",
+			err:  urlErr(x509.UnknownAuthorityError{}),
+			want: netcheck.TLS,
+		},
+		{
+			name: "Deadline",
+			err:  urlErr(context.DeadlineExceeded),
+			want: netcheck.Timeout,
+		},
+		{
+			name: "Refused",
+			err:  urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
+			want: netcheck.Refused,
+		},
+		{
+			name: "Unauthorized",
+			err:  fmt.Errorf("get repository ID: %w", errors.New("non-200 OK status code: 401 Unauthorized body: ...")),
+			want: netcheck.Auth,
+		},
+		{
+			name: "BadCredentials",
+			err:  errors.New(`{"message": "Bad credentials"}`),
+			want: netcheck.Auth,
+		},
+		{
+			name: "Unknown",
+			err:  errors.New("great sadness"),
+			want: netcheck.Unknown,
+		},
+		{
+			name: "Nil",
+			want: netcheck.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, netcheck.Classify(tt.err))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	err := &netcheck.Error{
+		URL:     "https://api.example.com/v3",
+		Problem: netcheck.DNS,
+		Err: &url.Error{
+			Op:  "Head",
+			URL: "https://api.example.com/v3",
+			Err: errors.New("no such host"),
+		},
+	}
+	assert.EqualError(t, err, "api.example.com: DNS lookup failed: no such host")
+
+	err.URL = ""
+	assert.EqualError(t, err, "DNS lookup failed: no such host")
+}
+
+func assertProblem(t *testing.T, want netcheck.Problem, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	var netErr *netcheck.Error
+	require.True(t, errors.As(err, &netErr), "want *netcheck.Error, got %T: %v", err, err)
+	assert.Equal(t, want, netErr.Problem, "problem: %v", netErr)
+}
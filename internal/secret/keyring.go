@@ -2,6 +2,9 @@ package secret
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/zalando/go-keyring"
 )
@@ -9,34 +12,153 @@ import (
 // Keyring is a secure secret store that uses the system's keychain
 // if available.
 //
+// Secrets larger than what the keychain can hold in a single entry
+// (e.g. 2560 bytes for the Windows Credential Manager)
+// are split across multiple entries.
+//
 // Its zero value is ready for use.
 type Keyring struct{}
 
 var _ Stash = (*Keyring)(nil)
 
+// _keyringMaxSize is the maximum size of a single keyring entry.
+// Zero means there's no limit.
+//
+// This is a variable so tests can override it.
+var _keyringMaxSize = keyringMaxSize
+
+// _keyringPartsPrefix prefixes the value of an entry
+// whose secret is split across multiple entries.
+// The prefix is followed by the number of parts.
+const _keyringPartsPrefix = "git-spice:parts:"
+
 func keyringService(service string) string {
 	return "git-spice:" + service
 }
 
+// keyringPartKey returns the key for the given part (1-indexed)
+// of a secret that is split across multiple entries.
+func keyringPartKey(key string, part int) string {
+	return key + "#" + strconv.Itoa(part)
+}
+
 // SaveSecret saves a secret in the keyring.
 func (*Keyring) SaveSecret(service, key, secret string) error {
-	return keyring.Set(keyringService(service), key, secret)
+	service = keyringService(service)
+
+	// If the secret was previously split, drop the old parts
+	// so they don't linger if the new secret needs fewer of them.
+	if err := deleteKeyringParts(service, key); err != nil {
+		return err
+	}
+
+	maxSize := _keyringMaxSize
+	// Secrets that look like a part header are always split
+	// so that they aren't mistaken for one when loaded.
+	split := (maxSize > 0 && len(secret) > maxSize) ||
+		strings.HasPrefix(secret, _keyringPartsPrefix)
+	if !split {
+		return keyring.Set(service, key, secret)
+	}
+	if maxSize <= 0 {
+		maxSize = len(secret)
+	}
+
+	var parts int
+	for rest := secret; len(rest) > 0; {
+		chunk := rest[:min(maxSize, len(rest))]
+		rest = rest[len(chunk):]
+
+		parts++
+		if err := keyring.Set(service, keyringPartKey(key, parts), chunk); err != nil {
+			return fmt.Errorf("save part %d: %w", parts, err)
+		}
+	}
+
+	return keyring.Set(service, key, _keyringPartsPrefix+strconv.Itoa(parts))
 }
 
 // LoadSecret loads a secret from the keyring.
 func (*Keyring) LoadSecret(service, key string) (string, error) {
-	secret, err := keyring.Get(keyringService(service), key)
+	service = keyringService(service)
+	secret, err := keyring.Get(service, key)
 	if errors.Is(err, keyring.ErrNotFound) {
 		return "", ErrNotFound
 	}
-	return secret, err
+	if err != nil {
+		return "", err
+	}
+
+	parts, ok, err := keyringParts(secret)
+	if err != nil || !ok {
+		return secret, err
+	}
+
+	var sb strings.Builder
+	for part := 1; part <= parts; part++ {
+		chunk, err := keyring.Get(service, keyringPartKey(key, part))
+		if err != nil {
+			// A missing part means the secret is corrupt.
+			// Report it as an error, not as ErrNotFound.
+			return "", fmt.Errorf("load part %d: %w", part, err)
+		}
+		sb.WriteString(chunk)
+	}
+	return sb.String(), nil
 }
 
 // DeleteSecret deletes a secret from the keyring.
 func (*Keyring) DeleteSecret(service, key string) error {
-	err := keyring.Delete(keyringService(service), key)
+	service = keyringService(service)
+	if err := deleteKeyringParts(service, key); err != nil {
+		return err
+	}
+
+	err := keyring.Delete(service, key)
 	if errors.Is(err, keyring.ErrNotFound) {
 		err = nil
 	}
 	return err
 }
+
+// deleteKeyringParts deletes the parts of a secret
+// that was split across multiple entries.
+// It's a no-op if the secret doesn't exist or wasn't split.
+func deleteKeyringParts(service, key string) error {
+	value, err := keyring.Get(service, key)
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	parts, ok, err := keyringParts(value)
+	if err != nil || !ok {
+		// A corrupt header will be overwritten or deleted anyway.
+		return nil
+	}
+
+	for part := 1; part <= parts; part++ {
+		err := keyring.Delete(service, keyringPartKey(key, part))
+		if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("delete part %d: %w", part, err)
+		}
+	}
+	return nil
+}
+
+// keyringParts reports the number of parts
+// if the given keyring value refers to a split secret.
+func keyringParts(value string) (parts int, ok bool, err error) {
+	count, ok := strings.CutPrefix(value, _keyringPartsPrefix)
+	if !ok {
+		return 0, false, nil
+	}
+
+	parts, err = strconv.Atoi(count)
+	if err != nil || parts <= 0 {
+		return 0, false, fmt.Errorf("bad part count %q", count)
+	}
+	return parts, true, nil
+}
@@ -0,0 +1,6 @@
+//go:build !windows
+
+package secret
+
+// Other keychains don't impose a limit small enough to matter.
+const keyringMaxSize = 0
@@ -0,0 +1,85 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeyring_split(t *testing.T) {
+	// keyring.MockInit is called in TestMain.
+	defer func(old int) { _keyringMaxSize = old }(_keyringMaxSize)
+	_keyringMaxSize = 4
+
+	const service = "split-service"
+	var stash Keyring
+
+	require.NoError(t, stash.SaveSecret(service, "key", "0123456789"))
+
+	t.Run("Parts", func(t *testing.T) {
+		svc := keyringService(service)
+		for i, want := range []string{"0123", "4567", "89"} {
+			got, err := keyring.Get(svc, keyringPartKey("key", i+1))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		got, err := stash.LoadSecret(service, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", got)
+	})
+
+	t.Run("OverwriteSmaller", func(t *testing.T) {
+		require.NoError(t, stash.SaveSecret(service, "key", "abc"))
+
+		got, err := stash.LoadSecret(service, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "abc", got)
+
+		_, err = keyring.Get(keyringService(service), keyringPartKey("key", 1))
+		assert.ErrorIs(t, err, keyring.ErrNotFound, "old parts must be deleted")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, stash.SaveSecret(service, "key", strings.Repeat("x", 9)))
+		require.NoError(t, stash.DeleteSecret(service, "key"))
+
+		_, err := stash.LoadSecret(service, "key")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		for part := 1; part <= 3; part++ {
+			_, err := keyring.Get(keyringService(service), keyringPartKey("key", part))
+			assert.ErrorIs(t, err, keyring.ErrNotFound, "part %d", part)
+		}
+	})
+
+	t.Run("MissingPart", func(t *testing.T) {
+		require.NoError(t, stash.SaveSecret(service, "key", "0123456789"))
+		require.NoError(t, keyring.Delete(keyringService(service), keyringPartKey("key", 2)))
+
+		_, err := stash.LoadSecret(service, "key")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.ErrorContains(t, err, "load part 2")
+	})
+}
+
+func TestKeyring_headerLikeSecret(t *testing.T) {
+	defer func(old int) { _keyringMaxSize = old }(_keyringMaxSize)
+	_keyringMaxSize = 0
+
+	const service = "header-service"
+	var stash Keyring
+
+	secret := _keyringPartsPrefix + "3"
+	require.NoError(t, stash.SaveSecret(service, "key", secret))
+
+	got, err := stash.LoadSecret(service, "key")
+	require.NoError(t, err)
+	assert.Equal(t, secret, got)
+}
@@ -0,0 +1,6 @@
+//go:build windows
+
+package secret
+
+// The Windows Credential Manager limits secrets to 2560 bytes.
+const keyringMaxSize = 2560
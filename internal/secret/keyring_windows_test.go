@@ -0,0 +1,34 @@
+//go:build windows
+
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeyring_windowsLimit(t *testing.T) {
+	// keyring.MockInit is called in TestMain,
+	// so this doesn't touch the real Credential Manager.
+	const service = "windows-service"
+	var stash Keyring
+
+	// Large tokens (e.g. OAuth tokens with many scopes)
+	// don't fit in a single Credential Manager entry.
+	secret := strings.Repeat("t", 3*keyringMaxSize+1)
+	require.NoError(t, stash.SaveSecret(service, "key", secret))
+
+	for part := 1; part <= 4; part++ {
+		chunk, err := keyring.Get(keyringService(service), keyringPartKey("key", part))
+		require.NoError(t, err, "part %d", part)
+		assert.LessOrEqual(t, len(chunk), keyringMaxSize, "part %d", part)
+	}
+
+	got, err := stash.LoadSecret(service, "key")
+	require.NoError(t, err)
+	assert.Equal(t, secret, got)
+}
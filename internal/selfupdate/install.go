@@ -0,0 +1,253 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+const (
+	// _checksumsName is the name of the release asset
+	// listing SHA-256 checksums of the other assets.
+	_checksumsName = "checksums.txt"
+
+	// _maxArchiveSize limits how much of an archive is downloaded.
+	// Releases are a fraction of this.
+	_maxArchiveSize = 256 << 20 // 256 MiB
+)
+
+// Platform identifies the operating system and architecture
+// that a binary was built for.
+type Platform struct {
+	// OS and Arch are values of GOOS and GOARCH.
+	OS, Arch string
+
+	// ARM is the value of GOARM for 32-bit ARM builds.
+	ARM string
+}
+
+// CurrentPlatform returns the platform of the running binary.
+func CurrentPlatform() Platform {
+	p := Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if p.Arch == "arm" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, s := range info.Settings {
+				if s.Key == "GOARM" {
+					p.ARM = s.Value
+				}
+			}
+		}
+	}
+	return p
+}
+
+// ArchiveName returns the name of the release archive
+// holding the binary for this platform.
+//
+// This must match the archive name_template in .goreleaser.yml.
+func (p Platform) ArchiveName() string {
+	var arch string
+	switch {
+	case p.Arch == "amd64":
+		arch = "x86_64"
+	case p.Arch == "386":
+		arch = "i386"
+	case p.Arch == "arm64" && p.OS == "linux":
+		arch = "aarch64"
+	default:
+		arch = p.Arch
+	}
+	if p.ARM != "" {
+		arch += "v" + p.ARM
+	}
+
+	goos := p.OS
+	if goos != "" {
+		goos = strings.ToUpper(goos[:1]) + goos[1:]
+	}
+
+	return "git-spice." + goos + "-" + arch + ".tar.gz"
+}
+
+// BinaryName returns the name of the binary inside the release archive.
+func (p Platform) BinaryName() string {
+	if p.OS == "windows" {
+		return "git-spice.exe"
+	}
+	return "git-spice"
+}
+
+// Download downloads the binary for the given platform from a release.
+//
+// The release archive is verified against the SHA-256 checksum
+// published with the release before the binary is extracted.
+func (c *Client) Download(ctx context.Context, rel *Release, p Platform) ([]byte, error) {
+	archiveName := p.ArchiveName()
+	archive := rel.asset(archiveName)
+	if archive == nil {
+		return nil, fmt.Errorf("release %v has no archive for %v/%v: expected %v",
+			rel.Version, p.OS, p.Arch, archiveName)
+	}
+	checksums := rel.asset(_checksumsName)
+	if checksums == nil {
+		return nil, fmt.Errorf("release %v has no %v", rel.Version, _checksumsName)
+	}
+
+	checksumsBody, err := c.get(ctx, checksums.URL, "", 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("download %v: %w", _checksumsName, err)
+	}
+	want, err := findChecksum(checksumsBody, archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log().Debug("Downloading release archive", "url", archive.URL)
+	archiveBody, err := c.get(ctx, archive.URL, "", _maxArchiveSize)
+	if err != nil {
+		return nil, fmt.Errorf("download %v: %w", archiveName, err)
+	}
+
+	got := sha256.Sum256(archiveBody)
+	if subtle.ConstantTimeCompare(got[:], want) != 1 {
+		return nil, fmt.Errorf("checksum mismatch for %v: expected %x, got %x",
+			archiveName, want, got)
+	}
+
+	bin, err := extractBinary(archiveBody, p.BinaryName())
+	if err != nil {
+		return nil, fmt.Errorf("extract %v: %w", archiveName, err)
+	}
+	return bin, nil
+}
+
+// findChecksum finds the checksum of the named file
+// in a file produced by sha256sum.
+func findChecksum(checksums []byte, name string) ([]byte, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		sum, file, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok || strings.TrimLeft(strings.TrimSpace(file), "*") != name {
+			continue
+		}
+
+		bs, err := hex.DecodeString(sum)
+		if err != nil || len(bs) != sha256.Size {
+			return nil, fmt.Errorf("bad checksum for %v: %q", name, sum)
+		}
+		return bs, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %v: %w", _checksumsName, err)
+	}
+	return nil, fmt.Errorf("%v does not list %v", _checksumsName, name)
+}
+
+// extractBinary returns the contents of the named file
+// from a gzip-compressed tar archive.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%v not found in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != name {
+			continue
+		}
+		return io.ReadAll(io.LimitReader(tr, _maxArchiveSize))
+	}
+}
+
+// Replace replaces the executable at path with the given binary.
+//
+// The new binary is written next to the old one
+// and renamed over it so that the executable is never left half-written.
+// On Windows, where a running executable can't be replaced,
+// the old binary is moved aside to path + ".old" first.
+func Replace(path string, bin []byte) (err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	dir, base := filepath.Split(path)
+	tmp, err := os.CreateTemp(dir, "."+base+".new-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(bin); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("make new binary executable: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		_ = os.Remove(old) // left behind by a previous upgrade
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("move old binary aside: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				_ = os.Rename(old, path)
+			}
+		}()
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace binary: %w", err)
+	}
+	return nil
+}
+
+// ManagedBy reports the name of the package manager
+// that installed the executable at path, if any.
+// Binaries installed by package managers should be upgraded with them.
+func ManagedBy(path string) string {
+	path = filepath.ToSlash(path)
+	switch {
+	case strings.Contains(path, "/Cellar/"), strings.Contains(path, "/Caskroom/"):
+		return "Homebrew"
+	case strings.HasPrefix(path, "/nix/store/"):
+		return "Nix"
+	case strings.Contains(path, "/mise/installs/"):
+		return "mise"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,204 @@
+// Package selfupdate finds new releases of git-spice
+// and replaces the running binary with them.
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.abhg.dev/gs/internal/silog"
+	"golang.org/x/mod/semver"
+)
+
+// Defaults for where releases are published.
+const (
+	DefaultAPIURL     = "https://api.github.com"
+	DefaultRepository = "abhinav/git-spice"
+)
+
+// _maxErrorBody is the most we'll read from an error response.
+const _maxErrorBody = 4 << 10 // 4 KiB
+
+// Release is a published release of git-spice.
+type Release struct {
+	// Version is the version of the release, e.g. "v0.20.0".
+	Version string
+
+	// URL is the web page for the release.
+	URL string
+
+	// Assets are the files attached to the release.
+	Assets []*Asset
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	// Name is the file name of the asset.
+	Name string
+
+	// URL is the address from which the asset may be downloaded.
+	URL string
+}
+
+// asset returns the asset with the given name, or nil.
+func (r *Release) asset(name string) *Asset {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Client looks up and downloads releases of git-spice
+// from GitHub.
+type Client struct {
+	// APIURL is the base URL of the GitHub REST API.
+	// Defaults to DefaultAPIURL.
+	APIURL string
+
+	// Repository is the repository that releases are published to,
+	// in the form "owner/name".
+	// Defaults to DefaultRepository.
+	Repository string
+
+	// HTTPClient is used to make requests.
+	// Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Log receives debug messages.
+	Log *silog.Logger
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+func (c *Client) log() *silog.Logger {
+	if c.Log == nil {
+		return silog.Nop()
+	}
+	return c.Log
+}
+
+// LatestRelease returns the most recent release.
+// Pre-releases are ignored.
+func (c *Client) LatestRelease(ctx context.Context) (*Release, error) {
+	apiURL := c.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	repo := c.Repository
+	if repo == "" {
+		repo = DefaultRepository
+	}
+
+	u, err := url.JoinPath(apiURL, "repos", repo, "releases", "latest")
+	if err != nil {
+		return nil, fmt.Errorf("build URL: %w", err)
+	}
+
+	body, err := c.get(ctx, u, "application/vnd.github+json", 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("get latest release: %w", err)
+	}
+
+	var res struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("decode latest release: %w", err)
+	}
+	if !semver.IsValid(canonical(res.TagName)) {
+		return nil, fmt.Errorf("latest release has an invalid version: %q", res.TagName)
+	}
+
+	rel := &Release{
+		Version: res.TagName,
+		URL:     res.HTMLURL,
+	}
+	for _, a := range res.Assets {
+		rel.Assets = append(rel.Assets, &Asset{Name: a.Name, URL: a.URL})
+	}
+	c.log().Debug("Found latest release", "version", rel.Version)
+	return rel, nil
+}
+
+// get sends a GET request and returns the response body,
+// reading at most limit bytes.
+func (c *Client) get(ctx context.Context, u, accept string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, _maxErrorBody))
+		msg := res.Status
+		if body := strings.TrimSpace(string(body)); body != "" {
+			msg += ": " + body
+		}
+		return nil, errors.New(msg)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("response is larger than %d bytes", limit)
+	}
+	return body, nil
+}
+
+// IsNewer reports whether version is newer than current.
+// It reports false if either is not a valid semantic version,
+// e.g. for development builds.
+//
+// The leading "v" is optional for both.
+func IsNewer(current, version string) bool {
+	current, version = canonical(current), canonical(version)
+	if !semver.IsValid(current) || !semver.IsValid(version) {
+		return false
+	}
+	return semver.Compare(version, current) > 0
+}
+
+// IsRelease reports whether version identifies a released build
+// instead of a development build.
+func IsRelease(version string) bool {
+	version = canonical(version)
+	return semver.IsValid(version) && !strings.HasSuffix(semver.Prerelease(version), "-dev")
+}
+
+// canonical adds the leading "v" that semver requires.
+// Release builds report versions without it.
+func canonical(version string) string {
+	if version != "" && !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
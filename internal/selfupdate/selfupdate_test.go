@@ -0,0 +1,255 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+)
+
+// buildArchive builds a tar.gz archive with the given files.
+func buildArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o755,
+			Size:     int64(len(body)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// releaseServer serves a release of git-spice
+// with the given archive for linux/amd64.
+type releaseServer struct {
+	*httptest.Server
+
+	Archive   []byte
+	Checksums string // defaults to the archive's checksum
+}
+
+func newReleaseServer(t *testing.T, archive []byte) *releaseServer {
+	t.Helper()
+
+	srv := &releaseServer{Archive: archive}
+	srv.Checksums = fmt.Sprintf("%x  git-spice.Linux-x86_64.tar.gz\n%x  git-spice.Darwin-arm64.tar.gz\n",
+		sha256.Sum256(archive), sha256.Sum256(nil))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/abhinav/git-spice/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"tag_name": "v0.20.0",
+			"html_url": "https://example.com/releases/v0.20.0",
+			"assets": []map[string]string{
+				{"name": "checksums.txt", "browser_download_url": srv.URL + "/download/checksums.txt"},
+				{"name": "git-spice.Linux-x86_64.tar.gz", "browser_download_url": srv.URL + "/download/linux.tar.gz"},
+			},
+		})
+	})
+	mux.HandleFunc("GET /download/checksums.txt", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(srv.Checksums))
+	})
+	mux.HandleFunc("GET /download/linux.tar.gz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(srv.Archive)
+	})
+
+	srv.Server = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_LatestRelease(t *testing.T) {
+	srv := newReleaseServer(t, nil)
+
+	client := Client{APIURL: srv.URL, Log: silogtest.New(t)}
+	rel, err := client.LatestRelease(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.20.0", rel.Version)
+	assert.Equal(t, "https://example.com/releases/v0.20.0", rel.URL)
+	assert.Len(t, rel.Assets, 2)
+
+	t.Run("NotFound", func(t *testing.T) {
+		client := Client{APIURL: srv.URL, Repository: "alice/example"}
+		_, err := client.LatestRelease(t.Context())
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+}
+
+func TestClient_Download(t *testing.T) {
+	linux := Platform{OS: "linux", Arch: "amd64"}
+	archive := buildArchive(t, map[string]string{
+		"README.md": "readme",
+		"git-spice": "new binary",
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		srv := newReleaseServer(t, archive)
+		client := Client{APIURL: srv.URL, Log: silogtest.New(t)}
+		rel, err := client.LatestRelease(t.Context())
+		require.NoError(t, err)
+
+		bin, err := client.Download(t.Context(), rel, linux)
+		require.NoError(t, err)
+		assert.Equal(t, "new binary", string(bin))
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		srv := newReleaseServer(t, archive)
+		srv.Archive = buildArchive(t, map[string]string{"git-spice": "tampered"})
+
+		client := Client{APIURL: srv.URL, Log: silogtest.New(t)}
+		rel, err := client.LatestRelease(t.Context())
+		require.NoError(t, err)
+
+		_, err = client.Download(t.Context(), rel, linux)
+		assert.ErrorContains(t, err, "checksum mismatch for git-spice.Linux-x86_64.tar.gz")
+	})
+
+	t.Run("NotListed", func(t *testing.T) {
+		srv := newReleaseServer(t, archive)
+		srv.Checksums = ""
+
+		client := Client{APIURL: srv.URL, Log: silogtest.New(t)}
+		rel, err := client.LatestRelease(t.Context())
+		require.NoError(t, err)
+
+		_, err = client.Download(t.Context(), rel, linux)
+		assert.ErrorContains(t, err, "checksums.txt does not list git-spice.Linux-x86_64.tar.gz")
+	})
+
+	t.Run("NoArchive", func(t *testing.T) {
+		srv := newReleaseServer(t, archive)
+		client := Client{APIURL: srv.URL, Log: silogtest.New(t)}
+		rel, err := client.LatestRelease(t.Context())
+		require.NoError(t, err)
+
+		_, err = client.Download(t.Context(), rel, Platform{OS: "plan9", Arch: "amd64"})
+		assert.ErrorContains(t, err, "release v0.20.0 has no archive for plan9/amd64")
+	})
+
+	t.Run("NoBinary", func(t *testing.T) {
+		srv := newReleaseServer(t, buildArchive(t, map[string]string{"README.md": "readme"}))
+		client := Client{APIURL: srv.URL, Log: silogtest.New(t)}
+		rel, err := client.LatestRelease(t.Context())
+		require.NoError(t, err)
+
+		_, err = client.Download(t.Context(), rel, linux)
+		assert.ErrorContains(t, err, "git-spice not found in archive")
+	})
+}
+
+func TestPlatform_ArchiveName(t *testing.T) {
+	tests := []struct {
+		give Platform
+		want string
+	}{
+		{Platform{OS: "linux", Arch: "amd64"}, "git-spice.Linux-x86_64.tar.gz"},
+		{Platform{OS: "linux", Arch: "arm64"}, "git-spice.Linux-aarch64.tar.gz"},
+		{Platform{OS: "linux", Arch: "arm", ARM: "7"}, "git-spice.Linux-armv7.tar.gz"},
+		{Platform{OS: "darwin", Arch: "arm64"}, "git-spice.Darwin-arm64.tar.gz"},
+		{Platform{OS: "darwin", Arch: "amd64"}, "git-spice.Darwin-x86_64.tar.gz"},
+		{Platform{OS: "windows", Arch: "amd64"}, "git-spice.Windows-x86_64.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give.ArchiveName())
+		})
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, version string
+		want             bool
+	}{
+		{"0.19.0", "v0.20.0", true},
+		{"v0.19.0", "v0.20.0", true},
+		{"0.20.0", "v0.20.0", false},
+		{"0.21.0", "v0.20.0", false},
+		{"0.21.0-dev", "v0.21.0", true},
+		{"dev", "v0.20.0", false},
+		{"0.20.0", "latest", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"/"+tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsNewer(tt.current, tt.version))
+		})
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	assert.True(t, IsRelease("0.20.0"))
+	assert.True(t, IsRelease("v0.20.0-rc.1"))
+	assert.False(t, IsRelease("0.21.0-dev"))
+	assert.False(t, IsRelease("dev"))
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "git-spice")
+	require.NoError(t, os.WriteFile(path, []byte("old binary"), 0o755))
+
+	require.NoError(t, Replace(path, []byte("new binary")))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new binary", string(got))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must not be left behind")
+}
+
+func TestManagedBy(t *testing.T) {
+	assert.Equal(t, "Homebrew", ManagedBy("/opt/homebrew/Caskroom/git-spice/0.20.0/git-spice"))
+	assert.Equal(t, "Homebrew", ManagedBy("/home/linuxbrew/.linuxbrew/Cellar/git-spice/0.20.0/bin/git-spice"))
+	assert.Equal(t, "Nix", ManagedBy("/nix/store/abc-git-spice-0.20.0/bin/git-spice"))
+	assert.Empty(t, ManagedBy("/usr/local/bin/git-spice"))
+}
+
+func TestCheckState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "git-spice", "update-check.json")
+
+	state, err := LoadCheckState(path)
+	require.NoError(t, err)
+	assert.Equal(t, new(CheckState), state, "missing file is empty state")
+
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, (&CheckState{CheckedAt: now, Latest: "v0.20.0"}).Save(path))
+
+	state, err = LoadCheckState(path)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(state.CheckedAt))
+	assert.Equal(t, "v0.20.0", state.Latest)
+}
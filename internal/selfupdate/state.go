@@ -0,0 +1,56 @@
+package selfupdate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CheckState records the result of the last check for a new release.
+// It's used to limit how often git-spice checks for new releases
+// on its own.
+type CheckState struct {
+	// CheckedAt is when the last check was made.
+	// This is recorded even if the check failed
+	// so that failures aren't retried on every command.
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// Latest is the latest version found by the last check, if any.
+	Latest string `json:"latest,omitempty"`
+}
+
+// LoadCheckState loads the CheckState stored at path.
+// It returns an empty CheckState if the file does not exist.
+func LoadCheckState(path string) (*CheckState, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return new(CheckState), nil
+		}
+		return nil, err
+	}
+
+	var state CheckState
+	if err := json.Unmarshal(bs, &state); err != nil {
+		return nil, fmt.Errorf("decode %v: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the CheckState to path,
+// creating its parent directory if needed.
+func (s *CheckState) Save(path string) error {
+	bs, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, bs, 0o644)
+}
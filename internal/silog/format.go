@@ -0,0 +1,186 @@
+package silog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+
+	"go.abhg.dev/log/silog"
+)
+
+// Format is the output format of a logger.
+type Format int32
+
+// Supported output formats.
+const (
+	// FormatText logs human-readable messages.
+	FormatText Format = iota
+
+	// FormatJSON logs one JSON object per message.
+	// Each object has the following fields:
+	//
+	//   - time: timestamp of the message
+	//   - level: name of the level, e.g. "info" or "error"
+	//   - msg: the message, including the logger's prefix if any
+	//   - fields: object holding attributes of the message, if any
+	FormatJSON
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Format(%d)", int32(f))
+	}
+}
+
+// formatHandler is a slog.Handler that sends messages
+// to either a text or JSON handler based on a format
+// that may be changed after the handler is created.
+type formatHandler struct {
+	format *atomic.Int32 // required
+	text   *silog.Handler
+	json   *jsonHandler
+}
+
+var _ slog.Handler = (*formatHandler)(nil)
+
+func (h *formatHandler) current() slog.Handler {
+	if Format(h.format.Load()) == FormatJSON {
+		return h.json
+	}
+	return h.text
+}
+
+func (h *formatHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return h.current().Enabled(ctx, lvl)
+}
+
+func (h *formatHandler) Handle(ctx context.Context, rec slog.Record) error {
+	return h.current().Handle(ctx, rec)
+}
+
+func (h *formatHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &formatHandler{
+		format: h.format,
+		text:   h.text.WithAttrs(attrs).(*silog.Handler),
+		json:   h.json.WithAttrs(attrs).(*jsonHandler),
+	}
+}
+
+func (h *formatHandler) WithGroup(name string) slog.Handler {
+	return &formatHandler{
+		format: h.format,
+		text:   h.text.WithGroup(name).(*silog.Handler),
+		json:   h.json.WithGroup(name).(*jsonHandler),
+	}
+}
+
+func (h *formatHandler) WithLevel(lvl slog.Leveler) *formatHandler {
+	return &formatHandler{
+		format: h.format,
+		text:   h.text.WithLevel(lvl),
+		json:   h.json.WithLevel(lvl),
+	}
+}
+
+func (h *formatHandler) WithPrefix(prefix string) *formatHandler {
+	return &formatHandler{
+		format: h.format,
+		text:   h.text.WithPrefix(prefix),
+		json:   h.json.WithPrefix(prefix),
+	}
+}
+
+func (h *formatHandler) WithLevelOffset(n int) *formatHandler {
+	return &formatHandler{
+		format: h.format,
+		text:   h.text.WithLevelOffset(n),
+		json:   h.json.WithLevelOffset(n),
+	}
+}
+
+// jsonHandler is a slog.Handler that writes JSON objects
+// with the same leveling and prefixing behavior as silog.Handler.
+type jsonHandler struct {
+	h      slog.Handler // required
+	lvl    slog.Leveler // required
+	prefix string
+	offset slog.Level
+}
+
+var _ slog.Handler = (*jsonHandler)(nil)
+
+func newJSONHandler(w io.Writer, lvl slog.Leveler) *jsonHandler {
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		// Leveling is handled by jsonHandler
+		// so that it can be changed with WithLevel.
+		Level: slog.Level(-1 << 10),
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.LevelKey {
+				if lvl, ok := a.Value.Any().(slog.Level); ok {
+					return slog.String(slog.LevelKey, Level(lvl).String())
+				}
+			}
+			return a
+		},
+	})
+
+	return &jsonHandler{
+		// All attributes are placed under "fields"
+		// to keep them from colliding with the top-level keys.
+		h:   h.WithGroup("fields"),
+		lvl: lvl,
+	}
+}
+
+func (h *jsonHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return lvl+h.offset >= h.lvl.Level()
+}
+
+func (h *jsonHandler) Handle(ctx context.Context, rec slog.Record) error {
+	rec.Level += h.offset
+	if rec.Level < h.lvl.Level() {
+		return nil
+	}
+	if h.prefix != "" {
+		rec.Message = h.prefix + ": " + rec.Message
+	}
+	return h.h.Handle(ctx, rec)
+}
+
+func (h *jsonHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newH := *h
+	newH.h = h.h.WithAttrs(attrs)
+	return &newH
+}
+
+func (h *jsonHandler) WithGroup(name string) slog.Handler {
+	newH := *h
+	newH.h = h.h.WithGroup(name)
+	return &newH
+}
+
+func (h *jsonHandler) WithLevel(lvl slog.Leveler) *jsonHandler {
+	newH := *h
+	newH.lvl = lvl
+	return &newH
+}
+
+func (h *jsonHandler) WithPrefix(prefix string) *jsonHandler {
+	newH := *h
+	newH.prefix = prefix
+	return &newH
+}
+
+func (h *jsonHandler) WithLevelOffset(n int) *jsonHandler {
+	newH := *h
+	newH.offset += slog.Level(n)
+	return &newH
+}
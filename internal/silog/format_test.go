@@ -0,0 +1,110 @@
+package silog_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+func TestLogger_formatJSON(t *testing.T) {
+	var buffer strings.Builder
+	log := silog.New(&buffer, &silog.Options{
+		Format: silog.FormatJSON,
+	})
+	assert.Equal(t, silog.FormatJSON, log.Format())
+
+	// readLines decodes the JSON objects logged so far,
+	// dropping their timestamps.
+	readLines := func(t *testing.T) []map[string]any {
+		t.Helper()
+		defer buffer.Reset()
+
+		var lines []map[string]any
+		for line := range strings.Lines(buffer.String()) {
+			var obj map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &obj), "line: %q", line)
+			assert.NotEmpty(t, obj["time"], "time")
+			delete(obj, "time")
+			lines = append(lines, obj)
+		}
+		return lines
+	}
+
+	t.Run("Message", func(t *testing.T) {
+		log.Info("foo")
+		log.Debug("not logged")
+		log.Errorf("bar %d", 42)
+
+		assert.Equal(t, []map[string]any{
+			{"level": "info", "msg": "foo"},
+			{"level": "error", "msg": "bar 42"},
+		}, readLines(t))
+	})
+
+	t.Run("Attrs", func(t *testing.T) {
+		log := log.With("k1", true)
+		log.Warn("foo", "k2", 2, "level", "not the level")
+
+		assert.Equal(t, []map[string]any{
+			{
+				"level": "warn",
+				"msg":   "foo",
+				"fields": map[string]any{
+					"k1":    true,
+					"k2":    float64(2),
+					"level": "not the level",
+				},
+			},
+		}, readLines(t))
+	})
+
+	t.Run("WithPrefix", func(t *testing.T) {
+		log.WithPrefix("prefix").Info("foo")
+
+		assert.Equal(t, []map[string]any{
+			{"level": "info", "msg": "prefix: foo"},
+		}, readLines(t))
+	})
+
+	t.Run("WithLevel", func(t *testing.T) {
+		log.WithLevel(silog.LevelDebug).Debug("foo")
+
+		assert.Equal(t, []map[string]any{
+			{"level": "debug", "msg": "foo"},
+		}, readLines(t))
+	})
+
+	t.Run("Downgrade", func(t *testing.T) {
+		log := log.Downgrade()
+		log.Warn("foo")
+		log.Info("not logged")
+
+		assert.Equal(t, []map[string]any{
+			{"level": "info", "msg": "foo"},
+		}, readLines(t))
+	})
+}
+
+func TestLogger_setFormat(t *testing.T) {
+	var buffer strings.Builder
+	log := silog.New(&buffer, nil)
+	clone := log.WithPrefix("clone")
+	assert.Equal(t, silog.FormatText, log.Format())
+
+	log.Info("foo")
+	assert.Equal(t, "INF foo\n", buffer.String())
+	buffer.Reset()
+
+	log.SetFormat(silog.FormatJSON)
+	clone.Info("bar")
+	assert.Contains(t, buffer.String(), `"level":"info","msg":"clone: bar"`)
+	buffer.Reset()
+
+	log.SetFormat(silog.FormatText)
+	clone.Info("baz")
+	assert.Equal(t, "INF clone: baz\n", buffer.String())
+}
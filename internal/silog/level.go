@@ -9,6 +9,7 @@ var _ slog.Leveler = (Level)(0)
 
 // Supported log levels.
 const (
+	LevelTrace = Level(slog.LevelDebug - 4)
 	LevelDebug = Level(slog.LevelDebug)
 	LevelInfo  = Level(slog.LevelInfo)
 	LevelWarn  = Level(slog.LevelWarn)
@@ -18,6 +19,7 @@ const (
 
 // Levels is a list of all supported log levels.
 var Levels = []Level{
+	LevelTrace,
 	LevelDebug,
 	LevelInfo,
 	LevelWarn,
@@ -28,6 +30,8 @@ var Levels = []Level{
 // String returns the string representation of the log level.
 func (l Level) String() string {
 	switch l {
+	case LevelTrace:
+		return "trace"
 	case LevelDebug:
 		return "debug"
 	case LevelInfo:
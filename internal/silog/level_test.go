@@ -12,6 +12,7 @@ func TestLevel_String(t *testing.T) {
 		level    silog.Level
 		expected string
 	}{
+		{silog.LevelTrace, "trace"},
 		{silog.LevelDebug, "debug"},
 		{silog.LevelInfo, "info"},
 		{silog.LevelWarn, "warn"},
@@ -31,7 +32,7 @@ func TestLevel_Dec(t *testing.T) {
 	tests := []struct {
 		give, want silog.Level
 	}{
-		{silog.LevelDebug, silog.Level(-8)},
+		{silog.LevelDebug, silog.LevelTrace},
 		{silog.LevelInfo, silog.LevelDebug},
 		{silog.LevelWarn, silog.LevelInfo},
 		{silog.LevelError, silog.LevelWarn},
@@ -14,6 +14,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"sync/atomic"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-isatty"
@@ -38,6 +39,10 @@ type Options struct {
 	// when a fatal log message is logged.
 	OnFatal func() // optional
 
+	// Format is the output format of the logger.
+	// The default is FormatText.
+	Format Format
+
 	// Style is the style to use for the logger.
 	// If unset, the style will be picked based on whether
 	// the output is a terminal or not.
@@ -51,6 +56,8 @@ type Options struct {
 type Logger struct {
 	sl      *slog.Logger   // required
 	lvl     *slog.LevelVar // required
+	format  *atomic.Int32  // required
+	style   *silog.Style   // required
 	onFatal func()         // required
 }
 
@@ -73,7 +80,7 @@ func New(w io.Writer, opts *Options) *Logger {
 		Level: LevelInfo,
 	})
 
-	must.Bef(opts.Level >= LevelDebug, "level must be >= LevelDebug, got %d", opts.Level)
+	must.Bef(opts.Level >= LevelTrace, "level must be >= LevelTrace, got %d", opts.Level)
 	must.Bef(opts.Level <= LevelError, "level must be <= LevelError, got %d", opts.Level)
 
 	if opts.Style == nil {
@@ -90,6 +97,15 @@ func New(w io.Writer, opts *Options) *Logger {
 		}
 	}
 
+	// Ensure that trace level has styling:
+	slogTrace := LevelTrace.Level()
+	if _, ok := opts.Style.LevelLabels[slogTrace]; !ok {
+		opts.Style.LevelLabels[slogTrace] = opts.Style.LevelLabels[slog.LevelDebug].SetString("TRC")
+	}
+	if _, ok := opts.Style.Messages[slogTrace]; !ok {
+		opts.Style.Messages[slogTrace] = opts.Style.Messages[slog.LevelDebug]
+	}
+
 	// Ensure that fatal level has styling:
 	slogFatal := LevelFatal.Level()
 	if _, ok := opts.Style.LevelLabels[slogFatal]; !ok {
@@ -101,16 +117,22 @@ func New(w io.Writer, opts *Options) *Logger {
 
 	var lvl slog.LevelVar
 	lvl.Set(opts.Level.Level())
-	sl := slog.New(silog.NewHandler(w, &silog.HandlerOptions{
-		Level: &lvl,
-		Style: opts.Style,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			if len(groups) == 0 && a.Key == slog.TimeKey {
-				return slog.Attr{}
-			}
-			return a
-		},
-	}))
+	var format atomic.Int32
+	format.Store(int32(opts.Format))
+	sl := slog.New(&formatHandler{
+		format: &format,
+		text: silog.NewHandler(w, &silog.HandlerOptions{
+			Level: &lvl,
+			Style: opts.Style,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}),
+		json: newJSONHandler(w, &lvl),
+	})
 
 	onFatal := opts.OnFatal
 	if onFatal == nil {
@@ -120,6 +142,8 @@ func New(w io.Writer, opts *Options) *Logger {
 	return &Logger{
 		sl:      sl,
 		lvl:     &lvl,
+		format:  &format,
+		style:   opts.Style,
 		onFatal: onFatal,
 	}
 }
@@ -152,6 +176,40 @@ func (l *Logger) SetLevel(lvl Level) {
 	l.lvl.Set(lvl.Level())
 }
 
+// Format returns the current output format of the logger.
+func (l *Logger) Format() Format {
+	if l == nil {
+		return FormatText
+	}
+	return Format(l.format.Load())
+}
+
+// SetFormat changes the output format of the logger
+// and all loggers cloned from it.
+func (l *Logger) SetFormat(f Format) {
+	if l == nil {
+		return
+	}
+	l.format.Store(int32(f))
+}
+
+// SetLevelColors changes the colors used for level labels
+// of the logger and all loggers cloned from it.
+// Levels not in the map keep their current colors.
+//
+// Colors are dropped if the output does not support them.
+func (l *Logger) SetLevelColors(colors map[Level]lipgloss.TerminalColor) {
+	if l == nil {
+		return
+	}
+	for lvl, color := range colors {
+		slvl := lvl.Level()
+		if label, ok := l.style.LevelLabels[slvl]; ok {
+			l.style.LevelLabels[slvl] = label.Foreground(color)
+		}
+	}
+}
+
 // WithLevel returns a copy of this logger
 // that will log at the given level.
 func (l *Logger) WithLevel(lvl Level) *Logger {
@@ -162,7 +220,7 @@ func (l *Logger) WithLevel(lvl Level) *Logger {
 	newL := l.Clone()
 	newL.lvl = new(slog.LevelVar)
 	newL.lvl.Set(lvl.Level())
-	newL.sl = slog.New(newL.sl.Handler().(*silog.Handler).WithLevel(newL.lvl))
+	newL.sl = slog.New(newL.sl.Handler().(*formatHandler).WithLevel(newL.lvl))
 	return newL
 }
 
@@ -186,7 +244,7 @@ func (l *Logger) WithPrefix(prefix string) *Logger {
 		return l
 	}
 	newL := l.Clone()
-	newL.sl = slog.New(newL.sl.Handler().(*silog.Handler).WithPrefix(prefix))
+	newL.sl = slog.New(newL.sl.Handler().(*formatHandler).WithPrefix(prefix))
 	return newL
 }
 
@@ -202,7 +260,7 @@ func (l *Logger) Downgrade() *Logger {
 		return l
 	}
 	newL := l.Clone()
-	newL.sl = slog.New(newL.sl.Handler().(*silog.Handler).WithLevelOffset(-4))
+	newL.sl = slog.New(newL.sl.Handler().(*formatHandler).WithLevelOffset(-4))
 	return newL
 }
 
@@ -238,6 +296,9 @@ func (l *Logger) Logf(lvl Level, format string, args ...any) {
 	l.Log(lvl, fmt.Sprintf(format, args...))
 }
 
+// Trace posts a structured log message with the level [LevelTrace].
+func (l *Logger) Trace(msg string, kvs ...any) { l.Log(LevelTrace, msg, kvs...) }
+
 // Debug posts a structured log message with the level [LevelDebug].
 func (l *Logger) Debug(msg string, kvs ...any) { l.Log(LevelDebug, msg, kvs...) }
 
@@ -254,6 +315,9 @@ func (l *Logger) Error(msg string, kvs ...any) { l.Log(LevelError, msg, kvs...)
 // It also exits the program with a non-zero status code.
 func (l *Logger) Fatal(msg string, kvs ...any) { l.Log(LevelFatal, msg, kvs...) }
 
+// Tracef posts a printf-style log message with the level [LevelTrace].
+func (l *Logger) Tracef(format string, args ...any) { l.Logf(LevelTrace, format, args...) }
+
 // Debugf posts a printf-style log message with the level [LevelDebug].
 func (l *Logger) Debugf(format string, args ...any) { l.Logf(LevelDebug, format, args...) }
 
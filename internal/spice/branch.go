@@ -1,6 +1,7 @@
 package spice
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -74,6 +75,16 @@ type LookupBranchResponse struct {
 	// or an empty string if the branch is not tracking an upstream branch.
 	UpstreamBranch string
 
+	// UpstreamRemote is the remote that the upstream branch was pushed to
+	// if it's different from the repository's remote, e.g. a fork.
+	// It is empty if the branch was pushed to the repository's remote.
+	UpstreamRemote string
+
+	// UpstreamHash is the last commit git-spice pushed
+	// to the upstream branch, if known.
+	// The upstream branch may have been updated since by someone else.
+	UpstreamHash git.Hash
+
 	// Head is the commit at the head of the branch.
 	Head git.Hash
 
@@ -82,6 +93,54 @@ type LookupBranchResponse struct {
 	//
 	// This is used to correctly display the history of the branch.
 	MergedDownstack []json.RawMessage
+
+	// Note is a free-form note attached to the branch by the user.
+	Note string
+
+	// Archived indicates that the branch was archived with ArchiveBranch.
+	Archived bool
+
+	// Config holds configuration overrides set on this branch
+	// with SetBranchConfig.
+	// Overrides inherited from downstack branches are not included.
+	// Use [Service.BranchConfig] for those.
+	Config map[string][]string
+
+	// Picks lists commits that were cherry-picked onto the branch
+	// with RecordPickedCommits, in the order they were picked.
+	Picks []state.PickedCommit
+
+	// UpstreamBase is the remote branch that this branch is based on,
+	// or nil if it's based on Base.
+	// Base is the trunk branch if this is set.
+	//
+	// See [Service.SetUpstreamBase].
+	UpstreamBase *state.UpstreamBase
+
+	// CommitChanges lists the changes submitted
+	// for individual commits of the branch, oldest commit first.
+	// This is empty unless the branch was submitted
+	// with one change per commit.
+	CommitChanges []state.CommitChange
+}
+
+// BaseRef returns the Git reference that the branch is based on.
+// This is the remote-tracking branch for UpstreamBase if it's set,
+// and Base otherwise.
+func (b *LookupBranchResponse) BaseRef() string {
+	if b.UpstreamBase != nil {
+		return b.UpstreamBase.Ref()
+	}
+	return b.Base
+}
+
+// baseName is the name of the branch that this branch is based on
+// for display purposes.
+func (b *LookupBranchResponse) baseName() string {
+	if b.UpstreamBase != nil {
+		return b.UpstreamBase.String()
+	}
+	return b.Base
 }
 
 // DeletedBranchError is returned when a branch was deleted out of band.
@@ -122,13 +181,15 @@ func (s *Service) LookupBranch(ctx context.Context, name string) (*LookupBranchR
 		// and was previously pushed to a remote,
 		// but the remote branch reference has since been deleted.
 		if resp.UpstreamBranch != "" {
-			ok, err := s.verifyUpstreamBranchRef(ctx, name, resp.UpstreamBranch)
+			ok, err := s.verifyUpstreamBranchRef(ctx, name, resp.UpstreamRemote, resp.UpstreamBranch)
 			if err != nil {
 				s.log.Warn("Unable to verify upstream branch reference",
 					"branch", name,
 					"upstream", resp.UpstreamBranch,
 					"error", err)
 				resp.UpstreamBranch = ""
+				resp.UpstreamRemote = ""
+				resp.UpstreamHash = ""
 			}
 			if !ok {
 				// Upstream branch reference has been deleted.
@@ -137,6 +198,8 @@ func (s *Service) LookupBranch(ctx context.Context, name string) (*LookupBranchR
 					"upstream", resp.UpstreamBranch)
 
 				resp.UpstreamBranch = ""
+				resp.UpstreamRemote = ""
+				resp.UpstreamHash = ""
 			}
 		}
 
@@ -144,8 +207,16 @@ func (s *Service) LookupBranch(ctx context.Context, name string) (*LookupBranchR
 			Base:            resp.Base,
 			BaseHash:        resp.BaseHash,
 			UpstreamBranch:  resp.UpstreamBranch,
+			UpstreamRemote:  resp.UpstreamRemote,
+			UpstreamHash:    resp.UpstreamHash,
 			Head:            head,
 			MergedDownstack: resp.MergedDownstack,
+			Note:            resp.Note,
+			Archived:        resp.Archived,
+			Config:          resp.Config,
+			Picks:           resp.Picks,
+			UpstreamBase:    resp.UpstreamBase,
+			CommitChanges:   resp.CommitChanges,
 		}
 
 		if resp.ChangeMetadata != nil {
@@ -210,11 +281,17 @@ func (s *Service) LookupBranch(ctx context.Context, name string) (*LookupBranchR
 // $branch's local state will forget about the upstream branch,
 // but the branch will not be deleted.
 //
+// upstreamRemote is the remote the branch was pushed to,
+// or empty if it was pushed to the repository's remote.
+//
 // Returns true if the upstream branch reference is valid.l
-func (s *Service) verifyUpstreamBranchRef(ctx context.Context, branch, upstreamBranch string) (ok bool, err error) {
-	remote, err := s.store.Remote()
-	if err != nil {
-		return false, nil // no remote, no upstream branch
+func (s *Service) verifyUpstreamBranchRef(ctx context.Context, branch, upstreamRemote, upstreamBranch string) (ok bool, err error) {
+	remote := upstreamRemote
+	if remote == "" {
+		remote, err = s.store.Remote()
+		if err != nil {
+			return false, nil // no remote, no upstream branch
+		}
 	}
 
 	upstreamRef := remote + "/" + upstreamBranch
@@ -302,7 +379,13 @@ func (s *Service) ForgetBranch(ctx context.Context, name string) error {
 // RenameBranch renames a branch tracked by git-spice.
 // This handles both, renaming the branch in the repository,
 // and updating the internal state to reflect the new name.
+//
+// Returns [ProtectedBranchError] if the branch is protected.
 func (s *Service) RenameBranch(ctx context.Context, oldName, newName string) error {
+	if err := s.CheckProtected(oldName); err != nil {
+		return err
+	}
+
 	oldBranch, err := s.LookupBranch(ctx, oldName)
 	if err != nil {
 		return fmt.Errorf("lookup %v: %w", oldName, err)
@@ -344,6 +427,12 @@ func (s *Service) RenameBranch(ctx context.Context, oldName, newName string) err
 		ChangeForge:    changeForge,
 		ChangeMetadata: changeMetadata,
 		UpstreamBranch: &oldBranch.UpstreamBranch,
+		UpstreamRemote: &oldBranch.UpstreamRemote,
+		UpstreamHash:   &oldBranch.UpstreamHash,
+		Note:           &oldBranch.Note,
+		Archived:       &oldBranch.Archived,
+		Config:         &oldBranch.Config,
+		AddPicks:       oldBranch.Picks,
 	}); err != nil {
 		return fmt.Errorf("create branch with name %v: %w", newName, err)
 	}
@@ -381,6 +470,175 @@ func (s *Service) RenameBranch(ctx context.Context, oldName, newName string) err
 	return nil
 }
 
+// SetBranchNote attaches a free-form note to a tracked branch,
+// replacing any existing note.
+// An empty note removes the note from the branch.
+func (s *Service) SetBranchNote(ctx context.Context, name, note string) error {
+	if _, err := s.store.LookupBranch(ctx, name); err != nil {
+		if errors.Is(err, state.ErrNotExist) {
+			return fmt.Errorf("branch not tracked: %v", name)
+		}
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	msg := fmt.Sprintf("%v: set note", name)
+	if note == "" {
+		msg = fmt.Sprintf("%v: clear note", name)
+	}
+
+	tx := s.store.BeginBranchTx()
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name: name,
+		Note: &note,
+	}); err != nil {
+		return fmt.Errorf("update note: %w", err)
+	}
+	if err := tx.Commit(ctx, msg); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+
+	return nil
+}
+
+// RecordPickedCommits records that the given commits
+// were cherry-picked onto a tracked branch.
+// They're added after any commits previously recorded for the branch.
+//
+// Nothing is recorded for the trunk branch.
+func (s *Service) RecordPickedCommits(ctx context.Context, name string, picks []state.PickedCommit) error {
+	if len(picks) == 0 || name == s.store.Trunk() {
+		return nil
+	}
+
+	tx := s.store.BeginBranchTx()
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name:     name,
+		AddPicks: picks,
+	}); err != nil {
+		return fmt.Errorf("record picked commits: %w", err)
+	}
+	if err := tx.Commit(ctx, fmt.Sprintf("%v: cherry-pick %d commit(s)", name, len(picks))); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+	return nil
+}
+
+// ArchiveBranch archives a tracked branch,
+// leaving it out of log, restack, and submit operations
+// that don't explicitly ask for it.
+// The branch and its state are otherwise left unchanged.
+//
+// All branches above the archived branch must already be archived
+// so that archived branches never sit below active ones.
+// Returns [ErrAlreadyArchived] if the branch is already archived.
+func (s *Service) ArchiveBranch(ctx context.Context, name string) error {
+	if name == s.store.Trunk() {
+		return state.ErrTrunk
+	}
+
+	graph, err := s.BranchGraph(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("get branch graph: %w", err)
+	}
+
+	branch, ok := graph.Lookup(name)
+	if !ok {
+		return fmt.Errorf("branch not tracked: %v", name)
+	}
+	if branch.Archived {
+		return ErrAlreadyArchived
+	}
+
+	var active []string
+	for above := range graph.Upstack(name) {
+		if above == name {
+			continue
+		}
+		if item, ok := graph.Lookup(above); ok && !item.Archived {
+			active = append(active, above)
+		}
+	}
+	if len(active) > 0 {
+		return &ActiveUpstackError{Branch: name, Upstack: active}
+	}
+
+	return s.setBranchArchived(ctx, name, true)
+}
+
+// UnarchiveBranch restores a branch archived with ArchiveBranch.
+//
+// The base of the branch must not be archived.
+// Returns [ErrNotArchived] if the branch is not archived.
+func (s *Service) UnarchiveBranch(ctx context.Context, name string) error {
+	branch, err := s.store.LookupBranch(ctx, name)
+	if err != nil {
+		if errors.Is(err, state.ErrNotExist) {
+			return fmt.Errorf("branch not tracked: %v", name)
+		}
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+	if !branch.Archived {
+		return ErrNotArchived
+	}
+
+	if branch.Base != s.store.Trunk() {
+		base, err := s.store.LookupBranch(ctx, branch.Base)
+		if err != nil {
+			return fmt.Errorf("lookup base %v: %w", branch.Base, err)
+		}
+		if base.Archived {
+			return fmt.Errorf("base branch %v is archived: unarchive it first", branch.Base)
+		}
+	}
+
+	return s.setBranchArchived(ctx, name, false)
+}
+
+func (s *Service) setBranchArchived(ctx context.Context, name string, archived bool) error {
+	msg := fmt.Sprintf("%v: archive", name)
+	if !archived {
+		msg = fmt.Sprintf("%v: unarchive", name)
+	}
+
+	tx := s.store.BeginBranchTx()
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name:     name,
+		Archived: &archived,
+	}); err != nil {
+		return fmt.Errorf("update branch: %w", err)
+	}
+	if err := tx.Commit(ctx, msg); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+	return nil
+}
+
+var (
+	// ErrAlreadyArchived is returned by ArchiveBranch
+	// if the branch is already archived.
+	ErrAlreadyArchived = errors.New("branch is already archived")
+
+	// ErrNotArchived is returned by UnarchiveBranch
+	// if the branch is not archived.
+	ErrNotArchived = errors.New("branch is not archived")
+)
+
+// ActiveUpstackError is returned by ArchiveBranch
+// when a branch has branches above it that are not archived.
+type ActiveUpstackError struct {
+	// Branch is the branch that was being archived.
+	Branch string
+
+	// Upstack lists the branches above Branch that are not archived,
+	// in upstack order.
+	Upstack []string
+}
+
+func (e *ActiveUpstackError) Error() string {
+	return fmt.Sprintf("%v has branches above it that are not archived: %v",
+		e.Branch, strings.Join(e.Upstack, ", "))
+}
+
 // LoadBranchItem is a single branch returned by LoadBranches.
 type LoadBranchItem struct {
 	// Name is the name of the branch.
@@ -404,9 +662,37 @@ type LoadBranchItem struct {
 	// was pushed to the upstream repository.
 	UpstreamBranch string
 
+	// UpstreamRemote is the remote that the branch was pushed to
+	// if it's different from the repository's remote.
+	UpstreamRemote string
+
 	// MergedDownstack contains information about any branches,
 	// which this one was based on, that have already been merged into trunk.
 	MergedDownstack []json.RawMessage
+
+	// Note is a free-form note attached to the branch by the user.
+	Note string
+
+	// Archived indicates that the branch was archived with ArchiveBranch.
+	Archived bool
+
+	// Config holds configuration overrides set on this branch.
+	// See [LookupBranchResponse.Config].
+	Config map[string][]string
+
+	// UpstreamBase is the remote branch that this branch is based on,
+	// or nil if it's based on Base.
+	// See [LookupBranchResponse.UpstreamBase].
+	UpstreamBase *state.UpstreamBase
+}
+
+// BaseRef returns the Git reference that the branch is based on.
+// See [LookupBranchResponse.BaseRef].
+func (b *LoadBranchItem) BaseRef() string {
+	if b.UpstreamBase != nil {
+		return b.UpstreamBase.Ref()
+	}
+	return b.Base
 }
 
 // LoadBranches loads all tracked branches
@@ -453,8 +739,13 @@ func (s *Service) LoadBranches(ctx context.Context) ([]LoadBranchItem, error) {
 					Base:            resp.Base,
 					BaseHash:        resp.BaseHash,
 					UpstreamBranch:  resp.UpstreamBranch,
+					UpstreamRemote:  resp.UpstreamRemote,
 					Change:          resp.Change,
 					MergedDownstack: resp.MergedDownstack,
+					Note:            resp.Note,
+					Archived:        resp.Archived,
+					Config:          resp.Config,
+					UpstreamBase:    resp.UpstreamBase,
 				})
 				mu.Unlock()
 			}
@@ -555,7 +846,10 @@ func (s *Service) ListAbove(ctx context.Context, base string) ([]string, error)
 // The given branch is the first element in the returned slice.
 //
 // The returned slice is ordered by branch position in the upstack.
-// It is guaranteed that for i < j, branch[i] is not a parent of branch[j].
+// It is guaranteed that for i < j, branch[j] is not the base of branch[i].
+// Branches that share a base are ordered by name,
+// so the result is stable across calls.
+// See [BranchGraph.Upstack] for details.
 func (s *Service) ListUpstack(ctx context.Context, start string) ([]string, error) {
 	graph, err := s.BranchGraph(ctx, nil)
 	if err != nil {
@@ -575,6 +869,9 @@ func (s *Service) ListUpstack(ctx context.Context, start string) ([]string, erro
 
 // FindTop returns the topmost branches in each upstack chain
 // starting at the given branch.
+//
+// The order is stable: branches are visited breadth-first,
+// and branches that share a base are visited in order of their names.
 func (s *Service) FindTop(ctx context.Context, start string) ([]string, error) {
 	graph, err := s.BranchGraph(ctx, nil)
 	if err != nil {
@@ -607,6 +904,79 @@ func (s *Service) ListDownstack(ctx context.Context, start string) ([]string, er
 	return downstack, nil
 }
 
+// StackItem is a single branch reported by
+// [Service.ListUpstackItems] and [Service.ListDownstackItems].
+//
+// It carries the information recorded for the branch
+// so that callers don't have to look it up again.
+type StackItem struct {
+	LoadBranchItem
+
+	// Trunk reports whether this item is the trunk branch.
+	// Only Name is set for trunk.
+	Trunk bool
+}
+
+// ListUpstackItems is a variant of [Service.ListUpstack]
+// that reports information about each branch.
+// Items are in the same order as [Service.ListUpstack].
+//
+// If start is trunk, the first item is trunk.
+func (s *Service) ListUpstackItems(ctx context.Context, start string) ([]StackItem, error) {
+	graph, err := s.BranchGraph(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get branch graph: %w", err)
+	}
+
+	var items []StackItem
+	for name := range graph.Upstack(start) {
+		items = append(items, graph.stackItem(name))
+	}
+	if len(items) == 0 {
+		// Untracked branch. Match ListUpstack.
+		items = []StackItem{{LoadBranchItem: LoadBranchItem{Name: start}}}
+	}
+	must.BeEqualf(start, items[0].Name, "starting branch must be first upstack")
+	return items, nil
+}
+
+// ListDownstackItemsOptions specifies options for
+// [Service.ListDownstackItems].
+type ListDownstackItemsOptions struct {
+	// IncludeTrunk specifies whether trunk should be reported
+	// as the last item in the list.
+	IncludeTrunk bool
+}
+
+// ListDownstackItems is a variant of [Service.ListDownstack]
+// that reports information about each branch.
+// Items are in the same order as [Service.ListDownstack]:
+// the given branch first, and the bottom-most branch last.
+// Each item is based on the item after it.
+//
+// If IncludeTrunk is set, trunk is added as the last item,
+// and start being trunk results in a single item.
+func (s *Service) ListDownstackItems(
+	ctx context.Context,
+	start string,
+	opts *ListDownstackItemsOptions,
+) ([]StackItem, error) {
+	opts = cmp.Or(opts, &ListDownstackItemsOptions{})
+	graph, err := s.BranchGraph(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get branch graph: %w", err)
+	}
+
+	var items []StackItem
+	for name := range graph.Downstack(start) {
+		items = append(items, graph.stackItem(name))
+	}
+	if opts.IncludeTrunk {
+		items = append(items, graph.stackItem(graph.Trunk()))
+	}
+	return items, nil
+}
+
 // FindBottom returns the bottom-most branch in the downstack chain
 // starting at the given branch just before trunk.
 //
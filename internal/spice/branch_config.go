@@ -0,0 +1,140 @@
+package spice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// BranchConfig is the set of configuration overrides
+// in effect for a branch.
+//
+// Overrides set on a branch apply to that branch
+// and all branches stacked on top of it,
+// unless they are overridden again further upstack.
+// Setting an override on the bottom-most branch of a stack
+// applies it to the whole stack.
+type BranchConfig map[string]*BranchConfigValue
+
+// BranchConfigValue is an override in a [BranchConfig].
+type BranchConfigValue struct {
+	// Values are the values of the key.
+	// Single-valued keys have exactly one value.
+	Values []string
+
+	// Branch is the branch the override was set on.
+	// This is either the branch itself
+	// or a branch downstack from it.
+	Branch string
+}
+
+// Get returns the values of the given key
+// and whether the key is overridden.
+func (c BranchConfig) Get(key string) ([]string, bool) {
+	v, ok := c[key]
+	if !ok {
+		return nil, false
+	}
+	return v.Values, true
+}
+
+// Keys returns the overridden keys in sorted order.
+func (c BranchConfig) Keys() []string {
+	return slices.Sorted(maps.Keys(c))
+}
+
+// ResolveBranchConfig reports the overrides in effect for a branch
+// given information about all tracked branches, e.g. from [Service.LoadBranches].
+//
+// It returns an empty BranchConfig if the branch is not tracked.
+func ResolveBranchConfig(branches []LoadBranchItem, name string) BranchConfig {
+	byName := make(map[string]*LoadBranchItem, len(branches))
+	for idx := range branches {
+		byName[branches[idx].Name] = &branches[idx]
+	}
+
+	cfg := make(BranchConfig)
+	seen := make(map[string]struct{})
+	for {
+		item, ok := byName[name]
+		if !ok {
+			break // trunk or untracked
+		}
+		if _, ok := seen[name]; ok {
+			break // cycle; reported elsewhere
+		}
+		seen[name] = struct{}{}
+
+		for key, values := range item.Config {
+			// Overrides closer to the branch win.
+			if _, ok := cfg[key]; !ok {
+				cfg[key] = &BranchConfigValue{
+					Values: values,
+					Branch: name,
+				}
+			}
+		}
+		name = item.Base
+	}
+	return cfg
+}
+
+// BranchConfig reports the configuration overrides in effect
+// for the given branch,
+// including those inherited from branches downstack from it.
+func (s *Service) BranchConfig(ctx context.Context, name string) (BranchConfig, error) {
+	branches, err := s.LoadBranches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load branches: %w", err)
+	}
+	return ResolveBranchConfig(branches, name), nil
+}
+
+// SetBranchConfig overrides the value of a configuration key
+// for a tracked branch and the branches stacked on top of it.
+// Values replace any values of the key previously set on the branch.
+// An empty list of values removes the override from the branch.
+//
+// The key and values are not validated.
+func (s *Service) SetBranchConfig(ctx context.Context, name, key string, values []string) error {
+	branch, err := s.store.LookupBranch(ctx, name)
+	if err != nil {
+		if errors.Is(err, state.ErrNotExist) {
+			return fmt.Errorf("branch not tracked: %v", name)
+		}
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	config := maps.Clone(branch.Config)
+	if config == nil {
+		config = make(map[string][]string)
+	}
+
+	msg := fmt.Sprintf("%v: set %v", name, key)
+	if len(values) == 0 {
+		if _, ok := config[key]; !ok {
+			return nil // nothing to do
+		}
+		delete(config, key)
+		msg = fmt.Sprintf("%v: unset %v", name, key)
+	} else {
+		config[key] = slices.Clone(values)
+	}
+
+	tx := s.store.BeginBranchTx()
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name:   name,
+		Config: &config,
+	}); err != nil {
+		return fmt.Errorf("update config: %w", err)
+	}
+	if err := tx.Commit(ctx, msg); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package spice_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+func TestResolveBranchConfig(t *testing.T) {
+	// main -> feat1 -> feat2 -> feat3
+	//      -> other
+	branches := []spice.LoadBranchItem{
+		{
+			Name: "feat1",
+			Base: "main",
+			Config: map[string][]string{
+				"spice.submit.reviewers": {"alice", "bob"},
+				"spice.submit.draft":     {"true"},
+			},
+		},
+		{
+			Name: "feat2",
+			Base: "feat1",
+			Config: map[string][]string{
+				"spice.submit.draft": {"false"},
+			},
+		},
+		{Name: "feat3", Base: "feat2"},
+		{Name: "other", Base: "main"},
+	}
+
+	tests := []struct {
+		name string
+		want spice.BranchConfig
+	}{
+		{
+			name: "feat1",
+			want: spice.BranchConfig{
+				"spice.submit.reviewers": {Values: []string{"alice", "bob"}, Branch: "feat1"},
+				"spice.submit.draft":     {Values: []string{"true"}, Branch: "feat1"},
+			},
+		},
+		{
+			name: "feat3",
+			want: spice.BranchConfig{
+				"spice.submit.reviewers": {Values: []string{"alice", "bob"}, Branch: "feat1"},
+				"spice.submit.draft":     {Values: []string{"false"}, Branch: "feat2"},
+			},
+		},
+		{name: "other", want: spice.BranchConfig{}},
+		{name: "main", want: spice.BranchConfig{}},
+		{name: "untracked", want: spice.BranchConfig{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spice.ResolveBranchConfig(branches, tt.name)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		cfg := spice.ResolveBranchConfig(branches, "feat3")
+		assert.Equal(t, []string{"spice.submit.draft", "spice.submit.reviewers"}, cfg.Keys())
+
+		values, ok := cfg.Get("spice.submit.draft")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"false"}, values)
+
+		_, ok = cfg.Get("spice.submit.label")
+		assert.False(t, ok)
+	})
+}
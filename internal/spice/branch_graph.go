@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"iter"
 	"slices"
+	"strings"
 
 	"go.abhg.dev/container/ring"
+	"go.abhg.dev/gs/internal/cli"
 )
 
 // BranchGraph is a full view of the graph of branches in the repository.
@@ -51,6 +53,17 @@ func NewBranchGraph(ctx context.Context, loader BranchLoader, opts *BranchGraphO
 		return nil, fmt.Errorf("load branches: %w", err)
 	}
 
+	if err := ValidateBranchGraph(loader.Trunk(), branches); err != nil {
+		return nil, err
+	}
+
+	// Listings from the graph are ordered by branch name
+	// wherever topological order leaves a choice.
+	// Don't rely on the loader for that.
+	branches = slices.SortedFunc(slices.Values(branches), func(a, b LoadBranchItem) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
 	names := make([]string, len(branches))
 	byName := make(map[string]int, len(branches))
 	byBase := make(map[string][]int, len(branches))
@@ -84,6 +97,135 @@ func NewBranchGraph(ctx context.Context, loader BranchLoader, opts *BranchGraphO
 	}, nil
 }
 
+// BranchEdge is an edge in the branch graph:
+// a branch and the base recorded for it.
+type BranchEdge struct {
+	Branch string
+	Base   string
+}
+
+func (e BranchEdge) String() string {
+	return e.Branch + " -> " + e.Base
+}
+
+// InvalidBranchGraphError is returned when the bases recorded
+// for tracked branches do not form a tree rooted at trunk.
+//
+// This is only possible if the stored state was edited by hand
+// or corrupted by a bug.
+type InvalidBranchGraphError struct {
+	// Cycles lists groups of branches whose bases form a cycle.
+	//
+	// The edges of each cycle are in order:
+	// the base of each edge is the branch of the next edge,
+	// and the base of the last edge is the branch of the first.
+	Cycles [][]BranchEdge
+
+	// UntrackedBases lists branches whose bases
+	// are neither trunk nor a tracked branch.
+	UntrackedBases []BranchEdge
+}
+
+func (e *InvalidBranchGraphError) Error() string {
+	var problems []string
+	for _, cycle := range e.Cycles {
+		path := make([]string, 0, len(cycle)+1)
+		for _, edge := range cycle {
+			path = append(path, edge.Branch)
+		}
+		path = append(path, cycle[0].Branch)
+		problems = append(problems, "cycle: "+strings.Join(path, " -> "))
+	}
+	for _, edge := range e.UntrackedBases {
+		problems = append(problems, "untracked base: "+edge.String())
+	}
+
+	return fmt.Sprintf("invalid branch graph: %v (use '%v repo doctor' to repair)",
+		strings.Join(problems, "; "), cli.Name())
+}
+
+// ValidateBranchGraph verifies that the given branches
+// form a tree rooted at trunk:
+// every branch reaches trunk by following its bases.
+//
+// Returns [InvalidBranchGraphError] listing the offending edges if not.
+func ValidateBranchGraph(trunk string, branches []LoadBranchItem) error {
+	baseOf := make(map[string]string, len(branches))
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		baseOf[branch.Name] = branch.Base
+		names = append(names, branch.Name)
+	}
+	slices.Sort(names) // for deterministic reporting
+
+	var graphErr InvalidBranchGraphError
+
+	// Each branch has exactly one base,
+	// so following bases from any branch either reaches
+	// trunk, an untracked branch, or a cycle.
+	// Branches already visited by a previous walk are not revisited.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(branches))
+	for _, name := range names {
+		var path []string
+		current := name
+		for {
+			if current == trunk || state[current] == visited {
+				break
+			}
+
+			if state[current] == visiting {
+				// Found a cycle starting at current.
+				start := slices.Index(path, current)
+				graphErr.Cycles = append(graphErr.Cycles, cycleEdges(path[start:], baseOf))
+				break
+			}
+
+			base, tracked := baseOf[current]
+			if !tracked {
+				break // trunk or untracked
+			}
+
+			state[current] = visiting
+			path = append(path, current)
+			if base != trunk {
+				if _, ok := baseOf[base]; !ok {
+					graphErr.UntrackedBases = append(graphErr.UntrackedBases, BranchEdge{
+						Branch: current,
+						Base:   base,
+					})
+				}
+			}
+			current = base
+		}
+
+		for _, branch := range path {
+			state[branch] = visited
+		}
+	}
+
+	if len(graphErr.Cycles) == 0 && len(graphErr.UntrackedBases) == 0 {
+		return nil
+	}
+	return &graphErr
+}
+
+// cycleEdges returns the edges of a cycle formed by the given branches,
+// starting at the alphabetically first branch.
+func cycleEdges(cycle []string, baseOf map[string]string) []BranchEdge {
+	start := slices.Index(cycle, slices.Min(cycle))
+	edges := make([]BranchEdge, 0, len(cycle))
+	for i := range cycle {
+		branch := cycle[(start+i)%len(cycle)]
+		edges = append(edges, BranchEdge{Branch: branch, Base: baseOf[branch]})
+	}
+	return edges
+}
+
 // Trunk reports the name of the trunk branch in the repository.
 func (g *BranchGraph) Trunk() string {
 	return g.trunk
@@ -123,6 +265,23 @@ func (g *BranchGraph) Lookup(name string) (item LoadBranchItem, ok bool) {
 	return g.branches[idx], true
 }
 
+// stackItem builds a StackItem for the given branch.
+// Only the name is set for trunk and untracked branches.
+func (g *BranchGraph) stackItem(name string) StackItem {
+	if name == g.trunk {
+		return StackItem{
+			LoadBranchItem: LoadBranchItem{Name: name},
+			Trunk:          true,
+		}
+	}
+
+	item, ok := g.Lookup(name)
+	if !ok {
+		item = LoadBranchItem{Name: name}
+	}
+	return StackItem{LoadBranchItem: item}
+}
+
 // Worktree returns the Git worktree where this branch is checked out.
 // An empty string is returned if the branch is not checked out anywhere,
 // or is not a tracked branch.
@@ -139,6 +298,7 @@ func (g *BranchGraph) Worktree(name string) string {
 }
 
 // Aboves returns branches directly above the given branch,
+// ordered by name.
 func (g *BranchGraph) Aboves(branch string) iter.Seq[string] {
 	return func(yield func(string) bool) {
 		for _, idx := range g.byBase[branch] {
@@ -158,6 +318,9 @@ func (g *BranchGraph) Aboves(branch string) iter.Seq[string] {
 // it is guaranteed that a branch seen earlier in the sequence
 // does not use a branch seen later as its base.
 //
+// The order is stable: branches are visited breadth-first,
+// and branches that share a base are visited in order of their names.
+//
 // If branch is trunk, this reports all branches in the repository,
 // including trunk itself.
 func (g *BranchGraph) Upstack(branch string) iter.Seq[string] {
@@ -269,6 +432,7 @@ func (g *BranchGraph) Bottom(branch string) string {
 // Branches are reversed in topological order:
 // it is guaranteed that a branch seen earlier in the sequence
 // does not use a branch seen later as its base.
+// Upstack branches are ordered as in [BranchGraph.Upstack].
 //
 // The branch itself is always included in the stack,
 // but its position is based on the number of downstack branches
@@ -236,6 +236,127 @@ func TestBranchGraph(t *testing.T) {
 	})
 }
 
+func TestNewBranchGraph_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		branches []LoadBranchItem
+		want     *InvalidBranchGraphError
+	}{
+		{
+			name: "SelfCycle",
+			branches: []LoadBranchItem{
+				{Name: "feat1", Base: "feat1"},
+			},
+			want: &InvalidBranchGraphError{
+				Cycles: [][]BranchEdge{
+					{{Branch: "feat1", Base: "feat1"}},
+				},
+			},
+		},
+		{
+			name: "Cycle",
+			branches: []LoadBranchItem{
+				{Name: "feat1", Base: "main"},
+				{Name: "feat4", Base: "feat2"},
+				{Name: "feat3", Base: "feat4"},
+				{Name: "feat2", Base: "feat3"},
+				{Name: "feat5", Base: "feat3"},
+			},
+			want: &InvalidBranchGraphError{
+				Cycles: [][]BranchEdge{
+					{
+						{Branch: "feat2", Base: "feat3"},
+						{Branch: "feat3", Base: "feat4"},
+						{Branch: "feat4", Base: "feat2"},
+					},
+				},
+			},
+		},
+		{
+			name: "UntrackedBase",
+			branches: []LoadBranchItem{
+				{Name: "feat1", Base: "main"},
+				{Name: "feat2", Base: "gone"},
+				{Name: "feat3", Base: "feat2"},
+			},
+			want: &InvalidBranchGraphError{
+				UntrackedBases: []BranchEdge{
+					{Branch: "feat2", Base: "gone"},
+				},
+			},
+		},
+		{
+			name: "Multiple",
+			branches: []LoadBranchItem{
+				{Name: "b", Base: "a"},
+				{Name: "a", Base: "b"},
+				{Name: "c", Base: "gone"},
+				{Name: "d", Base: "d"},
+			},
+			want: &InvalidBranchGraphError{
+				Cycles: [][]BranchEdge{
+					{{Branch: "a", Base: "b"}, {Branch: "b", Base: "a"}},
+					{{Branch: "d", Base: "d"}},
+				},
+				UntrackedBases: []BranchEdge{
+					{Branch: "c", Base: "gone"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBranchGraph(t.Context(), &branchLoaderStub{
+				trunk:    "main",
+				branches: tt.branches,
+			}, nil)
+			require.Error(t, err)
+
+			var graphErr *InvalidBranchGraphError
+			require.ErrorAs(t, err, &graphErr)
+			assert.Equal(t, tt.want, graphErr)
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		err := ValidateBranchGraph("main", []LoadBranchItem{
+			{Name: "a", Base: "b"},
+			{Name: "b", Base: "a"},
+			{Name: "c", Base: "gone"},
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "cycle: a -> b -> a; untracked base: c -> gone")
+	})
+}
+
+func TestBranchGraph_loadOrder(t *testing.T) {
+	// Regression test:
+	// branches sharing a base were listed in load order.
+	branches := []LoadBranchItem{
+		{Name: "a23", Base: "main"},
+		{Name: "V", Base: "a23"},
+		{Name: "1E", Base: "a23"},
+		{Name: "x", Base: "1E"},
+		{Name: "b", Base: "V"},
+	}
+
+	for _, order := range [][]LoadBranchItem{
+		branches,
+		{branches[4], branches[3], branches[2], branches[1], branches[0]},
+		{branches[2], branches[0], branches[4], branches[1], branches[3]},
+	} {
+		graph, err := NewBranchGraph(t.Context(), &branchLoaderStub{
+			trunk:    "main",
+			branches: order,
+		}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a23", "1E", "V", "x", "b"}, slices.Collect(graph.Upstack("a23")))
+		assert.Equal(t, []string{"1E", "V"}, slices.Collect(graph.Aboves("a23")))
+	}
+}
+
 func TestBranchGraphRapid(t *testing.T) {
 	rapid.Check(t, testBranchGraphRapid)
 }
@@ -272,6 +393,19 @@ func testBranchGraphRapid(t *rapid.T) {
 	}, nil)
 	require.NoError(t, err)
 
+	baseOf := make(map[string]string, len(branchItems))
+	for _, item := range branchItems {
+		baseOf[item.Name] = item.Base
+	}
+
+	// The same branches reported by the loader in a different order
+	// must produce identical listings.
+	shuffledGraph, err := NewBranchGraph(t.Context(), &branchLoaderStub{
+		trunk:    trunk,
+		branches: rapid.Permutation(branchItems).Draw(t, "shuffledBranches"),
+	}, nil)
+	require.NoError(t, err)
+
 	t.Repeat(map[string]func(*rapid.T){
 		"All": func(t *rapid.T) {
 			var gotNames []string
@@ -306,6 +440,16 @@ func testBranchGraphRapid(t *rapid.T) {
 			if assert.NotEmpty(t, upstack, "upstack should not be empty for tracked branches") {
 				assert.Equal(t, branch, upstack[0], "upstack should start with the branch itself")
 			}
+
+			// Every branch after the first must be based on
+			// a branch seen earlier in the list.
+			for i, name := range upstack[1:] {
+				assert.Contains(t, upstack[:i+1], baseOf[name],
+					"base of %q must be listed before it", name)
+			}
+
+			assert.Equal(t, upstack, slices.Collect(shuffledGraph.Upstack(branch)),
+				"upstack order must not depend on load order")
 		},
 		"Tops": func(t *rapid.T) {
 			branch := rapid.SampledFrom(allBranches).Draw(t, "branch")
@@ -325,6 +469,14 @@ func testBranchGraphRapid(t *rapid.T) {
 				assert.Empty(t, downstack, "downstack should be empty for trunk branch")
 			} else if assert.NotEmpty(t, downstack, "downstack should not be empty for tracked branches") {
 				assert.Equal(t, branch, downstack[0], "downstack should start with the branch itself")
+				assert.Equal(t, trunk, baseOf[downstack[len(downstack)-1]],
+					"last downstack branch should be based on trunk")
+			}
+
+			// Each branch must be based on the one after it.
+			for i := 1; i < len(downstack); i++ {
+				assert.Equal(t, downstack[i], baseOf[downstack[i-1]],
+					"%q must be based on the next branch", downstack[i-1])
 			}
 		},
 		"Bottom": func(t *rapid.T) {
@@ -347,6 +499,18 @@ func testBranchGraphRapid(t *rapid.T) {
 			if assert.NotEmpty(t, stack, "stack should not be empty for tracked branches") {
 				assert.Contains(t, stack, branch, "stack should contain the branch itself")
 			}
+
+			// Every branch must be listed after its base
+			// unless the base is outside the stack (trunk).
+			for i, name := range stack {
+				if base := baseOf[name]; slices.Contains(stack, base) {
+					assert.Contains(t, stack[:i], base,
+						"base of %q must be listed before it", name)
+				}
+			}
+
+			assert.Equal(t, stack, slices.Collect(shuffledGraph.Stack(branch)),
+				"stack order must not depend on load order")
 		},
 		"StackLinear": func(t *rapid.T) {
 			branch := rapid.SampledFrom(allBranches).Draw(t, "branch")
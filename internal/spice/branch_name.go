@@ -0,0 +1,153 @@
+package spice
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// BranchNameData is the data available to branch name templates
+// expanded with [ExpandBranchNameTemplate].
+type BranchNameData struct {
+	// User is the name of the current user, replacing {user}.
+	User string
+
+	// Ticket is the ID of the ticket the change is for, replacing {ticket}.
+	// This may be empty.
+	Ticket string
+
+	// Slug is a short description of the change, replacing {slug}.
+	Slug string
+}
+
+var _branchNamePlaceholderRe = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ExpandBranchNameTemplate expands the placeholders in a branch name template,
+// e.g. "{user}/{ticket}-{slug}".
+//
+// Separators ('-', '_', '.') left dangling by empty placeholders
+// are removed, as are empty path components.
+// So the example above expands to "alice/fix-login"
+// if there is no ticket.
+//
+// Returns an error if the template contains an unknown placeholder.
+func ExpandBranchNameTemplate(tmpl string, data BranchNameData) (string, error) {
+	var expandErr error
+	name := _branchNamePlaceholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		switch p {
+		case "{user}":
+			return data.User
+		case "{ticket}":
+			return data.Ticket
+		case "{slug}":
+			return data.Slug
+		default:
+			if expandErr == nil {
+				expandErr = fmt.Errorf("unknown placeholder %v: expected {user}, {ticket}, or {slug}", p)
+			}
+			return ""
+		}
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	parts := strings.Split(name, "/")
+	cleaned := parts[:0]
+	for _, part := range parts {
+		part = _repeatedSeparatorRe.ReplaceAllStringFunc(part, func(s string) string {
+			return s[:1]
+		})
+		part = strings.Trim(part, "-_.")
+		if part != "" {
+			cleaned = append(cleaned, part)
+		}
+	}
+	return strings.Join(cleaned, "/"), nil
+}
+
+// UpstreamBranchData is the data available to upstream branch name templates
+// expanded with [ExpandUpstreamBranchTemplate].
+type UpstreamBranchData struct {
+	// Branch is the name of the local branch, replacing {branch}.
+	Branch string
+
+	// User is the name of the current user, replacing {user}.
+	User string
+}
+
+// ExpandUpstreamBranchTemplate expands the placeholders in a template
+// for the name of a branch when it's pushed to a remote,
+// e.g. "users/{user}/{branch}".
+//
+// Returns an error if the template contains an unknown placeholder,
+// or doesn't contain {branch}.
+func ExpandUpstreamBranchTemplate(tmpl string, data UpstreamBranchData) (string, error) {
+	if !strings.Contains(tmpl, "{branch}") {
+		return "", fmt.Errorf("template %q must contain {branch}", tmpl)
+	}
+
+	var expandErr error
+	name := _branchNamePlaceholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		switch p {
+		case "{branch}":
+			return data.Branch
+		case "{user}":
+			return data.User
+		default:
+			if expandErr == nil {
+				expandErr = fmt.Errorf("unknown placeholder %v: expected {branch} or {user}", p)
+			}
+			return ""
+		}
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return name, nil
+}
+
+// IdentUser returns the name of the user in a Git identity
+// as reported by 'git var GIT_AUTHOR_IDENT'.
+// This is the lowercased local part of the email address.
+func IdentUser(ident string) (string, error) {
+	// Name <email> timestamp timezone
+	_, email, ok := strings.Cut(ident, "<")
+	if ok {
+		email, _, ok = strings.Cut(email, ">")
+	}
+	user, _, _ := strings.Cut(email, "@")
+	if !ok || user == "" {
+		return "", fmt.Errorf("no email address in author %q", ident)
+	}
+	return strings.ToLower(user), nil
+}
+
+var _repeatedSeparatorRe = regexp.MustCompile(`[-_.]{2,}`)
+
+// _ticketRe matches issue tracker IDs like "ABC-123".
+var _ticketRe = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+
+// FindTicket looks for an issue tracker ID like "ABC-123"
+// in a commit subject.
+//
+// It returns the ID, and the subject with the ID removed
+// so that it may be used to generate the rest of the branch name.
+// If there's no ID, or nothing else is left in the subject,
+// the subject is returned unchanged.
+func FindTicket(subject string) (ticket, rest string) {
+	loc := _ticketRe.FindStringIndex(subject)
+	if loc == nil {
+		return "", subject
+	}
+
+	ticket = subject[loc[0]:loc[1]]
+	rest = subject[:loc[0]] + subject[loc[1]:]
+	if !strings.ContainsFunc(rest, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}) {
+		return ticket, subject
+	}
+	return ticket, rest
+}
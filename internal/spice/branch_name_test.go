@@ -0,0 +1,129 @@
+package spice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandBranchNameTemplate(t *testing.T) {
+	data := BranchNameData{
+		User:   "alice",
+		Ticket: "ABC-123",
+		Slug:   "fix-login",
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		data BranchNameData
+		want string
+
+		wantErr string
+	}{
+		{name: "Slug", tmpl: "{slug}", data: data, want: "fix-login"},
+		{name: "All", tmpl: "{user}/{ticket}-{slug}", data: data, want: "alice/ABC-123-fix-login"},
+		{
+			name: "NoTicket",
+			tmpl: "{user}/{ticket}-{slug}",
+			data: BranchNameData{User: "alice", Slug: "fix-login"},
+			want: "alice/fix-login",
+		},
+		{
+			name: "TicketComponent",
+			tmpl: "{user}/{ticket}/{slug}",
+			data: BranchNameData{User: "alice", Slug: "fix-login"},
+			want: "alice/fix-login",
+		},
+		{
+			name: "InnerSeparator",
+			tmpl: "{slug}_{ticket}_wip",
+			data: BranchNameData{Slug: "fix-login"},
+			want: "fix-login_wip",
+		},
+		{
+			name:    "Unknown",
+			tmpl:    "{user}/{date}-{slug}",
+			data:    data,
+			wantErr: "unknown placeholder {date}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandBranchNameTemplate(tt.tmpl, tt.data)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandUpstreamBranchTemplate(t *testing.T) {
+	data := UpstreamBranchData{Branch: "feat/login", User: "alice"}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+
+		wantErr string
+	}{
+		{name: "Branch", tmpl: "{branch}", want: "feat/login"},
+		{name: "UserPrefix", tmpl: "users/{user}/{branch}", want: "users/alice/feat/login"},
+		{name: "NoBranch", tmpl: "users/{user}", wantErr: "must contain {branch}"},
+		{name: "Unknown", tmpl: "{slug}/{branch}", wantErr: "unknown placeholder {slug}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandUpstreamBranchTemplate(tt.tmpl, data)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdentUser(t *testing.T) {
+	user, err := IdentUser("Alice <Alice.Smith@example.com> 1718313752 +0000")
+	require.NoError(t, err)
+	assert.Equal(t, "alice.smith", user)
+
+	_, err = IdentUser("Alice 1718313752 +0000")
+	assert.ErrorContains(t, err, "no email address")
+}
+
+func TestFindTicket(t *testing.T) {
+	tests := []struct {
+		give string
+
+		wantTicket string
+		wantRest   string
+	}{
+		{give: "Fix login", wantRest: "Fix login"},
+		{give: "ABC-123: Fix login", wantTicket: "ABC-123", wantRest: ": Fix login"},
+		{give: "Fix login (PROJ2-7)", wantTicket: "PROJ2-7", wantRest: "Fix login ()"},
+		{give: "ABC-123", wantTicket: "ABC-123", wantRest: "ABC-123"},
+		{give: "Bump abc-123", wantRest: "Bump abc-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			ticket, rest := FindTicket(tt.give)
+			assert.Equal(t, tt.wantTicket, ticket)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
@@ -308,3 +308,102 @@ func TestService_LoadBranches_lookupError_issue926(t *testing.T) {
 		_, _ = svc.LoadBranches(ctx)
 	})
 }
+
+func TestService_ListStackItems(t *testing.T) {
+	ctx := t.Context()
+
+	// main -> feat1 -> {feat2, feat3}
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:     storage.NewDB(make(storage.MapBackend)),
+		Trunk:  "main",
+		Remote: "origin",
+		Log:    silogtest.New(t),
+	})
+	require.NoError(t, err)
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "feat1", Base: "main", BaseHash: "abc"},
+			{Name: "feat3", Base: "feat1", BaseHash: "abc"},
+			{Name: "feat2", Base: "feat1", BaseHash: "abc"},
+		},
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	mockRepo := NewMockGitRepository(mockCtrl)
+	mockRepo.EXPECT().
+		PeelToCommit(gomock.Any(), gomock.Any()).
+		Return(git.Hash("def"), nil).
+		AnyTimes()
+
+	svc := NewService(mockRepo, NewMockGitWorktree(mockCtrl), store, nil, silogtest.New(t))
+
+	type item struct {
+		Name  string
+		Base  string
+		Trunk bool
+	}
+	simplify := func(items []StackItem) []item {
+		out := make([]item, len(items))
+		for i, it := range items {
+			out[i] = item{Name: it.Name, Base: it.Base, Trunk: it.Trunk}
+		}
+		return out
+	}
+
+	t.Run("Upstack", func(t *testing.T) {
+		items, err := svc.ListUpstackItems(ctx, "main")
+		require.NoError(t, err)
+		assert.Equal(t, []item{
+			{Name: "main", Trunk: true},
+			{Name: "feat1", Base: "main"},
+			{Name: "feat2", Base: "feat1"},
+			{Name: "feat3", Base: "feat1"},
+		}, simplify(items))
+
+		names, err := svc.ListUpstack(ctx, "main")
+		require.NoError(t, err)
+		for i, it := range items {
+			assert.Equal(t, names[i], it.Name)
+		}
+	})
+
+	t.Run("UpstackUntracked", func(t *testing.T) {
+		items, err := svc.ListUpstackItems(ctx, "other")
+		require.NoError(t, err)
+		assert.Equal(t, []item{{Name: "other"}}, simplify(items))
+	})
+
+	t.Run("Downstack", func(t *testing.T) {
+		items, err := svc.ListDownstackItems(ctx, "feat3", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []item{
+			{Name: "feat3", Base: "feat1"},
+			{Name: "feat1", Base: "main"},
+		}, simplify(items))
+		assert.Equal(t, git.Hash("def"), items[0].Head)
+	})
+
+	t.Run("DownstackIncludeTrunk", func(t *testing.T) {
+		items, err := svc.ListDownstackItems(ctx, "feat3", &ListDownstackItemsOptions{
+			IncludeTrunk: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []item{
+			{Name: "feat3", Base: "feat1"},
+			{Name: "feat1", Base: "main"},
+			{Name: "main", Trunk: true},
+		}, simplify(items))
+	})
+
+	t.Run("DownstackTrunk", func(t *testing.T) {
+		items, err := svc.ListDownstackItems(ctx, "main", nil)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+
+		items, err = svc.ListDownstackItems(ctx, "main", &ListDownstackItemsOptions{
+			IncludeTrunk: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []item{{Name: "main", Trunk: true}}, simplify(items))
+	})
+}
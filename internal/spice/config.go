@@ -41,6 +41,9 @@ var _ GitConfigLister = (*git.Config)(nil)
 //
 // Configuration for git-spice is specified via git-config.
 // These can be system, user, repository, or worktree-level.
+// Defaults may also be checked into the repository
+// in a [RepoConfigFile] read with [LayeredConfig].
+// Values set in git-config take precedence over values in that file.
 //
 // The configuration keys are read from the root namespace "spice"
 // for keys in the CLI grammar tagged with the `config:"key"` tag.
@@ -195,6 +198,17 @@ func LoadConfig(ctx context.Context, cfg GitConfigLister, opts ConfigOptions) (*
 	}, nil
 }
 
+// Value returns the value of a git-spice configuration key
+// specified without the "spice." prefix, e.g. "repoInit.trunk".
+// If the key has multiple values, the last one is returned.
+func (c *Config) Value(key string) (string, bool) {
+	values := c.items[git.ConfigKey(_spiceSection+"."+key).Canonical()]
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
+
 // ExperimentEnabled reports whether the given experimental feature is enabled.
 func (c *Config) ExperimentEnabled(name string) bool {
 	_, ok := c.experiments[strings.ToLower(name)]
@@ -0,0 +1,386 @@
+package spice
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/buildkite/shellwords"
+	"go.abhg.dev/gs/internal/cli/experiment"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// GitConfigOriginLister provides access to git-config output
+// along with where each entry was defined.
+type GitConfigOriginLister interface {
+	ListOriginRegexp(context.Context, ...string) iter.Seq2[git.ConfigEntry, error]
+}
+
+var _ GitConfigOriginLister = (*git.Config)(nil)
+
+// ConfigProblem is a problem found in git-spice configuration
+// by [CheckConfig].
+type ConfigProblem struct {
+	// Key is the configuration key with the problem.
+	// Known keys are spelled as they are documented,
+	// e.g. "spice.submit.navigationComment".
+	Key string
+
+	// Value is the value of the key.
+	Value string
+
+	// Origin is where the value was defined,
+	// as reported by [git.ConfigEntry.Origin].
+	Origin string
+
+	// Line is the 1-indexed line in the origin file
+	// that the value was defined on.
+	// This is zero if the line is not known.
+	Line int
+
+	// Message describes the problem.
+	Message string
+}
+
+// Location reports a human-readable description of where the value
+// with the problem was defined, e.g. "/home/user/.gitconfig:12".
+func (p *ConfigProblem) Location() string {
+	return git.FormatConfigOrigin(p.Origin, p.Line)
+}
+
+// CheckConfigOptions specifies options for [CheckConfig].
+type CheckConfigOptions struct {
+	// Log specifies the logger to use for logging.
+	// Defaults to no logging.
+	Log *silog.Logger
+
+	// Forge is the forge of the repository that changes are submitted to,
+	// and Capabilities are the capabilities of that repository.
+	//
+	// If Forge is nil, configuration is not checked
+	// against the capabilities of the repository.
+	Forge        forge.Forge
+	Capabilities forge.Capabilities
+}
+
+// CheckConfig inspects git-spice configuration for problems.
+// It reports keys that are not known to the given Kong application,
+// values that cannot be decoded for their flags,
+// deprecated keys, and combinations of values
+// that cause other keys to have no effect.
+//
+// An empty list is returned if no problems are found.
+func CheckConfig(
+	ctx context.Context,
+	cfg GitConfigOriginLister,
+	app *kong.Application,
+	opts *CheckConfigOptions,
+) ([]*ConfigProblem, error) {
+	if opts == nil {
+		opts = &CheckConfigOptions{}
+	}
+	if opts.Log == nil {
+		opts.Log = silog.Nop()
+	}
+
+	c := configChecker{
+		log:         opts.Log,
+		schema:      NewConfigSchema(app),
+		experiments: make(map[string]struct{}),
+		values:      make(map[git.ConfigKey]*configValue),
+		lines:       &git.ConfigLineFinder{Log: opts.Log},
+	}
+	c.indexModel(app.Node)
+
+	for entry, err := range cfg.ListOriginRegexp(ctx, "^"+_spiceSection+`\.`) {
+		if err != nil {
+			return nil, fmt.Errorf("list configuration: %w", err)
+		}
+		c.checkEntry(entry)
+	}
+
+	c.checkConflicts()
+	if opts.Forge != nil {
+		c.checkCapabilities(opts.Forge, opts.Capabilities)
+	}
+	return c.problems, nil
+}
+
+// configValue is the effective value of a known configuration key.
+type configValue struct {
+	Name   string // see ConfigOption.Key
+	Entry  git.ConfigEntry
+	Line   int
+	Flag   *kong.Flag
+	Target reflect.Value // decoded value
+}
+
+type configChecker struct {
+	log *silog.Logger
+
+	schema *ConfigSchema
+
+	// experiments is the set of known experiment names, lowercased.
+	experiments map[string]struct{}
+
+	// values maps canonical keys to their effective values.
+	// Keys with invalid values are omitted.
+	values map[git.ConfigKey]*configValue
+
+	lines *git.ConfigLineFinder
+
+	problems []*ConfigProblem
+}
+
+func (c *configChecker) indexModel(root *kong.Node) {
+	for _, exp := range experiment.List(root) {
+		c.experiments[strings.ToLower(exp.Name)] = struct{}{}
+	}
+}
+
+func (c *configChecker) checkEntry(entry git.ConfigEntry) {
+	key := entry.Key.Canonical()
+	line := c.lines.Line(entry)
+	report := func(name, format string, args ...any) {
+		c.problems = append(c.problems, &ConfigProblem{
+			Key:     name,
+			Value:   entry.Value,
+			Origin:  entry.Origin,
+			Line:    line,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	section, subsection, name := key.Split()
+	if section != _spiceSection {
+		return
+	}
+
+	switch subsection {
+	case _shorthandSubsection:
+		if strings.HasPrefix(entry.Value, "!") {
+			return // shell command
+		}
+		if _, err := shellwords.SplitPosix(entry.Value); err != nil {
+			report(string(key), "invalid shorthand: %v", err)
+		}
+		return
+
+	case _experimentSubsection:
+		if _, ok := c.experiments[strings.ToLower(name)]; !ok {
+			report(string(key), "unknown experiment %q", name)
+		}
+		if _, err := strconv.ParseBool(entry.Value); err != nil {
+			report(string(key), "invalid value %q: must be true or false", entry.Value)
+		}
+		return
+	}
+
+	opt, err := c.schema.Find(string(key))
+	if err != nil {
+		report(string(key), "%v", err)
+		return
+	}
+
+	if opt.Deprecated {
+		report(opt.Key, "deprecated key: support will be removed in a future version")
+	}
+
+	target, err := decodeConfigValue(opt.flag, entry.Value)
+	if err != nil {
+		report(opt.Key, "%v", err)
+		delete(c.values, key) // last value wins
+		return
+	}
+
+	c.values[key] = &configValue{
+		Name:   opt.Key,
+		Entry:  entry,
+		Line:   line,
+		Flag:   opt.flag,
+		Target: target,
+	}
+}
+
+// configConflict is a value of a configuration key
+// that causes other keys to have no effect.
+type configConflict struct {
+	Key   string // without "spice." prefix
+	Value string
+
+	// Ignored lists keys that have no effect
+	// when Key is set to Value.
+	Ignored []string
+}
+
+var _configConflicts = []configConflict{
+	{
+		Key:   "submit.navigationComment",
+		Value: "false",
+		Ignored: []string{
+			"submit.navigationCommentSync",
+			"submit.navigationComment.downstack",
+			"submit.navigationCommentStyle.marker",
+		},
+	},
+	{
+		// Only existing CRs are updated without publishing,
+		// so options for new CRs are never used.
+		Key:   "submit.publish",
+		Value: "false",
+		Ignored: []string{
+			"submit.draft",
+			"submit.template",
+			"submit.includeNote",
+		},
+	},
+}
+
+func (c *configChecker) checkConflicts() {
+	for _, conflict := range _configConflicts {
+		if !c.valueIs(conflict.Key, conflict.Value) {
+			continue
+		}
+
+		for _, ignored := range conflict.Ignored {
+			c.reportIneffective(ignored,
+				"no effect because %v is %v", _spiceSection+"."+conflict.Key, conflict.Value)
+		}
+	}
+}
+
+// configCapability is a configuration key
+// that has an effect only if the forge supports a feature.
+type configCapability struct {
+	Key string // without "spice." prefix
+
+	// Value, if set, is the value of Key that needs the feature.
+	// If empty, all values need it.
+	Value string
+
+	Feature   string
+	Supported func(forge.Capabilities) bool
+}
+
+var _configCapabilities = []configCapability{
+	{
+		Key:       "submit.draft",
+		Value:     "true",
+		Feature:   "draft change requests",
+		Supported: func(c forge.Capabilities) bool { return c.Draft },
+	},
+	{
+		Key:       "submit.label",
+		Feature:   "labels",
+		Supported: func(c forge.Capabilities) bool { return c.Labels },
+	},
+	{
+		Key:       "submit.reviewers",
+		Feature:   "reviewers",
+		Supported: func(c forge.Capabilities) bool { return c.Reviewers },
+	},
+	{
+		Key:       "submit.assignees",
+		Feature:   "assignees",
+		Supported: func(c forge.Capabilities) bool { return c.Assignees },
+	},
+}
+
+func (c *configChecker) checkCapabilities(f forge.Forge, caps forge.Capabilities) {
+	for _, cc := range _configCapabilities {
+		if cc.Supported(caps) {
+			continue
+		}
+		if cc.Value != "" && !c.valueIs(cc.Key, cc.Value) {
+			continue
+		}
+
+		c.reportIneffective(cc.Key, "no effect because %v does not support %v", f.ID(), cc.Feature)
+	}
+}
+
+// valueIs reports whether the configured value of a key
+// decodes to the same value as the given string.
+func (c *configChecker) valueIs(name, want string) bool {
+	v, ok := c.values[git.ConfigKey(_spiceSection+"."+name).Canonical()]
+	if !ok {
+		return false
+	}
+
+	wantTarget, err := decodeConfigValue(v.Flag, want)
+	if err != nil {
+		c.log.Debug("Could not decode value for comparison",
+			"key", name, "value", want, "error", err)
+		return false
+	}
+	return reflect.DeepEqual(v.Target.Interface(), wantTarget.Interface())
+}
+
+// reportIneffective reports a problem with a key if it's configured.
+func (c *configChecker) reportIneffective(name, format string, args ...any) {
+	v, ok := c.values[git.ConfigKey(_spiceSection+"."+name).Canonical()]
+	if !ok {
+		return
+	}
+
+	c.problems = append(c.problems, &ConfigProblem{
+		Key:     v.Name,
+		Value:   v.Entry.Value,
+		Origin:  v.Entry.Origin,
+		Line:    v.Line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// decodeConfigValue decodes a configuration value
+// the same way it would be decoded for the given flag,
+// including validation of enums.
+func decodeConfigValue(flag *kong.Flag, value string) (reflect.Value, error) {
+	target := reflect.New(flag.Target.Type()).Elem()
+	if target.Kind() == reflect.Ptr {
+		target.Set(reflect.New(target.Type().Elem()))
+	}
+
+	// Validate scalar enums before decoding
+	// so that custom decoders don't mask the list of allowed values.
+	if flag.Tag.Enum != "" && target.Kind() != reflect.Slice && !flag.EnumMap()[value] {
+		return reflect.Value{}, fmt.Errorf("invalid value %q: must be one of %v",
+			value, strings.Join(flag.EnumSlice(), ", "))
+	}
+
+	scan := kong.Scan().PushTyped(value, kong.FlagValueToken)
+	if err := flag.Mapper.Decode(&kong.DecodeContext{Value: flag.Value, Scan: scan}, target); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if flag.Tag.Enum != "" {
+		enums := flag.EnumMap()
+		v := target
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+
+		values := []reflect.Value{v}
+		if v.Kind() == reflect.Slice {
+			values = values[:0]
+			for i := range v.Len() {
+				values = append(values, v.Index(i))
+			}
+		}
+
+		for _, v := range values {
+			if !enums[fmt.Sprint(v.Interface())] {
+				return reflect.Value{}, fmt.Errorf("invalid value %q: must be one of %v",
+					fmt.Sprint(v.Interface()), strings.Join(flag.EnumSlice(), ", "))
+			}
+		}
+	}
+
+	return target, nil
+}
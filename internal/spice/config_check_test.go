@@ -0,0 +1,165 @@
+package spice_test
+
+import (
+	"context"
+	"iter"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/forge/forgetest"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeOriginLister []git.ConfigEntry
+
+func (l fakeOriginLister) ListOriginRegexp(context.Context, ...string) iter.Seq2[git.ConfigEntry, error] {
+	return func(yield func(git.ConfigEntry, error) bool) {
+		for _, e := range l {
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+type checkConfigCLI struct {
+	Submit struct {
+		Publish    bool     `config:"submit.publish" default:"true" negatable:""`
+		NavComment string   `config:"submit.navigationComment" enum:"true,false,multiple" default:"true"`
+		NavSync    string   `config:"submit.navigationCommentSync" enum:"branch,downstack" default:"branch"`
+		Draft      bool     `config:"submit.draft"`
+		Labels     []string `config:"submit.label"`
+		Editor     string   `config:"@core.editor"`
+		OldPrompt  *bool    `config:"submit.oldPrompt" deprecated:""`
+	} `cmd:""`
+
+	Fixup struct{} `cmd:"" experiment:"commitFixup"`
+}
+
+func TestCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+	userConfig := filepath.Join(dir, "gitconfig")
+	require.NoError(t, os.WriteFile(userConfig, []byte(text.Dedent(`
+		[spice "submit"]
+			navigationComment = false
+			navigationCommentSync = downstack
+		[spice "Submit"]
+			publish = false
+		[spice.submit]
+			typo = x
+		[spice "submit"]
+			navigationCommentSync = sideways
+			oldPrompt = true
+			draft = true
+			label = bug
+	`)), 0o644))
+	userOrigin := "file:" + userConfig
+
+	entries := fakeOriginLister{
+		{Key: "spice.submit.navigationcomment", Value: "false", Origin: userOrigin},
+		{Key: "spice.submit.navigationcommentsync", Value: "downstack", Origin: userOrigin},
+		{Key: "spice.Submit.publish", Value: "false", Origin: userOrigin},
+		{Key: "spice.submit.typo", Value: "x", Origin: userOrigin},
+		{Key: "spice.submit.navigationcommentsync", Value: "sideways", Origin: userOrigin},
+		{Key: "spice.submit.oldprompt", Value: "true", Origin: userOrigin},
+		{Key: "spice.submit.draft", Value: "true", Origin: userOrigin},
+		{Key: "spice.submit.label", Value: "bug", Origin: userOrigin},
+		{Key: "spice.experiment.commitfixup", Value: "true", Origin: "command line:"},
+		{Key: "spice.experiment.unknown", Value: "nah", Origin: "command line:"},
+		{Key: "spice.shorthand.ok", Value: "!echo 'unbalanced", Origin: "command line:"},
+		{Key: "spice.shorthand.bad", Value: "branch 'unbalanced", Origin: "command line:"},
+	}
+
+	var cli checkConfigCLI
+	parser, err := kong.New(&cli)
+	require.NoError(t, err)
+
+	t.Run("NoForge", func(t *testing.T) {
+		problems, err := spice.CheckConfig(t.Context(), entries, parser.Model, &spice.CheckConfigOptions{
+			Log: silogtest.New(t),
+		})
+		require.NoError(t, err)
+
+		type problem struct{ Location, Key, Message string }
+		var got []problem
+		for _, p := range problems {
+			got = append(got, problem{p.Location(), p.Key, p.Message})
+		}
+
+		assert.Equal(t, []problem{
+			{
+				userConfig + ":5",
+				"spice.Submit.publish",
+				"unknown key: did you mean spice.submit.publish?",
+			},
+			{userConfig + ":7", "spice.submit.typo", "unknown key"},
+			{
+				userConfig + ":9",
+				"spice.submit.navigationCommentSync",
+				`invalid value "sideways": must be one of branch, downstack`,
+			},
+			{
+				userConfig + ":10",
+				"spice.submit.oldPrompt",
+				"deprecated key: support will be removed in a future version",
+			},
+			{"command line", "spice.experiment.unknown", `unknown experiment "unknown"`},
+			{"command line", "spice.experiment.unknown", `invalid value "nah": must be true or false`},
+			{"command line", "spice.shorthand.bad", "invalid shorthand: expected closing quote ' at offset 17, got EOF"},
+		}, got)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		// Without the invalid value of navigationCommentSync,
+		// the first value is in effect.
+		entries := fakeOriginLister{entries[0], entries[1]}
+		problems, err := spice.CheckConfig(t.Context(), entries, parser.Model, nil)
+		require.NoError(t, err)
+		require.Len(t, problems, 1)
+		assert.Equal(t, &spice.ConfigProblem{
+			Key:     "spice.submit.navigationCommentSync",
+			Value:   "downstack",
+			Origin:  userOrigin,
+			Line:    3,
+			Message: "no effect because spice.submit.navigationComment is false",
+		}, problems[0])
+	})
+
+	t.Run("Capabilities", func(t *testing.T) {
+		mockForge := forgetest.NewMockForge(gomock.NewController(t))
+		mockForge.EXPECT().ID().Return("example").AnyTimes()
+
+		entries := fakeOriginLister{entries[6], entries[7]}
+		problems, err := spice.CheckConfig(t.Context(), entries, parser.Model, &spice.CheckConfigOptions{
+			Forge:        mockForge,
+			Capabilities: forge.Capabilities{Reviewers: true},
+		})
+		require.NoError(t, err)
+
+		var messages []string
+		for _, p := range problems {
+			messages = append(messages, p.Key+": "+p.Message)
+		}
+		assert.Equal(t, []string{
+			"spice.submit.draft: no effect because example does not support draft change requests",
+			"spice.submit.label: no effect because example does not support labels",
+		}, messages)
+
+		// Nothing to report if the forge supports everything.
+		problems, err = spice.CheckConfig(t.Context(), entries, parser.Model, &spice.CheckConfigOptions{
+			Forge:        mockForge,
+			Capabilities: forge.AllCapabilities,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+}
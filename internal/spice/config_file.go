@@ -0,0 +1,204 @@
+package spice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"gopkg.in/yaml.v3"
+)
+
+// RepoConfigFile is the name of a configuration file
+// that may be checked into the root of a repository
+// to provide defaults for everyone working on it.
+const RepoConfigFile = ".gitspice.yaml"
+
+// ReadConfigFile reads git-spice configuration from a YAML file.
+//
+// Keys in the file are relative to the "spice" section.
+// Nested mappings are flattened into dotted keys,
+// and lists become multiple values for the same key.
+// For example, the following:
+//
+//	submit:
+//	  reviewers: [alice, bob]
+//	  navigationCommentStyle:
+//	    layout: tree
+//
+// Is equivalent to the following git-config:
+//
+//	[spice "submit"]
+//		reviewers = alice
+//		reviewers = bob
+//	[spice "submit.navigationCommentStyle"]
+//		layout = tree
+//
+// Returns [fs.ErrNotExist] if the file does not exist.
+func ReadConfigFile(path string) ([]git.ConfigEntry, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, fmt.Errorf("parse %v: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil // empty file
+	}
+	if root := doc.Content[0]; root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%v: line %d: expected a mapping at the top level", path, root.Line)
+	}
+
+	var entries []git.ConfigEntry
+	add := func(key, value string) {
+		entries = append(entries, git.ConfigEntry{
+			Key:    git.ConfigKey(_spiceSection + "." + key).Canonical(),
+			Value:  value,
+			Origin: "file:" + path,
+		})
+	}
+
+	var flatten func(prefix string, node *yaml.Node) error
+	flatten = func(prefix string, node *yaml.Node) error {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Kind != yaml.ScalarNode {
+					return fmt.Errorf("line %d: key must be a string", key.Line)
+				}
+
+				name := key.Value
+				if prefix != "" {
+					name = prefix + "." + name
+				}
+				if err := flatten(name, value); err != nil {
+					return err
+				}
+			}
+
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode {
+					return fmt.Errorf("line %d: %v: list items must be plain values", item.Line, prefix)
+				}
+				add(prefix, item.Value)
+			}
+
+		case yaml.ScalarNode:
+			add(prefix, node.Value)
+
+		default:
+			return fmt.Errorf("line %d: %v: unsupported value", node.Line, prefix)
+		}
+		return nil
+	}
+
+	if err := flatten("", doc.Content[0]); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return entries, nil
+}
+
+// LayeredConfig lists entries from a [RepoConfigFile]
+// followed by entries from git-config.
+// Since later values win for single-valued options,
+// git-config takes precedence over the file,
+// and values of list options are combined.
+type LayeredConfig struct {
+	// Git is the git-config to read.
+	Git *git.Config // required
+
+	// RepoConfigFile is the path to the repository configuration file.
+	// Nothing is read from it if it's empty or the file doesn't exist.
+	RepoConfigFile string
+
+	// Log specifies the logger to use for logging.
+	// Defaults to no logging.
+	Log *silog.Logger
+}
+
+var (
+	_ GitConfigLister       = (*LayeredConfig)(nil)
+	_ GitConfigOriginLister = (*LayeredConfig)(nil)
+)
+
+// ListRegexp lists configuration entries matching the given patterns.
+// See [git.Config.ListRegexp] for details.
+func (c *LayeredConfig) ListRegexp(ctx context.Context, patterns ...string) iter.Seq2[git.ConfigEntry, error] {
+	return c.list(patterns, c.Git.ListRegexp(ctx, patterns...))
+}
+
+// ListOriginRegexp lists configuration entries matching the given patterns
+// along with where they were defined.
+// See [git.Config.ListOriginRegexp] for details.
+func (c *LayeredConfig) ListOriginRegexp(ctx context.Context, patterns ...string) iter.Seq2[git.ConfigEntry, error] {
+	return c.list(patterns, c.Git.ListOriginRegexp(ctx, patterns...))
+}
+
+func (c *LayeredConfig) list(
+	patterns []string,
+	gitEntries iter.Seq2[git.ConfigEntry, error],
+) iter.Seq2[git.ConfigEntry, error] {
+	return func(yield func(git.ConfigEntry, error) bool) {
+		for _, entry := range c.fileEntries(patterns) {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+
+		for entry, err := range gitEntries {
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
+
+// fileEntries returns entries from the repository configuration file
+// that match any of the given patterns.
+//
+// A bad file is logged and ignored
+// so that it doesn't prevent git-config from being used.
+func (c *LayeredConfig) fileEntries(patterns []string) []git.ConfigEntry {
+	if c.RepoConfigFile == "" {
+		return nil
+	}
+
+	log := c.Log
+	if log == nil {
+		log = silog.Nop()
+	}
+
+	entries, err := ReadConfigFile(c.RepoConfigFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Warn("Ignoring invalid repository configuration", "error", err)
+		}
+		return nil
+	}
+
+	pattern := "."
+	if len(patterns) > 0 {
+		pattern = strings.Join(patterns, "|")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		log.Warn("Ignoring repository configuration: bad pattern", "pattern", pattern, "error", err)
+		return nil
+	}
+
+	return slices.DeleteFunc(entries, func(entry git.ConfigEntry) bool {
+		return !re.MatchString(string(entry.Key))
+	})
+}
@@ -0,0 +1,168 @@
+package spice_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want map[git.ConfigKey][]string
+
+		wantErr string
+	}{
+		{name: "Empty", want: map[git.ConfigKey][]string{}},
+		{
+			name: "Nested",
+			give: text.Dedent(`
+				submit:
+				  reviewers: [alice, bob]
+				  draft: true
+				  navigationCommentStyle:
+				    layout: tree
+				  navigationComment.downstack: open
+				shorthand:
+				  wip: commit create -m wip
+			`),
+			want: map[git.ConfigKey][]string{
+				"spice.submit.reviewers":                     {"alice", "bob"},
+				"spice.submit.draft":                         {"true"},
+				"spice.submit.navigationCommentStyle.layout": {"tree"},
+				"spice.submit.navigationComment.downstack":   {"open"},
+				"spice.shorthand.wip":                        {"commit create -m wip"},
+			},
+		},
+		{
+			name:    "TopLevelScalar",
+			give:    "hello\n",
+			wantErr: "expected a mapping",
+		},
+		{
+			name: "NestedList",
+			give: text.Dedent(`
+				submit:
+				  label:
+				    - [a, b]
+			`),
+			wantErr: "submit.label: list items must be plain values",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), spice.RepoConfigFile)
+			require.NoError(t, os.WriteFile(path, []byte(tt.give), 0o644))
+
+			entries, err := spice.ReadConfigFile(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got := make(map[git.ConfigKey][]string)
+			for _, entry := range entries {
+				assert.Equal(t, "file:"+path, entry.Origin)
+				got[entry.Key] = append(got[entry.Key], entry.Value)
+			}
+
+			want := make(map[git.ConfigKey][]string, len(tt.want))
+			for k, v := range tt.want {
+				want[k.Canonical()] = v
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("DoesNotExist", func(t *testing.T) {
+		_, err := spice.ReadConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestIntegrationLayeredConfig(t *testing.T) {
+	home := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(home, ".gitconfig"),
+		[]byte(text.Dedent(`
+			[spice "submit"]
+			draft = false
+			reviewers = carol
+		`)),
+		0o600,
+	))
+
+	repoConfig := filepath.Join(t.TempDir(), spice.RepoConfigFile)
+	require.NoError(t, os.WriteFile(repoConfig, []byte(text.Dedent(`
+		submit:
+		  draft: true
+		  reviewers: [alice, bob]
+		  web: created
+	`)), 0o644))
+
+	gitCfg := git.NewConfig(git.ConfigOptions{
+		Log: silogtest.New(t),
+		Dir: home,
+		Env: []string{
+			"HOME=" + home,
+			"USER=testuser",
+			"GIT_CONFIG_NOSYSTEM=1",
+		},
+	})
+
+	load := func(t *testing.T, path string) (got struct {
+		Draft     bool     `config:"submit.draft"`
+		Reviewers []string `config:"submit.reviewers"`
+		Web       string   `config:"submit.web"`
+	},
+	) {
+		layered := &spice.LayeredConfig{
+			Git:            gitCfg,
+			RepoConfigFile: path,
+			Log:            silogtest.New(t),
+		}
+		spicecfg, err := spice.LoadConfig(t.Context(), layered, spice.ConfigOptions{
+			Log: silogtest.New(t),
+		})
+		require.NoError(t, err)
+
+		cli, err := kong.New(&got, kong.Resolvers(spicecfg))
+		require.NoError(t, err)
+		_, err = cli.Parse(nil)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("Layered", func(t *testing.T) {
+		got := load(t, repoConfig)
+		assert.False(t, got.Draft, "git-config should take precedence")
+		assert.Equal(t, []string{"alice", "bob", "carol"}, got.Reviewers)
+		assert.Equal(t, "created", got.Web)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), spice.RepoConfigFile)
+		require.NoError(t, os.WriteFile(invalid, []byte("[not, a, mapping]\n"), 0o644))
+
+		got := load(t, invalid)
+		assert.Equal(t, []string{"carol"}, got.Reviewers)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		got := load(t, filepath.Join(t.TempDir(), spice.RepoConfigFile))
+		assert.Equal(t, []string{"carol"}, got.Reviewers)
+		assert.Empty(t, got.Web)
+	})
+}
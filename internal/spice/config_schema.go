@@ -0,0 +1,240 @@
+package spice
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+)
+
+// ConfigOption is a git-spice configuration key
+// that sets the default value of a command line flag.
+type ConfigOption struct {
+	// Key is the full name of the key, spelled as documented,
+	// e.g. "spice.submit.navigationComment".
+	Key string
+
+	// Help is the help text of the flag.
+	Help string
+
+	// Type is a short description of the kind of value the key accepts:
+	// "bool", "int", "duration", "list", or "string".
+	Type string
+
+	// Default is the value used if the key is not set.
+	// This is empty if the flag does not have a default.
+	Default string
+
+	// Enum lists the accepted values if the key
+	// accepts only a fixed set of values.
+	Enum []string
+
+	// Deprecated reports whether support for the key
+	// will be removed in a future version.
+	Deprecated bool
+
+	flag *kong.Flag
+}
+
+// Validate reports an error if the given value
+// is not valid for the option.
+func (o *ConfigOption) Validate(value string) error {
+	_, err := decodeConfigValue(o.flag, value)
+	return err
+}
+
+// ConfigSchema is the set of git-spice configuration keys
+// known to a Kong application.
+//
+// Keys are discovered from flags tagged with `config:"..."`.
+// Keys outside the spice section (tagged with "@") are owned by Git
+// and are not part of the schema.
+// Shorthands and experiments are configured with dynamic keys
+// and are not part of the schema either.
+type ConfigSchema struct {
+	options []*ConfigOption // sorted by key
+
+	// byKey maps canonical keys to their options.
+	byKey map[git.ConfigKey]*ConfigOption
+}
+
+// NewConfigSchema builds a [ConfigSchema]
+// from the flags of the given Kong application.
+func NewConfigSchema(app *kong.Application) *ConfigSchema {
+	s := &ConfigSchema{
+		byKey: make(map[git.ConfigKey]*ConfigOption),
+	}
+	s.indexFlags(app.Node)
+	slices.SortFunc(s.options, func(a, b *ConfigOption) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return s
+}
+
+func (s *ConfigSchema) indexFlags(node *kong.Node) {
+	for _, flag := range node.Flags {
+		name := flag.Tag.Get(_configTag)
+		if name == "" || strings.HasPrefix(name, "@") {
+			continue
+		}
+
+		name = _spiceSection + "." + name
+		key := git.ConfigKey(name).Canonical()
+		if _, ok := s.byKey[key]; ok {
+			// Multiple commands may read the same key.
+			// The first flag wins.
+			continue
+		}
+
+		opt := &ConfigOption{
+			Key:        name,
+			Help:       flag.Help,
+			Type:       configValueType(flag),
+			Default:    flag.Default,
+			Deprecated: flag.Tag.Has("deprecated"),
+			flag:       flag,
+		}
+		if flag.Tag.Enum != "" {
+			opt.Enum = flag.EnumSlice()
+		}
+		s.byKey[key] = opt
+		s.options = append(s.options, opt)
+	}
+
+	for _, child := range node.Children {
+		s.indexFlags(child)
+	}
+}
+
+// Options returns all known options, sorted by key.
+func (s *ConfigSchema) Options() []*ConfigOption {
+	return slices.Clone(s.options)
+}
+
+// Lookup returns the option for the given key.
+// The section and name of the key are case-insensitive,
+// but the subsection is not.
+func (s *ConfigSchema) Lookup(key string) (*ConfigOption, bool) {
+	opt, ok := s.byKey[git.ConfigKey(key).Canonical()]
+	return opt, ok
+}
+
+// Find is a variant of [ConfigSchema.Lookup]
+// that returns an [*UnknownConfigKeyError] if the key is not known.
+func (s *ConfigSchema) Find(key string) (*ConfigOption, error) {
+	if opt, ok := s.Lookup(key); ok {
+		return opt, nil
+	}
+	return nil, &UnknownConfigKeyError{
+		Key:        key,
+		Suggestion: s.suggest(key),
+	}
+}
+
+// _maxSuggestDistance is the maximum number of single-character edits
+// between an unknown key and a known key for the known key
+// to be suggested as a replacement.
+const _maxSuggestDistance = 2
+
+// suggest returns the known key that the given unknown key
+// was most likely meant to be, or an empty string.
+func (s *ConfigSchema) suggest(key string) string {
+	// Subsections are case-sensitive,
+	// so "spice.Submit.publish" is not "spice.submit.publish".
+	for _, opt := range s.options {
+		if strings.EqualFold(opt.Key, key) {
+			return opt.Key
+		}
+	}
+
+	var (
+		best     string
+		bestDist = _maxSuggestDistance + 1
+	)
+	lowerKey := strings.ToLower(key)
+	for _, opt := range s.options {
+		if d := editDistance(lowerKey, strings.ToLower(opt.Key)); d < bestDist {
+			best, bestDist = opt.Key, d
+		}
+	}
+	return best
+}
+
+// UnknownConfigKeyError is returned by [ConfigSchema.Find]
+// for keys that are not known to git-spice.
+type UnknownConfigKeyError struct {
+	Key string
+
+	// Suggestion is a known key similar to Key, if any.
+	Suggestion string
+}
+
+func (e *UnknownConfigKeyError) Error() string {
+	msg := "unknown key"
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(": did you mean %v?", e.Suggestion)
+	}
+	return msg
+}
+
+// configValueType describes the type of value accepted by a flag.
+func configValueType(flag *kong.Flag) string {
+	if flag.IsBool() {
+		return "bool"
+	}
+
+	typ := flag.Target.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == reflect.TypeFor[time.Duration]():
+		return "duration"
+	case reflect.PointerTo(typ).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()),
+		reflect.PointerTo(typ).Implements(reflect.TypeFor[kong.MapperValue]()):
+		// Types with custom decoding are usually
+		// integer enums parsed from strings.
+		return "string"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Slice:
+		return "list"
+	default:
+		return "string"
+	}
+}
+
+// editDistance returns the Levenshtein distance between two strings:
+// the number of single-byte insertions, deletions, or substitutions
+// needed to turn one into the other.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := range len(a) {
+		cur[0] = i + 1
+		for j := range len(b) {
+			cost := 1
+			if a[i] == b[j] {
+				cost = 0
+			}
+			cur[j+1] = min(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
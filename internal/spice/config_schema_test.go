@@ -0,0 +1,169 @@
+package spice_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/spice"
+)
+
+type configSchemaCLI struct {
+	Submit struct {
+		Publish    bool          `config:"submit.publish" default:"true" negatable:"" help:"Publish the change"`
+		NavComment string        `config:"submit.navigationComment" enum:"true,false,multiple" default:"true" help:"Post a navigation comment"`
+		Labels     []string      `config:"submit.label" help:"Labels to add"`
+		Editor     string        `config:"@core.editor"`
+		OldPrompt  *bool         `config:"submit.oldPrompt" deprecated:""`
+		Timeout    time.Duration `config:"submit.timeout" default:"1m"`
+		Level      configLevel   `config:"submit.level" default:"low"`
+	} `cmd:""`
+
+	Log struct {
+		Limit int `config:"log.limit" default:"10"`
+
+		// Same key as submit.publish.
+		Publish bool `config:"submit.publish" help:"Duplicate"`
+	} `cmd:""`
+}
+
+// configLevel is an integer enum decoded from strings.
+type configLevel int
+
+func (l *configLevel) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return fmt.Errorf("invalid level %q", bs)
+	}
+	return nil
+}
+
+func TestConfigSchema(t *testing.T) {
+	var cli configSchemaCLI
+	parser, err := kong.New(&cli)
+	require.NoError(t, err)
+
+	schema := spice.NewConfigSchema(parser.Model)
+
+	type option struct {
+		Key, Type, Default, Help string
+		Enum                     []string
+		Deprecated               bool
+	}
+	var got []option
+	for _, opt := range schema.Options() {
+		got = append(got, option{
+			Key:        opt.Key,
+			Type:       opt.Type,
+			Default:    opt.Default,
+			Help:       opt.Help,
+			Enum:       opt.Enum,
+			Deprecated: opt.Deprecated,
+		})
+	}
+	assert.Equal(t, []option{
+		{Key: "spice.log.limit", Type: "int", Default: "10"},
+		{
+			Key:  "spice.submit.label",
+			Type: "list",
+			Help: "Labels to add",
+		},
+		{Key: "spice.submit.level", Type: "string", Default: "low"},
+		{
+			Key:     "spice.submit.navigationComment",
+			Type:    "string",
+			Default: "true",
+			Help:    "Post a navigation comment",
+			Enum:    []string{"true", "false", "multiple"},
+		},
+		{Key: "spice.submit.oldPrompt", Type: "bool", Deprecated: true},
+		{
+			Key:     "spice.submit.publish",
+			Type:    "bool",
+			Default: "true",
+			Help:    "Publish the change",
+		},
+		{Key: "spice.submit.timeout", Type: "duration", Default: "1m"},
+	}, got)
+
+	t.Run("Lookup", func(t *testing.T) {
+		opt, ok := schema.Lookup("SPICE.submit.NAVIGATIONCOMMENT")
+		require.True(t, ok)
+		assert.Equal(t, "spice.submit.navigationComment", opt.Key)
+
+		_, ok = schema.Lookup("spice.Submit.navigationComment")
+		assert.False(t, ok, "subsection is case-sensitive")
+
+		_, ok = schema.Lookup("core.editor")
+		assert.False(t, ok, "keys owned by Git are not in the schema")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		tests := []struct {
+			key     string
+			wantErr string
+		}{
+			{key: "spice.submit.publish"},
+			{key: "spice.Submit.publish", wantErr: "unknown key: did you mean spice.submit.publish?"},
+			{key: "spice.submit.publsh", wantErr: "unknown key: did you mean spice.submit.publish?"},
+			{key: "spice.sbumit.label", wantErr: "unknown key: did you mean spice.submit.label?"},
+			{key: "spice.submit.typo", wantErr: "unknown key"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.key, func(t *testing.T) {
+				_, err := schema.Find(tt.key)
+				if tt.wantErr == "" {
+					assert.NoError(t, err)
+					return
+				}
+
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+
+				var unknownErr *spice.UnknownConfigKeyError
+				require.ErrorAs(t, err, &unknownErr)
+				assert.Equal(t, tt.key, unknownErr.Key)
+			})
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		tests := []struct {
+			key, value string
+			wantErr    string
+		}{
+			{key: "spice.submit.publish", value: "false"},
+			{key: "spice.submit.publish", value: "maybe", wantErr: "bool value"},
+			{key: "spice.submit.navigationComment", value: "multiple"},
+			{key: "spice.submit.navigationComment", value: "sometimes", wantErr: `invalid value "sometimes"`},
+			{key: "spice.log.limit", value: "20"},
+			{key: "spice.log.limit", value: "lots", wantErr: "lots"},
+			{key: "spice.submit.timeout", value: "30s"},
+			{key: "spice.submit.timeout", value: "soon", wantErr: "soon"},
+			{key: "spice.submit.level", value: "high"},
+			{key: "spice.submit.level", value: "medium", wantErr: `invalid level "medium"`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+				opt, ok := schema.Lookup(tt.key)
+				require.True(t, ok)
+
+				err := opt.Validate(tt.value)
+				if tt.wantErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorContains(t, err, tt.wantErr)
+				}
+			})
+		}
+	})
+}
@@ -149,6 +149,38 @@ func TestIntegrationConfig_loadFromGit(t *testing.T) {
 			}{},
 			wantErr: []string{`multiple values but no separator`},
 		},
+		{
+			name: "Multiple/NewlineSeparator",
+			config: text.Dedent(`
+				[spice.forge]
+				header = "Accept: a, b"
+				header = "Cookie: c=1; d=2"
+			`),
+			want: struct {
+				Header []string `config:"forge.header" sep:"\n"`
+			}{Header: []string{"Accept: a, b", "Cookie: c=1; d=2"}},
+		},
+		{
+			name: "Single/NewlineSeparator",
+			config: text.Dedent(`
+				[spice.forge]
+				header = "Accept: a, b"
+			`),
+			want: struct {
+				Header []string `config:"forge.header" sep:"\n"`
+			}{Header: []string{"Accept: a, b"}},
+		},
+		{
+			name: "BoolPointer",
+			config: text.Dedent(`
+				[spice]
+				enabled = false
+			`),
+			want: struct {
+				Enabled *bool `config:"enabled" negatable:""`
+				Unset   *bool `config:"unset" negatable:""`
+			}{Enabled: new(false)},
+		},
 		{
 			name: "Multiple/LastWins",
 			config: text.Dedent(`
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/sliceutil"
 )
@@ -25,11 +26,26 @@ const (
 // about the state of a repository during initialization.
 type Guesser struct {
 	// Select prompts a user to select from a list of options
-	// and returns the selected option.
+	// and returns the value of the selected option.
 	//
-	// selected is the the option that should be selected by default
+	// selected is the value of the option that should be selected by default
 	// or an empty string if there's no preferred default.
-	Select func(op GuessOp, opts []string, selected string) (string, error) // required
+	Select func(op GuessOp, opts []GuessOption, selected string) (string, error) // required
+
+	// Forges is used to identify the forge hosting each remote
+	// when asking the user to pick one.
+	Forges *forge.Registry // optional
+}
+
+// GuessOption is an option presented to the user by a [Guesser].
+type GuessOption struct {
+	// Value is the value of the option, e.g. the name of a remote.
+	Value string
+
+	// Description is additional information to help the user
+	// pick the option, e.g. the URL of a remote.
+	// It may be empty.
+	Description string
 }
 
 // GuessRemote attempts to guess the name of the remote
@@ -48,7 +64,15 @@ func (g *Guesser) GuessRemote(ctx context.Context, repo GitRepository) (string,
 	case 1:
 		return remotes[0], nil
 	default:
-		remote, err := g.Select(GuessRemote, remotes, "")
+		opts := make([]GuessOption, len(remotes))
+		for i, remote := range remotes {
+			opts[i] = GuessOption{
+				Value:       remote,
+				Description: g.describeRemote(ctx, repo, remote),
+			}
+		}
+
+		remote, err := g.Select(GuessRemote, opts, "")
 		if err != nil {
 			return "", fmt.Errorf("prompt for remote: %w", err)
 		}
@@ -56,46 +80,77 @@ func (g *Guesser) GuessRemote(ctx context.Context, repo GitRepository) (string,
 	}
 }
 
+// describeRemote returns the URL of a remote,
+// and the forge and repository it points to if known.
+func (g *Guesser) describeRemote(ctx context.Context, repo GitRepository, remote string) string {
+	url, err := repo.RemoteURL(ctx, remote)
+	if err != nil {
+		return ""
+	}
+
+	if g.Forges != nil {
+		if f, rid, ok := forge.MatchRemoteURL(g.Forges, url); ok {
+			return fmt.Sprintf("%v, %v: %v", url, f.ID(), rid)
+		}
+	}
+	return url
+}
+
 // GuessTrunk attempts to guess the name of the trunk branch of the repository.
 // If remote is non-empty, it should be the name of the remote for the repository.
+//
+// If the remote reports a default branch (its HEAD)
+// and that branch exists locally, it is used without prompting.
+// The returned branch may not exist locally
+// if the repository does not have any local branches yet.
 func (g *Guesser) GuessTrunk(ctx context.Context, repo GitRepository, wt GitWorktree, remote string) (string, error) {
-	defaultTrunk, err := wt.CurrentBranch(ctx)
+	localBranches, err := sliceutil.CollectErr(repo.LocalBranches(ctx, nil))
 	if err != nil {
-		return "", fmt.Errorf("determine current branch: %w", err)
+		return "", fmt.Errorf("list local branches: %w", err)
 	}
+	slices.SortFunc(localBranches, func(a, b git.LocalBranch) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 
 	// If there's a remote, and it has a default branch,
-	// use that as the default trunk branch in the prompt.
+	// use that as the trunk if it exists locally,
+	// and as the default trunk in the prompt otherwise.
+	var defaultTrunk string
 	if remote != "" {
 		if upstream, err := repo.RemoteDefaultBranch(ctx, remote); err == nil {
+			if slices.ContainsFunc(localBranches, func(b git.LocalBranch) bool {
+				return b.Name == upstream
+			}) {
+				return upstream, nil
+			}
 			defaultTrunk = upstream
 		}
 	}
 
-	localBranches, err := sliceutil.CollectErr(repo.LocalBranches(ctx, nil))
-	if err != nil {
-		return "", fmt.Errorf("list local branches: %w", err)
+	if defaultTrunk == "" {
+		defaultTrunk, err = wt.CurrentBranch(ctx)
+		if err != nil {
+			return "", fmt.Errorf("determine current branch: %w", err)
+		}
 	}
-	slices.SortFunc(localBranches, func(a, b git.LocalBranch) int {
-		return strings.Compare(a.Name, b.Name)
-	})
 
 	switch len(localBranches) {
 	case 0:
 		// There are no branches with any commits,
 		// but HEAD still points to a branch.
 		// This will be true for new repositories
-		// without any commits only.
+		// without any commits,
+		// and for clones that haven't checked out a branch.
 		return defaultTrunk, nil
 	case 1:
 		return localBranches[0].Name, nil
 	default:
-		branchNames := make([]string, len(localBranches))
+		opts := make([]GuessOption, len(localBranches))
 		for i, b := range localBranches {
-			branchNames[i] = b.Name
+			opts[i] = GuessOption{Value: b.Name}
 		}
 
-		branch, err := g.Select(GuessTrunk, branchNames, defaultTrunk)
+		branch, err := g.Select(GuessTrunk, opts, defaultTrunk)
 		if err != nil {
 			return "", fmt.Errorf("prompt for trunk branch: %w", err)
 		}
@@ -118,6 +118,7 @@ func (s *Service) BranchOnto(ctx context.Context, req *BranchOntoRequest) error
 		Name:            req.Branch,
 		Base:            req.Onto,
 		BaseHash:        baseHash,
+		UpstreamBase:    &state.UpstreamBase{}, // now based on Onto
 		MergedDownstack: req.MergedDownstack,
 	}); err != nil {
 		return fmt.Errorf("set base of branch %s to %s: %w", req.Branch, req.Onto, err)
@@ -130,6 +131,7 @@ func (s *Service) BranchOnto(ctx context.Context, req *BranchOntoRequest) error
 			Onto:      ontoHash.String(),
 			Autostash: true,
 			Quiet:     true, // TODO: if verbose, disable this
+			GPGSign:   s.signCommits,
 		}); err != nil {
 			return fmt.Errorf("rebase: %w", err)
 		}
@@ -0,0 +1,50 @@
+package spice
+
+import (
+	"fmt"
+	"path"
+)
+
+// ProtectedBranchError is returned by operations
+// that refuse to modify a protected branch.
+//
+// See [Service.SetProtectedBranches].
+type ProtectedBranchError struct {
+	// Branch is the name of the protected branch.
+	Branch string
+
+	// Pattern is the pattern that matched the branch.
+	Pattern string
+}
+
+func (e *ProtectedBranchError) Error() string {
+	return fmt.Sprintf("branch %v is protected (matches %q)", e.Branch, e.Pattern)
+}
+
+// SetProtectedBranches specifies glob patterns for branches
+// that git-spice must not restack, rename, delete, or fold.
+// Patterns use the syntax of [path.Match],
+// so '*' does not match '/'.
+//
+// Returns an error if any of the patterns are malformed.
+func (s *Service) SetProtectedBranches(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad pattern %q: %w", pattern, err)
+		}
+	}
+	s.protectedBranches = patterns
+	return nil
+}
+
+// CheckProtected returns a [ProtectedBranchError]
+// if the given branch matches a protected branch pattern.
+func (s *Service) CheckProtected(name string) error {
+	for _, pattern := range s.protectedBranches {
+		// Patterns were validated in SetProtectedBranches.
+		if ok, _ := path.Match(pattern, name); ok {
+			return &ProtectedBranchError{Branch: name, Pattern: pattern}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package spice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceCheckProtected(t *testing.T) {
+	var svc Service
+	require.NoError(t, svc.SetProtectedBranches([]string{"integration", "release/*"}))
+
+	tests := []struct {
+		branch      string
+		wantPattern string // empty if not protected
+	}{
+		{branch: "integration", wantPattern: "integration"},
+		{branch: "release/1.0", wantPattern: "release/*"},
+		{branch: "release/1.0/hotfix"},
+		{branch: "feature"},
+		{branch: "integration-tests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			err := svc.CheckProtected(tt.branch)
+			if tt.wantPattern == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var protectedErr *ProtectedBranchError
+			require.ErrorAs(t, err, &protectedErr)
+			assert.Equal(t, tt.branch, protectedErr.Branch)
+			assert.Equal(t, tt.wantPattern, protectedErr.Pattern)
+		})
+	}
+
+	t.Run("BadPattern", func(t *testing.T) {
+		var svc Service
+		err := svc.SetProtectedBranches([]string{"release/["})
+		assert.ErrorContains(t, err, `bad pattern "release/["`)
+	})
+}
@@ -15,13 +15,17 @@ var ErrAlreadyRestacked = errors.New("branch is already restacked")
 
 // RestackResponse is the response to a restack operation.
 type RestackResponse struct {
+	// Base is the name of the branch that the branch was restacked on.
+	// For branches with an upstream base, this is the remote branch,
+	// e.g. "origin/feature".
 	Base string
 }
 
 // Restack restacks the given branch on top of its base branch,
 // handling movement of the base branch if necessary.
 //
-// Returns [ErrAlreadyRestacked] if the branch does not need to be restacked.
+// Returns [ErrAlreadyRestacked] if the branch does not need to be restacked,
+// and [ProtectedBranchError] if it does but it's protected.
 func (s *Service) Restack(ctx context.Context, name string) (*RestackResponse, error) {
 	b, err := s.LookupBranch(ctx, name)
 	if err != nil {
@@ -40,7 +44,10 @@ func (s *Service) Restack(ctx context.Context, name string) (*RestackResponse, e
 	}
 
 	// The branch needs to be restacked on top of its base branch.
-	// We will proceed with the restack.
+	// We will proceed with the restack unless it's protected.
+	if err := s.CheckProtected(name); err != nil {
+		return nil, err
+	}
 
 	baseHash := restackErr.BaseHash
 	upstream := b.BaseHash
@@ -71,7 +78,7 @@ func (s *Service) Restack(ctx context.Context, name string) (*RestackResponse, e
 	// if the recorded base hash is out of date
 	// because the user changed something externally.
 	if !s.repo.IsAncestor(ctx, upstream, b.Head) {
-		forkPoint, err := s.repo.ForkPoint(ctx, b.Base, name)
+		forkPoint, err := s.repo.ForkPoint(ctx, b.BaseRef(), name)
 		if err == nil {
 			if upstream != forkPoint {
 				s.log.Debug("Recorded base hash is out of date. Restacking from fork point.",
@@ -89,10 +96,15 @@ func (s *Service) Restack(ctx context.Context, name string) (*RestackResponse, e
 		Branch:    name,
 		Autostash: true,
 		Quiet:     true,
+		GPGSign:   s.signCommits,
 	}); err != nil {
 		return nil, fmt.Errorf("rebase: %w", err)
 	}
 
+	// The branch has been rebased.
+	// Record that even if the operation was interrupted
+	// so that state matches the repository.
+	ctx = context.WithoutCancel(ctx)
 	tx := s.store.BeginBranchTx()
 	if err := tx.Upsert(ctx, state.UpsertRequest{
 		Name:     name,
@@ -101,12 +113,12 @@ func (s *Service) Restack(ctx context.Context, name string) (*RestackResponse, e
 		return nil, fmt.Errorf("update base hash of %v: %w", name, err)
 	}
 
-	if err := tx.Commit(ctx, fmt.Sprintf("%v: restacked on %v", name, b.Base)); err != nil {
+	if err := tx.Commit(ctx, fmt.Sprintf("%v: restacked on %v", name, b.baseName())); err != nil {
 		return nil, fmt.Errorf("update state: %w", err)
 	}
 
 	return &RestackResponse{
-		Base: b.Base,
+		Base: b.baseName(),
 	}, nil
 }
 
@@ -114,6 +126,8 @@ func (s *Service) Restack(ctx context.Context, name string) (*RestackResponse, e
 // when a branch needs to be restacked.
 type BranchNeedsRestackError struct {
 	// Base is the name of the base branch for the branch.
+	// For branches with an upstream base,
+	// this is the remote branch, e.g. "origin/feature".
 	Base string
 
 	// BaseHash is the hash of the base branch.
@@ -151,17 +165,17 @@ func (s *Service) CheckRestacked(ctx context.Context, name string) (baseHash git
 		return git.ZeroHash, err
 	}
 
-	baseHash, err = s.repo.PeelToCommit(ctx, b.Base)
+	baseHash, err = s.repo.PeelToCommit(ctx, b.BaseRef())
 	if err != nil {
 		if errors.Is(err, git.ErrNotExist) {
-			return git.ZeroHash, fmt.Errorf("base branch %v does not exist", b.Base)
+			return git.ZeroHash, fmt.Errorf("base branch %v does not exist", b.baseName())
 		}
-		return git.ZeroHash, fmt.Errorf("find commit for %v: %w", b.Base, err)
+		return git.ZeroHash, fmt.Errorf("find commit for %v: %w", b.baseName(), err)
 	}
 
 	if !s.repo.IsAncestor(ctx, baseHash, b.Head) {
 		return git.ZeroHash, &BranchNeedsRestackError{
-			Base:     b.Base,
+			Base:     b.baseName(),
 			BaseHash: baseHash,
 		}
 	}
@@ -100,6 +100,14 @@ type Service struct {
 	store  Store         // required
 	log    *silog.Logger
 	forges *forge.Registry
+
+	// signCommits is true if commits rewritten
+	// by restacking should be signed.
+	signCommits bool
+
+	// protectedBranches is a list of glob patterns
+	// for branches that must not be modified.
+	protectedBranches []string
 }
 
 // NewService builds a new service operating on the given repository and store.
@@ -129,6 +137,15 @@ func newService(
 	}
 }
 
+// SetSignCommits specifies whether commits rewritten
+// when branches are restacked or moved should be signed.
+//
+// Commits are always signed if the user's Git configuration
+// asks for it (e.g. commit.gpgSign) regardless of this setting.
+func (s *Service) SetSignCommits(sign bool) {
+	s.signCommits = sign
+}
+
 // Trunk reports the name of the trunk branch.
 func (s *Service) Trunk() string {
 	return s.store.Trunk()
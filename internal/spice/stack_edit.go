@@ -27,6 +27,13 @@ type StackEditRequest struct {
 
 	// Editor to use for editing the stack.
 	Editor string
+
+	// DropDeleted indicates that branches deleted from the list
+	// should be removed from the stack
+	// by moving them onto the base of the lowest branch in the stack.
+	//
+	// If unset, deleted branches are not modified.
+	DropDeleted bool
 }
 
 // StackEditResult is the result of a stack edit operation.
@@ -34,6 +41,10 @@ type StackEditResult struct {
 	// Stack is the new order of branches after the edit operation.
 	// The branch closest to trunk is first in the list.
 	Stack []string
+
+	// Dropped lists branches that were removed from the stack.
+	// This is set only if [StackEditRequest.DropDeleted] was set.
+	Dropped []string
 }
 
 // StackEdit allows the user to edit the order of branches in a stack.
@@ -52,7 +63,11 @@ func (s *Service) StackEdit(ctx context.Context, req *StackEditRequest) (*StackE
 		return nil, fmt.Errorf("look up lowest branch (%q): %w", req.Stack[0], err)
 	}
 
-	branches, err := editStackFile(req.Editor, req.Stack)
+	footer := _stackEditFileFooter
+	if req.DropDeleted {
+		footer = _stackEditDropFileFooter
+	}
+	branches, err := editStackFile(req.Editor, req.Stack, footer)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +100,27 @@ func (s *Service) StackEdit(ctx context.Context, req *StackEditRequest) (*StackE
 		base = branch
 	}
 
-	return &StackEditResult{Stack: branches}, nil
+	var dropped []string
+	if req.DropDeleted {
+		for _, branch := range req.Stack {
+			if slices.Contains(branches, branch) {
+				continue
+			}
+
+			if err := s.BranchOnto(ctx, &BranchOntoRequest{
+				Branch: branch,
+				Onto:   bottom.Base,
+			}); err != nil {
+				return nil, fmt.Errorf("branch %v onto %v: %w", branch, bottom.Base, err)
+			}
+			dropped = append(dropped, branch)
+		}
+	}
+
+	return &StackEditResult{
+		Stack:   branches,
+		Dropped: dropped,
+	}, nil
 }
 
 // editStackFile opens the editor with the given branches
@@ -96,13 +131,13 @@ func (s *Service) StackEdit(ctx context.Context, req *StackEditRequest) (*StackE
 // The response list will be in the same order as the input list.
 //
 // Returns ErrStackEditAborted if the user aborts the edit operation.
-func editStackFile(editor string, branches []string) ([]string, error) {
+func editStackFile(editor string, branches []string, footer string) ([]string, error) {
 	originals := make(map[string]struct{}, len(branches))
 	for _, branch := range branches {
 		originals[branch] = struct{}{}
 	}
 
-	branchesFile, err := createStackEditFile(branches)
+	branchesFile, err := createStackEditFile(branches, footer)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +192,17 @@ const _stackEditFileFooter = `
 # Delete all lines in the editor to abort the operation.
 `
 
-func createStackEditFile(branches []string) (_ string, err error) {
+const _stackEditDropFileFooter = `
+# Edit the order of branches by modifying the list above.
+# The branch at the bottom of the list will be merged into trunk first.
+# Branches above that will be stacked on top of it in the order they appear.
+# Branches deleted from the list will be moved out of the stack.
+#
+# Save and quit the editor to apply the changes.
+# Delete all lines in the editor to abort the operation.
+`
+
+func createStackEditFile(branches []string, footer string) (_ string, err error) {
 	// TODO:
 	// Is there a file format that'll get highlighted correctly in editors?
 	file, err := os.CreateTemp("", "spice-edit-*.txt")
@@ -172,7 +217,7 @@ func createStackEditFile(branches []string) (_ string, err error) {
 		}
 	}
 
-	if _, err := io.WriteString(file, _stackEditFileFooter); err != nil {
+	if _, err := io.WriteString(file, footer); err != nil {
 		return "", fmt.Errorf("write footer: %w", err)
 	}
 
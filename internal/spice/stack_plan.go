@@ -0,0 +1,166 @@
+package spice
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StackPlan is a list of branches to create as a stack
+// before any work has been done on them.
+type StackPlan struct {
+	// Base is the branch to start the stack from.
+	// If empty, the caller picks the base.
+	Base string `yaml:"base"`
+
+	// Branches in the stack, with the branch closest to the base first.
+	Branches []StackPlanBranch `yaml:"branches"`
+}
+
+// StackPlanBranch is a single branch in a [StackPlan].
+type StackPlanBranch struct {
+	// Name of the branch.
+	Name string `yaml:"name"`
+
+	// Description of the work planned for the branch.
+	// This may be empty.
+	Description string `yaml:"description"`
+}
+
+// ParseStackPlan parses a stack plan from the given reader.
+//
+// Files with a .yaml or .yml extension are parsed as YAML
+// in the following format:
+//
+//	base: main
+//	branches:
+//	  - name: feat1
+//	    description: Add feature 1
+//	  - name: feat2
+//
+// All other files are parsed as Markdown lists:
+// each top-level list item is a branch, in the form "name: description",
+// and indented lines that follow an item continue its description.
+// Everything outside of list items is ignored.
+//
+//	# Refactor the widget
+//
+//	- feat1: Add feature 1
+//	- feat2: Add feature 2
+//	  on top of feature 1
+func ParseStackPlan(filename string, r io.Reader) (*StackPlan, error) {
+	var (
+		plan *StackPlan
+		err  error
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		plan, err = parseStackPlanYAML(r)
+	default:
+		plan, err = parseStackPlanMarkdown(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plan.Branches) == 0 {
+		return nil, errors.New("plan does not list any branches")
+	}
+
+	seen := make(map[string]struct{}, len(plan.Branches))
+	for idx, b := range plan.Branches {
+		if b.Name == "" {
+			return nil, fmt.Errorf("branch %d: name is required", idx+1)
+		}
+		if _, ok := seen[b.Name]; ok {
+			return nil, fmt.Errorf("branch %v is listed more than once", b.Name)
+		}
+		seen[b.Name] = struct{}{}
+	}
+
+	return plan, nil
+}
+
+func parseStackPlanYAML(r io.Reader) (*StackPlan, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var plan StackPlan
+	if err := dec.Decode(&plan); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &plan, nil
+		}
+		return nil, fmt.Errorf("decode YAML: %w", err)
+	}
+
+	for i, b := range plan.Branches {
+		plan.Branches[i] = StackPlanBranch{
+			Name:        strings.TrimSpace(b.Name),
+			Description: strings.TrimSpace(b.Description),
+		}
+	}
+	return &plan, nil
+}
+
+// _planListItemRe matches top-level Markdown list items:
+// "- item", "* item", "+ item", "1. item", or "1) item".
+var _planListItemRe = regexp.MustCompile(`^ {0,3}(?:[-*+]|\d+[.)])\s+(.*)$`)
+
+func parseStackPlanMarkdown(r io.Reader) (*StackPlan, error) {
+	var (
+		plan StackPlan
+		desc []string // description lines of the current branch
+
+		// Whether indented lines continue the current item.
+		// This is false until the first list item,
+		// and after any unindented line that isn't a list item.
+		inItem bool
+	)
+	flush := func() {
+		if len(plan.Branches) > 0 && len(desc) > 0 {
+			last := &plan.Branches[len(plan.Branches)-1]
+			last.Description = strings.Join(desc, "\n")
+		}
+		desc = nil
+	}
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := strings.TrimRight(scan.Text(), " \t")
+		if m := _planListItemRe.FindStringSubmatch(line); m != nil {
+			flush()
+
+			name, description, _ := strings.Cut(m[1], ":")
+			name = strings.Trim(strings.TrimSpace(name), "`")
+			plan.Branches = append(plan.Branches, StackPlanBranch{Name: name})
+			if description = strings.TrimSpace(description); description != "" {
+				desc = append(desc, description)
+			}
+			inItem = true
+			continue
+		}
+
+		switch {
+		case line == "":
+			// Blank lines don't end an item,
+			// but they're not part of the description either.
+		case inItem && (line[0] == ' ' || line[0] == '\t'):
+			desc = append(desc, strings.TrimSpace(line))
+		default:
+			flush()
+			inItem = false
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, fmt.Errorf("read plan: %w", err)
+	}
+	flush()
+
+	return &plan, nil
+}
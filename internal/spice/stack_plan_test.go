@@ -0,0 +1,160 @@
+package spice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestParseStackPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		give     string
+		want     *StackPlan
+	}{
+		{
+			name:     "YAML",
+			filename: "plan.yaml",
+			give: text.Dedent(`
+				base: main
+				branches:
+				  - name: feat1
+				    description: Add feature 1
+				  - name: feat2
+			`),
+			want: &StackPlan{
+				Base: "main",
+				Branches: []StackPlanBranch{
+					{Name: "feat1", Description: "Add feature 1"},
+					{Name: "feat2"},
+				},
+			},
+		},
+		{
+			name:     "YMLMultilineDescription",
+			filename: "plan.yml",
+			give: text.Dedent(`
+				branches:
+				  - name: feat1
+				    description: |
+				      Add feature 1.
+				      Then use it.
+			`),
+			want: &StackPlan{
+				Branches: []StackPlanBranch{
+					{Name: "feat1", Description: "Add feature 1.\nThen use it."},
+				},
+			},
+		},
+		{
+			name:     "Markdown",
+			filename: "plan.md",
+			give: text.Dedent(`
+				# Refactor the widget
+
+				Some notes that are not part of the plan.
+
+				- feat1: Add feature 1
+				- feat2: Add feature 2
+				  on top of feature 1
+
+				- feat3
+			`),
+			want: &StackPlan{
+				Branches: []StackPlanBranch{
+					{Name: "feat1", Description: "Add feature 1"},
+					{Name: "feat2", Description: "Add feature 2\non top of feature 1"},
+					{Name: "feat3"},
+				},
+			},
+		},
+		{
+			name:     "MarkdownNumberedBackticks",
+			filename: "PLAN",
+			give: text.Dedent(`
+				1. ` + "`refactor/api`" + `: Extract the API
+				2) refactor/callers: Update callers
+			`),
+			want: &StackPlan{
+				Branches: []StackPlanBranch{
+					{Name: "refactor/api", Description: "Extract the API"},
+					{Name: "refactor/callers", Description: "Update callers"},
+				},
+			},
+		},
+		{
+			name:     "MarkdownParagraphEndsItem",
+			filename: "plan.md",
+			give: text.Dedent(`
+				- feat1: Add feature 1
+
+				Trailing notes.
+				  Indented, but not part of feat1.
+			`),
+			want: &StackPlan{
+				Branches: []StackPlanBranch{
+					{Name: "feat1", Description: "Add feature 1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStackPlan(tt.filename, strings.NewReader(tt.give))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseStackPlan_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		give     string
+		wantErr  string
+	}{
+		{
+			name:     "Empty",
+			filename: "plan.md",
+			give:     "# Nothing here\n",
+			wantErr:  "does not list any branches",
+		},
+		{
+			name:     "EmptyYAML",
+			filename: "plan.yaml",
+			give:     "",
+			wantErr:  "does not list any branches",
+		},
+		{
+			name:     "Duplicate",
+			filename: "plan.md",
+			give:     "- feat1\n- feat1\n",
+			wantErr:  "feat1 is listed more than once",
+		},
+		{
+			name:     "MissingName",
+			filename: "plan.md",
+			give:     "- feat1\n- : no name\n",
+			wantErr:  "branch 2: name is required",
+		},
+		{
+			name:     "UnknownYAMLField",
+			filename: "plan.yaml",
+			give:     "branches:\n  - name: feat1\n    title: Feature 1\n",
+			wantErr:  "field title not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStackPlan(tt.filename, strings.NewReader(tt.give))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
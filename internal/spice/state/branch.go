@@ -28,10 +28,27 @@ const _branchesDir = "branches"
 type branchStateBase struct {
 	Name string `json:"name"`
 	Hash string `json:"hash"`
+
+	// Upstream is set if the branch is based on a branch
+	// in a remote repository instead of Name.
+	Upstream *branchStateUpstreamBase `json:"upstream,omitempty"`
+}
+
+type branchStateUpstreamBase struct {
+	Remote string `json:"remote"`
+	Branch string `json:"branch"`
 }
 
 type branchUpstreamState struct {
 	Branch string `json:"branch,omitempty"`
+
+	// Remote is the remote the branch was pushed to
+	// if it's different from the repository's remote,
+	// e.g. when the branch was pushed to a fork.
+	Remote string `json:"remote,omitempty"`
+
+	// Hash is the last commit git-spice pushed to the upstream branch.
+	Hash string `json:"hash,omitempty"`
 }
 
 type branchChangeState struct {
@@ -61,12 +78,38 @@ func (bs *branchChangeState) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type branchPickState struct {
+	Commit string `json:"commit"`
+	From   string `json:"from,omitempty"`
+	Hash   string `json:"hash"`
+}
+
+// branchCommitChangeState is a change submitted
+// for a single commit of a branch.
+type branchCommitChangeState struct {
+	Hash    string             `json:"hash"`
+	Subject string             `json:"subject"`
+	Branch  string             `json:"branch"`
+	Base    string             `json:"base"`
+	Change  *branchChangeState `json:"change,omitempty"`
+}
+
 type branchState struct {
 	Base     branchStateBase      `json:"base"`
 	Upstream *branchUpstreamState `json:"upstream,omitempty"`
 	Change   *branchChangeState   `json:"change,omitempty"`
 
 	MergedDownstack []json.RawMessage `json:"merged,omitempty"`
+
+	Note string `json:"note,omitempty"`
+
+	Archived bool `json:"archived,omitempty"`
+
+	Config map[string][]string `json:"config,omitempty"`
+
+	Picks []branchPickState `json:"picks,omitempty"`
+
+	Commits []branchCommitChangeState `json:"commits,omitempty"`
 }
 
 // branchKey returns the path to the JSON file for the given branch
@@ -99,6 +142,19 @@ type LookupResponse struct {
 	// or an empty string if the branch is not tracking an upstream branch.
 	UpstreamBranch string
 
+	// UpstreamRemote is the name of the remote
+	// that the upstream branch was pushed to
+	// if it's different from the repository's remote,
+	// e.g. when the branch was pushed to a fork.
+	// It is empty if the branch was pushed to the repository's remote.
+	UpstreamRemote string
+
+	// UpstreamHash is the last commit pushed to the upstream branch
+	// by git-spice, or an empty hash if it's not known.
+	// The upstream branch may have been updated since
+	// by someone else.
+	UpstreamHash git.Hash
+
 	// MergedDownstack holds information about branches
 	// that were previously downstack from this branch
 	// that have since been merged into trunk.
@@ -107,6 +163,94 @@ type LookupResponse struct {
 	// For example, if the stack was main -> A -> B -> C,
 	// where C is this branch, MergedDownstack will be [A, B].
 	MergedDownstack []json.RawMessage
+
+	// Note is a free-form note attached to the branch by the user.
+	// It is empty if the branch has no note.
+	Note string
+
+	// Archived indicates that the branch was archived by the user
+	// and should be left out of day-to-day operations.
+	Archived bool
+
+	// Config holds configuration overrides set on the branch,
+	// keyed by configuration key, e.g. "spice.submit.reviewers".
+	// It is nil if the branch has no overrides.
+	Config map[string][]string
+
+	// Picks lists commits that were cherry-picked onto the branch,
+	// in the order they were picked.
+	Picks []PickedCommit
+
+	// UpstreamBase is the remote branch that this branch is based on,
+	// or nil if it's based on Base.
+	UpstreamBase *UpstreamBase
+
+	// CommitChanges lists the changes submitted
+	// for individual commits of the branch, oldest commit first.
+	// It is empty unless the branch was submitted
+	// with one change per commit.
+	CommitChanges []CommitChange
+}
+
+// UpstreamBase is a branch in a remote repository
+// that a tracked branch is based on,
+// e.g. a coworker's branch that is still in review.
+//
+// Branches with an upstream base are based on trunk in the branch graph.
+type UpstreamBase struct {
+	// Remote is the name of the remote, e.g. "origin".
+	Remote string
+
+	// Branch is the name of the branch in the remote.
+	Branch string
+}
+
+// Ref returns the remote-tracking reference for the branch,
+// e.g. "refs/remotes/origin/feature".
+func (b *UpstreamBase) Ref() string {
+	return "refs/remotes/" + b.Remote + "/" + b.Branch
+}
+
+func (b *UpstreamBase) String() string {
+	return b.Remote + "/" + b.Branch
+}
+
+// CommitChange is a change submitted for a single commit of a branch
+// when the branch is submitted with one change per commit.
+type CommitChange struct {
+	// Hash is the last version of the commit that was pushed.
+	Hash git.Hash
+
+	// Subject is the subject of the commit when it was last pushed.
+	// This is used to find the commit after it has been amended.
+	Subject string
+
+	// UpstreamBranch is the branch in the remote
+	// that the commit was pushed to.
+	UpstreamBranch string
+
+	// UpstreamBase is the branch in the remote
+	// that the change was last based on.
+	UpstreamBase string
+
+	// ChangeForge is the forge that the change was published to.
+	ChangeForge string
+
+	// ChangeMetadata holds the forge-specific metadata for the change.
+	ChangeMetadata json.RawMessage
+}
+
+// PickedCommit records that a commit was cherry-picked onto a branch.
+type PickedCommit struct {
+	// Commit is the commit that was cherry-picked.
+	Commit git.Hash
+
+	// From is the branch that the commit was picked from,
+	// or an empty string if it's not known.
+	From string
+
+	// Hash is the commit that was created on the branch.
+	Hash git.Hash
 }
 
 // LookupBranch returns information about a tracked branch.
@@ -121,6 +265,30 @@ func (s *Store) LookupBranch(ctx context.Context, name string) (*LookupResponse,
 		Base:            state.Base.Name,
 		BaseHash:        git.Hash(state.Base.Hash),
 		MergedDownstack: state.MergedDownstack,
+		Note:            state.Note,
+		Archived:        state.Archived,
+		Config:          state.Config,
+	}
+	for _, pick := range state.Picks {
+		res.Picks = append(res.Picks, PickedCommit{
+			Commit: git.Hash(pick.Commit),
+			From:   pick.From,
+			Hash:   git.Hash(pick.Hash),
+		})
+	}
+
+	for _, c := range state.Commits {
+		cc := CommitChange{
+			Hash:           git.Hash(c.Hash),
+			Subject:        c.Subject,
+			UpstreamBranch: c.Branch,
+			UpstreamBase:   c.Base,
+		}
+		if c.Change != nil {
+			cc.ChangeForge = c.Change.Forge
+			cc.ChangeMetadata = c.Change.Change
+		}
+		res.CommitChanges = append(res.CommitChanges, cc)
 	}
 
 	if change := state.Change; change != nil {
@@ -128,8 +296,17 @@ func (s *Store) LookupBranch(ctx context.Context, name string) (*LookupResponse,
 		res.ChangeForge = change.Forge
 	}
 
+	if upstream := state.Base.Upstream; upstream != nil {
+		res.UpstreamBase = &UpstreamBase{
+			Remote: upstream.Remote,
+			Branch: upstream.Branch,
+		}
+	}
+
 	if upstream := state.Upstream; upstream != nil {
 		res.UpstreamBranch = upstream.Branch
+		res.UpstreamRemote = upstream.Remote
+		res.UpstreamHash = git.Hash(upstream.Hash)
 	}
 
 	return res, nil
@@ -212,6 +389,11 @@ type UpsertRequest struct {
 	// Leave empty to keep the current base hash.
 	BaseHash git.Hash
 
+	// UpstreamBase is the remote branch to base the branch on.
+	// Leave nil to leave it unchanged, or set to a zero value to clear it.
+	// It is cleared if Base changes.
+	UpstreamBase *UpstreamBase
+
 	// ChangeMetadata is arbitrary, forge-specific metadata
 	// recorded with the branch.
 	//
@@ -229,9 +411,47 @@ type UpsertRequest struct {
 	// Leave nil to leave it unchanged, or set to an empty string to clear it.
 	UpstreamBranch *string
 
+	// UpstreamRemote is the name of the remote
+	// that the upstream branch was pushed to
+	// if it's different from the repository's remote.
+	// Leave nil to leave it unchanged, or set to an empty string to clear it.
+	//
+	// This is ignored if the branch does not have an upstream branch.
+	UpstreamRemote *string
+
+	// UpstreamHash is the commit that was pushed to the upstream branch.
+	// Leave nil to leave it unchanged, or set to an empty hash to clear it.
+	// It is cleared if the upstream branch is renamed.
+	//
+	// This is ignored if the branch does not have an upstream branch.
+	UpstreamHash *git.Hash
+
 	// MergedDownstack is a list of branches that were previously
 	// downstack from this branch that have since been merged into trunk.
 	MergedDownstack *[]json.RawMessage
+
+	// Note is a free-form note to attach to the branch.
+	// Leave nil to leave it unchanged, or set to an empty string to clear it.
+	Note *string
+
+	// Archived specifies whether the branch is archived.
+	// Leave nil to leave it unchanged.
+	Archived *bool
+
+	// Config replaces the configuration overrides set on the branch.
+	// Leave nil to leave them unchanged,
+	// or set to an empty map to clear them.
+	Config *map[string][]string
+
+	// AddPicks records commits that were cherry-picked onto the branch.
+	// They're added after any previously recorded picks.
+	AddPicks []PickedCommit
+
+	// CommitChanges replaces the changes submitted
+	// for individual commits of the branch.
+	// Leave nil to leave them unchanged,
+	// or set to an empty slice to clear them.
+	CommitChanges *[]CommitChange
 }
 
 // Upsert adds or updates information about a branch.
@@ -281,9 +501,25 @@ func (tx *BranchTx) Upsert(ctx context.Context, req UpsertRequest) error {
 			}
 
 		}
+		if state.Base.Name != req.Base {
+			state.Base.Upstream = nil
+		}
 		state.Base.Name = req.Base
 	}
 
+	if req.UpstreamBase != nil {
+		if *req.UpstreamBase == (UpstreamBase{}) {
+			state.Base.Upstream = nil
+		} else {
+			must.NotBeBlankf(req.UpstreamBase.Remote, "upstream base remote is required")
+			must.NotBeBlankf(req.UpstreamBase.Branch, "upstream base branch is required")
+			state.Base.Upstream = &branchStateUpstreamBase{
+				Remote: req.UpstreamBase.Remote,
+				Branch: req.UpstreamBase.Branch,
+			}
+		}
+	}
+
 	if req.BaseHash != "" {
 		state.Base.Hash = req.BaseHash.String()
 	}
@@ -304,16 +540,77 @@ func (tx *BranchTx) Upsert(ctx context.Context, req UpsertRequest) error {
 		if *req.UpstreamBranch == "" {
 			state.Upstream = nil
 		} else {
+			var remote, hash string
+			if old := state.Upstream; old != nil {
+				remote = old.Remote
+				if old.Branch == *req.UpstreamBranch {
+					hash = old.Hash
+				}
+			}
 			state.Upstream = &branchUpstreamState{
 				Branch: *req.UpstreamBranch,
+				Remote: remote,
+				Hash:   hash,
 			}
 		}
 	}
 
+	if req.UpstreamRemote != nil && state.Upstream != nil {
+		state.Upstream.Remote = *req.UpstreamRemote
+	}
+
+	if req.UpstreamHash != nil && state.Upstream != nil {
+		state.Upstream.Hash = req.UpstreamHash.String()
+	}
+
 	if req.MergedDownstack != nil {
 		state.MergedDownstack = *req.MergedDownstack
 	}
 
+	if req.Note != nil {
+		state.Note = *req.Note
+	}
+
+	if req.Archived != nil {
+		state.Archived = *req.Archived
+	}
+
+	if req.Config != nil {
+		state.Config = nil
+		if len(*req.Config) > 0 {
+			state.Config = maps.Clone(*req.Config)
+		}
+	}
+
+	for _, pick := range req.AddPicks {
+		state.Picks = append(state.Picks, branchPickState{
+			Commit: pick.Commit.String(),
+			From:   pick.From,
+			Hash:   pick.Hash.String(),
+		})
+	}
+
+	if req.CommitChanges != nil {
+		state.Commits = nil
+		for _, c := range *req.CommitChanges {
+			must.NotBeBlankf(c.UpstreamBranch, "upstream branch is required for commit changes")
+			cs := branchCommitChangeState{
+				Hash:    c.Hash.String(),
+				Subject: c.Subject,
+				Branch:  c.UpstreamBranch,
+				Base:    c.UpstreamBase,
+			}
+			if len(c.ChangeMetadata) > 0 {
+				must.NotBeBlankf(c.ChangeForge, "change forge is required when change metadata is set")
+				cs.Change = &branchChangeState{
+					Forge:  c.ChangeForge,
+					Change: c.ChangeMetadata,
+				}
+			}
+			state.Commits = append(state.Commits, cs)
+		}
+	}
+
 	tx.states[req.Name] = state
 	tx.sets[req.Name] = struct{}{}
 	delete(tx.dels, req.Name)
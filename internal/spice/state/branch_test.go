@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/sliceutil"
 	"go.abhg.dev/gs/internal/spice/state"
 	"go.abhg.dev/gs/internal/spice/state/statetest"
@@ -287,6 +288,256 @@ func TestBranchTxUpsert_canClearUpstream(t *testing.T) {
 	assert.Equal(t, "", foo.UpstreamBranch)
 }
 
+func TestBranchTxUpsert_note(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	note := "Waiting on API review"
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name: "foo",
+				Base: "main",
+				Note: &note,
+			},
+		},
+		Message: "add foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "Waiting on API review", foo.Note)
+
+	// Unrelated updates leave the note alone.
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", BaseHash: "abc"},
+		},
+		Message: "update foo",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "Waiting on API review", foo.Note)
+
+	var empty string
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", Note: &empty},
+		},
+		Message: "clear note",
+	}))
+
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Empty(t, foo.Note)
+}
+
+func TestBranchTxUpsert_config(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	config := map[string][]string{
+		"spice.submit.reviewers": {"alice", "bob"},
+		"spice.submit.draft":     {"true"},
+	}
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name:   "foo",
+				Base:   "main",
+				Config: &config,
+			},
+		},
+		Message: "add foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, config, foo.Config)
+
+	// Unrelated updates leave the overrides alone.
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", BaseHash: "abc"},
+		},
+		Message: "update foo",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, config, foo.Config)
+
+	empty := map[string][]string{}
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", Config: &empty},
+		},
+		Message: "clear config",
+	}))
+
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Nil(t, foo.Config)
+}
+
+func TestBranchTxUpsert_archived(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	archived := true
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name:     "foo",
+				Base:     "main",
+				Archived: &archived,
+			},
+		},
+		Message: "add foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.True(t, foo.Archived)
+
+	// Unrelated updates leave the branch archived.
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", BaseHash: "abc"},
+		},
+		Message: "update foo",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.True(t, foo.Archived)
+
+	archived = false
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", Archived: &archived},
+		},
+		Message: "unarchive foo",
+	}))
+
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.False(t, foo.Archived)
+}
+
+func TestBranchTxUpsert_picks(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	first := state.PickedCommit{Commit: "abc", From: "bar", Hash: "def"}
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name:     "foo",
+				Base:     "main",
+				AddPicks: []state.PickedCommit{first},
+			},
+		},
+		Message: "add foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []state.PickedCommit{first}, foo.Picks)
+
+	// New picks are added after existing ones.
+	second := state.PickedCommit{Commit: "123", Hash: "456"}
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", AddPicks: []state.PickedCommit{second}},
+		},
+		Message: "pick onto foo",
+	}))
+
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []state.PickedCommit{first, second}, foo.Picks)
+}
+
+func TestBranchTxUpsert_commitChanges(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	changes := []state.CommitChange{
+		{
+			Hash:           "abc",
+			Subject:        "first",
+			UpstreamBranch: "foo-1",
+			UpstreamBase:   "main",
+			ChangeForge:    "shamhub",
+			ChangeMetadata: json.RawMessage(`{"number":1}`),
+		},
+		{
+			Hash:           "def",
+			Subject:        "second",
+			UpstreamBranch: "foo-2",
+			UpstreamBase:   "foo-1",
+		},
+	}
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name:          "foo",
+				Base:          "main",
+				CommitChanges: &changes,
+			},
+		},
+		Message: "add foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, changes, foo.CommitChanges)
+
+	// Unrelated updates leave them alone.
+	note := "hello"
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{{Name: "foo", Note: &note}},
+		Message: "add note",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, changes, foo.CommitChanges)
+
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", CommitChanges: &[]state.CommitChange{}},
+		},
+		Message: "clear commit changes",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Empty(t, foo.CommitChanges)
+}
+
 // Uses rapid to run randomized scenarios on the branch state
 // to ensure we never leave it in a corrupted state.
 func TestBranchStateUncorruptible(t *testing.T) {
@@ -565,3 +816,188 @@ func (sm *branchStateUncorruptible) ChangeTrunk(t *rapid.T) {
 	sm.trunk = newTrunk
 	t.Logf("changed trunk to %q", newTrunk)
 }
+
+func TestBranchTxUpsert_upstreamRemote(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	upstream, remote := "feature", "fork"
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name:           "foo",
+				Base:           "main",
+				UpstreamBranch: &upstream,
+				UpstreamRemote: &remote,
+			},
+		},
+		Message: "add foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "feature", foo.UpstreamBranch)
+	assert.Equal(t, "fork", foo.UpstreamRemote)
+
+	// Changing the upstream branch name keeps the remote.
+	renamed := "feature-2"
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", UpstreamBranch: &renamed},
+		},
+		Message: "rename upstream",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "feature-2", foo.UpstreamBranch)
+	assert.Equal(t, "fork", foo.UpstreamRemote)
+
+	// Clearing the upstream branch clears the remote.
+	var empty string
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", UpstreamBranch: &empty},
+		},
+		Message: "clear upstream",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Empty(t, foo.UpstreamBranch)
+	assert.Empty(t, foo.UpstreamRemote)
+}
+
+func TestBranchTxUpsert_upstreamHash(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	upstream, hash := "foo", git.Hash("abc")
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name:           "foo",
+				Base:           "main",
+				UpstreamBranch: &upstream,
+				UpstreamHash:   &hash,
+			},
+		},
+		Message: "push foo",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, git.Hash("abc"), foo.UpstreamHash)
+
+	// Setting the same upstream branch keeps the hash.
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", UpstreamBranch: &upstream},
+		},
+		Message: "update foo",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, git.Hash("abc"), foo.UpstreamHash)
+
+	// Renaming the upstream branch clears it.
+	renamed := "bar"
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", UpstreamBranch: &renamed},
+		},
+		Message: "rename upstream",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", foo.UpstreamBranch)
+	assert.Empty(t, foo.UpstreamHash)
+}
+
+func TestBranchTxUpsert_upstreamBase(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+	store, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{
+				Name: "foo",
+				Base: "main",
+				UpstreamBase: &state.UpstreamBase{
+					Remote: "origin",
+					Branch: "alice/feature",
+				},
+			},
+			{Name: "bar", Base: "main"},
+		},
+		Message: "add foo and bar",
+	}))
+
+	foo, err := store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, &state.UpstreamBase{
+		Remote: "origin",
+		Branch: "alice/feature",
+	}, foo.UpstreamBase)
+	assert.Equal(t, "refs/remotes/origin/alice/feature", foo.UpstreamBase.Ref())
+
+	// Unrelated updates leave the upstream base alone.
+	require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+		Upserts: []state.UpsertRequest{
+			{Name: "foo", Base: "main", BaseHash: "abc"},
+		},
+		Message: "update foo",
+	}))
+	foo, err = store.LookupBranch(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "origin/alice/feature", foo.UpstreamBase.String())
+
+	t.Run("Clear", func(t *testing.T) {
+		require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+			Upserts: []state.UpsertRequest{
+				{Name: "foo", UpstreamBase: &state.UpstreamBase{}},
+			},
+			Message: "clear upstream base",
+		}))
+
+		foo, err := store.LookupBranch(ctx, "foo")
+		require.NoError(t, err)
+		assert.Nil(t, foo.UpstreamBase)
+	})
+
+	t.Run("ChangeBase", func(t *testing.T) {
+		require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+			Upserts: []state.UpsertRequest{
+				{
+					Name:         "foo",
+					UpstreamBase: &state.UpstreamBase{Remote: "origin", Branch: "bob"},
+				},
+			},
+			Message: "set upstream base",
+		}))
+		require.NoError(t, statetest.UpdateBranch(ctx, store, &statetest.UpdateRequest{
+			Upserts: []state.UpsertRequest{
+				{Name: "foo", Base: "bar"},
+			},
+			Message: "move foo onto bar",
+		}))
+
+		foo, err := store.LookupBranch(ctx, "foo")
+		require.NoError(t, err)
+		assert.Equal(t, "bar", foo.Base)
+		assert.Nil(t, foo.UpstreamBase)
+	})
+}
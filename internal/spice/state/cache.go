@@ -0,0 +1,29 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+const _cacheDir = "cache"
+
+// LoadCache loads the value cached under the given name into v.
+// Returns [ErrNotExist] if nothing is cached under that name.
+//
+// Names may contain "/" to group related values.
+func (s *Store) LoadCache(ctx context.Context, name string, v any) error {
+	if err := s.db.Get(ctx, path.Join(_cacheDir, name), v); err != nil {
+		return fmt.Errorf("load cache %q: %w", name, err)
+	}
+	return nil
+}
+
+// SaveCache caches v under the given name.
+// If there's existing cached data, it will be overwritten.
+func (s *Store) SaveCache(ctx context.Context, name string, v any) error {
+	if err := s.db.Set(ctx, path.Join(_cacheDir, name), v, "cache "+name); err != nil {
+		return fmt.Errorf("save cache %q: %w", name, err)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/must"
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/timing"
 )
 
 // GitRepository is the subset of the git.Repository API used by the state package.
@@ -36,6 +37,8 @@ var _ GitRepository = (*git.Repository)(nil)
 
 // GitBackend implements a storage backend using a Git repository
 // reference as the storage medium.
+//
+// Git commands run by GitBackend are timed as [timing.Store].
 type GitBackend struct {
 	repo GitRepository
 	ref  string
@@ -77,6 +80,7 @@ func NewGitBackend(cfg GitConfig) *GitBackend {
 func (g *GitBackend) Keys(ctx context.Context, dir string) ([]string, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
+	ctx = timing.Attribute(ctx, timing.Store)
 
 	var (
 		treeHash git.Hash
@@ -114,6 +118,7 @@ func (g *GitBackend) Keys(ctx context.Context, dir string) ([]string, error) {
 func (g *GitBackend) Get(ctx context.Context, key string, v any) error {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
+	ctx = timing.Attribute(ctx, timing.Store)
 
 	blobHash, err := g.repo.HashAt(ctx, g.ref, key)
 	if err != nil {
@@ -136,6 +141,7 @@ func (g *GitBackend) Get(ctx context.Context, key string, v any) error {
 func (g *GitBackend) Clear(ctx context.Context, msg string) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	ctx = timing.Attribute(ctx, timing.Store)
 
 	prevCommit, err := g.repo.PeelToCommit(ctx, g.ref)
 	if err != nil {
@@ -176,6 +182,7 @@ func (g *GitBackend) Clear(ctx context.Context, msg string) error {
 func (g *GitBackend) Update(ctx context.Context, req UpdateRequest) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	ctx = timing.Attribute(ctx, timing.Store)
 
 	setBlobs := make([]git.Hash, len(req.Sets))
 	for i, set := range req.Sets {
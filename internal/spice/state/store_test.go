@@ -129,6 +129,34 @@ func TestStore(t *testing.T) {
 	})
 }
 
+func TestStore_cache(t *testing.T) {
+	ctx := t.Context()
+	db := storage.NewDB(make(storage.MapBackend))
+
+	_, err := state.InitStore(ctx, state.InitStoreRequest{
+		DB:    db,
+		Trunk: "main",
+	})
+	require.NoError(t, err)
+
+	store, err := state.OpenStore(ctx, db, silogtest.New(t))
+	require.NoError(t, err)
+
+	var got map[string]string
+	err = store.LoadCache(ctx, "bitbucket/users/ws", &got)
+	assert.ErrorIs(t, err, state.ErrNotExist)
+
+	require.NoError(t, store.SaveCache(ctx, "bitbucket/users/ws", map[string]string{"alice": "{1}"}))
+	require.NoError(t, store.LoadCache(ctx, "bitbucket/users/ws", &got))
+	assert.Equal(t, map[string]string{"alice": "{1}"}, got)
+
+	// Cached values are not mistaken for branches.
+	for name, err := range store.ListBranches(ctx) {
+		require.NoError(t, err)
+		t.Errorf("unexpected branch: %v", name)
+	}
+}
+
 func TestOpenStore_errors(t *testing.T) {
 	t.Run("VersionMismatch", func(t *testing.T) {
 		mem := storage.MapBackend{
@@ -0,0 +1,110 @@
+package spice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/must"
+	"go.abhg.dev/gs/internal/spice/state"
+)
+
+// ParseUpstreamBase parses a remote branch name like "origin/feature"
+// into a remote and a branch name.
+// The remote must be known to the repository.
+func (s *Service) ParseUpstreamBase(ctx context.Context, name string) (*state.UpstreamBase, error) {
+	remotes, err := s.repo.ListRemotes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list remotes: %w", err)
+	}
+
+	// Remote names may contain slashes,
+	// so prefer the longest matching remote.
+	var match *state.UpstreamBase
+	for _, remote := range remotes {
+		branch, ok := strings.CutPrefix(name, remote+"/")
+		if !ok || branch == "" {
+			continue
+		}
+		if match == nil || len(remote) > len(match.Remote) {
+			match = &state.UpstreamBase{Remote: remote, Branch: branch}
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%v: not a remote branch: expected <remote>/<branch>", name)
+	}
+	return match, nil
+}
+
+// SetUpstreamBase bases a tracked branch on a branch in a remote repository,
+// e.g. a coworker's branch that is still in review.
+//
+// The branch is moved onto trunk in the branch graph,
+// but it is restacked on top of the remote-tracking branch
+// until the upstream base is cleared.
+// Use [Service.BranchOnto] to base it on a local branch again.
+//
+// The remote-tracking branch must already exist.
+// The branch is not rebased by this operation.
+func (s *Service) SetUpstreamBase(ctx context.Context, name string, base *state.UpstreamBase) error {
+	must.NotBeEqualf(name, s.store.Trunk(), "cannot set upstream base of trunk")
+
+	b, err := s.LookupBranch(ctx, name)
+	if err != nil {
+		return fmt.Errorf("lookup branch: %w", err)
+	}
+
+	if _, err := s.repo.PeelToCommit(ctx, base.Ref()); err != nil {
+		if errors.Is(err, git.ErrNotExist) {
+			return fmt.Errorf("remote branch %v does not exist: fetch it and try again", base)
+		}
+		return fmt.Errorf("resolve %v: %w", base, err)
+	}
+
+	// Only commits that aren't in the remote branch belong to this branch.
+	// Use their starting point as the base hash
+	// so that a restack moves only those commits.
+	baseHash, err := s.repo.MergeBase(ctx, base.Ref(), name)
+	if err != nil {
+		s.log.Debug("No merge base with upstream base, keeping base hash",
+			"branch", name, "upstreamBase", base, "error", err)
+		baseHash = b.BaseHash
+	}
+
+	tx := s.store.BeginBranchTx()
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name:         name,
+		Base:         s.store.Trunk(),
+		BaseHash:     baseHash,
+		UpstreamBase: base,
+	}); err != nil {
+		return fmt.Errorf("set upstream base of %v: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx, fmt.Sprintf("%v: based on %v", name, base)); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+	return nil
+}
+
+// ClearUpstreamBase bases a branch with an upstream base
+// back on trunk, e.g. because the remote branch was merged.
+//
+// The recorded base hash is kept so that a later restack
+// moves only the branch's own commits onto trunk.
+func (s *Service) ClearUpstreamBase(ctx context.Context, name string) error {
+	tx := s.store.BeginBranchTx()
+	if err := tx.Upsert(ctx, state.UpsertRequest{
+		Name:         name,
+		UpstreamBase: &state.UpstreamBase{},
+	}); err != nil {
+		return fmt.Errorf("clear upstream base of %v: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx, fmt.Sprintf("%v: based on %v", name, s.store.Trunk())); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+	return nil
+}
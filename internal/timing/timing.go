@@ -0,0 +1,239 @@
+// Package timing records where time is spent while running a command
+// so that it can be reported to the user.
+//
+// Nothing recorded by this package leaves the user's machine.
+//
+// A [Recorder] is carried in a context.Context.
+// Instrumented operations (e.g. Git commands, forge API requests)
+// report their durations to it with [Record].
+package timing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Category is a kind of operation that takes time.
+type Category int
+
+const (
+	// Git is time spent running Git commands.
+	Git Category = iota
+
+	// Store is time spent reading and writing git-spice's state.
+	// Git commands run on behalf of the state store
+	// are attributed here instead of to [Git].
+	Store
+
+	// Forge is time spent waiting on forge API requests.
+	Forge
+
+	// Prompt is time spent waiting for the user to answer prompts.
+	Prompt
+
+	numCategories
+)
+
+// String returns a human-readable name for the category.
+func (c Category) String() string {
+	switch c {
+	case Git:
+		return "git"
+	case Store:
+		return "state store"
+	case Forge:
+		return "forge API"
+	case Prompt:
+		return "prompts"
+	default:
+		return fmt.Sprintf("Category(%d)", int(c))
+	}
+}
+
+// unit names a single operation of the category in the report.
+func (c Category) unit() string {
+	switch c {
+	case Git, Store:
+		return "command"
+	case Forge:
+		return "request"
+	case Prompt:
+		return "prompt"
+	default:
+		return "operation"
+	}
+}
+
+// Stat is the time spent on a category of operations.
+type Stat struct {
+	// Count is the number of operations.
+	Count int
+
+	// Duration is the total time spent on them.
+	Duration time.Duration
+}
+
+// Recorder accumulates time spent on each [Category].
+// It is safe for concurrent use.
+//
+// A nil Recorder discards all records.
+type Recorder struct {
+	start time.Time
+
+	mu    sync.Mutex
+	stats [numCategories]Stat
+}
+
+// NewRecorder builds a Recorder.
+// Time elapsed since this call is reported as the total.
+func NewRecorder() *Recorder {
+	return &Recorder{start: _timeNow()}
+}
+
+var _timeNow = time.Now // for testing
+
+// Record records an operation of the given category
+// that took the given amount of time.
+func (r *Recorder) Record(c Category, d time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats[c].Count++
+	r.stats[c].Duration += d
+}
+
+// Stat reports the time spent on the given category so far.
+func (r *Recorder) Stat(c Category) Stat {
+	if r == nil {
+		return Stat{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats[c]
+}
+
+// WriteReport writes a table of the time spent on each category,
+// the time not accounted for by any category,
+// and the total time elapsed since the Recorder was created.
+//
+// Operations may run concurrently,
+// so the sum of all categories may exceed the total.
+func (r *Recorder) WriteReport(w io.Writer) error {
+	if r == nil {
+		return nil
+	}
+
+	type row struct {
+		name     string
+		duration time.Duration
+		count    string // optional
+	}
+
+	total := _timeNow().Sub(r.start)
+	other := total
+
+	var rows []row
+	for c := range numCategories {
+		stat := r.Stat(c)
+		if stat.Count == 0 {
+			continue
+		}
+		other -= stat.Duration
+
+		unit := c.unit()
+		if stat.Count != 1 {
+			unit += "s"
+		}
+		rows = append(rows, row{
+			name:     c.String(),
+			duration: stat.Duration,
+			count:    fmt.Sprintf("%d %s", stat.Count, unit),
+		})
+	}
+	rows = append(rows,
+		row{name: "other", duration: max(other, 0)},
+		row{name: "total", duration: total},
+	)
+
+	var nameWidth, durWidth int
+	for _, row := range rows {
+		nameWidth = max(nameWidth, len(row.name)+1) // +1 for ":"
+		durWidth = max(durWidth, len(round(row.duration).String()))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("Time spent:\n")
+	for _, row := range rows {
+		fmt.Fprintf(&buf, "  %-*s  %*v", nameWidth, row.name+":", durWidth, round(row.duration))
+		if row.count != "" {
+			fmt.Fprintf(&buf, "  (%s)", row.count)
+		}
+		buf.WriteString("\n")
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// round rounds a duration for display.
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a copy of ctx that carries the given Recorder.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the Recorder carried by ctx,
+// or nil if it doesn't carry one.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+type categoryKey struct{}
+
+// Attribute returns a copy of ctx that attributes
+// operations recorded with it to the given category,
+// regardless of the category they are recorded under.
+//
+// For example, the state store uses this to claim
+// the Git commands that it runs.
+func Attribute(ctx context.Context, c Category) context.Context {
+	return context.WithValue(ctx, categoryKey{}, c)
+}
+
+// Record records an operation that took the given amount of time
+// with the Recorder carried by ctx, if any.
+//
+// The operation is recorded under the given category
+// unless ctx is attributed to a different one with [Attribute].
+func Record(ctx context.Context, c Category, d time.Duration) {
+	r := FromContext(ctx)
+	if r == nil {
+		return
+	}
+
+	if attributed, ok := ctx.Value(categoryKey{}).(Category); ok {
+		c = attributed
+	}
+	r.Record(c, d)
+}
@@ -0,0 +1,76 @@
+package timing
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_WriteReport(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	defer func(old func() time.Time) { _timeNow = old }(_timeNow)
+	_timeNow = func() time.Time { return now }
+
+	rec := NewRecorder()
+	rec.Record(Git, 1200*time.Millisecond)
+	rec.Record(Git, 300*time.Millisecond)
+	rec.Record(Forge, 250*time.Millisecond)
+	rec.Record(Prompt, 3*time.Second)
+	now = now.Add(5 * time.Second)
+
+	var out strings.Builder
+	require.NoError(t, rec.WriteReport(&out))
+	assert.Equal(t, strings.Join([]string{
+		"Time spent:",
+		"  git:         1.5s  (2 commands)",
+		"  forge API:  250ms  (1 request)",
+		"  prompts:       3s  (1 prompt)",
+		"  other:      250ms",
+		"  total:         5s",
+		"",
+	}, "\n"), out.String())
+}
+
+func TestRecorder_concurrent(t *testing.T) {
+	rec := NewRecorder()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			rec.Record(Forge, time.Millisecond)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, Stat{Count: 10, Duration: 10 * time.Millisecond}, rec.Stat(Forge))
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("NoRecorder", func(*testing.T) {
+		Record(t.Context(), Git, time.Second) // doesn't panic
+	})
+
+	t.Run("Attribute", func(t *testing.T) {
+		rec := NewRecorder()
+		ctx := WithRecorder(t.Context(), rec)
+
+		Record(ctx, Git, time.Second)
+		Record(Attribute(ctx, Store), Git, 2*time.Second)
+
+		assert.Equal(t, Stat{Count: 1, Duration: time.Second}, rec.Stat(Git))
+		assert.Equal(t, Stat{Count: 1, Duration: 2 * time.Second}, rec.Stat(Store))
+	})
+
+	t.Run("NilRecorder", func(t *testing.T) {
+		var rec *Recorder
+		rec.Record(Git, time.Second)
+		assert.Zero(t, rec.Stat(Git))
+		assert.NoError(t, rec.WriteReport(nil))
+		assert.Nil(t, FromContext(context.Background()))
+	})
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Answers is a set of pre-supplied answers to prompts.
+//
+// Answers are matched to fields by title,
+// ignoring case, surrounding whitespace,
+// and a trailing '?' or ':'.
+type Answers struct {
+	// Yes accepts all confirmation prompts
+	// that don't have an explicit answer.
+	Yes bool
+
+	// values maps normalized titles to raw JSON answers.
+	values map[string]json.RawMessage
+}
+
+// Set records an answer for prompts with the given title.
+//
+// If value is valid JSON, it's used as-is.
+// Otherwise, it's treated as a string.
+// The accepted values depend on the field:
+// confirms take booleans, inputs take strings,
+// and selects take the label of an option.
+// All fields accept true to keep their default value.
+func (a *Answers) Set(title, value string) {
+	raw := json.RawMessage(value)
+	if !json.Valid(raw) {
+		raw, _ = json.Marshal(value) // can't fail for strings
+	}
+
+	if a.values == nil {
+		a.values = make(map[string]json.RawMessage)
+	}
+	a.values[normalizeAnswerKey(title)] = raw
+}
+
+// ParseAnswer parses a "title=value" pair and records it.
+func (a *Answers) ParseAnswer(s string) error {
+	title, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(title) == "" {
+		return fmt.Errorf("invalid answer %q: expected TITLE=VALUE", s)
+	}
+
+	a.Set(title, value)
+	return nil
+}
+
+// ReadJSON reads answers from a JSON object
+// mapping prompt titles to their values.
+//
+//	{"New branch name": "feature", "Draft": false}
+func (a *Answers) ReadJSON(r io.Reader) error {
+	var values map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&values); err != nil {
+		return fmt.Errorf("decode answers: %w", err)
+	}
+
+	for title, value := range values {
+		a.Set(title, string(value))
+	}
+	return nil
+}
+
+// Empty reports whether there are no answers.
+func (a *Answers) Empty() bool {
+	return a == nil || (!a.Yes && len(a.values) == 0)
+}
+
+// lookup returns the answer for the given field, if any.
+func (a *Answers) lookup(f Field) (json.RawMessage, bool) {
+	if raw, ok := a.values[normalizeAnswerKey(f.Title())]; ok {
+		return raw, true
+	}
+
+	if _, ok := f.(*Confirm); ok && a.Yes {
+		return json.RawMessage("true"), true
+	}
+
+	return nil, false
+}
+
+func normalizeAnswerKey(title string) string {
+	title = strings.TrimSpace(title)
+	title = strings.TrimRight(title, "?:")
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// AnswerView is a [View] that answers prompts
+// with pre-supplied [Answers].
+//
+// Fields without an answer are sent to the wrapped view.
+// If the wrapped view is not interactive,
+// [Run] fails with a [PromptError] listing those fields.
+type AnswerView struct {
+	View    View     // required
+	Answers *Answers // required
+}
+
+var _ InteractiveView = (*AnswerView)(nil)
+
+func (av *AnswerView) Write(p []byte) (int, error) {
+	return av.View.Write(p)
+}
+
+// Prompt fills the given fields from the answers,
+// prompting the wrapped view for the rest.
+//
+// Fields are handled one at a time, in order,
+// so that deferred fields see the answers to earlier fields.
+func (av *AnswerView) Prompt(fields ...Field) error {
+	for idx, field := range fields {
+		if isSkipCmd(field.Init()) {
+			continue
+		}
+
+		raw, ok := av.Answers.lookup(field)
+		if !ok {
+			if err := Run(av.View, field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := field.UnmarshalValue(func(dst any) error {
+			return json.Unmarshal(raw, dst)
+		})
+		if err == nil {
+			err = field.Err()
+		}
+		if err != nil {
+			return fmt.Errorf("field [%d] %q: bad answer %s: %w", idx, field.Title(), raw, err)
+		}
+	}
+
+	return nil
+}
+
+// isSkipCmd reports whether the given command,
+// returned from a field's Init, asks to skip the field.
+func isSkipCmd(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+
+	switch msg := cmd().(type) {
+	case skipFieldMsg:
+		return true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if isSkipCmd(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewAnswerView wraps the given view to answer prompts
+// with the given answers.
+//
+// Returns the view as-is if there are no answers.
+func NewAnswerView(v View, answers *Answers) View {
+	if answers.Empty() {
+		return v
+	}
+	return &AnswerView{View: v, Answers: answers}
+}
@@ -0,0 +1,109 @@
+package ui_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+func TestAnswerView(t *testing.T) {
+	var answers ui.Answers
+	require.NoError(t, answers.ParseAnswer("New branch name=feature"))
+	require.NoError(t, answers.ParseAnswer("draft=false"))
+	require.NoError(t, answers.ParseAnswer("Pick a color=Blue"))
+
+	var (
+		name  string
+		draft = true
+		color string
+		del   bool
+	)
+	view := ui.NewAnswerView(&ui.FileView{W: io.Discard}, &answers)
+	require.True(t, ui.Interactive(view))
+
+	err := ui.Run(view,
+		ui.NewInput().WithTitle("New branch name").WithValue(&name),
+		ui.NewConfirm().WithTitle("Draft").WithValue(&draft),
+		ui.NewSelect[string]().
+			WithTitle("Pick a color:").
+			WithValue(&color).
+			With(ui.ComparableOptions("", "Red", "Blue")),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", name)
+	assert.False(t, draft)
+	assert.Equal(t, "Blue", color)
+
+	t.Run("Unanswered", func(t *testing.T) {
+		err := ui.Run(view,
+			ui.NewConfirm().WithTitle("Delete branch?").WithValue(&del),
+		)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ui.ErrPrompt)
+		assert.ErrorContains(t, err, `"Delete branch?"`)
+	})
+
+	t.Run("Yes", func(t *testing.T) {
+		view := ui.NewAnswerView(&ui.FileView{W: io.Discard}, &ui.Answers{Yes: true})
+		require.NoError(t, ui.Run(view,
+			ui.NewConfirm().WithTitle("Delete branch?").WithValue(&del),
+		))
+		assert.True(t, del)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var answers ui.Answers
+		require.NoError(t, answers.ParseAnswer("New branch name= "))
+
+		view := ui.NewAnswerView(&ui.FileView{W: io.Discard}, &answers)
+		err := ui.Run(view,
+			ui.NewInput().
+				WithTitle("New branch name").
+				WithValidate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return errors.New("branch name cannot be empty")
+					}
+					return nil
+				}),
+		)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "branch name cannot be empty")
+	})
+}
+
+func TestAnswers_ReadJSON(t *testing.T) {
+	var answers ui.Answers
+	require.NoError(t, answers.ReadJSON(strings.NewReader(
+		`{"New branch name": "feature", "Draft?": true}`,
+	)))
+
+	var (
+		name  string
+		draft bool
+	)
+	view := ui.NewAnswerView(&ui.FileView{W: io.Discard}, &answers)
+	require.NoError(t, ui.Run(view,
+		ui.NewInput().WithTitle("new branch name").WithValue(&name),
+		ui.NewConfirm().WithTitle("Draft").WithValue(&draft),
+	))
+	assert.Equal(t, "feature", name)
+	assert.True(t, draft)
+}
+
+func TestAnswers_ParseAnswer(t *testing.T) {
+	var answers ui.Answers
+	assert.ErrorContains(t, answers.ParseAnswer("no equals sign"), "expected TITLE=VALUE")
+	assert.ErrorContains(t, answers.ParseAnswer("=value"), "expected TITLE=VALUE")
+	assert.True(t, answers.Empty())
+}
+
+func TestNewAnswerView_empty(t *testing.T) {
+	view := &ui.FileView{W: io.Discard}
+	assert.Same(t, view, ui.NewAnswerView(view, &ui.Answers{}))
+	assert.False(t, ui.Interactive(ui.NewAnswerView(view, nil)))
+}
@@ -2,9 +2,9 @@ package branchtree
 
 import (
 	"cmp"
-	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -55,6 +55,11 @@ type Item struct {
 	// nil indicates state is not available.
 	ChangeState *forge.ChangeState
 
+	// ChangeDiffStat reports the size of the change.
+	// Only rendered if ChangeID is also set.
+	// nil indicates the size is not available.
+	ChangeDiffStat *forge.DiffStat
+
 	// Worktree is the absolute path where this branch is checked out.
 	// If non-empty and differs from GraphOptions.CurrentWorktree,
 	// rendered as "[wt: path]".
@@ -64,6 +69,11 @@ type Item struct {
 	// Characters at these indexes use Style.TextHighlight.
 	WorktreeHighlights []int
 
+	// Note is an optional free-form note attached to the branch.
+	// If non-empty, each line of the note renders
+	// on its own line below the branch, before any commits.
+	Note string
+
 	// Commits is an optional list of commits to render below the branch.
 	// Each commit renders on its own line.
 	//
@@ -74,6 +84,10 @@ type Item struct {
 	// If true, renders the needs-restack indicator.
 	NeedsRestack bool
 
+	// Archived indicates whether the branch is archived.
+	// If true, renders the archived indicator.
+	Archived bool
+
 	// PushStatus contains push-related information.
 	// Rendered according to GraphOptions.PushStatusFormat.
 	PushStatus PushStatus
@@ -134,16 +148,26 @@ type Style struct {
 	// Each style must include the text via SetString.
 	ChangeState ChangeStateStyle
 
+	// ChangeDiffStat styles the size of the change.
+	ChangeDiffStat lipgloss.Style
+
 	// Worktree styles the worktree indicator.
 	Worktree lipgloss.Style
 
 	// PushStatus styles the push status text.
 	PushStatus lipgloss.Style
 
+	// Note styles lines of the branch note.
+	Note lipgloss.Style
+
 	// NeedsRestack styles the needs-restack indicator.
 	// Must include the text " (needs restack)" via SetString.
 	NeedsRestack lipgloss.Style
 
+	// Archived styles the archived indicator.
+	// Must include the text " (archived)" via SetString.
+	Archived lipgloss.Style
+
 	// NodeMarker is the default node marker style.
 	// Must include the marker character via SetString.
 	NodeMarker lipgloss.Style
@@ -181,24 +205,33 @@ type ChangeStateStyle struct {
 
 // DefaultStyle is the default style for rendering branch trees.
 // Copy and modify this to create custom styles.
-var DefaultStyle = Style{
-	Branch:            ui.NewStyle().Bold(true),
-	BranchHighlighted: ui.NewStyle().Bold(true).Foreground(ui.Cyan),
-	BranchDisabled:    ui.NewStyle().Foreground(ui.Gray),
-	ChangeID:          ui.NewStyle(),
-	ChangeState: ChangeStateStyle{
-		Open:   ui.NewStyle().Foreground(ui.Green).SetString("open"),
-		Closed: ui.NewStyle().Foreground(ui.Gray).SetString("closed"),
-		Merged: ui.NewStyle().Foreground(ui.Magenta).SetString("merged"),
-	},
-	Worktree:              ui.NewStyle().Faint(true),
-	PushStatus:            ui.NewStyle().Foreground(ui.Yellow).Faint(true),
-	NeedsRestack:          ui.NewStyle().Foreground(ui.Gray).SetString(" (needs restack)"), // TODO: drop leading space
-	NodeMarker:            fliptree.DefaultNodeMarker,
-	NodeMarkerHighlighted: fliptree.DefaultNodeMarker.SetString("■"),
-	NodeMarkerDisabled:    fliptree.DefaultNodeMarker.Faint(true),
-	TextHighlight:         ui.NewStyle().Foreground(ui.Cyan),
-	Marker:                ui.NewStyle().Foreground(ui.Yellow).Bold(true).SetString("◀"),
+var DefaultStyle Style
+
+func init() {
+	ui.RegisterStyles(func() {
+		DefaultStyle = Style{
+			Branch:            ui.NewStyle().Bold(true),
+			BranchHighlighted: ui.NewStyle().Bold(true).Foreground(ui.Cyan),
+			BranchDisabled:    ui.NewStyle().Foreground(ui.Gray),
+			ChangeID:          ui.NewStyle(),
+			ChangeState: ChangeStateStyle{
+				Open:   ui.NewStyle().Foreground(ui.Green).SetString("open"),
+				Closed: ui.NewStyle().Foreground(ui.Gray).SetString("closed"),
+				Merged: ui.NewStyle().Foreground(ui.Magenta).SetString("merged"),
+			},
+			ChangeDiffStat:        ui.NewStyle().Faint(true),
+			Worktree:              ui.NewStyle().Faint(true),
+			PushStatus:            ui.NewStyle().Foreground(ui.Yellow).Faint(true),
+			Note:                  ui.NewStyle().Italic(true).Faint(true),
+			NeedsRestack:          ui.NewStyle().Foreground(ui.Gray).SetString(" (needs restack)"), // TODO: drop leading space
+			Archived:              ui.NewStyle().Foreground(ui.Gray).SetString(" (archived)"),
+			NodeMarker:            fliptree.DefaultNodeMarker,
+			NodeMarkerHighlighted: fliptree.DefaultNodeMarker.SetString(ui.Glyph("■", "*")),
+			NodeMarkerDisabled:    fliptree.DefaultNodeMarker.Faint(true),
+			TextHighlight:         ui.NewStyle().Foreground(ui.Cyan),
+			Marker:                ui.NewStyle().Foreground(ui.Yellow).Bold(true).SetString(ui.Glyph("◀", "<")),
+		}
+	})
 }
 
 // GraphOptions configures branch tree rendering.
@@ -267,6 +300,7 @@ func Write(w io.Writer, g Graph, opts *GraphOptions) error {
 				return style.NodeMarker
 			}
 		},
+		ASCII: ui.CurrentTheme().ASCII,
 	}
 
 	return fliptree.Write(w, fliptree.Graph[*Item]{
@@ -295,7 +329,7 @@ func (r *branchTreeRenderer) item(sb *strings.Builder, item *Item) {
 	r.branchName(sb, item)
 
 	if item.ChangeID != "" {
-		r.changeID(sb, item.ChangeID, item.ChangeIDHighlights, item.ChangeState)
+		r.changeID(sb, item.ChangeID, item.ChangeIDHighlights, item.ChangeState, item.ChangeDiffStat)
 	}
 
 	if wt := item.Worktree; wt != "" && wt != r.CurrentWorktree {
@@ -306,6 +340,10 @@ func (r *branchTreeRenderer) item(sb *strings.Builder, item *Item) {
 		sb.WriteString(r.Style.NeedsRestack.String())
 	}
 
+	if item.Archived {
+		sb.WriteString(r.Style.Archived.String())
+	}
+
 	r.pushStatus(sb, item.PushStatus)
 
 	if item.Highlighted {
@@ -313,6 +351,10 @@ func (r *branchTreeRenderer) item(sb *strings.Builder, item *Item) {
 		sb.WriteString(r.Style.Marker.String())
 	}
 
+	if item.Note != "" {
+		r.note(sb, item.Note)
+	}
+
 	if len(item.Commits) > 0 {
 		r.commits(sb, item.Highlighted, item.Commits)
 	}
@@ -336,6 +378,7 @@ func (r *branchTreeRenderer) changeID(
 	changeID string,
 	changeIDHighlights []int,
 	changeState *forge.ChangeState,
+	diffStat *forge.DiffStat,
 ) {
 	sb.WriteString(" (")
 	defer sb.WriteString(")")
@@ -353,6 +396,11 @@ func (r *branchTreeRenderer) changeID(
 			sb.WriteString(r.Style.ChangeState.Merged.String())
 		}
 	}
+
+	if diffStat != nil {
+		sb.WriteString(" ")
+		sb.WriteString(r.Style.ChangeDiffStat.Render(diffStat.String()))
+	}
 }
 
 func (r *branchTreeRenderer) worktree(
@@ -433,16 +481,23 @@ func (r *branchTreeRenderer) pushStatus(
 		if status.Ahead > 0 || status.Behind > 0 {
 			var parts []string
 			if status.Ahead > 0 {
-				parts = append(parts, fmt.Sprintf("⇡%d", status.Ahead))
+				parts = append(parts, ui.Glyph("⇡", "+")+strconv.Itoa(status.Ahead))
 			}
 			if status.Behind > 0 {
-				parts = append(parts, fmt.Sprintf("⇣%d", status.Behind))
+				parts = append(parts, ui.Glyph("⇣", "-")+strconv.Itoa(status.Behind))
 			}
 			sb.WriteString(r.Style.PushStatus.Render(" (" + strings.Join(parts, "") + ")"))
 		}
 	}
 }
 
+func (r *branchTreeRenderer) note(sb *strings.Builder, note string) {
+	for line := range strings.Lines(strings.TrimSpace(note)) {
+		sb.WriteString("\n")
+		sb.WriteString(r.Style.Note.Render(strings.TrimRight(line, "\r\n")))
+	}
+}
+
 func (r *branchTreeRenderer) commits(
 	sb *strings.Builder,
 	highlighted bool,
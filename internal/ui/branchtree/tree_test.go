@@ -149,6 +149,41 @@ func TestWrite(t *testing.T) {
 			},
 			want: "feat1 (#789 merged)\n",
 		},
+		{
+			name: "WithChangeDiffStat",
+			give: Graph{
+				Items: []*Item{{
+					Branch:      "feat1",
+					ChangeID:    "#123",
+					ChangeState: ptr(forge.ChangeOpen),
+					ChangeDiffStat: &forge.DiffStat{
+						Additions:    12,
+						Deletions:    3,
+						ChangedFiles: 1,
+					},
+				}},
+				Roots: []int{0},
+			},
+			want: "feat1 (#123 open +12/-3, 1 file)\n",
+		},
+		{
+			name: "WithIncompleteChangeDiffStat",
+			give: Graph{
+				Items: []*Item{{
+					Branch:      "feat1",
+					ChangeID:    "#123",
+					ChangeState: ptr(forge.ChangeOpen),
+					ChangeDiffStat: &forge.DiffStat{
+						Additions:    12,
+						Deletions:    3,
+						ChangedFiles: 2,
+						Incomplete:   true,
+					},
+				}},
+				Roots: []int{0},
+			},
+			want: "feat1 (#123 open at least +12/-3, 2 files)\n",
+		},
 		{
 			name: "WithWorktree",
 			give: Graph{
@@ -165,6 +200,14 @@ func TestWrite(t *testing.T) {
 			},
 			want: "feat1 (needs restack)\n",
 		},
+		{
+			name: "Archived",
+			give: Graph{
+				Items: []*Item{{Branch: "feat1", NeedsRestack: true, Archived: true}},
+				Roots: []int{0},
+			},
+			want: "feat1 (needs restack) (archived)\n",
+		},
 		{
 			name: "Highlighted",
 			give: Graph{
@@ -202,6 +245,28 @@ func TestWrite(t *testing.T) {
 				"def5678 Fix bug (2 years ago)",
 			),
 		},
+		{
+			name: "WithNote",
+			give: Graph{
+				Items: []*Item{{
+					Branch: "feat1",
+					Note:   "Blocked on API review\nSee design doc\n",
+					Commits: []commit.Summary{
+						{ShortHash: "abc1234", Subject: "Add feature", AuthorDate: now.Add(-2 * time.Hour)},
+					},
+				}},
+				Roots: []int{0},
+			},
+			opts: &GraphOptions{
+				CommitStyle: plainCommitStyle(),
+			},
+			want: joinLines(
+				"feat1",
+				"Blocked on API review",
+				"See design doc",
+				"abc1234 Add feature (2 years ago)",
+			),
+		},
 		{
 			name: "PushStatusSimple",
 			give: Graph{
@@ -558,9 +623,11 @@ func plainStyle() *Style {
 			Closed: ui.NewStyle().SetString("closed"),
 			Merged: ui.NewStyle().SetString("merged"),
 		},
+		ChangeDiffStat:        ui.NewStyle(),
 		Worktree:              ui.NewStyle(),
 		PushStatus:            ui.NewStyle(),
 		NeedsRestack:          ui.NewStyle().SetString(" (needs restack)"),
+		Archived:              ui.NewStyle().SetString(" (archived)"),
 		NodeMarker:            ui.NewStyle().SetString("□"),
 		NodeMarkerHighlighted: ui.NewStyle().SetString("■"),
 		NodeMarkerDisabled:    ui.NewStyle().SetString("□"),
@@ -57,10 +57,16 @@ func (s SummaryStyle) Bold(b bool) SummaryStyle {
 
 // DefaultSummaryStyle is the default style
 // for rendering a Summary.
-var DefaultSummaryStyle = SummaryStyle{
-	Hash:    ui.NewStyle().Foreground(ui.Yellow),
-	Subject: ui.NewStyle().Foreground(ui.Plain),
-	Time:    ui.NewStyle().Foreground(ui.Gray),
+var DefaultSummaryStyle SummaryStyle
+
+func init() {
+	ui.RegisterStyles(func() {
+		DefaultSummaryStyle = SummaryStyle{
+			Hash:    ui.NewStyle().Foreground(ui.Yellow),
+			Subject: ui.NewStyle().Foreground(ui.Plain),
+			Time:    ui.NewStyle().Foreground(ui.Gray),
+		}
+	})
 }
 
 // SummaryOptions further customizes the behavior of commit summary rendering.
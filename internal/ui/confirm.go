@@ -37,8 +37,14 @@ type ConfirmStyle struct {
 }
 
 // DefaultConfirmStyle is the default style for a [Confirm] field.
-var DefaultConfirmStyle = ConfirmStyle{
-	Key: NewStyle().Foreground(Magenta),
+var DefaultConfirmStyle ConfirmStyle
+
+func init() {
+	RegisterStyles(func() {
+		DefaultConfirmStyle = ConfirmStyle{
+			Key: NewStyle().Foreground(Magenta),
+		}
+	})
 }
 
 // Confirm is a boolean confirmation field that takes a yes or no answer.
@@ -86,6 +92,26 @@ func (c *Confirm) UnmarshalValue(unmarshal func(any) error) error {
 	return unmarshal(c.value)
 }
 
+var _ ChoiceField = (*Confirm)(nil)
+
+// Choices returns the choices for the confirm field: yes and no.
+func (c *Confirm) Choices() (labels []string, defaults []int, multiple bool) {
+	if *c.value {
+		return []string{"yes", "no"}, []int{0}, false
+	}
+	return []string{"yes", "no"}, []int{1}, false
+}
+
+// Choose sets the value of the confirm field
+// to true for the first choice and false for the second.
+func (c *Confirm) Choose(idxs []int) error {
+	if len(idxs) != 1 {
+		return fmt.Errorf("expected one choice, got %d", len(idxs))
+	}
+	*c.value = idxs[0] == 0
+	return nil
+}
+
 // WithTitle sets the title for the confirm field.
 func (c *Confirm) WithTitle(title string) *Confirm {
 	c.title = title
@@ -22,7 +22,13 @@ import (
 )
 
 // DefaultNodeMarker is the marker used for each node in the tree.
-var DefaultNodeMarker = lipgloss.NewStyle().SetString("□")
+var DefaultNodeMarker lipgloss.Style
+
+func init() {
+	ui.RegisterStyles(func() {
+		DefaultNodeMarker = lipgloss.NewStyle().SetString(ui.Glyph("□", "o"))
+	})
+}
 
 // Graph defines a directed graph.
 type Graph[T any] struct {
@@ -60,6 +66,10 @@ type Style[T any] struct {
 	//
 	// By default, all nodes are marked with [DefaultNodeMarker].
 	NodeMarker func(T) lipgloss.Style
+
+	// ASCII draws joints with ASCII characters
+	// instead of box-drawing characters.
+	ASCII bool
 }
 
 // DefaultStyle returns the default style for rendering trees.
@@ -69,6 +79,7 @@ func DefaultStyle[T any]() *Style[T] {
 		NodeMarker: func(T) lipgloss.Style {
 			return DefaultNodeMarker
 		},
+		ASCII: ui.CurrentTheme().ASCII,
 	}
 }
 
@@ -113,6 +124,20 @@ func (b boxRune) String() string {
 	return string(b)
 }
 
+// ASCII returns the ASCII equivalent of the box-drawing character.
+func (b boxRune) ASCII() string {
+	switch b {
+	case _vertical:
+		return "|"
+	case _horizontal:
+		return "-"
+	case _horizontalUp, _verticalRight, _downRight:
+		return "+"
+	default:
+		return b.String()
+	}
+}
+
 func (b boxRune) Valid() bool {
 	switch b {
 	case _vertical, _horizontal, _horizontalUp, _verticalRight, _downRight:
@@ -197,17 +222,17 @@ func (tw *treeWriter[T]) writeTree(nodeIdx int, path []int, pathNodeIxes []int)
 	// for the branch above.
 	titlePrefix := tw.style.NodeMarker(nodeValue).String() + " "
 	if hasChildren {
-		titlePrefix = tw.style.Joint.Render(string(_horizontalUp)) + titlePrefix
+		titlePrefix = tw.style.Joint.Render(tw.box(_horizontalUp)) + titlePrefix
 	}
 	bodyPrefix := strings.Repeat(" ", lipgloss.Width(titlePrefix))
 
-	lastJoint := string(_downRight) + string(_horizontal)
+	lastJoint := tw.box(_downRight, _horizontal)
 	if len(path) > 0 && path[len(path)-1] > 0 {
 		// If pos > 0, then we've drawn siblings
 		// above this branch, so we need a connecting pipe.
 		// Otherwise, this is the topmost branch,
 		// so we need no connecting pipe.
-		lastJoint = string(_verticalRight) + string(_horizontal)
+		lastJoint = tw.box(_verticalRight, _horizontal)
 	}
 
 	lines := strings.Split(tw.g.View(nodeValue), "\n")
@@ -217,7 +242,7 @@ func (tw *treeWriter[T]) writeTree(nodeIdx int, path []int, pathNodeIxes []int)
 		if idx == 0 {
 			tw.pipes(path, lastJoint, titlePrefix)
 		} else {
-			tw.pipes(path, string(_vertical)+" ", bodyPrefix)
+			tw.pipes(path, tw.box(_vertical)+" ", bodyPrefix)
 		}
 
 		_, _ = tw.w.WriteString(line)
@@ -240,7 +265,7 @@ func (tw *treeWriter[T]) pipes(path []int, joint string, marker string) {
 	for _, pos := range path[:len(path)-1] {
 		if pos > 0 {
 			_, _ = tw.w.WriteString(
-				style.Render(string(_vertical) + " "),
+				style.Render(tw.box(_vertical) + " "),
 			)
 		} else {
 			_, _ = tw.w.WriteString("  ")
@@ -250,6 +275,20 @@ func (tw *treeWriter[T]) pipes(path []int, joint string, marker string) {
 	_, _ = tw.w.WriteString(style.Render(joint) + marker)
 }
 
+// box renders the given box-drawing characters
+// in the configured character set.
+func (tw *treeWriter[T]) box(runes ...boxRune) string {
+	var s strings.Builder
+	for _, r := range runes {
+		if tw.style.ASCII {
+			s.WriteString(r.ASCII())
+		} else {
+			s.WriteString(r.String())
+		}
+	}
+	return s.String()
+}
+
 // CycleError is returned when a cycle is detected in the tree.
 type CycleError struct {
 	// Nodes that form the cycle.
@@ -307,6 +307,43 @@ func testWriteProperty(t *rapid.T) {
 	}
 }
 
+func TestWrite_ascii(t *testing.T) {
+	g := Graph[string]{
+		Values: []string{"feat1", "feat1.1", "feat2", "main"},
+		Roots:  []int{3},
+		Edges: func(n string) []int {
+			switch n {
+			case "main":
+				return []int{0, 2}
+			case "feat1":
+				return []int{1}
+			default:
+				return nil
+			}
+		},
+		View: func(n string) string { return n },
+	}
+
+	style := plainStyle()
+	style.NodeMarker = func(string) lipgloss.Style {
+		return ui.NewStyle().SetString("o")
+	}
+	style.ASCII = true
+
+	var sb strings.Builder
+	require.NoError(t, Write(&sb, g, Options[string]{Style: style}))
+	assert.Equal(t, joinLines(
+		"  +-o feat1.1",
+		"+-+o feat1",
+		"+-o feat2",
+		"main",
+	), sb.String())
+}
+
+func joinLines(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func stripTrailingSpaces(s string) string {
 	lines := strings.Split(s, "\n")
 	for i, line := range lines {
@@ -38,13 +38,19 @@ type FormStyle struct {
 }
 
 // DefaultFormStyle is the default style for a [Form].
-var DefaultFormStyle = FormStyle{
-	Error:         NewStyle().Foreground(Red),
-	Title:         _titleStyle,
-	Description:   _descriptionStyle,
-	AcceptedTitle: _acceptedTitleStyle,
+var DefaultFormStyle FormStyle
 
-	AcceptedField: NewStyle().Faint(true),
+func init() {
+	RegisterStyles(func() {
+		DefaultFormStyle = FormStyle{
+			Error:         NewStyle().Foreground(Red),
+			Title:         titleStyle(),
+			Description:   descriptionStyle(),
+			AcceptedTitle: acceptedTitleStyle(),
+
+			AcceptedField: NewStyle().Faint(true),
+		}
+	})
 }
 
 type acceptFieldMsg struct{}
@@ -108,11 +114,18 @@ type Field interface {
 }
 
 // Run presents the given field to the user using the given View.
-// If the view is not interactive, it will return an error.
+// If the view is not interactive, it will return a [PromptError]
+// listing the fields that could not be presented.
 func Run(v View, fs ...Field) error {
 	iv, ok := v.(InteractiveView)
 	if !ok {
-		return ErrPrompt
+		titles := make([]string, 0, len(fs))
+		for _, f := range fs {
+			if title := f.Title(); title != "" {
+				titles = append(titles, title)
+			}
+		}
+		return &PromptError{Fields: titles}
 	}
 
 	return iv.Prompt(fs...)
@@ -0,0 +1,465 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// FuzzyListKeyMap defines the key bindings for [FuzzyList].
+type FuzzyListKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Accept key.Binding
+
+	DeleteFilterChar key.Binding
+	ClearFilter      key.Binding
+}
+
+// DefaultFuzzyListKeyMap is the default key map for a [FuzzyList].
+var DefaultFuzzyListKeyMap = FuzzyListKeyMap{
+	Up:               DefaultSelectKeyMap.Up,
+	Down:             DefaultSelectKeyMap.Down,
+	Accept:           DefaultSelectKeyMap.Accept,
+	DeleteFilterChar: DefaultSelectKeyMap.DeleteFilterChar,
+	ClearFilter: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "clear filter"),
+	),
+}
+
+// FuzzyListStyle defines the styles for [FuzzyList].
+type FuzzyListStyle struct {
+	Selected  lipgloss.Style
+	Highlight lipgloss.Style
+
+	// Cursor is placed next to the selected option.
+	Cursor string
+
+	// Filter styles the line showing the current filter
+	// and the number of matches.
+	Filter lipgloss.Style
+
+	ScrollMarker lipgloss.Style
+	ScrollUp     string
+	ScrollDown   string
+}
+
+// DefaultFuzzyListStyle is the default style for a [FuzzyList].
+var DefaultFuzzyListStyle FuzzyListStyle
+
+func init() {
+	RegisterStyles(func() {
+		DefaultFuzzyListStyle = FuzzyListStyle{
+			Selected:     NewStyle().Foreground(Yellow),
+			Highlight:    NewStyle().Foreground(Cyan),
+			Cursor:       Glyph("▶", ">"),
+			Filter:       NewStyle().Foreground(Gray),
+			ScrollMarker: NewStyle().Foreground(Gray),
+			ScrollUp:     Glyph("▲▲▲", "^^^"),
+			ScrollDown:   Glyph("▼▼▼", "vvv"),
+		}
+	})
+}
+
+// FuzzyList is a prompt that allows selecting from a long list of options
+// by typing parts of the desired option's label.
+//
+// Unlike [Select], matches are ranked by how well they match the filter,
+// so the best match is always at the top and selected by default.
+// Use this for lists that can grow large, e.g. lists of branches.
+type FuzzyList[T any] struct {
+	KeyMap FuzzyListKeyMap
+	Style  FuzzyListStyle
+
+	title string
+	desc  string
+	value *T
+
+	options  []SelectOption[T]
+	labels   []string         // labels of options for matching
+	filter   []rune           // filter to match options
+	matches  []fuzzyListMatch // options matching filter, best first
+	selected int              // index in matches of selected option
+
+	visible int // number of visible options, 0 means all (immutable)
+	offset  int // index in matches of first visible option (mutable)
+
+	accepted bool  // true after the field has been accepted
+	err      error // error state
+}
+
+type fuzzyListMatch struct {
+	Index      int   // index of option
+	Highlights []int // byte offsets in label to highlight
+}
+
+var _ Field = (*FuzzyList[int])(nil)
+
+// NewFuzzyList builds a new [FuzzyList] field.
+func NewFuzzyList[T any]() *FuzzyList[T] {
+	return &FuzzyList[T]{
+		KeyMap: DefaultFuzzyListKeyMap,
+		Style:  DefaultFuzzyListStyle,
+		value:  new(T),
+	}
+}
+
+// WithValue sets the destination for the field.
+func (l *FuzzyList[T]) WithValue(value *T) *FuzzyList[T] {
+	l.value = value
+	return l
+}
+
+// Value reports the current value of the field.
+func (l *FuzzyList[T]) Value() T {
+	return *l.value
+}
+
+// With runs the given function with the field.
+func (l *FuzzyList[T]) With(f func(*FuzzyList[T])) *FuzzyList[T] {
+	f(l)
+	return l
+}
+
+// ComparableFuzzyOptions is like [ComparableOptions], but for a [FuzzyList].
+func ComparableFuzzyOptions[T comparable](selected T, opts ...T) func(*FuzzyList[T]) {
+	var selectedIdx int
+	options := make([]SelectOption[T], len(opts))
+	for i, v := range opts {
+		if v == selected {
+			selectedIdx = i
+		}
+		options[i] = SelectOption[T]{
+			Label: fmt.Sprintf("%v", v),
+			Value: v,
+		}
+	}
+
+	return func(l *FuzzyList[T]) {
+		l.WithOptions(options...)
+		l.WithSelected(selectedIdx)
+	}
+}
+
+// WithOptions sets the available options for the field.
+// Until the user starts typing,
+// the options are presented in the order they are provided.
+// Existing options will be replaced.
+//
+// [SelectOption.PaddingAbove] is honored only while the filter is empty.
+func (l *FuzzyList[T]) WithOptions(opts ...SelectOption[T]) *FuzzyList[T] {
+	l.options = opts
+	l.labels = make([]string, len(opts))
+	l.matches = make([]fuzzyListMatch, len(opts))
+	for i, opt := range opts {
+		l.labels[i] = opt.Label
+		l.matches[i] = fuzzyListMatch{Index: i}
+	}
+	return l
+}
+
+// WithSelected sets the index of the option selected by default.
+func (l *FuzzyList[T]) WithSelected(selected int) *FuzzyList[T] {
+	l.selected = selected
+	return l
+}
+
+// Title returns the title of the field.
+func (l *FuzzyList[T]) Title() string {
+	return l.title
+}
+
+// WithTitle sets the title for the field.
+func (l *FuzzyList[T]) WithTitle(title string) *FuzzyList[T] {
+	l.title = title
+	return l
+}
+
+// Description returns the description of the field.
+func (l *FuzzyList[T]) Description() string {
+	return l.desc
+}
+
+// WithDescription sets the description for the field.
+func (l *FuzzyList[T]) WithDescription(desc string) *FuzzyList[T] {
+	l.desc = desc
+	return l
+}
+
+// WithVisible sets the number of options visible at a time.
+// If unset, a default is picked based on the terminal height.
+func (l *FuzzyList[T]) WithVisible(visible int) *FuzzyList[T] {
+	l.visible = visible
+	return l
+}
+
+// Err reports any errors in the field.
+func (l *FuzzyList[T]) Err() error {
+	return l.err
+}
+
+// UnmarshalValue unmarshals the value of the field
+// using the provided unmarshal function.
+//
+// It accepts one of the following types:
+//
+//   - bool: if the value is true, accept the field
+//   - string: pick the option with a matching label,
+//     or the only option whose label starts with it as a word
+func (l *FuzzyList[T]) UnmarshalValue(unmarshal func(any) error) error {
+	if ok := new(bool); unmarshal(ok) == nil && *ok {
+		// Leave the field as is.
+		return nil
+	}
+
+	var label string
+	if err := unmarshal(&label); err != nil {
+		return err
+	}
+
+	label = strings.TrimSpace(label)
+	for _, opt := range l.options {
+		if strings.TrimSpace(opt.Label) == label {
+			*l.value = opt.Value
+			return nil
+		}
+	}
+
+	// Labels may carry details after the first word,
+	// e.g. "origin (https://example.com/foo.git)".
+	// Accept the first word if it identifies exactly one option.
+	match := -1
+	for i, opt := range l.options {
+		if first, _, _ := strings.Cut(strings.TrimSpace(opt.Label), " "); first == label {
+			if match >= 0 {
+				return fmt.Errorf("ambiguous label: %v", label)
+			}
+			match = i
+		}
+	}
+	if match >= 0 {
+		*l.value = l.options[match].Value
+		return nil
+	}
+
+	return fmt.Errorf("no option with label: %v", label)
+}
+
+var _ ChoiceField = (*FuzzyList[int])(nil)
+
+// Choices returns the labels of all options
+// and the index of the selected option.
+func (l *FuzzyList[T]) Choices() (labels []string, defaults []int, multiple bool) {
+	if l.selected < len(l.matches) {
+		defaults = []int{l.matches[l.selected].Index}
+	}
+	return l.labels, defaults, false
+}
+
+// Choose selects the option at the given index.
+func (l *FuzzyList[T]) Choose(idxs []int) error {
+	if len(idxs) != 1 || idxs[0] < 0 || idxs[0] >= len(l.options) {
+		return fmt.Errorf("expected one option, got %v", idxs)
+	}
+	*l.value = l.options[idxs[0]].Value
+	return nil
+}
+
+// Init initializes the field.
+func (l *FuzzyList[T]) Init() tea.Cmd {
+	l.selected = max(0, min(l.selected, len(l.matches)-1))
+	l.scrollToSelected()
+	return nil
+}
+
+// Update receives messages from bubbletea.
+func (l *FuzzyList[T]) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		if l.visible == 0 {
+			// Leave enough room for title, filter, description,
+			// error, and two scroll markers.
+			l.visible = max(1, msg.Height-6)
+			l.scrollToSelected()
+		}
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, l.KeyMap.Up):
+			l.moveSelection(-1)
+
+		case key.Matches(msg, l.KeyMap.Down):
+			l.moveSelection(1)
+
+		case key.Matches(msg, l.KeyMap.Accept):
+			if l.selected < len(l.matches) {
+				*l.value = l.options[l.matches[l.selected].Index].Value
+				l.accepted = true
+				return AcceptField
+			}
+
+		case key.Matches(msg, l.KeyMap.DeleteFilterChar):
+			if len(l.filter) > 0 {
+				l.filter = l.filter[:len(l.filter)-1]
+				l.updateMatches(true)
+			}
+
+		case key.Matches(msg, l.KeyMap.ClearFilter):
+			if len(l.filter) > 0 {
+				l.filter = l.filter[:0]
+				l.updateMatches(true)
+			}
+
+		case msg.Type == tea.KeyRunes:
+			l.filter = append(l.filter, msg.Runes...)
+			l.updateMatches(false)
+		}
+	}
+
+	return nil
+}
+
+func (l *FuzzyList[T]) moveSelection(delta int) {
+	if len(l.matches) == 0 {
+		return
+	}
+
+	l.selected = (l.selected + delta) % len(l.matches)
+	if l.selected < 0 {
+		l.selected += len(l.matches)
+	}
+	l.scrollToSelected()
+}
+
+// scrollToSelected adjusts the offset
+// so that the selected option is visible.
+func (l *FuzzyList[T]) scrollToSelected() {
+	if l.visible <= 0 {
+		l.offset = 0
+		return
+	}
+
+	if l.selected < l.offset {
+		l.offset = l.selected
+	}
+	if l.selected >= l.offset+l.visible {
+		l.offset = l.selected - l.visible + 1
+	}
+}
+
+// updateMatches re-ranks options against the filter.
+// The best match is selected unless keepSelected is set
+// and the previously selected option still matches.
+func (l *FuzzyList[T]) updateMatches(keepSelected bool) {
+	l.err = nil
+
+	prev := -1
+	if l.selected < len(l.matches) {
+		prev = l.matches[l.selected].Index
+	}
+
+	l.matches = l.matches[:0]
+	l.selected, l.offset = 0, 0
+	if len(l.filter) == 0 {
+		// No filter: all options in their original order.
+		// Keep the previous selection if there was one.
+		for i := range l.options {
+			l.matches = append(l.matches, fuzzyListMatch{Index: i})
+		}
+		l.selected = max(prev, 0)
+	} else {
+		// fuzzy.Find sorts matches by score, best first,
+		// and otherwise retains the original order.
+		// When the filter is shortened,
+		// keep the previous selection as it still matches.
+		for _, m := range fuzzy.Find(string(l.filter), l.labels) {
+			if keepSelected && m.Index == prev {
+				l.selected = len(l.matches)
+			}
+			l.matches = append(l.matches, fuzzyListMatch{
+				Index:      m.Index,
+				Highlights: m.MatchedIndexes,
+			})
+		}
+	}
+
+	l.scrollToSelected()
+}
+
+// Render renders the field.
+func (l *FuzzyList[T]) Render(out Writer) {
+	// If the field has been accepted,
+	// only render the label of the selected option.
+	if l.accepted {
+		out.WriteString(l.options[l.matches[l.selected].Index].Label)
+		return
+	}
+
+	if l.title != "" {
+		// If there's a title, we're currently on the same line as the
+		// title following the ": " separator.
+		out.WriteString("\n")
+	}
+
+	if len(l.filter) > 0 {
+		fmt.Fprintf(out, "%s\n", l.Style.Filter.Render(fmt.Sprintf(
+			"  %v (%d/%d)", string(l.filter), len(l.matches), len(l.options),
+		)))
+	}
+
+	if len(l.matches) == 0 {
+		l.err = fmt.Errorf("no matches for: %v", string(l.filter))
+		return
+	}
+
+	matches := l.matches
+	if l.visible > 0 && len(matches) > l.visible {
+		matches = matches[l.offset:min(l.offset+l.visible, len(matches))]
+	}
+
+	if l.offset > 0 {
+		fmt.Fprintf(out, "%s\n", l.Style.ScrollMarker.Render("  "+l.Style.ScrollUp))
+	} else {
+		out.WriteString("\n")
+	}
+
+	for i, m := range matches {
+		opt := l.options[m.Index]
+
+		// Padding is meaningless when the order is by rank.
+		if len(l.filter) == 0 {
+			for range opt.PaddingAbove {
+				out.WriteString("\n")
+			}
+		}
+
+		style := NewStyle()
+		if l.offset+i == l.selected {
+			style = l.Style.Selected
+			out.WriteString(l.Style.Cursor + " ")
+		} else {
+			out.WriteString("  ")
+		}
+
+		// Highlight the matched characters.
+		label, last := opt.Label, 0
+		for _, idx := range m.Highlights {
+			_, size := utf8.DecodeRuneInString(label[idx:])
+			out.WriteString(style.Render(label[last:idx]))
+			out.WriteString(l.Style.Highlight.Render(label[idx : idx+size]))
+			last = idx + size
+		}
+		out.WriteString(style.Render(label[last:]))
+		out.WriteString("\n")
+	}
+
+	if l.visible > 0 && l.offset+l.visible < len(l.matches) {
+		fmt.Fprintf(out, "%s\n", l.Style.ScrollMarker.Render("  "+l.Style.ScrollDown))
+	}
+}
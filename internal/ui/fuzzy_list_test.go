@@ -0,0 +1,125 @@
+package ui_test
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rogpeppe/go-internal/testscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/ui"
+	"go.abhg.dev/gs/internal/ui/uitest"
+)
+
+// Expected files:
+//
+//   - want: expected string value
+//   - give: []string options
+//   - selected (optional): starting string value
+//   - visible (optional): number of items visible at a time
+func TestFuzzyList(t *testing.T) {
+	uitest.RunScripts(t,
+		func(t testing.TB, ts *testscript.TestScript, view ui.InteractiveView) {
+			var want string
+			require.NoError(t,
+				json.Unmarshal([]byte(ts.ReadFile("want")), &want),
+				"read 'want' file")
+
+			var give []string
+			require.NoError(t,
+				json.Unmarshal([]byte(ts.ReadFile("give")), &give),
+				"read 'give' file")
+
+			var desc string
+			if _, err := os.Stat(ts.MkAbs("desc")); err == nil {
+				desc = strings.TrimSpace(ts.ReadFile("desc"))
+			}
+
+			var visible int
+			if _, err := os.Stat(ts.MkAbs("visible")); err == nil {
+				require.NoError(t,
+					json.Unmarshal([]byte(ts.ReadFile("visible")), &visible),
+					"read 'visible' file")
+			}
+
+			var selected string
+			if _, err := os.Stat(ts.MkAbs("selected")); err == nil {
+				require.NoError(t,
+					json.Unmarshal([]byte(ts.ReadFile("selected")), &selected),
+					"read 'selected' file")
+			}
+
+			opts := make([]ui.SelectOption[string], len(give))
+			for i, v := range give {
+				opts[i] = ui.SelectOption[string]{Label: v, Value: v}
+			}
+
+			var got string
+			widget := ui.NewFuzzyList[string]().
+				WithTitle("Pick a value").
+				WithValue(&got).
+				WithDescription(desc).
+				WithVisible(visible).
+				WithOptions(opts...).
+				WithSelected(max(slices.Index(give, selected), 0))
+
+			require.NoError(t, ui.Run(view, widget))
+			assert.Equal(t, want, got)
+		},
+		&uitest.RunScriptsOptions{
+			Update: *ui.UpdateFixtures,
+		},
+		"testdata/script/fuzzy_list",
+	)
+}
+
+func TestFuzzyList_UnmarshalValue(t *testing.T) {
+	newList := func(got *string) *ui.FuzzyList[string] {
+		return ui.NewFuzzyList[string]().
+			WithValue(got).
+			WithOptions(
+				ui.SelectOption[string]{Label: "origin (https://example.com/fork.git)", Value: "origin"},
+				ui.SelectOption[string]{Label: "upstream (https://example.com/repo.git)", Value: "upstream"},
+				ui.SelectOption[string]{Label: "dup one", Value: "dup1"},
+				ui.SelectOption[string]{Label: "dup two", Value: "dup2"},
+			)
+	}
+
+	unmarshalString := func(s string) func(any) error {
+		return func(v any) error {
+			bs, err := json.Marshal(s)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(bs, v)
+		}
+	}
+
+	t.Run("FullLabel", func(t *testing.T) {
+		var got string
+		require.NoError(t, newList(&got).UnmarshalValue(
+			unmarshalString("upstream (https://example.com/repo.git)")))
+		assert.Equal(t, "upstream", got)
+	})
+
+	t.Run("FirstWord", func(t *testing.T) {
+		var got string
+		require.NoError(t, newList(&got).UnmarshalValue(unmarshalString("origin")))
+		assert.Equal(t, "origin", got)
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		var got string
+		err := newList(&got).UnmarshalValue(unmarshalString("dup"))
+		assert.ErrorContains(t, err, "ambiguous label: dup")
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		var got string
+		err := newList(&got).UnmarshalValue(unmarshalString("orig"))
+		assert.ErrorContains(t, err, "no option with label: orig")
+	})
+}
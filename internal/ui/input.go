@@ -96,12 +96,19 @@ func (i *Input) WithOptions(options []string) *Input {
 // UnmarshalValue reads a string value for the input field.
 // Optionally, the input may be a boolean true to accept
 // the input as is.
+// Err reports whether the new value failed validation.
 func (i *Input) UnmarshalValue(unmarshal func(any) error) error {
 	if ok := new(bool); unmarshal(ok) == nil && *ok {
 		return nil
 	}
 
-	return unmarshal(i.value)
+	if err := unmarshal(i.value); err != nil {
+		return err
+	}
+
+	// Run validation against the new value.
+	i.model.SetValue(*i.value)
+	return nil
 }
 
 // WithTitle sets the title of the input field.
@@ -121,11 +128,6 @@ func (i *Input) WithDescription(desc string) *Input {
 	return i
 }
 
-const (
-	_scrollUpSymbol   = "▲"
-	_scrollDownSymbol = "▼"
-)
-
 // Description returns the description of the input field.
 // If there are options to choose from,
 // the description includes markers for scrolling.
@@ -141,10 +143,10 @@ func (i *Input) Description() string {
 	}
 	desc.WriteString("(")
 	if i.optionIdx == -1 || i.optionIdx > 0 {
-		desc.WriteString(_scrollUpSymbol)
+		desc.WriteString(Glyph("▲", "^"))
 	}
 	if i.optionIdx == -1 || i.optionIdx < len(i.options)-1 {
-		desc.WriteString(_scrollDownSymbol)
+		desc.WriteString(Glyph("▼", "v"))
 	}
 	desc.WriteString(" for other options)")
 	return desc.String()
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -40,10 +41,16 @@ type ListStyle struct {
 }
 
 // DefaultListStyle is the default style for a [List].
-var DefaultListStyle = ListStyle{
-	Cursor:            NewStyle().Foreground(Yellow).Bold(true).SetString("▶"),
-	ItemTitle:         NewStyle().Foreground(Gray),
-	SelectedItemTitle: NewStyle().Foreground(Yellow),
+var DefaultListStyle ListStyle
+
+func init() {
+	RegisterStyles(func() {
+		DefaultListStyle = ListStyle{
+			Cursor:            NewStyle().Foreground(Yellow).Bold(true).SetString(Glyph("▶", ">")),
+			ItemTitle:         NewStyle().Foreground(Gray),
+			SelectedItemTitle: NewStyle().Foreground(Yellow),
+		}
+	})
 }
 
 // List is a prompt that allows selecting from a list of options.
@@ -121,6 +128,35 @@ func (l *List[T]) UnmarshalValue(unmarshal func(any) error) error {
 	return nil
 }
 
+var _ ChoiceField = (*List[int])(nil)
+
+// Choices returns the titles of all items, followed by their descriptions,
+// and the index of the selected item.
+func (l *List[T]) Choices() (labels []string, defaults []int, multiple bool) {
+	labels = make([]string, len(l.items))
+	for i, item := range l.items {
+		labels[i] = item.Title
+		if desc := strings.Join(strings.Fields(item.Description(false)), " "); desc != "" {
+			labels[i] += ": " + desc
+		}
+	}
+	if l.selected >= 0 && l.selected < len(l.items) {
+		defaults = []int{l.selected}
+	}
+	return labels, defaults, false
+}
+
+// Choose selects the item at the given index.
+func (l *List[T]) Choose(idxs []int) error {
+	if len(idxs) != 1 || idxs[0] < 0 || idxs[0] >= len(l.items) {
+		return fmt.Errorf("expected one item, got %v", idxs)
+	}
+	l.selected = idxs[0]
+	*l.value = l.items[l.selected].Value
+	l.accepted = true
+	return nil
+}
+
 // WithTitle sets the title of the [List].
 func (l *List[T]) WithTitle(title string) *List[T] {
 	l.title = title
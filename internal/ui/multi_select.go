@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -49,14 +51,24 @@ type MultiSelectStyle struct {
 
 	// ScrollDown is the string for the scroll down marker.
 	ScrollDown lipgloss.Style
+
+	// Group is the style for group headings.
+	Group lipgloss.Style
 }
 
 // DefaultMultiSelectStyle is the default style for a [MultiSelect].
-var DefaultMultiSelectStyle = MultiSelectStyle{
-	Cursor:     NewStyle().Foreground(Yellow).Bold(true).SetString("▶"),
-	Done:       NewStyle().Foreground(Green).SetString("Done"),
-	ScrollUp:   NewStyle().Foreground(Gray).SetString("▲▲▲"),
-	ScrollDown: NewStyle().Foreground(Gray).SetString("▼▼▼"),
+var DefaultMultiSelectStyle MultiSelectStyle
+
+func init() {
+	RegisterStyles(func() {
+		DefaultMultiSelectStyle = MultiSelectStyle{
+			Cursor:     NewStyle().Foreground(Yellow).Bold(true).SetString(Glyph("▶", ">")),
+			Done:       NewStyle().Foreground(Green).SetString("Done"),
+			ScrollUp:   NewStyle().Foreground(Gray).SetString(Glyph("▲▲▲", "^^^")),
+			ScrollDown: NewStyle().Foreground(Gray).SetString(Glyph("▼▼▼", "vvv")),
+			Group:      NewStyle().Foreground(Magenta).Bold(true),
+		}
+	})
 }
 
 // MultiSelect is a prompt that allows selecting one or more options.
@@ -125,6 +137,51 @@ func (s *MultiSelect[T]) UnmarshalValue(unmarshal func(any) error) error {
 	return nil
 }
 
+var _ ChoiceField = (*MultiSelect[int])(nil)
+
+// Choices returns the rendered options that may be selected
+// and the indexes of those already selected.
+// Skipped options are not included.
+func (s *MultiSelect[T]) Choices() (labels []string, defaults []int, multiple bool) {
+	for _, option := range s.choosable() {
+		var label strings.Builder
+		s.renderOption(&label, option, s.options[option])
+		labels = append(labels, strings.TrimSpace(label.String()))
+		if s.options[option].Selected {
+			defaults = append(defaults, len(labels)-1)
+		}
+	}
+	return labels, defaults, true
+}
+
+// Choose selects the options at the given indexes,
+// and deselects all others.
+func (s *MultiSelect[T]) Choose(idxs []int) error {
+	choosable := s.choosable()
+	for _, idx := range idxs {
+		if idx < 0 || idx >= len(choosable) {
+			return fmt.Errorf("index %d is out of bounds [0, %d]", idx, len(choosable)-1)
+		}
+	}
+
+	for i, option := range choosable {
+		s.options[option].Selected = slices.Contains(idxs, i)
+	}
+	s.accepted = true
+	return nil
+}
+
+// choosable returns the indexes of options that aren't skipped.
+func (s *MultiSelect[T]) choosable() []int {
+	idxs := make([]int, 0, len(s.options))
+	for idx, option := range s.options {
+		if !option.Skip {
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs
+}
+
 // MultiSelectOption is an option for a multi-select field.
 type MultiSelectOption[T any] struct {
 	// Value of the option.
@@ -137,6 +194,12 @@ type MultiSelectOption[T any] struct {
 	// Skipped options are not selectable
 	// and will never be included in the result.
 	Skip bool
+
+	// Group is the name of the group the option belongs to.
+	// Consecutive options with the same group
+	// are listed under a shared heading.
+	// Options without a group have no heading.
+	Group string
 }
 
 // WithOptions sets the options for the multi-select field.
@@ -184,7 +247,8 @@ func (s *MultiSelect[T]) Init() tea.Cmd {
 func (s *MultiSelect[T]) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		s.visible = msg.Height - 5 // two scroll markers + Done
+		// two scroll markers + Done + group headings
+		s.visible = msg.Height - 5 - s.groupCount()
 
 	case tea.KeyMsg:
 		switch {
@@ -284,6 +348,11 @@ func (s *MultiSelect[T]) Render(w Writer) {
 	for offsetIdx, option := range options {
 		idx := offset + offsetIdx
 
+		if s.startsGroup(idx) {
+			w.WriteString("\n")
+			w.WriteString(s.Style.Group.Render(option.Group))
+		}
+
 		w.WriteString("\n")
 		if idx == s.cursor.idx {
 			w.WriteString(s.Style.Cursor.String())
@@ -316,3 +385,21 @@ func (s *MultiSelect[T]) Render(w Writer) {
 		w.WriteString(s.Style.Done.String())
 	}
 }
+
+// startsGroup reports whether the option at idx
+// is the first option of a group.
+func (s *MultiSelect[T]) startsGroup(idx int) bool {
+	group := s.options[idx].Group
+	return group != "" && (idx == 0 || s.options[idx-1].Group != group)
+}
+
+// groupCount returns the number of group headings.
+func (s *MultiSelect[T]) groupCount() int {
+	var n int
+	for idx := range s.options {
+		if s.startsGroup(idx) {
+			n++
+		}
+	}
+	return n
+}
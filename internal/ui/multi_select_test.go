@@ -19,6 +19,7 @@ import (
 //   - want: expected []string values
 //   - give: available []string options
 //   - selected (optional): []string values already selected
+//   - groups (optional): []string group for each option in 'give'
 //   - desc (optional): widget description
 func TestMultiSelect(t *testing.T) {
 	uitest.RunScripts(t,
@@ -40,12 +41,23 @@ func TestMultiSelect(t *testing.T) {
 				json.Unmarshal([]byte(ts.ReadFile("give")), &give),
 				"read 'give' file")
 
+			var groups []string
+			if _, err := os.Stat(ts.MkAbs("groups")); err == nil {
+				require.NoError(t,
+					json.Unmarshal([]byte(ts.ReadFile("groups")), &groups),
+					"read 'groups' file")
+				require.Len(t, groups, len(give), "'groups' must match 'give'")
+			}
+
 			options := make([]ui.MultiSelectOption[string], len(give))
 			for i, value := range give {
 				options[i] = ui.MultiSelectOption[string]{
 					Value:    value,
 					Selected: slices.Contains(selected, value),
 				}
+				if groups != nil {
+					options[i].Group = groups[i]
+				}
 			}
 
 			var desc string
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
@@ -40,10 +41,16 @@ type OpenEditorStyle struct {
 }
 
 // DefaultOpenEditorStyle is the default style for an [OpenEditor] field.
-var DefaultOpenEditorStyle = OpenEditorStyle{
-	Key:             NewStyle().Foreground(Magenta),
-	Editor:          NewStyle().Foreground(Green),
-	NoEditorMessage: "please set an editor",
+var DefaultOpenEditorStyle OpenEditorStyle
+
+func init() {
+	RegisterStyles(func() {
+		DefaultOpenEditorStyle = OpenEditorStyle{
+			Key:             NewStyle().Foreground(Magenta),
+			Editor:          NewStyle().Foreground(Green),
+			NoEditorMessage: "please set an editor",
+		}
+	})
 }
 
 // Editor configures the editor to open.
@@ -193,19 +200,9 @@ func (a *OpenEditor) Update(msg tea.Msg) tea.Cmd {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, a.KeyMap.Edit) && a.Editor.Command != "":
-			ext := strings.TrimPrefix(a.Editor.Ext, ".")
-
-			tmpFile, err := osutil.TempFilePath("", "*."+ext)
+			tmpFile, err := a.writeTempFile()
 			if err != nil {
-				a.err = fmt.Errorf("create temporary file: %w", err)
-				return tea.Quit
-			}
-
-			if err := os.WriteFile(tmpFile, []byte(*a.value), 0o644); err != nil {
-				a.err = errors.Join(
-					fmt.Errorf("write to temporary file: %w", err),
-					os.Remove(tmpFile),
-				)
+				a.err = err
 				return tea.Quit
 			}
 
@@ -239,6 +236,68 @@ func (a *OpenEditor) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// writeTempFile writes the current value to a temporary file
+// for the editor to open.
+// The caller must delete the file when done.
+func (a *OpenEditor) writeTempFile() (string, error) {
+	ext := strings.TrimPrefix(a.Editor.Ext, ".")
+
+	tmpFile, err := osutil.TempFilePath("", "*."+ext)
+	if err != nil {
+		return "", fmt.Errorf("create temporary file: %w", err)
+	}
+
+	if err := os.WriteFile(tmpFile, []byte(*a.value), 0o644); err != nil {
+		return "", errors.Join(
+			fmt.Errorf("write to temporary file: %w", err),
+			os.Remove(tmpFile),
+		)
+	}
+
+	return tmpFile, nil
+}
+
+var _ ChoiceField = (*OpenEditor)(nil)
+
+// Choices returns the choices for the field:
+// open the editor, or skip it.
+func (a *OpenEditor) Choices() (labels []string, defaults []int, multiple bool) {
+	return []string{"open " + cmp.Or(a.Editor.Command, "editor"), "skip"}, []int{0}, false
+}
+
+// Choose runs the editor and waits for it to exit
+// if the first choice was picked.
+// Otherwise, the value is left unchanged.
+func (a *OpenEditor) Choose(idxs []int) error {
+	if len(idxs) != 1 {
+		return fmt.Errorf("expected one choice, got %d", len(idxs))
+	}
+	if idxs[0] != 0 {
+		return nil
+	}
+	if a.Editor.Command == "" {
+		return errors.New(a.Style.NoEditorMessage)
+	}
+
+	tmpFile, err := a.writeTempFile()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmpFile) }()
+
+	if err := xec.EditCommand(a.Editor.Command, tmpFile).Run(); err != nil {
+		return fmt.Errorf("run editor: %w", err)
+	}
+
+	content, err := os.ReadFile(tmpFile)
+	if err != nil {
+		return fmt.Errorf("read temporary file: %w", err)
+	}
+
+	*a.value = string(content)
+	return nil
+}
+
 // Render renders the field to the screen.
 func (a *OpenEditor) Render(w Writer) {
 	fmt.Fprintf(w, "Press [%v] to open %v or [%v] to skip",
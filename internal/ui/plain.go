@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChoiceField is a [Field] that picks from a fixed list of options.
+//
+// [PlainView] presents these fields as numbered lists of choices
+// instead of rendering them.
+type ChoiceField interface {
+	Field
+
+	// Choices returns labels for the options that may be picked,
+	// the indexes of the options picked by default,
+	// and whether more than one option may be picked.
+	//
+	// This is called after Init.
+	Choices() (labels []string, defaults []int, multiple bool)
+
+	// Choose picks the options at the given indexes
+	// in the list returned by Choices and accepts the field.
+	Choose(idxs []int) error
+}
+
+// PlainView is an [InteractiveView] that prompts for input
+// one line at a time, without moving the cursor or redrawing the screen.
+// This makes it suitable for screen readers and other assistive technology.
+//
+// Fields that implement [ChoiceField] are presented
+// as numbered lists of choices.
+// Other fields read a line of text, which is passed to them
+// as a string with [Field.UnmarshalValue].
+// An empty line keeps the field's current value.
+type PlainView struct {
+	R io.Reader // required
+	W io.Writer // required
+
+	scanner *bufio.Scanner
+}
+
+var _ InteractiveView = (*PlainView)(nil)
+
+func (pv *PlainView) Write(p []byte) (int, error) {
+	return pv.W.Write(p)
+}
+
+// Prompt prompts for the given fields in order.
+func (pv *PlainView) Prompt(fields ...Field) error {
+	for _, field := range fields {
+		if isSkipCmd(field.Init()) {
+			continue
+		}
+
+		// Deferred fields are only available after Init.
+		if d, ok := field.(*Deferred); ok {
+			field = d.f
+		}
+
+		if err := field.Err(); err != nil {
+			return err
+		}
+
+		var err error
+		if cf, ok := field.(ChoiceField); ok {
+			err = pv.promptChoice(cf)
+		} else {
+			err = pv.promptText(field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pv *PlainView) promptChoice(f ChoiceField) error {
+	labels, defaults, multiple := f.Choices()
+	if len(labels) == 0 {
+		return fmt.Errorf("%v: no choices available", f.Title())
+	}
+
+	pv.printHeader(f)
+	for i, label := range labels {
+		fmt.Fprintf(pv.W, "  %d. %s\n", i+1, label)
+	}
+
+	defaultLabels := make([]string, len(defaults))
+	for i, idx := range defaults {
+		defaultLabels[i] = strconv.Itoa(idx + 1)
+	}
+
+	var prompt string
+	if multiple {
+		prompt = "Enter numbers separated by commas, or 'none'"
+	} else {
+		prompt = fmt.Sprintf("Enter a number from 1 to %d", len(labels))
+	}
+	if len(defaultLabels) > 0 {
+		prompt += " [" + strings.Join(defaultLabels, ",") + "]"
+	}
+	prompt += ": "
+
+	for {
+		line, err := pv.readLine(prompt)
+		if err != nil {
+			return err
+		}
+
+		idxs := defaults
+		if line != "" {
+			idxs, err = parseChoices(line, labels, multiple)
+		} else if !multiple && len(defaults) == 0 {
+			err = errors.New("please pick one of the choices")
+		}
+		if err == nil {
+			err = f.Choose(idxs)
+		}
+		if err == nil {
+			err = f.Err()
+		}
+		if err == nil {
+			return nil
+		}
+
+		fmt.Fprintln(pv.W, err)
+	}
+}
+
+func (pv *PlainView) promptText(f Field) error {
+	pv.printHeader(f)
+
+	prompt := "Enter a value"
+	if input, ok := f.(*Input); ok {
+		if len(input.options) > 0 {
+			fmt.Fprintf(pv.W, "Suggestions: %s\n", strings.Join(input.options, ", "))
+		}
+		if *input.value != "" {
+			prompt += " [" + *input.value + "]"
+		}
+	}
+	prompt += ": "
+
+	for {
+		line, err := pv.readLine(prompt)
+		if err != nil {
+			return err
+		}
+
+		// An empty line accepts the current value.
+		raw := []byte("true")
+		if line != "" {
+			raw, err = json.Marshal(line)
+			if err != nil {
+				return fmt.Errorf("encode input: %w", err)
+			}
+		}
+
+		err = f.UnmarshalValue(func(dst any) error {
+			return json.Unmarshal(raw, dst)
+		})
+		if err == nil {
+			err = f.Err()
+		}
+		if err == nil {
+			return nil
+		}
+
+		fmt.Fprintln(pv.W, err)
+	}
+}
+
+func (pv *PlainView) printHeader(f Field) {
+	if title := f.Title(); title != "" {
+		if !strings.HasSuffix(title, "?") && !strings.HasSuffix(title, ":") {
+			title += ":"
+		}
+		fmt.Fprintln(pv.W, title)
+	}
+	if desc := f.Description(); desc != "" {
+		fmt.Fprintln(pv.W, desc)
+	}
+}
+
+func (pv *PlainView) readLine(prompt string) (string, error) {
+	if pv.scanner == nil {
+		pv.scanner = bufio.NewScanner(pv.R)
+	}
+
+	fmt.Fprint(pv.W, prompt)
+	if !pv.scanner.Scan() {
+		if err := pv.scanner.Err(); err != nil {
+			return "", fmt.Errorf("read input: %w", err)
+		}
+		return "", fmt.Errorf("read input: %w", io.ErrUnexpectedEOF)
+	}
+	return strings.TrimSpace(pv.scanner.Text()), nil
+}
+
+// parseChoices parses a user's response to a list of choices.
+// Choices may be specified by number (starting at 1),
+// or by label or an unambiguous prefix of it, ignoring case.
+func parseChoices(line string, labels []string, multiple bool) ([]int, error) {
+	tokens := []string{line}
+	if multiple {
+		if strings.EqualFold(line, "none") {
+			return []int{}, nil
+		}
+
+		tokens = strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+
+	idxs := make([]int, 0, len(tokens))
+	for _, token := range tokens {
+		idx, err := parseChoice(token, labels)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(idxs, idx) {
+			idxs = append(idxs, idx)
+		}
+	}
+	slices.Sort(idxs)
+	return idxs, nil
+}
+
+func parseChoice(token string, labels []string) (int, error) {
+	if n, err := strconv.Atoi(token); err == nil {
+		if n < 1 || n > len(labels) {
+			return 0, fmt.Errorf("%d is not a number from 1 to %d", n, len(labels))
+		}
+		return n - 1, nil
+	}
+
+	if idx := slices.IndexFunc(labels, func(label string) bool {
+		return strings.EqualFold(label, token)
+	}); idx >= 0 {
+		return idx, nil
+	}
+
+	match := -1
+	for idx, label := range labels {
+		if len(label) >= len(token) && strings.EqualFold(label[:len(token)], token) {
+			if match >= 0 {
+				return 0, fmt.Errorf("%q matches more than one choice", token)
+			}
+			match = idx
+		}
+	}
+
+	if match < 0 {
+		return 0, fmt.Errorf("%q does not match any choice", token)
+	}
+	return match, nil
+}
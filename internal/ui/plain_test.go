@@ -0,0 +1,145 @@
+package ui_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+func TestPlainView(t *testing.T) {
+	t.Run("Select", func(t *testing.T) {
+		var out strings.Builder
+		view := &ui.PlainView{
+			R: strings.NewReader("7\nba\nBAZ\n"),
+			W: &out,
+		}
+
+		var got string
+		require.NoError(t, ui.Run(view, ui.NewSelect[string]().
+			WithTitle("Pick a value").
+			WithDescription("Any value will do").
+			WithValue(&got).
+			With(ui.ComparableOptions("bar", "foo", "bar", "baz"))))
+		assert.Equal(t, "baz", got)
+
+		assert.Equal(t, strings.Join([]string{
+			"Pick a value:",
+			"Any value will do",
+			"  1. foo",
+			"  2. bar",
+			"  3. baz",
+			"Enter a number from 1 to 3 [2]: 7 is not a number from 1 to 3",
+			`Enter a number from 1 to 3 [2]: "ba" matches more than one choice`,
+			"Enter a number from 1 to 3 [2]: ",
+		}, "\n"), out.String())
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		var got string
+		require.NoError(t, ui.Run(&ui.PlainView{
+			R: strings.NewReader("\n"),
+			W: io.Discard,
+		}, ui.NewSelect[string]().
+			WithValue(&got).
+			With(ui.ComparableOptions("bar", "foo", "bar", "baz"))))
+		assert.Equal(t, "bar", got)
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		var out strings.Builder
+		view := &ui.PlainView{
+			R: strings.NewReader("y\n"),
+			W: &out,
+		}
+
+		var got bool
+		require.NoError(t, ui.Run(view, ui.NewConfirm().
+			WithTitle("Delete branch?").
+			WithValue(&got)))
+		assert.True(t, got)
+
+		assert.Equal(t, strings.Join([]string{
+			"Delete branch?",
+			"  1. yes",
+			"  2. no",
+			"Enter a number from 1 to 2 [2]: ",
+		}, "\n"), out.String())
+	})
+
+	t.Run("Input", func(t *testing.T) {
+		var out strings.Builder
+		view := &ui.PlainView{
+			R: strings.NewReader("\nbar\n"),
+			W: &out,
+		}
+
+		first, second := "foo", ""
+		require.NoError(t, ui.Run(view,
+			ui.NewInput().WithTitle("First").WithValue(&first),
+			ui.NewInput().WithTitle("Second").WithValue(&second),
+		))
+		assert.Equal(t, "foo", first)
+		assert.Equal(t, "bar", second)
+
+		assert.Equal(t, strings.Join([]string{
+			"First:",
+			"Enter a value [foo]: Second:",
+			"Enter a value: ",
+		}, "\n"), out.String())
+	})
+
+	t.Run("MultiSelect", func(t *testing.T) {
+		var out strings.Builder
+		view := &ui.PlainView{
+			R: strings.NewReader("1, 3\n"),
+			W: &out,
+		}
+
+		widget := ui.NewMultiSelect(func(w ui.Writer, _ int, opt ui.MultiSelectOption[string]) {
+			_, _ = w.WriteString(opt.Value)
+		}).
+			WithTitle("Pick values").
+			WithOptions(
+				ui.MultiSelectOption[string]{Value: "a"},
+				ui.MultiSelectOption[string]{Value: "b", Selected: true},
+				ui.MultiSelectOption[string]{Value: "c", Skip: true},
+				ui.MultiSelectOption[string]{Value: "d"},
+			)
+		require.NoError(t, ui.Run(view, widget))
+		assert.Equal(t, []string{"a", "d"}, widget.Value())
+
+		assert.Equal(t, strings.Join([]string{
+			"Pick values:",
+			"  1. a",
+			"  2. b",
+			"  3. d",
+			"Enter numbers separated by commas, or 'none' [2]: ",
+		}, "\n"), out.String())
+	})
+
+	t.Run("SkipField", func(t *testing.T) {
+		view := &ui.PlainView{
+			R: strings.NewReader(""),
+			W: io.Discard,
+		}
+
+		require.NoError(t, ui.Run(view, ui.Defer(func() ui.Field {
+			return nil
+		})))
+	})
+
+	t.Run("EOF", func(t *testing.T) {
+		view := &ui.PlainView{
+			R: strings.NewReader(""),
+			W: io.Discard,
+		}
+
+		err := ui.Run(view, ui.NewInput().WithTitle("Name"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
@@ -45,14 +45,28 @@ type SelectStyle struct {
 	Selected  lipgloss.Style
 	Highlight lipgloss.Style
 
+	// Cursor is placed next to the selected option.
+	Cursor string
+
 	ScrollMarker lipgloss.Style
+	ScrollUp     string
+	ScrollDown   string
 }
 
 // DefaultSelectStyle is the default style for a [Select].
-var DefaultSelectStyle = SelectStyle{
-	Selected:     NewStyle().Foreground(Yellow),
-	Highlight:    NewStyle().Foreground(Cyan),
-	ScrollMarker: NewStyle().Foreground(Gray),
+var DefaultSelectStyle SelectStyle
+
+func init() {
+	RegisterStyles(func() {
+		DefaultSelectStyle = SelectStyle{
+			Selected:     NewStyle().Foreground(Yellow),
+			Highlight:    NewStyle().Foreground(Cyan),
+			Cursor:       Glyph("▶", ">"),
+			ScrollMarker: NewStyle().Foreground(Gray),
+			ScrollUp:     Glyph("▲▲▲", "^^^"),
+			ScrollDown:   Glyph("▼▼▼", "vvv"),
+		}
+	})
 }
 
 // Select is a prompt that allows selecting from a list of options
@@ -144,6 +158,31 @@ func (s *Select[T]) UnmarshalValue(unmarshal func(any) error) error {
 	return fmt.Errorf("no option with label: %v", selectLabel)
 }
 
+var _ ChoiceField = (*Select[int])(nil)
+
+// Choices returns the labels of all options
+// and the index of the selected option.
+func (s *Select[T]) Choices() (labels []string, defaults []int, multiple bool) {
+	labels = make([]string, len(s.options))
+	for i, opt := range s.options {
+		labels[i] = opt.Label
+	}
+	if s.selected < len(s.matched) {
+		defaults = []int{s.matched[s.selected]}
+	}
+	return labels, defaults, false
+}
+
+// Choose selects the option at the given index.
+func (s *Select[T]) Choose(idxs []int) error {
+	if len(idxs) != 1 || idxs[0] < 0 || idxs[0] >= len(s.options) {
+		return fmt.Errorf("expected one option, got %v", idxs)
+	}
+	*s.value = s.options[idxs[0]].Value
+	s.accepted = true
+	return nil
+}
+
 // With runs the given function with the select field.
 func (s *Select[T]) With(f func(*Select[T])) *Select[T] {
 	f(s)
@@ -415,7 +454,7 @@ func (s *Select[T]) Render(out Writer) {
 	}
 
 	if offset > 0 {
-		fmt.Fprintf(out, "%s\n", s.Style.ScrollMarker.Render("  ▲▲▲"))
+		fmt.Fprintf(out, "%s\n", s.Style.ScrollMarker.Render("  "+s.Style.ScrollUp))
 	} else {
 		out.WriteString("\n")
 	}
@@ -431,7 +470,7 @@ func (s *Select[T]) Render(out Writer) {
 		style := NewStyle()
 		if matchIdx == s.selected {
 			style = s.Style.Selected
-			out.WriteString("▶ ")
+			out.WriteString(s.Style.Cursor + " ")
 		} else {
 			out.WriteString("  ")
 		}
@@ -449,6 +488,6 @@ func (s *Select[T]) Render(out Writer) {
 	}
 
 	if offset+s.visible < len(s.matched) {
-		fmt.Fprintf(out, "%s\n", s.Style.ScrollMarker.Render("  ▼▼▼"))
+		fmt.Fprintf(out, "%s\n", s.Style.ScrollMarker.Render("  "+s.Style.ScrollDown))
 	}
 }
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme defines the colors and symbols used by UI components.
+//
+// Each color is adaptive: it has a variant for light backgrounds
+// and a variant for dark backgrounds.
+type Theme struct {
+	Yellow  lipgloss.AdaptiveColor
+	Red     lipgloss.AdaptiveColor
+	Green   lipgloss.AdaptiveColor
+	Plain   lipgloss.AdaptiveColor
+	Cyan    lipgloss.AdaptiveColor
+	Magenta lipgloss.AdaptiveColor
+	Gray    lipgloss.AdaptiveColor
+
+	// ASCII replaces non-ASCII symbols (cursors, tree lines, markers)
+	// with ASCII alternatives.
+	//
+	// Use this for terminals or fonts that can't render them.
+	ASCII bool
+}
+
+// DefaultTheme is the theme used if none is configured.
+var DefaultTheme = Theme{
+	Yellow:  lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+	Red:     lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+	Green:   lipgloss.AdaptiveColor{Light: "2", Dark: "10"},
+	Plain:   lipgloss.AdaptiveColor{Light: "0", Dark: "7"},
+	Cyan:    lipgloss.AdaptiveColor{Light: "6", Dark: "14"},
+	Magenta: lipgloss.AdaptiveColor{Light: "5", Dark: "13"},
+	Gray:    lipgloss.AdaptiveColor{Light: "8", Dark: "8"},
+}
+
+// HighContrastTheme uses darker colors on light backgrounds
+// and brighter colors on dark backgrounds than [DefaultTheme].
+var HighContrastTheme = Theme{
+	Yellow:  lipgloss.AdaptiveColor{Light: "130", Dark: "226"},
+	Red:     lipgloss.AdaptiveColor{Light: "124", Dark: "196"},
+	Green:   lipgloss.AdaptiveColor{Light: "22", Dark: "46"},
+	Plain:   lipgloss.AdaptiveColor{Light: "16", Dark: "231"},
+	Cyan:    lipgloss.AdaptiveColor{Light: "24", Dark: "51"},
+	Magenta: lipgloss.AdaptiveColor{Light: "90", Dark: "201"},
+	Gray:    lipgloss.AdaptiveColor{Light: "238", Dark: "250"},
+}
+
+// ThemeNames lists the names of the built-in themes
+// accepted by [LookupTheme].
+var ThemeNames = []string{"default", "high-contrast"}
+
+// LookupTheme returns the built-in theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	switch name {
+	case "default":
+		return DefaultTheme, true
+	case "high-contrast":
+		return HighContrastTheme, true
+	default:
+		return Theme{}, false
+	}
+}
+
+// ColorNames lists the names of the colors in a [Theme]
+// accepted by [Theme.SetColor].
+var ColorNames = []string{"yellow", "red", "green", "plain", "cyan", "magenta", "gray"}
+
+func (t *Theme) color(name string) *lipgloss.AdaptiveColor {
+	switch strings.ToLower(name) {
+	case "yellow":
+		return &t.Yellow
+	case "red":
+		return &t.Red
+	case "green":
+		return &t.Green
+	case "plain":
+		return &t.Plain
+	case "cyan":
+		return &t.Cyan
+	case "magenta":
+		return &t.Magenta
+	case "gray", "grey":
+		return &t.Gray
+	default:
+		return nil
+	}
+}
+
+// SetColor changes the color with the given name.
+//
+// value is either a single color used on all backgrounds,
+// or two comma-separated colors for light and dark backgrounds.
+// Colors are ANSI color numbers (0-255) or hex codes (#RRGGBB).
+//
+//	t.SetColor("yellow", "3")
+//	t.SetColor("yellow", "3,11")
+//	t.SetColor("yellow", "#af8700,#ffff00")
+func (t *Theme) SetColor(name, value string) error {
+	c := t.color(name)
+	if c == nil {
+		return fmt.Errorf("unknown color %q: must be one of %v",
+			name, strings.Join(ColorNames, ", "))
+	}
+
+	light, dark, ok := strings.Cut(value, ",")
+	if !ok {
+		dark = light
+	}
+	light, dark = strings.TrimSpace(light), strings.TrimSpace(dark)
+	for _, s := range []string{light, dark} {
+		if !_colorRegexp.MatchString(s) {
+			return fmt.Errorf("bad color %q: must be a number from 0 to 255 or a hex code (#RRGGBB)", s)
+		}
+	}
+
+	*c = lipgloss.AdaptiveColor{Light: light, Dark: dark}
+	return nil
+}
+
+// ParseColor parses a color override in the form NAME=VALUE
+// and applies it to the theme with [Theme.SetColor].
+func (t *Theme) ParseColor(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return errors.New("expected NAME=VALUE")
+	}
+	return t.SetColor(strings.TrimSpace(name), value)
+}
+
+var _colorRegexp = regexp.MustCompile(`^(?:25[0-5]|2[0-4][0-9]|1?[0-9]{1,2}|#[0-9a-fA-F]{6})$`)
+
+var (
+	_theme         = DefaultTheme
+	_styleBuilders []func()
+)
+
+// CurrentTheme returns the theme currently in use.
+func CurrentTheme() Theme {
+	return _theme
+}
+
+// SetTheme changes the theme used by UI components.
+//
+// The palette variables ([Yellow], [Red], etc.) are updated,
+// and all styles registered with [RegisterStyles] are rebuilt.
+// Components built before the call keep their old styles.
+func SetTheme(t Theme) {
+	_theme = t
+	Yellow = t.Yellow
+	Red = t.Red
+	Green = t.Green
+	Plain = t.Plain
+	Cyan = t.Cyan
+	Magenta = t.Magenta
+	Gray = t.Gray
+
+	for _, build := range _styleBuilders {
+		build()
+	}
+}
+
+// RegisterStyles registers a function that builds
+// package-level styles from the current theme.
+//
+// The function is called immediately,
+// and again every time the theme changes.
+// It should be called from an init function.
+func RegisterStyles(build func()) {
+	_styleBuilders = append(_styleBuilders, build)
+	build()
+}
+
+// Glyph returns unicode, or ascii if the current theme
+// is restricted to ASCII symbols.
+func Glyph(unicode, ascii string) string {
+	if _theme.ASCII {
+		return ascii
+	}
+	return unicode
+}
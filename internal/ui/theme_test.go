@@ -0,0 +1,119 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+func TestTheme_SetColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		color string
+		value string
+
+		want    lipgloss.AdaptiveColor
+		wantErr string
+	}{
+		{
+			name:  "Single",
+			color: "yellow",
+			value: "3",
+			want:  lipgloss.AdaptiveColor{Light: "3", Dark: "3"},
+		},
+		{
+			name:  "LightDark",
+			color: "Yellow",
+			value: "3, 11",
+			want:  lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+		},
+		{
+			name:  "Hex",
+			color: "yellow",
+			value: "#af8700,#FFFF00",
+			want:  lipgloss.AdaptiveColor{Light: "#af8700", Dark: "#FFFF00"},
+		},
+		{
+			name:    "UnknownName",
+			color:   "orange",
+			value:   "3",
+			wantErr: `unknown color "orange"`,
+		},
+		{
+			name:    "OutOfRange",
+			color:   "yellow",
+			value:   "256",
+			wantErr: `bad color "256"`,
+		},
+		{
+			name:    "BadHex",
+			color:   "yellow",
+			value:   "3,#fff",
+			wantErr: `bad color "#fff"`,
+		},
+		{
+			name:    "Empty",
+			color:   "yellow",
+			value:   "",
+			wantErr: `bad color ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme := ui.DefaultTheme
+			err := theme.SetColor(tt.color, tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, ui.DefaultTheme, theme, "theme must not change")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, theme.Yellow)
+		})
+	}
+}
+
+func TestTheme_ParseColor(t *testing.T) {
+	theme := ui.DefaultTheme
+	require.NoError(t, theme.ParseColor("gray=240,250"))
+	assert.Equal(t, lipgloss.AdaptiveColor{Light: "240", Dark: "250"}, theme.Gray)
+
+	assert.ErrorContains(t, theme.ParseColor("gray"), "expected NAME=VALUE")
+}
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range ui.ThemeNames {
+		_, ok := ui.LookupTheme(name)
+		assert.True(t, ok, "theme %q", name)
+	}
+
+	_, ok := ui.LookupTheme("solarized")
+	assert.False(t, ok)
+}
+
+func TestSetTheme(t *testing.T) {
+	t.Cleanup(func() { ui.SetTheme(ui.DefaultTheme) })
+
+	var cursor string
+	ui.RegisterStyles(func() {
+		cursor = ui.Glyph("▶", ">")
+	})
+	assert.Equal(t, "▶", cursor)
+	assert.Equal(t, "▶", ui.DefaultListStyle.Cursor.Value())
+
+	theme := ui.HighContrastTheme
+	theme.ASCII = true
+	ui.SetTheme(theme)
+
+	assert.Equal(t, theme, ui.CurrentTheme())
+	assert.Equal(t, theme.Yellow, ui.Yellow)
+	assert.Equal(t, ">", cursor)
+	assert.Equal(t, ">", ui.DefaultListStyle.Cursor.Value())
+	assert.Equal(t, "vvv", ui.DefaultMultiSelectStyle.ScrollDown.Value())
+}
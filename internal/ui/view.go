@@ -3,11 +3,44 @@ package ui
 import (
 	"errors"
 	"io"
+	"strconv"
+	"strings"
 )
 
 // ErrPrompt indicates that we're not running in interactive mode.
 var ErrPrompt = errors.New("not allowed to prompt for input")
 
+// PromptError is returned by [Run] when it's asked to prompt for input
+// with a view that is not interactive.
+//
+// It matches [ErrPrompt] with errors.Is.
+type PromptError struct {
+	// Fields lists the titles of the fields
+	// that would have been presented to the user.
+	Fields []string
+}
+
+var _ error = (*PromptError)(nil)
+
+func (e *PromptError) Error() string {
+	var msg strings.Builder
+	msg.WriteString(ErrPrompt.Error())
+	for i, title := range e.Fields {
+		if i == 0 {
+			msg.WriteString(": ")
+		} else {
+			msg.WriteString(", ")
+		}
+		msg.WriteString(strconv.Quote(title))
+	}
+	return msg.String()
+}
+
+// Is reports whether target is [ErrPrompt].
+func (e *PromptError) Is(target error) bool {
+	return target == ErrPrompt
+}
+
 // View provides access to the UI,
 // allowing the application to send messages to the user,
 // and in interactive mode, prompt for input.
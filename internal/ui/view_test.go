@@ -0,0 +1,28 @@
+package ui_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+func TestRun_nonInteractive(t *testing.T) {
+	view := &ui.FileView{W: io.Discard}
+
+	err := ui.Run(view,
+		ui.NewInput().WithTitle("Branch name"),
+		ui.NewConfirm().WithTitle("Delete branch?"),
+		ui.NewInput(), // untitled
+	)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ui.ErrPrompt)
+	assert.EqualError(t, err, `not allowed to prompt for input: "Branch name", "Delete branch?"`)
+
+	var promptErr *ui.PromptError
+	require.True(t, errors.As(err, &promptErr))
+	assert.Equal(t, []string{"Branch name", "Delete branch?"}, promptErr.Fields)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"slices"
 	"sort"
+	"strings"
 	"unicode"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -52,12 +53,16 @@ var DefaultBranchSelectKeyMap = BranchSelectKeyMap{
 
 // _branchSelectStyle is the default style for a [BranchTreeSelect] widget.
 // It modifies branchtree.DefaultStyle to match the widget's visual appearance.
-var _branchSelectStyle = func() branchtree.Style {
-	s := branchtree.DefaultStyle
-	s.Branch = ui.NewStyle()
-	s.BranchHighlighted = ui.NewStyle().Bold(true).Foreground(ui.Yellow)
-	return s
-}()
+var _branchSelectStyle branchtree.Style
+
+func init() {
+	ui.RegisterStyles(func() {
+		s := branchtree.DefaultStyle
+		s.Branch = ui.NewStyle()
+		s.BranchHighlighted = ui.NewStyle().Bold(true).Foreground(ui.Yellow)
+		_branchSelectStyle = s
+	})
+}
 
 // BranchTreeItem is a single item in a [BranchTreeSelect].
 type BranchTreeItem struct {
@@ -182,6 +187,43 @@ func (b *BranchTreeSelect) UnmarshalValue(unmarshal func(any) error) error {
 	return fmt.Errorf("unknown branch: %s", got)
 }
 
+var _ ui.ChoiceField = (*BranchTreeSelect)(nil)
+
+// Choices returns the branches that may be selected
+// in the order they appear in the tree,
+// along with their bases and change IDs.
+func (b *BranchTreeSelect) Choices() (labels []string, defaults []int, multiple bool) {
+	labels = make([]string, len(b.selectable))
+	for i, idx := range b.selectable {
+		bi := b.all[idx]
+
+		var label strings.Builder
+		label.WriteString(bi.Branch)
+		if bi.ChangeID != "" {
+			label.WriteString(" " + bi.ChangeID)
+		}
+		if bi.Base != "" {
+			label.WriteString(" (on " + bi.Base + ")")
+		}
+		labels[i] = label.String()
+	}
+	if b.focused >= 0 && b.focused < len(b.selectable) {
+		defaults = []int{b.focused}
+	}
+	return labels, defaults, false
+}
+
+// Choose selects the branch at the given index.
+func (b *BranchTreeSelect) Choose(idxs []int) error {
+	if len(idxs) != 1 || idxs[0] < 0 || idxs[0] >= len(b.selectable) {
+		return fmt.Errorf("expected one branch, got %v", idxs)
+	}
+	b.focused = idxs[0]
+	*b.value = b.all[b.selectable[b.focused]].Branch
+	b.accepted = true
+	return nil
+}
+
 // Init initializes the widget.
 func (b *BranchTreeSelect) Init() tea.Cmd {
 	rootSet := make(map[int]struct{})
@@ -23,11 +23,17 @@ type BranchSplitStyle struct {
 }
 
 // DefaultBranchSplitStyle is the default style for a [BranchSplit].
-var DefaultBranchSplitStyle = BranchSplitStyle{
-	Commit:      commit.DefaultSummaryStyle,
-	HeadCommit:  commit.DefaultSummaryStyle.Faint(true),
-	SplitMarker: ui.NewStyle().SetString("□"),
-	HeadMarker:  ui.NewStyle().SetString("■"),
+var DefaultBranchSplitStyle BranchSplitStyle
+
+func init() {
+	ui.RegisterStyles(func() {
+		DefaultBranchSplitStyle = BranchSplitStyle{
+			Commit:      commit.DefaultSummaryStyle,
+			HeadCommit:  commit.DefaultSummaryStyle.Faint(true),
+			SplitMarker: ui.NewStyle().SetString(ui.Glyph("□", "o")),
+			HeadMarker:  ui.NewStyle().SetString(ui.Glyph("■", "*")),
+		}
+	})
 }
 
 // BranchSplit is a widget that allows users to pick out commits to split
@@ -50,8 +56,8 @@ func NewBranchSplit() *BranchSplit {
 		Style: DefaultBranchSplitStyle,
 	}
 	bs.model = ui.NewMultiSelect(bs.renderCommit)
-	bs.model.Style.ScrollUp = ui.NewStyle().Foreground(ui.Gray).SetString("    ▲▲▲")
-	bs.model.Style.ScrollDown = ui.NewStyle().Foreground(ui.Gray).SetString("    ▼▼▼")
+	bs.model.Style.ScrollUp = ui.NewStyle().Foreground(ui.Gray).SetString("    " + ui.Glyph("▲▲▲", "^^^"))
+	bs.model.Style.ScrollDown = ui.NewStyle().Foreground(ui.Gray).SetString("    " + ui.Glyph("▼▼▼", "vvv"))
 	return bs
 }
 
@@ -97,6 +103,27 @@ func (b *BranchSplit) UnmarshalValue(unmarshal func(any) error) error {
 	})
 }
 
+var _ ui.ChoiceField = (*BranchSplit)(nil)
+
+// Choices returns the commits after which the branch may be split.
+// The head commit is not included.
+func (b *BranchSplit) Choices() (labels []string, defaults []int, multiple bool) {
+	for idx, c := range b.commits[:len(b.commits)-1] {
+		labels = append(labels, fmt.Sprintf("%v %v", c.ShortHash, c.Subject))
+		if slices.Contains(b.model.Selected(), idx) {
+			defaults = append(defaults, idx)
+		}
+	}
+	return labels, defaults, true
+}
+
+// Choose selects the commits at the given indexes to split at.
+func (b *BranchSplit) Choose(idxs []int) error {
+	// The head commit is the only option skipped by the model,
+	// so indexes in Choices match the model's choices.
+	return b.model.Choose(idxs)
+}
+
 // Title returns the title of the widget.
 func (b *BranchSplit) Title() string {
 	return b.model.Title()
@@ -2,6 +2,7 @@ package widget
 
 import (
 	"errors"
+	"fmt"
 	"maps"
 	"slices"
 	"strings"
@@ -49,13 +50,19 @@ type CommitPickStyle struct {
 }
 
 // DefaultCommitPickStyle is the default style for the commit pick widget.
-var DefaultCommitPickStyle = CommitPickStyle{
-	Branch: ui.NewStyle().Bold(true),
-	CursorStyle: ui.NewStyle().
-		Foreground(ui.Yellow).
-		Bold(true).
-		SetString("▶"),
-	LogCommitStyle: commit.DefaultSummaryStyle,
+var DefaultCommitPickStyle CommitPickStyle
+
+func init() {
+	ui.RegisterStyles(func() {
+		DefaultCommitPickStyle = CommitPickStyle{
+			Branch: ui.NewStyle().Bold(true),
+			CursorStyle: ui.NewStyle().
+				Foreground(ui.Yellow).
+				Bold(true).
+				SetString(ui.Glyph("▶", ">")),
+			LogCommitStyle: commit.DefaultSummaryStyle,
+		}
+	})
 }
 
 // CommitPickBranch is a single branch shown in the commit pick widget.
@@ -168,6 +175,36 @@ func (c *CommitPick) UnmarshalValue(unmarshal func(any) error) error {
 	return nil
 }
 
+var _ ui.ChoiceField = (*CommitPick)(nil)
+
+// Choices returns the commits that may be picked
+// in the order they appear in the tree,
+// along with their branches.
+func (c *CommitPick) Choices() (labels []string, defaults []int, multiple bool) {
+	labels = make([]string, len(c.order))
+	for i, commitIdx := range c.order {
+		pick := c.commits[commitIdx]
+		labels[i] = fmt.Sprintf("%v %v (%v)",
+			pick.Summary.ShortHash, pick.Summary.Subject,
+			c.branches[pick.Branch].Name)
+	}
+	if c.cursor < len(c.order) {
+		defaults = []int{c.cursor}
+	}
+	return labels, defaults, false
+}
+
+// Choose picks the commit at the given index.
+func (c *CommitPick) Choose(idxs []int) error {
+	if len(idxs) != 1 || idxs[0] < 0 || idxs[0] >= len(c.order) {
+		return fmt.Errorf("expected one commit, got %v", idxs)
+	}
+	c.cursor = idxs[0]
+	*c.value = c.commits[c.order[c.cursor]].Summary.ShortHash
+	c.accepted = true
+	return nil
+}
+
 // Init initializes the widget. This is called by Bubble Tea.
 // With* functions may not be used once this is called.
 func (c *CommitPick) Init() tea.Cmd {
@@ -20,6 +20,24 @@
 //   - use Stderr to redirect stderr elsewhere
 //   - use WithLogPrefix to change the prefix for log messages
 //
+// # Tracing
+//
+// If the logger is at trace level or lower,
+// every command that is run is logged at trace level
+// with its arguments, how long it took, and its exit code.
+//
+// Use WithTiming to also report the time taken by a command
+// to the [timing.Recorder] in its context, if any.
+//
+// # Cancellation
+//
+// If the context passed to [Command] is canceled,
+// or the command runs longer than the timeout set with WithTimeout,
+// the command is asked to stop with SIGTERM
+// (or killed outright on platforms without it).
+// If it's still running after a grace period (see WithGracePeriod),
+// it's killed with SIGKILL.
+//
 // # Environment variables
 //
 // All commands spawned via this package
@@ -40,29 +58,61 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/timing"
 )
 
 const _gitSpiceEnv = "GIT_SPICE=1"
 
+// DefaultGracePeriod is how long a command is given to exit
+// after it's asked to stop before it's killed.
+const DefaultGracePeriod = 5 * time.Second
+
 var _osEnviron = os.Environ
 
 // Cmd is an external command being prepared or run.
 type Cmd struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
 	cmd     *exec.Cmd
 	log     *prefixLogger
 	_execer Execer
 
+	// Time at which the command was started.
+	// Used only for tracing and timing.
+	start time.Time
+
+	// Category under which the command's duration is recorded.
+	timing    timing.Category
+	hasTiming bool
+
+	// Maximum time the command may run for, if positive.
+	timeout time.Duration
+	timer   *time.Timer // non-nil while a timeout is pending
+
 	// Wraps an error with stderr output.
 	wrap func(error) error
 }
 
+// TimeoutError is returned when a command is stopped
+// because it ran longer than its timeout.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %v", e.Timeout)
+}
+
 // Command constructs a Cmd to execute a program with the given arguments.
 //
 // ctx controls the lifetime of the command,
 // and log is used to log command output and errors.
 // If log is nil, stderr is buffered and surfaced in the error if the command fails.
+//
+// The command has no timeout by default. Use WithTimeout to set one.
 func Command(ctx context.Context, log *silog.Logger, name string, args ...string) *Cmd {
 	if log == nil {
 		log = silog.Nop(&silog.Options{
@@ -71,10 +121,19 @@ func Command(ctx context.Context, log *silog.Logger, name string, args ...string
 	}
 	logger := &prefixLogger{Logger: log, prefix: name}
 	stderr, wrap := outputLogWriter("stderr", logger)
+
+	// The command gets its own context so that it can be stopped
+	// when it times out without affecting the caller's context.
+	// This is released when the command finishes.
+	ctx, cancel := context.WithCancelCause(ctx)
 	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Cancel = func() error { return interrupt(cmd.Process) }
+	cmd.WaitDelay = DefaultGracePeriod
 	cmd.Stderr = stderr
 	cmd.Env = append(_osEnviron(), _gitSpiceEnv)
 	return &Cmd{
+		ctx:     ctx,
+		cancel:  cancel,
 		cmd:     cmd,
 		log:     logger,
 		wrap:    wrap,
@@ -89,6 +148,26 @@ func (c *Cmd) WithExecer(execer Execer) *Cmd {
 	return c
 }
 
+// WithTimeout sets the maximum time the command may run for,
+// starting from when it's started.
+// If it runs longer, it's stopped and returns a [*TimeoutError].
+//
+// A zero or negative timeout disables the timeout.
+func (c *Cmd) WithTimeout(timeout time.Duration) *Cmd {
+	c.timeout = timeout
+	return c
+}
+
+// WithGracePeriod sets how long the command is given to exit
+// after it's asked to stop (because its context was canceled
+// or it timed out) before it's killed.
+//
+// This defaults to [DefaultGracePeriod].
+func (c *Cmd) WithGracePeriod(d time.Duration) *Cmd {
+	c.cmd.WaitDelay = d
+	return c
+}
+
 func (c *Cmd) execer() Execer {
 	if c._execer != nil {
 		return c._execer
@@ -100,19 +179,29 @@ func (c *Cmd) execer() Execer {
 //
 // It returns an error if the command fails with a non-zero exit code.
 func (c *Cmd) Run() error {
-	return c.wrap(c.execer().Run(c.cmd))
+	c.begin()
+	err := c.execer().Run(c.cmd)
+	err = c.finish(err)
+	return c.wrap(err)
 }
 
 // Start starts the command, returning immediately.
 // It returns an error if the command fails to start.
 func (c *Cmd) Start() error {
-	return c.wrap(c.execer().Start(c.cmd))
+	c.begin()
+	err := c.execer().Start(c.cmd)
+	if err != nil {
+		err = c.finish(err)
+	}
+	return c.wrap(err)
 }
 
 // Wait waits for a command started with Start to complete.
 // It returns an error if the command fails with a non-zero exit code.
 func (c *Cmd) Wait() error {
-	return c.wrap(c.execer().Wait(c.cmd))
+	err := c.execer().Wait(c.cmd)
+	err = c.finish(err)
+	return c.wrap(err)
 }
 
 // Kill kills a command started with Start.
@@ -123,7 +212,10 @@ func (c *Cmd) Kill() error {
 // Output runs the command and returns its stdout.
 // It returns an error if the command fails with a non-zero exit code.
 func (c *Cmd) Output() ([]byte, error) {
-	return c.execer().Output(c.cmd)
+	c.begin()
+	out, err := c.execer().Output(c.cmd)
+	err = c.finish(err)
+	return out, err
 }
 
 // Args returns the arguments passed to the command,
@@ -147,6 +239,15 @@ func (c *Cmd) WithLogPrefix(prefix string) *Cmd {
 	return c
 }
 
+// WithTiming records the time taken by the command
+// under the given category with the [timing.Recorder]
+// in the command's context.
+func (c *Cmd) WithTiming(category timing.Category) *Cmd {
+	c.timing = category
+	c.hasTiming = true
+	return c
+}
+
 // WithDir sets the working directory for the command.
 func (c *Cmd) WithDir(dir string) *Cmd {
 	c.cmd.Dir = dir
@@ -263,7 +364,10 @@ func (c *Cmd) Scan(split bufio.SplitFunc) iter.Seq2[[]byte, error] {
 		var finished bool
 		defer func() {
 			if !finished {
+				// Reap the process so that its timer and context
+				// are released.
 				_ = c.Kill()
+				_ = c.Wait()
 			}
 		}()
 
@@ -280,14 +384,73 @@ func (c *Cmd) Scan(split bufio.SplitFunc) iter.Seq2[[]byte, error] {
 			return
 		}
 
+		finished = true
 		if err := c.Wait(); err != nil {
 			// If the command failed, wrap the error with stderr output.
 			yield(nil, fmt.Errorf("wait: %w", c.wrap(err)))
 			return
 		}
+	}
+}
 
-		finished = true
+// begin records the start of a command
+// and starts its timeout, if any.
+func (c *Cmd) begin() {
+	c.start = time.Now()
+	if timeout := c.timeout; timeout > 0 {
+		c.timer = time.AfterFunc(timeout, func() {
+			c.cancel(&TimeoutError{Timeout: timeout})
+		})
+	}
+}
+
+// finish releases resources held by a command that has finished running,
+// and reports whether it timed out.
+// It returns the error that the command should report.
+func (c *Cmd) finish(err error) error {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
 	}
+
+	if err != nil {
+		var timeoutErr *TimeoutError
+		if errors.As(context.Cause(c.ctx), &timeoutErr) {
+			err = fmt.Errorf("%w: %w", timeoutErr, err)
+		}
+	}
+	c.cancel(nil)
+
+	c.record(err)
+	return err
+}
+
+// record records the time taken by a command that has finished running,
+// and logs it if the logger is at trace level.
+func (c *Cmd) record(err error) {
+	duration := time.Since(c.start)
+	if c.hasTiming {
+		timing.Record(c.ctx, c.timing, duration)
+	}
+	if c.log.Level() > silog.LevelTrace {
+		return
+	}
+
+	exitCode := 0
+	if err != nil {
+		exitCode = -1 // failed to start, or killed
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+
+	// Log the full command line without the log prefix.
+	c.log.Logger.Log(silog.LevelTrace, strings.Join(c.cmd.Args, " "),
+		silog.NonZero("dir", c.cmd.Dir),
+		"duration", duration,
+		"exit", exitCode,
+	)
 }
 
 // Returns an io.Writer that will record an output stream for later use,
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/timing"
 	"go.abhg.dev/gs/internal/xec/xectest"
 	"go.uber.org/mock/gomock"
 )
@@ -346,6 +347,23 @@ func TestCmd_Scan(t *testing.T) {
 	assert.Equal(t, []string{"word1", "word2", "word3"}, words)
 }
 
+func TestCmd_Scan_stopEarly(t *testing.T) {
+	ctx := t.Context()
+	log := silog.Nop()
+
+	// "yes" prints forever, so only stopping early ends it.
+	cmd := Command(ctx, log, "yes").WithTimeout(time.Minute)
+
+	for line, err := range cmd.Scan(bufio.ScanLines) {
+		require.NoError(t, err)
+		assert.Equal(t, "y", string(line))
+		break
+	}
+
+	assert.Nil(t, cmd.timer, "timer must be stopped")
+	assert.Error(t, cmd.ctx.Err(), "context must be canceled")
+}
+
 func TestCmd_Scan_StartError(t *testing.T) {
 	ctx := t.Context()
 	log := silog.Nop()
@@ -466,6 +484,70 @@ func TestCmd_ContextCancellation(t *testing.T) {
 	assert.Error(t, cmd.Wait())
 }
 
+func TestCmd_WithTimeout(t *testing.T) {
+	// Subprocess mode: hang until killed.
+	if os.Getenv("INSIDE_TEST") == "1" {
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+
+	t.Run("Exceeded", func(t *testing.T) {
+		start := time.Now()
+		err := Command(t.Context(), silog.Nop(), _testBinary, "-test.run", "^TestCmd_WithTimeout$").
+			AppendEnv("INSIDE_TEST=1").
+			WithTimeout(100 * time.Millisecond).
+			Run()
+		require.Error(t, err)
+
+		var timeoutErr *TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, 100*time.Millisecond, timeoutErr.Timeout)
+		assert.ErrorContains(t, err, "timed out after 100ms")
+		assert.Less(t, time.Since(start), 30*time.Second)
+
+		// The caller's context is unaffected.
+		assert.NoError(t, t.Context().Err())
+	})
+
+	t.Run("Output", func(t *testing.T) {
+		_, err := Command(t.Context(), silog.Nop(), _testBinary, "-test.run", "^TestCmd_WithTimeout$").
+			AppendEnv("INSIDE_TEST=1").
+			WithTimeout(100 * time.Millisecond).
+			Output()
+		var timeoutErr *TimeoutError
+		assert.ErrorAs(t, err, &timeoutErr)
+	})
+
+	t.Run("NotExceeded", func(t *testing.T) {
+		out, err := Command(t.Context(), silog.Nop(), "echo", "hello").
+			WithTimeout(time.Minute).
+			Output()
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", string(out))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		// Failures unrelated to the timeout are reported as-is.
+		err := Command(t.Context(), silog.Nop(), "false").
+			WithTimeout(time.Minute).
+			Run()
+		require.Error(t, err)
+
+		var timeoutErr *TimeoutError
+		assert.False(t, errors.As(err, &timeoutErr))
+	})
+
+	t.Run("StartWait", func(t *testing.T) {
+		cmd := Command(t.Context(), silog.Nop(), _testBinary, "-test.run", "^TestCmd_WithTimeout$").
+			AppendEnv("INSIDE_TEST=1").
+			WithTimeout(100 * time.Millisecond)
+		require.NoError(t, cmd.Start())
+
+		var timeoutErr *TimeoutError
+		assert.ErrorAs(t, cmd.Wait(), &timeoutErr)
+	})
+}
+
 func TestCmd_WithExecer(t *testing.T) {
 	ctx := t.Context()
 	log := silog.Nop()
@@ -528,3 +610,50 @@ func TestCmd_WithExecer(t *testing.T) {
 		assert.Equal(t, expected, output)
 	})
 }
+
+func TestCmd_Trace(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("TraceLevel", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, &silog.Options{
+			Level: silog.LevelTrace,
+		})
+
+		require.NoError(t, Command(ctx, log, "true", "foo", "bar").Run())
+		assert.Regexp(t, `TRC true foo bar  duration=\S+ exit=0`, logBuffer.String())
+		logBuffer.Reset()
+
+		require.Error(t, Command(ctx, log, "sh", "-c", "exit 3").WithDir(t.TempDir()).Run())
+		assert.Regexp(t, `TRC sh -c exit 3  dir=\S+ duration=\S+ exit=3`, logBuffer.String())
+		logBuffer.Reset()
+
+		_, err := Command(ctx, log, "echo", "hello").Output()
+		require.NoError(t, err)
+		assert.Regexp(t, `TRC echo hello  duration=\S+ exit=0`, logBuffer.String())
+	})
+
+	t.Run("DebugLevel", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		log := silog.New(&logBuffer, &silog.Options{
+			Level: silog.LevelDebug,
+		})
+
+		require.NoError(t, Command(ctx, log, "true").Run())
+		assert.NotContains(t, logBuffer.String(), "TRC")
+	})
+}
+
+func TestCmd_WithTiming(t *testing.T) {
+	rec := timing.NewRecorder()
+	ctx := timing.WithRecorder(t.Context(), rec)
+
+	require.NoError(t, Command(ctx, silog.Nop(), "true").WithTiming(timing.Git).Run())
+	_, err := Command(ctx, silog.Nop(), "echo").WithTiming(timing.Git).Output()
+	require.NoError(t, err)
+
+	// Commands without a category are not recorded.
+	require.NoError(t, Command(ctx, silog.Nop(), "true").Run())
+
+	assert.Equal(t, 2, rec.Stat(timing.Git).Count)
+}
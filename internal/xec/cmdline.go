@@ -0,0 +1,75 @@
+package xec
+
+import "strings"
+
+// splitCommandLine splits a command line into arguments
+// following the rules used by Windows programs
+// (CommandLineToArgvW and the Microsoft C runtime):
+//
+//   - arguments are separated by spaces or tabs
+//   - double quotes group an argument that contains spaces;
+//     "" inside a quoted argument is a literal quote
+//   - 2n backslashes followed by a quote become n backslashes,
+//     and the quote begins or ends a quoted section
+//   - 2n+1 backslashes followed by a quote become n backslashes
+//     and a literal quote
+//   - backslashes not followed by a quote are literal
+//
+// It's defined for all platforms so that it can be tested everywhere.
+func splitCommandLine(cmdline string) []string {
+	var (
+		args    []string
+		arg     strings.Builder
+		inArg   bool // whether arg has been started
+		inQuote bool
+	)
+	for i := 0; i < len(cmdline); i++ {
+		c := cmdline[i]
+		switch {
+		case c == '\\':
+			n := 1
+			for i+n < len(cmdline) && cmdline[i+n] == '\\' {
+				n++
+			}
+			inArg = true
+			if i+n < len(cmdline) && cmdline[i+n] == '"' {
+				arg.WriteString(strings.Repeat(`\`, n/2))
+				if n%2 == 1 {
+					// Escaped quote.
+					arg.WriteByte('"')
+					i += n // skip past the quote
+				} else {
+					// Quote is handled by the next iteration.
+					i += n - 1
+				}
+			} else {
+				arg.WriteString(strings.Repeat(`\`, n))
+				i += n - 1
+			}
+
+		case c == '"':
+			inArg = true
+			if inQuote && i+1 < len(cmdline) && cmdline[i+1] == '"' {
+				arg.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+
+		case (c == ' ' || c == '\t') && !inQuote:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+
+		default:
+			inArg = true
+			arg.WriteByte(c)
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
@@ -0,0 +1,75 @@
+package xec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want []string
+	}{
+		{name: "Empty", give: "", want: nil},
+		{name: "Blank", give: " \t ", want: nil},
+		{name: "Single", give: "notepad", want: []string{"notepad"}},
+		{
+			name: "Args",
+			give: "code  --wait\t--new-window",
+			want: []string{"code", "--wait", "--new-window"},
+		},
+		{
+			name: "QuotedPath",
+			give: `"C:\Program Files\Notepad++\notepad++.exe" -multiInst -nosession`,
+			want: []string{`C:\Program Files\Notepad++\notepad++.exe`, "-multiInst", "-nosession"},
+		},
+		{
+			name: "QuotedMidArgument",
+			give: `--dir="C:\Program Files"\x`,
+			want: []string{`--dir=C:\Program Files\x`},
+		},
+		{
+			name: "EmptyQuoted",
+			give: `a "" b`,
+			want: []string{"a", "", "b"},
+		},
+		{
+			name: "DoubledQuoteInQuotes",
+			give: `"a ""b"" c"`,
+			want: []string{`a "b" c`},
+		},
+		{
+			name: "EscapedQuote",
+			give: `a\"b`,
+			want: []string{`a"b`},
+		},
+		{
+			name: "EvenBackslashesBeforeQuote",
+			give: `"a\\" b`,
+			want: []string{`a\`, "b"},
+		},
+		{
+			name: "OddBackslashesBeforeQuote",
+			give: `"a\\\" b"`,
+			want: []string{`a\" b`},
+		},
+		{
+			name: "TrailingBackslashes",
+			give: `C:\dir\ x`,
+			want: []string{`C:\dir\`, "x"},
+		},
+		{
+			name: "UnterminatedQuote",
+			give: `"a b`,
+			want: []string{"a b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitCommandLine(tt.give))
+		})
+	}
+}
@@ -13,11 +13,7 @@ func EditCommand(editCmd string, args ...string) *exec.Cmd {
 	if exe, err := LookPath(editCmd); err == nil {
 		cmd = exec.Command(exe, args...)
 	} else {
-		// We'll run:
-		//   sh -c 'EDITOR "$@"' -- "$1" "$2" ...
-		// The shell will take care of quoting issues.
-		args = append([]string{"-c", editCmd + ` "$@"`, "--"}, args...)
-		cmd = exec.Command("sh", args...)
+		cmd = shellEditCommand(editCmd, args)
 	}
 	cmd.Env = append(os.Environ(), _gitSpiceEnv)
 	cmd.Stdout = os.Stdout
@@ -25,3 +21,13 @@ func EditCommand(editCmd string, args ...string) *exec.Cmd {
 	cmd.Stdin = os.Stdin
 	return cmd
 }
+
+// shEditCommand runs an editor command with arguments through sh:
+//
+//	sh -c 'EDITOR "$@"' -- "$1" "$2" ...
+//
+// The shell will take care of quoting issues.
+func shEditCommand(sh, editCmd string, args []string) *exec.Cmd {
+	args = append([]string{"-c", editCmd + ` "$@"`, "--"}, args...)
+	return exec.Command(sh, args...)
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package xec
+
+import "os/exec"
+
+func shellEditCommand(editCmd string, args []string) *exec.Cmd {
+	return shEditCommand("sh", editCmd, args)
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package xec
+
+import "os/exec"
+
+func shellEditCommand(editCmd string, args []string) *exec.Cmd {
+	// Git for Windows runs the editor with its bundled sh.
+	// Do the same if it's available so that editor commands
+	// that work with Git also work with git-spice.
+	if sh, err := LookPath("sh"); err == nil {
+		return shEditCommand(sh, editCmd, args)
+	}
+
+	// Otherwise, split the command with the same rules
+	// as Windows programs use to parse their command lines,
+	// so that quoted paths like
+	//
+	//	"C:\Program Files\Notepad++\notepad++.exe" -multiInst
+	//
+	// work as expected.
+	editArgs := splitCommandLine(editCmd)
+	if len(editArgs) == 0 {
+		// exec.Command will report the missing executable
+		// when the command is run.
+		return exec.Command(editCmd, args...)
+	}
+	return exec.Command(editArgs[0], append(editArgs[1:], args...)...)
+}
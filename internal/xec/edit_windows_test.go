@@ -0,0 +1,37 @@
+//go:build windows
+
+package xec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditCommand_windowsWithoutShell(t *testing.T) {
+	// Copy the test binary to a directory with a space in its name
+	// to verify that quoted paths are handled.
+	dir := filepath.Join(t.TempDir(), "My Editor")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	editor := filepath.Join(dir, "editor.exe")
+	bs, err := os.ReadFile(_testBinary)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(editor, bs, 0o755))
+
+	// Hide sh so that the command line is split by us.
+	t.Setenv("PATH", dir)
+
+	tmpFile := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(tmpFile, []byte(""), 0o644))
+
+	cmd := EditCommand(`"`+editor+`" -test.run "^TestEditCommand_setsGitSpiceExec$"`, tmpFile)
+	cmd.Env = append(cmd.Env, "INSIDE_TEST=1")
+	require.NoError(t, cmd.Run())
+
+	body, err := os.ReadFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, "GIT_SPICE=1", string(body))
+}
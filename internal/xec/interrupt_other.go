@@ -0,0 +1,13 @@
+//go:build !unix
+
+package xec
+
+import "os"
+
+// interrupt asks a process to stop.
+//
+// Processes can't be sent SIGTERM on this platform,
+// so the process is killed right away.
+func interrupt(p *os.Process) error {
+	return p.Kill()
+}
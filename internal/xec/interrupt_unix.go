@@ -0,0 +1,13 @@
+//go:build unix
+
+package xec
+
+import (
+	"os"
+	"syscall"
+)
+
+// interrupt asks a process to stop.
+func interrupt(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
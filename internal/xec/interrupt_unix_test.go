@@ -0,0 +1,84 @@
+//go:build unix
+
+package xec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+func TestCmd_cancelSendsSIGTERM(t *testing.T) {
+	// Subprocess mode: record SIGTERM and exit.
+	if os.Getenv("INSIDE_TEST") == "1" {
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGTERM)
+		fmt.Println("ready")
+
+		<-sigc
+		_ = os.WriteFile(os.Getenv("SIGNAL_FILE"), []byte("SIGTERM"), 0o644)
+		os.Exit(0)
+	}
+
+	signalFile := filepath.Join(t.TempDir(), "signal")
+	ctx, cancel := context.WithCancel(t.Context())
+	cmd := Command(ctx, silog.Nop(), _testBinary, "-test.run", "^"+t.Name()+"$").
+		AppendEnv("INSIDE_TEST=1", "SIGNAL_FILE="+signalFile)
+	waitReady(t, cmd)
+
+	cancel()
+	assert.Error(t, cmd.Wait())
+
+	got, err := os.ReadFile(signalFile)
+	require.NoError(t, err)
+	assert.Equal(t, "SIGTERM", string(got))
+}
+
+func TestCmd_killedAfterGracePeriod(t *testing.T) {
+	// Subprocess mode: ignore SIGTERM and hang.
+	if os.Getenv("INSIDE_TEST") == "1" {
+		signal.Ignore(syscall.SIGTERM)
+		fmt.Println("ready")
+
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+
+	cmd := Command(t.Context(), silog.Nop(), _testBinary, "-test.run", "^"+t.Name()+"$").
+		AppendEnv("INSIDE_TEST=1").
+		WithTimeout(100 * time.Millisecond).
+		WithGracePeriod(100 * time.Millisecond)
+	waitReady(t, cmd)
+
+	start := time.Now()
+	err := cmd.Wait()
+
+	var timeoutErr *TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Less(t, time.Since(start), 30*time.Second)
+}
+
+// waitReady starts cmd and waits for it to print "ready",
+// indicating that its signal handlers are installed.
+func waitReady(t *testing.T, cmd *Cmd) {
+	t.Helper()
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+
+	buf := make([]byte, len("ready\n"))
+	_, err = io.ReadFull(stdout, buf)
+	require.NoError(t, err)
+	require.Equal(t, "ready\n", string(buf))
+}
@@ -30,6 +30,11 @@ type logCmd struct {
 }
 
 func (*logCmd) AfterApply(kctx *kong.Context) error {
+	return bindListHandler(kctx)
+}
+
+// bindListHandler makes a ListHandler available to the command.
+func bindListHandler(kctx *kong.Context) error {
 	return kctx.BindToProvider(func(
 		log *silog.Logger,
 		repo *git.Repository,
@@ -63,9 +68,13 @@ type branchLogCmd struct {
 	ChangeFormatShort *changeFormat `config:"logShort.crFormat" help:"Format for displaying change request information in short log. One of 'id' or 'url', defaults to crFormat." hidden:""`
 	ChangeFormatLong  *changeFormat `config:"logLong.crFormat" help:"Format for displaying change request information in long log. One of 'id' or 'url', defaults to crFormat." hidden:""`
 
+	URLs bool `name:"urls" released:"unreleased" help:"Show URLs of Change Requests instead of their IDs"`
+
 	CRStatus bool `name:"cr-status" short:"S" config:"log.crStatus" help:"Request and include information about the Change Request" default:"false" negatable:""`
 	// TODO: When needed, add a crStatusFormat config to control presentation.
 
+	Stat bool `name:"stat" config:"log.stat" released:"unreleased" help:"Request and include the size of the Change Request" default:"false" negatable:""`
+
 	PushStatusFormat pushStatusFormat `config:"log.pushStatusFormat" help:"Show indicator for branches that are out of sync with their remotes. One of 'true', 'false' and 'aheadbehind'." hidden:"" default:"true"`
 
 	JSON bool `name:"json" released:"v0.18.0" help:"Write to stdout as a stream of JSON objects in an unspecified order"`
@@ -110,6 +119,9 @@ func (cmd *branchLogCmd) run(
 		} else if !opts.Commits && cmd.ChangeFormatShort != nil {
 			changeFormat = *cmd.ChangeFormatShort
 		}
+		if cmd.URLs {
+			changeFormat = changeFormatURL
+		}
 
 		wantChangeURL = changeFormat == changeFormatURL
 		wantPushStatus = cmd.PushStatusFormat.Enabled()
@@ -119,6 +131,8 @@ func (cmd *branchLogCmd) run(
 			Stderr:           kctx.Stderr,
 			ChangeFormat:     changeFormat,
 			ShowCRStatus:     wantChangeState,
+			ShowCRDiffStat:   cmd.Stat,
+			ShowNotes:        opts.Commits,
 			PushStatusFormat: cmd.PushStatusFormat,
 			CurrentWorktree:  wt.RootDir(),
 		}
@@ -134,6 +148,9 @@ func (cmd *branchLogCmd) run(
 	if wantChangeState {
 		req.Include |= list.IncludeChangeState
 	}
+	if cmd.Stat {
+		req.Include |= list.IncludeChangeDiffStat
+	}
 	if opts.Commits {
 		req.Include |= list.IncludeCommits
 	}
@@ -157,6 +174,8 @@ type graphLogPresenter struct {
 	Stderr           io.Writer        // required
 	ChangeFormat     changeFormat     // required
 	ShowCRStatus     bool             // required
+	ShowCRDiffStat   bool             // required
+	ShowNotes        bool             // required
 	PushStatusFormat pushStatusFormat // required
 	CurrentWorktree  string           // required
 }
@@ -174,6 +193,7 @@ func (p *graphLogPresenter) Present(res *list.BranchesResponse, currentBranch st
 			Branch:       b.Name,
 			Worktree:     b.Worktree,
 			NeedsRestack: b.NeedsRestack,
+			Archived:     b.Archived,
 			Aboves:       b.Aboves,
 			Highlighted:  b.Name == currentBranch,
 		}
@@ -182,7 +202,7 @@ func (p *graphLogPresenter) Present(res *list.BranchesResponse, currentBranch st
 		if b.ChangeID != nil {
 			switch p.ChangeFormat {
 			case changeFormatID:
-				item.ChangeID = b.ChangeID.String()
+				item.ChangeID = b.ChangeDisplayID
 			case changeFormatURL:
 				item.ChangeID = b.ChangeURL
 			}
@@ -191,6 +211,10 @@ func (p *graphLogPresenter) Present(res *list.BranchesResponse, currentBranch st
 			if p.ShowCRStatus && b.ChangeState != 0 {
 				item.ChangeState = &b.ChangeState
 			}
+
+			if p.ShowCRDiffStat {
+				item.ChangeDiffStat = b.ChangeDiffStat
+			}
 		}
 
 		if s := b.PushStatus; s != nil {
@@ -201,6 +225,10 @@ func (p *graphLogPresenter) Present(res *list.BranchesResponse, currentBranch st
 			}
 		}
 
+		if p.ShowNotes {
+			item.Note = b.Note
+		}
+
 		if len(b.Commits) > 0 {
 			item.Commits = make([]commit.Summary, len(b.Commits))
 			for j, c := range b.Commits {
@@ -307,9 +335,20 @@ func (p *jsonLogPresenter) Present(res *list.BranchesResponse, currentBranch str
 					jc.Status = "merged"
 				}
 			}
+			if stat := branch.ChangeDiffStat; stat != nil {
+				jc.DiffStat = &jsonLogDiffStat{
+					Additions:    stat.Additions,
+					Deletions:    stat.Deletions,
+					ChangedFiles: stat.ChangedFiles,
+					Incomplete:   stat.Incomplete,
+				}
+			}
 			logBranch.Change = jc
 		}
 
+		logBranch.Note = branch.Note
+		logBranch.Archived = branch.Archived
+
 		if status := branch.PushStatus; status != nil {
 			logBranch.Push = &jsonLogPushStatus{
 				Ahead:     status.Ahead,
@@ -361,6 +400,17 @@ type jsonLogBranch struct {
 	// from git-spice's perspective.
 	Push *jsonLogPushStatus `json:"push,omitempty"`
 
+	// Note is the free-form note attached to this branch
+	// with 'git-spice branch note edit'.
+	// This is unset if the branch has no note.
+	Note string `json:"note,omitempty"`
+
+	// Archived is true if this branch was archived
+	// with 'git-spice branch archive'.
+	// Archived branches are only listed with --archived,
+	// or if they're needed to show the current branch.
+	Archived bool `json:"archived,omitempty"`
+
 	// Worktree is the absolute path to the worktree
 	// where this branch is checked out,
 	// if it's not the current branch.
@@ -404,6 +454,26 @@ type jsonLogChange struct {
 
 	// Status is the current state of the change (open|closed|merged).
 	Status string `json:"status,omitempty"`
+
+	// DiffStat is the size of the change.
+	// This is unset unless invoked with --stat.
+	DiffStat *jsonLogDiffStat `json:"diffstat,omitempty"`
+}
+
+type jsonLogDiffStat struct {
+	// Additions is the number of lines added by the change.
+	Additions int `json:"additions"`
+
+	// Deletions is the number of lines deleted by the change.
+	Deletions int `json:"deletions"`
+
+	// ChangedFiles is the number of files changed by the change.
+	ChangedFiles int `json:"changedFiles"`
+
+	// Incomplete is true if the forge did not report
+	// the lines changed in some files.
+	// Additions and Deletions count only the other files.
+	Incomplete bool `json:"incomplete,omitempty"`
 }
 
 type jsonLogPushStatus struct {
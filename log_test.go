@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/handler/list"
 )
@@ -260,6 +261,39 @@ func TestJSONLogPresenter_Present(t *testing.T) {
 `, buf.String())
 	})
 
+	t.Run("ChangeDiffStat", func(t *testing.T) {
+		var buf bytes.Buffer
+		presenter := &jsonLogPresenter{
+			Stdout:          &buf,
+			CurrentWorktree: "/repo",
+		}
+
+		res := &list.BranchesResponse{
+			Branches: []*list.BranchItem{
+				{Name: "main"},
+				{
+					Name:      "feature",
+					Base:      "main",
+					ChangeID:  &mockChangeID{id: "123"},
+					ChangeURL: "https://github.com/owner/repo/pull/123",
+					ChangeDiffStat: &forge.DiffStat{
+						Additions:    12,
+						Deletions:    3,
+						ChangedFiles: 2,
+					},
+				},
+			},
+			TrunkIdx: 0,
+		}
+
+		err := presenter.Present(res, "feature")
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"name":"main"}
+{"name":"feature","current":true,"down":{"name":"main"},"change":{"id":"123","url":"https://github.com/owner/repo/pull/123","diffstat":{"additions":12,"deletions":3,"changedFiles":2}}}
+`, buf.String())
+	})
+
 	t.Run("WorktreeFiltering", func(t *testing.T) {
 		var buf bytes.Buffer
 		presenter := &jsonLogPresenter{
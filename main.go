@@ -11,6 +11,7 @@ import (
 	"strconv"
 
 	"github.com/alecthomas/kong"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-isatty"
 	"go.abhg.dev/gs/internal/browser"
 	"go.abhg.dev/gs/internal/cli/experiment"
@@ -23,18 +24,25 @@ import (
 	"go.abhg.dev/gs/internal/handler/autostash"
 	"go.abhg.dev/gs/internal/handler/checkout"
 	"go.abhg.dev/gs/internal/handler/cherrypick"
+	"go.abhg.dev/gs/internal/handler/comments"
+	"go.abhg.dev/gs/internal/handler/conflict"
 	"go.abhg.dev/gs/internal/handler/delete"
+	"go.abhg.dev/gs/internal/handler/migrate"
+	"go.abhg.dev/gs/internal/handler/pull"
+	"go.abhg.dev/gs/internal/handler/refresh"
 	"go.abhg.dev/gs/internal/handler/restack"
 	"go.abhg.dev/gs/internal/handler/split"
 	"go.abhg.dev/gs/internal/handler/squash"
 	"go.abhg.dev/gs/internal/handler/submit"
 	"go.abhg.dev/gs/internal/handler/sync"
 	"go.abhg.dev/gs/internal/handler/track"
+	"go.abhg.dev/gs/internal/netcheck"
 	"go.abhg.dev/gs/internal/secret"
 	"go.abhg.dev/gs/internal/sigstack"
 	"go.abhg.dev/gs/internal/silog"
 	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/timing"
 	"go.abhg.dev/gs/internal/ui"
 	"go.abhg.dev/gs/internal/xec"
 	"go.abhg.dev/komplete"
@@ -59,11 +67,26 @@ var (
 
 var errNoPrompt = ui.ErrPrompt
 
-var _highlightStyle = ui.NewStyle().Foreground(ui.Cyan).Bold(true)
+var _highlightStyle lipgloss.Style
+
+func init() {
+	ui.RegisterStyles(func() {
+		_highlightStyle = ui.NewStyle().Foreground(ui.Cyan).Bold(true)
+	})
+}
 
 func main() {
-	logger := silog.New(os.Stderr, &silog.Options{
+	// Log output is remembered and saved when the command exits
+	// so that 'gs bug-report' can include it.
+	cmdLog := &commandLog{w: os.Stderr, sanitize: newBugReportSanitizer()}
+	var command string // set once the command line is parsed
+	var logger *silog.Logger
+	logger = silog.New(cmdLog, &silog.Options{
 		Level: silog.LevelInfo,
+		OnFatal: func() {
+			saveCommandLog(logger, cmdLog, command, true)
+			os.Exit(1)
+		},
 	})
 
 	// Register supported forges.
@@ -75,7 +98,11 @@ func main() {
 		forges.Register(f)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	// Time spent on different operations is recorded
+	// to report with --profile.
+	timings := timing.NewRecorder()
+
+	ctx, cancel := context.WithCancel(timing.WithRecorder(context.Background(), timings))
 	defer cancel()
 
 	var sigStack sigstack.Stack
@@ -85,7 +112,7 @@ func main() {
 		select {
 		case <-sigc:
 			sigStack.Stop(sigc)
-			logger.Info("Cleaning up. Press Ctrl-C again to exit immediately.")
+			logger.Info("Interrupted: finishing the current step. Press Ctrl-C again to exit immediately.")
 			cancel()
 
 		case <-ctx.Done():
@@ -105,7 +132,7 @@ func main() {
 
 	spiceConfig, err := spice.LoadConfig(
 		ctx,
-		git.NewConfig(git.ConfigOptions{Log: logger}),
+		newLayeredConfig(ctx, logger),
 		spice.ConfigOptions{
 			Log: logger,
 		},
@@ -123,6 +150,7 @@ func main() {
 	}
 
 	var cmd mainCmd
+	lock := &repoLock{Log: logger}
 
 	// Forges may register additional command line flags
 	// by implementing CLIPlugin.
@@ -137,13 +165,12 @@ func main() {
 		kong.Name(cmdName),
 		kong.Description("git-spice is a command line tool for stacking Git branches."),
 		kong.Resolvers(spiceConfig),
-		kong.Bind(logger, &forges, &sigStack),
+		kong.Bind(logger, &forges, &sigStack, spiceConfig, lock),
 		kong.BindTo(ctx, (*context.Context)(nil)),
 		kong.BindTo(spiceConfig, (*experiment.Enabler)(nil)),
 		kong.BindTo(secretStash, (*secret.Stash)(nil)),
 		kong.Vars{
-			// Default to prompting only when the terminal is interactive.
-			"defaultPrompt": strconv.FormatBool(isatty.IsTerminal(os.Stdin.Fd())),
+			"defaultPrompt": strconv.FormatBool(defaultPrompt()),
 		},
 		kong.UsageOnError(),
 		kong.Help(helpPrinter),
@@ -169,6 +196,8 @@ func main() {
 		komplete.WithPredictor("remotes", komplete.PredictFunc(predictRemotes)),
 		komplete.WithPredictor("dirs", komplete.PredictFunc(predictDirs)),
 		komplete.WithPredictor("forges", komplete.PredictFunc(predictForges(&forges))),
+		komplete.WithPredictor("changes", komplete.PredictFunc(predictChanges(&forges))),
+		komplete.WithPredictor("configKeys", komplete.PredictFunc(predictConfigKeys(parser.Model))),
 	)
 
 	args := os.Args[1:]
@@ -225,22 +254,48 @@ func main() {
 
 	kctx, err := parser.Parse(args)
 	if err != nil {
-		logger.Fatalf("%v: %v", cmdName, err)
+		fatalError(logger, cmdName, err)
 	}
+	command = kctx.Command()
 
 	if err := cmd.Profile.Start(); err != nil {
 		logger.Error("Error creating trace file", "error", err)
 	}
 
-	if err := kctx.Run(builtinShorthands); err != nil {
-		logger.Fatalf("%v: %v", cmdName, err)
+	err = kctx.Run(builtinShorthands)
+	lock.Release()
+	if cmd.Globals.Profile {
+		if err := timings.WriteReport(os.Stderr); err != nil {
+			logger.Error("Error writing timing report", "error", err)
+		}
+	}
+	if err != nil {
+		fatalError(logger, cmdName, err)
+	}
+
+	if cmd.Globals.UpdateCheck && shouldCheckForUpdate(kctx.Command()) {
+		checkForUpdate(ctx, logger)
 	}
+	saveCommandLog(logger, cmdLog, command, false)
 
 	if err := cmd.Profile.Stop(); err != nil {
 		logger.Error("Error closing trace file", "error", err)
 	}
 }
 
+// defaultPrompt reports whether we should prompt for missing information
+// if neither --prompt nor --no-prompt is specified.
+//
+// We prompt only when the terminal is interactive
+// and GIT_SPICE_NO_PROMPT is not set to a true value,
+// allowing scripts and CI to opt out even with a terminal attached.
+func defaultPrompt() bool {
+	if noPrompt, err := strconv.ParseBool(os.Getenv("GIT_SPICE_NO_PROMPT")); err == nil && noPrompt {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdin.Fd())
+}
+
 type mainCmd struct {
 	kong.Plugins
 	experiment.Check
@@ -251,16 +306,56 @@ type mainCmd struct {
 	Globals struct {
 		// Flags with built-in side effects.
 		Version versionFlag        `help:"Print version information and quit"`
-		Verbose bool               `short:"v" help:"Enable verbose output" env:"GIT_SPICE_VERBOSE"`
+		Verbose verboseFlag        `short:"v" help:"Enable verbose output" env:"GIT_SPICE_VERBOSE"`
 		Dir     kong.ChangeDirFlag `short:"C" placeholder:"DIR" help:"Change to DIR before doing anything" predictor:"dirs"`
-		Prompt  bool               `name:"prompt" negatable:"" default:"${defaultPrompt}" help:"Whether to prompt for missing information"`
+		Prompt  bool               `name:"prompt" negatable:"" default:"${defaultPrompt}" help:"Whether to prompt for missing information. Disabled by default if GIT_SPICE_NO_PROMPT is true."`
+
+		// PlainPrompt is for screen readers and other assistive technology.
+		PlainPrompt bool `name:"prompt-plain" hidden:"" released:"unreleased" config:"prompt.plain" env:"GIT_SPICE_PLAIN_PROMPT" help:"Prompt one line at a time with numbered choices instead of interactive widgets."`
+
+		// Pre-supplied answers to prompts.
+		Yes         bool     `name:"yes" help:"Accept all confirmation prompts"`
+		Answer      []string `name:"answer" placeholder:"TITLE=VALUE" sep:"none" help:"Answer the prompt with the given title. May be repeated."`
+		AnswersFile string   `name:"answers" placeholder:"FILE" help:"Read answers to prompts from a JSON file mapping titles to values. Use '-' for stdin."`
+
+		// Profile reports where time was spent when the command finishes.
+		// Unlike ProfileFlags, this is available in all builds.
+		Profile bool `name:"profile" hidden:"" released:"unreleased" help:"Print a breakdown of where time was spent when the command finishes."`
+
+		// LogFormat is for CI and other tools that parse our output.
+		LogFormat string `name:"log-format" hidden:"" released:"unreleased" config:"logFormat" env:"GIT_SPICE_LOG_FORMAT" default:"text" enum:"text,json" help:"Format of log messages. One of 'text' and 'json'."`
+
+		// GPGSign re-signs commits rewritten by restacking,
+		// which would otherwise lose their signatures.
+		GPGSign bool `name:"gpg-sign" hidden:"" released:"unreleased" config:"restack.sign" help:"Sign commits that are rewritten when branches are restacked or moved."`
+
+		// ProtectedBranches guards shared branches
+		// that were tracked by mistake.
+		ProtectedBranches []string `name:"protected-branch" hidden:"" released:"unreleased" config:"protectedBranches" placeholder:"GLOB" help:"Glob patterns for branches that must not be restacked, renamed, deleted, or folded. May be repeated."`
+
+		// ForceUnlock recovers from a lock left behind
+		// by a git-spice command that didn't exit cleanly.
+		ForceUnlock bool `name:"force-unlock" hidden:"" released:"unreleased" help:"Remove the lock held by another git-spice command in this repository. Use only if that command is no longer running."`
+
+		// UpdateCheck lets users opt out of the weekly check for new releases.
+		UpdateCheck bool `name:"update-check" hidden:"" released:"unreleased" config:"updateCheck" env:"GIT_SPICE_UPDATE_CHECK" default:"true" negatable:"" help:"Check for new releases of git-spice once a week."`
+
+		Theme themeOptions `embed:""`
 	} `embed:"" group:"globals"`
 
-	Shell shellCmd `cmd:"" group:"Shell"`
-	Auth  authCmd  `cmd:"" group:"Authentication"`
+	Shell  shellCmd  `cmd:"" group:"Shell"`
+	Prompt promptCmd `cmd:"" group:"Shell" released:"unreleased" help:"Print stack information for shell prompts"`
+	RPC    rpcCmd    `cmd:"" name:"rpc" group:"Shell" released:"unreleased" help:"Serve JSON-RPC requests for editor integrations"`
+	Auth   authCmd   `cmd:"" group:"Authentication"`
+
+	Config     configCmd     `cmd:"" group:"Configuration"`
+	Experiment experimentCmd `cmd:"" group:"Configuration"`
 
-	Repo repoCmd `cmd:"" aliases:"r" group:"Repository"`
-	Log  logCmd  `cmd:"" aliases:"l" group:"Log"`
+	Repo    repoCmd    `cmd:"" aliases:"r" group:"Repository"`
+	Guard   guardCmd   `cmd:"" group:"Repository" released:"unreleased" help:"Check that stacked Change Requests are merged in order"`
+	Migrate migrateCmd `cmd:"" group:"Repository" released:"unreleased" help:"Track branches managed by another stacking tool"`
+	Log     logCmd     `cmd:"" aliases:"l" group:"Log"`
+	Status  statusCmd  `cmd:"" aliases:"st" group:"Log" released:"unreleased" help:"Show branches that need attention"`
 
 	Stack     stackCmd     `cmd:"" aliases:"s" group:"Stack"`
 	Upstack   upstackCmd   `cmd:"" aliases:"us" group:"Stack"`
@@ -270,6 +365,7 @@ type mainCmd struct {
 	Commit commitCmd `cmd:"" aliases:"c" group:"Commit"`
 
 	Rebase rebaseCmd `cmd:"" aliases:"rb" group:"Rebase"`
+	Stash  stashCmd  `cmd:"" group:"Stash"`
 
 	// Navigation
 	Up     upCmd     `cmd:"" aliases:"u" group:"Navigation" help:"Move up one branch"`
@@ -278,7 +374,9 @@ type mainCmd struct {
 	Bottom bottomCmd `cmd:"" aliases:"D" group:"Navigation" help:"Move to the bottom of the stack"`
 	Trunk  trunkCmd  `cmd:"" group:"Navigation" help:"Move to the trunk branch"`
 
-	Version versionCmd `cmd:"" help:"Print version information and quit"`
+	Version   versionCmd   `cmd:"" help:"Print version information and quit"`
+	Upgrade   upgradeCmd   `cmd:"" released:"unreleased" help:"Upgrade git-spice to the latest release"`
+	BugReport bugReportCmd `cmd:"" name:"bug-report" released:"unreleased" help:"Generate a report to attach to a git-spice issue"`
 
 	Internal internalCmd `cmd:"" hidden:"" help:"For internal use only."`
 
@@ -286,15 +384,46 @@ type mainCmd struct {
 	DumpMD dumpMarkdownCmd `name:"dumpmd" hidden:"" cmd:"" help:"Dump a Markdown reference to stdout and quit"`
 }
 
-func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *silog.Logger) error {
-	if cmd.Globals.Verbose {
-		logger.SetLevel(silog.LevelDebug)
+func (cmd *mainCmd) AfterApply(
+	ctx context.Context,
+	kctx *kong.Context,
+	logger *silog.Logger,
+	spiceConfig *spice.Config,
+	lock *repoLock,
+) error {
+	lock.Force = cmd.Globals.ForceUnlock
+
+	if lvl := cmd.Globals.Verbose.Level(); lvl < logger.Level() {
+		logger.SetLevel(lvl)
+	}
+	if cmd.Globals.LogFormat == "json" {
+		logger.SetFormat(silog.FormatJSON)
+	}
+
+	if err := cmd.Globals.Theme.apply(logger); err != nil {
+		return fmt.Errorf("configure theme: %w", err)
 	}
 
-	view, err := _buildView(os.Stdin, kctx.Stderr, cmd.Globals.Prompt)
+	interactive := cmd.Globals.Prompt
+	if kctx.Command() == "rpc" {
+		// rpc reads requests from stdin, so it must never prompt.
+		interactive = false
+	}
+
+	view, err := _buildView(os.Stdin, kctx.Stderr, interactive, cmd.Globals.PlainPrompt)
 	if err != nil {
 		return fmt.Errorf("build view: %w", err)
 	}
+
+	if iv, ok := view.(ui.InteractiveView); ok {
+		view = &timedView{InteractiveView: iv, rec: timing.FromContext(ctx)}
+	}
+
+	answers, err := cmd.answers()
+	if err != nil {
+		return err
+	}
+	view = ui.NewAnswerView(view, answers)
 	kctx.BindTo(view, (*ui.View)(nil))
 
 	// TODO: bind interfaces, not values
@@ -312,8 +441,19 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 		kctx.BindSingletonProvider(func(wt *git.Worktree) (*git.Repository, error) {
 			return wt.Repository(), nil
 		}),
-		kctx.BindSingletonProvider(func(repo *git.Repository, wt *git.Worktree) (*state.Store, error) {
-			return ensureStore(ctx, repo, wt, logger, view)
+		kctx.BindSingletonProvider(func(
+			repo *git.Repository,
+			wt *git.Worktree,
+			forges *forge.Registry,
+		) (*state.Store, error) {
+			// Commands that can modify the store
+			// hold the lock until they exit.
+			if !isReadOnlyCmd(kctx) {
+				if err := lock.Acquire(repo); err != nil {
+					return nil, err
+				}
+			}
+			return ensureStore(ctx, repo, wt, logger, view, forges, spiceConfig)
 		}),
 		kctx.BindSingletonProvider(func(
 			repo *git.Repository,
@@ -321,7 +461,23 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 			store *state.Store,
 			forges *forge.Registry,
 		) (*spice.Service, error) {
-			return spice.NewService(repo, wt, store, forges, logger), nil
+			svc := spice.NewService(repo, wt, store, forges, logger)
+			svc.SetSignCommits(cmd.Globals.GPGSign)
+			if err := svc.SetProtectedBranches(cmd.Globals.ProtectedBranches); err != nil {
+				return nil, fmt.Errorf("spice.protectedBranches: %w", err)
+			}
+			return svc, nil
+		}),
+		kctx.BindSingletonProvider(func(
+			repo *git.Repository,
+			svc *spice.Service,
+			forges *forge.Registry,
+		) (ChangeBranchResolver, error) {
+			return &changeBranchResolver{
+				Repository: repo,
+				Service:    svc,
+				Forges:     forges,
+			}, nil
 		}),
 		kctx.BindSingletonProvider(func(
 			log *silog.Logger,
@@ -340,6 +496,8 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 			repo *git.Repository,
 			store *state.Store,
 			svc *spice.Service,
+			secretStash secret.Stash,
+			forges *forge.Registry,
 		) (TrackHandler, error) {
 			return &track.Handler{
 				Log:        log,
@@ -347,6 +505,34 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 				Repository: repo,
 				Store:      store,
 				Service:    svc,
+				OpenRemoteRepository: func(ctx context.Context) (forge.Repository, error) {
+					remote, err := ensureRemote(ctx, repo, store, log, view)
+					if err != nil {
+						return nil, err
+					}
+					return openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+				},
+			}, nil
+		}),
+		kctx.BindSingletonProvider(func(
+			log *silog.Logger,
+			view ui.View,
+			repo *git.Repository,
+			store *state.Store,
+			secretStash secret.Stash,
+			forges *forge.Registry,
+		) (MigrateHandler, error) {
+			return &migrate.Handler{
+				Log:        log,
+				Repository: repo,
+				Store:      store,
+				OpenRemoteRepository: func(ctx context.Context) (forge.Repository, error) {
+					remote, err := ensureRemote(ctx, repo, store, log, view)
+					if err != nil {
+						return nil, err
+					}
+					return openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+				},
 			}, nil
 		}),
 		kctx.BindSingletonProvider(func(
@@ -356,6 +542,8 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 			wt *git.Worktree,
 			svc *spice.Service,
 			trackHandler TrackHandler,
+			secretStash secret.Stash,
+			forges *forge.Registry,
 		) (CheckoutHandler, error) {
 			return &checkout.Handler{
 				Stdout:     kctx.Stdout,
@@ -365,6 +553,37 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 				Worktree:   wt,
 				Track:      trackHandler,
 				Service:    svc,
+				OpenRemoteRepository: func(ctx context.Context) (forge.Repository, error) {
+					remote, err := ensureRemote(ctx, repo, store, log, view)
+					if err != nil {
+						return nil, err
+					}
+					return openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+				},
+			}, nil
+		}),
+		kctx.BindSingletonProvider(func(
+			log *silog.Logger,
+			store *state.Store,
+			repo *git.Repository,
+			wt *git.Worktree,
+			trackHandler TrackHandler,
+			secretStash secret.Stash,
+			forges *forge.Registry,
+		) (PullHandler, error) {
+			return &pull.Handler{
+				Log:        log,
+				Store:      store,
+				Repository: repo,
+				Worktree:   wt,
+				Track:      trackHandler,
+				OpenRemoteRepository: func(ctx context.Context) (forge.Repository, error) {
+					remote, err := ensureRemote(ctx, repo, store, log, view)
+					if err != nil {
+						return nil, err
+					}
+					return openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+				},
 			}, nil
 		}),
 		kctx.BindSingletonProvider(func(
@@ -387,8 +606,27 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 					return ensureRemote(ctx, wt.Repository(), store, log, view)
 				},
 				OpenRemoteRepository: func(ctx context.Context, remote string) (forge.Repository, error) {
-					return openRemoteRepository(ctx, log, secretStash, forges, wt.Repository(), remote)
+					remoteRepo, err := openRemoteRepository(ctx, log, secretStash, forges, wt.Repository(), store, remote)
+					var unreachable *forgeUnreachableError
+					if errors.As(err, &unreachable) && unreachable.Err.Problem != netcheck.Auth {
+						log.Info("To push branches without creating change requests, submit with --no-publish.")
+					}
+					return remoteRepo, err
 				},
+				FindRemoteRepositoryID: func(ctx context.Context, remote string) (forge.Forge, forge.RepositoryID, error) {
+					return findRemoteRepositoryID(ctx, forges, wt.Repository(), remote)
+				},
+			}, nil
+		}),
+		kctx.BindSingletonProvider(func(
+			log *silog.Logger,
+			worktree *git.Worktree,
+		) (ConflictHandler, error) {
+			return &conflict.Handler{
+				Log:        log,
+				View:       view,
+				Repository: worktree.Repository(),
+				Worktree:   worktree,
 			}, nil
 		}),
 		kctx.BindSingletonProvider(func(
@@ -396,12 +634,14 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 			worktree *git.Worktree,
 			store *state.Store,
 			svc *spice.Service,
+			conflictHandler ConflictHandler,
 		) (RestackHandler, error) {
 			return &restack.Handler{
 				Log:      log,
 				Worktree: worktree,
 				Store:    store,
 				Service:  svc,
+				Conflict: conflictHandler,
 			}, nil
 		}),
 		kctx.BindSingletonProvider(func(
@@ -458,6 +698,7 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 			log *silog.Logger,
 			repo *git.Repository,
 			wt *git.Worktree,
+			svc *spice.Service,
 			restackHandler RestackHandler,
 			autostashHandler AutostashHandler,
 		) (CherryPickHandler, error) {
@@ -465,10 +706,89 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 				Log:        log,
 				Repository: repo,
 				Worktree:   wt,
+				Service:    svc,
 				Restack:    restackHandler,
 				Autostash:  autostashHandler,
 			}, nil
 		}),
+		kctx.BindSingletonProvider(func(
+			log *silog.Logger,
+			view ui.View,
+			repo *git.Repository,
+			store *state.Store,
+			svc *spice.Service,
+			secretStash secret.Stash,
+			forges *forge.Registry,
+		) (RefreshHandler, error) {
+			remote, err := ensureRemote(ctx, repo, store, log, view)
+			if err != nil {
+				return nil, err
+			}
+
+			remoteRepo, err := openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+			if err != nil {
+				return nil, err
+			}
+
+			return &refresh.Handler{
+				Log:              log,
+				Store:            store,
+				Service:          svc,
+				RemoteRepository: remoteRepo,
+				FindRemoteRepositoryID: func(ctx context.Context, remote string) (forge.Forge, forge.RepositoryID, error) {
+					return findRemoteRepositoryID(ctx, forges, repo, remote)
+				},
+			}, nil
+		}),
+		kctx.BindSingletonProvider(func(
+			log *silog.Logger,
+			view ui.View,
+			repo *git.Repository,
+			store *state.Store,
+			svc *spice.Service,
+			secretStash secret.Stash,
+			forges *forge.Registry,
+		) (CommentsHandler, error) {
+			remote, err := ensureRemote(ctx, repo, store, log, view)
+			if err != nil {
+				return nil, err
+			}
+
+			remoteRepo, err := openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+			if err != nil {
+				return nil, err
+			}
+
+			return &comments.Handler{
+				Log:              log,
+				View:             view,
+				Service:          svc,
+				RemoteRepository: remoteRepo,
+			}, nil
+		}),
+		kctx.BindSingletonProvider(func(
+			log *silog.Logger,
+			view ui.View,
+			repo *git.Repository,
+			store *state.Store,
+			secretStash secret.Stash,
+			forges *forge.Registry,
+		) (NavCommentCleaner, error) {
+			remote, err := ensureRemote(ctx, repo, store, log, view)
+			if err != nil {
+				return nil, err
+			}
+
+			remoteRepo, err := openRemoteRepository(ctx, log, secretStash, forges, repo, store, remote)
+			if err != nil {
+				return nil, err
+			}
+
+			return &submit.NavCommentCleaner{
+				Log:              log,
+				RemoteRepository: remoteRepo,
+			}, nil
+		}),
 		kctx.BindSingletonProvider(func(
 			log *silog.Logger,
 			view ui.View,
@@ -487,13 +807,41 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 				return nil, err
 			}
 
-			remoteRepo, err := openRemoteRepositorySilent(ctx, secretStash, forges, repo, remote)
+			remoteRepo, err := openRemoteRepositorySilent(ctx, secretStash, forges, repo, store, remote)
 			if err != nil {
-				var unsupported *unsupportedForgeError
-				if !errors.As(err, &unsupported) {
+				var (
+					unsupported *unsupportedForgeError
+					unreachable *forgeUnreachableError
+				)
+				switch {
+				case errors.As(err, &unsupported):
+					remoteRepo = nil
+				case errors.As(err, &unreachable):
+					logForgeUnreachable(log, unreachable)
 					return nil, err
+				default:
+					return nil, err
+				}
+			}
+
+			var (
+				refreshHandler    sync.RefreshHandler
+				navCommentCleaner sync.NavCommentCleaner
+			)
+			if remoteRepo != nil {
+				refreshHandler = &refresh.Handler{
+					Log:              log,
+					Store:            store,
+					Service:          svc,
+					RemoteRepository: remoteRepo,
+					FindRemoteRepositoryID: func(ctx context.Context, remote string) (forge.Forge, forge.RepositoryID, error) {
+						return findRemoteRepositoryID(ctx, forges, repo, remote)
+					},
+				}
+				navCommentCleaner = &submit.NavCommentCleaner{
+					Log:              log,
+					RemoteRepository: remoteRepo,
 				}
-				remoteRepo = nil
 			}
 
 			return &sync.Handler{
@@ -507,6 +855,8 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 				Restack:          restackHandler,
 				Remote:           remote,
 				RemoteRepository: remoteRepo,
+				Refresh:          refreshHandler,
+				NavComments:      navCommentCleaner,
 			}, nil
 		}),
 	)
@@ -515,11 +865,53 @@ func (cmd *mainCmd) AfterApply(ctx context.Context, kctx *kong.Context, logger *
 type AutostashHandler interface {
 	BeginAutostash(ctx context.Context, opts *autostash.Options) (func(*error), error)
 	RestoreAutostash(ctx context.Context, stashHash string) error
+	ListAutostashes(ctx context.Context) ([]git.StashEntry, error)
+	PopAutostash(ctx context.Context, name string) error
 }
 
 var _ AutostashHandler = (*autostash.Handler)(nil)
 
-var _buildView = func(stdin io.Reader, stderr io.Writer, interactive bool) (ui.View, error) {
+// answers builds the set of pre-supplied answers to prompts
+// from the global flags.
+func (cmd *mainCmd) answers() (*ui.Answers, error) {
+	answers := ui.Answers{Yes: cmd.Globals.Yes}
+	for _, answer := range cmd.Globals.Answer {
+		if err := answers.ParseAnswer(answer); err != nil {
+			return nil, fmt.Errorf("--answer: %w", err)
+		}
+	}
+
+	switch path := cmd.Globals.AnswersFile; path {
+	case "":
+		// No answers file.
+
+	case "-":
+		if err := answers.ReadJSON(os.Stdin); err != nil {
+			return nil, fmt.Errorf("--answers: %w", err)
+		}
+
+	default:
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("--answers: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		if err := answers.ReadJSON(f); err != nil {
+			return nil, fmt.Errorf("--answers: %w", err)
+		}
+	}
+
+	return &answers, nil
+}
+
+var _buildView = func(stdin io.Reader, stderr io.Writer, interactive, plain bool) (ui.View, error) {
+	if interactive && plain {
+		return &ui.PlainView{
+			R: stdin,
+			W: stderr,
+		}, nil
+	}
 	if interactive {
 		return &ui.TerminalView{
 			R: stdin,
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/migrate"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type migrateCmd struct {
+	From   migrate.Source `required:"" enum:"graphite,ghstack,spr" placeholder:"TOOL" help:"Tool to migrate from. One of: graphite, ghstack, spr"`
+	Branch string         `placeholder:"BRANCH" predictor:"branches" help:"Branch holding the stack of commits for ghstack and spr. Defaults to the current branch."`
+}
+
+func (*migrateCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Reads the metadata of another stacking tool
+		and tracks the branches it manages with %[1]s,
+		along with their Change Requests.
+		Branches that are already tracked are left alone.
+
+		With --from=graphite, branches tracked by Graphite
+		are tracked with the same bases.
+
+		With --from=ghstack or --from=spr,
+		each commit in the stack on the current branch (or --branch)
+		becomes a branch stacked on the one before it.
+		The branches take over the remote branches
+		and Change Requests created by the tool,
+		so '%[1]s stack submit' updates them in place.
+		For ghstack, fetch its branches before migrating.
+
+		Change Requests are associated with branches
+		if the forge can be reached.
+		Otherwise, they are detected on the next submit.
+	`, cli.Name()))
+}
+
+// MigrateHandler tracks branches managed by other stacking tools.
+type MigrateHandler interface {
+	Migrate(context.Context, *migrate.Request) error
+}
+
+var _ MigrateHandler = (*migrate.Handler)(nil)
+
+func (cmd *migrateCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" && cmd.From != migrate.Graphite {
+		var err error
+		cmd.Branch, err = wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+	}
+	return nil
+}
+
+func (cmd *migrateCmd) Run(ctx context.Context, handler MigrateHandler) error {
+	return handler.Migrate(ctx, &migrate.Request{
+		From:   cmd.From,
+		Branch: cmd.Branch,
+	})
+}
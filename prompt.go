@@ -0,0 +1,250 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+type promptCmd struct {
+	Long bool `short:"l" released:"unreleased" help:"Separate fields with spaces and spell out markers, e.g. '2/5 ↑1 needs-restack #123'"`
+}
+
+func (*promptCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Prints a short summary of the current branch's stack
+		for use in shell prompts.
+		For example:
+
+			2/5↑1! #123
+
+		This reads as follows:
+
+			2/5   current branch is 2nd of 5 branches in its stack
+			↑1    1 commit has not been pushed
+			!     branch needs to be restacked
+			#123  branch has been submitted as #123
+
+		Use --long to print "needs-restack" instead of "!"
+		and separate each field with a space.
+		For example:
+
+			2/5 ↑1 needs-restack #123
+
+		Nothing is printed on trunk, in detached HEAD state,
+		on untracked branches,
+		or if the repository has not been initialized.
+
+		Only local state is used.
+		The forge is never contacted,
+		so this is fast enough to run on every prompt.
+		For example, with zsh:
+
+			setopt PROMPT_SUBST
+			PROMPT='$(%[1]s prompt 2>/dev/null) '$PROMPT
+	`, cli.Name()))
+}
+
+func (cmd *promptCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	wt *git.Worktree,
+	repo *git.Repository,
+	forges *forge.Registry,
+) error {
+	current, err := wt.CurrentBranch(ctx)
+	if err != nil {
+		if errors.Is(err, git.ErrDetachedHead) {
+			return nil
+		}
+		return fmt.Errorf("get current branch: %w", err)
+	}
+
+	// Don't use ensureStore: prompts must never initialize the repository.
+	store, err := state.OpenStore(ctx, newRepoStorage(repo, log), log)
+	if err != nil {
+		if errors.Is(err, state.ErrUninitialized) {
+			return nil
+		}
+		return fmt.Errorf("open store: %w", err)
+	}
+
+	if current == store.Trunk() {
+		return nil
+	}
+
+	branches, err := loadPromptBranches(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	branch, ok := branches[current]
+	if !ok {
+		return nil // untracked
+	}
+
+	// To keep the prompt short, fields are joined without a separator
+	// unless --long is used.
+	var fields []string
+	position, total := promptStackPosition(branches, current)
+	fields = append(fields, fmt.Sprintf("%d/%d", position, total))
+
+	head, err := repo.PeelToCommit(ctx, current)
+	if err != nil {
+		return fmt.Errorf("peel to commit: %w", err)
+	}
+
+	if branch.UpstreamBranch != "" {
+		remote := branch.UpstreamRemote
+		if remote == "" {
+			remote, _ = store.Remote()
+		}
+
+		upstream, err := repo.PeelToCommit(ctx, remote+"/"+branch.UpstreamBranch)
+		if err == nil && upstream != head {
+			ahead, err := repo.CountCommits(ctx, git.CommitRangeFrom(head).ExcludeFrom(upstream))
+			if err == nil && ahead > 0 {
+				fields = append(fields, ui.Glyph("↑", "^")+strconv.Itoa(ahead))
+			}
+		}
+	}
+
+	if baseHash, err := repo.PeelToCommit(ctx, branch.Base); err == nil {
+		if !repo.IsAncestor(ctx, baseHash, head) {
+			marker := "!"
+			if cmd.Long {
+				marker = "needs-restack"
+			}
+			fields = append(fields, marker)
+		}
+	}
+
+	if branch.ChangeMetadata != nil {
+		if f, ok := forges.Lookup(branch.ChangeForge); ok {
+			if md, err := f.UnmarshalChangeMetadata(branch.ChangeMetadata); err == nil {
+				// The change ID is always separated by a space.
+				id := forge.FormatChangeID(f, md.ChangeID())
+				if !cmd.Long {
+					id = " " + id
+				}
+				fields = append(fields, id)
+			}
+		}
+	}
+
+	sep := ""
+	if cmd.Long {
+		sep = " "
+	}
+	_, err = fmt.Fprintln(kctx.Stdout, strings.Join(fields, sep))
+	return err
+}
+
+// loadPromptBranches loads the state of all tracked branches.
+//
+// Unlike Service.LoadBranches, this only reads the data store.
+// It does not resolve branch heads or verify upstream branches,
+// and it never modifies the store.
+func loadPromptBranches(ctx context.Context, store *state.Store) (map[string]*state.LookupResponse, error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	branches := make(map[string]*state.LookupResponse)
+	namec := make(chan string)
+	for range runtime.GOMAXPROCS(0) {
+		wg.Go(func() {
+			for name := range namec {
+				resp, err := store.LookupBranch(ctx, name)
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("get branch %v: %w", name, err))
+				} else {
+					branches[name] = resp
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+	var listErr error
+	for name, err := range store.ListBranches(ctx) {
+		if err != nil {
+			listErr = fmt.Errorf("list branches: %w", err)
+			break
+		}
+		namec <- name
+	}
+	close(namec)
+	wg.Wait()
+
+	if err := cmp.Or(listErr, errors.Join(errs...)); err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// promptStackPosition reports the position of the given branch
+// in its stack, counting from 1 at the bottom,
+// and the total number of branches in that stack
+// along the tallest path through the branch.
+//
+// Archived branches above the branch are not counted.
+func promptStackPosition(branches map[string]*state.LookupResponse, name string) (position, total int) {
+	// Walk down to trunk.
+	seen := make(map[string]struct{})
+	for b := name; ; {
+		resp, ok := branches[b]
+		if !ok {
+			break // reached trunk or an untracked base
+		}
+		if _, ok := seen[b]; ok {
+			break // cycle in corrupt state
+		}
+		seen[b] = struct{}{}
+
+		position++
+		b = resp.Base
+	}
+
+	aboves := make(map[string][]string)
+	for b, resp := range branches {
+		if !resp.Archived {
+			aboves[resp.Base] = append(aboves[resp.Base], b)
+		}
+	}
+
+	// Height of the tallest stack above name.
+	clear(seen)
+	var height func(string) int
+	height = func(b string) int {
+		if _, ok := seen[b]; ok {
+			return 0
+		}
+		seen[b] = struct{}{}
+
+		var h int
+		for _, above := range aboves[b] {
+			h = max(h, 1+height(above))
+		}
+		return h
+	}
+
+	return position, position + height(name)
+}
@@ -9,11 +9,20 @@ import (
 	"github.com/alecthomas/kong"
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/conflict"
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/spice/state"
 	"go.abhg.dev/gs/internal/text"
 )
 
+// ConflictHandler helps resolve conflicts that interrupt a rebase.
+type ConflictHandler interface {
+	ResolveConflicts(ctx context.Context, req *conflict.Request) error
+}
+
+var _ ConflictHandler = (*conflict.Handler)(nil)
+
 type rebaseContinueCmd struct {
 	Edit bool `default:"true" negatable:"" config:"rebaseContinue.edit" help:"Whether to open an editor to edit the commit message."`
 }
@@ -31,6 +40,10 @@ func (*rebaseContinueCmd) Help() string {
 		The command can be used in place of 'git rebase --continue'
 		even if a git-spice operation is not currently in progress.
 
+		If the rebase runs into more conflicts,
+		an interactive assistant helps resolve them
+		and offers to abort the operation instead.
+
 		Use the --no-edit flag to continue without opening an editor.
 		Make --no-edit the default by setting 'spice.rebaseContinue.edit' to false
 		and use --edit to override it.
@@ -42,6 +55,8 @@ func (cmd *rebaseContinueCmd) Run(
 	log *silog.Logger,
 	wt *git.Worktree,
 	store *state.Store,
+	svc *spice.Service,
+	conflictHandler ConflictHandler,
 	parser *kong.Kong,
 ) error {
 	if _, err := wt.RebaseState(ctx); err != nil {
@@ -60,6 +75,29 @@ func (cmd *rebaseContinueCmd) Run(
 	if err := wt.RebaseContinue(ctx, &opts); err != nil {
 		var rebaseErr *git.RebaseInterruptError
 		if errors.As(err, &rebaseErr) {
+			err = cmd.resolveConflicts(ctx, wt, svc, conflictHandler, rebaseErr)
+		}
+
+		switch {
+		case err == nil:
+			// The assistant resolved the conflicts
+			// and the rebase ran to completion.
+
+		case errors.Is(err, conflict.ErrAborted):
+			// The operations that were waiting on this rebase
+			// will not run.
+			conts, takeErr := store.TakeContinuations(ctx, cli.Name()+" rebase continue")
+			if takeErr != nil {
+				return fmt.Errorf("take rebase continuations: %w", takeErr)
+			}
+			for _, cont := range conts {
+				log.Debug("Rebase aborted: will not run command",
+					"command", strings.Join(cont.Command, " "),
+					"branch", cont.Branch)
+			}
+			return err
+
+		case errors.As(err, &rebaseErr):
 			var msg strings.Builder
 			fmt.Fprintf(&msg, "There are more conflicts to resolve.\n")
 			fmt.Fprintf(&msg, "Resolve them and run the following command again:\n")
@@ -67,8 +105,11 @@ func (cmd *rebaseContinueCmd) Run(
 			fmt.Fprintf(&msg, "To abort the remaining operations run:\n")
 			fmt.Fprintf(&msg, "  git rebase --abort\n")
 			log.Error(msg.String())
+			return err
+
+		default:
+			return err
 		}
-		return err
 	}
 
 	// Once we get here, we have a clean state to continue running
@@ -97,6 +138,12 @@ func (cmd *rebaseContinueCmd) Run(
 		}
 
 		if err := kctx.Run(ctx); err != nil {
+			// If the user aborted the rebase,
+			// the remaining operations are dropped.
+			if errors.Is(err, conflict.ErrAborted) {
+				return err
+			}
+
 			// If the command failed, it has already printed the
 			// rebase message, and appended its continuations.
 			// We'll append the remainder.
@@ -110,3 +157,30 @@ func (cmd *rebaseContinueCmd) Run(
 
 	return nil
 }
+
+// resolveConflicts runs the conflict assistant
+// for a rebase of a tracked branch that hit more conflicts.
+// Other rebases are left for the user to resolve.
+func (cmd *rebaseContinueCmd) resolveConflicts(
+	ctx context.Context,
+	wt *git.Worktree,
+	svc *spice.Service,
+	conflictHandler ConflictHandler,
+	rebaseErr *git.RebaseInterruptError,
+) error {
+	rebaseState, err := wt.RebaseState(ctx)
+	if err != nil || rebaseState.Branch == "" {
+		return rebaseErr
+	}
+
+	branch, err := svc.LookupBranch(ctx, rebaseState.Branch)
+	if err != nil {
+		return rebaseErr
+	}
+
+	return conflictHandler.ResolveConflicts(ctx, &conflict.Request{
+		Branch: rebaseState.Branch,
+		Base:   branch.Base,
+		Err:    rebaseErr,
+	})
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/netcheck"
 	"go.abhg.dev/gs/internal/secret"
 	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice/state"
 )
 
 type unsupportedForgeError struct {
@@ -29,6 +32,23 @@ func (e *notLoggedInError) Error() string {
 	return "not logged in to " + e.Forge.ID()
 }
 
+type forgeUnreachableError struct {
+	Forge forge.Forge     // required
+	Err   *netcheck.Error // required
+}
+
+func (e *forgeUnreachableError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Forge.ID(), e.Err)
+}
+
+func (e *forgeUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// _forgePreflightTimeout is the maximum time to wait
+// when checking whether a forge is reachable.
+var _forgePreflightTimeout = netcheck.DefaultTimeout
+
 // Attempts to open the forge.Repository associated with the given Git remote.
 //
 // Does not print any error messages to the user.
@@ -37,27 +57,54 @@ func (e *notLoggedInError) Error() string {
 //   - unsupportedForgeError if the remote URL does not match
 //     any any known forges.
 //   - notLoggedInError if the user is not authenticated with the forge.
+//   - forgeUnreachableError if the forge could not be reached
+//     or rejected the authentication token.
 func openRemoteRepositorySilent(
 	ctx context.Context,
 	stash secret.Stash,
 	forges *forge.Registry,
 	gitRepo *git.Repository,
+	store *state.Store,
 	remote string,
 ) (forge.Repository, error) {
+	f, repoID, err := findRemoteRepositoryID(ctx, forges, gitRepo, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := openForgeRepository(ctx, stash, f, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if withCache, ok := repo.(forge.WithCache); ok && store != nil {
+		withCache.SetCache(&forgeCache{store: store, forge: f.ID()})
+	}
+	return repo, nil
+}
+
+// findRemoteRepositoryID identifies the forge and repository
+// that the given remote points to without opening it.
+func findRemoteRepositoryID(
+	ctx context.Context,
+	forges *forge.Registry,
+	gitRepo *git.Repository,
+	remote string,
+) (forge.Forge, forge.RepositoryID, error) {
 	remoteURL, err := gitRepo.RemoteURL(ctx, remote)
 	if err != nil {
-		return nil, fmt.Errorf("get remote URL: %w", err)
+		return nil, nil, fmt.Errorf("get remote URL: %w", err)
 	}
 
 	f, repoID, ok := forge.MatchRemoteURL(forges, remoteURL)
 	if !ok {
-		return nil, &unsupportedForgeError{
+		return nil, nil, &unsupportedForgeError{
 			Remote:    remote,
 			RemoteURL: remoteURL,
 		}
 	}
 
-	return openForgeRepository(ctx, stash, f, repoID)
+	return f, repoID, nil
 }
 
 func openForgeRepository(
@@ -74,7 +121,40 @@ func openForgeRepository(
 		return nil, fmt.Errorf("load authentication token: %w", err)
 	}
 
-	return f.OpenRepository(ctx, tok, repoID)
+	// Check that the forge is reachable before opening the repository.
+	// Without this, a network problem shows up as a long hang
+	// followed by an error from deep inside the forge's API client.
+	var apiURL string
+	if withAPI, ok := f.(forge.WithAPIURL); ok {
+		apiURL = withAPI.APIURL()
+
+		// Go through the same proxies and credentials as API requests.
+		var client *http.Client
+		if withTransport, ok := f.(forge.WithHTTPTransport); ok {
+			transport, err := withTransport.HTTPTransport()
+			if err != nil {
+				return nil, fmt.Errorf("configure HTTP transport: %w", err)
+			}
+			client = &http.Client{Transport: transport}
+		}
+
+		if err := netcheck.Check(ctx, client, apiURL, _forgePreflightTimeout); err != nil {
+			var netErr *netcheck.Error
+			if errors.As(err, &netErr) {
+				return nil, &forgeUnreachableError{Forge: f, Err: netErr}
+			}
+			return nil, err
+		}
+	}
+
+	repo, err := f.OpenRepository(ctx, tok, repoID)
+	if err != nil && (errors.Is(err, forge.ErrUnauthorized) || netcheck.IsUnauthorized(err)) {
+		return nil, &forgeUnreachableError{
+			Forge: f,
+			Err:   &netcheck.Error{URL: apiURL, Problem: netcheck.Auth, Err: err},
+		}
+	}
+	return repo, err
 }
 
 func openRemoteRepository(
@@ -83,13 +163,15 @@ func openRemoteRepository(
 	stash secret.Stash,
 	forges *forge.Registry,
 	gitRepo *git.Repository,
+	store *state.Store,
 	remote string,
 ) (forge.Repository, error) {
-	forgeRepo, err := openRemoteRepositorySilent(ctx, stash, forges, gitRepo, remote)
+	forgeRepo, err := openRemoteRepositorySilent(ctx, stash, forges, gitRepo, store, remote)
 
 	var (
 		unsupportedErr *unsupportedForgeError
 		notLoggedInErr *notLoggedInError
+		unreachableErr *forgeUnreachableError
 	)
 	switch {
 	case errors.As(err, &unsupportedErr):
@@ -103,7 +185,60 @@ func openRemoteRepository(
 		log.Errorf("Try running `%s auth login --forge=%s`", cli.Name(), f.ID())
 		return nil, err
 
+	case errors.As(err, &unreachableErr):
+		logForgeUnreachable(log, unreachableErr)
+		return nil, err
+
 	default:
 		return forgeRepo, err
 	}
 }
+
+// logForgeUnreachable explains why a forge could not be reached
+// and what the user can do about it.
+func logForgeUnreachable(log *silog.Logger, err *forgeUnreachableError) {
+	f, problem := err.Forge, err.Err.Problem
+	if problem == netcheck.Auth {
+		log.Errorf("%s rejected the authentication token.", forge.GetDisplayName(f))
+		log.Error(problem.Hint())
+		log.Errorf("Try running `%s auth login --forge=%s`", cli.Name(), f.ID())
+		return
+	}
+
+	log.Errorf("Could not reach %s (%v).", forge.GetDisplayName(f), problem)
+	log.Error(problem.Hint())
+
+	// Forges with configurable transports can fix these
+	// without changing the system's settings.
+	if _, ok := f.(forge.WithHTTPTransport); ok {
+		switch problem {
+		case netcheck.Proxy:
+			log.Errorf("To use a different proxy for %s, set spice.forge.%s.proxy.",
+				forge.GetDisplayName(f), f.ID())
+		case netcheck.TLS:
+			log.Errorf("If a proxy intercepts TLS connections, set spice.forge.%s.caBundle "+
+				"to a file with its certificate authority.", f.ID())
+		}
+	}
+}
+
+// forgeCache is a [forge.Cache] backed by the git-spice data store.
+// Each forge gets its own namespace in the store.
+type forgeCache struct {
+	store *state.Store
+	forge string // forge ID
+}
+
+var _ forge.Cache = (*forgeCache)(nil)
+
+func (c *forgeCache) Get(ctx context.Context, key string, v any) error {
+	err := c.store.LoadCache(ctx, c.forge+"/"+key, v)
+	if errors.Is(err, state.ErrNotExist) {
+		return forge.ErrNotFound
+	}
+	return err
+}
+
+func (c *forgeCache) Set(ctx context.Context, key string, v any) error {
+	return c.store.SaveCache(ctx, c.forge+"/"+key, v)
+}
@@ -1,7 +1,9 @@
 package main
 
 type repoCmd struct {
-	Init    repoInitCmd    `cmd:"" aliases:"i" help:"Initialize a repository"`
-	Sync    repoSyncCmd    `cmd:"" aliases:"s" help:"Pull latest changes from the remote"`
-	Restack repoRestackCmd `cmd:"" aliases:"r" help:"Restack all tracked branches" released:"v0.16.0"`
+	Init            repoInitCmd            `cmd:"" aliases:"i" help:"Initialize a repository"`
+	Sync            repoSyncCmd            `cmd:"" aliases:"s" help:"Pull latest changes from the remote"`
+	Restack         repoRestackCmd         `cmd:"" aliases:"r" help:"Restack all tracked branches" released:"v0.16.0"`
+	CleanupComments repoCleanupCommentsCmd `cmd:"" name:"cleanup-comments" help:"Clean up navigation comments on merged CRs" released:"unreleased"`
+	Doctor          repoDoctorCmd          `cmd:"" help:"Find and repair problems with tracked branches" released:"unreleased"`
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/handler/submit"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type repoCleanupCommentsCmd struct {
+	Changes []string                 `arg:"" help:"Change Requests to clean up, as numbers or URLs"`
+	Mode    submit.NavCommentCleanup `default:"strike" enum:"strike,collapse,delete" help:"How to clean up the comments. One of 'strike', 'collapse', and 'delete'."`
+}
+
+func (*repoCleanupCommentsCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Cleans up the stack navigation comments
+		posted on Change Requests that have been merged,
+		so that merged Change Requests don't show stale stacks.
+
+		Use --mode to pick what happens to the comments:
+
+		  - strike: strike out the stack (default)
+		  - collapse: collapse the stack into a <details> block
+		  - delete: delete the comments
+
+		Comments that were already cleaned up are left alone.
+
+		To clean up comments automatically
+		when '%[1]s repo sync' finds that a stack has fully merged,
+		set the spice.submit.navigationCommentCleanup configuration option.
+	`, cli.Name()))
+}
+
+// NavCommentCleaner cleans up navigation comments on merged change requests.
+type NavCommentCleaner interface {
+	ParseChangeID(string) (forge.ChangeID, error)
+	CleanupNavigationComments(context.Context, *submit.CleanupNavCommentsRequest) (*submit.CleanupNavCommentsResponse, error)
+}
+
+var _ NavCommentCleaner = (*submit.NavCommentCleaner)(nil)
+
+func (cmd *repoCleanupCommentsCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	cleaner NavCommentCleaner,
+) error {
+	changes := make([]forge.ChangeID, len(cmd.Changes))
+	for i, s := range cmd.Changes {
+		id, err := cleaner.ParseChangeID(s)
+		if err != nil {
+			return fmt.Errorf("parse change %q: %w", s, err)
+		}
+		changes[i] = id
+	}
+
+	res, err := cleaner.CleanupNavigationComments(ctx, &submit.CleanupNavCommentsRequest{
+		Changes: changes,
+		Mode:    cmd.Mode,
+	})
+	if err != nil {
+		return fmt.Errorf("clean up comments: %w", err)
+	}
+
+	if len(res.Failed) > 0 {
+		return fmt.Errorf("could not clean up comments on %d change(s)", len(res.Failed))
+	}
+	if len(res.Cleaned) == 0 {
+		log.Info("No navigation comments to clean up")
+	}
+	return nil
+}
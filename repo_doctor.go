@@ -0,0 +1,350 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/secret"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+type repoDoctorCmd struct{}
+
+func (*repoDoctorCmd) Help() string {
+	return text.Dedent(`
+		Checks the repository and the branches tracked by git-spice
+		for problems that prevent other commands from working.
+
+		The following problems are reported
+		along with commands that fix them:
+
+		  - no remote, or a remote that no longer exists
+		  - not being logged in to the Git host of the remote
+		  - tracked branches that no longer exist
+		  - branches whose pushed copy was changed by someone else
+		  - a detached HEAD
+		  - a shallow clone
+
+		The following problems are repaired:
+
+		  - cycles: branches that are based on themselves,
+		    directly or through other branches
+		  - untracked bases: branches based on a branch
+		    that is neither trunk nor tracked
+
+		For each of these, you will be prompted
+		to pick the correct base for an affected branch.
+		Only the recorded base is changed:
+		restack the branch afterwards to move its commits.
+
+		The command fails if any problems are left unrepaired.
+	`)
+}
+
+// repoDoctorFinding is a problem found by repo doctor
+// that must be fixed by hand.
+type repoDoctorFinding struct {
+	// Problem describes what's wrong.
+	Problem string
+
+	// Fixes are commands that fix the problem.
+	// Any one of them may be used.
+	Fixes []string
+}
+
+func (cmd *repoDoctorCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	view ui.View,
+	repo *git.Repository,
+	wt *git.Worktree,
+	store *state.Store,
+	secretStash secret.Stash,
+	forges *forge.Registry,
+) error {
+	findings, branches, err := cmd.check(ctx, log, repo, wt, store, secretStash, forges)
+	if err != nil {
+		return err
+	}
+	for _, f := range findings {
+		log.Warn(f.Problem)
+		for _, fix := range f.Fixes {
+			log.Warnf("  %v", fix)
+		}
+	}
+
+	if err := cmd.repairGraph(ctx, log, view, repo, store, branches); err != nil {
+		return err
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("found %d problem(s)", len(findings))
+	}
+	return nil
+}
+
+// check looks for problems that must be fixed by hand.
+// It also returns the tracked branches as recorded in the store,
+// including those that no longer exist.
+func (cmd *repoDoctorCmd) check(
+	ctx context.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	wt *git.Worktree,
+	store *state.Store,
+	secretStash secret.Stash,
+	forges *forge.Registry,
+) ([]repoDoctorFinding, []spice.LoadBranchItem, error) {
+	var findings []repoDoctorFinding
+
+	if _, err := wt.CurrentBranch(ctx); errors.Is(err, git.ErrDetachedHead) {
+		findings = append(findings, repoDoctorFinding{
+			Problem: "HEAD is detached. Commands that operate on the current branch will fail.",
+			Fixes:   []string{"gs branch checkout"},
+		})
+	}
+
+	if shallow, err := repo.IsShallow(ctx); err != nil {
+		log.Warn("Could not check if repository is shallow", "error", err)
+	} else if shallow {
+		findings = append(findings, repoDoctorFinding{
+			Problem: "Repository is a shallow clone. Branches may appear unrelated to their bases.",
+			Fixes:   []string{"git fetch --unshallow"},
+		})
+	}
+
+	remote, err := store.Remote()
+	if err != nil {
+		remote = ""
+		findings = append(findings, repoDoctorFinding{
+			Problem: "No remote is set. Branches cannot be submitted.",
+			Fixes:   []string{"gs repo init --remote=REMOTE"},
+		})
+	} else if remotes, err := repo.ListRemotes(ctx); err != nil {
+		return nil, nil, fmt.Errorf("list remotes: %w", err)
+	} else if !slices.Contains(remotes, remote) {
+		findings = append(findings, repoDoctorFinding{
+			Problem: fmt.Sprintf("Remote %v does not exist. Branches cannot be submitted.", remote),
+			Fixes: []string{
+				fmt.Sprintf("git remote add %v URL", remote),
+				"gs repo init --remote=REMOTE",
+			},
+		})
+		remote = ""
+	} else if f, _, err := findRemoteRepositoryID(ctx, forges, repo, remote); err != nil {
+		log.Debug("Not checking authentication", "remote", remote, "error", err)
+	} else if _, err := f.LoadAuthenticationToken(secretStash); err != nil {
+		if !errors.Is(err, secret.ErrNotFound) {
+			return nil, nil, fmt.Errorf("load authentication token: %w", err)
+		}
+		findings = append(findings, repoDoctorFinding{
+			Problem: fmt.Sprintf("Not logged in to %v. Branches cannot be submitted.", f.ID()),
+			Fixes:   []string{"gs auth login"},
+		})
+	}
+
+	// Read branches from the store directly
+	// because Service.LoadBranches forgets branches that no longer exist.
+	var branches []spice.LoadBranchItem
+	for name, err := range store.ListBranches(ctx) {
+		if err != nil {
+			return nil, nil, fmt.Errorf("list branches: %w", err)
+		}
+
+		b, err := store.LookupBranch(ctx, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("lookup branch %v: %w", name, err)
+		}
+		branches = append(branches, spice.LoadBranchItem{
+			Name: name,
+			Base: b.Base,
+		})
+
+		if !repo.BranchExists(ctx, name) {
+			findings = append(findings, repoDoctorFinding{
+				Problem: fmt.Sprintf("%v is tracked but no longer exists.", name),
+				Fixes:   []string{"gs branch untrack " + name},
+			})
+			continue
+		}
+
+		upstreamRemote := cmp.Or(b.UpstreamRemote, remote)
+		if b.UpstreamBranch == "" || b.UpstreamHash == "" || upstreamRemote == "" {
+			continue
+		}
+		upstream := upstreamRemote + "/" + b.UpstreamBranch
+		pushed, err := repo.PeelToCommit(ctx, "refs/remotes/"+upstream)
+		if err != nil {
+			continue // not fetched, or deleted
+		}
+		if pushed != b.UpstreamHash {
+			findings = append(findings, repoDoctorFinding{
+				Problem: fmt.Sprintf("%v: %v was changed since it was last pushed.", name, upstream),
+				Fixes: []string{
+					fmt.Sprintf("git log %v..%v", name, upstream),
+					fmt.Sprintf("gs branch submit --branch %v --force", name),
+				},
+			})
+		}
+	}
+	slices.SortFunc(branches, func(a, b spice.LoadBranchItem) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return findings, branches, nil
+}
+
+// repairGraph prompts to repair cycles and untracked bases
+// in the branch graph.
+func (cmd *repoDoctorCmd) repairGraph(
+	ctx context.Context,
+	log *silog.Logger,
+	view ui.View,
+	repo *git.Repository,
+	store *state.Store,
+	branches []spice.LoadBranchItem,
+) error {
+	trunk := store.Trunk()
+	var graphErr *spice.InvalidBranchGraphError
+	if err := spice.ValidateBranchGraph(trunk, branches); err == nil {
+		log.Info("No problems found")
+		return nil
+	} else if !errors.As(err, &graphErr) {
+		return err
+	}
+
+	for _, cycle := range graphErr.Cycles {
+		log.Error("Branches form a cycle:")
+		for _, edge := range cycle {
+			log.Errorf("  %v is based on %v", edge.Branch, edge.Base)
+		}
+	}
+	for _, edge := range graphErr.UntrackedBases {
+		log.Errorf("%v is based on untracked branch %v", edge.Branch, edge.Base)
+	}
+
+	if !ui.Interactive(view) {
+		return fmt.Errorf("cannot repair branches: %w", errNoPrompt)
+	}
+
+	baseOf := make(map[string]string, len(branches))
+	for _, b := range branches {
+		baseOf[b.Name] = b.Base
+	}
+
+	// Each repair is a branch and its new base.
+	var repairs []spice.BranchEdge
+	repair := func(branch string) error {
+		var base string
+		prompt := ui.NewSelect[string]().
+			WithValue(&base).
+			With(ui.ComparableOptions(trunk, validBases(trunk, baseOf)...)).
+			WithTitle(fmt.Sprintf("Select a new base for %v", branch)).
+			WithDescription(fmt.Sprintf("%v is currently based on %v", branch, baseOf[branch]))
+		if err := ui.Run(view, prompt); err != nil {
+			return fmt.Errorf("select base: %w", err)
+		}
+
+		baseOf[branch] = base
+		repairs = append(repairs, spice.BranchEdge{Branch: branch, Base: base})
+		return nil
+	}
+
+	for _, cycle := range graphErr.Cycles {
+		opts := make([]ui.SelectOption[string], len(cycle))
+		for i, edge := range cycle {
+			opts[i] = ui.SelectOption[string]{
+				Label: edge.String(),
+				Value: edge.Branch,
+			}
+		}
+
+		var branch string
+		prompt := ui.NewSelect[string]().
+			WithValue(&branch).
+			WithOptions(opts...).
+			WithTitle("Select the branch with the wrong base").
+			WithDescription("These branches form a cycle")
+		if err := ui.Run(view, prompt); err != nil {
+			return fmt.Errorf("select branch: %w", err)
+		}
+
+		if err := repair(branch); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range graphErr.UntrackedBases {
+		if err := repair(edge.Branch); err != nil {
+			return err
+		}
+	}
+
+	tx := store.BeginBranchTx()
+	for _, r := range repairs {
+		baseHash, err := repo.MergeBase(ctx, r.Branch, r.Base)
+		if err != nil {
+			return fmt.Errorf("%v: find merge base with %v: %w", r.Branch, r.Base, err)
+		}
+
+		if err := tx.Upsert(ctx, state.UpsertRequest{
+			Name:     r.Branch,
+			Base:     r.Base,
+			BaseHash: baseHash,
+		}); err != nil {
+			return fmt.Errorf("%v: set base to %v: %w", r.Branch, r.Base, err)
+		}
+	}
+	if err := tx.Commit(ctx, "repo doctor: repair branch bases"); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+
+	for _, r := range repairs {
+		log.Infof("%v: moved onto %v", r.Branch, r.Base)
+	}
+	return nil
+}
+
+// validBases returns the branches that may be used as a base
+// while repairing the given branch graph:
+// trunk, and branches that reach trunk by following their bases.
+//
+// Branches that are part of a cycle or above one,
+// or that are based on untracked branches, are not valid bases.
+func validBases(trunk string, baseOf map[string]string) []string {
+	valid := map[string]bool{trunk: true}
+	var reachesTrunk func(branch string, seen map[string]struct{}) bool
+	reachesTrunk = func(branch string, seen map[string]struct{}) bool {
+		if ok, known := valid[branch]; known {
+			return ok
+		}
+		base, tracked := baseOf[branch]
+		if _, cycle := seen[branch]; cycle || !tracked {
+			return false
+		}
+
+		seen[branch] = struct{}{}
+		ok := reachesTrunk(base, seen)
+		valid[branch] = ok
+		return ok
+	}
+
+	bases := []string{trunk}
+	for branch := range baseOf {
+		if reachesTrunk(branch, make(map[string]struct{})) {
+			bases = append(bases, branch)
+		}
+	}
+	slices.Sort(bases[1:])
+	return bases
+}
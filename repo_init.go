@@ -5,8 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/must"
 	"go.abhg.dev/gs/internal/silog"
@@ -18,8 +21,8 @@ import (
 )
 
 type repoInitCmd struct {
-	Trunk  string `placeholder:"BRANCH" predictor:"branches" help:"Name of the trunk branch"`
-	Remote string `placeholder:"NAME" predictor:"remotes" help:"Name of the remote to push changes to"`
+	Trunk  string `placeholder:"BRANCH" predictor:"branches" config:"repoInit.trunk" help:"Name of the trunk branch"`
+	Remote string `placeholder:"NAME" predictor:"remotes" config:"repoInit.remote" help:"Name of the remote to push changes to"`
 
 	Reset bool `help:"Forget all information about the repository"`
 }
@@ -28,13 +31,20 @@ func (*repoInitCmd) Help() string {
 	return text.Dedent(`
 		A trunk branch is required.
 		This is the branch that changes will be merged into.
-		A prompt will ask for one if not provided with --trunk.
+		If the remote reports a default branch,
+		and that branch exists locally, it is used as the trunk.
+		Otherwise, a prompt will ask for one if not provided with --trunk.
 
 		Most branch stacking operations are local
 		and do not require a network connection.
 		For operations that push or pull commits, a remote is required.
 		A prompt will ask for one during initialization
-		if not provided with --remote.
+		if there are multiple remotes and one isn't provided with --remote.
+
+		Use --trunk and --remote to initialize without prompting,
+		e.g. in scripts or CI.
+		If the trunk branch exists only on the remote,
+		a local branch is created for it.
 
 		Re-run the command on an already initialized repository
 		to change the trunk or remote.
@@ -53,29 +63,35 @@ func (cmd *repoInitCmd) Run(
 	view ui.View,
 	repo *git.Repository,
 	wt *git.Worktree,
+	forges *forge.Registry,
 ) error {
 	guesser := spice.Guesser{
-		Select: func(op spice.GuessOp, opts []string, selected string) (string, error) {
-			if !ui.Interactive(view) {
-				return "", errNoPrompt
-			}
-
-			var msg, desc string
+		Forges: forges,
+		Select: func(op spice.GuessOp, opts []spice.GuessOption, selected string) (string, error) {
+			var msg, desc, flag string
 			switch op {
 			case spice.GuessRemote:
 				msg = "Please select a remote"
 				desc = "Merged changes will be pushed to this remote"
+				flag = "--remote"
 			case spice.GuessTrunk:
 				msg = "Please select the trunk branch"
 				desc = "Changes will be merged into this branch"
+				flag = "--trunk"
 			default:
 				must.Failf("unknown guess operation: %v", op)
 			}
 
+			if !ui.Interactive(view) {
+				log.Errorf("Use '%s repo init %s=NAME' to pick one of: %v",
+					cli.Name(), flag, guessOptionValues(opts))
+				return "", errNoPrompt
+			}
+
 			var result string
-			prompt := ui.NewSelect[string]().
+			prompt := ui.NewFuzzyList[string]().
 				WithValue(&result).
-				With(ui.ComparableOptions(selected, opts...)).
+				With(guessFuzzyOptions(selected, opts)).
 				WithTitle(msg).
 				WithDescription(desc)
 			if err := ui.Run(view, prompt); err != nil {
@@ -97,21 +113,42 @@ func (cmd *repoInitCmd) Run(
 		} else {
 			log.Infof("Using remote: %v", cmd.Remote)
 		}
+	} else {
+		remotes, err := repo.ListRemotes(ctx)
+		if err != nil {
+			return fmt.Errorf("list remotes: %w", err)
+		}
+		if !slices.Contains(remotes, cmd.Remote) {
+			return fmt.Errorf("not a remote: %v", cmd.Remote)
+		}
 	}
 
-	if cmd.Trunk == "" {
+	trunkFromUser := cmd.Trunk != ""
+	if !trunkFromUser {
 		var err error
 		cmd.Trunk, err = guesser.GuessTrunk(ctx, repo, wt, cmd.Remote)
 		if err != nil {
 			return fmt.Errorf("guess trunk: %w", err)
 		}
-	} else if !repo.BranchExists(ctx, cmd.Trunk) {
-		// User-provided trunk must be a local branch.
-		log.Errorf("Are you sure %v is a local branch?", cmd.Trunk)
-		return fmt.Errorf("not a branch: %v", cmd.Trunk)
 	}
 	must.NotBeBlankf(cmd.Trunk, "trunk branch must have been set")
 
+	if !repo.BranchExists(ctx, cmd.Trunk) {
+		// The trunk may exist only on the remote,
+		// e.g. in CI, where clones are often in detached HEAD state.
+		created, err := createTrunkFromRemote(ctx, log, repo, cmd.Remote, cmd.Trunk)
+		if err != nil {
+			return err
+		}
+
+		// User-provided trunk must be a local branch.
+		// Guessed trunks may be unborn in repositories without commits.
+		if !created && trunkFromUser {
+			log.Errorf("Are you sure %v is a local branch?", cmd.Trunk)
+			return fmt.Errorf("not a branch: %v", cmd.Trunk)
+		}
+	}
+
 	_, err := state.InitStore(ctx, state.InitStoreRequest{
 		DB:     newRepoStorage(repo, log),
 		Trunk:  cmd.Trunk,
@@ -138,6 +175,76 @@ func (cmd *repoInitCmd) Run(
 	return nil
 }
 
+// createTrunkFromRemote creates a local trunk branch
+// from the remote branch of the same name, if there is one.
+// It reports whether the branch was created.
+func createTrunkFromRemote(
+	ctx context.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	remote, trunk string,
+) (bool, error) {
+	if remote == "" {
+		return false, nil
+	}
+
+	upstream := remote + "/" + trunk
+	hash, err := repo.PeelToCommit(ctx, upstream)
+	if err != nil {
+		return false, nil // not on the remote either
+	}
+
+	if err := repo.CreateBranch(ctx, git.CreateBranchRequest{
+		Name: trunk,
+		Head: hash.String(),
+	}); err != nil {
+		return false, fmt.Errorf("create trunk branch: %w", err)
+	}
+	if err := repo.SetBranchUpstream(ctx, trunk, upstream); err != nil {
+		log.Warn("Could not set upstream of trunk branch", "upstream", upstream, "error", err)
+	}
+
+	log.Infof("Created %v from %v", trunk, upstream)
+	return true, nil
+}
+
+// guessFuzzyOptions builds the options of a prompt
+// from the options of a [spice.Guesser],
+// showing descriptions next to values.
+func guessFuzzyOptions(selected string, opts []spice.GuessOption) func(*ui.FuzzyList[string]) {
+	var selectedIdx int
+	options := make([]ui.SelectOption[string], len(opts))
+	for i, opt := range opts {
+		if opt.Value == selected {
+			selectedIdx = i
+		}
+
+		label := opt.Value
+		if opt.Description != "" {
+			label += " (" + opt.Description + ")"
+		}
+		options[i] = ui.SelectOption[string]{
+			Label: label,
+			Value: opt.Value,
+		}
+	}
+
+	return func(l *ui.FuzzyList[string]) {
+		l.WithOptions(options...)
+		l.WithSelected(selectedIdx)
+	}
+}
+
+// guessOptionValues returns a comma-separated list
+// of the values of the given options.
+func guessOptionValues(opts []spice.GuessOption) string {
+	values := make([]string, len(opts))
+	for i, opt := range opts {
+		values[i] = opt.Value
+	}
+	return strings.Join(values, ", ")
+}
+
 const (
 	_dataRef     = "refs/spice/data"
 	_authorName  = "git-spice"
@@ -166,6 +273,8 @@ func ensureStore(
 	wt *git.Worktree,
 	log *silog.Logger,
 	view ui.View,
+	forges *forge.Registry,
+	cfg *spice.Config,
 ) (*state.Store, error) {
 	db := newRepoStorage(repo, log)
 	store, err := state.OpenStore(ctx, db, log)
@@ -175,7 +284,15 @@ func ensureStore(
 
 	if errors.Is(err, state.ErrUninitialized) {
 		log.Info("Repository not initialized. Initializing.")
-		if err := (&repoInitCmd{}).Run(ctx, log, view, repo, wt); err != nil {
+		// Configured defaults for 'repo init' apply here too
+		// so that a trunk checked into the repository configuration
+		// is used without prompting.
+		var initCmd repoInitCmd
+		if cfg != nil {
+			initCmd.Trunk, _ = cfg.Value("repoInit.trunk")
+			initCmd.Remote, _ = cfg.Value("repoInit.remote")
+		}
+		if err := initCmd.Run(ctx, log, view, repo, wt, forges); err != nil {
 			return nil, fmt.Errorf("auto-initialize: %w", err)
 		}
 
@@ -206,15 +323,15 @@ func ensureRemote(
 	// Guess or prompt for one and update the store.
 	log.Warn("No remote was specified at init time")
 	remote, err = (&spice.Guesser{
-		Select: func(_ spice.GuessOp, opts []string, selected string) (string, error) {
+		Select: func(_ spice.GuessOp, opts []spice.GuessOption, selected string) (string, error) {
 			if !ui.Interactive(view) {
 				return "", errNoPrompt
 			}
 
 			result := selected
-			prompt := ui.NewSelect[string]().
+			prompt := ui.NewFuzzyList[string]().
 				WithValue(&result).
-				With(ui.ComparableOptions(selected, opts...)).
+				With(guessFuzzyOptions(selected, opts)).
 				WithTitle("Please select a remote").
 				WithDescription("Changes will be pushed to this remote")
 			if err := ui.Run(view, prompt); err != nil {
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/lockfile"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// _repoLockEnv is set for child processes of a git-spice command
+// that holds the repository lock, e.g. Git hooks.
+// If these invoke git-spice, it runs without taking the lock again.
+const _repoLockEnv = "__GS_REPO_LOCK"
+
+// readOnlyCmd is implemented by commands
+// that never modify git-spice's state.
+// These don't take the repository lock,
+// so they can run alongside other commands.
+type readOnlyCmd interface{ readOnly() }
+
+func (*logShortCmd) readOnly()         {}
+func (*logLongCmd) readOnly()          {}
+func (*statusCmd) readOnly()           {}
+func (*promptCmd) readOnly()           {}
+func (*branchConfigListCmd) readOnly() {}
+func (*branchNoteShowCmd) readOnly()   {}
+
+// rpc takes the lock for each request that makes changes.
+func (*rpcCmd) readOnly() {}
+
+// isReadOnlyCmd reports whether the selected command is read-only.
+func isReadOnlyCmd(kctx *kong.Context) bool {
+	node := kctx.Selected()
+	if node == nil || !node.Target.CanAddr() {
+		return false
+	}
+	_, ok := node.Target.Addr().Interface().(readOnlyCmd)
+	return ok
+}
+
+// repoLockedError indicates that another git-spice command
+// holds the repository lock.
+type repoLockedError struct {
+	PID int // 0 if unknown
+}
+
+func (e *repoLockedError) Error() string {
+	if e.PID == 0 {
+		return "another gs command is running"
+	}
+	return fmt.Sprintf("another gs command is running (pid %d)", e.PID)
+}
+
+// repoLock prevents git-spice commands that modify a repository
+// from running at the same time and corrupting its state.
+//
+// The lock is advisory: it only guards against other git-spice commands.
+type repoLock struct {
+	Log *silog.Logger // required
+
+	// Force removes a lock held by another command before taking it.
+	Force bool
+
+	lock *lockfile.Lock
+}
+
+// Acquire takes the lock for the given repository.
+// It returns a [*repoLockedError] if another command holds it.
+// Acquire is a no-op if the lock is already held by this process
+// or by a git-spice command that started this process.
+func (l *repoLock) Acquire(repo *git.Repository) error {
+	if l.lock != nil {
+		return nil
+	}
+
+	path := filepath.Join(repo.GitDir(), "spice.lock")
+	if os.Getenv(_repoLockEnv) == path {
+		return nil
+	}
+
+	if l.Force {
+		if err := lockfile.Remove(path); err != nil {
+			return fmt.Errorf("remove lock: %w", err)
+		}
+		l.Log.Warn("Removed repository lock", "path", path)
+		l.Force = false // only once
+	}
+
+	lock, err := lockfile.Acquire(path)
+	if err != nil {
+		var lockedErr *lockfile.LockedError
+		if errors.As(err, &lockedErr) {
+			l.Log.Errorf("Wait for it to finish, or use --force-unlock if it's no longer running.")
+			return &repoLockedError{PID: lockedErr.PID}
+		}
+		return fmt.Errorf("lock repository: %w", err)
+	}
+
+	l.lock = lock
+	if err := os.Setenv(_repoLockEnv, path); err != nil {
+		l.Log.Warn("Could not export repository lock", "error", err)
+	}
+	return nil
+}
+
+// Release releases the lock if it's held.
+func (l *repoLock) Release() {
+	if l.lock == nil {
+		return
+	}
+
+	if err := l.lock.Release(); err != nil {
+		l.Log.Warn("Could not release repository lock", "error", err)
+	}
+	l.lock = nil
+	_ = os.Unsetenv(_repoLockEnv)
+}
+
+// Do runs fn while holding the lock for the given repository.
+func (l *repoLock) Do(repo *git.Repository, fn func() error) error {
+	if l.lock == nil {
+		if err := l.Acquire(repo); err != nil {
+			return err
+		}
+		defer l.Release()
+	}
+
+	return fn()
+}
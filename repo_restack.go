@@ -12,16 +12,22 @@ import (
 	"go.abhg.dev/gs/internal/text"
 )
 
-type repoRestackCmd struct{}
+type repoRestackCmd struct {
+	IncludeUntracked bool `config:"autostash.includeUntracked" released:"unreleased" help:"Also stash untracked files while restacking"`
+}
 
 func (*repoRestackCmd) Help() string {
 	return text.Dedent(`
 		All tracked branches in the repository are rebased on top of their
 		respective bases in dependency order, ensuring a linear history.
+
+		Progress is reported as each branch is restacked,
+		followed by a summary of the status of every branch:
+		restacked, up to date, skipped, or conflicted.
 	`)
 }
 
-func (*repoRestackCmd) Run(
+func (cmd *repoRestackCmd) Run(
 	ctx context.Context,
 	log *silog.Logger,
 	wt *git.Worktree,
@@ -35,9 +41,11 @@ func (*repoRestackCmd) Run(
 	}
 
 	cleanup, err := autostashHandler.BeginAutostash(ctx, &autostash.Options{
-		Message:   "git-spice: autostash before restacking",
-		ResetMode: autostash.ResetHard,
-		Branch:    currentBranch,
+		Message:          "git-spice: autostash before restacking",
+		ResetMode:        autostash.ResetHard,
+		Branch:           currentBranch,
+		Command:          "repo restack",
+		IncludeUntracked: cmd.IncludeUntracked,
 	})
 	if err != nil {
 		return err
@@ -48,6 +56,7 @@ func (*repoRestackCmd) Run(
 		Branch:          store.Trunk(),
 		Scope:           restack.ScopeUpstackExclusive,
 		ContinueCommand: []string{"repo", "restack"},
+		Progress:        true,
 	})
 	if err != nil {
 		return err
@@ -62,6 +71,5 @@ func (*repoRestackCmd) Run(
 		return fmt.Errorf("checkout %v: %w", currentBranch, err)
 	}
 
-	log.Infof("Restacked %d branches", count)
 	return nil
 }
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/checkout"
+	"go.abhg.dev/gs/internal/handler/submit"
+	"go.abhg.dev/gs/internal/jsonrpc"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type rpcCmd struct {
+	submit.Options
+
+	PollInterval time.Duration `name:"poll-interval" hidden:"" default:"1s" help:"How often to check the repository for changes"`
+}
+
+func (*rpcCmd) Help() string {
+	return text.Dedent(`
+		Serves JSON-RPC 2.0 requests over stdin and stdout
+		for editor extensions and other tools.
+		Messages are framed with Content-Length headers,
+		the same as the Language Server Protocol.
+		Logs are written to stderr.
+
+		The following methods are supported:
+
+			graph/list       list tracked branches and the current branch
+			branch/checkout  check out a branch: {"branch": NAME}
+			branch/restack   restack a branch: {"branch": NAME}
+			branch/submit    submit a branch: {"branch": NAME, ...}
+
+		branch/submit accepts the optional parameters
+		"title", "body", "fill", "draft", "dryRun", and "force".
+		Other submit options are taken from the command line
+		and configuration.
+
+		The following notifications are sent
+		when the repository changes for any reason:
+
+			store/changed  tracked branches were changed
+			head/changed   a different branch was checked out: {"branch": NAME}
+
+		Requests that make changes fail if another git-spice command
+		is making changes to the repository at the same time.
+
+		The command never prompts for input.
+		It exits when stdin is closed.
+	`)
+}
+
+func (cmd *rpcCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	wt *git.Worktree,
+	store *state.Store,
+	svc *spice.Service,
+	forges *forge.Registry,
+	checkoutHandler CheckoutHandler,
+	restackHandler RestackHandler,
+	submitHandler SubmitHandler,
+	lock *repoLock,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	srv := jsonrpc.NewServer(os.Stdin, kctx.Stdout, log)
+	srv.Handle("graph/list", func(ctx context.Context, _ json.RawMessage) (any, error) {
+		return rpcListGraph(ctx, wt, store, svc, forges)
+	})
+	srv.Handle("branch/checkout", func(ctx context.Context, params json.RawMessage) (any, error) {
+		var req rpcBranchParams
+		if err := decodeRPCParams(params, &req, &req.Branch); err != nil {
+			return nil, err
+		}
+		return nil, lock.Do(repo, func() error {
+			return checkoutHandler.CheckoutBranch(ctx, &checkout.Request{
+				Branch: req.Branch,
+			})
+		})
+	})
+	srv.Handle("branch/restack", func(ctx context.Context, params json.RawMessage) (any, error) {
+		var req rpcBranchParams
+		if err := decodeRPCParams(params, &req, &req.Branch); err != nil {
+			return nil, err
+		}
+		return nil, lock.Do(repo, func() error {
+			return restackHandler.RestackBranch(ctx, req.Branch)
+		})
+	})
+	srv.Handle("branch/submit", func(ctx context.Context, params json.RawMessage) (any, error) {
+		var req rpcSubmitParams
+		if err := decodeRPCParams(params, &req, &req.Branch); err != nil {
+			return nil, err
+		}
+
+		opts := cmd.Options // copy so requests don't affect each other
+		opts.Fill = opts.Fill || req.Fill
+		opts.DryRun = opts.DryRun || req.DryRun
+		opts.Force = opts.Force || req.Force
+		if req.Draft != nil {
+			opts.Draft = req.Draft
+		}
+		return nil, lock.Do(repo, func() error {
+			return submitHandler.Submit(ctx, &submit.Request{
+				Branch:  req.Branch,
+				Title:   req.Title,
+				Body:    req.Body,
+				Options: &opts,
+			})
+		})
+	})
+
+	go (&rpcWatcher{
+		Log:      log,
+		Repo:     repo,
+		Worktree: wt,
+		Notify:   srv.Notify,
+		Interval: cmd.PollInterval,
+	}).Watch(ctx)
+
+	return srv.Serve(ctx)
+}
+
+// rpcBranchParams are the parameters of methods
+// that operate on a single branch.
+type rpcBranchParams struct {
+	Branch string `json:"branch"`
+}
+
+// rpcSubmitParams are the parameters of branch/submit.
+type rpcSubmitParams struct {
+	Branch string `json:"branch"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body,omitempty"`
+	Fill   bool   `json:"fill,omitempty"`
+	Draft  *bool  `json:"draft,omitempty"`
+	DryRun bool   `json:"dryRun,omitempty"`
+	Force  bool   `json:"force,omitempty"`
+}
+
+// decodeRPCParams decodes the parameters of a request
+// that operates on a branch.
+func decodeRPCParams(params json.RawMessage, v any, branch *string) error {
+	if len(params) == 0 {
+		return &jsonrpc.Error{Code: jsonrpc.InvalidParams, Message: "missing params"}
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &jsonrpc.Error{Code: jsonrpc.InvalidParams, Message: err.Error()}
+	}
+	if *branch == "" {
+		return &jsonrpc.Error{Code: jsonrpc.InvalidParams, Message: "missing branch"}
+	}
+	return nil
+}
+
+// rpcGraph is the result of graph/list.
+type rpcGraph struct {
+	Trunk    string      `json:"trunk"`
+	Current  string      `json:"current,omitempty"` // empty if detached
+	Branches []rpcBranch `json:"branches"`
+}
+
+// rpcBranch is a tracked branch reported by graph/list.
+type rpcBranch struct {
+	Name         string `json:"name"`
+	Base         string `json:"base"`
+	Head         string `json:"head"`
+	NeedsRestack bool   `json:"needsRestack,omitempty"`
+	Change       string `json:"change,omitempty"` // e.g. "#123"
+	Upstream     string `json:"upstream,omitempty"`
+}
+
+func rpcListGraph(
+	ctx context.Context,
+	wt *git.Worktree,
+	store *state.Store,
+	svc *spice.Service,
+	forges *forge.Registry,
+) (*rpcGraph, error) {
+	current, err := wt.CurrentBranch(ctx)
+	if err != nil && !errors.Is(err, git.ErrDetachedHead) {
+		return nil, fmt.Errorf("get current branch: %w", err)
+	}
+
+	items, err := svc.LoadBranches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load branches: %w", err)
+	}
+
+	graph := &rpcGraph{
+		Trunk:    store.Trunk(),
+		Current:  current,
+		Branches: make([]rpcBranch, 0, len(items)),
+	}
+	for _, item := range items {
+		b := rpcBranch{
+			Name:     item.Name,
+			Base:     item.Base,
+			Head:     item.Head.String(),
+			Upstream: item.UpstreamBranch,
+		}
+
+		var restackErr *spice.BranchNeedsRestackError
+		if err := svc.VerifyRestacked(ctx, item.Name); errors.As(err, &restackErr) {
+			b.NeedsRestack = true
+		}
+
+		if item.Change != nil {
+			f, _ := forges.Lookup(item.Change.ForgeID())
+			b.Change = forge.FormatChangeID(f, item.Change.ChangeID())
+		}
+
+		graph.Branches = append(graph.Branches, b)
+	}
+	return graph, nil
+}
+
+// rpcWatcher polls the repository for changes
+// and notifies the client about them.
+type rpcWatcher struct {
+	Log      *silog.Logger
+	Repo     *git.Repository
+	Worktree *git.Worktree
+	Notify   func(method string, params any) error
+	Interval time.Duration
+}
+
+// Watch polls until the context is canceled.
+func (w *rpcWatcher) Watch(ctx context.Context) {
+	storeHash, head := w.poll(ctx)
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		newStoreHash, newHead := w.poll(ctx)
+		if newStoreHash != storeHash {
+			storeHash = newStoreHash
+			w.notify("store/changed", struct{}{})
+		}
+		if newHead != head {
+			head = newHead
+			w.notify("head/changed", map[string]string{"branch": head})
+		}
+	}
+}
+
+// poll reports the commit at the head of the data store
+// and the current branch.
+// Either is empty if it can't be determined.
+func (w *rpcWatcher) poll(ctx context.Context) (storeHash git.Hash, head string) {
+	storeHash, _ = w.Repo.PeelToCommit(ctx, _dataRef)
+	head, _ = w.Worktree.CurrentBranch(ctx)
+	return storeHash, head
+}
+
+func (w *rpcWatcher) notify(method string, params any) {
+	if err := w.Notify(method, params); err != nil {
+		w.Log.Warn("Could not send notification", "method", method, "error", err)
+	}
+}
@@ -69,7 +69,7 @@ func TestMain(m *testing.M) {
 			// If ROBOT_INPUT is set, install a uitest.RobotView
 			// instead of the normal view. This will always be interactive.
 			if fixtureFile := os.Getenv("ROBOT_INPUT"); fixtureFile != "" {
-				_buildView = func(_ io.Reader, stderr io.Writer, _ bool) (ui.View, error) {
+				_buildView = func(_ io.Reader, stderr io.Writer, _, _ bool) (ui.View, error) {
 					return uitest.NewRobotView(
 						fixtureFile,
 						&uitest.RobotViewOptions{
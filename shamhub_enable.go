@@ -0,0 +1,14 @@
+//go:build shamhub
+
+package main
+
+import "go.abhg.dev/gs/internal/forge/shamhub"
+
+// Building with the "shamhub" build tag adds support for
+// ShamHub servers run with 'shamhub serve'.
+// The server is selected with the SHAMHUB_URL
+// and SHAMHUB_API_URL environment variables.
+
+func init() {
+	_extraForges = append(_extraForges, new(shamhub.Forge))
+}
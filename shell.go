@@ -2,4 +2,5 @@ package main
 
 type shellCmd struct {
 	Completion shellCompletionCmd `cmd:"" help:"Generate shell completion script"`
+	Aliases    shellAliasesCmd    `cmd:"" released:"unreleased" help:"Install Git aliases for git-spice commands"`
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+// _gitAliases are the Git aliases installed by 'shell aliases',
+// mapping alias names to git-spice commands.
+var _gitAliases = []struct {
+	Name    string
+	Command string
+}{
+	{"stack", "stack"},
+	{"submit", "stack submit"},
+	{"restack", "stack restack"},
+	{"sync", "repo sync"},
+	{"stacks", "log short"},
+	{"up", "up"},
+	{"down", "down"},
+	{"top", "top"},
+	{"bottom", "bottom"},
+}
+
+type shellAliasesCmd struct {
+	Remove bool `help:"Remove aliases installed by this command"`
+	Local  bool `help:"Change aliases for the current repository only"`
+	Force  bool `help:"Replace aliases that already exist with a different meaning"`
+}
+
+func (*shellAliasesCmd) Help() string {
+	var s strings.Builder
+	for _, alias := range _gitAliases {
+		fmt.Fprintf(&s, "\tgit %-8s  %s %s\n", alias.Name, cli.Name(), alias.Command)
+	}
+
+	return fmt.Sprintf(text.Dedent(`
+		Installs Git aliases that run git-spice commands:
+
+		%s
+		Aliases are installed for all repositories of the current user.
+		Use --local to install them for the current repository only.
+		Run the command again after upgrading to pick up new aliases.
+
+		Aliases that already exist with a different meaning
+		are left unchanged unless --force is used.
+		Aliases that have the same name as a Git command are skipped
+		because Git ignores them.
+
+		Use --remove to remove the aliases installed by this command.
+		Aliases that were changed since they were installed are kept.
+	`), s.String())
+}
+
+func (cmd *shellAliasesCmd) Run(ctx context.Context, log *silog.Logger) error {
+	cfg := git.NewConfig(git.ConfigOptions{Log: log})
+	opts := &git.ConfigSetOptions{Global: !cmd.Local}
+
+	existing := make(map[string]string) // alias name => value
+	for entry, err := range cfg.ListRegexp(ctx, `^alias\.`) {
+		if err != nil {
+			return fmt.Errorf("list aliases: %w", err)
+		}
+		existing[strings.ToLower(entry.Key.Name())] = entry.Value
+	}
+
+	// Git ignores aliases that have the same name as a Git command.
+	var commands []string
+	if !cmd.Remove {
+		var err error
+		commands, err = cfg.ListCommands(ctx)
+		if err != nil {
+			log.Warn("Could not list Git commands", "error", err)
+		}
+	}
+
+	for _, alias := range _gitAliases {
+		key := git.ConfigKey("alias." + alias.Name)
+		want := "!" + cli.Name() + " " + alias.Command
+		got, ok := existing[alias.Name]
+
+		// Aliases that run git-spice are ours to update or remove.
+		ours := strings.HasPrefix(got, "!"+cli.Name()+" ")
+
+		if cmd.Remove {
+			if !ok || !ours {
+				continue
+			}
+			if got != want {
+				log.Warnf("git %v: changed since it was installed, keeping: %v", alias.Name, got)
+				continue
+			}
+			if err := cfg.Unset(ctx, key, opts); err != nil {
+				return fmt.Errorf("remove %v: %w", key, err)
+			}
+			log.Infof("git %v: removed", alias.Name)
+			continue
+		}
+
+		switch {
+		case slices.Contains(commands, alias.Name):
+			log.Warnf("git %v: a Git command with this name already exists, skipping", alias.Name)
+			continue
+
+		case got == want:
+			log.Debugf("git %v: already installed", alias.Name)
+			continue
+
+		case ok && !ours && !cmd.Force:
+			log.Warnf("git %v: already an alias for %q, skipping. Use --force to replace it.", alias.Name, got)
+			continue
+		}
+
+		if err := cfg.Set(ctx, key, want, opts); err != nil {
+			return fmt.Errorf("set %v: %w", key, err)
+		}
+		log.Infof("git %v: runs '%v %v'", alias.Name, cli.Name(), alias.Command)
+	}
+
+	return nil
+}
@@ -1,18 +1,23 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/alecthomas/kong"
 	"go.abhg.dev/gs/internal/cli"
 	"go.abhg.dev/gs/internal/forge"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/sliceutil"
+	"go.abhg.dev/gs/internal/spice"
 	"go.abhg.dev/gs/internal/spice/state"
 	"go.abhg.dev/gs/internal/text"
 	"go.abhg.dev/komplete"
@@ -143,3 +148,74 @@ func predictForges(forges *forge.Registry) func(komplete.Args) (predictions []st
 		return ids
 	}
 }
+
+// predictChanges predicts the numbers of change requests
+// submitted for tracked branches.
+//
+// This uses only the change metadata stored with each branch,
+// so it doesn't make network requests.
+// Change IDs are predicted without forge-specific prefixes
+// (e.g. "123" instead of "#123") because they're accepted everywhere
+// and some shells treat "#" specially.
+func predictChanges(forges *forge.Registry) func(komplete.Args) (predictions []string) {
+	return func(komplete.Args) (predictions []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		repo, err := git.Open(ctx, ".", git.OpenOptions{})
+		if err != nil {
+			return nil
+		}
+
+		db := newRepoStorage(repo, nil /* log */)
+		store, err := state.OpenStore(ctx, db, nil /* log */)
+		if err != nil {
+			return nil // not initialized
+		}
+
+		branches, err := sliceutil.CollectErr(store.ListBranches(ctx))
+		if err != nil {
+			return nil
+		}
+
+		for _, name := range branches {
+			b, err := store.LookupBranch(ctx, name)
+			if err != nil || b.ChangeForge == "" {
+				continue
+			}
+
+			f, ok := forges.Lookup(b.ChangeForge)
+			if !ok {
+				continue
+			}
+
+			md, err := f.UnmarshalChangeMetadata(b.ChangeMetadata)
+			if err != nil {
+				continue
+			}
+
+			id := strings.TrimLeftFunc(md.ChangeID().String(), func(r rune) bool {
+				return !unicode.IsDigit(r)
+			})
+			if id != "" {
+				predictions = append(predictions, id)
+			}
+		}
+
+		// Sort numerically: shorter numbers first.
+		slices.SortFunc(predictions, func(a, b string) int {
+			return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+		})
+		return slices.Compact(predictions)
+	}
+}
+
+// predictConfigKeys predicts git-spice configuration keys.
+func predictConfigKeys(app *kong.Application) func(komplete.Args) (predictions []string) {
+	return func(komplete.Args) (predictions []string) {
+		for _, opt := range spice.NewConfigSchema(app).Options() {
+			predictions = append(predictions, opt.Key)
+		}
+		return predictions
+	}
+}
@@ -1,8 +1,14 @@
 package main
 
 type stackCmd struct {
-	Submit  stackSubmitCmd  `cmd:"" aliases:"s" help:"Submit a stack"`
-	Restack stackRestackCmd `cmd:"" aliases:"r" help:"Restack a stack"`
-	Edit    stackEditCmd    `cmd:"" aliases:"e" help:"Edit the order of branches in a stack"`
-	Delete  stackDeleteCmd  `cmd:"" aliases:"d" released:"v0.16.0" help:"Delete all branches in a stack"`
+	Submit   stackSubmitCmd   `cmd:"" aliases:"s" help:"Submit a stack"`
+	Restack  stackRestackCmd  `cmd:"" aliases:"r" help:"Restack a stack"`
+	Edit     stackEditCmd     `cmd:"" aliases:"e" help:"Edit the order of branches in a stack"`
+	Delete   stackDeleteCmd   `cmd:"" aliases:"d" released:"v0.16.0" help:"Delete all branches in a stack"`
+	Test     stackTestCmd     `cmd:"" aliases:"t" released:"unreleased" help:"Run a command on each branch in a stack"`
+	Plan     stackPlanCmd     `cmd:"" aliases:"p" released:"unreleased" help:"Plan a stack of branches up front"`
+	Describe stackDescribeCmd `cmd:"" released:"unreleased" help:"Post a description of the whole stack on its topmost CR"`
+	Pull     stackPullCmd     `cmd:"" released:"unreleased" help:"Pull a stack of CRs submitted by someone else"`
+	Export   stackExportCmd   `cmd:"" released:"unreleased" help:"Export a stack as a series of patches"`
+	Import   stackImportCmd   `cmd:"" released:"unreleased" help:"Import a series of patches as a stack"`
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/submit"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stackDescribeCmd struct {
+	Branch string `placeholder:"NAME" help:"Branch whose stack to describe. Defaults to current." predictor:"trackedBranches"`
+}
+
+func (*stackDescribeCmd) Help() string {
+	return text.Dedent(`
+		Posts a comment on the topmost Change Request of the stack
+		that lists all Change Requests in the stack
+		with their titles and states, in dependency order.
+		This gives reviewers the big picture of the stack.
+
+		If the stack branches out, the comment is posted
+		on the topmost Change Request of each branch.
+		The comment is updated if it was posted before.
+
+		Merged downstack Change Requests are included.
+		Branches that haven't been submitted are not.
+
+		Use --branch to describe the stack of a different branch.
+	`)
+}
+
+func (cmd *stackDescribeCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *stackDescribeCmd) Run(
+	ctx context.Context,
+	store *state.Store,
+	svc *spice.Service,
+	submitHandler SubmitHandler,
+) error {
+	stack, err := svc.ListStack(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("list stack: %w", err)
+	}
+	stack = slices.DeleteFunc(stack, func(branch string) bool {
+		return branch == store.Trunk()
+	})
+	if len(stack) == 0 {
+		return fmt.Errorf("%v: no branches in the stack", cmd.Branch)
+	}
+
+	return submitHandler.DescribeStack(ctx, &submit.DescribeStackRequest{
+		Branches: stack,
+	})
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/secret"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stackExportCmd struct {
+	Format        string `default:"mbox" enum:"mbox" help:"Output format. Only 'mbox' is supported."`
+	Branch        string `placeholder:"NAME" help:"Branch whose stack to export. Defaults to current." predictor:"trackedBranches"`
+	Output        string `short:"o" placeholder:"FILE" help:"Write the patches to FILE instead of stdout"`
+	SubjectPrefix string `placeholder:"PREFIX" default:"PATCH" help:"Prefix for patch subjects instead of 'PATCH', e.g. 'PATCH v2'"`
+}
+
+func (*stackExportCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Exports the current stack as a series of patches
+		for projects that accept patches by email
+		instead of Change Requests.
+
+		Each branch becomes a separate patch series,
+		from the bottom of the stack to the top,
+		in the format produced by 'git format-patch'.
+		Each series starts with a cover letter
+		using the title and description of the branch's Change Request.
+		Branches that haven't been submitted
+		use the branch description instead (see '%[1]s branch describe'),
+		or the branch name if there isn't one.
+
+		The output may be sent with 'git send-email'
+		or applied with 'git am'.
+		Use -o to write it to a file instead of stdout,
+		and --subject-prefix to mark revisions, e.g. 'PATCH v2'.
+		Use --branch to export the stack of a different branch.
+	`, cli.Name()))
+}
+
+func (cmd *stackExportCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+func (cmd *stackExportCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	store *state.Store,
+	svc *spice.Service,
+	secretStash secret.Stash,
+	forges *forge.Registry,
+) error {
+	stack, err := svc.ListStack(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("list stack: %w", err)
+	}
+	stack = slices.DeleteFunc(stack, func(branch string) bool {
+		return branch == store.Trunk()
+	})
+	if len(stack) == 0 {
+		return fmt.Errorf("%v: no branches in the stack", cmd.Branch)
+	}
+
+	// The forge is opened only if a branch was submitted.
+	// If it can't be reached, cover letters use branch descriptions.
+	var (
+		remoteRepo   forge.Repository
+		remoteOpened bool
+	)
+	openRemote := func() forge.Repository {
+		if remoteOpened {
+			return remoteRepo
+		}
+		remoteOpened = true
+
+		remote, err := store.Remote()
+		if err != nil {
+			return nil
+		}
+		remoteRepo, err = openRemoteRepositorySilent(ctx, secretStash, forges, repo, store, remote)
+		if err != nil {
+			log.Warn("Could not reach forge: using branch descriptions for cover letters", "error", err)
+			remoteRepo = nil
+		}
+		return remoteRepo
+	}
+
+	var out bytes.Buffer
+	for _, branch := range stack {
+		b, err := svc.LookupBranch(ctx, branch)
+		if err != nil {
+			return fmt.Errorf("look up %v: %w", branch, err)
+		}
+
+		var patches bytes.Buffer
+		if err := repo.FormatPatch(ctx, &git.FormatPatchRequest{
+			Base:          b.BaseHash.String(),
+			Head:          b.Head.String(),
+			CoverLetter:   true,
+			SubjectPrefix: cmd.SubjectPrefix,
+		}, &patches); err != nil {
+			return fmt.Errorf("format patches for %v: %w", branch, err)
+		}
+		if patches.Len() == 0 {
+			log.Warnf("%v: no commits, skipping", branch)
+			continue
+		}
+
+		subject, body, err := stackExportCoverLetter(ctx, repo, openRemote, branch, b.Change)
+		if err != nil {
+			return err
+		}
+		out.Write(fillCoverLetter(patches.Bytes(), subject, body))
+		log.Infof("%v: exported %v", branch, subject)
+	}
+
+	if cmd.Output == "" || cmd.Output == "-" {
+		_, err := kctx.Stdout.Write(out.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(cmd.Output, out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write patches: %w", err)
+	}
+	log.Infof("Wrote patches to %v", cmd.Output)
+	return nil
+}
+
+// stackExportCoverLetter picks the subject and body of the cover letter
+// for a branch's patch series.
+//
+// In order of preference, it uses the branch's change request,
+// its branch description, or its name.
+func stackExportCoverLetter(
+	ctx context.Context,
+	repo *git.Repository,
+	openRemote func() forge.Repository,
+	branch string,
+	change forge.ChangeMetadata,
+) (subject, body string, _ error) {
+	if change != nil {
+		if remoteRepo := openRemote(); remoteRepo != nil {
+			desc, err := remoteRepo.ChangeDescription(ctx, change.ChangeID())
+			if err == nil {
+				return desc.Subject, desc.Body, nil
+			}
+			if !errors.Is(err, forge.ErrNotFound) {
+				return "", "", fmt.Errorf("%v: get change description: %w", branch, err)
+			}
+		}
+	}
+
+	desc, err := repo.BranchDescription(ctx, branch)
+	if err != nil {
+		return "", "", fmt.Errorf("%v: get branch description: %w", branch, err)
+	}
+	if desc != "" {
+		subject, body, _ = strings.Cut(desc, "\n")
+		return strings.TrimSpace(subject), body, nil
+	}
+
+	return branch, "", nil
+}
+
+const (
+	_coverLetterSubject = "*** SUBJECT HERE ***"
+	_coverLetterBlurb   = "*** BLURB HERE ***"
+)
+
+// fillCoverLetter replaces the placeholders in the cover letter
+// generated by 'git format-patch' with the given subject and body.
+// An empty body drops the placeholder.
+func fillCoverLetter(patches []byte, subject, body string) []byte {
+	header, rest, ok := bytes.Cut(patches, []byte("\n\n"))
+	if !ok {
+		return patches
+	}
+
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	header = bytes.Replace(header,
+		[]byte(_coverLetterSubject),
+		[]byte(mime.QEncoding.Encode("utf-8", subject)), 1)
+	if !isASCII(body) && !bytes.Contains(header, []byte("\nContent-Type:")) {
+		// The placeholder was plain ASCII,
+		// so git didn't declare an encoding.
+		header = append(header, "\nMIME-Version: 1.0"+
+			"\nContent-Type: text/plain; charset=UTF-8"+
+			"\nContent-Transfer-Encoding: 8bit"...)
+	}
+
+	if body == "" {
+		rest = bytes.Replace(rest, []byte(_coverLetterBlurb+"\n\n"), nil, 1)
+	} else {
+		rest = bytes.Replace(rest, []byte(_coverLetterBlurb), []byte(body), 1)
+	}
+
+	out := make([]byte, 0, len(header)+len(rest)+2)
+	out = append(out, header...)
+	out = append(out, "\n\n"...)
+	return append(out, rest...)
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/text"
+)
+
+func TestFillCoverLetter(t *testing.T) {
+	coverLetter := text.Dedent(`
+		From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+		From: Test <test@example.com>
+		Subject: [PATCH 0/1] *** SUBJECT HERE ***
+
+		*** BLURB HERE ***
+
+		Test (1):
+		  Add feature
+
+	`)
+
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    string
+	}{
+		{
+			name:    "Body",
+			subject: "Add a feature",
+			body:    "It does things.\r\n\r\nMany things.\r\n",
+			want: text.Dedent(`
+				From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+				From: Test <test@example.com>
+				Subject: [PATCH 0/1] Add a feature
+
+				It does things.
+
+				Many things.
+
+				Test (1):
+				  Add feature
+
+			`),
+		},
+		{
+			name:    "NoBody",
+			subject: "feature",
+			want: text.Dedent(`
+				From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+				From: Test <test@example.com>
+				Subject: [PATCH 0/1] feature
+
+				Test (1):
+				  Add feature
+
+			`),
+		},
+		{
+			name:    "NonASCII",
+			subject: "Ajouter une fonctionnalité",
+			body:    "Ça marche.",
+			want: text.Dedent(`
+				From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+				From: Test <test@example.com>
+				Subject: [PATCH 0/1] =?utf-8?q?Ajouter_une_fonctionnalit=C3=A9?=
+				MIME-Version: 1.0
+				Content-Type: text/plain; charset=UTF-8
+				Content-Transfer-Encoding: 8bit
+
+				Ça marche.
+
+				Test (1):
+				  Add feature
+
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fillCoverLetter([]byte(coverLetter), tt.subject, tt.body)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
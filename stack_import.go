@@ -0,0 +1,433 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stackImportCmd struct {
+	branchCreateConfig
+
+	FromMbox string `name:"from-mbox" required:"" placeholder:"PATH" help:"mbox file or directory of patch files to import"`
+	Base     string `placeholder:"BRANCH" predictor:"trackedBranches" help:"Branch to stack the imported branches on. Defaults to the current branch."`
+	PerPatch bool   `help:"Create a branch for each patch instead of each patch series"`
+}
+
+func (*stackImportCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Applies a series of patches received by email
+		as a stack of tracked branches,
+		so that they can be reviewed and submitted as Change Requests.
+
+		The patches are read from an mbox file,
+		or from a directory of .patch files
+		as written by 'git format-patch'.
+		Each patch series becomes a branch
+		stacked on the branch for the previous series.
+		A series starts at a cover letter ("[PATCH 0/N]")
+		or at its first patch ("[PATCH 1/N]").
+		Use --per-patch to create a branch for each patch instead.
+
+		Branch names are generated from the subject of the cover letter,
+		or the first patch if there isn't one.
+		The cover letter is saved as the branch description,
+		which becomes the title and body of the Change Request
+		when the branch is submitted.
+		See '%[1]s branch describe' for more.
+
+		Patches are applied in a temporary worktree,
+		so the current worktree is left untouched
+		if any of them fail to apply.
+		The topmost branch is checked out afterwards.
+		Use --base to stack the branches on a branch
+		other than the current branch.
+	`, cli.Name()))
+}
+
+// patchEmail is a single email in a patch series.
+type patchEmail struct {
+	Path string // path to the email
+	Info *git.MailInfo
+}
+
+// patchSeries is a group of patch emails imported as a single branch.
+type patchSeries struct {
+	// Cover is the cover letter of the series, if any.
+	Cover *git.MailInfo
+
+	// Patches are the emails with patches, in order.
+	Patches []patchEmail
+}
+
+func (cmd *stackImportCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	wt *git.Worktree,
+	store *state.Store,
+	svc *spice.Service,
+) (err error) {
+	base := cmd.Base
+	if base == "" {
+		base, err = wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+	}
+
+	var baseHash git.Hash
+	if base == store.Trunk() {
+		baseHash, err = repo.PeelToCommit(ctx, base)
+		if err != nil {
+			return fmt.Errorf("resolve %v: %w", base, err)
+		}
+	} else {
+		baseInfo, err := svc.LookupBranch(ctx, base)
+		if err != nil {
+			if errors.Is(err, git.ErrNotExist) {
+				return fmt.Errorf("branch does not exist: %v", base)
+			}
+			if errors.Is(err, state.ErrNotExist) {
+				return fmt.Errorf("branch not tracked: %v", base)
+			}
+			return fmt.Errorf("lookup branch %v: %w", base, err)
+		}
+		baseHash = baseInfo.Head
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gs-stack-import-")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Warn("Could not remove temporary directory", "path", tmpDir, "error", err)
+		}
+	}()
+
+	emails, err := cmd.readEmails(ctx, repo, filepath.Join(tmpDir, "mail"))
+	if err != nil {
+		return err
+	}
+	series := groupPatchEmails(emails, cmd.PerPatch)
+	if len(series) == 0 {
+		return fmt.Errorf("no patches found in %v", cmd.FromMbox)
+	}
+
+	applyWt, err := repo.AddWorktree(ctx, &git.AddWorktreeRequest{
+		Path:      filepath.Join(tmpDir, "worktree"),
+		Commitish: baseHash.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("create temporary worktree: %w", err)
+	}
+	defer func() {
+		// Clean up even if the command was interrupted.
+		ctx := context.WithoutCancel(ctx)
+		if err := repo.RemoveWorktree(ctx, applyWt.RootDir(), &git.RemoveWorktreeOptions{Force: true}); err != nil {
+			log.Warn("Could not remove temporary worktree", "path", applyWt.RootDir(), "error", err)
+		}
+	}()
+
+	type importBranch struct {
+		Name        string
+		Head        git.Hash
+		Description string
+		Patches     int
+	}
+	branches := make([]importBranch, 0, len(series))
+	usedNames := make(map[string]struct{}, len(series))
+	for _, s := range series {
+		title := s.title()
+		paths := make([]string, len(s.Patches))
+		for idx, p := range s.Patches {
+			paths[idx] = p.Path
+		}
+		if err := applyWt.ApplyMailbox(ctx, paths...); err != nil {
+			log.Errorf("Could not apply %q on %v", title, base)
+			return fmt.Errorf("apply patches: %w", err)
+		}
+
+		head, err := applyWt.Head(ctx)
+		if err != nil {
+			return fmt.Errorf("get head: %w", err)
+		}
+
+		name, err := cmd.branchName(ctx, repo, title, usedNames)
+		if err != nil {
+			return err
+		}
+		usedNames[name] = struct{}{}
+
+		branches = append(branches, importBranch{
+			Name:        name,
+			Head:        head,
+			Description: s.description(),
+			Patches:     len(s.Patches),
+		})
+	}
+
+	branchTx := store.BeginBranchTx()
+	prev, prevHash := base, baseHash
+	for _, b := range branches {
+		if err := branchTx.Upsert(ctx, state.UpsertRequest{
+			Name:     b.Name,
+			Base:     prev,
+			BaseHash: prevHash,
+		}); err != nil {
+			return fmt.Errorf("add branch %v with base %v: %w", b.Name, prev, err)
+		}
+		prev, prevHash = b.Name, b.Head
+	}
+
+	// If any branch fails to be created,
+	// delete the ones we did create so the import can be retried.
+	var created []string
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		for _, name := range created {
+			if delErr := repo.DeleteBranch(ctx, name, git.BranchDeleteOptions{Force: true}); delErr != nil {
+				log.Warn("Could not delete branch", "branch", name, "error", delErr)
+			}
+		}
+	}()
+
+	for _, b := range branches {
+		if err := repo.CreateBranch(ctx, git.CreateBranchRequest{
+			Name: b.Name,
+			Head: b.Head.String(),
+		}); err != nil {
+			return fmt.Errorf("create branch %v: %w", b.Name, err)
+		}
+		created = append(created, b.Name)
+
+		if b.Description != "" {
+			if err := repo.SetBranchDescription(ctx, b.Name, b.Description); err != nil {
+				return fmt.Errorf("set description of %v: %w", b.Name, err)
+			}
+		}
+	}
+
+	msg := fmt.Sprintf("stack import: create %d branches on %v", len(branches), base)
+	if err := branchTx.Commit(ctx, msg); err != nil {
+		return fmt.Errorf("update branch state: %w", err)
+	}
+	created = nil // tracked now; keep them
+
+	prev = base
+	for _, b := range branches {
+		log.Infof("%v: imported %d patch(es) on %v", b.Name, b.Patches, prev)
+		prev = b.Name
+	}
+
+	top := branches[len(branches)-1].Name
+	if err := wt.CheckoutBranch(ctx, top); err != nil {
+		return fmt.Errorf("checkout branch %v: %w", top, err)
+	}
+	return nil
+}
+
+// readEmails splits the mailbox or patch directory being imported
+// into individual emails in dir.
+func (cmd *stackImportCmd) readEmails(ctx context.Context, repo *git.Repository, dir string) ([]patchEmail, error) {
+	info, err := os.Stat(cmd.FromMbox)
+	if err != nil {
+		return nil, fmt.Errorf("read patches: %w", err)
+	}
+
+	mailboxes := []string{cmd.FromMbox}
+	if info.IsDir() {
+		mailboxes, err = filepath.Glob(filepath.Join(cmd.FromMbox, "*.patch"))
+		if err != nil {
+			return nil, fmt.Errorf("list patches: %w", err)
+		}
+		if len(mailboxes) == 0 {
+			return nil, fmt.Errorf("no .patch files found in %v", cmd.FromMbox)
+		}
+		slices.Sort(mailboxes)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create directory: %w", err)
+	}
+	paths, err := repo.SplitMailbox(ctx, dir, mailboxes...)
+	if err != nil {
+		return nil, fmt.Errorf("split patches: %w", err)
+	}
+
+	emails := make([]patchEmail, len(paths))
+	for idx, path := range paths {
+		info, err := repo.ReadMailInfo(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("read patch %d: %w", idx+1, err)
+		}
+		emails[idx] = patchEmail{Path: path, Info: info}
+	}
+	return emails, nil
+}
+
+// branchName generates a name for a branch imported from the given title,
+// avoiding existing branches and names in used.
+func (cmd *stackImportCmd) branchName(
+	ctx context.Context,
+	repo *git.Repository,
+	title string,
+	used map[string]struct{},
+) (string, error) {
+	if !strings.ContainsFunc(title, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}) {
+		title = "patch"
+	}
+
+	msgName, err := cmd.generateBranchName(ctx, repo, title)
+	if err != nil {
+		return "", err
+	}
+
+	taken := func(name string) bool {
+		_, ok := used[name]
+		return ok || repo.BranchExists(ctx, name)
+	}
+	name := cmd.Prefix + msgName
+	for num := 2; taken(name); num++ {
+		name = fmt.Sprintf("%s%s-%d", cmd.Prefix, msgName, num)
+	}
+
+	if err := cmd.checkBranchName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// _patchNumberRegexp matches the number of a patch in a series
+// in an email subject, e.g. "[PATCH v2 1/3]".
+var _patchNumberRegexp = regexp.MustCompile(`^\[[^\]]*?\b(\d+)/\d+\]`)
+
+// _subjectPrefixRegexp matches bracketed prefixes
+// added to patch email subjects, e.g. "[PATCH 1/3]" or "[RFC]".
+var _subjectPrefixRegexp = regexp.MustCompile(`^(\[[^\]]*\]\s*)+`)
+
+// groupPatchEmails groups emails into patch series, in order.
+//
+// A new series starts at a cover letter,
+// or at a patch numbered 1 or not numbered at all.
+// If perPatch is set, each patch is its own series
+// and cover letters are ignored.
+func groupPatchEmails(emails []patchEmail, perPatch bool) []*patchSeries {
+	var (
+		series []*patchSeries
+		cur    *patchSeries
+	)
+	for _, email := range emails {
+		isCover := email.Info.Patch == ""
+		if perPatch {
+			if !isCover {
+				series = append(series, &patchSeries{Patches: []patchEmail{email}})
+			}
+			continue
+		}
+
+		num, numbered := patchNumber(email.Info.Subject)
+		if cur == nil || isCover || (len(cur.Patches) > 0 && (!numbered || num <= 1)) {
+			cur = new(patchSeries)
+			series = append(series, cur)
+		}
+
+		if isCover {
+			cur.Cover = email.Info
+		} else {
+			cur.Patches = append(cur.Patches, email)
+		}
+	}
+
+	return slices.DeleteFunc(series, func(s *patchSeries) bool {
+		return len(s.Patches) == 0
+	})
+}
+
+// patchNumber reports the position of a patch in its series
+// based on its subject, if it's numbered.
+func patchNumber(subject string) (int, bool) {
+	m := _patchNumberRegexp.FindStringSubmatch(subject)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	return n, err == nil
+}
+
+// stripSubjectPrefix removes prefixes like "[PATCH 1/2]" from a subject.
+func stripSubjectPrefix(subject string) string {
+	return strings.TrimSpace(_subjectPrefixRegexp.ReplaceAllString(subject, ""))
+}
+
+// coverSubject returns the subject of the series' cover letter,
+// or an empty string if it doesn't have one
+// or the subject wasn't filled in.
+func (s *patchSeries) coverSubject() string {
+	if s.Cover == nil {
+		return ""
+	}
+	subject := stripSubjectPrefix(s.Cover.Subject)
+	if subject == _coverLetterSubject {
+		return ""
+	}
+	return subject
+}
+
+// title returns a short description of the series.
+func (s *patchSeries) title() string {
+	if subject := s.coverSubject(); subject != "" {
+		return subject
+	}
+	return stripSubjectPrefix(s.Patches[0].Info.Subject)
+}
+
+// _shortlogHeaderRegexp matches the first line of a shortlog
+// added to cover letters by git format-patch, e.g. "Alice (2):".
+var _shortlogHeaderRegexp = regexp.MustCompile(`(?m)^\S.* \(\d+\):$`)
+
+// description returns the branch description for the series
+// built from its cover letter,
+// or an empty string if it doesn't have one.
+//
+// The shortlog, diffstat, and signature
+// added by git format-patch are dropped.
+func (s *patchSeries) description() string {
+	subject := s.coverSubject()
+	if subject == "" {
+		return ""
+	}
+
+	body := s.Cover.Message
+	if idx := strings.Index(body, "\n-- \n"); idx >= 0 {
+		body = body[:idx]
+	}
+	if loc := _shortlogHeaderRegexp.FindStringIndex(body); loc != nil {
+		body = body[:loc[0]]
+	}
+	body = strings.TrimSpace(body)
+	if body == "" || body == _coverLetterBlurb {
+		return subject
+	}
+	return subject + "\n\n" + body
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/gs/internal/git"
+)
+
+func TestGroupPatchEmails(t *testing.T) {
+	cover := func(subject string) patchEmail {
+		return patchEmail{Path: subject, Info: &git.MailInfo{Subject: subject}}
+	}
+	patch := func(subject string) patchEmail {
+		return patchEmail{Path: subject, Info: &git.MailInfo{Subject: subject, Patch: "diff"}}
+	}
+
+	// titles summarizes series as the titles of their patches.
+	titles := func(series []*patchSeries) [][]string {
+		var out [][]string
+		for _, s := range series {
+			var paths []string
+			for _, p := range s.Patches {
+				paths = append(paths, p.Path)
+			}
+			out = append(out, paths)
+		}
+		return out
+	}
+
+	emails := []patchEmail{
+		cover("[PATCH 0/2] Feature one"),
+		patch("[PATCH 1/2] Add a"),
+		patch("[PATCH 2/2] Add b"),
+		patch("[PATCH v2 1/2] Add c"),
+		patch("[PATCH v2 2/2] Add d"),
+		patch("[PATCH] Fix typo"),
+		patch("[PATCH] Fix another typo"),
+		cover("[PATCH 0/1] Empty series"),
+		cover("[PATCH 0/1] Feature two"),
+		patch("[PATCH 1/1] Add e"),
+	}
+
+	t.Run("Series", func(t *testing.T) {
+		series := groupPatchEmails(emails, false)
+		assert.Equal(t, [][]string{
+			{"[PATCH 1/2] Add a", "[PATCH 2/2] Add b"},
+			{"[PATCH v2 1/2] Add c", "[PATCH v2 2/2] Add d"},
+			{"[PATCH] Fix typo"},
+			{"[PATCH] Fix another typo"},
+			{"[PATCH 1/1] Add e"},
+		}, titles(series))
+
+		assert.Equal(t, "Feature one", series[0].title())
+		assert.Equal(t, "Add c", series[1].title())
+		assert.Equal(t, "Feature two", series[4].title())
+	})
+
+	t.Run("PerPatch", func(t *testing.T) {
+		series := groupPatchEmails(emails, true)
+		assert.Len(t, series, 7)
+		for _, s := range series {
+			assert.Len(t, s.Patches, 1)
+			assert.Nil(t, s.Cover)
+		}
+	})
+}
+
+func TestPatchSeriesDescription(t *testing.T) {
+	tests := []struct {
+		name  string
+		cover *git.MailInfo
+		want  string
+	}{
+		{name: "NoCover"},
+		{
+			name: "Placeholders",
+			cover: &git.MailInfo{
+				Subject: "[PATCH 0/1] *** SUBJECT HERE ***",
+				Message: "*** BLURB HERE ***\n\nTest (1):\n  Add a\n",
+			},
+		},
+		{
+			name: "SubjectOnly",
+			cover: &git.MailInfo{
+				Subject: "[PATCH 0/1] Add a feature",
+				Message: "Test (1):\n  Add a\n\n a | 1 +\n\n-- \n2.39.5\n",
+			},
+			want: "Add a feature",
+		},
+		{
+			name: "Body",
+			cover: &git.MailInfo{
+				Subject: "[RFC PATCH v2 0/2] Add a feature",
+				Message: "It does things.\n\nMany things.\n\n" +
+					"Alice (1):\n  Add a\n\nBob (1):\n  Add b\n\n" +
+					" a | 1 +\n b | 1 +\n\n-- \n2.39.5\n",
+			},
+			want: "Add a feature\n\nIt does things.\n\nMany things.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &patchSeries{Cover: tt.cover}
+			assert.Equal(t, tt.want, s.description())
+		})
+	}
+}
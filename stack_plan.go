@@ -0,0 +1,5 @@
+package main
+
+type stackPlanCmd struct {
+	Apply stackPlanApplyCmd `cmd:"" aliases:"a" released:"unreleased" help:"Create the branches listed in a plan file"`
+}
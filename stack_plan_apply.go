@@ -0,0 +1,185 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stackPlanApplyCmd struct {
+	File string `arg:"" help:"Path to the plan file, or '-' to read from stdin"`
+	Base string `placeholder:"BRANCH" predictor:"trackedBranches" help:"Branch to start the stack from. Defaults to the plan's base or the current branch."`
+}
+
+func (*stackPlanApplyCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Reads a list of branches from a plan file
+		and creates them as a stack, in order,
+		with the first branch based on the base branch.
+		The branches are created empty, ready for commits,
+		and the first branch is checked out.
+
+		Plans with a .yaml or .yml extension are read as YAML:
+
+			base: main
+			branches:
+			  - name: refactor/extract-api
+			    description: Extract the widget API
+			  - name: refactor/use-api
+			    description: Switch callers to the new API
+
+		Other plans are read as Markdown lists,
+		with one "name: description" item per branch.
+		Indented lines continue the description of the item above.
+		Text outside of list items is ignored.
+
+			# Widget refactor
+
+			- refactor/extract-api: Extract the widget API
+			- refactor/use-api: Switch callers to the new API
+
+		Branch descriptions are saved as branch notes.
+		Use '%[1]s branch note show' to view them.
+
+		None of the branches may exist already.
+		Use --base to start the stack from a different branch.
+	`, cli.Name()))
+}
+
+func (cmd *stackPlanApplyCmd) Run(
+	ctx context.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	wt *git.Worktree,
+	store *state.Store,
+	svc *spice.Service,
+) (err error) {
+	plan, err := cmd.readPlan()
+	if err != nil {
+		return err
+	}
+
+	base := cmp.Or(cmd.Base, plan.Base)
+	if base == "" {
+		base, err = wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+	}
+
+	var baseHash git.Hash
+	if base == store.Trunk() {
+		baseHash, err = repo.PeelToCommit(ctx, base)
+		if err != nil {
+			return fmt.Errorf("resolve %v: %w", base, err)
+		}
+	} else {
+		baseInfo, err := svc.LookupBranch(ctx, base)
+		if err != nil {
+			if errors.Is(err, git.ErrNotExist) {
+				return fmt.Errorf("branch does not exist: %v", base)
+			}
+			if errors.Is(err, state.ErrNotExist) {
+				return fmt.Errorf("branch not tracked: %v", base)
+			}
+			return fmt.Errorf("lookup branch %v: %w", base, err)
+		}
+		baseHash = baseInfo.Head
+	}
+
+	// Verify that the plan would work before creating any branches.
+	for _, b := range plan.Branches {
+		if repo.BranchExists(ctx, b.Name) {
+			return fmt.Errorf("branch already exists: %v", b.Name)
+		}
+	}
+
+	branchTx := store.BeginBranchTx()
+	prev := base
+	for _, b := range plan.Branches {
+		req := state.UpsertRequest{
+			Name:     b.Name,
+			Base:     prev,
+			BaseHash: baseHash,
+		}
+		if b.Description != "" {
+			req.Note = &b.Description
+		}
+		if err := branchTx.Upsert(ctx, req); err != nil {
+			return fmt.Errorf("add branch %v with base %v: %w", b.Name, prev, err)
+		}
+		prev = b.Name
+	}
+
+	// If any branch fails to be created,
+	// delete the ones we did create so the plan can be retried.
+	var created []string
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		for _, name := range created {
+			if delErr := repo.DeleteBranch(ctx, name, git.BranchDeleteOptions{Force: true}); delErr != nil {
+				log.Warn("Could not delete branch", "branch", name, "error", delErr)
+			}
+		}
+	}()
+
+	for _, b := range plan.Branches {
+		if err := repo.CreateBranch(ctx, git.CreateBranchRequest{
+			Name: b.Name,
+			Head: baseHash.String(),
+		}); err != nil {
+			return fmt.Errorf("create branch %v: %w", b.Name, err)
+		}
+		created = append(created, b.Name)
+	}
+
+	msg := fmt.Sprintf("stack plan apply: create %d branches on %v", len(plan.Branches), base)
+	if err := branchTx.Commit(ctx, msg); err != nil {
+		return fmt.Errorf("update branch state: %w", err)
+	}
+	created = nil // tracked now; keep them
+
+	prev = base
+	for _, b := range plan.Branches {
+		log.Infof("%v: created on %v", b.Name, prev)
+		prev = b.Name
+	}
+
+	first := plan.Branches[0].Name
+	if err := wt.CheckoutBranch(ctx, first); err != nil {
+		return fmt.Errorf("checkout branch %v: %w", first, err)
+	}
+
+	return nil
+}
+
+func (cmd *stackPlanApplyCmd) readPlan() (*spice.StackPlan, error) {
+	var r io.Reader = os.Stdin
+	if cmd.File != "-" {
+		f, err := os.Open(cmd.File)
+		if err != nil {
+			return nil, fmt.Errorf("open plan: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	plan, err := spice.ParseStackPlan(cmd.File, r)
+	if err != nil {
+		return nil, fmt.Errorf("parse plan: %w", err)
+	}
+	return plan, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"go.abhg.dev/gs/internal/handler/pull"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stackPullCmd struct {
+	Change   string `arg:"" help:"Topmost Change Request of the stack, e.g. '#123' or its URL"`
+	Checkout bool   `negatable:"" default:"true" help:"Check out the topmost branch after pulling"`
+}
+
+func (*stackPullCmd) Help() string {
+	return text.Dedent(`
+		Pulls a stack of Change Requests submitted by someone else
+		so that you can work on it.
+		This is useful when handing off a stack to a teammate,
+		or when pairing on one.
+
+		Starting at the given Change Request,
+		the base branch of each CR is followed to the open CR
+		for that branch until the trunk is reached.
+		The head branches of all these CRs are fetched
+		and tracked locally with the same bases,
+		and each branch is associated with its CR
+		so that it may be submitted again.
+
+		Branches that already exist locally
+		are fast-forwarded to match the remote if possible.
+		Branches that have diverged are left as is.
+
+		The topmost branch is checked out afterwards.
+		Use --no-checkout to stay on the current branch.
+	`)
+}
+
+// PullHandler pulls stacks of change requests from a forge.
+type PullHandler interface {
+	PullStack(ctx context.Context, req *pull.StackRequest) error
+}
+
+var _ PullHandler = (*pull.Handler)(nil)
+
+func (cmd *stackPullCmd) Run(ctx context.Context, handler PullHandler) error {
+	return handler.PullStack(ctx, &pull.StackRequest{
+		Change:     cmd.Change,
+		NoCheckout: !cmd.Checkout,
+	})
+}
@@ -66,13 +66,23 @@ func verifyRestackFromTrunk(
 	return nil
 }
 
-func (cmd *stackRestackCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+func (cmd *stackRestackCmd) AfterApply(
+	ctx context.Context,
+	wt *git.Worktree,
+	changes ChangeBranchResolver,
+) error {
 	if cmd.Branch == "" {
 		currentBranch, err := wt.CurrentBranch(ctx)
 		if err != nil {
 			return fmt.Errorf("get current branch: %w", err)
 		}
 		cmd.Branch = currentBranch
+	} else {
+		branch, err := changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
+		cmd.Branch = branch
 	}
 	return nil
 }
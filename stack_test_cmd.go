@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/spice"
+	"go.abhg.dev/gs/internal/spice/state"
+	"go.abhg.dev/gs/internal/text"
+	"go.abhg.dev/gs/internal/ui"
+	"go.abhg.dev/gs/internal/xec"
+)
+
+type stackTestCmd struct {
+	Branch   string   `help:"Branch whose stack to test" placeholder:"NAME" predictor:"trackedBranches"`
+	FailFast bool     `help:"Stop at the first branch that fails"`
+	Command  []string `arg:"" help:"Command to run on each branch. Use -- before the command if it has flags."`
+}
+
+func (*stackTestCmd) Help() string {
+	return text.Dedent(`
+		Runs a command against every branch in the current stack,
+		starting at the bottom of the stack,
+		and reports which branches passed and which failed.
+
+		Branches are checked out in a temporary worktree,
+		so the current worktree is left untouched
+		and may be used while the command runs.
+		The temporary worktree is removed afterwards.
+
+		The command is run directly, not through a shell.
+		Use 'sh -c' to run shell expressions:
+
+			gs stack test -- sh -c 'make && make test'
+
+		Use --branch to test the stack of a different branch.
+		Use --fail-fast to stop at the first failing branch.
+	`)
+}
+
+func (cmd *stackTestCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+	if cmd.Branch == "" {
+		currentBranch, err := wt.CurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("get current branch: %w", err)
+		}
+		cmd.Branch = currentBranch
+	}
+	return nil
+}
+
+// stackTestResult is the result of testing a single branch.
+type stackTestResult struct {
+	Branch string
+	Err    error // nil if the command succeeded
+}
+
+func (cmd *stackTestCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	repo *git.Repository,
+	store *state.Store,
+	svc *spice.Service,
+) (err error) {
+	stack, err := svc.ListStack(ctx, cmd.Branch)
+	if err != nil {
+		return fmt.Errorf("list stack: %w", err)
+	}
+
+	branches := make([]string, 0, len(stack))
+	for _, b := range stack {
+		if b != store.Trunk() {
+			branches = append(branches, b)
+		}
+	}
+	if len(branches) == 0 {
+		return fmt.Errorf("no branches to test in the stack of %v", cmd.Branch)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gs-stack-test-")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Warn("Could not remove temporary directory", "path", tmpDir, "error", err)
+		}
+	}()
+
+	testWt, err := repo.AddWorktree(ctx, &git.AddWorktreeRequest{
+		Path:      tmpDir,
+		Commitish: branches[0],
+	})
+	if err != nil {
+		return fmt.Errorf("create temporary worktree: %w", err)
+	}
+	defer func() {
+		// Clean up even if the command was interrupted.
+		ctx := context.WithoutCancel(ctx)
+		if err := repo.RemoveWorktree(ctx, tmpDir, &git.RemoveWorktreeOptions{Force: true}); err != nil {
+			log.Warn("Could not remove temporary worktree", "path", tmpDir, "error", err)
+		}
+	}()
+
+	cmdStr := strings.Join(cmd.Command, " ")
+	results := make([]stackTestResult, 0, len(branches))
+	for _, branch := range branches {
+		// Discard changes made by the previous run to tracked files.
+		// Untracked files (e.g. build caches) are left alone.
+		if err := testWt.Reset(ctx, "HEAD", git.ResetOptions{
+			Mode:  git.ResetHard,
+			Quiet: true,
+		}); err != nil {
+			return fmt.Errorf("reset temporary worktree: %w", err)
+		}
+		if err := testWt.DetachHead(ctx, branch); err != nil {
+			return fmt.Errorf("checkout %v: %w", branch, err)
+		}
+
+		log.Infof("%v: running: %v", branch, cmdStr)
+		runErr := xec.Command(ctx, log, cmd.Command[0], cmd.Command[1:]...).
+			WithDir(testWt.RootDir()).
+			WithStdout(kctx.Stdout).
+			WithStderr(kctx.Stderr).
+			Run()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		results = append(results, stackTestResult{Branch: branch, Err: runErr})
+		if runErr != nil && cmd.FailFast {
+			break
+		}
+	}
+
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+
+	printStackTestResults(kctx.Stderr, results)
+	if skipped := len(branches) - len(results); skipped > 0 {
+		log.Infof("Skipped %d branch(es) after the first failure", skipped)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d branch(es) failed", failed, len(results))
+	}
+	return nil
+}
+
+var (
+	_stackTestPassStyle = ui.NewStyle().Foreground(ui.Green)
+	_stackTestFailStyle = ui.NewStyle().Foreground(ui.Red)
+)
+
+// printStackTestResults writes a table with the result of each branch.
+func printStackTestResults(w io.Writer, results []stackTestResult) {
+	var width int
+	for _, r := range results {
+		width = max(width, len(r.Branch))
+	}
+
+	for _, r := range results {
+		status := _stackTestPassStyle.Render("pass")
+		if r.Err != nil {
+			status = _stackTestFailStyle.Render("FAIL")
+			var exitErr interface{ ExitCode() int }
+			if errors.As(r.Err, &exitErr) {
+				status += fmt.Sprintf(" (exit code %d)", exitErr.ExitCode())
+			} else {
+				status += fmt.Sprintf(" (%v)", r.Err)
+			}
+		}
+		fmt.Fprintf(w, "%-*s  %s\n", width, r.Branch, status)
+	}
+}
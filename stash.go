@@ -0,0 +1,6 @@
+package main
+
+type stashCmd struct {
+	List stashListCmd `cmd:"" released:"unreleased" help:"List autostashes left behind by git-spice"`
+	Pop  stashPopCmd  `cmd:"" released:"unreleased" help:"Restore an autostash left behind by git-spice"`
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stashListCmd struct{}
+
+func (*stashListCmd) Help() string {
+	return text.Dedent(`
+		Commands that need a clean working tree stash uncommitted changes
+		before they run, and restore them afterwards.
+		If such a command fails or is aborted,
+		or if the changes could not be restored cleanly,
+		the stash is kept in Git's stash list.
+
+		This lists those stashes, most recent first,
+		along with the command that created them.
+		Use 'gs stash pop' to restore one.
+	`)
+}
+
+func (*stashListCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	log *silog.Logger,
+	handler AutostashHandler,
+) error {
+	entries, err := handler.ListAutostashes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		log.Info("No autostashes found")
+		return nil
+	}
+
+	for _, entry := range entries {
+		fmt.Fprintf(kctx.Stdout, "%v: %v\n", entry.Name, entry.Message)
+	}
+	return nil
+}
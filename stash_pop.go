@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+
+	"go.abhg.dev/gs/internal/text"
+)
+
+type stashPopCmd struct {
+	Stash string `arg:"" optional:"" help:"Name of the stash to restore, e.g. 'stash@{1}'. Defaults to the most recent autostash."`
+}
+
+func (*stashPopCmd) Help() string {
+	return text.Dedent(`
+		Applies an autostash left behind by a failed or aborted command
+		to the working tree, and removes it from the stash list.
+		Use 'gs stash list' to see available autostashes.
+
+		If the stash does not apply cleanly,
+		it is kept in the stash list.
+	`)
+}
+
+func (cmd *stashPopCmd) Run(ctx context.Context, handler AutostashHandler) error {
+	return handler.PopAutostash(ctx, cmd.Stash)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/forge"
+	"go.abhg.dev/gs/internal/git"
+	"go.abhg.dev/gs/internal/handler/list"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type statusCmd struct {
+	CRStatus bool `name:"cr-status" short:"S" help:"Request the state of Change Requests from the forge instead of using the last known state"`
+}
+
+func (*statusCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Lists all tracked branches with problems that need attention:
+
+		  - needs restack: the base branch has moved
+		  - needs push: there are commits that haven't been pushed
+		  - diverged: the branch and its remote have different commits
+		  - not submitted: the branch doesn't have a Change Request
+		  - merged or closed: the Change Request is no longer open
+
+		No network requests are made:
+		push status is based on the last fetch from the remote,
+		and Change Request states are the last ones seen by
+		'%[1]s log short --cr-status' or '%[1]s status --cr-status'.
+		Use --cr-status to request the current states from the forge.
+	`, cli.Name()))
+}
+
+func (*statusCmd) AfterApply(kctx *kong.Context) error {
+	return bindListHandler(kctx)
+}
+
+func (cmd *statusCmd) Run(
+	ctx context.Context,
+	kctx *kong.Context,
+	wt *git.Worktree,
+	listHandler ListHandler,
+) error {
+	currentBranch, err := wt.CurrentBranch(ctx)
+	if err != nil {
+		currentBranch = "" // may be detached
+	}
+
+	req := list.BranchesRequest{
+		Branch:  currentBranch,
+		Options: &list.Options{All: true},
+		Include: list.IncludePushStatus,
+	}
+	if cmd.CRStatus {
+		req.Include |= list.IncludeChangeState
+	} else {
+		req.Include |= list.IncludeCachedChangeState
+	}
+
+	res, err := listHandler.ListBranches(ctx, &req)
+	if err != nil {
+		return fmt.Errorf("list branches: %w", err)
+	}
+
+	return writeStatus(kctx.Stderr, res, currentBranch)
+}
+
+// writeStatus writes one line per tracked branch, depth-first from trunk,
+// followed by a summary.
+func writeStatus(w io.Writer, res *list.BranchesResponse, currentBranch string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	var needAttention, total int
+	var visit func(idx, depth int)
+	visit = func(idx, depth int) {
+		b := res.Branches[idx]
+		if idx != res.TrunkIdx {
+			total++
+
+			marker := " "
+			if b.Name == currentBranch {
+				marker = "▶"
+			}
+
+			var change string
+			if b.ChangeID != nil {
+				change = b.ChangeDisplayID
+				if b.ChangeState != 0 {
+					change += " (" + b.ChangeState.String() + ")"
+				}
+			}
+
+			problems := branchProblems(b)
+			summary := "ok"
+			if len(problems) > 0 {
+				needAttention++
+				summary = strings.Join(problems, ", ")
+			}
+
+			_, _ = fmt.Fprintf(tw, "%s %s%s\t%s\t%s\n",
+				marker, strings.Repeat("  ", depth-1), b.Name, change, summary)
+		}
+
+		for _, above := range b.Aboves {
+			visit(above, depth+1)
+		}
+	}
+	visit(res.TrunkIdx, 0)
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case total == 0:
+		_, err = fmt.Fprintln(w, "No tracked branches")
+	case needAttention == 0:
+		_, err = fmt.Fprintln(w, "All branches are up to date")
+	case needAttention == 1:
+		_, err = fmt.Fprintln(w, "1 branch needs attention")
+	default:
+		_, err = fmt.Fprintf(w, "%d branches need attention\n", needAttention)
+	}
+	return err
+}
+
+// branchProblems lists problems with a branch that need attention.
+func branchProblems(b *list.BranchItem) []string {
+	var problems []string
+	if b.NeedsRestack {
+		problems = append(problems, "needs restack")
+	}
+
+	if s := b.PushStatus; s != nil {
+		switch {
+		case s.Ahead > 0 && s.Behind > 0:
+			problems = append(problems, fmt.Sprintf("diverged (%d ahead, %d behind)", s.Ahead, s.Behind))
+		case s.Ahead > 0:
+			problems = append(problems, fmt.Sprintf("needs push (%d ahead)", s.Ahead))
+		case s.Behind > 0:
+			problems = append(problems, fmt.Sprintf("behind remote (%d behind)", s.Behind))
+		}
+	}
+
+	switch {
+	case b.ChangeID == nil:
+		problems = append(problems, "not submitted")
+	case b.ChangeState == forge.ChangeMerged:
+		problems = append(problems, "merged")
+	case b.ChangeState == forge.ChangeClosed:
+		problems = append(problems, "closed")
+	}
+
+	return problems
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// themeOptions configure the colors and symbols used in the UI.
+// These can only be set with configuration.
+type themeOptions struct {
+	Theme      string   `name:"ui-theme" hidden:"" released:"unreleased" config:"ui.theme" default:"default" enum:"default,high-contrast" help:"Color theme for the UI. One of 'default' and 'high-contrast'."`
+	ASCII      bool     `name:"ui-ascii" hidden:"" released:"unreleased" config:"ui.ascii" help:"Use only ASCII characters for cursors, markers, and trees."`
+	Background string   `name:"ui-background" hidden:"" released:"unreleased" config:"ui.background" default:"auto" enum:"auto,light,dark" help:"Background color of the terminal. One of 'auto', 'light', and 'dark'."`
+	Colors     []string `name:"ui-color" hidden:"" released:"unreleased" config:"ui.color" sep:";" help:"Override a color in the theme with NAME=COLOR. May be repeated."`
+}
+
+// apply configures the UI with these options.
+// It must be called before any UI components are built.
+func (opts *themeOptions) apply(log *silog.Logger) error {
+	theme, ok := ui.LookupTheme(opts.Theme)
+	if !ok {
+		return fmt.Errorf("unknown theme: %q", opts.Theme)
+	}
+	theme.ASCII = opts.ASCII
+	for _, c := range opts.Colors {
+		if err := theme.ParseColor(c); err != nil {
+			return fmt.Errorf("spice.ui.color: %q: %w", c, err)
+		}
+	}
+
+	// Adaptive colors are picked based on the background,
+	// which isn't always detected correctly.
+	switch opts.Background {
+	case "light":
+		ui.Renderer.SetHasDarkBackground(false)
+	case "dark":
+		ui.Renderer.SetHasDarkBackground(true)
+	}
+
+	ui.SetTheme(theme)
+	log.SetLevelColors(map[silog.Level]lipgloss.TerminalColor{
+		silog.LevelTrace: ui.Gray,
+		silog.LevelDebug: ui.Gray,
+		silog.LevelInfo:  ui.Cyan,
+		silog.LevelWarn:  ui.Yellow,
+		silog.LevelError: ui.Red,
+		silog.LevelFatal: ui.Red,
+	})
+	return nil
+}
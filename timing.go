@@ -0,0 +1,27 @@
+package main
+
+import (
+	"time"
+
+	"go.abhg.dev/gs/internal/timing"
+	"go.abhg.dev/gs/internal/ui"
+)
+
+// timedView is an InteractiveView that records
+// time spent waiting for the user to answer prompts.
+type timedView struct {
+	ui.InteractiveView
+
+	rec *timing.Recorder
+}
+
+var _ ui.InteractiveView = (*timedView)(nil)
+
+func (v *timedView) Prompt(fields ...ui.Field) error {
+	start := time.Now()
+	defer func() {
+		v.rec.Record(timing.Prompt, time.Since(start))
+	}()
+
+	return v.InteractiveView.Prompt(fields...)
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/alecthomas/kong"
 	"go.abhg.dev/gs/internal/git"
 	"go.abhg.dev/gs/internal/handler/checkout"
 	"go.abhg.dev/gs/internal/must"
@@ -16,6 +18,8 @@ import (
 
 type topCmd struct {
 	checkout.Options
+
+	All bool `released:"unreleased" help:"Print all top-most branches without checking any of them out"`
 }
 
 func (*topCmd) Help() string {
@@ -24,11 +28,23 @@ func (*topCmd) Help() string {
 		If there are multiple possible top-most branches,
 		a prompt will ask you to pick one.
 		Use the -n flag to print the branch without checking it out.
+
+		Use --all to print all top-most branches, one per line.
+		Branches are listed breadth-first,
+		with branches that share a base listed in order of their names.
 	`)
 }
 
+func (cmd *topCmd) AfterApply() error {
+	if cmd.All && cmd.Detach {
+		return errors.New("cannot use --all with --detach")
+	}
+	return nil
+}
+
 func (cmd *topCmd) Run(
 	ctx context.Context,
+	kctx *kong.Context,
 	log *silog.Logger,
 	view ui.View,
 	wt *git.Worktree,
@@ -47,11 +63,24 @@ func (cmd *topCmd) Run(
 	}
 	must.NotBeEmptyf(tops, "FindTopmost always returns at least one branch")
 
+	if cmd.All {
+		for _, top := range tops {
+			if _, err := fmt.Fprintln(kctx.Stdout, top); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	branch := tops[0]
 	if len(tops) > 1 {
 		desc := "There are multiple top-level branches reachable from the current branch."
 		if !ui.Interactive(view) {
 			log.Error(desc)
+			for _, top := range tops {
+				log.Error("  - " + top)
+			}
+			log.Error("Use --all to list them, or check one out by name.")
 			return errNoPrompt
 		}
 
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/selfupdate"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+const (
+	// _updateCheckInterval is how often git-spice checks
+	// for new releases on its own.
+	_updateCheckInterval = 7 * 24 * time.Hour
+
+	// _updateCheckTimeout bounds how long the check may delay
+	// the command that triggered it.
+	_updateCheckTimeout = 2 * time.Second
+)
+
+// Overridden in tests.
+var (
+	_updateCheckStatePath = defaultUpdateCheckStatePath
+	_updateCheckNow       = time.Now
+)
+
+func defaultUpdateCheckStatePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "git-spice", "update-check.json"), nil
+}
+
+// shouldCheckForUpdate reports whether the passive update check
+// may run after the given command.
+//
+// It's skipped for development builds, in CI,
+// when stderr is not a terminal,
+// and for commands whose output is consumed by other programs.
+func shouldCheckForUpdate(command string) bool {
+	if !selfupdate.IsRelease(_version) || os.Getenv("CI") != "" {
+		return false
+	}
+	if !isatty.IsTerminal(os.Stderr.Fd()) {
+		return false
+	}
+
+	name, _, _ := strings.Cut(command, " ")
+	switch name {
+	case "version", "upgrade", "rpc", "prompt", "shell", "internal", "dumpmd":
+		return false
+	}
+	return true
+}
+
+// checkForUpdate checks for a new release at most once a week,
+// and logs a notice if one is available.
+//
+// Failures are logged at debug level only:
+// the check must never get in the way of the command that triggered it.
+func checkForUpdate(ctx context.Context, log *silog.Logger) {
+	path, err := _updateCheckStatePath()
+	if err != nil {
+		log.Debug("Skipping update check", "error", err)
+		return
+	}
+
+	state, err := selfupdate.LoadCheckState(path)
+	if err != nil {
+		// Corrupt state will be overwritten below.
+		log.Debug("Could not load update check state", "error", err)
+		state = new(selfupdate.CheckState)
+	}
+
+	now := _updateCheckNow()
+	if now.Sub(state.CheckedAt) < _updateCheckInterval {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, _updateCheckTimeout)
+	defer cancel()
+
+	state.CheckedAt = now
+	rel, err := newSelfUpdateClient(log).LatestRelease(ctx)
+	if err != nil {
+		log.Debug("Could not check for updates", "error", err)
+	} else {
+		state.Latest = rel.Version
+	}
+	if err := state.Save(path); err != nil {
+		log.Debug("Could not save update check state", "error", err)
+	}
+
+	if rel == nil || !selfupdate.IsNewer(_version, rel.Version) {
+		return
+	}
+
+	log.Infof("A new version of git-spice is available: %v → %v", _version, rel.Version)
+	log.Infof("Run '%v upgrade' to install it. Set spice.updateCheck to false to stop these checks.", cli.Name())
+}
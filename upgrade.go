@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/selfupdate"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/text"
+)
+
+type upgradeCmd struct {
+	Force bool `help:"Install the latest release even if it isn't newer, or if this is a development build."`
+}
+
+func (*upgradeCmd) Help() string {
+	return text.Dedent(fmt.Sprintf(`
+		Replaces the running git-spice binary with the latest release.
+
+		The release archive is verified against the checksum
+		published with the release before it's installed.
+
+		If git-spice was installed with a package manager like Homebrew,
+		use that to upgrade instead.
+
+		git-spice checks for new releases once a week on its own.
+		Set spice.updateCheck to false to turn that off.
+		Use '%v version --check-update' to check without installing.
+	`, cli.Name()))
+}
+
+// _executablePath reports the path to the running binary.
+// Overridden in tests.
+var _executablePath = os.Executable
+
+func (cmd *upgradeCmd) Run(ctx context.Context, log *silog.Logger) error {
+	if !selfupdate.IsRelease(_version) && !cmd.Force {
+		log.Errorf("This is a development build of git-spice (%v).", _version)
+		log.Error("Use --force to replace it with the latest release.")
+		return errors.New("not a release build")
+	}
+
+	exe, err := _executablePath()
+	if err != nil {
+		return fmt.Errorf("find executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if pm := selfupdate.ManagedBy(exe); pm != "" {
+		log.Errorf("git-spice was installed with %v at %v.", pm, exe)
+		log.Errorf("Use %v to upgrade it.", pm)
+		return errors.New("installed by a package manager")
+	}
+
+	client := newSelfUpdateClient(log)
+	rel, err := client.LatestRelease(ctx)
+	if err != nil {
+		return fmt.Errorf("check for updates: %w", err)
+	}
+
+	if !selfupdate.IsNewer(_version, rel.Version) && !cmd.Force {
+		log.Infof("git-spice %v is up to date.", _version)
+		return nil
+	}
+
+	log.Infof("Downloading git-spice %v", rel.Version)
+	bin, err := client.Download(ctx, rel, selfupdate.CurrentPlatform())
+	if err != nil {
+		return fmt.Errorf("download %v: %w", rel.Version, err)
+	}
+
+	if err := selfupdate.Replace(exe, bin); err != nil {
+		return fmt.Errorf("install %v: %w", rel.Version, err)
+	}
+
+	log.Infof("Upgraded git-spice from %v to %v", _version, rel.Version)
+	return nil
+}
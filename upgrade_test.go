@@ -0,0 +1,191 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/selfupdate"
+	"go.abhg.dev/gs/internal/silog"
+	"go.abhg.dev/gs/internal/silog/silogtest"
+	"go.abhg.dev/testing/stub"
+)
+
+// testReleaseServer serves a single release of git-spice
+// with a binary for the current platform.
+type testReleaseServer struct {
+	*httptest.Server
+
+	// Requests counts requests for the latest release.
+	Requests atomic.Int32
+}
+
+func newTestReleaseServer(t *testing.T, version string, binary []byte) *testReleaseServer {
+	t.Helper()
+
+	platform := selfupdate.CurrentPlatform()
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     platform.BinaryName(),
+		Mode:     0o755,
+		Size:     int64(len(binary)),
+		Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write(binary)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	srv := new(testReleaseServer)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/abhinav/git-spice/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		srv.Requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"tag_name": version,
+			"html_url": "https://example.com/releases/" + version,
+			"assets": []map[string]string{
+				{"name": "checksums.txt", "browser_download_url": srv.URL + "/checksums.txt"},
+				{"name": platform.ArchiveName(), "browser_download_url": srv.URL + "/archive.tar.gz"},
+			},
+		})
+	})
+	mux.HandleFunc("GET /checksums.txt", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, "%x  %v\n", sha256.Sum256(archive.Bytes()), platform.ArchiveName())
+	})
+	mux.HandleFunc("GET /archive.tar.gz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive.Bytes())
+	})
+
+	srv.Server = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestUpgradeCmd(t *testing.T) {
+	setup := func(t *testing.T, current, latest string) (exe string) {
+		srv := newTestReleaseServer(t, latest, []byte("new binary"))
+		t.Cleanup(stub.Value(&_selfUpdateAPIURL, srv.URL))
+		t.Cleanup(stub.Value(&_version, current))
+
+		exe = filepath.Join(t.TempDir(), "git-spice")
+		require.NoError(t, os.WriteFile(exe, []byte("old binary"), 0o755))
+		t.Cleanup(stub.Value(&_executablePath, func() (string, error) { return exe, nil }))
+		return exe
+	}
+
+	readFile := func(t *testing.T, path string) string {
+		bs, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(bs)
+	}
+
+	t.Run("Upgrade", func(t *testing.T) {
+		exe := setup(t, "0.19.0", "v0.20.0")
+
+		require.NoError(t, new(upgradeCmd).Run(t.Context(), silogtest.New(t)))
+		assert.Equal(t, "new binary", readFile(t, exe))
+	})
+
+	t.Run("UpToDate", func(t *testing.T) {
+		exe := setup(t, "0.20.0", "v0.20.0")
+
+		require.NoError(t, new(upgradeCmd).Run(t.Context(), silogtest.New(t)))
+		assert.Equal(t, "old binary", readFile(t, exe))
+	})
+
+	t.Run("UpToDate/Force", func(t *testing.T) {
+		exe := setup(t, "0.20.0", "v0.20.0")
+
+		require.NoError(t, (&upgradeCmd{Force: true}).Run(t.Context(), silogtest.New(t)))
+		assert.Equal(t, "new binary", readFile(t, exe))
+	})
+
+	t.Run("DevelopmentBuild", func(t *testing.T) {
+		exe := setup(t, "dev", "v0.20.0")
+
+		err := new(upgradeCmd).Run(t.Context(), silogtest.New(t))
+		assert.ErrorContains(t, err, "not a release build")
+		assert.Equal(t, "old binary", readFile(t, exe))
+	})
+
+	t.Run("PackageManager", func(t *testing.T) {
+		setup(t, "0.19.0", "v0.20.0")
+		defer stub.Value(&_executablePath, func() (string, error) {
+			return "/opt/homebrew/Caskroom/git-spice/0.19.0/git-spice", nil
+		})()
+
+		err := new(upgradeCmd).Run(t.Context(), silogtest.New(t))
+		assert.ErrorContains(t, err, "installed by a package manager")
+	})
+}
+
+func TestCheckForUpdate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	defer stub.Value(&_updateCheckNow, func() time.Time { return now })()
+	defer stub.Value(&_version, "0.19.0")()
+
+	statePath := filepath.Join(t.TempDir(), "update-check.json")
+	defer stub.Value(&_updateCheckStatePath, func() (string, error) { return statePath, nil })()
+
+	srv := newTestReleaseServer(t, "v0.20.0", nil)
+	defer stub.Value(&_selfUpdateAPIURL, srv.URL)()
+
+	var logBuffer bytes.Buffer
+	log := silog.New(&logBuffer, nil)
+
+	checkForUpdate(t.Context(), log)
+	assert.Contains(t, logBuffer.String(), "A new version of git-spice is available: 0.19.0 → v0.20.0")
+	assert.Equal(t, int32(1), srv.Requests.Load())
+
+	state, err := selfupdate.LoadCheckState(statePath)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(state.CheckedAt))
+	assert.Equal(t, "v0.20.0", state.Latest)
+
+	t.Run("WithinInterval", func(t *testing.T) {
+		logBuffer.Reset()
+		defer stub.Value(&_updateCheckNow, func() time.Time { return now.Add(6 * 24 * time.Hour) })()
+
+		checkForUpdate(t.Context(), log)
+		assert.Empty(t, logBuffer.String())
+		assert.Equal(t, int32(1), srv.Requests.Load(), "must not check again")
+	})
+
+	t.Run("AfterInterval", func(t *testing.T) {
+		logBuffer.Reset()
+		defer stub.Value(&_updateCheckNow, func() time.Time { return now.Add(8 * 24 * time.Hour) })()
+
+		checkForUpdate(t.Context(), log)
+		assert.Contains(t, logBuffer.String(), "A new version of git-spice is available")
+		assert.Equal(t, int32(2), srv.Requests.Load())
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		logBuffer.Reset()
+		later := now.Add(30 * 24 * time.Hour)
+		defer stub.Value(&_updateCheckNow, func() time.Time { return later })()
+		defer stub.Value(&_selfUpdateAPIURL, "http://127.0.0.1:1")()
+
+		checkForUpdate(t.Context(), log)
+		assert.Empty(t, logBuffer.String(), "failures must be quiet")
+
+		state, err := selfupdate.LoadCheckState(statePath)
+		require.NoError(t, err)
+		assert.True(t, later.Equal(state.CheckedAt), "failed checks must be recorded")
+	})
+}
@@ -39,13 +39,23 @@ type RestackHandler interface {
 	RestackBranch(ctx context.Context, branch string) error
 }
 
-func (cmd *upstackRestackCmd) AfterApply(ctx context.Context, wt *git.Worktree) error {
+func (cmd *upstackRestackCmd) AfterApply(
+	ctx context.Context,
+	wt *git.Worktree,
+	changes ChangeBranchResolver,
+) error {
 	if cmd.Branch == "" {
 		currentBranch, err := wt.CurrentBranch(ctx)
 		if err != nil {
 			return fmt.Errorf("get current branch: %w", err)
 		}
 		cmd.Branch = currentBranch
+	} else {
+		branch, err := changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
+		cmd.Branch = branch
 	}
 	return nil
 }
@@ -37,6 +37,7 @@ func (cmd *upstackSubmitCmd) Run(
 	store *state.Store,
 	svc *spice.Service,
 	submitHandler SubmitHandler,
+	changes ChangeBranchResolver,
 ) error {
 	if cmd.Branch == "" {
 		currentBranch, err := wt.CurrentBranch(ctx)
@@ -44,6 +45,12 @@ func (cmd *upstackSubmitCmd) Run(
 			return fmt.Errorf("get current branch: %w", err)
 		}
 		cmd.Branch = currentBranch
+	} else {
+		branch, err := changes.ResolveBranch(ctx, cmd.Branch)
+		if err != nil {
+			return err
+		}
+		cmd.Branch = branch
 	}
 
 	if cmd.Branch != store.Trunk() {
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/silog"
+)
+
+// verboseFlag is the level of verbosity requested with -v.
+// Each -v increases it by one.
+type verboseFlag int
+
+// Decode decodes CLI flags for verboseFlag.
+// The following forms are supported:
+//
+//	-v, --verbose      // increase verbosity by one
+//	-vv                // increase verbosity by two
+//	--verbose=[true|false|N]
+//
+// The last form is also used for $GIT_SPICE_VERBOSE.
+func (v *verboseFlag) Decode(ctx *kong.DecodeContext) error {
+	if ctx.Scan.Peek().Type != kong.FlagValueToken {
+		*v++
+		return nil
+	}
+
+	token, err := ctx.Scan.PopValue("verbose")
+	if err != nil {
+		return err
+	}
+
+	value := token.String()
+	if b, err := strconv.ParseBool(value); err == nil {
+		*v = 0
+		if b {
+			*v = 1
+		}
+		return nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return errors.New("must be true, false, or a non-negative number")
+	}
+	*v = verboseFlag(n)
+	return nil
+}
+
+// IsBool returns true to indicate that verboseFlag is a boolean flag.
+// This is needed for Kong to render its help correctly.
+func (v verboseFlag) IsBool() bool { return true }
+
+// Level returns the log level for this verbosity.
+//
+// GS_TRACE=1 is equivalent to -vv.
+func (v verboseFlag) Level() silog.Level {
+	if trace, err := strconv.ParseBool(os.Getenv("GS_TRACE")); err == nil && trace {
+		v = max(v, 2)
+	}
+
+	switch {
+	case v >= 2:
+		return silog.LevelTrace
+	case v == 1:
+		return silog.LevelDebug
+	default:
+		return silog.LevelInfo
+	}
+}
@@ -1,49 +1,88 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"runtime/debug"
 	"strings"
 
 	"github.com/alecthomas/kong"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/httplog"
+	"go.abhg.dev/gs/internal/selfupdate"
+	"go.abhg.dev/gs/internal/silog"
 )
 
 type versionFlag bool
 
 func (v versionFlag) BeforeReset(app *kong.Kong) error {
-	if err := new(versionCmd).Run(app); err != nil {
-		return err
-	}
-
+	printVersion(app.Stdout)
 	app.Exit(0)
 	return nil
 }
 
 type versionCmd struct {
-	Short bool `help:"Print only the version number."`
+	Short       bool `help:"Print only the version number."`
+	CheckUpdate bool `name:"check-update" released:"unreleased" help:"Check whether a newer version of git-spice is available."`
 }
 
-func (cmd *versionCmd) Run(app *kong.Kong) error {
+func (cmd *versionCmd) Run(ctx context.Context, app *kong.Kong, log *silog.Logger) error {
 	if cmd.Short {
 		fmt.Fprintln(app.Stdout, _version)
+	} else {
+		printVersion(app.Stdout)
+	}
+
+	if !cmd.CheckUpdate {
 		return nil
 	}
 
-	fmt.Fprint(app.Stdout, "git-spice ", _version)
-	if report := _generateBuildReport(); report != "" {
-		fmt.Fprintf(app.Stdout, " (%s)", report)
+	rel, err := newSelfUpdateClient(log).LatestRelease(ctx)
+	if err != nil {
+		return fmt.Errorf("check for updates: %w", err)
 	}
 
-	fmt.Fprintln(app.Stdout)
-	fmt.Fprintln(app.Stdout, "Copyright (C) Abhinav Gupta")
-	fmt.Fprintln(app.Stdout, "  <https://github.com/abhinav/git-spice>")
-	fmt.Fprintln(app.Stdout, "This program comes with ABSOLUTELY NO WARRANTY")
-	fmt.Fprintln(app.Stdout, "This is free software, and you are welcome to redistribute it")
-	fmt.Fprintln(app.Stdout, "under certain conditions; see source for details.")
+	if !selfupdate.IsNewer(_version, rel.Version) {
+		fmt.Fprintf(app.Stdout, "git-spice is up to date (latest release is %v)\n", rel.Version)
+		return nil
+	}
 
+	fmt.Fprintf(app.Stdout, "A new version of git-spice is available: %v\n", rel.Version)
+	if rel.URL != "" {
+		fmt.Fprintf(app.Stdout, "  %v\n", rel.URL)
+	}
+	fmt.Fprintf(app.Stdout, "Run '%v upgrade' to install it.\n", cli.Name())
 	return nil
 }
 
+func printVersion(w io.Writer) {
+	fmt.Fprint(w, "git-spice ", _version)
+	if report := _generateBuildReport(); report != "" {
+		fmt.Fprintf(w, " (%s)", report)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Copyright (C) Abhinav Gupta")
+	fmt.Fprintln(w, "  <https://github.com/abhinav/git-spice>")
+	fmt.Fprintln(w, "This program comes with ABSOLUTELY NO WARRANTY")
+	fmt.Fprintln(w, "This is free software, and you are welcome to redistribute it")
+	fmt.Fprintln(w, "under certain conditions; see source for details.")
+}
+
+// _selfUpdateAPIURL is the GitHub API URL to look up releases from.
+// Overridden in tests.
+var _selfUpdateAPIURL = selfupdate.DefaultAPIURL
+
+func newSelfUpdateClient(log *silog.Logger) *selfupdate.Client {
+	return &selfupdate.Client{
+		APIURL:     _selfUpdateAPIURL,
+		HTTPClient: &http.Client{Transport: httplog.WrapTransport(nil, log)},
+		Log:        log,
+	}
+}
+
 var _debugReadBuildInfo = debug.ReadBuildInfo
 
 var _generateBuildReport = func() string {
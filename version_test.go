@@ -8,6 +8,8 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.abhg.dev/gs/internal/cli"
+	"go.abhg.dev/gs/internal/silog/silogtest"
 	"go.abhg.dev/testing/stub"
 )
 
@@ -35,9 +37,9 @@ func TestVersionCmd(t *testing.T) {
 
 	t.Run("Default", func(t *testing.T) {
 		var stdout bytes.Buffer
-		err := new(versionCmd).Run(&kong.Kong{
+		err := new(versionCmd).Run(t.Context(), &kong.Kong{
 			Stdout: &stdout,
-		})
+		}, silogtest.New(t))
 		require.NoError(t, err)
 
 		assert.Contains(t, stdout.String(), "git-spice v1.2.3")
@@ -45,13 +47,43 @@ func TestVersionCmd(t *testing.T) {
 
 	t.Run("Short", func(t *testing.T) {
 		var stdout bytes.Buffer
-		err := (&versionCmd{Short: true}).Run(&kong.Kong{
+		err := (&versionCmd{Short: true}).Run(t.Context(), &kong.Kong{
 			Stdout: &stdout,
-		})
+		}, silogtest.New(t))
 		require.NoError(t, err)
 
 		assert.Equal(t, "v1.2.3\n", stdout.String())
 	})
+
+	t.Run("CheckUpdate", func(t *testing.T) {
+		srv := newTestReleaseServer(t, "v1.3.0", nil)
+		defer stub.Value(&_selfUpdateAPIURL, srv.URL)()
+
+		var stdout bytes.Buffer
+		err := (&versionCmd{Short: true, CheckUpdate: true}).Run(t.Context(), &kong.Kong{
+			Stdout: &stdout,
+		}, silogtest.New(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, "v1.2.3\n"+
+			"A new version of git-spice is available: v1.3.0\n"+
+			"  https://example.com/releases/v1.3.0\n"+
+			"Run '"+cli.Name()+" upgrade' to install it.\n", stdout.String())
+	})
+
+	t.Run("CheckUpdate/UpToDate", func(t *testing.T) {
+		srv := newTestReleaseServer(t, "v1.2.3", nil)
+		defer stub.Value(&_selfUpdateAPIURL, srv.URL)()
+
+		var stdout bytes.Buffer
+		err := (&versionCmd{Short: true, CheckUpdate: true}).Run(t.Context(), &kong.Kong{
+			Stdout: &stdout,
+		}, silogtest.New(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, "v1.2.3\n"+
+			"git-spice is up to date (latest release is v1.2.3)\n", stdout.String())
+	})
 }
 
 func TestGenerateBuildReport(t *testing.T) {